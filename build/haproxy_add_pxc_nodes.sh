@@ -37,6 +37,10 @@ function main() {
 
 		node_name=$(echo "$pxc_host" | cut -d . -f -1)
 		node_id=$(echo $node_name | awk -F'-' '{print $NF}')
+		if [ -n "${REPORTING_NODE}" ] && [ "$node_name" == "${REPORTING_NODE}" ]; then
+			log "Skipping reporting node $node_name"
+			continue
+		fi
 		NODE_LIST_REPL+=("server $node_name $pxc_host:3306 $send_proxy $SERVER_OPTIONS")
 		if [ "x$node_id" == 'x0' ]; then
 			firs_node_replica="$pxc_host"
@@ -83,6 +83,11 @@ function main() {
 		--cluster-hostname="$first_host" \
 		--update-mysql-version
 
+	if [ -n "${REPORTING_NODE}" ]; then
+		echo "Removing reporting node ${REPORTING_NODE} from ProxySQL servers"
+		proxysql_admin_exec "127.0.0.1" "DELETE FROM mysql_servers WHERE hostname LIKE '${REPORTING_NODE}.%'; LOAD MYSQL SERVERS TO RUNTIME; SAVE MYSQL SERVERS TO DISK;"
+	fi
+
 	echo "All done!"
 }
 
@@ -39,6 +39,16 @@ function mysql_root_exec() {
 	set -x
 }
 
+# set_provider_option replaces the option in wsrep_provider_options or adds it
+function set_provider_option() {
+	local name="${1%%=*}"
+	sed -i -r \
+		-e "/^wsrep_provider_options=/ s/${name//./\\.}=[^;\"]*;?//" \
+		-e "s|^wsrep_provider_options=\"?|wsrep_provider_options=\"${1};|" \
+		-e '/^wsrep_provider_options=/ s/;?"?$/"/' \
+		${CFG}
+}
+
 NODE_IP=$(hostname -I | awk ' { print $1 } ')
 CLUSTER_NAME="$(hostname -f | cut -d'.' -f2)"
 SERVER_NUM=${HOSTNAME/$CLUSTER_NAME-/}
@@ -61,7 +71,8 @@ while read -ra LINE; do
 done
 
 if [ "${#PEERS[@]}" != 0 ]; then
-	DONOR_ADDRESS="$(printf '%s\n' "${PEERS[@]}" "${HOSTNAME}" | sort --version-sort | uniq | grep -v -- '-0$' | sed '$d' | tr '\n' ',' | sed 's/^,$//')"
+	# the reporting node isn't preferred as the donor
+	DONOR_ADDRESS="$(printf '%s\n' "${PEERS[@]}" "${HOSTNAME}" | sort --version-sort | uniq | grep -v -- '-0$' | sed '$d' | grep -vx -- "${REPORTING_NODE:-}" | tr '\n' ',' | sed 's/^,$//')"
 fi
 if [ "${#PEERS_FULL[@]}" != 0 ]; then
 	WSREP_CLUSTER_ADDRESS="$(printf '%s\n' "${PEERS_FULL[@]}" | sort --version-sort | tr '\n' ',' | sed 's/,$//')"
@@ -83,6 +94,25 @@ fi
 grep -E -q "^[#]?wsrep_sst_donor" "$CFG" || sed '/^\[mysqld\]/a wsrep_sst_donor=\n' ${CFG} 1<>${CFG}
 grep -E -q "^[#]?wsrep_node_incoming_address" "$CFG" || sed '/^\[mysqld\]/a wsrep_node_incoming_address=\n' ${CFG} 1<>${CFG}
 grep -E -q "^[#]?wsrep_provider_options" "$CFG" || sed '/^\[mysqld\]/a wsrep_provider_options="pc.weight=10"\n' ${CFG} 1<>${CFG}
+if [ -n "${PROVIDER_OPTIONS_DIR}" ] && [ -s "${PROVIDER_OPTIONS_DIR}/options" ]; then
+	IFS=';' read -ra PROVIDER_OPTIONS <"${PROVIDER_OPTIONS_DIR}/options"
+	for OPTION in "${PROVIDER_OPTIONS[@]}"; do
+		set_provider_option "${OPTION}"
+	done
+fi
+if [ -n "${GALERA_SEGMENTS_DIR}" ]; then
+	# the operator writes the segment of the pod once it's scheduled, the config map volume catches up later
+	for i in {1..120}; do
+		[ -f "${GALERA_SEGMENTS_DIR}/${HOSTNAME}" ] && break
+		sleep 1
+	done
+	SEGMENT=$(cat "${GALERA_SEGMENTS_DIR}/${HOSTNAME}" 2>/dev/null || :)
+	if [[ "${SEGMENT}" =~ ^[0-9]+$ ]]; then
+		set_provider_option "gmcast.segment=${SEGMENT}"
+	else
+		echo "Galera segment of ${HOSTNAME} isn't assigned, the default segment is used"
+	fi
+fi
 sed -r "s|^[#]?server_id=.*$|server_id=${SERVER_ID}|" ${CFG} 1<>${CFG}
 sed -r "s|^[#]?coredumper$|coredumper|" ${CFG} 1<>${CFG}
 sed -r "s|^[#]?wsrep_node_address=.*$|wsrep_node_address=${NODE_IP}|" ${CFG} 1<>${CFG}
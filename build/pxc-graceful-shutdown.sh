@@ -0,0 +1,58 @@
+#!/bin/bash
+
+# preStop hook of the PXC pods with the graceful shutdown enabled.
+# It puts the node in maintenance mode, so the proxies remove it from their pools,
+# waits for the received write-sets to be applied and optionally flushes the tables.
+# Each step is bounded by its timeout, mysqld is stopped after the hook in any case.
+
+exec >>/var/lib/mysql/graceful-shutdown.log 2>&1
+
+{ set +x; } 2>/dev/null
+mysql_pass=$(cat /etc/mysql/mysql-users-secret/operator || :)
+MYSQL_PASSWORD="${mysql_pass:-$OPERATOR_ADMIN_PASSWORD}"
+NODE_IP=$(hostname -I | awk ' { print $1 } ')
+
+run_sql() {
+	local timeout=$1
+	local query=$2
+	MYSQL_PWD="${MYSQL_PASSWORD}" timeout "${timeout}" mysql -P 33062 -h"${NODE_IP}" --protocol=TCP --user=operator -nNs -e "${query}"
+}
+
+log() {
+	echo "$(date -u +%Y-%m-%dT%H:%M:%SZ) $*"
+}
+
+DRAIN_SECONDS=${GRACEFUL_SHUTDOWN_DRAIN_SECONDS:-10}
+RECV_QUEUE_TIMEOUT=${GRACEFUL_SHUTDOWN_RECV_QUEUE_TIMEOUT:-60}
+FLUSH_TABLES_TIMEOUT=${GRACEFUL_SHUTDOWN_FLUSH_TABLES_TIMEOUT:-30}
+
+if ! run_sql 10 "SET GLOBAL pxc_maint_mode='MAINTENANCE'"; then
+	log "Node can't be put in maintenance mode, it's stopped without draining"
+	exit 0
+fi
+log "Node is in maintenance mode, draining for ${DRAIN_SECONDS}s"
+sleep "${DRAIN_SECONDS}"
+
+deadline=$((SECONDS + RECV_QUEUE_TIMEOUT))
+while true; do
+	queue=$(run_sql 10 "SHOW GLOBAL STATUS LIKE 'wsrep_local_recv_queue'" | awk '{ print $2 }')
+	if [[ ${queue} == '0' ]]; then
+		log "Receive queue is empty"
+		break
+	fi
+	if [[ ${SECONDS} -ge ${deadline} ]]; then
+		log "Receive queue isn't empty after ${RECV_QUEUE_TIMEOUT}s: ${queue:-unknown}"
+		break
+	fi
+	sleep 1
+done
+
+if [[ ${GRACEFUL_SHUTDOWN_FLUSH_TABLES} == 'yes' ]]; then
+	if run_sql "${FLUSH_TABLES_TIMEOUT}" "FLUSH TABLES"; then
+		log "Tables are flushed"
+	else
+		log "Tables aren't flushed in ${FLUSH_TABLES_TIMEOUT}s"
+	fi
+fi
+
+log "Node is drained"
@@ -13,3 +13,5 @@ install -o "$(id -u)" -g "$(id -g)" -m 0755 -D /get-pxc-state /var/lib/mysql/get
 install -o "$(id -u)" -g "$(id -g)" -m 0755 -D /pmm-prerun.sh /var/lib/mysql/pmm-prerun.sh
 install -o "$(id -u)" -g "$(id -g)" -m 0755 -D /mysql-state-monitor /var/lib/mysql/mysql-state-monitor
 install -o "$(id -u)" -g "$(id -g)" -m 0755 -D /wsrep_cmd_notify_handler.sh /var/lib/mysql/wsrep_cmd_notify_handler.sh
+install -o "$(id -u)" -g "$(id -g)" -m 0755 -D /velero-hook.sh /var/lib/mysql/velero-hook.sh
+install -o "$(id -u)" -g "$(id -g)" -m 0755 -D /pxc-graceful-shutdown.sh /var/lib/mysql/pxc-graceful-shutdown.sh
@@ -0,0 +1,30 @@
+#!/bin/bash
+
+# Velero backup hook of the PXC pods.
+# pre: desyncs the node, so flow control isn't triggered while its volumes
+#      are snapshotted, and flushes tables and logs to the disk.
+# post: syncs the node back.
+
+set -o errexit
+
+if [[ $1 != 'pre' && $1 != 'post' ]]; then
+	echo "Usage: $0 pre|post"
+	exit 1
+fi
+
+{ set +x; } 2>/dev/null
+mysql_pass=$(cat /etc/mysql/mysql-users-secret/operator || :)
+MYSQL_PASSWORD="${mysql_pass:-$OPERATOR_ADMIN_PASSWORD}"
+NODE_IP=$(hostname -I | awk ' { print $1 } ')
+
+run_sql() {
+	MYSQL_PWD="${MYSQL_PASSWORD}" mysql -P 33062 -h"${NODE_IP}" --protocol=TCP --user=operator -nNs -e "$1"
+}
+
+if [[ $1 == 'pre' ]]; then
+	run_sql "SET GLOBAL wsrep_desync=ON; FLUSH TABLES; FLUSH BINARY LOGS; FLUSH ENGINE LOGS;"
+	echo "Node is desynced for the backup"
+else
+	run_sql "SET GLOBAL wsrep_desync=OFF;"
+	echo "Node is synced after the backup"
+fi
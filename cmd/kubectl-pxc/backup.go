@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+)
+
+// backupTimeFormat is the creation time format in backup names, see the backup controller.
+const backupTimeFormat = "2006-01-02-15:04:05"
+
+func runBackup(ctx context.Context, args []string) error {
+	var o options
+	var stgName, name string
+	fs := newFlagSet("backup", &o)
+	fs.StringVar(&stgName, "storage", "", "storage of the backup, required if the cluster has more than one storage")
+	fs.StringVar(&name, "name", "", "name of the backup object, generated by default")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	c, err := o.clients()
+	if err != nil {
+		return err
+	}
+	cluster, err := c.getCluster(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	bcp, err := newBackup(cluster, stgName, name)
+	if err != nil {
+		return err
+	}
+	if err := c.client.Create(ctx, bcp); err != nil {
+		return errors.Wrap(err, "create backup")
+	}
+
+	fmt.Printf("perconaxtradbclusterbackup/%s created\n", bcp.Name)
+	return nil
+}
+
+// newBackup returns the backup of the cluster to the storage, the only storage of the cluster is used if stgName is empty.
+func newBackup(cluster *api.PerconaXtraDBCluster, stgName, name string) (*api.PerconaXtraDBClusterBackup, error) {
+	if cluster.Spec.Backup == nil {
+		return nil, errors.Errorf("backups aren't configured in cluster %s", cluster.Name)
+	}
+	if stgName == "" {
+		if len(cluster.Spec.Backup.Storages) != 1 {
+			return nil, errors.Errorf("cluster %s has %d storages, choose one with --storage", cluster.Name, len(cluster.Spec.Backup.Storages))
+		}
+		for n := range cluster.Spec.Backup.Storages {
+			stgName = n
+		}
+	}
+	if _, ok := cluster.Spec.Backup.Storages[stgName]; !ok {
+		return nil, errors.Errorf("storage %s is not defined in cluster %s", stgName, cluster.Name)
+	}
+
+	bcp := &api.PerconaXtraDBClusterBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cluster.Namespace,
+		},
+		Spec: api.PXCBackupSpec{
+			PXCCluster:  cluster.Name,
+			StorageName: stgName,
+		},
+	}
+	if name == "" {
+		bcp.GenerateName = cluster.Name + "-backup-"
+	}
+	return bcp, nil
+}
+
+func runBackups(ctx context.Context, args []string) error {
+	var o options
+	var remote bool
+	var stgName string
+	fs := newFlagSet("backups", &o)
+	fs.BoolVar(&remote, "remote", false, "list the backups found in the storages instead of the backup objects")
+	fs.StringVar(&stgName, "storage", "", "list the backups of this storage only")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	c, err := o.clients()
+	if err != nil {
+		return err
+	}
+	cluster, err := c.getCluster(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if remote {
+		return c.printRemoteBackups(ctx, cluster, stgName)
+	}
+
+	backups, err := c.clusterBackups(ctx, cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTORAGE\tDESTINATION\tSTATUS\tCOMPLETED")
+	for _, bcp := range backups {
+		if stgName != "" && bcp.Spec.StorageName != stgName {
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", bcp.Name, bcp.Spec.StorageName, bcp.Status.Destination, bcp.Status.State, formatTime(bcp.Status.CompletedAt))
+	}
+	return w.Flush()
+}
+
+// clusterBackups returns the backups of the cluster sorted by creation time.
+func (c *clients) clusterBackups(ctx context.Context, cluster string) ([]api.PerconaXtraDBClusterBackup, error) {
+	list := new(api.PerconaXtraDBClusterBackupList)
+	if err := c.client.List(ctx, list, client.InNamespace(c.namespace)); err != nil {
+		return nil, errors.Wrap(err, "list backups")
+	}
+
+	var backups []api.PerconaXtraDBClusterBackup
+	for _, bcp := range list.Items {
+		if bcp.Spec.PXCCluster == cluster {
+			backups = append(backups, bcp)
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreationTimestamp.Before(&backups[j].CreationTimestamp)
+	})
+	return backups, nil
+}
+
+// printRemoteBackups prints the full backups of the cluster found in its S3 and Azure storages.
+func (c *clients) printRemoteBackups(ctx context.Context, cluster *api.PerconaXtraDBCluster, stgName string) error {
+	if cluster.Spec.Backup == nil {
+		return errors.Errorf("backups aren't configured in cluster %s", cluster.Name)
+	}
+
+	var names []string
+	for name, stg := range cluster.Spec.Backup.Storages {
+		if stg == nil || stg.Type == api.BackupStorageFilesystem {
+			continue
+		}
+		if stgName == "" || stgName == name {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return errors.Errorf("cluster %s has no S3 or Azure storage %s", cluster.Name, stgName)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTORAGE\tDESTINATION\tCREATED")
+	for _, name := range names {
+		opts, err := storage.GetOptions(ctx, c.client, cluster, name)
+		if err != nil {
+			return errors.Wrapf(err, "get storage %s options", name)
+		}
+		stg, err := storage.NewClient(ctx, opts)
+		if err != nil {
+			return errors.Wrapf(err, "create storage %s client", name)
+		}
+		objects, err := stg.ListObjects(ctx, "")
+		if err != nil {
+			return errors.Wrapf(err, "list storage %s", name)
+		}
+
+		spec := cluster.Spec.Backup.Storages[name]
+		for _, bcp := range remoteBackups(objects, cluster.Name) {
+			var dest api.PXCBackupDestination
+			switch spec.Type {
+			case api.BackupStorageS3:
+				dest.SetS3Destination(spec.S3.Bucket, bcp.name)
+			case api.BackupStorageAzure:
+				dest.SetAzureDestination(spec.Azure.ContainerPath, bcp.name)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", bcp.name, name, dest, bcp.created.Format(time.RFC3339))
+		}
+	}
+	return w.Flush()
+}
+
+type remoteBackup struct {
+	name    string
+	created time.Time
+}
+
+// remoteBackups returns the full backups of the cluster in the storage objects sorted by creation time.
+func remoteBackups(objects []string, cluster string) []remoteBackup {
+	seen := make(map[string]struct{})
+	var backups []remoteBackup
+	for _, o := range objects {
+		name, _, _ := strings.Cut(o, "/")
+		name = strings.TrimSuffix(name, ".md5")
+		name = strings.TrimSuffix(name, ".sst_info")
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		created, ok := strings.CutPrefix(strings.TrimSuffix(name, "-full"), cluster+"-")
+		if !ok || !strings.HasSuffix(name, "-full") {
+			continue
+		}
+		t, err := time.Parse(backupTimeFormat, created)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, remoteBackup{name: name, created: t})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].created.Before(backups[j].created) })
+	return backups
+}
+
+func (c *clients) getCluster(ctx context.Context, name string) (*api.PerconaXtraDBCluster, error) {
+	cluster := new(api.PerconaXtraDBCluster)
+	if err := c.client.Get(ctx, types.NamespacedName{Name: name, Namespace: c.namespace}, cluster); err != nil {
+		return nil, errors.Wrapf(err, "get cluster %s", name)
+	}
+	return cluster, nil
+}
+
+func formatTime(t *metav1.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.RFC3339)
+}
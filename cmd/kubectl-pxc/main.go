@@ -0,0 +1,158 @@
+// kubectl-pxc is a kubectl plugin for the day-2 operations of the clusters managed by the operator.
+// It creates and reads the custom resources of the operator, so the operations don't require
+// writing the manifests by hand. Install the binary to PATH as kubectl-pxc to run it as kubectl pxc.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/pkg/errors"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/apis"
+)
+
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+var commands = []command{
+	{"backup", "CLUSTER [--storage NAME] [--name NAME]", "start a backup of the cluster", runBackup},
+	{"backups", "CLUSTER [--remote] [--storage NAME]", "list the backups of the cluster, or the backups found in its storages with --remote", runBackups},
+	{"restore", "CLUSTER (--backup NAME | --from DESTINATION --storage NAME) [--pitr-date DATE | --pitr-gtid GTID | --pitr-latest]", "start a restore of the cluster", runRestore},
+	{"restore-logs", "RESTORE [--follow]", "print the logs of the restore and point-in-time recovery jobs", runRestoreLogs},
+	{"status", "CLUSTER", "show the health of the cluster, its backups and point-in-time recovery", runStatus},
+}
+
+var errUsage = errors.New("usage")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	for _, c := range commands {
+		if c.name != os.Args[1] {
+			continue
+		}
+		err := c.run(ctx, os.Args[2:])
+		if errors.Is(err, errUsage) {
+			fmt.Fprintf(os.Stderr, "Usage: kubectl pxc %s %s\n", c.name, c.args)
+			os.Exit(1)
+		}
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "ERROR:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if os.Args[1] != "help" && os.Args[1] != "-h" && os.Args[1] != "--help" {
+		fmt.Fprintf(os.Stderr, "ERROR: unknown command %q.\n", os.Args[1])
+	}
+	usage()
+	os.Exit(1)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: kubectl pxc COMMAND [-n NAMESPACE] [--kubeconfig FILE] [--context NAME] ARGS\nCommands:")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-12s - %s\n", c.name, c.summary)
+	}
+}
+
+// options are the flags of all commands.
+type options struct {
+	namespace  string
+	kubeconfig string
+	context    string
+}
+
+// newFlagSet returns the flag set of the command with the common flags bound to o.
+func newFlagSet(name string, o *options) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.StringVar(&o.namespace, "n", "", "namespace of the cluster, the namespace of the current context by default")
+	fs.StringVar(&o.namespace, "namespace", "", "namespace of the cluster, the namespace of the current context by default")
+	fs.StringVar(&o.kubeconfig, "kubeconfig", "", "path to the kubeconfig file")
+	fs.StringVar(&o.context, "context", "", "name of the kubeconfig context")
+	return fs
+}
+
+// parse parses the flags and returns the positional arguments, the flags can follow them as in kubectl.
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+type clients struct {
+	client    client.Client
+	clientset kubernetes.Interface
+	namespace string
+}
+
+func (o *options) clients() (*clients, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = o.kubeconfig
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{CurrentContext: o.context})
+
+	restConfig, err := config.ClientConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "load kubeconfig")
+	}
+
+	namespace := o.namespace
+	if namespace == "" {
+		namespace, _, err = config.Namespace()
+		if err != nil {
+			return nil, errors.Wrap(err, "get namespace")
+		}
+	}
+
+	scheme := k8sruntime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := apis.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	cl, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, errors.Wrap(err, "create client")
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "create clientset")
+	}
+
+	return &clients{client: cl, clientset: clientset, namespace: namespace}, nil
+}
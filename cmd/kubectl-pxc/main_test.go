@@ -0,0 +1,129 @@
+package main
+
+import (
+	"flag"
+	"slices"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestParse(t *testing.T) {
+	var o options
+	var storage string
+	fs := newFlagSet("backup", &o)
+	fs.StringVar(&storage, "storage", "", "")
+
+	args, err := parse(fs, []string{"cluster1", "-n", "db", "--storage", "s3-us-west"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(args, []string{"cluster1"}) || o.namespace != "db" || storage != "s3-us-west" {
+		t.Errorf("unexpected args %v, namespace %q, storage %q", args, o.namespace, storage)
+	}
+
+	if _, err := parse(newFlagSet("backup", &o), []string{"cluster1", "--unknown"}); err == nil || err == flag.ErrHelp {
+		t.Errorf("expected an error for an unknown flag, got %v", err)
+	}
+}
+
+func newTestCluster() *api.PerconaXtraDBCluster {
+	return &api.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "db"},
+		Spec: api.PerconaXtraDBClusterSpec{
+			Backup: &api.PXCScheduledBackup{
+				Storages: map[string]*api.BackupStorageSpec{
+					"s3-us-west": {Type: api.BackupStorageS3, S3: &api.BackupStorageS3Spec{Bucket: "bucket"}},
+				},
+			},
+		},
+	}
+}
+
+func TestNewBackup(t *testing.T) {
+	cluster := newTestCluster()
+
+	bcp, err := newBackup(cluster, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bcp.Spec.StorageName != "s3-us-west" || bcp.Spec.PXCCluster != "cluster1" || bcp.GenerateName != "cluster1-backup-" {
+		t.Errorf("unexpected backup %+v", bcp)
+	}
+
+	if _, err := newBackup(cluster, "fs-pvc", ""); err == nil {
+		t.Error("expected an error for an unknown storage")
+	}
+
+	cluster.Spec.Backup.Storages["fs-pvc"] = &api.BackupStorageSpec{Type: api.BackupStorageFilesystem}
+	if _, err := newBackup(cluster, "", ""); err == nil {
+		t.Error("expected an error without a storage if the cluster has several")
+	}
+}
+
+func TestNewRestore(t *testing.T) {
+	cluster := newTestCluster()
+
+	tests := map[string]struct {
+		opts    restoreOptions
+		pitr    bool
+		wantErr bool
+	}{
+		"backup":              {opts: restoreOptions{backup: "backup1"}},
+		"destination":         {opts: restoreOptions{from: "s3://bucket/cluster1-2024-01-01-00:00:00-full", storage: "s3-us-west"}},
+		"no source":           {opts: restoreOptions{}, wantErr: true},
+		"both sources":        {opts: restoreOptions{backup: "backup1", from: "s3://bucket/backup"}, wantErr: true},
+		"destination storage": {opts: restoreOptions{from: "s3://bucket/backup"}, wantErr: true},
+		"pitr disabled":       {opts: restoreOptions{backup: "backup1", pitrLatest: true}, wantErr: true},
+		"pitr":                {opts: restoreOptions{backup: "backup1", pitrDate: "2024-01-01 10:00:00"}, pitr: true},
+		"pitr conflict":       {opts: restoreOptions{backup: "backup1", pitrDate: "2024-01-01 10:00:00", pitrLatest: true}, pitr: true, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := cluster.DeepCopy()
+			if tt.pitr {
+				c.Spec.Backup.PITR = api.PITRSpec{Enabled: true, StorageName: "s3-us-west"}
+			}
+
+			restore, err := newRestore(c, tt.opts)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := restore.CheckNsetDefaults(); err != nil {
+				t.Errorf("invalid restore: %v", err)
+			}
+			if tt.pitr && (restore.Spec.PITR.Type != "date" || restore.Spec.PITR.BackupSource.StorageName != "s3-us-west") {
+				t.Errorf("unexpected PITR %+v", restore.Spec.PITR)
+			}
+		})
+	}
+}
+
+func TestRemoteBackups(t *testing.T) {
+	objects := []string{
+		"cluster1-2024-01-02-00:00:00-full.md5",
+		"cluster1-2024-01-02-00:00:00-full/xtrabackup_info.00000000000000000000",
+		"cluster1-2024-01-01-00:00:00-full.sst_info/sst_info.00000000000000000000",
+		"cluster1-2024-01-01-00:00:00-full/ibdata1.00000000000000000000",
+		"cluster2-2024-01-01-00:00:00-full/ibdata1.00000000000000000000",
+		"cluster1-2024-01-03-00:00:00-incr/ibdata1.00000000000000000000",
+		"binlog_1704067200_0123456789abcdef",
+	}
+
+	var names []string
+	for _, bcp := range remoteBackups(objects, "cluster1") {
+		names = append(names, bcp.name)
+	}
+	expected := []string{"cluster1-2024-01-01-00:00:00-full", "cluster1-2024-01-02-00:00:00-full"}
+	if !slices.Equal(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+type restoreOptions struct {
+	name       string
+	backup     string
+	from       string
+	storage    string
+	pitrDate   string
+	pitrGTID   string
+	pitrLatest bool
+}
+
+func runRestore(ctx context.Context, args []string) error {
+	var o options
+	var ro restoreOptions
+	fs := newFlagSet("restore", &o)
+	fs.StringVar(&ro.name, "name", "", "name of the restore object, generated by default")
+	fs.StringVar(&ro.backup, "backup", "", "name of the backup object to restore")
+	fs.StringVar(&ro.from, "from", "", "destination of the backup to restore, e.g. s3://bucket/cluster1-2024-01-01-00:00:00-full")
+	fs.StringVar(&ro.storage, "storage", "", "storage of the cluster the --from backup is in")
+	fs.StringVar(&ro.pitrDate, "pitr-date", "", "recover the binlogs up to the date, e.g. \"2024-01-01 10:00:00\"")
+	fs.StringVar(&ro.pitrGTID, "pitr-gtid", "", "recover the binlogs up to the GTID")
+	fs.BoolVar(&ro.pitrLatest, "pitr-latest", false, "recover all the binlogs")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	c, err := o.clients()
+	if err != nil {
+		return err
+	}
+	cluster, err := c.getCluster(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	restore, err := newRestore(cluster, ro)
+	if err != nil {
+		return err
+	}
+	if err := c.client.Create(ctx, restore); err != nil {
+		return errors.Wrap(err, "create restore")
+	}
+
+	fmt.Printf("perconaxtradbclusterrestore/%s created\n", restore.Name)
+	return nil
+}
+
+// newRestore returns the restore of the cluster from the backup object or the backup destination.
+func newRestore(cluster *api.PerconaXtraDBCluster, o restoreOptions) (*api.PerconaXtraDBClusterRestore, error) {
+	restore := &api.PerconaXtraDBClusterRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      o.name,
+			Namespace: cluster.Namespace,
+		},
+		Spec: api.PerconaXtraDBClusterRestoreSpec{
+			PXCCluster: cluster.Name,
+			BackupName: o.backup,
+		},
+	}
+	if o.name == "" {
+		restore.GenerateName = cluster.Name + "-restore-"
+	}
+
+	switch {
+	case o.backup != "" && o.from != "":
+		return nil, errors.New("--backup and --from can't be used together")
+	case o.backup == "" && o.from == "":
+		return nil, errors.New("--backup or --from is required")
+	case o.from != "":
+		if cluster.Spec.Backup == nil {
+			return nil, errors.Errorf("backups aren't configured in cluster %s", cluster.Name)
+		}
+		if _, ok := cluster.Spec.Backup.Storages[o.storage]; !ok {
+			return nil, errors.Errorf("--from requires --storage with a storage of cluster %s", cluster.Name)
+		}
+		restore.Spec.BackupSource = &api.PXCBackupStatus{
+			Destination: api.PXCBackupDestination(o.from),
+			StorageName: o.storage,
+		}
+	}
+
+	pitr := new(api.PITR)
+	n := 0
+	if o.pitrDate != "" {
+		pitr.Type, pitr.Date = "date", o.pitrDate
+		n++
+	}
+	if o.pitrGTID != "" {
+		pitr.Type, pitr.GTID = "transaction", o.pitrGTID
+		n++
+	}
+	if o.pitrLatest {
+		pitr.Type = "latest"
+		n++
+	}
+	switch n {
+	case 0:
+		return restore, nil
+	case 1:
+	default:
+		return nil, errors.New("only one of --pitr-date, --pitr-gtid and --pitr-latest can be used")
+	}
+
+	if cluster.Spec.Backup == nil || !cluster.Spec.Backup.PITR.Enabled || cluster.Spec.Backup.PITR.StorageName == "" {
+		return nil, errors.Errorf("point-in-time recovery isn't enabled in cluster %s", cluster.Name)
+	}
+	pitr.BackupSource = &api.PXCBackupStatus{StorageName: cluster.Spec.Backup.PITR.StorageName}
+	restore.Spec.PITR = pitr
+
+	return restore, nil
+}
+
+func runRestoreLogs(ctx context.Context, args []string) error {
+	var o options
+	var follow bool
+	fs := newFlagSet("restore-logs", &o)
+	fs.BoolVar(&follow, "f", false, "follow the logs")
+	fs.BoolVar(&follow, "follow", false, "follow the logs")
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	c, err := o.clients()
+	if err != nil {
+		return err
+	}
+
+	restore := new(api.PerconaXtraDBClusterRestore)
+	if err := c.client.Get(ctx, types.NamespacedName{Name: args[0], Namespace: c.namespace}, restore); err != nil {
+		return errors.Wrapf(err, "get restore %s", args[0])
+	}
+
+	found := false
+	for _, job := range restoreJobNames(restore) {
+		pods, err := c.jobPods(ctx, job)
+		if err != nil {
+			return err
+		}
+		for _, pod := range pods {
+			found = true
+			fmt.Fprintf(os.Stderr, "==> pod/%s <==\n", pod.Name)
+			if err := c.printLogs(ctx, &pod, follow); err != nil {
+				return errors.Wrapf(err, "logs of pod %s", pod.Name)
+			}
+		}
+	}
+	if !found {
+		fmt.Fprintf(os.Stderr, "No restore job pods found, restore %s is in state %q: %s\n", restore.Name, restore.Status.State, restore.Status.Comments)
+		for _, line := range restore.Status.LatestLogLines {
+			fmt.Println(line)
+		}
+	}
+
+	return nil
+}
+
+// restoreJobNames returns the names of the restore job and the point-in-time recovery job in the order they run.
+func restoreJobNames(restore *api.PerconaXtraDBClusterRestore) []string {
+	return []string{
+		"restore-job-" + restore.Name + "-" + restore.Spec.PXCCluster,
+		"pitr-job-" + restore.Name + "-" + restore.Spec.PXCCluster,
+	}
+}
+
+// jobPods returns the pods of the job sorted by creation time.
+func (c *clients) jobPods(ctx context.Context, job string) ([]corev1.Pod, error) {
+	pods := new(corev1.PodList)
+	err := c.client.List(ctx, pods, client.InNamespace(c.namespace), client.MatchingLabels{batchv1.JobNameLabel: job})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list pods of job %s", job)
+	}
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].CreationTimestamp.Before(&pods.Items[j].CreationTimestamp)
+	})
+	return pods.Items, nil
+}
+
+func (c *clients) printLogs(ctx context.Context, pod *corev1.Pod, follow bool) error {
+	if pod.Status.Phase == corev1.PodPending {
+		fmt.Fprintln(os.Stderr, "Pod is pending")
+		return nil
+	}
+
+	stream, err := c.clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Follow: follow}).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	_, err = io.Copy(os.Stdout, stream)
+	return err
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+func runStatus(ctx context.Context, args []string) error {
+	var o options
+	fs := newFlagSet("status", &o)
+	args, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return errUsage
+	}
+
+	c, err := o.clients()
+	if err != nil {
+		return err
+	}
+	cluster, err := c.getCluster(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	backups, err := c.clusterBackups(ctx, cluster.Name)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	printClusterStatus(w, cluster)
+
+	fmt.Fprintln(w, "\nBackups:")
+	last := lastSucceededBackup(backups)
+	if last == nil {
+		fmt.Fprintln(w, "  Last succeeded:\t-")
+	} else {
+		fmt.Fprintf(w, "  Last succeeded:\t%s (%s, completed %s)\n", last.Name, last.Status.Destination, formatTime(last.Status.CompletedAt))
+	}
+	for _, bcp := range backups {
+		if bcp.Status.State != api.BackupSucceeded && bcp.Status.State != api.BackupFailed {
+			fmt.Fprintf(w, "  In progress:\t%s (%s)\n", bcp.Name, stateOrNew(string(bcp.Status.State)))
+		}
+	}
+
+	fmt.Fprintln(w, "\nPoint-in-time recovery:")
+	if cluster.Spec.Backup == nil || !cluster.Spec.Backup.PITR.Enabled {
+		fmt.Fprintln(w, "  Enabled:\tfalse")
+		return w.Flush()
+	}
+	fmt.Fprintf(w, "  Enabled:\ttrue (storage %s)\n", cluster.Spec.Backup.PITR.StorageName)
+
+	collector := new(appsv1.Deployment)
+	err = c.client.Get(ctx, types.NamespacedName{Name: naming.BinlogCollectorDeploymentName(cluster), Namespace: cluster.Namespace}, collector)
+	switch {
+	case k8serrors.IsNotFound(err):
+		fmt.Fprintln(w, "  Binlog collector:\tnot found")
+	case err != nil:
+		return errors.Wrap(err, "get binlog collector deployment")
+	default:
+		fmt.Fprintf(w, "  Binlog collector:\t%d/%d ready\n", collector.Status.ReadyReplicas, collector.Status.Replicas)
+	}
+	if last != nil {
+		fmt.Fprintf(w, "  Latest restorable time:\t%s\n", formatTime(last.Status.LatestRestorableTime))
+	}
+
+	return w.Flush()
+}
+
+func printClusterStatus(w *tabwriter.Writer, cluster *api.PerconaXtraDBCluster) {
+	fmt.Fprintf(w, "Cluster:\t%s/%s\n", cluster.Namespace, cluster.Name)
+	fmt.Fprintf(w, "State:\t%s\n", stateOrNew(string(cluster.Status.Status)))
+	if cluster.Status.Host != "" {
+		fmt.Fprintf(w, "Host:\t%s\n", cluster.Status.Host)
+	}
+	fmt.Fprintf(w, "PXC:\t%d/%d ready\n", cluster.Status.PXC.Ready, cluster.Status.PXC.Size)
+	if cluster.HAProxyEnabled() {
+		fmt.Fprintf(w, "HAProxy:\t%d/%d ready\n", cluster.Status.HAProxy.Ready, cluster.Status.HAProxy.Size)
+	}
+	if cluster.ProxySQLEnabled() {
+		fmt.Fprintf(w, "ProxySQL:\t%d/%d ready\n", cluster.Status.ProxySQL.Ready, cluster.Status.ProxySQL.Size)
+	}
+	for _, m := range cluster.Status.Messages {
+		fmt.Fprintf(w, "Message:\t%s\n", m)
+	}
+
+	if len(cluster.Status.Conditions) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "\nConditions:")
+	for _, cond := range cluster.Status.Conditions {
+		fmt.Fprintf(w, "  %s\t%s\t%s\t%s\n", cond.Type, cond.Status, cond.LastTransitionTime.Format("2006-01-02T15:04:05Z07:00"), cond.Message)
+	}
+}
+
+// lastSucceededBackup returns the succeeded backup completed last.
+func lastSucceededBackup(backups []api.PerconaXtraDBClusterBackup) *api.PerconaXtraDBClusterBackup {
+	var last *api.PerconaXtraDBClusterBackup
+	for i := range backups {
+		bcp := &backups[i]
+		if bcp.Status.State != api.BackupSucceeded || bcp.Status.CompletedAt == nil {
+			continue
+		}
+		if last == nil || last.Status.CompletedAt.Before(bcp.Status.CompletedAt) {
+			last = bcp
+		}
+	}
+	return last
+}
+
+func stateOrNew(state string) string {
+	if state == "" {
+		return "New"
+	}
+	return state
+}
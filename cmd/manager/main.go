@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/klog/v2"
 
@@ -14,10 +15,10 @@ import (
 	"github.com/go-logr/logr"
 	uzap "go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	eventsv1 "k8s.io/api/events/v1"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,8 +29,11 @@ import (
 
 	_ "github.com/Percona-Lab/percona-version-service/api"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/apis"
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/controller"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/introspection"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/tracing"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/webhook"
 	"github.com/percona/percona-xtradb-cluster-operator/version"
 )
@@ -46,12 +50,30 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var leaderElectionNamespace string
+	var leaseDuration, renewDeadline, retryPeriod time.Duration
+	var gracefulShutdownTimeout time.Duration
+	var introspectionAddr, introspectionTokenFile string
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", true,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&leaderElectionNamespace, "leader-elect-namespace", "",
+		"Namespace of the leader election lease. Defaults to the operator namespace.")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"Duration that non-leader candidates will wait to force acquire leadership.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"Duration that the acting leader will retry refreshing leadership before giving up.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second,
+		"Duration the leader election clients should wait between tries of actions.")
+	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", 2*time.Minute,
+		"Duration given to in-flight reconciles to finish before the operator stops and releases the leader lease.")
+	flag.StringVar(&introspectionAddr, "introspection-bind-address", "0",
+		"The address the read-only introspection endpoint binds to. Set it to \"0\" to disable the endpoint.")
+	flag.StringVar(&introspectionTokenFile, "introspection-token-file", "",
+		"File with the bearer token the introspection endpoint requires, usually mounted from a secret.")
 
 	opts := zap.Options{
 		Encoder: getLogEncoder(setupLog),
@@ -77,6 +99,12 @@ func main() {
 	setupLog.Info("Manager starting up", "gitCommit", GitCommit, "gitBranch", GitBranch,
 		"buildTime", BuildTime, "goVersion", runtime.Version(), "os", runtime.GOOS, "arch", runtime.GOARCH)
 
+	shutdownTracing, err := tracing.Init(version.Version)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+
 	namespace, err := k8s.GetWatchNamespace()
 	if err != nil {
 		setupLog.Error(err, "failed to get watch namespace")
@@ -96,9 +124,35 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "08db1feb.percona.com",
+		// release the lease right after the controllers are stopped,
+		// so a new leader doesn't wait for the lease to expire
+		LeaderElectionReleaseOnCancel: true,
+		LeaderElectionNamespace:       leaderElectionNamespace,
+		LeaseDuration:                 &leaseDuration,
+		RenewDeadline:                 &renewDeadline,
+		RetryPeriod:                   &retryPeriod,
+		GracefulShutdownTimeout:       &gracefulShutdownTimeout,
 		WebhookServer: ctrlWebhook.NewServer(ctrlWebhook.Options{
 			Port: 9443,
 		}),
+		Cache: cache.Options{
+			DefaultTransform: k8s.CacheTransform(),
+			ByObject:         k8s.CacheByObject(),
+		},
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				DisableFor: k8s.UncachedObjects(),
+			},
+		},
+		// name the updates of the operator apart from its applies,
+		// see k8s.UpdateFieldManager
+		NewClient: func(config *rest.Config, options client.Options) (client.Client, error) {
+			c, err := client.New(config, options)
+			if err != nil {
+				return nil, err
+			}
+			return client.WithFieldOwner(c, k8s.UpdateFieldManager), nil
+		},
 	}
 
 	// Add support for MultiNamespace set in WATCH_NAMESPACE
@@ -110,6 +164,25 @@ func main() {
 		options.Cache.DefaultNamespaces = namespaces
 	}
 
+	labelSelector, err := k8s.GetWatchLabelSelector()
+	if err != nil {
+		setupLog.Error(err, "failed to get watch label selector")
+		os.Exit(1)
+	}
+	if labelSelector != nil {
+		setupLog.Info("Watching clusters matching label selector", "selector", labelSelector.String())
+		options.Cache.ByObject[&api.PerconaXtraDBCluster{}] = cache.ByObject{Label: labelSelector}
+	}
+
+	namespaceSelector, err := k8s.GetWatchNamespaceSelector()
+	if err != nil {
+		setupLog.Error(err, "failed to get watch namespace selector")
+		os.Exit(1)
+	}
+	if namespaceSelector != nil {
+		setupLog.Info("Watching namespaces matching label selector", "selector", namespaceSelector.String())
+	}
+
 	// Get a config to talk to the apiserver
 	config, err := ctrl.GetConfig()
 	if err != nil {
@@ -165,15 +238,18 @@ func main() {
 		os.Exit(1)
 	}
 
-	err = mgr.GetFieldIndexer().IndexField(
-		context.Background(),
-		&eventsv1.Event{},
-		"regarding.name",
-		func(rawObj client.Object) []string {
-			event := rawObj.(*eventsv1.Event)
-			return []string{event.Regarding.Name}
-		},
-	)
+	if introspectionAddr != "" && introspectionAddr != "0" {
+		if introspectionTokenFile == "" {
+			setupLog.Error(nil, "the introspection endpoint requires --introspection-token-file")
+			os.Exit(1)
+		}
+		if err := mgr.Add(introspection.NewServer(mgr.GetClient(), introspectionAddr, introspectionTokenFile)); err != nil {
+			setupLog.Error(err, "unable to set up introspection endpoint")
+			os.Exit(1)
+		}
+	}
+
+	err = k8s.AddIndexes(context.Background(), mgr.GetFieldIndexer())
 	if err != nil {
 		setupLog.Error(err, "unable to index field")
 		os.Exit(1)
@@ -184,7 +260,13 @@ func main() {
 	ctx := k8s.StartStopSignalHandler(mgr.GetClient(), strings.Split(namespace, ","))
 
 	// Start the Cmd
-	if err := mgr.Start(ctx); err != nil {
+	err = mgr.Start(ctx)
+
+	if err := shutdownTracing(context.Background()); err != nil {
+		setupLog.Error(err, "failed to flush traces")
+	}
+
+	if err != nil {
 		setupLog.Error(err, "manager exited non-zero")
 		os.Exit(1)
 	}
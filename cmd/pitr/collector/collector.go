@@ -122,7 +122,17 @@ func New(ctx context.Context, c Config) (*Collector, error) {
 		if len(bucketArr) > 1 {
 			prefix = strings.TrimPrefix(c.BackupStorageS3.BucketURL, bucketArr[0]+"/") + "/"
 		}
-		s, err = storage.NewS3(ctx, c.BackupStorageS3.Endpoint, c.BackupStorageS3.AccessKeyID, c.BackupStorageS3.AccessKey, bucketArr[0], prefix, c.BackupStorageS3.Region, c.VerifyTLS)
+		opts := &storage.S3Options{
+			Endpoint:        c.BackupStorageS3.Endpoint,
+			AccessKeyID:     c.BackupStorageS3.AccessKeyID,
+			SecretAccessKey: c.BackupStorageS3.AccessKey,
+			BucketName:      bucketArr[0],
+			Prefix:          prefix,
+			Region:          c.BackupStorageS3.Region,
+			VerifyTLS:       c.VerifyTLS,
+		}
+		opts.Alternates = storage.S3AlternatesFromEnv("", opts)
+		s, err = storage.NewClient(ctx, opts)
 		if err != nil {
 			return nil, errors.Wrap(err, "new storage manager")
 		}
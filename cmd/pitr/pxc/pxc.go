@@ -202,6 +202,19 @@ func (p *PXC) SubtractGTIDSet(ctx context.Context, set, subSet string) (string,
 	return result, nil
 }
 
+// GetExecutedGTIDSet returns the GTID set of the transactions applied by the server.
+func (p *PXC) GetExecutedGTIDSet(ctx context.Context) (string, error) {
+	var result string
+	// GTID_SUBTRACT formats the set the same way as the other GTID functions
+	row := p.db.QueryRowContext(ctx, "SELECT GTID_SUBTRACT(@@GLOBAL.gtid_executed, '')")
+
+	if err := row.Scan(&result); err != nil {
+		return "", errors.Wrap(err, "scan gtid_executed")
+	}
+
+	return result, nil
+}
+
 func getNodesByServiceName(ctx context.Context, pxcServiceName string) ([]string, error) {
 	cmd := exec.CommandContext(ctx, "/opt/percona/peer-list", "-on-start=/usr/bin/get-pxc-state", "-service="+pxcServiceName)
 	out, err := cmd.CombinedOutput()
@@ -383,3 +396,128 @@ func (p *PXC) DropCollectorFunctions(ctx context.Context) error {
 
 	return nil
 }
+
+// RelayLogBasename returns the path and the base name of the relay log files.
+func (p *PXC) RelayLogBasename(ctx context.Context) (string, error) {
+	var basename string
+	if err := p.db.QueryRowContext(ctx, "SELECT @@GLOBAL.relay_log_basename").Scan(&basename); err != nil {
+		return "", errors.Wrap(err, "select relay_log_basename")
+	}
+
+	return basename, nil
+}
+
+// SetReplicaParallelWorkers sets the number of replica applier threads and returns the previous one.
+// It's applied to the channels started after the change.
+func (p *PXC) SetReplicaParallelWorkers(ctx context.Context, workers int) (int, error) {
+	var prev int
+	if err := p.db.QueryRowContext(ctx, "SELECT @@GLOBAL.replica_parallel_workers").Scan(&prev); err != nil {
+		return 0, errors.Wrap(err, "select replica_parallel_workers")
+	}
+
+	if _, err := p.db.ExecContext(ctx, "SET GLOBAL replica_parallel_workers = ?", workers); err != nil {
+		return 0, errors.Wrap(err, "set replica_parallel_workers")
+	}
+
+	return prev, nil
+}
+
+// ResetReplicaChannel removes the channel together with its relay log files.
+func (p *PXC) ResetReplicaChannel(ctx context.Context, channel string) error {
+	var count int
+	err := p.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM performance_schema.replication_applier_configuration WHERE CHANNEL_NAME = ?", channel).Scan(&count)
+	if err != nil {
+		return errors.Wrap(err, "check channel")
+	}
+	if count == 0 {
+		return nil
+	}
+
+	if _, err := p.db.ExecContext(ctx, "STOP REPLICA FOR CHANNEL ?", channel); err != nil {
+		return errors.Wrapf(err, "stop replica for channel %s", channel)
+	}
+	if _, err := p.db.ExecContext(ctx, "RESET REPLICA ALL FOR CHANNEL ?", channel); err != nil {
+		return errors.Wrapf(err, "reset replica for channel %s", channel)
+	}
+
+	return nil
+}
+
+// StartRelayLogApply creates the channel reading the relay log files listed in its index
+// starting from relayLogFile and starts its SQL thread. The SQL thread stops before the
+// transactions of untilBeforeGTIDs if it isn't empty. The channel has no source, its IO thread
+// is never started.
+func (p *PXC) StartRelayLogApply(ctx context.Context, channel, relayLogFile, untilBeforeGTIDs string) error {
+	_, err := p.db.ExecContext(ctx,
+		"CHANGE REPLICATION SOURCE TO SOURCE_HOST = 'pitr.invalid', RELAY_LOG_FILE = ?, RELAY_LOG_POS = 4 FOR CHANNEL ?", relayLogFile, channel)
+	if err != nil {
+		return errors.Wrapf(err, "change replication source for channel %s", channel)
+	}
+
+	if untilBeforeGTIDs != "" {
+		_, err = p.db.ExecContext(ctx, "START REPLICA SQL_THREAD UNTIL SQL_BEFORE_GTIDS = ? FOR CHANNEL ?", untilBeforeGTIDs, channel)
+	} else {
+		_, err = p.db.ExecContext(ctx, "START REPLICA SQL_THREAD FOR CHANNEL ?", channel)
+	}
+
+	return errors.Wrapf(err, "start replica sql thread for channel %s", channel)
+}
+
+// StopReplicaSQLThread stops the SQL thread of the channel. It waits for the
+// applier workers to finish the transactions they have already started.
+func (p *PXC) StopReplicaSQLThread(ctx context.Context, channel string) error {
+	_, err := p.db.ExecContext(ctx, "STOP REPLICA SQL_THREAD FOR CHANNEL ?", channel)
+	return errors.Wrapf(err, "stop replica sql thread for channel %s", channel)
+}
+
+// ReplicaSQLThreadStatus is the state of the SQL thread of a channel.
+type ReplicaSQLThreadStatus struct {
+	Running bool
+	State   string
+	Error   string
+}
+
+// CaughtUp returns true if the SQL thread has read all relay log files of the channel.
+func (s ReplicaSQLThreadStatus) CaughtUp() bool {
+	return s.Running && strings.Contains(s.State, "has read all relay log")
+}
+
+func (p *PXC) GetReplicaSQLThreadStatus(ctx context.Context, channel string) (ReplicaSQLThreadStatus, error) {
+	rows, err := p.db.QueryContext(ctx, "SHOW REPLICA STATUS FOR CHANNEL ?", channel)
+	if err != nil {
+		return ReplicaSQLThreadStatus{}, errors.Wrap(err, "show replica status")
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return ReplicaSQLThreadStatus{}, errors.Wrap(err, "get columns")
+	}
+	if !rows.Next() {
+		return ReplicaSQLThreadStatus{}, errors.Errorf("channel %s not found", channel)
+	}
+
+	values := make([]any, len(columns))
+	for i := range values {
+		values[i] = new(sql.RawBytes)
+	}
+	if err := rows.Scan(values...); err != nil {
+		return ReplicaSQLThreadStatus{}, errors.Wrap(err, "scan replica status")
+	}
+
+	var status ReplicaSQLThreadStatus
+	for i, name := range columns {
+		v := string(*values[i].(*sql.RawBytes))
+		switch name {
+		case "Replica_SQL_Running":
+			status.Running = v == "Yes"
+		case "Replica_SQL_Running_State":
+			status.State = v
+		case "Last_SQL_Error":
+			status.Error = v
+		}
+	}
+
+	return status, rows.Err()
+}
@@ -0,0 +1,240 @@
+package recoverer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// pitrChannel is the replication channel applying the binlogs as relay logs
+	pitrChannel = "pitr"
+	// binlogPrefetch is the number of binlogs downloaded ahead of the applied ones
+	binlogPrefetch = 4
+	// downloadPrefix is the prefix of the binlogs being downloaded to the relay log directory
+	downloadPrefix = "pitr-download-"
+)
+
+// applyParallel applies the binlogs with the multi-threaded replica applier of the server.
+// The binlogs are downloaded to the relay log directory of the server while the ones
+// downloaded before are applied by the SQL thread of a channel without a source.
+// It returns true if the SQL thread stopped at the transaction to recover to.
+func (r *Recoverer) applyParallel(ctx context.Context, binlogs []string) (bool, error) {
+	if len(binlogs) == 0 {
+		return false, nil
+	}
+
+	basename, err := r.db.RelayLogBasename(ctx)
+	if err != nil {
+		return false, err
+	}
+	relayLogBase := basename + "-" + pitrChannel
+	dir := filepath.Dir(basename)
+
+	// the channel is left behind if the previous attempt failed
+	if err := r.db.ResetReplicaChannel(ctx, pitrChannel); err != nil {
+		return false, err
+	}
+	removeDownloads(dir)
+
+	prevWorkers, err := r.db.SetReplicaParallelWorkers(ctx, r.parallelWorkers)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if _, err := r.db.SetReplicaParallelWorkers(context.Background(), prevWorkers); err != nil {
+			log.Printf("ERROR: restore replica_parallel_workers: %v", err)
+		}
+	}()
+
+	defer removeDownloads(dir)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	downloaded := make(chan string, binlogPrefetch)
+	downloadErr := make(chan error, 1)
+	go func() {
+		defer close(downloaded)
+		downloadErr <- r.downloadBinlogs(ctx, binlogs, dir, downloaded)
+	}()
+
+	var until string
+	if r.recoverType == Transaction {
+		until = r.gtid
+	}
+
+	seq, applied := 0, 0
+	for {
+		batch, ok := nextBatch(downloaded)
+		if !ok {
+			break
+		}
+
+		relayLogs := make([]string, 0, len(batch))
+		for _, path := range batch {
+			seq++
+			relayLog := fmt.Sprintf("%s.%06d", relayLogBase, seq)
+			if err := os.Rename(path, relayLog); err != nil {
+				return false, errors.Wrapf(err, "rename %s", path)
+			}
+			relayLogs = append(relayLogs, relayLog)
+		}
+
+		if err := writeRelayLogIndex(relayLogBase+".index", relayLogs); err != nil {
+			return false, err
+		}
+
+		log.Printf("applying %d binlogs with %d workers, %d out of %d remaining", len(batch), r.parallelWorkers, len(binlogs)-applied, len(binlogs))
+		applied += len(batch)
+
+		if err := r.db.StartRelayLogApply(ctx, pitrChannel, filepath.Base(relayLogs[0]), until); err != nil {
+			return false, err
+		}
+
+		untilReached, err := r.waitRelayLogApplied(ctx)
+		if err != nil {
+			return false, err
+		}
+
+		// the relay logs are deleted with the channel
+		if err := r.db.ResetReplicaChannel(ctx, pitrChannel); err != nil {
+			return false, err
+		}
+
+		if untilReached {
+			log.Printf("SQL thread stopped before %s", until)
+			return true, nil
+		}
+	}
+
+	if err := <-downloadErr; err != nil {
+		return false, errors.Wrap(err, "download binlogs")
+	}
+
+	return false, nil
+}
+
+// waitRelayLogApplied waits until the SQL thread of the channel applies all relay logs.
+// It returns true if the SQL thread stopped because of the UNTIL condition.
+func (r *Recoverer) waitRelayLogApplied(ctx context.Context) (bool, error) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		status, err := r.db.GetReplicaSQLThreadStatus(ctx, pitrChannel)
+		if err != nil {
+			return false, err
+		}
+		if status.Error != "" {
+			return false, errors.Errorf("apply relay logs: %s", status.Error)
+		}
+		if !status.Running {
+			return true, nil
+		}
+
+		if status.CaughtUp() {
+			// the workers finish the transactions they've been assigned before the SQL thread stops
+			if err := r.db.StopReplicaSQLThread(ctx, pitrChannel); err != nil {
+				return false, err
+			}
+			status, err := r.db.GetReplicaSQLThreadStatus(ctx, pitrChannel)
+			if err != nil {
+				return false, err
+			}
+			if status.Error != "" {
+				return false, errors.Errorf("apply relay logs: %s", status.Error)
+			}
+			return false, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// downloadBinlogs downloads the binlogs to dir one by one and sends the paths of the downloaded ones to out.
+func (r *Recoverer) downloadBinlogs(ctx context.Context, binlogs []string, dir string, out chan<- string) error {
+	for _, binlog := range binlogs {
+		path := filepath.Join(dir, downloadPrefix+filepath.Base(binlog))
+		if err := r.downloadBinlog(ctx, binlog, path); err != nil {
+			return errors.Wrapf(err, "download %s", binlog)
+		}
+
+		select {
+		case out <- path:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (r *Recoverer) downloadBinlog(ctx context.Context, binlog, path string) error {
+	obj, err := r.storage.GetObject(ctx, binlog)
+	if err != nil {
+		return errors.Wrap(err, "get obj")
+	}
+	defer obj.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrap(err, "create file")
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, obj); err != nil {
+		return errors.Wrap(err, "write file")
+	}
+
+	return errors.Wrap(f.Close(), "close file")
+}
+
+// nextBatch waits for a downloaded binlog and returns it together with the ones downloaded meanwhile.
+// It returns false if all binlogs are already returned.
+func nextBatch(downloaded <-chan string) ([]string, bool) {
+	path, ok := <-downloaded
+	if !ok {
+		return nil, false
+	}
+
+	batch := []string{path}
+	for {
+		select {
+		case path, ok := <-downloaded:
+			if !ok {
+				return batch, true
+			}
+			batch = append(batch, path)
+		default:
+			return batch, true
+		}
+	}
+}
+
+func writeRelayLogIndex(path string, relayLogs []string) error {
+	err := os.WriteFile(path, []byte(strings.Join(relayLogs, "\n")+"\n"), 0o640)
+	return errors.Wrap(err, "write relay log index")
+}
+
+func removeDownloads(dir string) {
+	files, err := filepath.Glob(filepath.Join(dir, downloadPrefix+"*"))
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			log.Printf("ERROR: remove %s: %v", f, err)
+		}
+	}
+}
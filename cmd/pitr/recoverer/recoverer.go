@@ -34,6 +34,8 @@ type Recoverer struct {
 	recoverEndTime time.Time
 	gtid           string
 	verifyTLS      bool
+	// parallelWorkers is the number of replica applier threads, binlogs are applied serially with mysqlbinlog if it's 0
+	parallelWorkers int
 }
 
 type Config struct {
@@ -49,6 +51,7 @@ type Config struct {
 	StorageType        string `env:"STORAGE_TYPE,required"`
 	BinlogStorageS3    BinlogS3
 	BinlogStorageAzure BinlogAzure
+	ParallelWorkers    int `env:"PITR_PARALLEL_WORKERS"`
 }
 
 func (c Config) storages(ctx context.Context) (storage.Storage, storage.Storage, error) {
@@ -59,7 +62,17 @@ func (c Config) storages(ctx context.Context) (storage.Storage, storage.Storage,
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "get bucket and prefix")
 		}
-		binlogStorage, err = storage.NewS3(ctx, c.BinlogStorageS3.Endpoint, c.BinlogStorageS3.AccessKeyID, c.BinlogStorageS3.AccessKey, bucket, prefix, c.BinlogStorageS3.Region, c.VerifyTLS)
+		opts := &storage.S3Options{
+			Endpoint:        c.BinlogStorageS3.Endpoint,
+			AccessKeyID:     c.BinlogStorageS3.AccessKeyID,
+			SecretAccessKey: c.BinlogStorageS3.AccessKey,
+			BucketName:      bucket,
+			Prefix:          prefix,
+			Region:          c.BinlogStorageS3.Region,
+			VerifyTLS:       c.VerifyTLS,
+		}
+		opts.Alternates = storage.S3AlternatesFromEnv("BINLOG_", opts)
+		binlogStorage, err = storage.NewClient(ctx, opts)
 		if err != nil {
 			return nil, nil, errors.Wrap(err, "new s3 storage")
 		}
@@ -142,9 +155,13 @@ func New(ctx context.Context, c Config) (*Recoverer, error) {
 		return nil, errors.Wrap(err, "new binlog storage manager")
 	}
 
-	startGTID, err := getStartGTIDSet(ctx, storage)
-	if err != nil {
-		return nil, errors.Wrap(err, "get start GTID")
+	var startGTID string
+	// a standby continues from the transactions it has already applied
+	if c.RecoverType != string(Standby) {
+		startGTID, err = getStartGTIDSet(ctx, storage)
+		if err != nil {
+			return nil, errors.Wrap(err, "get start GTID")
+		}
 	}
 
 	if c.RecoverType == string(Transaction) {
@@ -172,15 +189,16 @@ func New(ctx context.Context, c Config) (*Recoverer, error) {
 	}
 
 	return &Recoverer{
-		storage:        binlogStorage,
-		recoverTime:    c.RecoverTime,
-		pxcUser:        c.PXCUser,
-		pxcPass:        c.PXCPass,
-		pxcServiceName: c.PXCServiceName,
-		recoverType:    RecoverType(c.RecoverType),
-		startGTID:      startGTID,
-		gtid:           c.GTID,
-		verifyTLS:      c.VerifyTLS,
+		storage:         binlogStorage,
+		recoverTime:     c.RecoverTime,
+		pxcUser:         c.PXCUser,
+		pxcPass:         c.PXCPass,
+		pxcServiceName:  c.PXCServiceName,
+		recoverType:     RecoverType(c.RecoverType),
+		startGTID:       startGTID,
+		gtid:            c.GTID,
+		verifyTLS:       c.VerifyTLS,
+		parallelWorkers: c.ParallelWorkers,
 	}, nil
 }
 
@@ -262,6 +280,7 @@ const (
 	Date        RecoverType = "date"        // recover to exact date
 	Transaction RecoverType = "transaction" // recover to needed trunsaction
 	Skip        RecoverType = "skip"        // skip transactions
+	Standby     RecoverType = "standby"     // apply binlogs newer than the executed GTID set
 )
 
 func (r *Recoverer) Run(ctx context.Context) error {
@@ -274,6 +293,13 @@ func (r *Recoverer) Run(ctx context.Context) error {
 		return errors.Wrapf(err, "new manager with host %s", host)
 	}
 
+	if r.recoverType == Standby {
+		r.startGTID, err = r.db.GetExecutedGTIDSet(ctx)
+		if err != nil {
+			return errors.Wrap(err, "get executed GTID set")
+		}
+	}
+
 	err = r.setBinlogs(ctx)
 	if err != nil {
 		return errors.Wrap(err, "get binlog list")
@@ -293,7 +319,7 @@ func (r *Recoverer) Run(ctx context.Context) error {
 			return errors.Wrap(err, "parse date")
 		}
 		r.recoverEndTime = endTime
-	case Latest:
+	case Latest, Standby:
 	default:
 		return errors.New("wrong recover type")
 	}
@@ -307,6 +333,41 @@ func (r *Recoverer) Run(ctx context.Context) error {
 }
 
 func (r *Recoverer) recover(ctx context.Context) (err error) {
+	if err := r.prepare(ctx); err != nil {
+		return err
+	}
+
+	binlogs := r.binlogs
+	if r.recoverType == Date {
+		binlogs, err = binlogsUntil(r.binlogs, r.recoverEndTime)
+		if err != nil {
+			return err
+		}
+	}
+
+	// skipping a transaction isn't supported by the replica applier
+	if r.parallelWorkers > 0 && r.recoverType != Skip {
+		parallel, serial := binlogs, []string(nil)
+		// mysqlbinlog stops at the exact time inside the last binlog
+		if r.recoverType == Date && len(binlogs) > 0 {
+			parallel, serial = binlogs[:len(binlogs)-1], binlogs[len(binlogs)-1:]
+		}
+
+		untilReached, err := r.applyParallel(ctx, parallel)
+		if err != nil {
+			return errors.Wrap(err, "apply binlogs in parallel")
+		}
+		if untilReached {
+			log.Printf("Finished")
+			return nil
+		}
+		binlogs = serial
+	}
+
+	return r.applySerial(ctx, binlogs)
+}
+
+func (r *Recoverer) prepare(ctx context.Context) error {
 	version, err := r.db.GetVersion(ctx)
 	if err != nil {
 		return errors.Wrap(err, "get version")
@@ -329,6 +390,16 @@ func (r *Recoverer) recover(ctx context.Context) (err error) {
 		return errors.Wrap(err, "set mysql pwd env var")
 	}
 
+	return nil
+}
+
+// applySerial pipes the binlogs through mysqlbinlog to a single mysql client.
+func (r *Recoverer) applySerial(ctx context.Context, binlogs []string) error {
+	if len(binlogs) == 0 {
+		log.Printf("Finished")
+		return nil
+	}
+
 	mysqlStdin, binlogStdout := io.Pipe()
 	defer mysqlStdin.Close()
 
@@ -341,23 +412,9 @@ func (r *Recoverer) recover(ctx context.Context) (err error) {
 		return errors.Wrap(err, "start mysql")
 	}
 
-	for i, binlog := range r.binlogs {
-		remaining := len(r.binlogs) - i
-		log.Printf("working with %s, %d out of %d remaining\n", binlog, remaining, len(r.binlogs))
-		if r.recoverType == Date {
-			binlogArr := strings.Split(binlog, "_")
-			if len(binlogArr) < 2 {
-				return errors.New("get timestamp from binlog name")
-			}
-			binlogTime, err := strconv.ParseInt(binlogArr[1], 10, 64)
-			if err != nil {
-				return errors.Wrap(err, "get binlog time")
-			}
-			if binlogTime > r.recoverEndTime.Unix() {
-				log.Printf("Stopping at %s because it's after the recovery time (%d > %d)", binlog, binlogTime, r.recoverEndTime.Unix())
-				break
-			}
-		}
+	for i, binlog := range binlogs {
+		remaining := len(binlogs) - i
+		log.Printf("working with %s, %d out of %d remaining\n", binlog, remaining, len(binlogs))
 
 		binlogObj, err := r.storage.GetObject(ctx, binlog)
 		if err != nil {
@@ -390,6 +447,26 @@ func (r *Recoverer) recover(ctx context.Context) (err error) {
 	return nil
 }
 
+// binlogsUntil returns the binlogs started before the recovery end time.
+func binlogsUntil(binlogs []string, end time.Time) ([]string, error) {
+	for i, binlog := range binlogs {
+		binlogArr := strings.Split(binlog, "_")
+		if len(binlogArr) < 2 {
+			return nil, errors.New("get timestamp from binlog name")
+		}
+		binlogTime, err := strconv.ParseInt(binlogArr[1], 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "get binlog time")
+		}
+		if binlogTime > end.Unix() {
+			log.Printf("Stopping at %s because it's after the recovery time (%d > %d)", binlog, binlogTime, end.Unix())
+			return binlogs[:i], nil
+		}
+	}
+
+	return binlogs, nil
+}
+
 func getLastBackupGTID(ctx context.Context, sstInfo, xtrabackupInfo io.Reader) (string, error) {
 	sstContent, err := getDecompressedContent(ctx, sstInfo, "sst_info")
 	if err != nil {
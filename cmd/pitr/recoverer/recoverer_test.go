@@ -1,7 +1,9 @@
 package recoverer
 
 import (
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestGetBucketAndPrefix(t *testing.T) {
@@ -103,3 +105,50 @@ func TestGetExtendGTIDSet(t *testing.T) {
 		})
 	}
 }
+
+func TestBinlogsUntil(t *testing.T) {
+	binlogs := []string{"binlog_1700000000_aaa", "binlog_1700000600_bbb", "binlog_1700001200_ccc"}
+
+	list, err := binlogsUntil(binlogs, time.Unix(1700000700, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(list, binlogs[:2]) {
+		t.Errorf("expected %v, got %v", binlogs[:2], list)
+	}
+
+	list, err = binlogsUntil(binlogs, time.Unix(1700002000, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(list, binlogs) {
+		t.Errorf("expected %v, got %v", binlogs, list)
+	}
+
+	if _, err := binlogsUntil([]string{"binlog"}, time.Now()); err == nil {
+		t.Error("expected error for binlog name without timestamp")
+	}
+}
+
+func TestNextBatch(t *testing.T) {
+	downloaded := make(chan string, 3)
+	downloaded <- "a"
+	downloaded <- "b"
+
+	batch, ok := nextBatch(downloaded)
+	if !ok || !reflect.DeepEqual(batch, []string{"a", "b"}) {
+		t.Fatalf("expected [a b], got %v %t", batch, ok)
+	}
+
+	downloaded <- "c"
+	close(downloaded)
+
+	batch, ok = nextBatch(downloaded)
+	if !ok || !reflect.DeepEqual(batch, []string{"c"}) {
+		t.Fatalf("expected [c], got %v %t", batch, ok)
+	}
+
+	if _, ok := nextBatch(downloaded); ok {
+		t.Error("expected no batch after all binlogs are returned")
+	}
+}
@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"strings"
+	"time"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Condition types following the Kubernetes API conventions.
+// They are set on all custom resources, so kubectl wait and
+// health checks of GitOps tools work without custom scripts.
+const (
+	ConditionReady       = "Ready"
+	ConditionProgressing = "Progressing"
+	ConditionDegraded    = "Degraded"
+)
+
+// ConditionReplicaConsistent is set on replica clusters with enabled consistency checks.
+const ConditionReplicaConsistent = "ReplicaConsistent"
+
+// ConditionSuspended is true while the reconciliation of the cluster is suspended with spec.suspend.
+const ConditionSuspended = "Suspended"
+
+// ConditionDiagnosticBundle links the latest diagnostic bundle collected on a failure of the cluster.
+const ConditionDiagnosticBundle = "DiagnosticBundle"
+
+func isStateCondition(t AppState) bool {
+	return t == ConditionReady || t == ConditionProgressing || t == ConditionDegraded
+}
+
+func conditionStatus(v bool) metav1.ConditionStatus {
+	if v {
+		return metav1.ConditionTrue
+	}
+	return metav1.ConditionFalse
+}
+
+// conditionReason converts the state into the CamelCase reason, e.g. "Stopping Cluster" -> "StoppingCluster"
+func conditionReason(state string) string {
+	words := strings.FieldsFunc(state, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return "New"
+	}
+
+	var reason strings.Builder
+	for _, w := range words {
+		reason.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return reason.String()
+}
+
+func setStateConditions(conditions *[]metav1.Condition, generation int64, ready, progressing, degraded bool, reason, message string) {
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	for _, c := range []struct {
+		t string
+		v bool
+	}{
+		{ConditionReady, ready},
+		{ConditionProgressing, progressing},
+		{ConditionDegraded, degraded},
+	} {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               c.t,
+			Status:             conditionStatus(c.v),
+			Reason:             reason,
+			Message:            message,
+			ObservedGeneration: generation,
+			LastTransitionTime: now,
+		})
+	}
+}
+
+// SetStateConditions updates Ready, Progressing and Degraded conditions according to the backup state
+func (s *PXCBackupStatus) SetStateConditions(generation int64) {
+	setStateConditions(&s.Conditions, generation,
+		s.State == BackupSucceeded,
+		s.State == BackupNew || s.State == BackupStarting || s.State == BackupRunning,
+		s.State == BackupFailed,
+		conditionReason(string(s.State)), s.Error)
+}
+
+// SetStateConditions updates Ready, Progressing and Degraded conditions according to the restore state
+func (s *PerconaXtraDBClusterRestoreStatus) SetStateConditions(generation int64) {
+	setStateConditions(&s.Conditions, generation,
+		s.State == RestoreSucceeded,
+		s.State != RestoreSucceeded && s.State != RestoreFailed,
+		s.State == RestoreFailed,
+		conditionReason(string(s.State)), s.Comments)
+}
+
+// SetCondition adds the condition or replaces the existing one with the same type.
+// The transition time is preserved if the condition status hasn't changed.
+func (s *PerconaXtraDBClusterStatus) SetCondition(c ClusterCondition) {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type != c.Type {
+			continue
+		}
+		if s.Conditions[i].Status == c.Status {
+			c.LastTransitionTime = s.Conditions[i].LastTransitionTime
+		}
+		s.Conditions[i] = c
+		return
+	}
+
+	s.Conditions = append(s.Conditions, c)
+}
+
+// RemoveCondition removes the condition of the type if it's set.
+func (s *PerconaXtraDBClusterStatus) RemoveCondition(t AppState) {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == t {
+			s.Conditions = append(s.Conditions[:i], s.Conditions[i+1:]...)
+			return
+		}
+	}
+}
+
+// SetStateConditions updates Ready, Progressing and Degraded conditions according to the cluster state
+func (s *PerconaXtraDBClusterStatus) SetStateConditions(generation int64, inProgress bool, reconcileErr error) {
+	reason := conditionReason(string(s.Status))
+	if s.Status == "" {
+		reason = conditionReason(string(AppStateUnknown))
+	}
+	message := strings.Join(s.Messages, "; ")
+
+	degradedReason, degradedMessage := reason, message
+	canaryFailed := s.Canary != nil && s.Canary.State == CanaryFailed
+	if canaryFailed {
+		degradedReason, degradedMessage = "CanaryFailed", s.Canary.Message
+	}
+	replicationBroken := false
+	if s.PXCReplication != nil {
+		for _, ch := range s.PXCReplication.Channels {
+			if ch.Broken() {
+				replicationBroken = true
+				degradedReason, degradedMessage = "ReplicationBroken", "replication channel "+ch.Name+" is broken: "+ch.LastError
+				break
+			}
+		}
+	}
+	if reconcileErr != nil {
+		degradedReason, degradedMessage = "ReconcileError", reconcileErr.Error()
+	}
+
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	for _, c := range []struct {
+		t               AppState
+		v               bool
+		reason, message string
+	}{
+		{ConditionReady, s.Status == AppStateReady, reason, message},
+		{ConditionProgressing, s.Status == AppStateInit || s.Status == AppStateStopping || inProgress, reason, message},
+		{ConditionDegraded, s.Status == AppStateError || reconcileErr != nil || canaryFailed || replicationBroken, degradedReason, degradedMessage},
+	} {
+		s.SetCondition(ClusterCondition{
+			Type:               c.t,
+			Status:             ConditionStatus(conditionStatus(c.v)),
+			Reason:             c.reason,
+			Message:            c.message,
+			ObservedGeneration: generation,
+			LastTransitionTime: now,
+		})
+	}
+}
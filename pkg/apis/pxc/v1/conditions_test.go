@@ -0,0 +1,77 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestConditionReason(t *testing.T) {
+	cases := map[string]string{
+		"":                         "New",
+		"Succeeded":                "Succeeded",
+		"Stopping Cluster":         "StoppingCluster",
+		"Point-in-time recovering": "PointInTimeRecovering",
+		"initializing":             "Initializing",
+	}
+
+	for state, expected := range cases {
+		if reason := conditionReason(state); reason != expected {
+			t.Errorf("state %q: expected reason %s, got %s", state, expected, reason)
+		}
+	}
+}
+
+func TestClusterStateConditions(t *testing.T) {
+	s := PerconaXtraDBClusterStatus{Status: AppStateInit}
+	s.AddCondition(ClusterCondition{Type: AppStateInit, Status: ConditionTrue})
+	s.SetStateConditions(1, false, nil)
+
+	s.Status = AppStateReady
+	s.AddCondition(ClusterCondition{Type: AppStateReady, Status: ConditionTrue})
+	s.SetStateConditions(1, false, nil)
+
+	// the same state must not be added to the history twice
+	s.AddCondition(ClusterCondition{Type: AppStateReady, Status: ConditionTrue})
+	s.SetStateConditions(1, false, nil)
+
+	if len(s.Conditions) != 5 {
+		t.Fatalf("expected 2 history and 3 state conditions, got %v", s.Conditions)
+	}
+
+	expected := map[AppState]ConditionStatus{
+		ConditionReady:       ConditionTrue,
+		ConditionProgressing: ConditionFalse,
+		ConditionDegraded:    ConditionFalse,
+	}
+	for ct, status := range expected {
+		c := s.FindCondition(ct)
+		if c == nil || c.Status != status {
+			t.Errorf("expected %s condition with status %s, got %v", ct, status, c)
+		}
+	}
+
+	s.SetStateConditions(2, false, errors.New("boom"))
+	c := s.FindCondition(ConditionDegraded)
+	if c.Status != ConditionTrue || c.Reason != "ReconcileError" || c.ObservedGeneration != 2 {
+		t.Errorf("unexpected Degraded condition: %v", c)
+	}
+
+	s.Canary = &CanaryStatus{State: CanaryFailed, Message: "pod is not ready"}
+	s.SetStateConditions(3, false, nil)
+	c = s.FindCondition(ConditionDegraded)
+	if c.Status != ConditionTrue || c.Reason != "CanaryFailed" || c.Message != "pod is not ready" {
+		t.Errorf("unexpected Degraded condition: %v", c)
+	}
+
+	s.Canary = nil
+	s.PXCReplication = &ReplicationStatus{Channels: []ReplicationChannelStatus{
+		{Name: "stopped", IOThread: "No", SQLThread: "No"},
+		{Name: "dr", IOThread: "Connecting", SQLThread: "Yes", LastError: "error connecting to source"},
+	}}
+	s.SetStateConditions(4, false, nil)
+	c = s.FindCondition(ConditionDegraded)
+	if c.Status != ConditionTrue || c.Reason != "ReplicationBroken" || c.Message != "replication channel dr is broken: error connecting to source" {
+		t.Errorf("unexpected Degraded condition: %v", c)
+	}
+}
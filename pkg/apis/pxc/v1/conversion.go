@@ -0,0 +1,4 @@
+package v1
+
+// Hub marks v1 as the version other PerconaXtraDBCluster versions are converted through.
+func (*PerconaXtraDBCluster) Hub() {}
@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ClusterRolePrimary = "primary"
+	ClusterRoleReplica = "replica"
+)
+
+// +kubebuilder:object:generate=false
+// ExternalDNSTemplateData is the data the hostname template of external-dns is rendered with.
+type ExternalDNSTemplateData struct {
+	Cluster   string
+	Namespace string
+	Service   string
+	// Role is writer, reader, reporting, replication-source,
+	// or the name of the pod for the services of the PXC pods.
+	Role string
+	// ClusterRole is replica if the cluster replicates from another cluster
+	// with replication channels or is a standby cluster, primary otherwise.
+	ClusterRole string
+}
+
+// RenderHostname returns the hostname of the service.
+func (e *ExternalDNSSpec) RenderHostname(data ExternalDNSTemplateData) (string, error) {
+	tmpl, err := template.New("hostname").Parse(e.Hostname)
+	if err != nil {
+		return "", errors.Wrap(err, "parse template")
+	}
+
+	b := new(strings.Builder)
+	if err := tmpl.Execute(b, data); err != nil {
+		return "", errors.Wrap(err, "execute template")
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// ClusterRole returns the role of the cluster in the replication between clusters.
+func (cr *PerconaXtraDBCluster) ClusterRole() string {
+	if cr.Spec.Standby.IsEnabled() {
+		return ClusterRoleReplica
+	}
+	if cr.Spec.PXC != nil {
+		for _, channel := range cr.Spec.PXC.ReplicationChannels {
+			if !channel.IsSource {
+				return ClusterRoleReplica
+			}
+		}
+	}
+	return ClusterRolePrimary
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SuggestSpecChange publishes the change of the spec the operator would make if
+// the spec wasn't owned by a GitOps tool. The previous suggestion for the path is replaced.
+func (s *PerconaXtraDBClusterStatus) SuggestSpecChange(path, value, reason string) {
+	if s.GitOps == nil {
+		s.GitOps = new(GitOpsStatus)
+	}
+	for i := range s.GitOps.SuggestedChanges {
+		if s.GitOps.SuggestedChanges[i].Path == path {
+			s.GitOps.SuggestedChanges[i].Value = value
+			s.GitOps.SuggestedChanges[i].Reason = reason
+			return
+		}
+	}
+	s.GitOps.SuggestedChanges = append(s.GitOps.SuggestedChanges, SpecChange{Path: path, Value: value, Reason: reason})
+}
+
+// PruneAppliedSpecChanges removes the suggested changes which are already applied to the spec.
+// The status is reset if the spec isn't owned by a GitOps tool.
+func (cr *PerconaXtraDBCluster) PruneAppliedSpecChanges() error {
+	if !cr.Spec.GitOps.IsEnabled() {
+		cr.Status.GitOps = nil
+		return nil
+	}
+	if cr.Status.GitOps == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(cr.Spec)
+	if err != nil {
+		return err
+	}
+	spec := make(map[string]interface{})
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return err
+	}
+
+	changes := cr.Status.GitOps.SuggestedChanges[:0]
+	for _, c := range cr.Status.GitOps.SuggestedChanges {
+		v, ok := specValue(spec, c.Path)
+		// empty fields are omitted
+		if !ok && (c.Value == "false" || c.Value == "0" || c.Value == "") {
+			continue
+		}
+		if !ok || v != c.Value {
+			changes = append(changes, c)
+		}
+	}
+	cr.Status.GitOps.SuggestedChanges = changes
+	if len(changes) == 0 {
+		cr.Status.GitOps = nil
+	}
+
+	return nil
+}
+
+// specValue returns the value of the spec field by its path, e.g. spec.pxc.image.
+func specValue(spec map[string]interface{}, path string) (string, bool) {
+	keys := strings.Split(strings.TrimPrefix(path, "spec."), ".")
+
+	var v interface{} = spec
+	for _, k := range keys {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		v, ok = m[k]
+		if !ok {
+			return "", false
+		}
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}, []interface{}:
+		return "", false
+	case nil:
+		return "", false
+	default:
+		return fmt.Sprint(v), true
+	}
+}
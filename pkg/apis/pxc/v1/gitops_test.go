@@ -0,0 +1,62 @@
+package v1
+
+import (
+	"testing"
+)
+
+func TestPruneAppliedSpecChanges(t *testing.T) {
+	cr := &PerconaXtraDBCluster{
+		Spec: PerconaXtraDBClusterSpec{
+			CRVersion: "1.17.0",
+			Pause:     true,
+			GitOps:    &GitOpsSpec{Enabled: true},
+			PXC: &PXCSpec{
+				PodSpec: &PodSpec{Image: "percona/percona-xtradb-cluster:8.0.36"},
+			},
+		},
+	}
+
+	cr.Status.SuggestSpecChange("spec.crVersion", "1.17.0", "crVersion isn't set")
+	cr.Status.SuggestSpecChange("spec.pxc.image", "percona/percona-xtradb-cluster:8.0.35", "recommended by the version service")
+	cr.Status.SuggestSpecChange("spec.pxc.image", "percona/percona-xtradb-cluster:8.0.37", "recommended by the version service")
+	cr.Status.SuggestSpecChange("spec.pause", "false", "TLS is toggled")
+
+	if n := len(cr.Status.GitOps.SuggestedChanges); n != 3 {
+		t.Fatalf("expected 3 suggested changes, got %d", n)
+	}
+
+	if err := cr.PruneAppliedSpecChanges(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []SpecChange{
+		{Path: "spec.pxc.image", Value: "percona/percona-xtradb-cluster:8.0.37", Reason: "recommended by the version service"},
+		{Path: "spec.pause", Value: "false", Reason: "TLS is toggled"},
+	}
+	if len(cr.Status.GitOps.SuggestedChanges) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, cr.Status.GitOps.SuggestedChanges)
+	}
+	for i, c := range expected {
+		if cr.Status.GitOps.SuggestedChanges[i] != c {
+			t.Errorf("expected %v, got %v", c, cr.Status.GitOps.SuggestedChanges[i])
+		}
+	}
+
+	cr.Spec.Pause = false
+	cr.Spec.PXC.Image = "percona/percona-xtradb-cluster:8.0.37"
+	if err := cr.PruneAppliedSpecChanges(); err != nil {
+		t.Fatal(err)
+	}
+	if cr.Status.GitOps != nil {
+		t.Errorf("expected all changes to be applied, got %v", cr.Status.GitOps.SuggestedChanges)
+	}
+
+	cr.Status.SuggestSpecChange("spec.pause", "true", "TLS is toggled")
+	cr.Spec.GitOps.Enabled = false
+	if err := cr.PruneAppliedSpecChanges(); err != nil {
+		t.Fatal(err)
+	}
+	if cr.Status.GitOps != nil {
+		t.Error("expected the status to be reset if GitOps mode is disabled")
+	}
+}
@@ -0,0 +1,44 @@
+package v1
+
+import (
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ImageWithRegistry returns the image with its registry replaced by spec.imageRegistry.
+// Images without a registry, i.e. Docker Hub images, get the registry prepended.
+func (s *PerconaXtraDBClusterSpec) ImageWithRegistry(image string) string {
+	registry := strings.TrimSuffix(s.ImageRegistry, "/")
+	if registry == "" || image == "" || strings.HasPrefix(image, registry+"/") {
+		return image
+	}
+
+	if host, name, ok := strings.Cut(image, "/"); ok && (strings.ContainsAny(host, ".:") || host == "localhost") {
+		image = name
+	}
+	return registry + "/" + image
+}
+
+// ApplyImageOverrides replaces the registry of the images of the pod and adds
+// the cluster image pull secrets to the pull secrets of the pod.
+func (cr *PerconaXtraDBCluster) ApplyImageOverrides(pod *corev1.PodSpec) {
+	for i := range pod.InitContainers {
+		pod.InitContainers[i].Image = cr.Spec.ImageWithRegistry(pod.InitContainers[i].Image)
+	}
+	for i := range pod.Containers {
+		pod.Containers[i].Image = cr.Spec.ImageWithRegistry(pod.Containers[i].Image)
+	}
+
+	if len(cr.Spec.ImagePullSecrets) == 0 {
+		return
+	}
+	secrets := slices.Clone(pod.ImagePullSecrets)
+	for _, s := range cr.Spec.ImagePullSecrets {
+		if !slices.Contains(secrets, s) {
+			secrets = append(secrets, s)
+		}
+	}
+	pod.ImagePullSecrets = secrets
+}
@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestImageWithRegistry(t *testing.T) {
+	spec := &PerconaXtraDBClusterSpec{ImageRegistry: "registry.example.com/mirror/"}
+	cases := map[string]string{
+		"percona/percona-xtradb-cluster:8.0":            "registry.example.com/mirror/percona/percona-xtradb-cluster:8.0",
+		"docker.io/percona/haproxy:2.8":                 "registry.example.com/mirror/percona/haproxy:2.8",
+		"localhost:5000/percona/pmm-client:2":           "registry.example.com/mirror/percona/pmm-client:2",
+		"busybox":                                       "registry.example.com/mirror/busybox",
+		"registry.example.com/mirror/percona/fluentbit": "registry.example.com/mirror/percona/fluentbit",
+		"": "",
+	}
+	for image, expected := range cases {
+		if got := spec.ImageWithRegistry(image); got != expected {
+			t.Errorf("image %q: expected %q, got %q", image, expected, got)
+		}
+	}
+
+	if got := (&PerconaXtraDBClusterSpec{}).ImageWithRegistry("percona/haproxy"); got != "percona/haproxy" {
+		t.Errorf("expected the image to be unchanged without a registry, got %q", got)
+	}
+}
+
+func TestApplyImageOverrides(t *testing.T) {
+	cr := &PerconaXtraDBCluster{Spec: PerconaXtraDBClusterSpec{
+		ImageRegistry:    "registry.example.com",
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "mirror"}, {Name: "pxc"}},
+	}}
+	componentSecrets := []corev1.LocalObjectReference{{Name: "pxc"}}
+	pod := &corev1.PodSpec{
+		ImagePullSecrets: componentSecrets,
+		InitContainers:   []corev1.Container{{Image: "percona/percona-xtradb-cluster-operator:1.16.0"}},
+		Containers:       []corev1.Container{{Image: "percona/percona-xtradb-cluster:8.0"}, {Image: "percona/pmm-client:2"}},
+	}
+
+	cr.ApplyImageOverrides(pod)
+
+	for _, c := range append(pod.InitContainers, pod.Containers...) {
+		if !strings.HasPrefix(c.Image, "registry.example.com/") {
+			t.Errorf("image %s is not in the registry", c.Image)
+		}
+	}
+	if len(pod.ImagePullSecrets) != 2 || pod.ImagePullSecrets[1].Name != "mirror" {
+		t.Errorf("unexpected pull secrets %v", pod.ImagePullSecrets)
+	}
+	if len(componentSecrets) != 1 {
+		t.Error("pull secrets of the component are changed")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"path"
 	"strings"
 
+	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -71,6 +72,54 @@ type PXCBackupStatus struct {
 	Conditions            []metav1.Condition      `json:"conditions,omitempty"`
 	VerifyTLS             *bool                   `json:"verifyTLS,omitempty"`
 	LatestRestorableTime  *metav1.Time            `json:"latestRestorableTime,omitempty"`
+	// Layout describes the backups which weren't taken by the operator.
+	// It's used only by the backupSource of the restores.
+	Layout *BackupLayout `json:"layout,omitempty"`
+}
+
+type BackupFormat string
+
+const (
+	// BackupFormatOperator is the layout of the backups taken by the operator:
+	// the xbcloud chunks of the backup and of its SST info under <name>/ and <name>.sst_info/.
+	BackupFormatOperator BackupFormat = "operator"
+	// BackupFormatXbcloud is the layout of a backup uploaded with xbcloud put <name>, without the SST info.
+	BackupFormatXbcloud BackupFormat = "xbcloud"
+	// BackupFormatXbstream is a single xbstream object <name> uploaded with a generic tool.
+	BackupFormatXbstream BackupFormat = "xbstream"
+)
+
+type BackupLayout struct {
+	// +kubebuilder:validation:Enum={operator,xbcloud,xbstream}
+	Format BackupFormat `json:"format,omitempty"`
+	// Compression is the algorithm of xtrabackup --compress, the backup isn't compressed if empty.
+	// +kubebuilder:validation:Enum={quicklz,lz4,zstd}
+	Compression string `json:"compression,omitempty"`
+	// Prepared is set if xtrabackup --prepare was run before the backup was uploaded.
+	Prepared bool `json:"prepared,omitempty"`
+}
+
+func (l *BackupLayout) GetFormat() BackupFormat {
+	if l == nil || l.Format == "" {
+		return BackupFormatOperator
+	}
+	return l.Format
+}
+
+func (l *BackupLayout) checkNSetDefaults() error {
+	switch l.Format {
+	case "":
+		l.Format = BackupFormatOperator
+	case BackupFormatOperator, BackupFormatXbcloud, BackupFormatXbstream:
+	default:
+		return errors.Errorf("unknown format %s", l.Format)
+	}
+	switch l.Compression {
+	case "", "quicklz", "lz4", "zstd":
+	default:
+		return errors.Errorf("unknown compression %s", l.Compression)
+	}
+	return nil
 }
 
 type PXCBackupDestination string
@@ -134,6 +183,18 @@ func (dest *PXCBackupDestination) BackupName() string {
 	return backupName
 }
 
+// ObjectPrefixes returns the prefixes of the backup objects in the cloud storage.
+func (status *PXCBackupStatus) ObjectPrefixes() []string {
+	name := status.Destination.BackupName()
+	switch status.Layout.GetFormat() {
+	case BackupFormatXbcloud:
+		return []string{name + "/"}
+	case BackupFormatXbstream:
+		return []string{name}
+	}
+	return []string{name + "/", name + ".sst_info/"}
+}
+
 func (status *PXCBackupStatus) GetStorageType(cluster *PerconaXtraDBCluster) BackupStorageType {
 	if status.StorageType != "" {
 		return status.StorageType
@@ -0,0 +1,153 @@
+package v1
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PerconaXtraDBDatabaseSpec defines the desired state of PerconaXtraDBDatabase
+type PerconaXtraDBDatabaseSpec struct {
+	ClusterRef ClusterReference `json:"clusterRef"`
+	// Name is the schema name, metadata.name is used if it's empty.
+	Name         string `json:"name,omitempty"`
+	CharacterSet string `json:"characterSet,omitempty"`
+	Collation    string `json:"collation,omitempty"`
+
+	Owner            DatabaseOwner            `json:"owner,omitempty"`
+	ConnectionSecret DatabaseConnectionSecret `json:"connectionSecret,omitempty"`
+
+	DeletionPolicy DatabaseDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+type ClusterReference struct {
+	Name string `json:"name"`
+	// Namespace of the cluster, the namespace of the resource is used if it's empty.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type DatabaseOwner struct {
+	// Name of the owner user, the schema name is used if it's empty.
+	Name   string   `json:"name,omitempty"`
+	Hosts  []string `json:"hosts,omitempty"`
+	Grants []string `json:"grants,omitempty"`
+}
+
+type DatabaseConnectionSecret struct {
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+type DatabaseDeletionPolicy string
+
+const (
+	DatabaseDeletionRetain DatabaseDeletionPolicy = "Retain"
+	DatabaseDeletionDelete DatabaseDeletionPolicy = "Delete"
+)
+
+type DatabaseState string
+
+const (
+	DatabaseStatePending DatabaseState = "Pending"
+	DatabaseStateReady   DatabaseState = "Ready"
+	DatabaseStateError   DatabaseState = "Error"
+)
+
+// PerconaXtraDBDatabaseStatus defines the observed state of PerconaXtraDBDatabase
+type PerconaXtraDBDatabaseStatus struct {
+	State              DatabaseState `json:"state,omitempty"`
+	Message            string        `json:"message,omitempty"`
+	ObservedGeneration int64         `json:"observedGeneration,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBDatabase is the Schema for the perconaxtradbdatabases API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName="pxc-db";"pxc-dbs"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterRef.name",description="Cluster name"
+// +kubebuilder:printcolumn:name="Database",type="string",JSONPath=".spec.name",description="Schema name"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.state",description="Database status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PerconaXtraDBDatabase struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerconaXtraDBDatabaseSpec   `json:"spec,omitempty"`
+	Status PerconaXtraDBDatabaseStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBDatabaseList contains a list of PerconaXtraDBDatabase
+type PerconaXtraDBDatabaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerconaXtraDBDatabase `json:"items"`
+}
+
+var (
+	dbIdentifierRegexp = regexp.MustCompile(`^[a-zA-Z0-9_$]{1,64}$`)
+	dbUserRegexp       = regexp.MustCompile(`^[a-zA-Z0-9_$.-]{1,32}$`)
+	dbGrantRegexp      = regexp.MustCompile(`^[A-Za-z ]+$`)
+	dbCharsetRegexp    = regexp.MustCompile(`^[a-zA-Z0-9_]*$`)
+)
+
+func (cr *PerconaXtraDBDatabase) CheckNSetDefaults() error {
+	if cr.Spec.ClusterRef.Name == "" {
+		return errors.New("clusterRef.name can't be empty")
+	}
+	if cr.Spec.ClusterRef.Namespace == "" {
+		cr.Spec.ClusterRef.Namespace = cr.Namespace
+	}
+
+	if cr.Spec.Name == "" {
+		cr.Spec.Name = cr.Name
+	}
+	if !dbIdentifierRegexp.MatchString(cr.Spec.Name) {
+		return errors.Errorf("invalid schema name %s", cr.Spec.Name)
+	}
+	if !dbCharsetRegexp.MatchString(cr.Spec.CharacterSet) {
+		return errors.Errorf("invalid characterSet %s", cr.Spec.CharacterSet)
+	}
+	if !dbCharsetRegexp.MatchString(cr.Spec.Collation) {
+		return errors.Errorf("invalid collation %s", cr.Spec.Collation)
+	}
+
+	if cr.Spec.Owner.Name == "" {
+		cr.Spec.Owner.Name = cr.Spec.Name
+	}
+	if !dbUserRegexp.MatchString(cr.Spec.Owner.Name) {
+		return errors.Errorf("invalid owner name %s", cr.Spec.Owner.Name)
+	}
+	if len(cr.Spec.Owner.Hosts) == 0 {
+		cr.Spec.Owner.Hosts = []string{"%"}
+	}
+	if len(cr.Spec.Owner.Grants) == 0 {
+		cr.Spec.Owner.Grants = []string{"ALL PRIVILEGES"}
+	}
+	for _, g := range cr.Spec.Owner.Grants {
+		if !dbGrantRegexp.MatchString(g) {
+			return errors.Errorf("invalid grant %s", g)
+		}
+	}
+
+	if cr.Spec.ConnectionSecret.Name == "" {
+		cr.Spec.ConnectionSecret.Name = cr.Name + "-conn"
+	}
+	if cr.Spec.ConnectionSecret.Namespace == "" {
+		cr.Spec.ConnectionSecret.Namespace = cr.Namespace
+	}
+
+	switch cr.Spec.DeletionPolicy {
+	case "":
+		cr.Spec.DeletionPolicy = DatabaseDeletionRetain
+	case DatabaseDeletionRetain, DatabaseDeletionDelete:
+	default:
+		return errors.Errorf("unknown deletionPolicy %s", cr.Spec.DeletionPolicy)
+	}
+
+	return nil
+}
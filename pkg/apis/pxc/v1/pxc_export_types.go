@@ -0,0 +1,135 @@
+package v1
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PerconaXtraDBClusterExportSpec defines the desired state of PerconaXtraDBClusterExport
+type PerconaXtraDBClusterExportSpec struct {
+	PXCCluster string `json:"pxcCluster"`
+	// StorageName is an S3 or Azure storage of the cluster backup section.
+	StorageName string `json:"storageName"`
+	// Databases to export, all databases are exported if both Databases and Tables are empty.
+	Databases []string `json:"databases,omitempty"`
+	// Tables to export in the database.table format.
+	Tables []string   `json:"tables,omitempty"`
+	Tool   ExportTool `json:"tool,omitempty"`
+	// Image with the tool, the cluster PXC image is used for mysqldump by default.
+	Image string `json:"image,omitempty"`
+	// Args are extra arguments of the tool.
+	Args []string `json:"args,omitempty"`
+	// Schedule is a cron expression to export periodically, the export is run once if it's empty.
+	Schedule              string `json:"schedule,omitempty"`
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+type ExportTool string
+
+const (
+	ExportToolMydumper  ExportTool = "mydumper"
+	ExportToolMysqldump ExportTool = "mysqldump"
+)
+
+type ExportState string
+
+const (
+	ExportStateNew       ExportState = ""
+	ExportStatePending   ExportState = "Pending"
+	ExportStateScheduled ExportState = "Scheduled"
+	ExportStateRunning   ExportState = "Running"
+	ExportStateSucceeded ExportState = "Succeeded"
+	ExportStateFailed    ExportState = "Failed"
+)
+
+// PerconaXtraDBClusterExportStatus defines the observed state of PerconaXtraDBClusterExport
+type PerconaXtraDBClusterExportStatus struct {
+	State   ExportState `json:"state,omitempty"`
+	Message string      `json:"message,omitempty"`
+	Job     string      `json:"job,omitempty"`
+	// Destination of the export. Scheduled exports are stored
+	// with the destination as a prefix followed by the export time.
+	Destination        string       `json:"destination,omitempty"`
+	LastScheduleTime   *metav1.Time `json:"lastScheduleTime,omitempty"`
+	LastSuccessfulTime *metav1.Time `json:"lastSuccessfulTime,omitempty"`
+	StartedAt          *metav1.Time `json:"startedAt,omitempty"`
+	CompletedAt        *metav1.Time `json:"completed,omitempty"`
+	ObservedGeneration int64        `json:"observedGeneration,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBClusterExport is the Schema for the perconaxtradbclusterexports API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName="pxc-export";"pxc-exports"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.pxcCluster",description="Cluster name"
+// +kubebuilder:printcolumn:name="Storage",type="string",JSONPath=".spec.storageName",description="Storage name"
+// +kubebuilder:printcolumn:name="Destination",type="string",JSONPath=".status.destination",description="Export destination"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.state",description="Export status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PerconaXtraDBClusterExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerconaXtraDBClusterExportSpec   `json:"spec,omitempty"`
+	Status PerconaXtraDBClusterExportStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBClusterExportList contains a list of PerconaXtraDBClusterExport
+type PerconaXtraDBClusterExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerconaXtraDBClusterExport `json:"items"`
+}
+
+func (cr *PerconaXtraDBClusterExport) CheckNSetDefaults() error {
+	spec := &cr.Spec
+	if spec.PXCCluster == "" {
+		return errors.New("pxcCluster can't be empty")
+	}
+	if spec.StorageName == "" {
+		return errors.New("storageName can't be empty")
+	}
+
+	for _, db := range spec.Databases {
+		if !dbIdentifierRegexp.MatchString(db) {
+			return errors.Errorf("invalid database %q", db)
+		}
+	}
+	for _, t := range spec.Tables {
+		db, table, ok := strings.Cut(t, ".")
+		if !ok || !dbIdentifierRegexp.MatchString(db) || !dbIdentifierRegexp.MatchString(table) {
+			return errors.Errorf("invalid table %q, expected database.table", t)
+		}
+	}
+	if len(spec.Databases) > 0 && len(spec.Tables) > 0 {
+		return errors.New("only one of databases and tables can be set")
+	}
+
+	switch spec.Tool {
+	case "":
+		spec.Tool = ExportToolMydumper
+		fallthrough
+	case ExportToolMydumper:
+		if spec.Image == "" {
+			return errors.New("image can't be empty for mydumper")
+		}
+	case ExportToolMysqldump:
+	default:
+		return errors.Errorf("unknown tool %s", spec.Tool)
+	}
+
+	if spec.Schedule != "" {
+		if _, err := cron.ParseStandard(spec.Schedule); err != nil {
+			return errors.Wrapf(err, "invalid schedule %s", spec.Schedule)
+		}
+	}
+
+	return nil
+}
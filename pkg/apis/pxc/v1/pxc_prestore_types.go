@@ -2,6 +2,8 @@ package v1
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -15,14 +17,165 @@ type PerconaXtraDBClusterRestoreSpec struct {
 	BackupSource     *PXCBackupStatus            `json:"backupSource,omitempty"`
 	PITR             *PITR                       `json:"pitr,omitempty"`
 	Resources        corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Masking rewrites sensitive data before the restored cluster is opened for traffic.
+	Masking *RestoreMasking `json:"masking,omitempty"`
+	// Rehearsal restores the backup to a temporary cluster instead of PXCCluster.
+	Rehearsal *RestoreRehearsal `json:"rehearsal,omitempty"`
+	// Retrieval configures the retrieval of backups in archival storage classes.
+	Retrieval *RestoreRetrieval `json:"retrieval,omitempty"`
+}
+
+// RestoreRetrieval configures the retrieval of the backup objects in archival S3 storage classes,
+// e.g. GLACIER or DEEP_ARCHIVE. The restore waits in the AwaitingRetrieval state until
+// temporary copies of all the archived objects are readable.
+type RestoreRetrieval struct {
+	// Tier is the retrieval tier: Expedited, Standard or Bulk. Standard by default.
+	Tier RetrievalTier `json:"tier,omitempty"`
+	// Days is the number of days the retrieved copies are kept for, 1 by default.
+	Days int32 `json:"days,omitempty"`
+}
+
+type RetrievalTier string
+
+const (
+	RetrievalTierExpedited RetrievalTier = "Expedited"
+	RetrievalTierStandard  RetrievalTier = "Standard"
+	RetrievalTierBulk      RetrievalTier = "Bulk"
+)
+
+// RestoreRehearsal tests the disaster recovery without touching the cluster. The backup and
+// the binlogs are restored to a temporary single node cluster with its own volumes, the
+// cluster spec and the users secret are copied from PXCCluster with backups, replication,
+// proxies and exposure disabled.
+type RestoreRehearsal struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// ClusterName is the name of the temporary cluster, <pxcCluster>-rh by default.
+	ClusterName string `json:"clusterName,omitempty"`
+	// KeepCluster keeps the temporary cluster after the rehearsal for inspection.
+	// Otherwise it's deleted with its volumes once the recovery point is reported.
+	// If the rehearsal fails, the cluster is kept until the restore is deleted.
+	KeepCluster bool `json:"keepCluster,omitempty"`
+}
+
+func (r *RestoreRehearsal) IsEnabled() bool {
+	return r != nil && r.Enabled
+}
+
+// TargetCluster returns the name of the cluster the backup is restored to.
+func (cr *PerconaXtraDBClusterRestore) TargetCluster() string {
+	if cr.Spec.Rehearsal.IsEnabled() {
+		return cr.Spec.Rehearsal.ClusterName
+	}
+	return cr.Spec.PXCCluster
 }
 
 // PerconaXtraDBClusterRestoreStatus defines the observed state of PerconaXtraDBClusterRestore
 type PerconaXtraDBClusterRestoreStatus struct {
-	State         BcpRestoreStates `json:"state,omitempty"`
-	Comments      string           `json:"comments,omitempty"`
-	CompletedAt   *metav1.Time     `json:"completed,omitempty"`
-	LastScheduled *metav1.Time     `json:"lastscheduled,omitempty"`
+	State         BcpRestoreStates   `json:"state,omitempty"`
+	Comments      string             `json:"comments,omitempty"`
+	CompletedAt   *metav1.Time       `json:"completed,omitempty"`
+	LastScheduled *metav1.Time       `json:"lastscheduled,omitempty"`
+	Conditions    []metav1.Condition `json:"conditions,omitempty"`
+
+	// StateStartedAt is the time the restore moved to its current state.
+	// The restore fails if the cluster doesn't stop or start in time.
+	StateStartedAt *metav1.Time `json:"stateStartedAt,omitempty"`
+
+	// OriginalCluster keeps the cluster sizes changed for point-in-time recovery
+	// and masking to restore them afterwards.
+	OriginalCluster *RestoreOriginalCluster `json:"originalCluster,omitempty"`
+
+	// LatestLogLines are the latest key lines of the restore and PITR job output:
+	// errors, phase markers and xtrabackup completion messages.
+	LatestLogLines []string `json:"latestLogLines,omitempty"`
+
+	Rehearsal *RestoreRehearsalStatus `json:"rehearsal,omitempty"`
+
+	Retrieval *RestoreRetrievalStatus `json:"retrieval,omitempty"`
+
+	Progress *RestoreProgress `json:"progress,omitempty"`
+}
+
+// RestoreProgress reports the progress of the restore and its estimated completion time.
+// The estimation is based on the backup size, the rates observed by the restore and
+// the rates of the previous restores of the cluster.
+type RestoreProgress struct {
+	// BackupSize is the size of the backup objects in bytes, 0 if the storage doesn't report it.
+	BackupSize int64 `json:"backupSize,omitempty"`
+	// DownloadStartedAt is the time the restore job was started.
+	DownloadStartedAt *metav1.Time `json:"downloadStartedAt,omitempty"`
+	// PrepareStartedAt is the time the restore job started to prepare the downloaded backup.
+	PrepareStartedAt *metav1.Time `json:"prepareStartedAt,omitempty"`
+	// RestoredAt is the time the restore job completed.
+	RestoredAt *metav1.Time `json:"restoredAt,omitempty"`
+	// DownloadRate is the observed download rate of the backup in bytes per second.
+	DownloadRate int64 `json:"downloadRate,omitempty"`
+	// PrepareRate is the observed prepare rate of the backup in bytes per second.
+	PrepareRate int64 `json:"prepareRate,omitempty"`
+	// EstimatedCompletionTime is the time the restore is expected to complete at.
+	EstimatedCompletionTime *metav1.Time `json:"estimatedCompletionTime,omitempty"`
+}
+
+// RestoreRetrievalStatus reports the retrieval of the archived backup objects.
+type RestoreRetrievalStatus struct {
+	// StartedAt is the time the retrieval was requested.
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// ETA is the time the objects are expected to be retrieved by, estimated from their storage class and the tier.
+	ETA *metav1.Time `json:"eta,omitempty"`
+	// Objects is the number of the archived objects of the backup.
+	Objects int32 `json:"objects,omitempty"`
+	// Pending is the number of the archived objects which aren't retrieved yet.
+	Pending int32 `json:"pending,omitempty"`
+}
+
+// RestoreRehearsalStatus reports the result of a restore rehearsal.
+type RestoreRehearsalStatus struct {
+	ClusterName string `json:"clusterName,omitempty"`
+	// RecoveryTarget is the requested point-in-time recovery target, empty if only the backup is restored.
+	RecoveryTarget string `json:"recoveryTarget,omitempty"`
+	// RecoveryPoint is gtid_executed of the temporary cluster after the restore.
+	RecoveryPoint string `json:"recoveryPoint,omitempty"`
+}
+
+type RestoreOriginalCluster struct {
+	PXCSize         int32 `json:"pxcSize"`
+	HAProxySize     int32 `json:"haproxySize,omitempty"`
+	ProxySQLSize    int32 `json:"proxysqlSize,omitempty"`
+	UnsafePXCSize   bool  `json:"unsafePXCSize,omitempty"`
+	UnsafeProxySize bool  `json:"unsafeProxySize,omitempty"`
+}
+
+// RestoreMasking is applied on a single PXC node while the proxies are scaled down.
+// The other nodes get the masked data by SST.
+type RestoreMasking struct {
+	Rules []MaskingRule `json:"rules,omitempty"`
+	// Script is executed after the rules.
+	Script *SQLJobScript `json:"script,omitempty"`
+	// Image with the mysql client, the cluster PXC image is used by default.
+	Image                 string `json:"image,omitempty"`
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+type MaskingMethod string
+
+const (
+	// MaskingHash replaces the value with its SHA-256 hash truncated to the original length.
+	MaskingHash MaskingMethod = "hash"
+	// MaskingNull sets the column to NULL.
+	MaskingNull MaskingMethod = "null"
+	// MaskingStatic sets the column to Value.
+	MaskingStatic MaskingMethod = "static"
+	// MaskingRegex replaces the matches of Pattern with Value. Requires MySQL 8.0.
+	MaskingRegex MaskingMethod = "regex"
+)
+
+type MaskingRule struct {
+	Database string        `json:"database"`
+	Table    string        `json:"table"`
+	Column   string        `json:"column"`
+	Method   MaskingMethod `json:"method,omitempty"`
+	Value    string        `json:"value,omitempty"`
+	Pattern  string        `json:"pattern,omitempty"`
 }
 
 type PITR struct {
@@ -30,6 +183,10 @@ type PITR struct {
 	Type         string           `json:"type"`
 	Date         string           `json:"date"`
 	GTID         string           `json:"gtid"`
+	// ParallelWorkers applies the binlogs with the given number of replica applier threads.
+	// The binlogs are copied to the datadir of the first PXC pod, so the PITR job is scheduled
+	// on its node. The binlogs are applied serially with mysqlbinlog if it's 0.
+	ParallelWorkers int32 `json:"parallelWorkers,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -62,14 +219,16 @@ type PerconaXtraDBClusterRestoreList struct {
 type BcpRestoreStates string
 
 const (
-	RestoreNew          BcpRestoreStates = ""
-	RestoreStarting     BcpRestoreStates = "Starting"
-	RestoreStopCluster  BcpRestoreStates = "Stopping Cluster"
-	RestoreRestore      BcpRestoreStates = "Restoring"
-	RestoreStartCluster BcpRestoreStates = "Starting Cluster"
-	RestorePITR         BcpRestoreStates = "Point-in-time recovering"
-	RestoreFailed       BcpRestoreStates = "Failed"
-	RestoreSucceeded    BcpRestoreStates = "Succeeded"
+	RestoreNew               BcpRestoreStates = ""
+	RestoreStarting          BcpRestoreStates = "Starting"
+	RestoreAwaitingRetrieval BcpRestoreStates = "AwaitingRetrieval"
+	RestoreStopCluster       BcpRestoreStates = "Stopping Cluster"
+	RestoreRestore           BcpRestoreStates = "Restoring"
+	RestoreStartCluster      BcpRestoreStates = "Starting Cluster"
+	RestorePITR              BcpRestoreStates = "Point-in-time recovering"
+	RestoreMaskData          BcpRestoreStates = "Masking Data"
+	RestoreFailed            BcpRestoreStates = "Failed"
+	RestoreSucceeded         BcpRestoreStates = "Succeeded"
 )
 
 const AnnotationUnsafePITR = "percona.com/unsafe-pitr"
@@ -81,12 +240,99 @@ func (cr *PerconaXtraDBClusterRestore) CheckNsetDefaults() error {
 	if cr.Spec.PITR != nil && cr.Spec.PITR.BackupSource != nil && cr.Spec.PITR.BackupSource.StorageName == "" && cr.Spec.PITR.BackupSource.S3 == nil && cr.Spec.PITR.BackupSource.Azure == nil {
 		return errors.New("PITR.BackupSource.StorageName, PITR.BackupSource.S3 and PITR.BackupSource.Azure can't be empty simultaneously")
 	}
+	if cr.Spec.PITR != nil && cr.Spec.PITR.ParallelWorkers < 0 {
+		return errors.New("PITR.ParallelWorkers can't be negative")
+	}
 	if cr.Spec.BackupName == "" && cr.Spec.BackupSource == nil {
 		return errors.New("backupName and BackupSource can't be empty simultaneously")
 	}
 	if len(cr.Spec.BackupName) > 0 && cr.Spec.BackupSource != nil {
 		return errors.New("backupName and BackupSource can't be specified simultaneously")
 	}
+	if bs := cr.Spec.BackupSource; bs != nil && bs.Layout != nil {
+		if err := bs.Layout.checkNSetDefaults(); err != nil {
+			return fmt.Errorf("backupSource.layout: %w", err)
+		}
+		if bs.Layout.Format != BackupFormatOperator && bs.Destination.StorageTypePrefix() == PVCStoragePrefix {
+			return fmt.Errorf("backupSource.layout: format %s isn't supported for PVC backups", bs.Layout.Format)
+		}
+	}
+	if cr.Spec.PITR != nil && cr.Spec.PITR.BackupSource != nil && cr.Spec.PITR.BackupSource.Layout != nil {
+		return errors.New("PITR.BackupSource.Layout can't be set")
+	}
+	if co := cr.Spec.ContainerOptions; co != nil && co.Tuning != nil {
+		if err := co.Tuning.validate(); err != nil {
+			return fmt.Errorf("containerOptions.tuning: %w", err)
+		}
+	}
+	if cr.Spec.Masking != nil {
+		if err := cr.Spec.Masking.checkNSetDefaults(); err != nil {
+			return fmt.Errorf("masking: %w", err)
+		}
+	}
+	if r := cr.Spec.Rehearsal; r.IsEnabled() {
+		if r.ClusterName == "" {
+			name := cr.Spec.PXCCluster
+			if len(name) > clusterNameMaxLen-len("-rh") {
+				name = strings.TrimRight(name[:clusterNameMaxLen-len("-rh")], "-")
+			}
+			r.ClusterName = name + "-rh"
+		}
+		if r.ClusterName == cr.Spec.PXCCluster {
+			return errors.New("rehearsal.clusterName can't be the name of pxcCluster")
+		}
+		if len(r.ClusterName) > clusterNameMaxLen {
+			return fmt.Errorf("rehearsal.clusterName %s is too long, must be no more than %d characters", r.ClusterName, clusterNameMaxLen)
+		}
+	}
+	if r := cr.Spec.Retrieval; r != nil {
+		switch r.Tier {
+		case "":
+			r.Tier = RetrievalTierStandard
+		case RetrievalTierExpedited, RetrievalTierStandard, RetrievalTierBulk:
+		default:
+			return fmt.Errorf("retrieval.tier: unknown tier %s", r.Tier)
+		}
+		if r.Days == 0 {
+			r.Days = 1
+		}
+		if r.Days < 0 {
+			return errors.New("retrieval.days can't be negative")
+		}
+	}
+
+	return nil
+}
+
+func (m *RestoreMasking) checkNSetDefaults() error {
+	if len(m.Rules) == 0 && m.Script == nil {
+		return errors.New("rules or script should be set")
+	}
+	if m.Script != nil {
+		if err := m.Script.validate(); err != nil {
+			return err
+		}
+	}
+
+	for i := range m.Rules {
+		rule := &m.Rules[i]
+		for _, id := range []string{rule.Database, rule.Table, rule.Column} {
+			if !dbIdentifierRegexp.MatchString(id) {
+				return fmt.Errorf("rule %d: invalid identifier %q", i, id)
+			}
+		}
+		switch rule.Method {
+		case "":
+			rule.Method = MaskingHash
+		case MaskingHash, MaskingNull, MaskingStatic:
+		case MaskingRegex:
+			if rule.Pattern == "" {
+				return fmt.Errorf("rule %d: pattern can't be empty for the regex method", i)
+			}
+		default:
+			return fmt.Errorf("rule %d: unknown method %s", i, rule.Method)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,143 @@
+package v1
+
+import (
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PerconaXtraDBClusterSchemaMigrationSpec defines the desired state of PerconaXtraDBClusterSchemaMigration
+type PerconaXtraDBClusterSchemaMigrationSpec struct {
+	PXCCluster string `json:"pxcCluster"`
+	Database   string `json:"database"`
+	Table      string `json:"table"`
+	// Alter is the ALTER TABLE statement without the table, e.g. "ADD COLUMN c INT".
+	Alter string              `json:"alter"`
+	Tool  SchemaMigrationTool `json:"tool,omitempty"`
+	// Image with the tool.
+	Image string `json:"image"`
+	// Args are extra arguments of the tool.
+	Args                  []string                   `json:"args,omitempty"`
+	FlowControl           SchemaMigrationFlowControl `json:"flowControl,omitempty"`
+	ActiveDeadlineSeconds *int64                     `json:"activeDeadlineSeconds,omitempty"`
+}
+
+type SchemaMigrationTool string
+
+const (
+	SchemaMigrationToolPTOSC SchemaMigrationTool = "pt-online-schema-change"
+	SchemaMigrationToolGhost SchemaMigrationTool = "gh-ost"
+)
+
+// SchemaMigrationFlowControl limits the pressure the row copy puts on the cluster.
+// The pressure is the share of time the cluster is paused by Galera flow control.
+type SchemaMigrationFlowControl struct {
+	// ThrottlePercent pauses the row copy while the cluster is paused
+	// by flow control more than the percent of time.
+	ThrottlePercent int32 `json:"throttlePercent,omitempty"`
+	// AbortPercent aborts the migration if the cluster is paused by flow control
+	// more than the percent of time for AbortAfterSeconds. Zero disables the abort.
+	AbortPercent      int32 `json:"abortPercent,omitempty"`
+	AbortAfterSeconds int32 `json:"abortAfterSeconds,omitempty"`
+}
+
+type SchemaMigrationState string
+
+const (
+	SchemaMigrationStateNew       SchemaMigrationState = ""
+	SchemaMigrationStatePending   SchemaMigrationState = "Pending"
+	SchemaMigrationStateRunning   SchemaMigrationState = "Running"
+	SchemaMigrationStateSucceeded SchemaMigrationState = "Succeeded"
+	SchemaMigrationStateFailed    SchemaMigrationState = "Failed"
+	SchemaMigrationStateAborted   SchemaMigrationState = "Aborted"
+)
+
+// PerconaXtraDBClusterSchemaMigrationStatus defines the observed state of PerconaXtraDBClusterSchemaMigration
+type PerconaXtraDBClusterSchemaMigrationStatus struct {
+	State   SchemaMigrationState `json:"state,omitempty"`
+	Message string               `json:"message,omitempty"`
+	Job     string               `json:"job,omitempty"`
+	// Progress of the row copy reported by the tool.
+	Progress  string `json:"progress,omitempty"`
+	Throttled bool   `json:"throttled,omitempty"`
+	// FlowControlPausedPercent is the share of time the cluster was paused
+	// by flow control since the previous sample.
+	FlowControlPausedPercent int32        `json:"flowControlPausedPercent,omitempty"`
+	FlowControlPausedNS      int64        `json:"flowControlPausedNS,omitempty"`
+	FlowControlSampledAt     *metav1.Time `json:"flowControlSampledAt,omitempty"`
+	// OverloadedSince is the time flow control pauses exceeded AbortPercent.
+	OverloadedSince    *metav1.Time `json:"overloadedSince,omitempty"`
+	StartedAt          *metav1.Time `json:"startedAt,omitempty"`
+	CompletedAt        *metav1.Time `json:"completed,omitempty"`
+	ObservedGeneration int64        `json:"observedGeneration,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBClusterSchemaMigration is the Schema for the perconaxtradbclusterschemamigrations API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName="pxc-migration";"pxc-migrations"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.pxcCluster",description="Cluster name"
+// +kubebuilder:printcolumn:name="Table",type="string",JSONPath=".spec.table",description="Altered table"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.state",description="Migration status"
+// +kubebuilder:printcolumn:name="Progress",type="string",JSONPath=".status.progress",description="Row copy progress"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PerconaXtraDBClusterSchemaMigration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerconaXtraDBClusterSchemaMigrationSpec   `json:"spec,omitempty"`
+	Status PerconaXtraDBClusterSchemaMigrationStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBClusterSchemaMigrationList contains a list of PerconaXtraDBClusterSchemaMigration
+type PerconaXtraDBClusterSchemaMigrationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerconaXtraDBClusterSchemaMigration `json:"items"`
+}
+
+func (cr *PerconaXtraDBClusterSchemaMigration) CheckNSetDefaults() error {
+	spec := &cr.Spec
+	if spec.PXCCluster == "" {
+		return errors.New("pxcCluster can't be empty")
+	}
+	if !dbIdentifierRegexp.MatchString(spec.Database) {
+		return errors.Errorf("invalid database %q", spec.Database)
+	}
+	if !dbIdentifierRegexp.MatchString(spec.Table) {
+		return errors.Errorf("invalid table %q", spec.Table)
+	}
+	if spec.Alter == "" {
+		return errors.New("alter can't be empty")
+	}
+	if spec.Image == "" {
+		return errors.New("image can't be empty")
+	}
+
+	switch spec.Tool {
+	case "":
+		spec.Tool = SchemaMigrationToolPTOSC
+	case SchemaMigrationToolPTOSC, SchemaMigrationToolGhost:
+	default:
+		return errors.Errorf("unknown tool %s", spec.Tool)
+	}
+
+	fc := &spec.FlowControl
+	if fc.ThrottlePercent < 0 || fc.ThrottlePercent > 100 || fc.AbortPercent < 0 || fc.AbortPercent > 100 {
+		return errors.New("flowControl: percents should be between 0 and 100")
+	}
+	if fc.ThrottlePercent == 0 {
+		fc.ThrottlePercent = 10
+	}
+	if fc.AbortPercent > 0 && fc.AbortPercent < fc.ThrottlePercent {
+		return errors.New("flowControl: abortPercent can't be less than throttlePercent")
+	}
+	if fc.AbortAfterSeconds == 0 {
+		fc.AbortAfterSeconds = 300
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PerconaXtraDBClusterSQLJobSpec defines the desired state of PerconaXtraDBClusterSQLJob
+type PerconaXtraDBClusterSQLJobSpec struct {
+	PXCCluster string `json:"pxcCluster"`
+	// Script is executed once by the mysql client, changes of the spec
+	// after the job is started are ignored.
+	Script SQLJobScript `json:"script"`
+	// Database is the default schema of the script. Grants are limited
+	// to the schema if it's set.
+	Database string `json:"database,omitempty"`
+	// Grants of the short-lived user running the script, the user is dropped
+	// once the script is finished.
+	Grants []string `json:"grants,omitempty"`
+	// Transactional runs the script in a single transaction which is rolled back
+	// if a statement fails. Statements causing an implicit commit, like DDL, can't be rolled back.
+	Transactional bool `json:"transactional,omitempty"`
+	// Image with the mysql client, the PXC image of the cluster is used if it's empty.
+	Image                 string `json:"image,omitempty"`
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+}
+
+// SQLJobScript references the key with the script in a config map or a secret.
+type SQLJobScript struct {
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	SecretKeyRef    *corev1.SecretKeySelector    `json:"secretKeyRef,omitempty"`
+}
+
+type SQLJobState string
+
+const (
+	SQLJobStateNew       SQLJobState = ""
+	SQLJobStatePending   SQLJobState = "Pending"
+	SQLJobStateRunning   SQLJobState = "Running"
+	SQLJobStateSucceeded SQLJobState = "Succeeded"
+	SQLJobStateFailed    SQLJobState = "Failed"
+)
+
+// PerconaXtraDBClusterSQLJobStatus defines the observed state of PerconaXtraDBClusterSQLJob
+type PerconaXtraDBClusterSQLJobStatus struct {
+	State   SQLJobState `json:"state,omitempty"`
+	Message string      `json:"message,omitempty"`
+	Job     string      `json:"job,omitempty"`
+	// Output is the tail of the mysql client output.
+	Output             string       `json:"output,omitempty"`
+	StartedAt          *metav1.Time `json:"startedAt,omitempty"`
+	CompletedAt        *metav1.Time `json:"completed,omitempty"`
+	ObservedGeneration int64        `json:"observedGeneration,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBClusterSQLJob is the Schema for the perconaxtradbclustersqljobs API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName="pxc-sqljob";"pxc-sqljobs"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.pxcCluster",description="Cluster name"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.state",description="Job status"
+// +kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".status.completed",description="Completed time"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PerconaXtraDBClusterSQLJob struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerconaXtraDBClusterSQLJobSpec   `json:"spec,omitempty"`
+	Status PerconaXtraDBClusterSQLJobStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBClusterSQLJobList contains a list of PerconaXtraDBClusterSQLJob
+type PerconaXtraDBClusterSQLJobList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerconaXtraDBClusterSQLJob `json:"items"`
+}
+
+func (cr *PerconaXtraDBClusterSQLJob) CheckNSetDefaults() error {
+	if cr.Spec.PXCCluster == "" {
+		return errors.New("pxcCluster can't be empty")
+	}
+
+	if err := cr.Spec.Script.validate(); err != nil {
+		return err
+	}
+
+	if cr.Spec.Database != "" && !dbIdentifierRegexp.MatchString(cr.Spec.Database) {
+		return errors.Errorf("invalid database %s", cr.Spec.Database)
+	}
+
+	if len(cr.Spec.Grants) == 0 {
+		cr.Spec.Grants = []string{"ALL PRIVILEGES"}
+	}
+	for _, g := range cr.Spec.Grants {
+		if !dbGrantRegexp.MatchString(g) {
+			return errors.Errorf("invalid grant %s", g)
+		}
+	}
+
+	return nil
+}
+
+func (s *SQLJobScript) validate() error {
+	switch {
+	case s.ConfigMapKeyRef == nil && s.SecretKeyRef == nil:
+		return errors.New("script: configMapKeyRef or secretKeyRef should be set")
+	case s.ConfigMapKeyRef != nil && s.SecretKeyRef != nil:
+		return errors.New("script: only one of configMapKeyRef and secretKeyRef can be set")
+	case s.ConfigMapKeyRef != nil && (s.ConfigMapKeyRef.Name == "" || s.ConfigMapKeyRef.Key == ""):
+		return errors.New("script: configMapKeyRef name and key can't be empty")
+	case s.SecretKeyRef != nil && (s.SecretKeyRef.Name == "" || s.SecretKeyRef.Key == ""):
+		return errors.New("script: secretKeyRef name and key can't be empty")
+	}
+	return nil
+}
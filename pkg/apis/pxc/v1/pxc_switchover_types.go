@@ -0,0 +1,109 @@
+package v1
+
+import (
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PerconaXtraDBClusterSwitchoverSpec defines the desired state of PerconaXtraDBClusterSwitchover
+type PerconaXtraDBClusterSwitchoverSpec struct {
+	// SourceCluster is the cluster applications write to, it becomes the replica.
+	SourceCluster string `json:"sourceCluster"`
+	// ReplicaCluster replicates from SourceCluster, it becomes the source.
+	ReplicaCluster string `json:"replicaCluster"`
+	// SourcesList is used by SourceCluster to replicate from ReplicaCluster
+	// after the switchover. PXC pods of ReplicaCluster are used if it's empty.
+	SourcesList []ReplicationSource `json:"sourcesList,omitempty"`
+	// CatchUpTimeoutSeconds limits the time SourceCluster is read-only while
+	// ReplicaCluster applies the remaining transactions.
+	CatchUpTimeoutSeconds int32 `json:"catchUpTimeoutSeconds,omitempty"`
+	// ReplicationTimeoutSeconds limits the time to wait for SourceCluster
+	// to replicate from ReplicaCluster once the direction is reversed.
+	ReplicationTimeoutSeconds int32 `json:"replicationTimeoutSeconds,omitempty"`
+}
+
+type SwitchoverState string
+
+const (
+	SwitchoverStateNew        SwitchoverState = ""
+	SwitchoverStateFencing    SwitchoverState = "Fencing"
+	SwitchoverStateCatchingUp SwitchoverState = "CatchingUp"
+	SwitchoverStateReversing  SwitchoverState = "Reversing"
+	SwitchoverStateSucceeded  SwitchoverState = "Succeeded"
+	SwitchoverStateFailed     SwitchoverState = "Failed"
+)
+
+// PerconaXtraDBClusterSwitchoverStatus defines the observed state of PerconaXtraDBClusterSwitchover
+type PerconaXtraDBClusterSwitchoverStatus struct {
+	State   SwitchoverState `json:"state,omitempty"`
+	Message string          `json:"message,omitempty"`
+	// Channel is the replication channel between the clusters.
+	Channel string `json:"channel,omitempty"`
+	// SwitchoverGTID is the GTID set of the source at the moment it became read-only.
+	SwitchoverGTID     string       `json:"switchoverGTID,omitempty"`
+	StartedAt          *metav1.Time `json:"startedAt,omitempty"`
+	ReversedAt         *metav1.Time `json:"reversedAt,omitempty"`
+	CompletedAt        *metav1.Time `json:"completed,omitempty"`
+	ObservedGeneration int64        `json:"observedGeneration,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBClusterSwitchover is the Schema for the perconaxtradbclusterswitchovers API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName="pxc-switchover";"pxc-switchovers"
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.sourceCluster",description="Current source cluster name"
+// +kubebuilder:printcolumn:name="Replica",type="string",JSONPath=".spec.replicaCluster",description="Replica cluster name"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.state",description="Switchover status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PerconaXtraDBClusterSwitchover struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerconaXtraDBClusterSwitchoverSpec   `json:"spec,omitempty"`
+	Status PerconaXtraDBClusterSwitchoverStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBClusterSwitchoverList contains a list of PerconaXtraDBClusterSwitchover
+type PerconaXtraDBClusterSwitchoverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerconaXtraDBClusterSwitchover `json:"items"`
+}
+
+func (cr *PerconaXtraDBClusterSwitchover) CheckNSetDefaults() error {
+	if cr.Spec.SourceCluster == "" {
+		return errors.New("sourceCluster can't be empty")
+	}
+	if cr.Spec.ReplicaCluster == "" {
+		return errors.New("replicaCluster can't be empty")
+	}
+	if cr.Spec.ReplicaCluster == cr.Spec.SourceCluster {
+		return errors.New("replicaCluster should differ from sourceCluster")
+	}
+	for _, src := range cr.Spec.SourcesList {
+		if src.Host == "" {
+			return errors.New("sourcesList: host can't be empty")
+		}
+	}
+
+	for i := range cr.Spec.SourcesList {
+		if cr.Spec.SourcesList[i].Port == 0 {
+			cr.Spec.SourcesList[i].Port = 3306
+		}
+		if cr.Spec.SourcesList[i].Weight == 0 {
+			cr.Spec.SourcesList[i].Weight = 100
+		}
+	}
+	if cr.Spec.CatchUpTimeoutSeconds == 0 {
+		cr.Spec.CatchUpTimeoutSeconds = 300
+	}
+	if cr.Spec.ReplicationTimeoutSeconds == 0 {
+		cr.Spec.ReplicationTimeoutSeconds = 600
+	}
+
+	return nil
+}
@@ -4,8 +4,13 @@ package v1
 
 import (
 	"context"
+	"crypto/sha256"
+	"fmt"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
+	"time"
 
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/flosch/pongo2/v6"
@@ -13,12 +18,16 @@ import (
 	"github.com/go-logr/logr"
 	v "github.com/hashicorp/go-version"
 	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	"github.com/robfig/cron/v3"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
@@ -27,6 +36,8 @@ import (
 )
 
 // PerconaXtraDBClusterSpec defines the desired state of PerconaXtraDBCluster
+// +kubebuilder:validation:XValidation:rule="!(has(self.haproxy) && has(self.haproxy.enabled) && self.haproxy.enabled && has(self.proxysql) && has(self.proxysql.enabled) && self.proxysql.enabled)",message="haproxy and proxysql can't be enabled at the same time"
+// +kubebuilder:validation:XValidation:rule="!has(self.pxc) || !has(self.pxc.size) || self.pxc.size >= 3 || (has(self.pause) && self.pause) || (has(self.allowUnsafeConfigurations) && self.allowUnsafeConfigurations) || (has(self.unsafeFlags) && has(self.unsafeFlags.pxcSize) && self.unsafeFlags.pxcSize)",message="pxc.size must be at least 3, set unsafeFlags.pxcSize to disable this check"
 type PerconaXtraDBClusterSpec struct {
 	Platform               version.Platform                     `json:"platform,omitempty"`
 	CRVersion              string                               `json:"crVersion,omitempty"`
@@ -48,16 +59,442 @@ type PerconaXtraDBClusterSpec struct {
 	AllowUnsafeConfig      bool                                 `json:"allowUnsafeConfigurations,omitempty"`
 	Unsafe                 UnsafeFlags                          `json:"unsafeFlags,omitempty"`
 	VolumeExpansionEnabled bool                                 `json:"enableVolumeExpansion,omitempty"`
+	RequireChangesApproval bool                                 `json:"requireChangesApproval,omitempty"`
 
 	// Deprecated, should be removed in the future. Use InitContainer.Image instead
 	InitImage string `json:"initImage,omitempty"`
 
-	InitContainer             InitContainerSpec `json:"initContainer,omitempty"`
-	EnableCRValidationWebhook *bool             `json:"enableCRValidationWebhook,omitempty"`
-	IgnoreAnnotations         []string          `json:"ignoreAnnotations,omitempty"`
-	IgnoreLabels              []string          `json:"ignoreLabels,omitempty"`
+	InitContainer InitContainerSpec `json:"initContainer,omitempty"`
+	// +kubebuilder:default=false
+	EnableCRValidationWebhook *bool    `json:"enableCRValidationWebhook,omitempty"`
+	EnableCRDefaultingWebhook *bool    `json:"enableCRDefaultingWebhook,omitempty"`
+	IgnoreAnnotations         []string `json:"ignoreAnnotations,omitempty"`
+	IgnoreLabels              []string `json:"ignoreLabels,omitempty"`
+	// PropagateLabels are the keys of the labels of the cluster which are copied to its pods,
+	// statefulsets, services and PVCs. The objects are patched, so the changes don't restart the pods.
+	PropagateLabels []string `json:"propagateLabels,omitempty"`
+	// Suspend stops the operator from changing the cluster and its objects while the status is still
+	// reported, e.g. during manual interventions. Unlike Pause, the cluster keeps running.
+	Suspend bool `json:"suspend,omitempty"`
+	// ImageRegistry replaces the registry of every image the operator renders,
+	// e.g. registry.example.com/percona for air-gapped installations.
+	ImageRegistry string `json:"imageRegistry,omitempty"`
+	// ImagePullSecrets are added to every pod of the cluster after the pull secrets of its component.
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// Teardown is the policy of the percona.com/ordered-teardown finalizer.
+	Teardown *TeardownSpec `json:"teardown,omitempty"`
 
 	Users []User `json:"users,omitempty"`
+
+	VaultDBSecrets *VaultDBSecretsSpec `json:"vaultDBSecrets,omitempty"`
+
+	UsersSecretSource *UsersSecretSourceSpec `json:"usersSecretSource,omitempty"`
+
+	// ExternalUsersSecret means the users secret is owned by an external tool
+	// (e.g. External Secrets Operator or SealedSecrets). The operator never
+	// creates or modifies it and only reacts to password rotations.
+	ExternalUsersSecret bool `json:"externalUsersSecret,omitempty"`
+
+	AuditTrail *AuditTrailSpec `json:"auditTrail,omitempty"`
+
+	OperationsHistory *OperationsHistorySpec `json:"operationsHistory,omitempty"`
+
+	Monitoring *MonitoringSpec `json:"monitoring,omitempty"`
+
+	ConsistencyCheck *ConsistencyCheckSpec `json:"consistencyCheck,omitempty"`
+
+	Standby *StandbySpec `json:"standby,omitempty"`
+
+	QueryKiller *QueryKillerSpec `json:"queryKiller,omitempty"`
+
+	TableMaintenance *TableMaintenanceSpec `json:"tableMaintenance,omitempty"`
+
+	BinlogRetention *BinlogRetentionSpec `json:"binlogRetention,omitempty"`
+
+	Velero *VeleroSpec `json:"velero,omitempty"`
+
+	GitOps *GitOpsSpec `json:"gitOps,omitempty"`
+
+	// +kubebuilder:validation:Enum={istio,linkerd}
+	ServiceMesh ServiceMeshType `json:"serviceMesh,omitempty"`
+
+	NetworkPolicy *NetworkPolicySpec `json:"networkPolicy,omitempty"`
+}
+
+// ConsistencyCheckSpec schedules pt-table-checksum runs to catch silent replication drift.
+// On a source cluster the operator manages a CronJob writing checksums to percona.checksums,
+// the checksum queries are replicated to the async replicas as statements.
+// On a replica cluster the operator compares the replicated checksums and reports tables that differ.
+type ConsistencyCheckSpec struct {
+	Enabled  bool   `json:"enabled,omitempty"`
+	Schedule string `json:"schedule,omitempty"`
+	// Image with Percona Toolkit.
+	Image string `json:"image,omitempty"`
+	// Host is the PXC node the replicas replicate from, pod 0 by default.
+	// The checksums have to be calculated on it to reach the replicas as statements.
+	Host string `json:"host,omitempty"`
+	// Databases limits the check to the listed databases.
+	Databases []string `json:"databases,omitempty"`
+	// Args are extra pt-table-checksum arguments.
+	Args      []string                    `json:"args,omitempty"`
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+func (c *ConsistencyCheckSpec) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// StandbySpec keeps the cluster a warm standby of a cluster in another site
+// without network connectivity between them: the cluster is restored from the
+// latest backup of SourceCluster and periodically applies the binlogs the
+// source uploads for point-in-time recovery. The cluster is read-only while
+// the mode is enabled, disabling it promotes the cluster.
+type StandbySpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// SourceCluster is the name of the source cluster, its backups are named after it.
+	SourceCluster string `json:"sourceCluster,omitempty"`
+	// StorageName is the storage in backup.storages the source cluster uploads backups to.
+	StorageName string `json:"storageName,omitempty"`
+	// BinlogStorageName is the storage in backup.storages the source cluster uploads binlogs to.
+	BinlogStorageName string `json:"binlogStorageName,omitempty"`
+	// ApplyIntervalSeconds is the time between binlog applies.
+	// +kubebuilder:default=60
+	ApplyIntervalSeconds int32 `json:"applyIntervalSeconds,omitempty"`
+	// MaxLagSeconds is the allowed time since the last successful apply.
+	// Once it's exceeded the cluster is rebuilt from a newer backup of the source if there is one.
+	// +kubebuilder:default=900
+	MaxLagSeconds int32 `json:"maxLagSeconds,omitempty"`
+}
+
+func (s *StandbySpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// QueryKillerSpec protects the cluster from runaway queries. The operator periodically
+// checks the processlist of ready PXC nodes and kills queries running longer than
+// allowed for the user and connections idle in an open transaction.
+type QueryKillerSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// IntervalSeconds is the time between processlist checks, 10 by default.
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+	// Rules limit the query runtime, the first rule matching the query applies.
+	Rules []QueryKillerRule `json:"rules,omitempty"`
+	// IdleTransactionTimeoutSeconds is the time a connection can stay idle
+	// with an open transaction before it's killed, 0 disables the check.
+	IdleTransactionTimeoutSeconds int32 `json:"idleTransactionTimeoutSeconds,omitempty"`
+	// DryRun only records events for the queries that would be killed.
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// QueryKillerRule is the maximum query runtime of a class of users.
+type QueryKillerRule struct {
+	Name string `json:"name,omitempty"`
+	// Users the rule applies to, all users except the system ones if empty.
+	Users               []string `json:"users,omitempty"`
+	MaxQueryTimeSeconds int32    `json:"maxQueryTimeSeconds"`
+	// Match is a regular expression the query text has to match, e.g. ^SELECT.
+	Match string `json:"match,omitempty"`
+}
+
+func (q *QueryKillerSpec) IsEnabled() bool {
+	return q != nil && q.Enabled
+}
+
+// TableMaintenanceSpec schedules ANALYZE TABLE, OPTIMIZE TABLE and histogram refresh
+// for the tables of Databases. A run goes through the PXC pods except the writer one by one,
+// the statements are executed with the RSU method, so they aren't replicated and desync only
+// the pod running them. Runs are started within the maintenance windows of upgradeOptions.
+type TableMaintenanceSpec struct {
+	Enabled   bool     `json:"enabled,omitempty"`
+	Schedule  string   `json:"schedule,omitempty"`
+	Databases []string `json:"databases,omitempty"`
+	// Operations are executed in the listed order, analyze by default.
+	Operations []TableMaintenanceOperation `json:"operations,omitempty"`
+	Resources  corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+type TableMaintenanceOperation string
+
+const (
+	TableMaintenanceAnalyze  TableMaintenanceOperation = "analyze"
+	TableMaintenanceOptimize TableMaintenanceOperation = "optimize"
+	// TableMaintenanceHistograms updates the existing histograms keeping their number of buckets.
+	TableMaintenanceHistograms TableMaintenanceOperation = "histograms"
+)
+
+func (m *TableMaintenanceSpec) IsEnabled() bool {
+	return m != nil && m.Enabled
+}
+
+func (m *TableMaintenanceSpec) checkNSetDefaults() error {
+	if _, err := cron.ParseStandard(m.Schedule); err != nil {
+		return errors.Wrapf(err, "invalid schedule %s", m.Schedule)
+	}
+	if len(m.Databases) == 0 {
+		return errors.New("databases can't be empty")
+	}
+	for _, db := range m.Databases {
+		if !dbIdentifierRegexp.MatchString(db) {
+			return errors.Errorf("invalid database %q", db)
+		}
+	}
+	if len(m.Operations) == 0 {
+		m.Operations = []TableMaintenanceOperation{TableMaintenanceAnalyze}
+	}
+	for _, op := range m.Operations {
+		switch op {
+		case TableMaintenanceAnalyze, TableMaintenanceOptimize, TableMaintenanceHistograms:
+		default:
+			return errors.Errorf("unknown operation %s", op)
+		}
+	}
+	return nil
+}
+
+func (q *QueryKillerSpec) checkNSetDefaults() error {
+	if q.IntervalSeconds == 0 {
+		q.IntervalSeconds = 10
+	}
+	if q.IntervalSeconds < 0 || q.IdleTransactionTimeoutSeconds < 0 {
+		return errors.New("intervalSeconds and idleTransactionTimeoutSeconds can't be negative")
+	}
+	if len(q.Rules) == 0 && q.IdleTransactionTimeoutSeconds == 0 {
+		return errors.New("at least one rule or idleTransactionTimeoutSeconds is required")
+	}
+	for i, r := range q.Rules {
+		if r.MaxQueryTimeSeconds <= 0 {
+			return errors.Errorf("rules[%d]: maxQueryTimeSeconds must be positive", i)
+		}
+		if _, err := regexp.Compile(r.Match); err != nil {
+			return errors.Wrapf(err, "rules[%d]: invalid match", i)
+		}
+	}
+	return nil
+}
+
+// BinlogRetentionSpec makes the operator purge the binary logs of the PXC nodes.
+// Binary logs are purged only once they are older than Seconds, uploaded by the
+// PITR collector and not needed by the replicas of the cluster. If it can't be
+// determined that binary logs aren't needed anymore, purging is held.
+type BinlogRetentionSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Seconds is the time binary logs are kept at least.
+	Seconds int64 `json:"seconds"`
+	// IntervalSeconds is the time between purges, 300 by default.
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+}
+
+func (b *BinlogRetentionSpec) IsEnabled() bool {
+	return b != nil && b.Enabled
+}
+
+func (b *BinlogRetentionSpec) checkNSetDefaults() error {
+	if b.Seconds <= 0 {
+		return errors.New("seconds must be positive")
+	}
+	if b.IntervalSeconds == 0 {
+		b.IntervalSeconds = 300
+	}
+	if b.IntervalSeconds < 0 {
+		return errors.New("intervalSeconds can't be negative")
+	}
+	return nil
+}
+
+// VeleroSpec prepares the cluster to be protected by Velero.
+// PXC pods get backup hooks desyncing the node and flushing tables while
+// their volumes are snapshotted, and the objects the operator derives from
+// the cluster (statefulsets, services, disruption budgets) are excluded from
+// backups. When the cluster is restored by Velero, the operator deletes the
+// restored PXC pods so that they are recreated by the statefulset and the
+// cluster is bootstrapped from the most advanced node.
+type VeleroSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// HookTimeoutSeconds is the time the backup hooks are waited for, 300 by default.
+	HookTimeoutSeconds int32 `json:"hookTimeoutSeconds,omitempty"`
+}
+
+func (v *VeleroSpec) IsEnabled() bool {
+	return v != nil && v.Enabled
+}
+
+func (v *VeleroSpec) checkNSetDefaults() error {
+	if v.HookTimeoutSeconds == 0 {
+		v.HookTimeoutSeconds = 300
+	}
+	if v.HookTimeoutSeconds < 0 {
+		return errors.New("hookTimeoutSeconds can't be negative")
+	}
+	return nil
+}
+
+// GitOpsSpec marks the spec of the cluster as owned by a GitOps tool.
+// The operator doesn't write the spec then: the changes it would make (the
+// default crVersion, images recommended by the version service, the volume size
+// reverted after a failed resize, pausing the cluster to toggle TLS) are published
+// in status.gitOps.suggestedChanges to be committed to the repository instead.
+// Promotions done by the replication failover policy are still written to the spec.
+// Restores, blue-green upgrades and switchovers change the spec in several steps
+// and are rejected for such clusters.
+type GitOpsSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+func (g *GitOpsSpec) IsEnabled() bool {
+	return g != nil && g.Enabled
+}
+
+// ServiceMeshType is the service mesh injecting proxy sidecars into the pods of the cluster.
+// The pods are annotated to keep the Galera replication, SST and IST traffic out of the
+// proxy and its mTLS, to start the database containers after the proxy and to rewrite
+// HTTP probes of the sidecars. Backup and restore jobs stop the proxy when they exit,
+// otherwise the jobs never complete.
+type ServiceMeshType string
+
+const (
+	ServiceMeshIstio   ServiceMeshType = "istio"
+	ServiceMeshLinkerd ServiceMeshType = "linkerd"
+)
+
+// NetworkPolicySpec makes the operator generate the NetworkPolicies of the cluster pods.
+// The policies allow the Galera replication and SST between the PXC pods, mysql
+// connections from the pods of the cluster (proxies, backup and restore jobs) and the
+// operator, client connections to the proxies and the exposed services, and scrapes
+// of the metrics ports. Other ingress traffic is denied. Egress isn't restricted.
+type NetworkPolicySpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// OperatorNamespace is the namespace of the operator, the namespace of the operator pod by default.
+	OperatorNamespace string `json:"operatorNamespace,omitempty"`
+	// AdditionalIngress rules are added to the policies of the components.
+	AdditionalIngress NetworkPolicyIngress `json:"additionalIngress,omitempty"`
+}
+
+func (n *NetworkPolicySpec) IsEnabled() bool {
+	return n != nil && n.Enabled
+}
+
+type NetworkPolicyIngress struct {
+	PXC      []networkingv1.NetworkPolicyIngressRule `json:"pxc,omitempty"`
+	HAProxy  []networkingv1.NetworkPolicyIngressRule `json:"haproxy,omitempty"`
+	ProxySQL []networkingv1.NetworkPolicyIngressRule `json:"proxysql,omitempty"`
+}
+
+// MonitoringSpec configures monitoring objects provisioned together with the cluster.
+type MonitoringSpec struct {
+	PrometheusRule *PrometheusRuleSpec `json:"prometheusRule,omitempty"`
+	Dashboard      *DashboardSpec      `json:"dashboard,omitempty"`
+	ServiceMonitor *ServiceMonitorSpec `json:"serviceMonitor,omitempty"`
+}
+
+// ServiceMonitorSpec makes the operator create prometheus-operator PodMonitors
+// scraping the HAProxy and ProxySQL stats and the binlog collector metrics.
+// The mysqld_exporter sidecars are scraped by the PodMonitor of pxc.metrics,
+// the labels, interval and TLS configuration are applied to it as well.
+// PMM clients are scraped by PMM Server and aren't monitored.
+type ServiceMonitorSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Labels are added to the PodMonitors to match Prometheus podMonitorSelector.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Interval is the scrape interval, Prometheus default is used if it's empty.
+	Interval string `json:"interval,omitempty"`
+	// TLS configures the scrapes of mysqld_exporter serving metrics over HTTPS,
+	// e.g. with --web.config.file in pxc.metrics.args.
+	TLS *MetricsTLSSpec `json:"tls,omitempty"`
+}
+
+func (s *ServiceMonitorSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+type MetricsTLSSpec struct {
+	// CA is the secret key with the CA certificate of the exporter.
+	CA                 *corev1.SecretKeySelector `json:"ca,omitempty"`
+	ServerName         string                    `json:"serverName,omitempty"`
+	InsecureSkipVerify bool                      `json:"insecureSkipVerify,omitempty"`
+}
+
+// PrometheusRuleSpec configures alerts based on operator metrics.
+// Thresholds are Prometheus durations, e.g. 25h.
+type PrometheusRuleSpec struct {
+	Enabled bool              `json:"enabled,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	// BackupMaxAge is the age of the latest successful backup after which the alert is fired.
+	// +kubebuilder:default="25h"
+	BackupMaxAge string `json:"backupMaxAge,omitempty"`
+	// PITRMaxLag is the allowed lag of binlogs uploaded by the binlog collector.
+	// +kubebuilder:default="15m"
+	PITRMaxLag string `json:"pitrMaxLag,omitempty"`
+	// CertExpiryWarning is the time before TLS certificates expiration when the alert is fired.
+	// +kubebuilder:default="168h"
+	CertExpiryWarning string `json:"certExpiryWarning,omitempty"`
+}
+
+func (p *PrometheusRuleSpec) IsEnabled() bool {
+	return p != nil && p.Enabled
+}
+
+func (p *PrometheusRuleSpec) validate() error {
+	for name, d := range map[string]string{
+		"backupMaxAge":      p.BackupMaxAge,
+		"pitrMaxLag":        p.PITRMaxLag,
+		"certExpiryWarning": p.CertExpiryWarning,
+	} {
+		if d == "" {
+			continue
+		}
+		if _, err := model.ParseDuration(d); err != nil {
+			return errors.Wrapf(err, "invalid %s", name)
+		}
+	}
+	return nil
+}
+
+// DashboardSpec configures the config map with Grafana dashboard.
+// Labels should match the label used by Grafana dashboards sidecar.
+type DashboardSpec struct {
+	Enabled bool              `json:"enabled,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+func (d *DashboardSpec) IsEnabled() bool {
+	return d != nil && d.Enabled
+}
+
+// AuditTrailSpec configures where credential and privilege changes are reported.
+// Kubernetes events are always recorded, the webhook is optional.
+type AuditTrailSpec struct {
+	Webhook *AuditWebhookSpec `json:"webhook,omitempty"`
+}
+
+// OperationsHistorySpec keeps the history of backups, restores, upgrades, failovers and crash
+// recoveries of the cluster in a ConfigMap, so it survives the deletion of the backup and restore objects.
+type OperationsHistorySpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Limit is the number of the latest entries kept, 100 by default.
+	Limit int32 `json:"limit,omitempty"`
+}
+
+const DefaultOperationsHistoryLimit = 100
+
+func (h *OperationsHistorySpec) IsEnabled() bool {
+	return h != nil && h.Enabled
+}
+
+func (h *OperationsHistorySpec) checkNSetDefaults() error {
+	if h.Limit == 0 {
+		h.Limit = DefaultOperationsHistoryLimit
+	}
+	if h.Limit < 0 {
+		return errors.New("limit can't be negative")
+	}
+	return nil
+}
+
+type AuditWebhookSpec struct {
+	URL string `json:"url,omitempty"`
+	// AuthSecretName is a secret with the bearer token in the token key
+	AuthSecretName string `json:"authSecretName,omitempty"`
+	TLSSkipVerify  bool   `json:"tlsSkipVerify,omitempty"`
 }
 
 type SecretKeySelector struct {
@@ -74,6 +511,70 @@ type User struct {
 	WithGrantOption   bool               `json:"withGrantOption,omitempty"`
 }
 
+// VaultDBSecretsSpec configures the Vault database secrets engine
+// to issue short-lived MySQL credentials for the cluster.
+type VaultDBSecretsSpec struct {
+	Enabled        bool               `json:"enabled,omitempty"`
+	Address        string             `json:"address,omitempty"`
+	TokenSecretRef *SecretKeySelector `json:"tokenSecretRef,omitempty"`
+	// +kubebuilder:default=database
+	MountPath      string        `json:"mountPath,omitempty"`
+	ConnectionName string        `json:"connectionName,omitempty"`
+	TLSSkipVerify  bool          `json:"tlsSkipVerify,omitempty"`
+	Roles          []VaultDBRole `json:"roles,omitempty"`
+}
+
+type VaultDBRole struct {
+	Name                 string   `json:"name"`
+	CreationStatements   []string `json:"creationStatements,omitempty"`
+	RevocationStatements []string `json:"revocationStatements,omitempty"`
+	DefaultTTL           string   `json:"defaultTTL,omitempty"`
+	MaxTTL               string   `json:"maxTTL,omitempty"`
+}
+
+func (v *VaultDBSecretsSpec) IsEnabled() bool {
+	return v != nil && v.Enabled
+}
+
+type SecretSourceProvider string
+
+const (
+	SecretSourceAWS   SecretSourceProvider = "aws"
+	SecretSourceGCP   SecretSourceProvider = "gcp"
+	SecretSourceAzure SecretSourceProvider = "azure"
+)
+
+// UsersSecretSourceSpec points to a secret in a cloud secret manager
+// which is the source of truth for the users secret. The secret value
+// must be a JSON object of user names and passwords.
+type UsersSecretSourceSpec struct {
+	Provider SecretSourceProvider     `json:"provider,omitempty"`
+	AWS      *AWSSecretsManagerSource `json:"aws,omitempty"`
+	GCP      *GCPSecretManagerSource  `json:"gcp,omitempty"`
+	Azure    *AzureKeyVaultSource     `json:"azure,omitempty"`
+	// +kubebuilder:default="5m"
+	RefreshInterval *metav1.Duration `json:"refreshInterval,omitempty"`
+}
+
+type AWSSecretsManagerSource struct {
+	Region       string `json:"region,omitempty"`
+	SecretID     string `json:"secretId,omitempty"`
+	VersionStage string `json:"versionStage,omitempty"`
+}
+
+type GCPSecretManagerSource struct {
+	Project string `json:"project,omitempty"`
+	Secret  string `json:"secret,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+type AzureKeyVaultSource struct {
+	VaultURL      string `json:"vaultURL,omitempty"`
+	SecretName    string `json:"secretName,omitempty"`
+	SecretVersion string `json:"secretVersion,omitempty"`
+	ClientID      string `json:"clientID,omitempty"`
+}
+
 type UnsafeFlags struct {
 	TLS               bool `json:"tls,omitempty"`
 	PXCSize           bool `json:"pxcSize,omitempty"`
@@ -88,12 +589,658 @@ type InitContainerSpec struct {
 }
 
 type PXCSpec struct {
-	AutoRecovery        *bool                `json:"autoRecovery,omitempty"`
-	ReplicationChannels []ReplicationChannel `json:"replicationChannels,omitempty"`
-	Expose              ServiceExpose        `json:"expose,omitempty"`
+	AutoRecovery        *bool                    `json:"autoRecovery,omitempty"`
+	ReplicationChannels []ReplicationChannel     `json:"replicationChannels,omitempty"`
+	ExternalReplication *ExternalReplicationSpec `json:"externalReplication,omitempty"`
+	Reporting           *ReportingSpec           `json:"reporting,omitempty"`
+	Expose              ServiceExpose            `json:"expose,omitempty"`
+	Authentication      *AuthenticationSpec      `json:"authentication,omitempty"`
+	PasswordPolicy      *PasswordPolicySpec      `json:"passwordPolicy,omitempty"`
+	Metrics             *MetricsSpec             `json:"metrics,omitempty"`
+	EvictionProtection  *EvictionProtectionSpec  `json:"evictionProtection,omitempty"`
+	StorageLocality     *StorageLocalitySpec     `json:"storageLocality,omitempty"`
+	VolumeOverrides     []PodVolumeOverride      `json:"volumeOverrides,omitempty"`
+	DataVolumes         *DataVolumesSpec         `json:"dataVolumes,omitempty"`
+	SST                 *SSTSpec                 `json:"sst,omitempty"`
+	Diagnostics         *DiagnosticsSpec         `json:"diagnostics,omitempty"`
+	GaleraSegments      *GaleraSegmentsSpec      `json:"galeraSegments,omitempty"`
+	ProviderOptions     *ProviderOptionsSpec     `json:"providerOptions,omitempty"`
+	GracefulShutdown    *GracefulShutdownSpec    `json:"gracefulShutdown,omitempty"`
 	*PodSpec            `json:",inline"`
 }
 
+// GracefulShutdownSpec makes the PXC pods drain before mysqld is stopped, whether the pod is deleted
+// by a scale down, an upgrade or a restore. The preStop hook of the pod puts the node in
+// pxc_maint_mode=MAINTENANCE, so HAProxy and ProxySQL remove it from their pools, waits for
+// the received write-sets to be applied and optionally flushes the tables, which shortens
+// the IST of the node when it's back and the client errors during the routine operations.
+// The steps are bounded by their timeouts, which have to fit in the grace period of the pods.
+type GracefulShutdownSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// DrainSeconds is how long the node stays in maintenance mode before it's stopped,
+	// so the proxies notice it and the clients finish their transactions, 10 by default.
+	DrainSeconds int32 `json:"drainSeconds,omitempty"`
+	// RecvQueueTimeoutSeconds is the longest wait for wsrep_local_recv_queue to be empty, 60 by default.
+	RecvQueueTimeoutSeconds int32 `json:"recvQueueTimeoutSeconds,omitempty"`
+	// FlushTables runs FLUSH TABLES before mysqld is stopped.
+	FlushTables bool `json:"flushTables,omitempty"`
+	// FlushTablesTimeoutSeconds is the longest wait for FLUSH TABLES, 30 by default.
+	FlushTablesTimeoutSeconds int32 `json:"flushTablesTimeoutSeconds,omitempty"`
+}
+
+func (g *GracefulShutdownSpec) IsEnabled() bool {
+	return g != nil && g.Enabled
+}
+
+func (g *GracefulShutdownSpec) checkNSetDefaults(podSpec *PodSpec) error {
+	if g.DrainSeconds == 0 {
+		g.DrainSeconds = 10
+	}
+	if g.RecvQueueTimeoutSeconds == 0 {
+		g.RecvQueueTimeoutSeconds = 60
+	}
+	if g.FlushTablesTimeoutSeconds == 0 {
+		g.FlushTablesTimeoutSeconds = 30
+	}
+	if g.DrainSeconds < 0 || g.RecvQueueTimeoutSeconds < 0 || g.FlushTablesTimeoutSeconds < 0 {
+		return errors.New("drainSeconds, recvQueueTimeoutSeconds and flushTablesTimeoutSeconds can't be negative")
+	}
+
+	if podSpec.Lifecycle.PreStop != nil {
+		return errors.New("can't be used with lifecycle.preStop")
+	}
+
+	total := int64(g.DrainSeconds) + int64(g.RecvQueueTimeoutSeconds)
+	if g.FlushTables {
+		total += int64(g.FlushTablesTimeoutSeconds)
+	}
+	grace := defaultPXCGracePeriodSec
+	if podSpec.TerminationGracePeriodSeconds != nil {
+		grace = *podSpec.TerminationGracePeriodSeconds
+	}
+	if total >= grace {
+		return errors.Errorf("timeouts take %ds, which doesn't fit in the grace period of %ds", total, grace)
+	}
+	return nil
+}
+
+// ProviderOptionsSpec are the common wsrep_provider_options of the PXC nodes, validated by the operator
+// instead of being left to mysqld failing on start. The options are merged into the provider options
+// of the nodes when mysqld starts. The changes of gcs.fc_limit are applied to the running nodes,
+// the changes of the other options restart the pods.
+// wsrep_provider_options of the configuration replaces the whole option, so it can't be used together with them.
+type ProviderOptionsSpec struct {
+	// GCacheSize is gcache.size, the size of the write-set cache the joiners get IST from.
+	GCacheSize *resource.Quantity `json:"gcacheSize,omitempty"`
+	// GCSFCLimit is gcs.fc_limit, the number of write-sets in the receive queue which pauses replication.
+	GCSFCLimit *int32 `json:"gcsFcLimit,omitempty"`
+	// EVS are the timeouts the group membership of the cluster is detected with.
+	EVS *EVSTimeouts `json:"evs,omitempty"`
+}
+
+// EVSTimeouts are the evs.* timeouts of the group communication. A node which isn't heard of for
+// suspectTimeout is suspected to be down and it's dropped when all nodes suspect it, or after inactiveTimeout.
+type EVSTimeouts struct {
+	// KeepalivePeriod is evs.keepalive_period, 1s by default.
+	KeepalivePeriod *metav1.Duration `json:"keepalivePeriod,omitempty"`
+	// InactiveCheckPeriod is evs.inactive_check_period, 0.5s by default.
+	InactiveCheckPeriod *metav1.Duration `json:"inactiveCheckPeriod,omitempty"`
+	// SuspectTimeout is evs.suspect_timeout, 5s by default.
+	SuspectTimeout *metav1.Duration `json:"suspectTimeout,omitempty"`
+	// InactiveTimeout is evs.inactive_timeout, 15s by default.
+	InactiveTimeout *metav1.Duration `json:"inactiveTimeout,omitempty"`
+	// InstallTimeout is evs.install_timeout, 7.5s by default.
+	InstallTimeout *metav1.Duration `json:"installTimeout,omitempty"`
+}
+
+func (p *ProviderOptionsSpec) validate() error {
+	if p.GCacheSize != nil && p.GCacheSize.Sign() <= 0 {
+		return errors.New("gcacheSize should be positive")
+	}
+	if p.GCSFCLimit != nil && *p.GCSFCLimit <= 0 {
+		return errors.New("gcsFcLimit should be positive")
+	}
+	if p.EVS == nil {
+		return nil
+	}
+
+	timeout := func(d *metav1.Duration, def time.Duration) time.Duration {
+		if d == nil {
+			return def
+		}
+		return d.Duration
+	}
+	keepalive := timeout(p.EVS.KeepalivePeriod, time.Second)
+	check := timeout(p.EVS.InactiveCheckPeriod, 500*time.Millisecond)
+	suspect := timeout(p.EVS.SuspectTimeout, 5*time.Second)
+	inactive := timeout(p.EVS.InactiveTimeout, 15*time.Second)
+	install := timeout(p.EVS.InstallTimeout, 7500*time.Millisecond)
+
+	for name, d := range map[string]time.Duration{
+		"keepalivePeriod":     keepalive,
+		"inactiveCheckPeriod": check,
+		"suspectTimeout":      suspect,
+		"inactiveTimeout":     inactive,
+		"installTimeout":      install,
+	} {
+		if d <= 0 {
+			return errors.Errorf("evs.%s should be positive", name)
+		}
+	}
+	if keepalive >= suspect || check >= suspect {
+		return errors.New("evs.keepalivePeriod and evs.inactiveCheckPeriod should be shorter than evs.suspectTimeout")
+	}
+	if suspect > inactive {
+		return errors.New("evs.suspectTimeout can't be longer than evs.inactiveTimeout")
+	}
+	return nil
+}
+
+var providerOptionNameRegexp = regexp.MustCompile(`^[a-z_]+(\.[a-z0-9_]+)*$`)
+
+// validateConfigProviderOptions checks the syntax of wsrep_provider_options in the configuration,
+// since a mistyped option makes the nodes fail to start.
+func validateConfigProviderOptions(configuration string, structured bool) error {
+	file, err := ini.LoadSources(ini.LoadOptions{AllowBooleanKeys: true, IgnoreInlineComment: true}, []byte(configuration))
+	if err != nil {
+		// the configuration isn't always parsed as ini, e.g. with !include directives
+		return nil
+	}
+	s, err := file.GetSection("mysqld")
+	if err != nil || !s.HasKey("wsrep_provider_options") {
+		return nil
+	}
+	if structured {
+		return errors.New("wsrep_provider_options of configuration can't be used with providerOptions")
+	}
+
+	value := strings.Trim(s.Key("wsrep_provider_options").String(), `"'`)
+	for _, opt := range strings.Split(value, ";") {
+		opt = strings.TrimSpace(opt)
+		if opt == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(opt, "=")
+		name = strings.TrimSpace(name)
+		// a value with "=" is most likely a missing ";"
+		if !ok || !providerOptionNameRegexp.MatchString(name) || strings.Contains(value, "=") {
+			return errors.Errorf("wsrep_provider_options: invalid option %q, options should be separated with ';' and look like name=value", opt)
+		}
+	}
+	return nil
+}
+
+// GaleraSegmentsSpec makes the operator set gmcast.segment of the PXC pods from the topology label
+// of their nodes, so the members in the same zone or region replicate the write-sets to each other
+// and only one member of a segment relays them across segments.
+// The values of the label are given the segments of the segments map or the next free segments
+// in the order they are seen, the assigned segments are kept when the pods move.
+// The segment of a pod is applied when the pod starts, so the pods moved to another zone pick up
+// their new segment after a restart. The operator needs permissions to get nodes, without them
+// only the overrides are applied. gmcast.segment set in wsrep_provider_options of the configuration
+// takes precedence.
+type GaleraSegmentsSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// TopologyKey is the node label the segments are assigned by, topology.kubernetes.io/zone by default.
+	TopologyKey string `json:"topologyKey,omitempty"`
+	// Segments maps the values of the topology label to the segments.
+	Segments map[string]int32 `json:"segments,omitempty"`
+	// Overrides are the segments of the pods with the ordinals regardless of their nodes,
+	// e.g. the members of a stretch cluster whose nodes aren't labeled.
+	Overrides []PodSegmentOverride `json:"overrides,omitempty"`
+}
+
+// PodSegmentOverride is the gmcast.segment of the PXC pod with the ordinal.
+type PodSegmentOverride struct {
+	Ordinal int32 `json:"ordinal"`
+	Segment int32 `json:"segment"`
+}
+
+// MaxGaleraSegment is the largest gmcast.segment value.
+const MaxGaleraSegment = 255
+
+func (g *GaleraSegmentsSpec) IsEnabled() bool {
+	return g != nil && g.Enabled
+}
+
+// Override returns the segment override of the pod with the ordinal, nil if there is none.
+func (g *GaleraSegmentsSpec) Override(ordinal int32) *PodSegmentOverride {
+	for i := range g.Overrides {
+		if g.Overrides[i].Ordinal == ordinal {
+			return &g.Overrides[i]
+		}
+	}
+	return nil
+}
+
+func (g *GaleraSegmentsSpec) checkNSetDefaults() error {
+	if g.TopologyKey == "" {
+		g.TopologyKey = corev1.LabelTopologyZone
+	}
+
+	for value, segment := range g.Segments {
+		if segment < 0 || segment > MaxGaleraSegment {
+			return errors.Errorf("segment of %s should be between 0 and %d", value, MaxGaleraSegment)
+		}
+	}
+
+	ordinals := make(map[int32]struct{}, len(g.Overrides))
+	for _, o := range g.Overrides {
+		if o.Ordinal < 0 {
+			return errors.Errorf("ordinal %d can't be negative", o.Ordinal)
+		}
+		if _, ok := ordinals[o.Ordinal]; ok {
+			return errors.Errorf("ordinal %d is overridden more than once", o.Ordinal)
+		}
+		ordinals[o.Ordinal] = struct{}{}
+		if o.Segment < 0 || o.Segment > MaxGaleraSegment {
+			return errors.Errorf("segment of ordinal %d should be between 0 and %d", o.Ordinal, MaxGaleraSegment)
+		}
+	}
+	return nil
+}
+
+// DiagnosticsSpec makes the operator collect a diagnostic bundle when a PXC pod crash-loops or
+// the cluster loses quorum, so the state of the failure is kept for support escalations.
+// The bundle is a tar.gz archive with the tails of the logs of the PXC pods, the history of their
+// wsrep status, the recent events of the cluster and optionally the latest core dump of the crashed pod.
+// It's uploaded to a backup storage or saved to a PVC and linked from the DiagnosticBundle condition.
+type DiagnosticsSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// StorageName is the S3 or Azure backup storage the bundles are uploaded to.
+	StorageName string `json:"storageName,omitempty"`
+	// PersistentVolumeClaim is the spec of the PVC the bundles are saved to if storageName isn't set.
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimSpec `json:"persistentVolumeClaim,omitempty"`
+	// LogLines is the number of the last lines of the logs of each PXC pod, 1000 by default.
+	LogLines int64 `json:"logLines,omitempty"`
+	// CoreDumps adds the latest core dump of the crashed pod, it requires storageName.
+	CoreDumps bool `json:"coreDumps,omitempty"`
+	// IntervalSeconds is the minimal time between the bundles, 3600 by default.
+	IntervalSeconds int64 `json:"intervalSeconds,omitempty"`
+	// Keep is the number of the kept bundles, 5 by default.
+	Keep int32 `json:"keep,omitempty"`
+}
+
+func (d *DiagnosticsSpec) IsEnabled() bool {
+	return d != nil && d.Enabled
+}
+
+func (d *DiagnosticsSpec) checkNSetDefaults(backup *PXCScheduledBackup) error {
+	if d.LogLines == 0 {
+		d.LogLines = 1000
+	}
+	if d.IntervalSeconds == 0 {
+		d.IntervalSeconds = 3600
+	}
+	if d.Keep == 0 {
+		d.Keep = 5
+	}
+	if d.LogLines < 0 || d.IntervalSeconds < 0 || d.Keep < 0 {
+		return errors.New("logLines, intervalSeconds and keep can't be negative")
+	}
+
+	if d.StorageName == "" {
+		if d.PersistentVolumeClaim == nil {
+			return errors.New("storageName or persistentVolumeClaim should be specified")
+		}
+		if d.CoreDumps {
+			return errors.New("coreDumps require storageName")
+		}
+		return nil
+	}
+	if backup == nil {
+		return errors.Errorf("storage %s doesn't exist", d.StorageName)
+	}
+	stg, ok := backup.Storages[d.StorageName]
+	if !ok {
+		return errors.Errorf("storage %s doesn't exist", d.StorageName)
+	}
+	if stg.Type != BackupStorageS3 && stg.Type != BackupStorageAzure {
+		return errors.Errorf("storage %s should be s3 or azure", d.StorageName)
+	}
+	return nil
+}
+
+// DataVolumesSpec moves the files of mysqld out of the datadir to separate PVCs, so the IO of
+// the binary logs, temporary files and InnoDB logs can use storage classes of their own.
+// The operator mounts the volumes to the PXC pods and sets the paths in my.cnf.
+// The volumes are added to the volume claim templates of the statefulset and the paths of
+// the existing files aren't moved, so they can't be changed on a running cluster.
+// The joiners get the files on their volumes with SST, the restore job writes them to the datadir.
+type DataVolumesSpec struct {
+	// Binlog is the volume of the binary logs, log_bin.
+	Binlog *corev1.PersistentVolumeClaimSpec `json:"binlog,omitempty"`
+	// Tmpdir is the volume of the temporary files, tmpdir.
+	Tmpdir *corev1.PersistentVolumeClaimSpec `json:"tmpdir,omitempty"`
+	// InnoDBLogs is the volume of the redo and undo logs, innodb_log_group_home_dir and innodb_undo_directory.
+	InnoDBLogs *corev1.PersistentVolumeClaimSpec `json:"innodbLogs,omitempty"`
+}
+
+// changed returns true if a volume is added, removed, resized or moved to another storage class.
+func (d *DataVolumesSpec) changed(old *DataVolumesSpec) bool {
+	if d == nil {
+		d = new(DataVolumesSpec)
+	}
+	if old == nil {
+		old = new(DataVolumesSpec)
+	}
+	changed := func(spec, old *corev1.PersistentVolumeClaimSpec) bool {
+		if spec == nil || old == nil {
+			return spec != old
+		}
+		size, oldSize := spec.Resources.Requests[corev1.ResourceStorage], old.Resources.Requests[corev1.ResourceStorage]
+		sc, oldSC := spec.StorageClassName, old.StorageClassName
+		return size.Cmp(oldSize) != 0 || (sc == nil) != (oldSC == nil) || (sc != nil && *sc != *oldSC)
+	}
+	return changed(d.Binlog, old.Binlog) || changed(d.Tmpdir, old.Tmpdir) || changed(d.InnoDBLogs, old.InnoDBLogs)
+}
+
+func (d *DataVolumesSpec) validate() error {
+	for name, spec := range map[string]*corev1.PersistentVolumeClaimSpec{
+		"binlog":     d.Binlog,
+		"tmpdir":     d.Tmpdir,
+		"innodbLogs": d.InnoDBLogs,
+	} {
+		if spec == nil {
+			continue
+		}
+		if _, ok := spec.Resources.Requests[corev1.ResourceStorage]; !ok {
+			return errors.Errorf("%s.resources.requests.storage can't be empty", name)
+		}
+		if len(spec.AccessModes) == 0 {
+			spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+		}
+	}
+	return nil
+}
+
+// SSTSpec tunes the xtrabackup SST, which copies the whole datadir from the donor to a joiner,
+// e.g. a node rejoining after its volume was lost. The options are written to the [sst] and [xtrabackup]
+// groups of my.cnf, the SST script reads them on every SST, so changes don't restart the pods.
+// The backups are streamed from the donor by SST too, so they are compressed with the same options.
+type SSTSpec struct {
+	// Parallel is the number of threads copying the files on the donor and extracting them on the joiner.
+	// +kubebuilder:validation:Minimum=1
+	Parallel int32 `json:"parallel,omitempty"`
+	// Compressor compresses the stream on the donor, the joiner decompresses it.
+	// +kubebuilder:validation:Enum=lz4;zstd
+	Compressor SSTCompressor `json:"compressor,omitempty"`
+	// CompressThreads is the number of threads compressing and decompressing the stream, Parallel by default.
+	// +kubebuilder:validation:Minimum=1
+	CompressThreads int32 `json:"compressThreads,omitempty"`
+	// Encrypt is false to stream the data unencrypted, e.g. inside a trusted network where TLS costs too much
+	// for multi-terabyte datadirs. The stream is encrypted with the TLS certificates of the cluster if it's true.
+	// The SST script default is used if it's not set.
+	Encrypt *bool `json:"encrypt,omitempty"`
+}
+
+type SSTCompressor string
+
+const (
+	SSTCompressorLZ4  SSTCompressor = "lz4"
+	SSTCompressorZstd SSTCompressor = "zstd"
+)
+
+// GetCompressThreads returns the number of the compression threads, Parallel if it's not set.
+func (s *SSTSpec) GetCompressThreads() int32 {
+	if s.CompressThreads > 0 {
+		return s.CompressThreads
+	}
+	return s.Parallel
+}
+
+func (s *SSTSpec) validate(tlsEnabled bool) error {
+	if s.CompressThreads > 0 && s.Compressor == "" {
+		return errors.New("compressThreads requires compressor")
+	}
+	if s.Encrypt != nil && *s.Encrypt && !tlsEnabled {
+		return errors.New("encrypt requires TLS to be enabled")
+	}
+	return nil
+}
+
+// PodVolumeOverride is the data volume of the PXC pod with the ordinal which differs from
+// the volumeSpec of the other pods, e.g. a larger and cheaper volume of the member used for backups.
+// The operator creates the PVC of the pod before the statefulset creates the pod, so the PVC
+// is used instead of the volume claim template. The storage class of an existing PVC can't be changed,
+// the PVC has to be deleted together with its pod to be recreated.
+type PodVolumeOverride struct {
+	Ordinal          int32              `json:"ordinal"`
+	StorageClassName *string            `json:"storageClassName,omitempty"`
+	Size             *resource.Quantity `json:"size,omitempty"`
+}
+
+// VolumeOverride returns the volume override of the PXC pod with the ordinal, nil if there is none.
+func (s *PXCSpec) VolumeOverride(ordinal int32) *PodVolumeOverride {
+	for i := range s.VolumeOverrides {
+		if s.VolumeOverrides[i].Ordinal == ordinal {
+			return &s.VolumeOverrides[i]
+		}
+	}
+	return nil
+}
+
+func (s *PXCSpec) validateVolumeOverrides() error {
+	ordinals := make(map[int32]struct{}, len(s.VolumeOverrides))
+	for _, o := range s.VolumeOverrides {
+		if s.VolumeSpec.PersistentVolumeClaim == nil {
+			return errors.New("volumeSpec.persistentVolumeClaim should be specified")
+		}
+		if o.Ordinal < 0 {
+			return errors.Errorf("ordinal %d can't be negative", o.Ordinal)
+		}
+		if _, ok := ordinals[o.Ordinal]; ok {
+			return errors.Errorf("ordinal %d is overridden more than once", o.Ordinal)
+		}
+		ordinals[o.Ordinal] = struct{}{}
+		if o.Size != nil && o.Size.Sign() <= 0 {
+			return errors.Errorf("size of ordinal %d should be positive", o.Ordinal)
+		}
+	}
+	return nil
+}
+
+// EvictionProtectionSpec makes the operator annotate the PXC pods whose loss would break
+// the cluster quorum or interrupt a state transfer, i.e. the donor, the only synced member
+// and the primary, so cluster-autoscaler and descheduler don't evict them.
+// The annotations are removed when the pod doesn't have such a role anymore.
+type EvictionProtectionSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+func (s *EvictionProtectionSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// StorageLocalitySpec makes the operator check the placement of the PXC pods against the node affinity
+// of their bound persistent volumes, e.g. local PVs. Changes of the pod placement which would leave
+// a pod without nodes it can use its volume on are refused, and the pods whose volumes are bound
+// to nodes that don't exist anymore are reported with events.
+// The operator needs permissions to get nodes and persistent volumes.
+type StorageLocalitySpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+func (s *StorageLocalitySpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// ExternalReplicationSpec configures the cluster as a replication source for
+// consumers that aren't managed by the operator, e.g. CDC or reporting systems.
+type ExternalReplicationSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// User is created with the privileges needed to read the binary logs.
+	// +kubebuilder:default=external_replication
+	User  string   `json:"user,omitempty"`
+	Hosts []string `json:"hosts,omitempty"`
+	// PasswordSecretRef is the secret with the password of the user. If it's not set,
+	// the password is generated to the custom users secret.
+	PasswordSecretRef *SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// Expose configures the service consumers connect to.
+	Expose ServiceExpose `json:"expose,omitempty"`
+	// BinlogRetentionSeconds is the minimal time binary logs are kept on the
+	// PXC nodes, so the consumers can catch up after an outage.
+	BinlogRetentionSeconds int64 `json:"binlogRetentionSeconds,omitempty"`
+}
+
+func (s *ExternalReplicationSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+const DefaultExternalReplicationUser = "external_replication"
+
+// ReportingSpec dedicates the PXC pod with the highest ordinal to analytics and reporting queries.
+// The pod has its own my.cnf profile and service, HAProxy and ProxySQL don't route
+// queries to it and other pods don't prefer it as the SST donor.
+type ReportingSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Configuration is my.cnf content the reporting pod reads after the other option files.
+	Configuration string `json:"configuration,omitempty"`
+	// Expose configures the service reporting clients connect to.
+	Expose ServiceExpose `json:"expose,omitempty"`
+}
+
+func (s *ReportingSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// MetricsSpec configures the mysqld_exporter sidecar.
+// It's a lightweight alternative to PMM for plain Prometheus setups.
+type MetricsSpec struct {
+	Enabled bool   `json:"enabled,omitempty"`
+	Image   string `json:"image,omitempty"`
+	// +kubebuilder:default=IfNotPresent
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// +kubebuilder:default=9104
+	Port                     int32                       `json:"port,omitempty"`
+	Args                     []string                    `json:"args,omitempty"`
+	Resources                corev1.ResourceRequirements `json:"resources,omitempty"`
+	ContainerSecurityContext *corev1.SecurityContext     `json:"containerSecurityContext,omitempty"`
+	// ScrapeInterval is set to the generated PodMonitor, Prometheus default is used if it's empty.
+	ScrapeInterval string `json:"scrapeInterval,omitempty"`
+	// PodMonitorLabels are added to the generated PodMonitor to match Prometheus podMonitorSelector.
+	PodMonitorLabels map[string]string `json:"podMonitorLabels,omitempty"`
+}
+
+func (m *MetricsSpec) IsEnabled() bool {
+	return m != nil && m.Enabled
+}
+
+const DefaultMetricsPort = 9104
+
+type PasswordPolicyLevel string
+
+const (
+	PasswordPolicyLow    PasswordPolicyLevel = "LOW"
+	PasswordPolicyMedium PasswordPolicyLevel = "MEDIUM"
+	PasswordPolicyStrong PasswordPolicyLevel = "STRONG"
+)
+
+// PasswordPolicySpec configures the validate_password component
+// and password management options. Passwords generated by the operator
+// comply with the policy.
+type PasswordPolicySpec struct {
+	// +kubebuilder:default=MEDIUM
+	Policy PasswordPolicyLevel `json:"policy,omitempty"`
+	// +kubebuilder:default=8
+	Length int32 `json:"length,omitempty"`
+	// MixedCaseCount, NumberCount and SpecialCharCount are 1 if unset,
+	// or 0 with the LOW policy. An explicit 0 disables the requirement.
+	MixedCaseCount   *int32 `json:"mixedCaseCount,omitempty"`
+	NumberCount      *int32 `json:"numberCount,omitempty"`
+	SpecialCharCount *int32 `json:"specialCharCount,omitempty"`
+	// History is the number of previous passwords which can't be reused.
+	History int32 `json:"history,omitempty"`
+	// ReuseIntervalDays is the number of days before a password can be reused.
+	ReuseIntervalDays int32 `json:"reuseIntervalDays,omitempty"`
+	// ExpirationDays is the global password lifetime. System users never expire.
+	ExpirationDays int32 `json:"expirationDays,omitempty"`
+}
+
+func (p *PasswordPolicySpec) countOrDefault(count *int32) int32 {
+	if count != nil {
+		return *count
+	}
+	if p.Policy == PasswordPolicyLow {
+		return 0
+	}
+	return 1
+}
+
+// The getters are safe to call on the nil policy, generated passwords don't have requirements then.
+
+func (p *PasswordPolicySpec) GetLength() int32 {
+	if p == nil {
+		return 0
+	}
+	return p.Length
+}
+
+func (p *PasswordPolicySpec) GetMixedCaseCount() int32 {
+	if p == nil {
+		return 0
+	}
+	return p.countOrDefault(p.MixedCaseCount)
+}
+
+func (p *PasswordPolicySpec) GetNumberCount() int32 {
+	if p == nil {
+		return 0
+	}
+	return p.countOrDefault(p.NumberCount)
+}
+
+func (p *PasswordPolicySpec) GetSpecialCharCount() int32 {
+	if p == nil {
+		return 0
+	}
+	return p.countOrDefault(p.SpecialCharCount)
+}
+
+// AuthenticationSpec configures external authentication plugins on PXC nodes.
+type AuthenticationSpec struct {
+	LDAP *LDAPAuthSpec `json:"ldap,omitempty"`
+	OIDC *OIDCAuthSpec `json:"oidc,omitempty"`
+}
+
+type LDAPAuthMechanism string
+
+const (
+	LDAPAuthSimple LDAPAuthMechanism = "simple"
+	LDAPAuthSASL   LDAPAuthMechanism = "sasl"
+)
+
+type LDAPAuthSpec struct {
+	// +kubebuilder:default=simple
+	Mechanism LDAPAuthMechanism `json:"mechanism,omitempty"`
+	// ServerURIs is a list of ldap:// or ldaps:// URIs.
+	// The first one is the main server, the second one is used as a fallback.
+	ServerURIs []string `json:"serverURIs,omitempty"`
+	BindBaseDN string   `json:"bindBaseDN,omitempty"`
+	// BindSecretName is a secret with `dn` and `password` keys
+	// which are used to bind to the LDAP server for searches.
+	BindSecretName    string            `json:"bindSecretName,omitempty"`
+	UserSearchAttr    string            `json:"userSearchAttr,omitempty"`
+	GroupSearchAttr   string            `json:"groupSearchAttr,omitempty"`
+	GroupSearchFilter string            `json:"groupSearchFilter,omitempty"`
+	GroupRoleMapping  map[string]string `json:"groupRoleMapping,omitempty"`
+	SASLMethod        string            `json:"saslMethod,omitempty"`
+	CASecretName      string            `json:"caSecretName,omitempty"`
+}
+
+type OIDCAuthSpec struct {
+	Providers []OIDCProvider `json:"providers,omitempty"`
+}
+
+type OIDCProvider struct {
+	Name   string `json:"name"`
+	Issuer string `json:"issuer"`
+}
+
+func (a *AuthenticationSpec) IsEnabled() bool {
+	return a != nil && (a.LDAP != nil || a.OIDC != nil)
+}
+
 type ServiceExpose struct {
 	Enabled                  bool                                    `json:"enabled,omitempty"`
 	Type                     corev1.ServiceType                      `json:"type,omitempty"`
@@ -106,6 +1253,92 @@ type ServiceExpose struct {
 
 	// Deprecated: Use ExternalTrafficPolicy instead
 	TrafficPolicy corev1.ServiceExternalTrafficPolicyType `json:"trafficPolicy,omitempty"`
+
+	// Gateway exposes the service through a Gateway API route.
+	Gateway *GatewayRouteSpec `json:"gateway,omitempty"`
+
+	// ExternalDNS publishes the service by external-dns.
+	ExternalDNS *ExternalDNSSpec `json:"externalDNS,omitempty"`
+}
+
+// ExternalDNSSpec sets the external-dns annotations of the service.
+// Hostname is a text/template rendered with ExternalDNSTemplateData, e.g.
+// {{ .Role }}.{{ .Cluster }}.db.example.com. The hostname is rendered again on
+// every reconcile, so records using .ClusterRole follow the promotion of the
+// cluster by the replication failover.
+type ExternalDNSSpec struct {
+	Enabled  bool   `json:"enabled,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+	TTL      int64  `json:"ttl,omitempty"`
+}
+
+func (e *ExternalDNSSpec) IsEnabled() bool {
+	return e != nil && e.Enabled
+}
+
+func (e *ExternalDNSSpec) checkNSetDefaults() error {
+	if e.Hostname == "" {
+		return errors.New("hostname can't be empty")
+	}
+	if e.TTL < 0 {
+		return errors.New("ttl can't be negative")
+	}
+	if _, err := e.RenderHostname(ExternalDNSTemplateData{}); err != nil {
+		return errors.Wrap(err, "hostname")
+	}
+	return nil
+}
+
+// GatewayRouteSpec configures the Gateway API route forwarding the connections
+// of the listeners in ParentRefs to the mysql port of the service.
+type GatewayRouteSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Kind is the kind of the route, TCPRoute by default.
+	// +kubebuilder:validation:Enum={TCPRoute,TLSRoute}
+	Kind       GatewayRouteKind         `json:"kind,omitempty"`
+	ParentRefs []GatewayParentReference `json:"parentRefs,omitempty"`
+	// Hostnames are matched against the SNI of the connections, TLSRoute only.
+	Hostnames   []string          `json:"hostnames,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+}
+
+type GatewayRouteKind string
+
+const (
+	GatewayRouteTCP GatewayRouteKind = "TCPRoute"
+	GatewayRouteTLS GatewayRouteKind = "TLSRoute"
+)
+
+// GatewayParentReference is the Gateway, or its listener, the route is attached to.
+type GatewayParentReference struct {
+	Name string `json:"name"`
+	// Namespace of the Gateway, the namespace of the cluster by default.
+	Namespace   string `json:"namespace,omitempty"`
+	SectionName string `json:"sectionName,omitempty"`
+	Port        int32  `json:"port,omitempty"`
+}
+
+func (g *GatewayRouteSpec) IsEnabled() bool {
+	return g != nil && g.Enabled
+}
+
+func (g *GatewayRouteSpec) checkNSetDefaults() error {
+	if g.Kind == "" {
+		g.Kind = GatewayRouteTCP
+	}
+	if len(g.ParentRefs) == 0 {
+		return errors.New("parentRefs can't be empty")
+	}
+	for _, ref := range g.ParentRefs {
+		if ref.Name == "" {
+			return errors.New("parentRefs: name can't be empty")
+		}
+	}
+	if g.Kind == GatewayRouteTLS && len(g.Hostnames) == 0 {
+		return errors.New("hostnames can't be empty for TLSRoute")
+	}
+	return nil
 }
 
 type ReplicationChannel struct {
@@ -113,6 +1346,91 @@ type ReplicationChannel struct {
 	IsSource    bool                      `json:"isSource,omitempty"`
 	SourcesList []ReplicationSource       `json:"sourcesList,omitempty"`
 	Config      *ReplicationChannelConfig `json:"configuration,omitempty"`
+	// Failover promotes the replica cluster if all sources of the channel are unreachable.
+	Failover *ReplicationFailover `json:"failover,omitempty"`
+	// Reposition re-points the channel with GTID auto-positioning if it breaks with a recoverable error.
+	Reposition *ReplicationReposition `json:"reposition,omitempty"`
+	// Rebuild reseeds the cluster from the latest backup of the source cluster if replication can't be recovered.
+	Rebuild *ReplicationRebuild `json:"rebuild,omitempty"`
+	// Filters limit the schemas and tables replicated over the channel.
+	Filters *ReplicationFilters `json:"filters,omitempty"`
+}
+
+// ReplicationFilters are applied with CHANGE REPLICATION FILTER on the replica,
+// see the replicate-* options of the MySQL server for their semantics.
+// Tables are specified as db.table, wild tables accept % and _ wildcards.
+type ReplicationFilters struct {
+	DoDB            []string               `json:"doDB,omitempty"`
+	IgnoreDB        []string               `json:"ignoreDB,omitempty"`
+	DoTable         []string               `json:"doTable,omitempty"`
+	IgnoreTable     []string               `json:"ignoreTable,omitempty"`
+	WildDoTable     []string               `json:"wildDoTable,omitempty"`
+	WildIgnoreTable []string               `json:"wildIgnoreTable,omitempty"`
+	RewriteDB       []ReplicationRewriteDB `json:"rewriteDB,omitempty"`
+}
+
+// ReplicationRewriteDB replicates changes of the From database to the To database.
+type ReplicationRewriteDB struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func (f *ReplicationFilters) validate() error {
+	for _, list := range [][]string{f.DoTable, f.IgnoreTable, f.WildDoTable, f.WildIgnoreTable} {
+		for _, t := range list {
+			db, table, ok := strings.Cut(t, ".")
+			if !ok || db == "" || table == "" {
+				return errors.Errorf("invalid table %s, it should be specified as db.table", t)
+			}
+		}
+	}
+	for _, r := range f.RewriteDB {
+		if r.From == "" || r.To == "" {
+			return errors.New("rewriteDB rules require both from and to databases")
+		}
+	}
+	return nil
+}
+
+// ReplicationRebuild configures reseeding of a replica cluster whose replication is
+// unrecoverable: the source purged binary logs the replica needs or repositioning
+// didn't fix the channel. The cluster is restored from the latest backup of
+// SourceCluster, so the users secret has to match the source cluster.
+type ReplicationRebuild struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// SourceCluster is the name of the source cluster, its backups are named after it.
+	SourceCluster string `json:"sourceCluster,omitempty"`
+	// StorageName is the storage in backup.storages the source cluster uploads backups to.
+	StorageName string `json:"storageName,omitempty"`
+}
+
+// ReplicationReposition configures the retry policy of the automated
+// repositioning of a broken replication channel.
+type ReplicationReposition struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxAttempts is the number of attempts before the operator gives up and
+	// leaves the channel broken.
+	// +kubebuilder:default=3
+	MaxAttempts int32 `json:"maxAttempts,omitempty"`
+	// BackoffSeconds is the delay between attempts, it's doubled after every attempt.
+	// +kubebuilder:default=30
+	BackoffSeconds int32 `json:"backoffSeconds,omitempty"`
+}
+
+// ReplicationFailover configures the automated promotion of a replica
+// cluster when the source site is down.
+type ReplicationFailover struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// SourceDownSeconds is how long replication has to be broken and all
+	// sources unreachable before the cluster is promoted.
+	// +kubebuilder:default=300
+	SourceDownSeconds int32 `json:"sourceDownSeconds,omitempty"`
+	// Fence makes the sources that are still reachable read-only before the promotion.
+	Fence bool `json:"fence,omitempty"`
+	// PromotedServiceAnnotations are added to the exposed HAProxy, ProxySQL
+	// and PXC services once the cluster is promoted, e.g. to move a DNS name
+	// of the applications endpoint to this site.
+	PromotedServiceAnnotations map[string]string `json:"promotedServiceAnnotations,omitempty"`
 }
 
 type ReplicationChannelConfig struct {
@@ -121,12 +1439,50 @@ type ReplicationChannelConfig struct {
 	SSL                bool   `json:"ssl,omitempty"`
 	SSLSkipVerify      bool   `json:"sslSkipVerify,omitempty"`
 	CA                 string `json:"ca,omitempty"`
+	// SSLCert and SSLKey are paths to the client certificate and key in the pxc container.
+	SSLCert    string `json:"sslCert,omitempty"`
+	SSLKey     string `json:"sslKey,omitempty"`
+	SSLCipher  string `json:"sslCipher,omitempty"`
+	TLSVersion string `json:"tlsVersion,omitempty"`
+	// HeartbeatPeriod is the interval in seconds the source sends heartbeats in, the server default is used if it's 0.
+	HeartbeatPeriod uint `json:"heartbeatPeriod,omitempty"`
+	// CompressionAlgorithms is a comma separated list of zlib, zstd and uncompressed.
+	CompressionAlgorithms string `json:"compressionAlgorithms,omitempty"`
+	ZstdCompressionLevel  uint   `json:"zstdCompressionLevel,omitempty"`
+}
+
+func (c *ReplicationChannelConfig) validate() error {
+	if (c.SSLCert != "" || c.SSLKey != "" || c.SSLCipher != "" || c.TLSVersion != "") && !c.SSL {
+		return errors.New("ssl options require ssl to be enabled")
+	}
+	if (c.SSLCert == "") != (c.SSLKey == "") {
+		return errors.New("sslCert and sslKey must be set together")
+	}
+	// MySQL limits the heartbeat period to 4294967 seconds
+	if c.HeartbeatPeriod > 4294967 {
+		return errors.Errorf("heartbeatPeriod %d exceeds 4294967 seconds", c.HeartbeatPeriod)
+	}
+	if c.CompressionAlgorithms != "" {
+		for _, a := range strings.Split(c.CompressionAlgorithms, ",") {
+			switch strings.TrimSpace(a) {
+			case "zlib", "zstd", "uncompressed":
+			default:
+				return errors.Errorf("unknown compression algorithm %s", a)
+			}
+		}
+	}
+	if c.ZstdCompressionLevel > 22 {
+		return errors.Errorf("zstdCompressionLevel %d is out of range 1-22", c.ZstdCompressionLevel)
+	}
+	return nil
 }
 
 type ReplicationSource struct {
-	Host   string `json:"host,omitempty"`
-	Port   int    `json:"port,omitempty"`
-	Weight int    `json:"weight,omitempty"`
+	Host string `json:"host,omitempty"`
+	// +kubebuilder:default=3306
+	Port int `json:"port,omitempty"`
+	// +kubebuilder:default=100
+	Weight int `json:"weight,omitempty"`
 }
 
 type TLSSpec struct {
@@ -149,20 +1505,184 @@ func GetDefaultVersionServiceEndpoint() string {
 	return DefaultVersionServiceEndpoint
 }
 
-type UpgradeOptions struct {
-	VersionServiceEndpoint string `json:"versionServiceEndpoint,omitempty"`
-	Apply                  string `json:"apply,omitempty"`
-	Schedule               string `json:"schedule,omitempty"`
+type UpgradeOptions struct {
+	// +kubebuilder:default="https://check.percona.com"
+	VersionServiceEndpoint string `json:"versionServiceEndpoint,omitempty"`
+	// VersionServiceCASecret is a secret with the CA certificate in the ca.crt key
+	// used to verify the TLS certificate of a version service mirror.
+	VersionServiceCASecret string `json:"versionServiceCASecret,omitempty"`
+	// VersionMap is a config map with the versions.json key used instead of the version service.
+	// Versions are mapped by the apply value, e.g. {"recommended": {"pxcImage": "..."}}.
+	VersionMap string `json:"versionMap,omitempty"`
+	// +kubebuilder:default=disabled
+	Apply            string                `json:"apply,omitempty"`
+	Schedule         string                `json:"schedule,omitempty"`
+	Canary           *CanarySpec           `json:"canary,omitempty"`
+	PreUpgradeBackup *PreUpgradeBackupSpec `json:"preUpgradeBackup,omitempty"`
+	PreUpgradeCheck  *PreUpgradeCheckSpec  `json:"preUpgradeCheck,omitempty"`
+	// MaintenanceWindows limit version upgrades and SmartUpdate restarts to the listed windows.
+	// Version upgrades are checked on Schedule, it has to fire within the windows.
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+	// Blackouts are periods without version upgrades and SmartUpdate restarts.
+	Blackouts []BlackoutPeriod `json:"blackouts,omitempty"`
+}
+
+// MaintenanceWindow starts on Schedule and lasts for Duration.
+// Schedule is a cron expression, CRON_TZ prefix sets the time zone.
+type MaintenanceWindow struct {
+	Schedule string          `json:"schedule"`
+	Duration metav1.Duration `json:"duration"`
+}
+
+type BlackoutPeriod struct {
+	Name  string      `json:"name,omitempty"`
+	Start metav1.Time `json:"start"`
+	End   metav1.Time `json:"end"`
+}
+
+// MaintenanceAllowed returns true if version upgrades and restarts are allowed at t.
+// Otherwise it returns the reason.
+func (o *UpgradeOptions) MaintenanceAllowed(t time.Time) (bool, string) {
+	for _, b := range o.Blackouts {
+		if !t.Before(b.Start.Time) && t.Before(b.End.Time) {
+			return false, fmt.Sprintf("blackout %s lasts until %s", b.Name, b.End.Format(time.RFC3339))
+		}
+	}
+
+	if len(o.MaintenanceWindows) == 0 {
+		return true, ""
+	}
+
+	for _, w := range o.MaintenanceWindows {
+		sched, err := cron.ParseStandard(w.Schedule)
+		if err != nil {
+			continue
+		}
+		if !sched.Next(t.Add(-w.Duration.Duration)).After(t) {
+			return true, ""
+		}
+	}
+
+	return false, "outside of maintenance windows"
+}
+
+// PreUpgradeBackupSpec makes SmartUpdate require a successful backup before
+// the PXC image is changed. A backup completed within MaxAge is reused,
+// otherwise a new one is taken to StorageName.
+type PreUpgradeBackupSpec struct {
+	Enabled     bool             `json:"enabled,omitempty"`
+	StorageName string           `json:"storageName,omitempty"`
+	MaxAge      *metav1.Duration `json:"maxAge,omitempty"`
+}
+
+func (b *PreUpgradeBackupSpec) IsEnabled() bool {
+	return b != nil && b.Enabled
+}
+
+// PreUpgradeBackupStatus is the restore point of the statefulset revision being rolled out.
+type PreUpgradeBackupStatus struct {
+	Revision   string `json:"revision,omitempty"`
+	BackupName string `json:"backupName,omitempty"`
+}
+
+// TeardownSpec configures the ordered teardown of a deleted cluster with the percona.com/ordered-teardown finalizer.
+// The teardown stops the backup schedules and the binlog collector, handles the in-flight backups,
+// takes the final backup if it's configured and only then lets other finalizers remove the workloads.
+type TeardownSpec struct {
+	// InFlightBackups is Wait to let the running backups complete or Cancel to delete them.
+	// The backups that haven't started are deleted in both cases.
+	// +kubebuilder:validation:Enum=Wait;Cancel
+	// +kubebuilder:default=Wait
+	InFlightBackups TeardownBackupPolicy `json:"inFlightBackups,omitempty"`
+	// FinalBackup is taken after the in-flight backups are handled. The teardown doesn't continue
+	// if the final backup fails, remove the finalizer to delete the cluster without it.
+	FinalBackup *TeardownFinalBackupSpec `json:"finalBackup,omitempty"`
+}
+
+type TeardownBackupPolicy string
+
+const (
+	TeardownBackupWait   TeardownBackupPolicy = "Wait"
+	TeardownBackupCancel TeardownBackupPolicy = "Cancel"
+)
+
+type TeardownFinalBackupSpec struct {
+	StorageName string `json:"storageName"`
+}
+
+// PreUpgradeCheckSpec makes SmartUpdate run the MySQL Shell upgrade checker
+// against the cluster before the PXC image is changed.
+type PreUpgradeCheckSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Image with mysqlsh, the new PXC image is used if empty.
+	Image string `json:"image,omitempty"`
+}
+
+func (c *PreUpgradeCheckSpec) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+type PreUpgradeCheckState string
+
+const (
+	PreUpgradeCheckRunning PreUpgradeCheckState = "Running"
+	PreUpgradeCheckPassed  PreUpgradeCheckState = "Passed"
+	PreUpgradeCheckFailed  PreUpgradeCheckState = "Failed"
+)
+
+// PreUpgradeCheckStatus is the result of the upgrade checker for the statefulset revision being rolled out.
+type PreUpgradeCheckStatus struct {
+	Revision    string               `json:"revision,omitempty"`
+	TargetImage string               `json:"targetImage,omitempty"`
+	State       PreUpgradeCheckState `json:"state,omitempty"`
+	Errors      int32                `json:"errors,omitempty"`
+	Warnings    int32                `json:"warnings,omitempty"`
+	Report      []string             `json:"report,omitempty"`
+	Message     string               `json:"message,omitempty"`
+}
+
+// CanarySpec configures SmartUpdate to restart one secondary pod first and
+// soak it before the rest of the cluster is updated.
+type CanarySpec struct {
+	Enabled    bool             `json:"enabled,omitempty"`
+	SoakPeriod *metav1.Duration `json:"soakPeriod,omitempty"`
+	// MaxCertFailures is the number of certification failures the canary may
+	// report during the soak period. Zero disables the check.
+	MaxCertFailures int64 `json:"maxCertFailures,omitempty"`
+}
+
+func (c *CanarySpec) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+type CanaryState string
+
+const (
+	CanarySoaking CanaryState = "Soaking"
+	CanaryPassed  CanaryState = "Passed"
+	CanaryFailed  CanaryState = "Failed"
+)
+
+// CanaryStatus is the state of the canary pod for the statefulset revision being rolled out.
+type CanaryStatus struct {
+	Pod       string      `json:"pod,omitempty"`
+	Revision  string      `json:"revision,omitempty"`
+	State     CanaryState `json:"state,omitempty"`
+	StartedAt metav1.Time `json:"startedAt,omitempty"`
+	Restarts  int32       `json:"restarts,omitempty"`
+	Message   string      `json:"message,omitempty"`
 }
 
 const (
 	SmartUpdateStatefulSetStrategyType appsv1.StatefulSetUpdateStrategyType = "SmartUpdate"
 )
 
+// +kubebuilder:validation:XValidation:rule="!has(self.pitr) || !has(self.pitr.enabled) || !self.pitr.enabled || (has(self.pitr.storageName) && has(self.storages) && self.pitr.storageName in self.storages)",message="pitr.storageName must be one of backup.storages"
 type PXCScheduledBackup struct {
-	AllowParallel           *bool                         `json:"allowParallel,omitempty"`
-	Image                   string                        `json:"image,omitempty"`
-	ImagePullSecrets        []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	AllowParallel    *bool                         `json:"allowParallel,omitempty"`
+	Image            string                        `json:"image,omitempty"`
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// +kubebuilder:default=Always
 	ImagePullPolicy         corev1.PullPolicy             `json:"imagePullPolicy,omitempty"`
 	Schedule                []PXCScheduledBackupSchedule  `json:"schedule,omitempty"`
 	Storages                map[string]*BackupStorageSpec `json:"storages,omitempty"`
@@ -172,6 +1692,49 @@ type PXCScheduledBackup struct {
 	BackoffLimit            *int32                        `json:"backoffLimit,omitempty"`
 	ActiveDeadlineSeconds   *int64                        `json:"activeDeadlineSeconds,omitempty"`
 	StartingDeadlineSeconds *int64                        `json:"startingDeadlineSeconds,omitempty"`
+
+	GarbageCollection *BackupGarbageCollectionSpec `json:"garbageCollection,omitempty"`
+}
+
+// BackupGarbageCollectionSpec makes the operator look for the backups in the S3 and Azure storages
+// of the cluster no backup object refers to, e.g. left by crashed jobs or deleted clusters, and delete them.
+// Only the backups of the cluster and of the clusters which don't exist anymore are considered.
+type BackupGarbageCollectionSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// DryRun only reports the orphaned backups in the status and events.
+	DryRun bool `json:"dryRun,omitempty"`
+	// IntervalSeconds is the time between the storage scans, 86400 by default.
+	IntervalSeconds int64 `json:"intervalSeconds,omitempty"`
+	// MinAgeSeconds is the age orphaned backups are deleted after, 604800 by default.
+	MinAgeSeconds int64 `json:"minAgeSeconds,omitempty"`
+	// Storages are the names of the scanned storages, all S3 and Azure storages by default.
+	Storages []string `json:"storages,omitempty"`
+}
+
+func (g *BackupGarbageCollectionSpec) IsEnabled() bool {
+	return g != nil && g.Enabled
+}
+
+func (g *BackupGarbageCollectionSpec) checkNSetDefaults(storages map[string]*BackupStorageSpec) error {
+	if g.IntervalSeconds == 0 {
+		g.IntervalSeconds = 86400
+	}
+	if g.MinAgeSeconds == 0 {
+		g.MinAgeSeconds = 604800
+	}
+	if g.IntervalSeconds < 0 || g.MinAgeSeconds < 0 {
+		return errors.New("intervalSeconds and minAgeSeconds can't be negative")
+	}
+	for _, name := range g.Storages {
+		stg, ok := storages[name]
+		if !ok {
+			return errors.Errorf("storage %s doesn't exist", name)
+		}
+		if stg.Type == BackupStorageFilesystem {
+			return errors.Errorf("storage %s has unsupported type %s", name, stg.Type)
+		}
+	}
+	return nil
 }
 
 func (b *PXCScheduledBackup) GetAllowParallel() bool {
@@ -182,11 +1745,13 @@ func (b *PXCScheduledBackup) GetAllowParallel() bool {
 }
 
 type PITRSpec struct {
-	Enabled            bool                        `json:"enabled"`
-	StorageName        string                      `json:"storageName"`
-	Resources          corev1.ResourceRequirements `json:"resources,omitempty"`
-	TimeBetweenUploads float64                     `json:"timeBetweenUploads,omitempty"`
-	TimeoutSeconds     float64                     `json:"timeoutSeconds,omitempty"`
+	Enabled     bool                        `json:"enabled"`
+	StorageName string                      `json:"storageName"`
+	Resources   corev1.ResourceRequirements `json:"resources,omitempty"`
+	// +kubebuilder:default=60
+	TimeBetweenUploads float64 `json:"timeBetweenUploads,omitempty"`
+	// +kubebuilder:default=3600
+	TimeoutSeconds float64 `json:"timeoutSeconds,omitempty"`
 }
 
 type PXCScheduledBackupSchedule struct {
@@ -212,30 +1777,248 @@ const (
 
 // PerconaXtraDBClusterStatus defines the observed state of PerconaXtraDBCluster
 type PerconaXtraDBClusterStatus struct {
-	PXC                AppStatus          `json:"pxc,omitempty"`
-	PXCReplication     *ReplicationStatus `json:"pxcReplication,omitempty"`
-	ProxySQL           AppStatus          `json:"proxysql,omitempty"`
-	HAProxy            AppStatus          `json:"haproxy,omitempty"`
-	Backup             ComponentStatus    `json:"backup,omitempty"`
-	PMM                ComponentStatus    `json:"pmm,omitempty"`
-	LogCollector       ComponentStatus    `json:"logcollector,omitempty"`
-	Host               string             `json:"host,omitempty"`
-	Messages           []string           `json:"message,omitempty"`
-	Status             AppState           `json:"state,omitempty"`
-	Conditions         []ClusterCondition `json:"conditions,omitempty"`
-	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
-	Size               int32              `json:"size"`
-	Ready              int32              `json:"ready"`
+	PXC                AppStatus               `json:"pxc,omitempty"`
+	PXCReplication     *ReplicationStatus      `json:"pxcReplication,omitempty"`
+	ProxySQL           AppStatus               `json:"proxysql,omitempty"`
+	HAProxy            AppStatus               `json:"haproxy,omitempty"`
+	Backup             ComponentStatus         `json:"backup,omitempty"`
+	PMM                ComponentStatus         `json:"pmm,omitempty"`
+	LogCollector       ComponentStatus         `json:"logcollector,omitempty"`
+	Host               string                  `json:"host,omitempty"`
+	Messages           []string                `json:"message,omitempty"`
+	Status             AppState                `json:"state,omitempty"`
+	Conditions         []ClusterCondition      `json:"conditions,omitempty"`
+	ObservedGeneration int64                   `json:"observedGeneration,omitempty"`
+	Size               int32                   `json:"size"`
+	Ready              int32                   `json:"ready"`
+	Canary             *CanaryStatus           `json:"canary,omitempty"`
+	PreUpgradeBackup   *PreUpgradeBackupStatus `json:"preUpgradeBackup,omitempty"`
+	PreUpgradeCheck    *PreUpgradeCheckStatus  `json:"preUpgradeCheck,omitempty"`
+	SmartUpdate        *SmartUpdateStatus      `json:"smartUpdate,omitempty"`
+	PendingChanges     *PendingChangesStatus   `json:"pendingChanges,omitempty"`
+	ConsistencyCheck   *ConsistencyCheckStatus `json:"consistencyCheck,omitempty"`
+	Standby            *StandbyStatus          `json:"standby,omitempty"`
+	TableMaintenance   *TableMaintenanceStatus `json:"tableMaintenance,omitempty"`
+	BinlogRetention    *BinlogRetentionStatus  `json:"binlogRetention,omitempty"`
+	Velero             *VeleroStatus           `json:"velero,omitempty"`
+	GitOps             *GitOpsStatus           `json:"gitOps,omitempty"`
+	Diagnostics        *DiagnosticsStatus      `json:"diagnostics,omitempty"`
+	LastBackup         *LastBackupStatus       `json:"lastBackup,omitempty"`
+	PITR               *PITRStatus             `json:"pitr,omitempty"`
+
+	BackupGarbageCollection *BackupGarbageCollectionStatus `json:"backupGarbageCollection,omitempty"`
+}
+
+// BackupGarbageCollectionStatus is the result of the last scan of the backup storages.
+type BackupGarbageCollectionStatus struct {
+	// LastScanTime is the time the storages were scanned last.
+	LastScanTime *metav1.Time `json:"lastScanTime,omitempty"`
+	// Orphaned are the orphaned backups found by the last scan and not deleted.
+	Orphaned []OrphanedBackup `json:"orphaned,omitempty"`
+	// Deleted is the number of orphaned backups deleted by the last scan.
+	Deleted int32 `json:"deleted,omitempty"`
+	// Error is the reason the last scan failed, if it did.
+	Error string `json:"error,omitempty"`
+}
+
+// OrphanedBackup is a backup in a storage no backup object refers to.
+type OrphanedBackup struct {
+	Storage string `json:"storage"`
+	Name    string `json:"name"`
+}
+
+// LastBackupStatus is the latest successful backup of the cluster.
+type LastBackupStatus struct {
+	Name        string       `json:"name,omitempty"`
+	StorageName string       `json:"storageName,omitempty"`
+	Completed   *metav1.Time `json:"completed,omitempty"`
+}
+
+// PITRStatus is the state of the point-in-time recovery of the cluster.
+type PITRStatus struct {
+	// LatestRestorableTime is the time the binary logs are uploaded up to after the last backup,
+	// the time since then is the data the point-in-time recovery would lose.
+	LatestRestorableTime *metav1.Time `json:"latestRestorableTime,omitempty"`
+}
+
+// DiagnosticsStatus lists the diagnostic bundles collected by the operator, the latest last.
+type DiagnosticsStatus struct {
+	Bundles []DiagnosticBundle `json:"bundles,omitempty"`
+}
+
+// DiagnosticBundle is a diagnostic bundle collected on a failure of the cluster.
+type DiagnosticBundle struct {
+	Name string `json:"name"`
+	// Reason is the failure the bundle is collected for, CrashLoop or QuorumLost.
+	Reason string `json:"reason"`
+	// Pod is the crash-looping pod, empty if the cluster lost quorum.
+	Pod string `json:"pod,omitempty"`
+	// Location is the URL of the archive in the storage or the path in the PVC.
+	Location    string       `json:"location,omitempty"`
+	CollectedAt *metav1.Time `json:"collectedAt,omitempty"`
+	// Error is the reason the bundle wasn't saved, if it wasn't.
+	Error string `json:"error,omitempty"`
+}
+
+// LastBundle returns the latest diagnostic bundle, nil if there is none.
+func (s *DiagnosticsStatus) LastBundle() *DiagnosticBundle {
+	if s == nil || len(s.Bundles) == 0 {
+		return nil
+	}
+	return &s.Bundles[len(s.Bundles)-1]
+}
+
+// GitOpsStatus holds the spec changes the operator suggests when the spec is owned by a GitOps tool.
+type GitOpsStatus struct {
+	SuggestedChanges []SpecChange `json:"suggestedChanges,omitempty"`
+}
+
+// SpecChange is a change of the spec suggested by the operator.
+type SpecChange struct {
+	// Path is the dot separated path of the field, e.g. spec.pxc.image.
+	Path   string `json:"path"`
+	Value  string `json:"value"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// VeleroStatus is the state of the Velero integration.
+type VeleroStatus struct {
+	// RestoreName is the name of the last Velero restore the cluster was recovered from.
+	RestoreName string `json:"restoreName,omitempty"`
+	// RecoveredAt is the time the restored pods were recreated.
+	RecoveredAt *metav1.Time `json:"recoveredAt,omitempty"`
+}
+
+// BinlogRetentionStatus is the state of the binlog purge policy.
+type BinlogRetentionStatus struct {
+	// PurgedBefore is the time binary logs were last purged up to.
+	PurgedBefore *metav1.Time `json:"purgedBefore,omitempty"`
+	// LastPurgeTime is the time of the last purge.
+	LastPurgeTime *metav1.Time `json:"lastPurgeTime,omitempty"`
+	// HeldBy is the reason purging is held, empty if it isn't.
+	HeldBy string `json:"heldBy,omitempty"`
+	// ReplicasSeenAt is the last time a replica was connected to the cluster.
+	ReplicasSeenAt *metav1.Time `json:"replicasSeenAt,omitempty"`
+}
+
+// TableMaintenanceStatus is the progress of the table maintenance runs.
+type TableMaintenanceStatus struct {
+	// LastScheduleTime is the time the last run was started.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// LastCompletedTime is the time the last run went through all pods.
+	LastCompletedTime *metav1.Time `json:"lastCompletedTime,omitempty"`
+	// CurrentPod is the pod the maintenance job is running against.
+	CurrentPod string `json:"currentPod,omitempty"`
+	// PendingPods are the pods left in the running run.
+	PendingPods []string `json:"pendingPods,omitempty"`
+	// FailedPods are the pods the maintenance job failed for in the last run.
+	FailedPods []string `json:"failedPods,omitempty"`
+}
+
+// InProgress returns true if the last run didn't go through all pods yet.
+func (s *TableMaintenanceStatus) InProgress() bool {
+	return s.LastScheduleTime != nil && (s.LastCompletedTime == nil || s.LastCompletedTime.Before(s.LastScheduleTime))
+}
+
+// StandbyStatus is the progress of a warm-standby cluster.
+type StandbyStatus struct {
+	// Backup is the backup of the source cluster the standby is restored from.
+	Backup string `json:"backup,omitempty"`
+	// Restore is the running restore of Backup, it's cleared once the restore succeeds.
+	Restore string `json:"restore,omitempty"`
+	// LastAppliedTime is the start time of the last successful binlog apply,
+	// binlogs uploaded before it are applied.
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+	LagSeconds      int64        `json:"lagSeconds,omitempty"`
+}
+
+// ConsistencyCheckStatus is the result of the checksum comparison on a replica cluster.
+type ConsistencyCheckStatus struct {
+	// LastChecksumTime is the time of the latest replicated checksum.
+	LastChecksumTime *metav1.Time `json:"lastChecksumTime,omitempty"`
+	// DiffTables lists tables with checksums different from the source.
+	DiffTables []string `json:"diffTables,omitempty"`
+}
+
+// PendingChangesStatus lists the actions the operator takes to apply the spec.
+// If spec.requireChangesApproval is set, the actions wait until
+// percona.com/approved-changes annotation is set to ID.
+type PendingChangesStatus struct {
+	ID               string          `json:"id,omitempty"`
+	ApprovalRequired bool            `json:"approvalRequired,omitempty"`
+	Actions          []PendingAction `json:"actions,omitempty"`
+}
+
+type PendingAction struct {
+	Component   string `json:"component"`
+	Description string `json:"description"`
+}
+
+type SmartUpdatePhase string
+
+const (
+	SmartUpdatePostponed   SmartUpdatePhase = "Postponed"
+	SmartUpdateCanary      SmartUpdatePhase = "Canary"
+	SmartUpdateSecondaries SmartUpdatePhase = "UpdatingSecondaries"
+	SmartUpdatePrimary     SmartUpdatePhase = "UpdatingPrimary"
+	SmartUpdateHalted      SmartUpdatePhase = "Halted"
+	SmartUpdateCompleted   SmartUpdatePhase = "Completed"
+)
+
+// SmartUpdateStatus is the progress of the rolling update of PXC pods.
+type SmartUpdateStatus struct {
+	Revision    string           `json:"revision,omitempty"`
+	TargetImage string           `json:"targetImage,omitempty"`
+	Phase       SmartUpdatePhase `json:"phase,omitempty"`
+	CurrentPod  string           `json:"currentPod,omitempty"`
+	PodsUpdated int32            `json:"podsUpdated,omitempty"`
+	PodsPending int32            `json:"podsPending,omitempty"`
+	StartedAt   *metav1.Time     `json:"startedAt,omitempty"`
+	FinishedAt  *metav1.Time     `json:"finishedAt,omitempty"`
 }
 
 // TODO: add replication status(error,active and etc)
 type ReplicationStatus struct {
 	Channels []ReplicationChannelStatus `json:"replicationChannels,omitempty"`
+	// Failovers is the trail of automated promotions of the cluster.
+	Failovers []ReplicationFailoverRecord `json:"failovers,omitempty"`
 }
 
 type ReplicationChannelStatus struct {
 	Name                     string `json:"name,omitempty"`
 	ReplicationChannelConfig `json:",inline"`
+	// SourceDownSince is the time the sources of the channel were first found unreachable.
+	SourceDownSince *metav1.Time `json:"sourceDownSince,omitempty"`
+	// IOThread and SQLThread are the states of the replica threads as reported by SHOW REPLICA STATUS.
+	IOThread            string `json:"ioThread,omitempty"`
+	SQLThread           string `json:"sqlThread,omitempty"`
+	LastError           string `json:"lastError,omitempty"`
+	SecondsBehindSource *int64 `json:"secondsBehindSource,omitempty"`
+	// RepositionAttempts is the number of repositioning attempts since the channel broke.
+	RepositionAttempts int32        `json:"repositionAttempts,omitempty"`
+	LastRepositionTime *metav1.Time `json:"lastRepositionTime,omitempty"`
+	// RebuildRestore is the restore reseeding the cluster, it's cleared once the restore succeeds.
+	// A failed restore has to be deleted to rebuild the cluster again.
+	RebuildRestore string `json:"rebuildRestore,omitempty"`
+	// LastRebuildBackup is the backup of the last rebuild, it's not used for a rebuild twice.
+	LastRebuildBackup string `json:"lastRebuildBackup,omitempty"`
+	// Filters are the replication filters applied to the channel.
+	Filters *ReplicationFilters `json:"filters,omitempty"`
+}
+
+// Broken returns true if the channel has an error or only one of its threads is stopped.
+// A channel with both threads stopped and no error is considered stopped, not broken.
+func (s *ReplicationChannelStatus) Broken() bool {
+	return s.LastError != "" || (s.IOThread == "No") != (s.SQLThread == "No")
+}
+
+// ReplicationFailoverRecord records why and how the cluster was promoted.
+type ReplicationFailoverRecord struct {
+	Channel  string      `json:"channel"`
+	Time     metav1.Time `json:"time"`
+	Reason   string      `json:"reason"`
+	Fencing  string      `json:"fencing,omitempty"`
+	Promoted bool        `json:"promoted"`
 }
 
 type ConditionStatus string
@@ -252,6 +2035,7 @@ type ClusterCondition struct {
 	LastTransitionTime metav1.Time     `json:"lastTransitionTime,omitempty"`
 	Reason             string          `json:"reason,omitempty"`
 	Message            string          `json:"message,omitempty"`
+	ObservedGeneration int64           `json:"observedGeneration,omitempty"`
 }
 
 type ComponentStatus struct {
@@ -267,6 +2051,32 @@ type AppStatus struct {
 
 	Size  int32 `json:"size,omitempty"`
 	Ready int32 `json:"ready,omitempty"`
+	// ReadyMembers is the number of ready members out of the size, e.g. 2/3.
+	ReadyMembers string `json:"readyMembers,omitempty"`
+	// UnhealthyPods are the pods which aren't ready with the most relevant reason, sorted by name.
+	UnhealthyPods []UnhealthyPod `json:"unhealthyPods,omitempty"`
+}
+
+type UnhealthyPodReason string
+
+const (
+	UnhealthyPodPVCPending          UnhealthyPodReason = "PVCPending"
+	UnhealthyPodUnschedulable       UnhealthyPodReason = "Unschedulable"
+	UnhealthyPodImagePullFailed     UnhealthyPodReason = "ImagePullFailed"
+	UnhealthyPodInitContainerFailed UnhealthyPodReason = "InitContainerFailed"
+	UnhealthyPodContainerFailed     UnhealthyPodReason = "ContainerFailed"
+	UnhealthyPodWsrepNotSynced      UnhealthyPodReason = "WsrepNotSynced"
+	UnhealthyPodProbeFailed         UnhealthyPodReason = "ProbeFailed"
+	UnhealthyPodWaitingForRecovery  UnhealthyPodReason = "WaitingForRecovery"
+	UnhealthyPodOutdated            UnhealthyPodReason = "Outdated"
+	UnhealthyPodNotReady            UnhealthyPodReason = "NotReady"
+)
+
+// UnhealthyPod is a pod of the component which isn't ready.
+type UnhealthyPod struct {
+	Name    string             `json:"name"`
+	Reason  UnhealthyPodReason `json:"reason"`
+	Message string             `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -279,9 +2089,12 @@ type AppStatus struct {
 // +kubebuilder:resource:shortName="pxc";"pxcs"
 // +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".status.host"
 // +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.state"
-// +kubebuilder:printcolumn:name="PXC",type="string",JSONPath=".status.pxc.ready",description="Ready pxc nodes"
-// +kubebuilder:printcolumn:name="proxysql",type="string",JSONPath=".status.proxysql.ready",description="Ready proxysql nodes"
-// +kubebuilder:printcolumn:name="haproxy",type="string",JSONPath=".status.haproxy.ready",description="Ready haproxy nodes"
+// +kubebuilder:printcolumn:name="PXC",type="string",JSONPath=".status.pxc.readyMembers",description="Ready pxc nodes"
+// +kubebuilder:printcolumn:name="proxysql",type="string",JSONPath=".status.proxysql.readyMembers",description="Ready proxysql nodes"
+// +kubebuilder:printcolumn:name="haproxy",type="string",JSONPath=".status.haproxy.readyMembers",description="Ready haproxy nodes"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.pxc.version",description="PXC version"
+// +kubebuilder:printcolumn:name="Last Backup",type="date",JSONPath=".status.lastBackup.completed",description="Completion time of the latest successful backup"
+// +kubebuilder:printcolumn:name="PITR Lag",type="date",JSONPath=".status.pitr.latestRestorableTime",description="Time the binary logs are uploaded up to",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type PerconaXtraDBCluster struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -341,14 +2154,89 @@ func (cr *PerconaXtraDBCluster) Validate() error {
 				if channel.Config.SSL && channel.Config.CA == "" {
 					return errors.Errorf("if you set ssl for channel %s, you have to indicate a path to a CA file to verify the server certificate", channel.Name)
 				}
+				if err := channel.Config.validate(); err != nil {
+					return errors.Wrapf(err, "replication channel %s configuration", channel.Name)
+				}
+			}
+
+			if channel.Filters != nil {
+				if err := channel.Filters.validate(); err != nil {
+					return errors.Wrapf(err, "replication channel %s filters", channel.Name)
+				}
+			}
+
+			if rebuild := channel.Rebuild; rebuild != nil && rebuild.Enabled {
+				if rebuild.SourceCluster == "" {
+					return errors.Errorf("replication channel %s: rebuild.sourceCluster can't be empty", channel.Name)
+				}
+				if c.Backup == nil {
+					return errors.Errorf("replication channel %s: rebuild requires backup section", channel.Name)
+				}
+				stg, ok := c.Backup.Storages[rebuild.StorageName]
+				if !ok || stg == nil || (stg.Type != BackupStorageS3 && stg.Type != BackupStorageAzure) {
+					return errors.Errorf("replication channel %s: rebuild.storageName must be an S3 or Azure storage", channel.Name)
+				}
 			}
 		}
 	}
 
+	if c.PXC.Authentication.IsEnabled() {
+		if err := c.PXC.Authentication.validate(); err != nil {
+			return errors.Wrap(err, "pxc.authentication")
+		}
+		if c.ProxySQLEnabled() {
+			return errors.New("pxc.authentication requires HAProxy: ProxySQL can't pass through external authentication")
+		}
+	}
+
+	if pp := c.PXC.PasswordPolicy; pp != nil {
+		switch pp.Policy {
+		case "", PasswordPolicyLow, PasswordPolicyMedium, PasswordPolicyStrong:
+		default:
+			return errors.Errorf("pxc.passwordPolicy: unknown policy %s", pp.Policy)
+		}
+		if pp.Length > 32 {
+			return errors.New("pxc.passwordPolicy: length can't be greater than 32")
+		}
+	}
+
+	if c.LogCollector != nil && c.LogCollector.Sink != nil {
+		if err := c.LogCollector.Sink.validate(); err != nil {
+			return errors.Wrap(err, "logcollector.sink")
+		}
+	}
+
+	if c.Monitoring != nil && c.Monitoring.PrometheusRule.IsEnabled() {
+		if err := c.Monitoring.PrometheusRule.validate(); err != nil {
+			return errors.Wrap(err, "monitoring.prometheusRule")
+		}
+	}
+
+	if c.Monitoring != nil && c.Monitoring.ServiceMonitor.IsEnabled() && c.Monitoring.ServiceMonitor.Interval != "" {
+		if _, err := model.ParseDuration(c.Monitoring.ServiceMonitor.Interval); err != nil {
+			return errors.Wrap(err, "monitoring.serviceMonitor.interval")
+		}
+	}
+
+	if c.LogCollector != nil && c.LogCollector.AuditLog.IsEnabled() {
+		if err := c.LogCollector.AuditLog.validate(); err != nil {
+			return errors.Wrap(err, "logcollector.auditLog")
+		}
+	}
+
+	if c.PXC.Metrics.IsEnabled() && c.PXC.Metrics.Image == "" {
+		return errors.New("pxc.metrics.image can't be empty")
+	}
+
 	if c.PMM != nil && c.PMM.Enabled {
 		if c.PMM.Image == "" {
 			return errors.New("pmm.Image can't be empty")
 		}
+		switch c.PMM.Version {
+		case "", PMMVersion2, PMMVersion3:
+		default:
+			return errors.Errorf("pmm.version: unsupported version %s, should be %s or %s", c.PMM.Version, PMMVersion2, PMMVersion3)
+		}
 	}
 
 	if c.PXC.VolumeSpec == nil {
@@ -359,6 +2247,56 @@ func (cr *PerconaXtraDBCluster) Validate() error {
 		return errors.Wrap(err, "PXC: validate volume spec")
 	}
 
+	if err := c.PXC.validateVolumeOverrides(); err != nil {
+		return errors.Wrap(err, "PXC: volumeOverrides")
+	}
+
+	if err := c.PXC.validatePodMetadata(); err != nil {
+		return errors.Wrap(err, "PXC: podMetadata")
+	}
+
+	for _, key := range c.PropagateLabels {
+		if err := validateMetadataKey(key); err != nil {
+			return errors.Wrap(err, "propagateLabels")
+		}
+	}
+
+	if d := c.PXC.Diagnostics; d.IsEnabled() {
+		if err := d.checkNSetDefaults(c.Backup); err != nil {
+			return errors.Wrap(err, "PXC: diagnostics")
+		}
+	}
+
+	if c.PXC.ProviderOptions != nil {
+		if err := c.PXC.ProviderOptions.validate(); err != nil {
+			return errors.Wrap(err, "PXC: providerOptions")
+		}
+	}
+	if err := validateConfigProviderOptions(c.PXC.Configuration, c.PXC.ProviderOptions != nil); err != nil {
+		return errors.Wrap(err, "PXC: configuration")
+	}
+
+	if g := c.PXC.GracefulShutdown; g.IsEnabled() {
+		if err := g.checkNSetDefaults(c.PXC.PodSpec); err != nil {
+			return errors.Wrap(err, "PXC: gracefulShutdown")
+		}
+	}
+
+	if g := c.PXC.GaleraSegments; g.IsEnabled() {
+		if err := g.checkNSetDefaults(); err != nil {
+			return errors.Wrap(err, "PXC: galeraSegments")
+		}
+	}
+
+	if c.PXC.DataVolumes != nil {
+		if c.PXC.VolumeSpec.PersistentVolumeClaim == nil {
+			return errors.New("PXC: dataVolumes require volumeSpec.persistentVolumeClaim")
+		}
+		if err := c.PXC.DataVolumes.validate(); err != nil {
+			return errors.Wrap(err, "PXC: dataVolumes")
+		}
+	}
+
 	if c.HAProxyEnabled() && c.ProxySQLEnabled() {
 		return errors.New("can't enable both HAProxy and ProxySQL please only select one of them")
 	}
@@ -367,12 +2305,18 @@ func (cr *PerconaXtraDBCluster) Validate() error {
 		if c.HAProxy.Image == "" {
 			return errors.New("haproxy.Image can't be empty")
 		}
+		if err := c.HAProxy.validatePodMetadata(); err != nil {
+			return errors.Wrap(err, "HAProxy: podMetadata")
+		}
 	}
 
 	if c.ProxySQLEnabled() {
 		if c.ProxySQL.Image == "" {
 			return errors.New("proxysql.Image can't be empty")
 		}
+		if err := c.ProxySQL.validatePodMetadata(); err != nil {
+			return errors.Wrap(err, "ProxySQL: podMetadata")
+		}
 		if c.ProxySQL.VolumeSpec == nil {
 			return errors.New("ProxySQL: volumeSpec should be specified")
 		}
@@ -382,6 +2326,91 @@ func (cr *PerconaXtraDBCluster) Validate() error {
 		}
 	}
 
+	for _, w := range c.UpgradeOptions.MaintenanceWindows {
+		if _, err := cron.ParseStandard(w.Schedule); err != nil {
+			return errors.Wrapf(err, "upgradeOptions.maintenanceWindows: invalid schedule %s", w.Schedule)
+		}
+		if w.Duration.Duration <= 0 {
+			return errors.Errorf("upgradeOptions.maintenanceWindows: duration of %s must be positive", w.Schedule)
+		}
+	}
+	for _, b := range c.UpgradeOptions.Blackouts {
+		if !b.End.After(b.Start.Time) {
+			return errors.Errorf("upgradeOptions.blackouts: %s must end after it starts", b.Name)
+		}
+	}
+
+	if cc := c.ConsistencyCheck; cc.IsEnabled() {
+		if cc.Image == "" {
+			return errors.New("consistencyCheck.image can't be empty")
+		}
+		if _, err := cron.ParseStandard(cc.Schedule); err != nil {
+			return errors.Wrapf(err, "consistencyCheck: invalid schedule %s", cc.Schedule)
+		}
+	}
+
+	if qk := c.QueryKiller; qk.IsEnabled() {
+		if err := qk.checkNSetDefaults(); err != nil {
+			return errors.Wrap(err, "queryKiller")
+		}
+	}
+
+	if tm := c.TableMaintenance; tm.IsEnabled() {
+		if err := tm.checkNSetDefaults(); err != nil {
+			return errors.Wrap(err, "tableMaintenance")
+		}
+	}
+
+	if br := c.BinlogRetention; br.IsEnabled() {
+		if err := br.checkNSetDefaults(); err != nil {
+			return errors.Wrap(err, "binlogRetention")
+		}
+	}
+
+	for path, expose := range cr.exposes() {
+		if expose == nil {
+			continue
+		}
+		if expose.Gateway.IsEnabled() {
+			if err := expose.Gateway.checkNSetDefaults(); err != nil {
+				return errors.Wrapf(err, "%s.gateway", path)
+			}
+		}
+		if expose.ExternalDNS.IsEnabled() {
+			if err := expose.ExternalDNS.checkNSetDefaults(); err != nil {
+				return errors.Wrapf(err, "%s.externalDNS", path)
+			}
+		}
+	}
+
+	if v := c.Velero; v.IsEnabled() {
+		if err := v.checkNSetDefaults(); err != nil {
+			return errors.Wrap(err, "velero")
+		}
+	}
+
+	if sb := c.Standby; sb.IsEnabled() {
+		if sb.SourceCluster == "" {
+			return errors.New("standby.sourceCluster can't be empty")
+		}
+		if c.Backup == nil {
+			return errors.New("standby requires backup section")
+		}
+		for _, name := range []string{sb.StorageName, sb.BinlogStorageName} {
+			stg, ok := c.Backup.Storages[name]
+			if !ok || stg == nil || (stg.Type != BackupStorageS3 && stg.Type != BackupStorageAzure) {
+				return errors.Errorf("standby: storage %q must be an S3 or Azure storage", name)
+			}
+		}
+		if c.Backup.PITR.Enabled || len(c.PXC.ReplicationChannels) > 0 {
+			return errors.New("standby can't be used with point-in-time recovery or replication channels")
+		}
+	}
+
+	if c.UpgradeOptions.PreUpgradeBackup.IsEnabled() && c.Backup == nil {
+		return errors.New("upgradeOptions.preUpgradeBackup requires backup section")
+	}
+
 	if c.Backup != nil {
 		if c.Backup.Image == "" {
 			return errors.New("backup.Image can't be empty")
@@ -395,6 +2424,24 @@ func (cr *PerconaXtraDBCluster) Validate() error {
 				return errors.Errorf("pitr storage %s doesn't exist", cr.Spec.Backup.PITR.StorageName)
 			}
 		}
+		if gc := c.Backup.GarbageCollection; gc.IsEnabled() {
+			if err := gc.checkNSetDefaults(c.Backup.Storages); err != nil {
+				return errors.Wrap(err, "backup.garbageCollection")
+			}
+		}
+		if b := c.UpgradeOptions.PreUpgradeBackup; b.IsEnabled() {
+			if _, ok := c.Backup.Storages[b.StorageName]; !ok {
+				return errors.Errorf("upgradeOptions.preUpgradeBackup: storage %s doesn't exist", b.StorageName)
+			}
+		}
+		for name, strg := range c.Backup.Storages {
+			if strg == nil || strg.ContainerOptions == nil || strg.ContainerOptions.Tuning == nil {
+				continue
+			}
+			if err := strg.ContainerOptions.Tuning.validate(); err != nil {
+				return errors.Wrapf(err, "backup storage %s: containerOptions.tuning", name)
+			}
+		}
 		for _, sch := range c.Backup.Schedule {
 			strg, ok := cr.Spec.Backup.Storages[sch.StorageName]
 			if !ok {
@@ -426,6 +2473,58 @@ func (cr *PerconaXtraDBCluster) Validate() error {
 		}
 	}
 
+	if c.ExternalUsersSecret && c.UsersSecretSource != nil {
+		return errors.New("externalUsersSecret and usersSecretSource can't be used together")
+	}
+
+	if src := c.UsersSecretSource; src != nil {
+		switch src.Provider {
+		case SecretSourceAWS:
+			if src.AWS == nil || src.AWS.SecretID == "" {
+				return errors.New("usersSecretSource.aws.secretId can't be empty")
+			}
+		case SecretSourceGCP:
+			if src.GCP == nil || src.GCP.Project == "" || src.GCP.Secret == "" {
+				return errors.New("usersSecretSource.gcp.project and usersSecretSource.gcp.secret can't be empty")
+			}
+		case SecretSourceAzure:
+			if src.Azure == nil || src.Azure.VaultURL == "" || src.Azure.SecretName == "" {
+				return errors.New("usersSecretSource.azure.vaultURL and usersSecretSource.azure.secretName can't be empty")
+			}
+		default:
+			return errors.Errorf("usersSecretSource.provider %q is not supported", src.Provider)
+		}
+	}
+
+	if c.VaultDBSecrets.IsEnabled() {
+		if c.VaultDBSecrets.Address == "" {
+			return errors.New("vaultDBSecrets.address can't be empty")
+		}
+		if c.VaultDBSecrets.TokenSecretRef == nil || c.VaultDBSecrets.TokenSecretRef.Name == "" {
+			return errors.New("vaultDBSecrets.tokenSecretRef.name can't be empty")
+		}
+		vaultRoles := make(map[string]int8, len(c.VaultDBSecrets.Roles))
+		for _, role := range c.VaultDBSecrets.Roles {
+			if role.Name == "" {
+				return errors.New("vaultDBSecrets: role name can't be empty")
+			}
+			vaultRoles[role.Name]++
+			if vaultRoles[role.Name] > 1 {
+				return errors.Errorf("vaultDBSecrets: role %s is duplicated", role.Name)
+			}
+		}
+	}
+
+	if c.AuditTrail != nil && c.AuditTrail.Webhook != nil && c.AuditTrail.Webhook.URL == "" {
+		return errors.New("auditTrail.webhook.url can't be empty")
+	}
+
+	if h := c.OperationsHistory; h.IsEnabled() {
+		if err := h.checkNSetDefaults(); err != nil {
+			return errors.Wrap(err, "operationsHistory")
+		}
+	}
+
 	return nil
 }
 
@@ -508,6 +2607,75 @@ type PodSpec struct {
 	HookScript                   string                            `json:"hookScript,omitempty"`
 	Lifecycle                    corev1.Lifecycle                  `json:"lifecycle,omitempty"`
 	TopologySpreadConstraints    []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+	PodMetadata                  []PodMetadata                     `json:"podMetadata,omitempty"`
+}
+
+// PodMetadata are the extra labels and annotations of the pod with the ordinal.
+// The operator patches them to the running pod, so changing them doesn't restart it.
+// The keys removed from the spec are restored to the values of the pod template.
+type PodMetadata struct {
+	Ordinal     int32             `json:"ordinal"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// PodMetadataFor returns the metadata of the pod with the ordinal, nil if there is none.
+func (p *PodSpec) PodMetadataFor(ordinal int32) *PodMetadata {
+	for i := range p.PodMetadata {
+		if p.PodMetadata[i].Ordinal == ordinal {
+			return &p.PodMetadata[i]
+		}
+	}
+	return nil
+}
+
+func (p *PodSpec) validatePodMetadata() error {
+	ordinals := make(map[int32]struct{}, len(p.PodMetadata))
+	for _, m := range p.PodMetadata {
+		if m.Ordinal < 0 {
+			return errors.Errorf("ordinal %d can't be negative", m.Ordinal)
+		}
+		if _, ok := ordinals[m.Ordinal]; ok {
+			return errors.Errorf("ordinal %d is specified more than once", m.Ordinal)
+		}
+		ordinals[m.Ordinal] = struct{}{}
+		for k, v := range m.Labels {
+			if err := validateMetadataKey(k); err != nil {
+				return errors.Wrapf(err, "ordinal %d", m.Ordinal)
+			}
+			if errs := validation.IsValidLabelValue(v); len(errs) > 0 {
+				return errors.Errorf("ordinal %d: invalid value of label %s: %s", m.Ordinal, k, strings.Join(errs, "; "))
+			}
+		}
+		for k := range m.Annotations {
+			if err := validateMetadataKey(k); err != nil {
+				return errors.Wrapf(err, "ordinal %d", m.Ordinal)
+			}
+		}
+	}
+	return nil
+}
+
+// reservedMetadataKeyPrefixes are the prefixes of the keys set by the operator and by the statefulset controller,
+// the selectors of the statefulsets and services depend on them.
+var reservedMetadataKeyPrefixes = []string{
+	"app.kubernetes.io/",
+	"statefulset.kubernetes.io/",
+	"apps.kubernetes.io/",
+	"percona.com/",
+	"controller-revision-hash",
+}
+
+func validateMetadataKey(key string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return errors.Errorf("invalid key %s: %s", key, strings.Join(errs, "; "))
+	}
+	for _, prefix := range reservedMetadataKeyPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return errors.Errorf("key %s is reserved", key)
+		}
+	}
+	return nil
 }
 
 func (spec *PodSpec) HasSidecarInternalSecret(secret *corev1.Secret) bool {
@@ -567,24 +2735,132 @@ type PodDisruptionBudgetSpec struct {
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
-type PodAffinity struct {
-	TopologyKey *string          `json:"antiAffinityTopologyKey,omitempty"`
-	Advanced    *corev1.Affinity `json:"advanced,omitempty"`
+type PodAffinity struct {
+	TopologyKey *string          `json:"antiAffinityTopologyKey,omitempty"`
+	Advanced    *corev1.Affinity `json:"advanced,omitempty"`
+}
+
+type LogCollectorSpec struct {
+	Enabled                  bool                        `json:"enabled,omitempty"`
+	Image                    string                      `json:"image,omitempty"`
+	Resources                corev1.ResourceRequirements `json:"resources,omitempty"`
+	Configuration            string                      `json:"configuration,omitempty"`
+	ContainerSecurityContext *corev1.SecurityContext     `json:"containerSecurityContext,omitempty"`
+	// +kubebuilder:default=Always
+	ImagePullPolicy  corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	RuntimeClassName *string           `json:"runtimeClassName,omitempty"`
+	HookScript       string            `json:"hookScript,omitempty"`
+	SlowQueryLog     *SlowQueryLogSpec `json:"slowQueryLog,omitempty"`
+	AuditLog         *AuditLogSpec     `json:"auditLog,omitempty"`
+	Sink             *LogSinkSpec      `json:"sink,omitempty"`
+}
+
+// SlowQueryLogSpec enables the slow query log on PXC nodes.
+// The log is rotated by mysqld and shipped by the log collector if the sink is configured.
+type SlowQueryLogSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// LongQueryTime is the threshold in seconds, fractional values are allowed.
+	LongQueryTime             string `json:"longQueryTime,omitempty"`
+	MinExaminedRowLimit       int64  `json:"minExaminedRowLimit,omitempty"`
+	LogQueriesNotUsingIndexes bool   `json:"logQueriesNotUsingIndexes,omitempty"`
+	RateLimit                 int32  `json:"rateLimit,omitempty"`
+	Verbosity                 string `json:"verbosity,omitempty"`
+	// MaxSize is the size of the log file before rotation, e.g. 100M.
+	MaxSize string `json:"maxSize,omitempty"`
+	// MaxFiles is the number of rotated log files to keep.
+	MaxFiles int32 `json:"maxFiles,omitempty"`
+}
+
+func (s *SlowQueryLogSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+// AuditLogSpec configures Percona audit_log plugin. The plugin is loaded
+// on mysqld startup and its log is shipped by the log collector if the sink is configured.
+type AuditLogSpec struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// Format is one of OLD, NEW, JSON or CSV. JSON is used by default.
+	// +kubebuilder:default=JSON
+	Format string `json:"format,omitempty"`
+	// Policy is one of ALL, LOGINS, QUERIES or NONE. ALL is used by default.
+	// +kubebuilder:default=ALL
+	Policy          string   `json:"policy,omitempty"`
+	IncludeAccounts []string `json:"includeAccounts,omitempty"`
+	ExcludeAccounts []string `json:"excludeAccounts,omitempty"`
+	// RotateOnSize is the size of the log file before rotation, e.g. 100M.
+	RotateOnSize string `json:"rotateOnSize,omitempty"`
+	// Rotations is the number of rotated log files to keep.
+	Rotations int32 `json:"rotations,omitempty"`
+}
+
+func (s *AuditLogSpec) IsEnabled() bool {
+	return s != nil && s.Enabled
+}
+
+func (s *AuditLogSpec) validate() error {
+	switch s.Format {
+	case "", "OLD", "NEW", "JSON", "CSV":
+	default:
+		return errors.Errorf("unknown format %s", s.Format)
+	}
+	switch s.Policy {
+	case "", "ALL", "LOGINS", "QUERIES", "NONE":
+	default:
+		return errors.Errorf("unknown policy %s", s.Policy)
+	}
+	if len(s.IncludeAccounts) > 0 && len(s.ExcludeAccounts) > 0 {
+		return errors.New("includeAccounts and excludeAccounts are mutually exclusive")
+	}
+	return nil
+}
+
+type LogSinkType string
+
+const (
+	LogSinkLoki          LogSinkType = "loki"
+	LogSinkElasticsearch LogSinkType = "elasticsearch"
+	LogSinkS3            LogSinkType = "s3"
+)
+
+// LogSinkSpec is the destination of logs shipped by the log collector.
+// Credentials are read from the log collector secret: LOG_SINK_USER and LOG_SINK_PASSWORD
+// for Loki and Elasticsearch, AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY for S3.
+type LogSinkSpec struct {
+	Type LogSinkType `json:"type"`
+	// Host and Port of Loki or Elasticsearch.
+	Host      string `json:"host,omitempty"`
+	Port      int32  `json:"port,omitempty"`
+	TLS       bool   `json:"tls,omitempty"`
+	BasicAuth bool   `json:"basicAuth,omitempty"`
+	// Labels are added to Loki streams.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Index is the Elasticsearch index.
+	Index       string `json:"index,omitempty"`
+	Bucket      string `json:"bucket,omitempty"`
+	Region      string `json:"region,omitempty"`
+	EndpointURL string `json:"endpointUrl,omitempty"`
+	Prefix      string `json:"prefix,omitempty"`
 }
 
-type LogCollectorSpec struct {
-	Enabled                  bool                        `json:"enabled,omitempty"`
-	Image                    string                      `json:"image,omitempty"`
-	Resources                corev1.ResourceRequirements `json:"resources,omitempty"`
-	Configuration            string                      `json:"configuration,omitempty"`
-	ContainerSecurityContext *corev1.SecurityContext     `json:"containerSecurityContext,omitempty"`
-	ImagePullPolicy          corev1.PullPolicy           `json:"imagePullPolicy,omitempty"`
-	RuntimeClassName         *string                     `json:"runtimeClassName,omitempty"`
-	HookScript               string                      `json:"hookScript,omitempty"`
+func (s *LogSinkSpec) validate() error {
+	switch s.Type {
+	case LogSinkLoki, LogSinkElasticsearch:
+		if s.Host == "" {
+			return errors.Errorf("host is required for %s sink", s.Type)
+		}
+	case LogSinkS3:
+		if s.Bucket == "" {
+			return errors.New("bucket is required for s3 sink")
+		}
+	default:
+		return errors.Errorf("unknown sink type %s", s.Type)
+	}
+	return nil
 }
 
 type PMMSpec struct {
 	Enabled                  bool                        `json:"enabled,omitempty"`
+	Version                  string                      `json:"version,omitempty"`
 	ServerHost               string                      `json:"serverHost,omitempty"`
 	Image                    string                      `json:"image,omitempty"`
 	ServerUser               string                      `json:"serverUser,omitempty"`
@@ -592,10 +2868,11 @@ type PMMSpec struct {
 	ProxysqlParams           string                      `json:"proxysqlParams,omitempty"`
 	Resources                corev1.ResourceRequirements `json:"resources,omitempty"`
 	ContainerSecurityContext *corev1.SecurityContext     `json:"containerSecurityContext,omitempty"`
-	ImagePullPolicy          corev1.PullPolicy           `json:"imagePullPolicy,omitempty"`
-	RuntimeClassName         *string                     `json:"runtimeClassName,omitempty"`
-	LivenessProbes           *corev1.Probe               `json:"livenessProbes,omitempty"`
-	ReadinessProbes          *corev1.Probe               `json:"readinessProbes,omitempty"`
+	// +kubebuilder:default=Always
+	ImagePullPolicy  corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	RuntimeClassName *string           `json:"runtimeClassName,omitempty"`
+	LivenessProbes   *corev1.Probe     `json:"livenessProbes,omitempty"`
+	ReadinessProbes  *corev1.Probe     `json:"readinessProbes,omitempty"`
 }
 
 func (spec *PMMSpec) IsEnabled(secret *corev1.Secret) bool {
@@ -603,7 +2880,11 @@ func (spec *PMMSpec) IsEnabled(secret *corev1.Secret) bool {
 }
 
 func (spec *PMMSpec) HasSecret(secret *corev1.Secret) bool {
-	for _, key := range []string{users.PMMServer, users.PMMServerKey} {
+	keys := []string{users.PMMServer, users.PMMServerKey}
+	if spec.IsPMM3(secret) {
+		keys = []string{users.PMMServerToken}
+	}
+	for _, key := range keys {
 		if _, ok := secret.Data[key]; ok {
 			return true
 		}
@@ -611,6 +2892,23 @@ func (spec *PMMSpec) HasSecret(secret *corev1.Secret) bool {
 	return false
 }
 
+const (
+	PMMVersion2 = "2"
+	PMMVersion3 = "3"
+)
+
+// IsPMM3 returns true if the PMM 3 client should be used
+func (spec *PMMSpec) IsPMM3(secret *corev1.Secret) bool {
+	switch spec.Version {
+	case PMMVersion2:
+		return false
+	case PMMVersion3:
+		return true
+	}
+	_, ok := secret.Data[users.PMMServerToken]
+	return ok
+}
+
 func (spec *PMMSpec) UseAPI(secret *corev1.Secret) bool {
 	if _, ok := secret.Data[users.PMMServerKey]; !ok {
 		if _, ok := secret.Data[users.PMMServer]; ok {
@@ -642,17 +2940,39 @@ type BackupStorageSpec struct {
 }
 
 type BackupContainerOptions struct {
-	Env  []corev1.EnvVar     `json:"env,omitempty"`
-	Args BackupContainerArgs `json:"args,omitempty"`
+	Env    []corev1.EnvVar     `json:"env,omitempty"`
+	Args   BackupContainerArgs `json:"args,omitempty"`
+	Tuning *XtrabackupTuning   `json:"tuning,omitempty"`
+}
+
+// XtrabackupTuning overrides the defaults xtrabackup runs with in backup and restore jobs.
+type XtrabackupTuning struct {
+	// UseMemory is the memory the backup is prepared with (--use-memory).
+	// By default it's 75% of the memory of the restore job, but not more than 2GB.
+	UseMemory *resource.Quantity `json:"useMemory,omitempty"`
+	// Parallel is the number of threads data files are copied and prepared with (--parallel).
+	Parallel int32 `json:"parallel,omitempty"`
+	// PrepareResources are the resources of the restore job container preparing the backup.
+	// They take precedence over the resources of the restore.
+	PrepareResources *corev1.ResourceRequirements `json:"prepareResources,omitempty"`
 }
 
 func (b *BackupContainerOptions) GetEnv() []corev1.EnvVar {
-	return util.MergeEnvLists(b.Env, b.Args.Env())
+	return util.MergeEnvLists(b.Env, b.argsEnv())
+}
+
+// argsEnv returns the env of the container arguments together with the tuning ones.
+func (b *BackupContainerOptions) argsEnv() []corev1.EnvVar {
+	args := b.Args
+	if b.Tuning != nil && b.Tuning.Parallel > 0 {
+		args.Xtrabackup = append(append([]string{}, args.Xtrabackup...), fmt.Sprintf("--parallel=%d", b.Tuning.Parallel))
+	}
+	return args.Env()
 }
 
 func (b *BackupContainerOptions) GetEnvVar(cluster *PerconaXtraDBCluster, storageName string) []corev1.EnvVar {
 	if b != nil {
-		return util.MergeEnvLists(b.Args.Env(), b.Env)
+		return util.MergeEnvLists(b.argsEnv(), b.Env)
 	}
 
 	if cluster == nil || cluster.Spec.Backup == nil {
@@ -666,6 +2986,33 @@ func (b *BackupContainerOptions) GetEnvVar(cluster *PerconaXtraDBCluster, storag
 	return storage.ContainerOptions.GetEnvVar(nil, "")
 }
 
+// GetTuning returns the xtrabackup tuning of the options or of the storage if the options aren't set.
+func (b *BackupContainerOptions) GetTuning(cluster *PerconaXtraDBCluster, storageName string) *XtrabackupTuning {
+	if b != nil {
+		return b.Tuning
+	}
+
+	if cluster == nil || cluster.Spec.Backup == nil {
+		return nil
+	}
+
+	storage, ok := cluster.Spec.Backup.Storages[storageName]
+	if !ok || storage.ContainerOptions == nil {
+		return nil
+	}
+	return storage.ContainerOptions.Tuning
+}
+
+func (t *XtrabackupTuning) validate() error {
+	if t.Parallel < 0 {
+		return errors.New("parallel can't be negative")
+	}
+	if t.UseMemory != nil && t.UseMemory.Sign() <= 0 {
+		return errors.New("useMemory must be positive")
+	}
+	return nil
+}
+
 type BackupContainerArgs struct {
 	Xtrabackup []string `json:"xtrabackup,omitempty"`
 	Xbcloud    []string `json:"xbcloud,omitempty"`
@@ -708,6 +3055,101 @@ type BackupStorageS3Spec struct {
 	CredentialsSecret string `json:"credentialsSecret"`
 	Region            string `json:"region,omitempty"`
 	EndpointURL       string `json:"endpointUrl,omitempty"`
+	// Alternates are the endpoints used in order if the main one is unavailable,
+	// e.g. the replicas of the bucket in other regions.
+	Alternates []BackupStorageS3Alternate `json:"alternates,omitempty"`
+}
+
+// BackupStorageS3Alternate is an alternate endpoint of the S3 storage.
+// The empty fields are taken from the main endpoint.
+type BackupStorageS3Alternate struct {
+	Bucket            string `json:"bucket,omitempty"`
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
+	Region            string `json:"region,omitempty"`
+	EndpointURL       string `json:"endpointUrl,omitempty"`
+}
+
+// Endpoints returns the main endpoint of the storage followed by the alternates.
+// The returned specs don't have alternates.
+func (b *BackupStorageS3Spec) Endpoints() []BackupStorageS3Spec {
+	main := BackupStorageS3Spec{
+		Bucket:            b.Bucket,
+		CredentialsSecret: b.CredentialsSecret,
+		Region:            b.Region,
+		EndpointURL:       b.EndpointURL,
+	}
+
+	endpoints := []BackupStorageS3Spec{main}
+	for _, a := range b.Alternates {
+		e := main
+		if a.Bucket != "" {
+			e.Bucket = a.Bucket
+		}
+		if a.CredentialsSecret != "" {
+			e.CredentialsSecret = a.CredentialsSecret
+		}
+		if a.Region != "" {
+			e.Region = a.Region
+		}
+		if a.EndpointURL != "" {
+			e.EndpointURL = a.EndpointURL
+		}
+		endpoints = append(endpoints, e)
+	}
+	return endpoints
+}
+
+// Failover returns the storage using the i-th endpoint of Endpoints as the main one.
+// The other endpoints, in the same order, become its alternates.
+func (b *BackupStorageS3Spec) Failover(i int) *BackupStorageS3Spec {
+	endpoints := b.Endpoints()
+	if i <= 0 || i >= len(endpoints) {
+		return b.DeepCopy()
+	}
+
+	s3 := endpoints[i]
+	for j, e := range endpoints {
+		if j == i {
+			continue
+		}
+		s3.Alternates = append(s3.Alternates, BackupStorageS3Alternate{
+			Bucket:            e.Bucket,
+			CredentialsSecret: e.CredentialsSecret,
+			Region:            e.Region,
+			EndpointURL:       e.EndpointURL,
+		})
+	}
+	return &s3
+}
+
+// S3AlternateEnvName returns the name of the environment variable of the i-th alternate endpoint.
+func S3AlternateEnvName(prefix string, i int, name string) string {
+	return fmt.Sprintf("%sS3_ALTERNATE_%d_%s", prefix, i, name)
+}
+
+// AlternateEnvs returns the environment variables passing the alternate endpoints
+// to the binlog collector and the point-in-time recovery. The names start with prefix.
+func (b *BackupStorageS3Spec) AlternateEnvs(prefix string) []corev1.EnvVar {
+	var envs []corev1.EnvVar
+	for i, e := range b.Endpoints()[1:] {
+		envs = append(envs,
+			corev1.EnvVar{Name: S3AlternateEnvName(prefix, i, "BUCKET_URL"), Value: e.Bucket},
+			corev1.EnvVar{Name: S3AlternateEnvName(prefix, i, "REGION"), Value: e.Region},
+			corev1.EnvVar{Name: S3AlternateEnvName(prefix, i, "ENDPOINT"), Value: e.EndpointURL},
+		)
+		if e.CredentialsSecret == "" {
+			continue
+		}
+		for _, name := range []string{"ACCESS_KEY_ID", "SECRET_ACCESS_KEY"} {
+			selector := &corev1.SecretKeySelector{Key: "AWS_" + name}
+			selector.Name = e.CredentialsSecret
+			envs = append(envs, corev1.EnvVar{
+				Name:      S3AlternateEnvName(prefix, i, name),
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: selector},
+			})
+		}
+	}
+	return envs
 }
 
 // BucketAndPrefix returns bucket name and backup prefix from Bucket.
@@ -837,6 +3279,88 @@ func (cr *PerconaXtraDBCluster) setPodSecurityContext() {
 			}
 		}
 	}
+
+	if cr.Spec.Platform == version.PlatformOpenshift && cr.CompareVersionWith("1.17.0") >= 0 {
+		cr.setRestrictedSecurityContexts()
+	}
+}
+
+// setRestrictedSecurityContexts makes the pods of the cluster admitted by the
+// restricted-v2 SCC of OpenShift without granting other SCCs to the service accounts.
+// The user and fsGroup are left to the SCC, which assigns them from the
+// namespace range, the images run with any user of the root group.
+// Security contexts set in the spec are kept as is.
+func (cr *PerconaXtraDBCluster) setRestrictedSecurityContexts() {
+	restrictPod := func(sc *corev1.PodSecurityContext) *corev1.PodSecurityContext {
+		if sc == nil {
+			sc = &corev1.PodSecurityContext{SupplementalGroups: []int64{1001}}
+		} else {
+			sc = sc.DeepCopy()
+		}
+		if sc.RunAsNonRoot == nil {
+			sc.RunAsNonRoot = func(b bool) *bool { return &b }(true)
+		}
+		if sc.SeccompProfile == nil {
+			sc.SeccompProfile = &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault}
+		}
+		if sc.FSGroupChangePolicy == nil {
+			policy := corev1.FSGroupChangeOnRootMismatch
+			sc.FSGroupChangePolicy = &policy
+		}
+		return sc
+	}
+	setContainer := func(sc **corev1.SecurityContext) {
+		if *sc == nil {
+			*sc = restrictedSecurityContext()
+		}
+	}
+
+	c := &cr.Spec
+	if c.PXC != nil {
+		c.PXC.PodSecurityContext = restrictPod(c.PXC.PodSecurityContext)
+		setContainer(&c.PXC.ContainerSecurityContext)
+		if c.PXC.Metrics != nil {
+			setContainer(&c.PXC.Metrics.ContainerSecurityContext)
+		}
+	}
+	if c.HAProxy != nil {
+		c.HAProxy.PodSecurityContext = restrictPod(c.HAProxy.PodSecurityContext)
+		setContainer(&c.HAProxy.ContainerSecurityContext)
+	}
+	if c.ProxySQL != nil {
+		c.ProxySQL.PodSecurityContext = restrictPod(c.ProxySQL.PodSecurityContext)
+		setContainer(&c.ProxySQL.ContainerSecurityContext)
+	}
+	if c.LogCollector != nil {
+		setContainer(&c.LogCollector.ContainerSecurityContext)
+	}
+	if c.PMM != nil {
+		setContainer(&c.PMM.ContainerSecurityContext)
+	}
+	if c.Backup != nil {
+		for _, stg := range c.Backup.Storages {
+			if stg == nil {
+				continue
+			}
+			stg.PodSecurityContext = restrictPod(stg.PodSecurityContext)
+			setContainer(&stg.ContainerSecurityContext)
+		}
+	}
+}
+
+// restrictedSecurityContext returns the container security context accepted by
+// the restricted-v2 SCC and the restricted Pod Security Standard.
+func restrictedSecurityContext() *corev1.SecurityContext {
+	f := false
+	t := true
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: &f,
+		RunAsNonRoot:             &t,
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}
 }
 
 func (cr *PerconaXtraDBCluster) ShouldWaitForTokenIssue() bool {
@@ -866,10 +3390,6 @@ func (cr *PerconaXtraDBCluster) CheckNSetDefaults(serverVersion *version.ServerV
 	if c.PXC != nil {
 		c.PXC.VolumeSpec.reconcileOpts()
 
-		if len(c.PXC.ImagePullPolicy) == 0 {
-			c.PXC.ImagePullPolicy = corev1.PullAlways
-		}
-
 		c.PXC.VaultSecretName = c.VaultSecretName
 		if len(c.PXC.VaultSecretName) == 0 {
 			c.PXC.VaultSecretName = cr.Name + "-vault"
@@ -892,14 +3412,6 @@ func (cr *PerconaXtraDBCluster) CheckNSetDefaults(serverVersion *version.ServerV
 		}
 
 		for chIdx, channel := range c.PXC.ReplicationChannels {
-			for srcIdx, src := range channel.SourcesList {
-				if src.Weight == 0 {
-					c.PXC.ReplicationChannels[chIdx].SourcesList[srcIdx].Weight = 100
-				}
-				if src.Port == 0 {
-					c.PXC.ReplicationChannels[chIdx].SourcesList[srcIdx].Port = 3306
-				}
-			}
 			if !channel.IsSource && channel.Config == nil {
 				c.PXC.ReplicationChannels[chIdx].Config = &ReplicationChannelConfig{
 					SourceRetryCount:   3,
@@ -908,6 +3420,19 @@ func (cr *PerconaXtraDBCluster) CheckNSetDefaults(serverVersion *version.ServerV
 			}
 		}
 
+		if ext := c.PXC.ExternalReplication; ext != nil {
+			if len(ext.Hosts) == 0 {
+				ext.Hosts = []string{"%"}
+			}
+			if ext.PasswordSecretRef != nil && ext.PasswordSecretRef.Key == "" {
+				ext.PasswordSecretRef.Key = "password"
+			}
+		}
+
+		if c.PXC.Reporting.IsEnabled() && c.PXC.Size < 3 {
+			return errors.New("pxc.reporting requires at least 3 PXC pods")
+		}
+
 		t := true
 		f := false
 		if c.TLS == nil {
@@ -929,6 +3454,12 @@ func (cr *PerconaXtraDBCluster) CheckNSetDefaults(serverVersion *version.ServerV
 			c.TLS.Enabled = &t
 		}
 
+		if c.PXC.SST != nil {
+			if err := c.PXC.SST.validate(cr.TLSEnabled()); err != nil {
+				return errors.Wrap(err, "PXC: sst")
+			}
+		}
+
 		if cr.DeletionTimestamp == nil && !cr.Spec.Pause {
 			if cr.CompareVersionWith("1.15.0") < 0 {
 				setSafeDefaults(c, logger)
@@ -939,13 +3470,6 @@ func (cr *PerconaXtraDBCluster) CheckNSetDefaults(serverVersion *version.ServerV
 			}
 		}
 
-		// Set maxUnavailable = 1 by default for PodDisruptionBudget-PXC.
-		// It's a description of the number of pods from that set that can be unavailable after the eviction.
-		if c.PXC.PodDisruptionBudget == nil {
-			defaultMaxUnavailable := intstr.FromInt(1)
-			c.PXC.PodDisruptionBudget = &PodDisruptionBudgetSpec{MaxUnavailable: &defaultMaxUnavailable}
-		}
-
 		if c.PXC.TerminationGracePeriodSeconds == nil {
 			c.PXC.TerminationGracePeriodSeconds = &defaultPXCGracePeriodSec
 		}
@@ -983,15 +3507,20 @@ func (cr *PerconaXtraDBCluster) CheckNSetDefaults(serverVersion *version.ServerV
 		}
 	}
 
-	if c.PMM != nil && c.PMM.Enabled {
-		if len(c.PMM.ImagePullPolicy) == 0 {
-			c.PMM.ImagePullPolicy = corev1.PullAlways
+	if c.LogCollector != nil && c.LogCollector.Enabled {
+		if sink := c.LogCollector.Sink; sink != nil && sink.Port == 0 {
+			switch sink.Type {
+			case LogSinkLoki:
+				sink.Port = 3100
+			case LogSinkElasticsearch:
+				sink.Port = 9200
+			}
 		}
 	}
 
-	if c.LogCollector != nil && c.LogCollector.Enabled {
-		if len(c.LogCollector.ImagePullPolicy) == 0 {
-			c.LogCollector.ImagePullPolicy = corev1.PullAlways
+	if m := c.Monitoring; m != nil {
+		if d := m.Dashboard; d.IsEnabled() && len(d.Labels) == 0 {
+			d.Labels = map[string]string{"grafana_dashboard": "1"}
 		}
 	}
 
@@ -1011,16 +3540,6 @@ func (cr *PerconaXtraDBCluster) CheckNSetDefaults(serverVersion *version.ServerV
 			}
 		}
 
-		if len(c.HAProxy.ImagePullPolicy) == 0 {
-			c.HAProxy.ImagePullPolicy = corev1.PullAlways
-		}
-
-		// Set maxUnavailable = 1 by default for PodDisruptionBudget-HAProxy.
-		if c.HAProxy.PodDisruptionBudget == nil {
-			defaultMaxUnavailable := intstr.FromInt(1)
-			c.HAProxy.PodDisruptionBudget = &PodDisruptionBudgetSpec{MaxUnavailable: &defaultMaxUnavailable}
-		}
-
 		if c.HAProxy.TerminationGracePeriodSeconds == nil {
 			graceSec := int64(30)
 			c.HAProxy.TerminationGracePeriodSeconds = &graceSec
@@ -1046,10 +3565,6 @@ func (cr *PerconaXtraDBCluster) CheckNSetDefaults(serverVersion *version.ServerV
 	}
 
 	if c.ProxySQLEnabled() {
-		if len(c.ProxySQL.ImagePullPolicy) == 0 {
-			c.ProxySQL.ImagePullPolicy = corev1.PullAlways
-		}
-
 		c.ProxySQL.VolumeSpec.reconcileOpts()
 
 		if len(c.SSLSecretName) > 0 {
@@ -1064,12 +3579,6 @@ func (cr *PerconaXtraDBCluster) CheckNSetDefaults(serverVersion *version.ServerV
 			c.ProxySQL.SSLInternalSecretName = cr.Name + "-ssl-internal"
 		}
 
-		// Set maxUnavailable = 1 by default for PodDisruptionBudget-ProxySQL.
-		if c.ProxySQL.PodDisruptionBudget == nil {
-			defaultMaxUnavailable := intstr.FromInt(1)
-			c.ProxySQL.PodDisruptionBudget = &PodDisruptionBudgetSpec{MaxUnavailable: &defaultMaxUnavailable}
-		}
-
 		if c.ProxySQL.TerminationGracePeriodSeconds == nil {
 			graceSec := int64(30)
 			c.ProxySQL.TerminationGracePeriodSeconds = &graceSec
@@ -1095,20 +3604,6 @@ func (cr *PerconaXtraDBCluster) CheckNSetDefaults(serverVersion *version.ServerV
 	}
 
 	if c.Backup != nil {
-
-		if len(c.Backup.ImagePullPolicy) == 0 {
-			c.Backup.ImagePullPolicy = corev1.PullAlways
-		}
-		if cr.Spec.Backup.PITR.Enabled {
-			if cr.Spec.Backup.PITR.TimeBetweenUploads == 0 {
-				cr.Spec.Backup.PITR.TimeBetweenUploads = 60
-			}
-
-			if cr.Spec.Backup.PITR.TimeoutSeconds == 0 {
-				cr.Spec.Backup.PITR.TimeoutSeconds = 3600
-			}
-		}
-
 		for _, sch := range c.Backup.Schedule {
 			strg := c.Backup.Storages[sch.StorageName]
 			switch strg.Type {
@@ -1131,11 +3626,6 @@ func (cr *PerconaXtraDBCluster) CheckNSetDefaults(serverVersion *version.ServerV
 	cr.setProbesDefaults()
 	cr.setPodSecurityContext()
 
-	if cr.Spec.EnableCRValidationWebhook == nil {
-		falseVal := false
-		cr.Spec.EnableCRValidationWebhook = &falseVal
-	}
-
 	if cr.Spec.UpgradeOptions.Apply == "" {
 		cr.Spec.UpgradeOptions.Apply = UpgradeStrategyDisabled
 	}
@@ -1144,6 +3634,19 @@ func (cr *PerconaXtraDBCluster) CheckNSetDefaults(serverVersion *version.ServerV
 		cr.Spec.UpgradeOptions.VersionServiceEndpoint = DefaultVersionServiceEndpoint
 	}
 
+	if cr.Spec.UpgradeOptions.Canary.IsEnabled() && cr.Spec.UpgradeOptions.Canary.SoakPeriod == nil {
+		cr.Spec.UpgradeOptions.Canary.SoakPeriod = &metav1.Duration{Duration: 10 * time.Minute}
+	}
+
+	if c.VaultDBSecrets.IsEnabled() {
+		if c.VaultDBSecrets.ConnectionName == "" {
+			c.VaultDBSecrets.ConnectionName = cr.Namespace + "-" + cr.Name
+		}
+		if c.VaultDBSecrets.TokenSecretRef.Key == "" {
+			c.VaultDBSecrets.TokenSecretRef.Key = "token"
+		}
+	}
+
 	if cr.CompareVersionWith("1.14.0") >= 0 {
 		if cr.Spec.InitContainer.Resources == nil {
 			cr.Spec.InitContainer.Resources = &corev1.ResourceRequirements{
@@ -1411,6 +3914,41 @@ func (p *PodSpec) executeConfigurationTemplate() error {
 	return nil
 }
 
+func (a *AuthenticationSpec) validate() error {
+	if a.LDAP != nil && a.OIDC != nil {
+		return errors.New("only one of ldap or oidc can be specified")
+	}
+
+	if l := a.LDAP; l != nil {
+		switch l.Mechanism {
+		case "", LDAPAuthSimple, LDAPAuthSASL:
+		default:
+			return errors.Errorf("unknown ldap mechanism %s", l.Mechanism)
+		}
+		if len(l.ServerURIs) == 0 || len(l.ServerURIs) > 2 {
+			return errors.New("ldap.serverURIs should contain one or two URIs")
+		}
+		for _, uri := range l.ServerURIs {
+			if !strings.HasPrefix(uri, "ldap://") && !strings.HasPrefix(uri, "ldaps://") {
+				return errors.Errorf("invalid ldap server uri %s", uri)
+			}
+		}
+	}
+
+	if o := a.OIDC; o != nil {
+		if len(o.Providers) == 0 {
+			return errors.New("oidc.providers can't be empty")
+		}
+		for _, p := range o.Providers {
+			if p.Name == "" || p.Issuer == "" {
+				return errors.New("oidc provider name and issuer can't be empty")
+			}
+		}
+	}
+
+	return nil
+}
+
 func (v *VolumeSpec) reconcileOpts() {
 	if v.EmptyDir == nil && v.HostPath == nil && v.PersistentVolumeClaim == nil {
 		v.PersistentVolumeClaim = &corev1.PersistentVolumeClaimSpec{}
@@ -1531,6 +4069,15 @@ func (cr *PerconaXtraDBCluster) HAProxyReplicasNamespacedName() types.Namespaced
 	}
 }
 
+// ReportingPodName returns the name of the PXC pod dedicated to reporting
+// or an empty string if there is no such pod.
+func (cr *PerconaXtraDBCluster) ReportingPodName() string {
+	if cr.Spec.PXC == nil || !cr.Spec.PXC.Reporting.IsEnabled() {
+		return ""
+	}
+	return fmt.Sprintf("%s-pxc-%d", cr.Name, cr.Spec.PXC.Size-1)
+}
+
 func (cr *PerconaXtraDBCluster) HAProxyEnabled() bool {
 	return cr.Spec.HAProxy != nil && cr.Spec.HAProxy.Enabled
 }
@@ -1543,6 +4090,29 @@ func (cr *PerconaXtraDBCluster) HAProxyReplicasServiceEnabled() bool {
 	return cr.Spec.HAProxy.ExposeReplicas.ServiceExpose.Enabled
 }
 
+// exposes returns the service exposure settings of the cluster by their paths.
+func (cr *PerconaXtraDBCluster) exposes() map[string]*ServiceExpose {
+	exposes := map[string]*ServiceExpose{
+		"pxc.expose": &cr.Spec.PXC.Expose,
+	}
+	if er := cr.Spec.PXC.ExternalReplication; er != nil {
+		exposes["pxc.externalReplication.expose"] = &er.Expose
+	}
+	if rp := cr.Spec.PXC.Reporting; rp != nil {
+		exposes["pxc.reporting.expose"] = &rp.Expose
+	}
+	if cr.Spec.HAProxy != nil {
+		exposes["haproxy.exposePrimary"] = &cr.Spec.HAProxy.ExposePrimary
+		if cr.Spec.HAProxy.ExposeReplicas != nil {
+			exposes["haproxy.exposeReplicas"] = &cr.Spec.HAProxy.ExposeReplicas.ServiceExpose
+		}
+	}
+	if cr.Spec.ProxySQL != nil {
+		exposes["proxysql.expose"] = &cr.Spec.ProxySQL.Expose
+	}
+	return exposes
+}
+
 func (cr *PerconaXtraDBCluster) ProxySQLEnabled() bool {
 	return cr.Spec.ProxySQL != nil && cr.Spec.ProxySQL.Enabled
 }
@@ -1568,19 +4138,28 @@ func (s *PerconaXtraDBClusterStatus) ClusterStatus(inProgress, deleted bool) App
 
 const maxStatusesQuantity = 20
 
+// AddCondition appends the condition to the history of cluster states.
+// Ready, Progressing and Degraded conditions aren't a part of the history and are kept at the end.
 func (s *PerconaXtraDBClusterStatus) AddCondition(c ClusterCondition) {
-	if len(s.Conditions) == 0 {
-		s.Conditions = append(s.Conditions, c)
-		return
+	history := make([]ClusterCondition, 0, len(s.Conditions)+1)
+	var state []ClusterCondition
+	for _, cond := range s.Conditions {
+		if isStateCondition(cond.Type) {
+			state = append(state, cond)
+			continue
+		}
+		history = append(history, cond)
 	}
 
-	if s.Conditions[len(s.Conditions)-1].Type != c.Type {
-		s.Conditions = append(s.Conditions, c)
+	if len(history) == 0 || history[len(history)-1].Type != c.Type {
+		history = append(history, c)
 	}
 
-	if len(s.Conditions) > maxStatusesQuantity {
-		s.Conditions = s.Conditions[len(s.Conditions)-maxStatusesQuantity:]
+	if len(history) > maxStatusesQuantity {
+		history = history[len(history)-maxStatusesQuantity:]
 	}
+
+	s.Conditions = append(history, state...)
 }
 
 // FindCondition finds the conditionType in conditions.
@@ -1624,6 +4203,176 @@ func (s *PerconaXtraDBClusterSpec) ProxySQLEnabled() bool {
 
 const AnnotationPVCResizeInProgress = "percona.com/pvc-resize-in-progress"
 
+// AnnotationApprovedChanges approves the pending changes with the same ID.
+const AnnotationApprovedChanges = "percona.com/approved-changes"
+
+// SetPendingActions replaces the pending actions of the component.
+func (cr *PerconaXtraDBCluster) SetPendingActions(component string, actions []string) {
+	var pending []PendingAction
+	if cr.Status.PendingChanges != nil {
+		for _, a := range cr.Status.PendingChanges.Actions {
+			if a.Component != component {
+				pending = append(pending, a)
+			}
+		}
+	}
+	for _, a := range actions {
+		pending = append(pending, PendingAction{Component: component, Description: a})
+	}
+
+	if len(pending) == 0 {
+		cr.Status.PendingChanges = nil
+		return
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool { return pending[i].Component < pending[j].Component })
+
+	h := sha256.New()
+	for _, a := range pending {
+		fmt.Fprintf(h, "%s\x00%s\x00", a.Component, a.Description)
+	}
+
+	cr.Status.PendingChanges = &PendingChangesStatus{
+		ID:               fmt.Sprintf("%x", h.Sum(nil))[:12],
+		ApprovalRequired: cr.Spec.RequireChangesApproval,
+		Actions:          pending,
+	}
+}
+
+// ChangesApproved returns true if the pending changes can be applied.
+func (cr *PerconaXtraDBCluster) ChangesApproved() bool {
+	if !cr.Spec.RequireChangesApproval || cr.Status.PendingChanges == nil {
+		return true
+	}
+	return cr.Annotations[AnnotationApprovedChanges] == cr.Status.PendingChanges.ID
+}
+
+// AnnotationAllowUnsafeChanges disables the checks of ValidateUpdate.
+const AnnotationAllowUnsafeChanges = "percona.com/allow-unsafe-changes"
+
+func (cr *PerconaXtraDBCluster) UnsafeChangesAllowed() bool {
+	return cr.Annotations[AnnotationAllowUnsafeChanges] == "true"
+}
+
+// ValidateUpdate rejects changes of old that are destructive or unsupported:
+// shrinking volumes, changing storage classes and renaming the secrets of a running cluster.
+func (cr *PerconaXtraDBCluster) ValidateUpdate(old *PerconaXtraDBCluster) error {
+	if cr.UnsafeChangesAllowed() {
+		return nil
+	}
+
+	if cr.Spec.PXC != nil && old.Spec.PXC != nil {
+		if err := validateVolumeUpdate("pxc", cr.Spec.PXC.VolumeSpec, old.Spec.PXC.VolumeSpec); err != nil {
+			return err
+		}
+		for _, o := range old.Spec.PXC.VolumeOverrides {
+			n := cr.Spec.PXC.VolumeOverride(o.Ordinal)
+			if n == nil {
+				continue
+			}
+			if o.Size != nil && n.Size != nil && n.Size.Cmp(*o.Size) < 0 {
+				return errors.Errorf("pxc volume of ordinal %d can't be shrunk from %s to %s", o.Ordinal, o.Size.String(), n.Size.String())
+			}
+			if o.StorageClassName != nil && (n.StorageClassName == nil || *n.StorageClassName != *o.StorageClassName) {
+				return errors.Errorf("pxc volume storageClassName of ordinal %d can't be changed from %s", o.Ordinal, *o.StorageClassName)
+			}
+		}
+	}
+	if cr.Spec.ProxySQL != nil && old.Spec.ProxySQL != nil {
+		if err := validateVolumeUpdate("proxysql", cr.Spec.ProxySQL.VolumeSpec, old.Spec.ProxySQL.VolumeSpec); err != nil {
+			return err
+		}
+	}
+
+	secretsName := func(c *PerconaXtraDBCluster) string {
+		if c.Spec.SecretsName == "" {
+			return c.Name + "-secrets"
+		}
+		return c.Spec.SecretsName
+	}
+	if old.Status.Status != "" && secretsName(cr) != secretsName(old) {
+		return errors.Errorf("secretsName can't be changed from %s to %s on a running cluster", secretsName(old), secretsName(cr))
+	}
+	if old.Status.Status != "" && cr.Spec.PXC != nil && old.Spec.PXC != nil &&
+		cr.Spec.PXC.DataVolumes.changed(old.Spec.PXC.DataVolumes) {
+		return errors.New("pxc.dataVolumes can't be changed on a running cluster")
+	}
+
+	return nil
+}
+
+func validateVolumeUpdate(component string, vol, old *VolumeSpec) error {
+	if vol == nil || old == nil || vol.PersistentVolumeClaim == nil || old.PersistentVolumeClaim == nil {
+		return nil
+	}
+
+	size := vol.PersistentVolumeClaim.Resources.Requests[corev1.ResourceStorage]
+	oldSize := old.PersistentVolumeClaim.Resources.Requests[corev1.ResourceStorage]
+	if size.Cmp(oldSize) < 0 {
+		return errors.Errorf("%s volume can't be shrunk from %s to %s", component, oldSize.String(), size.String())
+	}
+
+	sc, oldSC := vol.PersistentVolumeClaim.StorageClassName, old.PersistentVolumeClaim.StorageClassName
+	if oldSC != nil && (sc == nil || *sc != *oldSC) {
+		return errors.Errorf("%s volume storageClassName can't be changed from %s", component, *oldSC)
+	}
+
+	return nil
+}
+
+// ValidateBackupSchedules checks that scheduled backups can be created.
+// Invalid schedules are skipped by the operator.
+func (cr *PerconaXtraDBCluster) ValidateBackupSchedules() error {
+	if cr.Spec.Backup == nil {
+		return nil
+	}
+
+	for _, s := range cr.Spec.Backup.Schedule {
+		if _, err := cron.ParseStandard(s.Schedule); err != nil {
+			return errors.Wrapf(err, "backup.schedule %s: invalid schedule %q", s.Name, s.Schedule)
+		}
+		if _, ok := cr.Spec.Backup.Storages[s.StorageName]; !ok {
+			return errors.Errorf("backup.schedule %s: storage %s doesn't exist", s.Name, s.StorageName)
+		}
+	}
+
+	return nil
+}
+
+// AdmissionWarnings returns the risky settings of the cluster.
+// They are reported to users by the admission webhook, the cluster is not rejected.
+func (cr *PerconaXtraDBCluster) AdmissionWarnings() []string {
+	var warnings []string
+
+	if cr.Spec.AllowUnsafeConfig {
+		warnings = append(warnings, "allowUnsafeConfigurations disables safety checks and is deprecated, use unsafeFlags instead")
+	}
+	if cr.Spec.Unsafe.TLS && cr.Spec.TLS != nil && cr.Spec.TLS.Enabled != nil && !*cr.Spec.TLS.Enabled {
+		warnings = append(warnings, "TLS is disabled, traffic between clients and PXC nodes is not encrypted")
+	}
+	if cr.Spec.PXC != nil && cr.Spec.PXC.PodSpec != nil && cr.Spec.PXC.Size > 0 && cr.Spec.PXC.Size < 3 {
+		warnings = append(warnings, fmt.Sprintf("pxc.size is %d, the cluster can't tolerate a node failure without losing quorum", cr.Spec.PXC.Size))
+	}
+	if cr.HAProxyEnabled() && cr.Spec.HAProxy.Size == 1 {
+		warnings = append(warnings, "haproxy.size is 1, the proxy is a single point of failure")
+	}
+	if cr.ProxySQLEnabled() && cr.Spec.ProxySQL.Size == 1 {
+		warnings = append(warnings, "proxysql.size is 1, the proxy is a single point of failure")
+	}
+
+	switch {
+	case cr.Spec.Backup == nil || len(cr.Spec.Backup.Schedule) == 0 && !cr.Spec.Backup.PITR.Enabled:
+		warnings = append(warnings, "backup.schedule is empty, the cluster has no scheduled backups")
+	case len(cr.Spec.Backup.Schedule) == 0:
+		warnings = append(warnings, "backup.pitr is enabled without backup.schedule, binary logs can't be restored without a full backup")
+	}
+	if cr.Spec.Unsafe.BackupIfUnhealthy {
+		warnings = append(warnings, "unsafeFlags.backupIfUnhealthy allows backups of an unhealthy cluster")
+	}
+
+	return warnings
+}
+
 func (cr *PerconaXtraDBCluster) PVCResizeInProgress() bool {
 	_, ok := cr.Annotations[AnnotationPVCResizeInProgress]
 	return ok
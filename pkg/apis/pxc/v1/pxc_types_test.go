@@ -3,8 +3,13 @@ package v1
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/percona/percona-xtradb-cluster-operator/version"
 )
 
 func TestReconcileAffinity(t *testing.T) {
@@ -75,3 +80,505 @@ func TestReconcileAffinity(t *testing.T) {
 		}
 	}
 }
+
+func TestPMMVersion(t *testing.T) {
+	cases := []struct {
+		name      string
+		version   string
+		keys      []string
+		pmm3      bool
+		hasSecret bool
+	}{
+		{
+			name:      "pmm2 api key",
+			keys:      []string{"pmmserverkey"},
+			hasSecret: true,
+		},
+		{
+			name:      "pmm3 detected by token",
+			keys:      []string{"pmmserverkey", "pmmservertoken"},
+			pmm3:      true,
+			hasSecret: true,
+		},
+		{
+			name:    "pmm3 without token",
+			version: PMMVersion3,
+			keys:    []string{"pmmserverkey"},
+			pmm3:    true,
+		},
+		{
+			name:    "pmm2 forced",
+			version: PMMVersion2,
+			keys:    []string{"pmmservertoken"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{Data: map[string][]byte{}}
+			for _, k := range tt.keys {
+				secret.Data[k] = []byte("secret")
+			}
+			spec := PMMSpec{Enabled: true, Version: tt.version}
+			if spec.IsPMM3(secret) != tt.pmm3 {
+				t.Errorf("expected IsPMM3 %t", tt.pmm3)
+			}
+			if spec.HasSecret(secret) != tt.hasSecret {
+				t.Errorf("expected HasSecret %t", tt.hasSecret)
+			}
+		})
+	}
+}
+
+func TestMaintenanceAllowed(t *testing.T) {
+	// Saturday
+	at := func(hour int) time.Time {
+		return time.Date(2026, 10, 17, hour, 30, 0, 0, time.UTC)
+	}
+	window := MaintenanceWindow{Schedule: "0 2 * * 6", Duration: metav1.Duration{Duration: 4 * time.Hour}}
+	blackout := BlackoutPeriod{Name: "freeze", Start: metav1.NewTime(at(3)), End: metav1.NewTime(at(4))}
+
+	tests := map[string]struct {
+		opts     UpgradeOptions
+		t        time.Time
+		expected bool
+	}{
+		"no restrictions":    {UpgradeOptions{}, at(1), true},
+		"inside window":      {UpgradeOptions{MaintenanceWindows: []MaintenanceWindow{window}}, at(2), true},
+		"before window":      {UpgradeOptions{MaintenanceWindows: []MaintenanceWindow{window}}, at(1), false},
+		"after window":       {UpgradeOptions{MaintenanceWindows: []MaintenanceWindow{window}}, at(6), false},
+		"blackout":           {UpgradeOptions{Blackouts: []BlackoutPeriod{blackout}}, at(3), false},
+		"after blackout":     {UpgradeOptions{Blackouts: []BlackoutPeriod{blackout}}, at(4), true},
+		"blackout in window": {UpgradeOptions{MaintenanceWindows: []MaintenanceWindow{window}, Blackouts: []BlackoutPeriod{blackout}}, at(3), false},
+		"window time zone":   {UpgradeOptions{MaintenanceWindows: []MaintenanceWindow{{Schedule: "CRON_TZ=Europe/Berlin 0 2 * * 6", Duration: metav1.Duration{Duration: time.Hour}}}}, at(0), true},
+		"invalid is skipped": {UpgradeOptions{MaintenanceWindows: []MaintenanceWindow{{Schedule: "invalid"}, window}}, at(2), true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			allowed, reason := tt.opts.MaintenanceAllowed(tt.t)
+			if allowed != tt.expected {
+				t.Errorf("expected %t, got %t: %s", tt.expected, allowed, reason)
+			}
+		})
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	newCluster := func(size, sc string) *PerconaXtraDBCluster {
+		return &PerconaXtraDBCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster1"},
+			Spec: PerconaXtraDBClusterSpec{
+				PXC: &PXCSpec{
+					PodSpec: &PodSpec{
+						VolumeSpec: &VolumeSpec{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimSpec{
+								StorageClassName: &sc,
+								Resources: corev1.VolumeResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+								},
+							},
+						},
+					},
+					VolumeOverrides: []PodVolumeOverride{
+						{Ordinal: 2, Size: resource.NewQuantity(20<<30, resource.BinarySI)},
+					},
+				},
+			},
+			Status: PerconaXtraDBClusterStatus{Status: AppStateReady},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(cr *PerconaXtraDBCluster)
+		wantErr bool
+	}{
+		{
+			name:   "volume expansion",
+			mutate: func(cr *PerconaXtraDBCluster) { *cr = *newCluster("10Gi", "standard") },
+		},
+		{
+			name:    "volume shrink",
+			mutate:  func(cr *PerconaXtraDBCluster) { *cr = *newCluster("1Gi", "standard") },
+			wantErr: true,
+		},
+		{
+			name:    "storage class change",
+			mutate:  func(cr *PerconaXtraDBCluster) { *cr = *newCluster("6Gi", "fast") },
+			wantErr: true,
+		},
+		{
+			name: "volume override expansion",
+			mutate: func(cr *PerconaXtraDBCluster) {
+				cr.Spec.PXC.VolumeOverrides[0].Size = resource.NewQuantity(30<<30, resource.BinarySI)
+			},
+		},
+		{
+			name: "volume override shrink",
+			mutate: func(cr *PerconaXtraDBCluster) {
+				cr.Spec.PXC.VolumeOverrides[0].Size = resource.NewQuantity(10<<30, resource.BinarySI)
+			},
+			wantErr: true,
+		},
+		{
+			name: "data volume added",
+			mutate: func(cr *PerconaXtraDBCluster) {
+				cr.Spec.PXC.DataVolumes = &DataVolumesSpec{
+					Binlog: &corev1.PersistentVolumeClaimSpec{
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")},
+						},
+					},
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name:    "secrets rename",
+			mutate:  func(cr *PerconaXtraDBCluster) { cr.Spec.SecretsName = "other-secrets" },
+			wantErr: true,
+		},
+		{
+			name:   "explicit default secrets name",
+			mutate: func(cr *PerconaXtraDBCluster) { cr.Spec.SecretsName = "cluster1-secrets" },
+		},
+		{
+			name: "unsafe changes allowed",
+			mutate: func(cr *PerconaXtraDBCluster) {
+				*cr = *newCluster("1Gi", "fast")
+				cr.Annotations = map[string]string{AnnotationAllowUnsafeChanges: "true"}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := newCluster("6Gi", "standard")
+			cr := newCluster("6Gi", "standard")
+			tt.mutate(cr)
+
+			err := cr.ValidateUpdate(old)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAdmissionWarnings(t *testing.T) {
+	safe := func() *PerconaXtraDBCluster {
+		return &PerconaXtraDBCluster{
+			Spec: PerconaXtraDBClusterSpec{
+				PXC:     &PXCSpec{PodSpec: &PodSpec{Size: 3}},
+				HAProxy: &HAProxySpec{PodSpec: PodSpec{Enabled: true, Size: 3}},
+				Backup: &PXCScheduledBackup{
+					Schedule: []PXCScheduledBackupSchedule{{Name: "daily", Schedule: "0 0 * * *"}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		mutate   func(cr *PerconaXtraDBCluster)
+		warnings int
+	}{
+		{
+			name:   "safe cluster",
+			mutate: func(cr *PerconaXtraDBCluster) {},
+		},
+		{
+			name: "unsafe cluster",
+			mutate: func(cr *PerconaXtraDBCluster) {
+				cr.Spec.AllowUnsafeConfig = true
+				cr.Spec.PXC.Size = 1
+				cr.Spec.HAProxy.Size = 1
+				cr.Spec.Backup = nil
+			},
+			warnings: 4,
+		},
+		{
+			name: "pitr without schedule",
+			mutate: func(cr *PerconaXtraDBCluster) {
+				cr.Spec.Backup.Schedule = nil
+				cr.Spec.Backup.PITR.Enabled = true
+			},
+			warnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := safe()
+			tt.mutate(cr)
+
+			warnings := cr.AdmissionWarnings()
+			if len(warnings) != tt.warnings {
+				t.Errorf("got %d warnings, want %d: %q", len(warnings), tt.warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestValidateBackupSchedules(t *testing.T) {
+	cr := &PerconaXtraDBCluster{
+		Spec: PerconaXtraDBClusterSpec{
+			Backup: &PXCScheduledBackup{
+				Storages: map[string]*BackupStorageSpec{"fs-pvc": {Type: BackupStorageFilesystem}},
+				Schedule: []PXCScheduledBackupSchedule{{Name: "daily", Schedule: "0 0 * * *", StorageName: "fs-pvc"}},
+			},
+		},
+	}
+	if err := cr.ValidateBackupSchedules(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cr.Spec.Backup.Schedule[0].Schedule = "0 0 * *"
+	if err := cr.ValidateBackupSchedules(); err == nil {
+		t.Error("invalid schedule is accepted")
+	}
+
+	cr.Spec.Backup.Schedule[0].Schedule = "@daily"
+	cr.Spec.Backup.Schedule[0].StorageName = "s3-us-west"
+	if err := cr.ValidateBackupSchedules(); err == nil {
+		t.Error("unknown storage is accepted")
+	}
+}
+
+func TestValidateReplicationChannelConfig(t *testing.T) {
+	cases := []struct {
+		config ReplicationChannelConfig
+		valid  bool
+	}{
+		{ReplicationChannelConfig{HeartbeatPeriod: 30, CompressionAlgorithms: "zstd,uncompressed", ZstdCompressionLevel: 3}, true},
+		{ReplicationChannelConfig{SSL: true, CA: "/ca.pem", SSLCert: "/cert.pem", SSLKey: "/key.pem", TLSVersion: "TLSv1.3"}, true},
+		{ReplicationChannelConfig{TLSVersion: "TLSv1.3"}, false},
+		{ReplicationChannelConfig{SSL: true, CA: "/ca.pem", SSLCert: "/cert.pem"}, false},
+		{ReplicationChannelConfig{CompressionAlgorithms: "lz4"}, false},
+		{ReplicationChannelConfig{ZstdCompressionLevel: 23}, false},
+	}
+
+	for _, c := range cases {
+		if err := c.config.validate(); (err == nil) != c.valid {
+			t.Errorf("config %+v: expected valid %t, got %v", c.config, c.valid, err)
+		}
+	}
+}
+
+func TestBackupContainerOptionsTuning(t *testing.T) {
+	useMemory := resource.MustParse("4Gi")
+	storageOpts := &BackupContainerOptions{
+		Args:   BackupContainerArgs{Xtrabackup: []string{"--someflag=abc"}},
+		Tuning: &XtrabackupTuning{Parallel: 8, UseMemory: &useMemory},
+	}
+	cr := &PerconaXtraDBCluster{
+		Spec: PerconaXtraDBClusterSpec{
+			Backup: &PXCScheduledBackup{
+				Storages: map[string]*BackupStorageSpec{"s3-us-west": {ContainerOptions: storageOpts}},
+			},
+		},
+	}
+
+	var opts *BackupContainerOptions
+	envs := opts.GetEnvVar(cr, "s3-us-west")
+	expected := []corev1.EnvVar{{Name: "XB_EXTRA_ARGS", Value: "--someflag=abc --parallel=8"}}
+	if !reflect.DeepEqual(envs, expected) {
+		t.Errorf("expected %v, got %v", expected, envs)
+	}
+	if len(storageOpts.Args.Xtrabackup) != 1 {
+		t.Errorf("storage args are modified: %v", storageOpts.Args.Xtrabackup)
+	}
+
+	if tuning := opts.GetTuning(cr, "s3-us-west"); tuning != storageOpts.Tuning {
+		t.Errorf("expected the storage tuning, got %v", tuning)
+	}
+	if tuning := (&BackupContainerOptions{}).GetTuning(cr, "s3-us-west"); tuning != nil {
+		t.Errorf("expected no tuning for options without it, got %v", tuning)
+	}
+}
+
+func TestRestrictedSecurityContexts(t *testing.T) {
+	userSC := &corev1.SecurityContext{Privileged: func(b bool) *bool { return &b }(false)}
+	cr := &PerconaXtraDBCluster{
+		Spec: PerconaXtraDBClusterSpec{
+			CRVersion: "1.17.0",
+			Platform:  version.PlatformOpenshift,
+			PXC:       &PXCSpec{PodSpec: &PodSpec{}},
+			HAProxy:   &HAProxySpec{PodSpec: PodSpec{ContainerSecurityContext: userSC}},
+			Backup: &PXCScheduledBackup{
+				Storages: map[string]*BackupStorageSpec{"s3-us-west": {}},
+			},
+		},
+	}
+	cr.setPodSecurityContext()
+
+	for name, sc := range map[string]*corev1.PodSecurityContext{
+		"pxc":     cr.Spec.PXC.PodSecurityContext,
+		"haproxy": cr.Spec.HAProxy.PodSecurityContext,
+		"storage": cr.Spec.Backup.Storages["s3-us-west"].PodSecurityContext,
+	} {
+		if sc == nil || sc.FSGroup != nil || sc.SeccompProfile == nil || sc.RunAsNonRoot == nil || !*sc.RunAsNonRoot {
+			t.Errorf("%s: unexpected pod security context %+v", name, sc)
+		}
+	}
+	if !reflect.DeepEqual(cr.Spec.PXC.ContainerSecurityContext, restrictedSecurityContext()) {
+		t.Errorf("unexpected container security context %+v", cr.Spec.PXC.ContainerSecurityContext)
+	}
+	if cr.Spec.HAProxy.ContainerSecurityContext != userSC {
+		t.Error("expected the container security context from the spec to be kept")
+	}
+
+	cr = &PerconaXtraDBCluster{
+		Spec: PerconaXtraDBClusterSpec{
+			CRVersion: "1.17.0",
+			Platform:  version.PlatformKubernetes,
+			PXC:       &PXCSpec{PodSpec: &PodSpec{}},
+		},
+	}
+	cr.setPodSecurityContext()
+	if cr.Spec.PXC.ContainerSecurityContext != nil || cr.Spec.PXC.PodSecurityContext.SeccompProfile != nil {
+		t.Error("expected no restricted security contexts on Kubernetes")
+	}
+}
+
+func TestBackupStorageS3Failover(t *testing.T) {
+	s3 := &BackupStorageS3Spec{
+		Bucket:            "main/backups",
+		CredentialsSecret: "main-secret",
+		Region:            "us-east-1",
+		Alternates: []BackupStorageS3Alternate{
+			{Bucket: "replica/backups", Region: "eu-west-1"},
+			{EndpointURL: "https://minio.example.com", CredentialsSecret: "minio-secret"},
+		},
+	}
+
+	endpoints := s3.Endpoints()
+	if len(endpoints) != 3 {
+		t.Fatalf("expected 3 endpoints, got %d", len(endpoints))
+	}
+	if e := endpoints[1]; e.Bucket != "replica/backups" || e.CredentialsSecret != "main-secret" || e.Region != "eu-west-1" {
+		t.Errorf("expected the alternate to inherit the credentials, got %+v", e)
+	}
+	if e := endpoints[2]; e.Bucket != "main/backups" || e.Region != "us-east-1" || e.EndpointURL != "https://minio.example.com" {
+		t.Errorf("unexpected alternate %+v", e)
+	}
+
+	failover := s3.Failover(1)
+	if failover.Bucket != "replica/backups" || failover.Region != "eu-west-1" {
+		t.Errorf("expected the alternate to be the main endpoint, got %+v", failover)
+	}
+	if len(failover.Alternates) != 2 || failover.Alternates[0].Bucket != "main/backups" || failover.Alternates[1].CredentialsSecret != "minio-secret" {
+		t.Errorf("expected the other endpoints to be the alternates, got %+v", failover.Alternates)
+	}
+
+	if !reflect.DeepEqual(s3.Failover(0), s3) {
+		t.Error("expected the storage to be kept if the main endpoint is available")
+	}
+}
+
+func TestValidateProviderOptions(t *testing.T) {
+	duration := func(d time.Duration) *metav1.Duration { return &metav1.Duration{Duration: d} }
+	size := resource.MustParse("1Gi")
+	zero := int32(0)
+
+	cases := []struct {
+		spec  ProviderOptionsSpec
+		valid bool
+	}{
+		{ProviderOptionsSpec{GCacheSize: &size, EVS: &EVSTimeouts{SuspectTimeout: duration(10 * time.Second), InactiveTimeout: duration(30 * time.Second)}}, true},
+		{ProviderOptionsSpec{GCSFCLimit: &zero}, false},
+		{ProviderOptionsSpec{EVS: &EVSTimeouts{SuspectTimeout: duration(20 * time.Second)}}, false},
+		{ProviderOptionsSpec{EVS: &EVSTimeouts{KeepalivePeriod: duration(5 * time.Second)}}, false},
+		{ProviderOptionsSpec{EVS: &EVSTimeouts{InstallTimeout: duration(0)}}, false},
+	}
+	for _, c := range cases {
+		if err := c.spec.validate(); (err == nil) != c.valid {
+			t.Errorf("spec %+v: expected valid %t, got %v", c.spec, c.valid, err)
+		}
+	}
+
+	configs := []struct {
+		configuration string
+		structured    bool
+		valid         bool
+	}{
+		{"[mysqld]\nwsrep_provider_options=\"gcache.size=1G; gcs.fc_limit=100;\"\n", false, true},
+		{"[mysqld]\nwsrep_provider_options=\"gcache.size=1G;base_port=4567\"\n", false, true},
+		{"[mysqld]\nwsrep_provider_options=\"gcache.size=1G,gcs.fc_limit=100\"\n", false, false},
+		{"[mysqld]\nwsrep_provider_options=\"gcache.size\"\n", false, false},
+		{"[mysqld]\nwsrep_provider_options=\"gcache.size=1G\"\n", true, false},
+		{"[mysqld]\nmax_connections=100\n", true, true},
+	}
+	for _, c := range configs {
+		if err := validateConfigProviderOptions(c.configuration, c.structured); (err == nil) != c.valid {
+			t.Errorf("configuration %q: expected valid %t, got %v", c.configuration, c.valid, err)
+		}
+	}
+}
+
+func TestGracefulShutdownDefaults(t *testing.T) {
+	gs := &GracefulShutdownSpec{Enabled: true, FlushTables: true}
+	if err := gs.checkNSetDefaults(&PodSpec{}); err != nil {
+		t.Fatal(err)
+	}
+	if gs.DrainSeconds != 10 || gs.RecvQueueTimeoutSeconds != 60 || gs.FlushTablesTimeoutSeconds != 30 {
+		t.Errorf("unexpected defaults %+v", gs)
+	}
+
+	grace := int64(60)
+	gs = &GracefulShutdownSpec{Enabled: true}
+	if err := gs.checkNSetDefaults(&PodSpec{TerminationGracePeriodSeconds: &grace}); err == nil {
+		t.Error("expected timeouts exceeding the grace period to fail")
+	}
+
+	gs = &GracefulShutdownSpec{Enabled: true}
+	podSpec := &PodSpec{Lifecycle: corev1.Lifecycle{PreStop: &corev1.LifecycleHandler{}}}
+	if err := gs.checkNSetDefaults(podSpec); err == nil {
+		t.Error("expected the preStop hook of the spec to conflict")
+	}
+}
+
+func TestPasswordPolicyCounts(t *testing.T) {
+	zero := int32(0)
+	three := int32(3)
+	cases := []struct {
+		policy   PasswordPolicySpec
+		expected [3]int32
+	}{
+		{PasswordPolicySpec{Policy: PasswordPolicyMedium}, [3]int32{1, 1, 1}},
+		{PasswordPolicySpec{Policy: PasswordPolicyLow}, [3]int32{0, 0, 0}},
+		{PasswordPolicySpec{Policy: PasswordPolicyStrong, MixedCaseCount: &zero, NumberCount: &three}, [3]int32{0, 3, 1}},
+	}
+	for _, c := range cases {
+		got := [3]int32{c.policy.GetMixedCaseCount(), c.policy.GetNumberCount(), c.policy.GetSpecialCharCount()}
+		if got != c.expected {
+			t.Errorf("policy %s: expected counts %v, got %v", c.policy.Policy, c.expected, got)
+		}
+	}
+}
+
+func TestValidateSST(t *testing.T) {
+	f := false
+	tr := true
+
+	cases := []struct {
+		spec       SSTSpec
+		tlsEnabled bool
+		valid      bool
+	}{
+		{SSTSpec{Parallel: 4, Compressor: SSTCompressorZstd, CompressThreads: 2, Encrypt: &tr}, true, true},
+		{SSTSpec{Parallel: 4, Encrypt: &f}, false, true},
+		{SSTSpec{CompressThreads: 2}, true, false},
+		{SSTSpec{Encrypt: &tr}, false, false},
+	}
+	for _, c := range cases {
+		if err := c.spec.validate(c.tlsEnabled); (err == nil) != c.valid {
+			t.Errorf("spec %+v: expected valid %t, got %v", c.spec, c.valid, err)
+		}
+	}
+
+	s := SSTSpec{Parallel: 8, Compressor: SSTCompressorLZ4}
+	if s.GetCompressThreads() != 8 {
+		t.Errorf("expected the compression threads to default to parallel, got %d", s.GetCompressThreads())
+	}
+}
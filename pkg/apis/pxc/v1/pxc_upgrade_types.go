@@ -0,0 +1,125 @@
+package v1
+
+import (
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PerconaXtraDBClusterUpgradeSpec defines the desired state of PerconaXtraDBClusterUpgrade
+type PerconaXtraDBClusterUpgradeSpec struct {
+	// SourceCluster is the name of the cluster being upgraded.
+	SourceCluster string          `json:"sourceCluster"`
+	Target        UpgradeTarget   `json:"target"`
+	Seed          UpgradeSeedSpec `json:"seed,omitempty"`
+	// Cutover starts the switchover to the target cluster once it replicates from the source.
+	Cutover bool `json:"cutover,omitempty"`
+	// CutoverTimeoutSeconds limits the time the source is read-only while
+	// the target applies the remaining transactions.
+	CutoverTimeoutSeconds int32 `json:"cutoverTimeoutSeconds,omitempty"`
+}
+
+// UpgradeTarget describes the parallel cluster. Its spec is copied
+// from the source cluster with the images replaced.
+type UpgradeTarget struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+	// BackupImage is set on the target after seeding, the backup of the
+	// source has to be prepared with the source version of xtrabackup.
+	BackupImage   string `json:"backupImage,omitempty"`
+	HAProxyImage  string `json:"haproxyImage,omitempty"`
+	ProxySQLImage string `json:"proxysqlImage,omitempty"`
+}
+
+// UpgradeSeedSpec defines the backup used to seed the target cluster.
+// A new backup is taken to StorageName if BackupName is empty.
+type UpgradeSeedSpec struct {
+	BackupName  string `json:"backupName,omitempty"`
+	StorageName string `json:"storageName,omitempty"`
+}
+
+type UpgradeState string
+
+const (
+	UpgradeStateNew          UpgradeState = ""
+	UpgradeStateProvisioning UpgradeState = "Provisioning"
+	UpgradeStateSeeding      UpgradeState = "Seeding"
+	UpgradeStateReplicating  UpgradeState = "Replicating"
+	UpgradeStateCuttingOver  UpgradeState = "CuttingOver"
+	UpgradeStateSucceeded    UpgradeState = "Succeeded"
+	UpgradeStateFailed       UpgradeState = "Failed"
+)
+
+// PerconaXtraDBClusterUpgradeStatus defines the observed state of PerconaXtraDBClusterUpgrade
+type PerconaXtraDBClusterUpgradeStatus struct {
+	State       UpgradeState `json:"state,omitempty"`
+	Message     string       `json:"message,omitempty"`
+	BackupName  string       `json:"backupName,omitempty"`
+	RestoreName string       `json:"restoreName,omitempty"`
+	// CutoverGTID is the GTID set of the source at the moment it became read-only.
+	CutoverGTID        string       `json:"cutoverGTID,omitempty"`
+	CutoverStartedAt   *metav1.Time `json:"cutoverStartedAt,omitempty"`
+	CompletedAt        *metav1.Time `json:"completed,omitempty"`
+	ObservedGeneration int64        `json:"observedGeneration,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBClusterUpgrade is the Schema for the perconaxtradbclusterupgrades API
+// +k8s:openapi-gen=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName="pxc-upgrade";"pxc-upgrades"
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.sourceCluster",description="Source cluster name"
+// +kubebuilder:printcolumn:name="Target",type="string",JSONPath=".spec.target.name",description="Target cluster name"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.state",description="Upgrade status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PerconaXtraDBClusterUpgrade struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerconaXtraDBClusterUpgradeSpec   `json:"spec,omitempty"`
+	Status PerconaXtraDBClusterUpgradeStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBClusterUpgradeList contains a list of PerconaXtraDBClusterUpgrade
+type PerconaXtraDBClusterUpgradeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerconaXtraDBClusterUpgrade `json:"items"`
+}
+
+const (
+	// AnnotationReadOnly makes the operator keep all PXC nodes of the cluster read-only.
+	AnnotationReadOnly = "percona.com/read-only"
+	// AnnotationCutoverTo points the services of the cluster to the pods of another cluster.
+	AnnotationCutoverTo = "percona.com/cutover-to"
+)
+
+// UpgradeReplicationChannel is the name of the replication channel
+// between the source and the target clusters.
+const UpgradeReplicationChannel = "bluegreen"
+
+func (cr *PerconaXtraDBClusterUpgrade) CheckNSetDefaults() error {
+	if cr.Spec.SourceCluster == "" {
+		return errors.New("sourceCluster can't be empty")
+	}
+	if cr.Spec.Target.Name == "" {
+		return errors.New("target.name can't be empty")
+	}
+	if cr.Spec.Target.Name == cr.Spec.SourceCluster {
+		return errors.New("target.name should differ from sourceCluster")
+	}
+	if cr.Spec.Target.Image == "" {
+		return errors.New("target.image can't be empty")
+	}
+	if cr.Spec.Seed.BackupName == "" && cr.Spec.Seed.StorageName == "" {
+		return errors.New("seed.backupName or seed.storageName should be specified")
+	}
+
+	if cr.Spec.CutoverTimeoutSeconds == 0 {
+		cr.Spec.CutoverTimeoutSeconds = 300
+	}
+
+	return nil
+}
@@ -26,5 +26,17 @@ func init() {
 		&PerconaXtraDBClusterBackupList{},
 		&PerconaXtraDBClusterRestore{},
 		&PerconaXtraDBClusterRestoreList{},
+		&PerconaXtraDBDatabase{},
+		&PerconaXtraDBDatabaseList{},
+		&PerconaXtraDBClusterUpgrade{},
+		&PerconaXtraDBClusterUpgradeList{},
+		&PerconaXtraDBClusterSwitchover{},
+		&PerconaXtraDBClusterSwitchoverList{},
+		&PerconaXtraDBClusterSQLJob{},
+		&PerconaXtraDBClusterSQLJobList{},
+		&PerconaXtraDBClusterSchemaMigration{},
+		&PerconaXtraDBClusterSchemaMigrationList{},
+		&PerconaXtraDBClusterExport{},
+		&PerconaXtraDBClusterExportList{},
 	)
 }
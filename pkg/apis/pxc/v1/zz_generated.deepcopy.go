@@ -7,15 +7,36 @@ package v1
 import (
 	apismetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSecretsManagerSource) DeepCopyInto(out *AWSSecretsManagerSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSecretsManagerSource.
+func (in *AWSSecretsManagerSource) DeepCopy() *AWSSecretsManagerSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSecretsManagerSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppStatus) DeepCopyInto(out *AppStatus) {
 	*out = *in
 	out.ComponentStatus = in.ComponentStatus
+	if in.UnhealthyPods != nil {
+		in, out := &in.UnhealthyPods, &out.UnhealthyPods
+		*out = make([]UnhealthyPod, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppStatus.
@@ -28,6 +49,106 @@ func (in *AppStatus) DeepCopy() *AppStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditLogSpec) DeepCopyInto(out *AuditLogSpec) {
+	*out = *in
+	if in.IncludeAccounts != nil {
+		in, out := &in.IncludeAccounts, &out.IncludeAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeAccounts != nil {
+		in, out := &in.ExcludeAccounts, &out.ExcludeAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditLogSpec.
+func (in *AuditLogSpec) DeepCopy() *AuditLogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditLogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditTrailSpec) DeepCopyInto(out *AuditTrailSpec) {
+	*out = *in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(AuditWebhookSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditTrailSpec.
+func (in *AuditTrailSpec) DeepCopy() *AuditTrailSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditTrailSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditWebhookSpec) DeepCopyInto(out *AuditWebhookSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditWebhookSpec.
+func (in *AuditWebhookSpec) DeepCopy() *AuditWebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditWebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthenticationSpec) DeepCopyInto(out *AuthenticationSpec) {
+	*out = *in
+	if in.LDAP != nil {
+		in, out := &in.LDAP, &out.LDAP
+		*out = new(LDAPAuthSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDCAuthSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationSpec.
+func (in *AuthenticationSpec) DeepCopy() *AuthenticationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthenticationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AzureKeyVaultSource) DeepCopyInto(out *AzureKeyVaultSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKeyVaultSource.
+func (in *AzureKeyVaultSource) DeepCopy() *AzureKeyVaultSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AzureKeyVaultSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupContainerArgs) DeepCopyInto(out *BackupContainerArgs) {
 	*out = *in
@@ -69,6 +190,11 @@ func (in *BackupContainerOptions) DeepCopyInto(out *BackupContainerOptions) {
 		}
 	}
 	in.Args.DeepCopyInto(&out.Args)
+	if in.Tuning != nil {
+		in, out := &in.Tuning, &out.Tuning
+		*out = new(XtrabackupTuning)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupContainerOptions.
@@ -81,6 +207,65 @@ func (in *BackupContainerOptions) DeepCopy() *BackupContainerOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupGarbageCollectionSpec) DeepCopyInto(out *BackupGarbageCollectionSpec) {
+	*out = *in
+	if in.Storages != nil {
+		in, out := &in.Storages, &out.Storages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupGarbageCollectionSpec.
+func (in *BackupGarbageCollectionSpec) DeepCopy() *BackupGarbageCollectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupGarbageCollectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupGarbageCollectionStatus) DeepCopyInto(out *BackupGarbageCollectionStatus) {
+	*out = *in
+	if in.LastScanTime != nil {
+		in, out := &in.LastScanTime, &out.LastScanTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Orphaned != nil {
+		in, out := &in.Orphaned, &out.Orphaned
+		*out = make([]OrphanedBackup, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupGarbageCollectionStatus.
+func (in *BackupGarbageCollectionStatus) DeepCopy() *BackupGarbageCollectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupGarbageCollectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupLayout) DeepCopyInto(out *BackupLayout) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupLayout.
+func (in *BackupLayout) DeepCopy() *BackupLayout {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupLayout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupStorageAzureSpec) DeepCopyInto(out *BackupStorageAzureSpec) {
 	*out = *in
@@ -96,9 +281,29 @@ func (in *BackupStorageAzureSpec) DeepCopy() *BackupStorageAzureSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupStorageS3Alternate) DeepCopyInto(out *BackupStorageS3Alternate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStorageS3Alternate.
+func (in *BackupStorageS3Alternate) DeepCopy() *BackupStorageS3Alternate {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupStorageS3Alternate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupStorageS3Spec) DeepCopyInto(out *BackupStorageS3Spec) {
 	*out = *in
+	if in.Alternates != nil {
+		in, out := &in.Alternates, &out.Alternates
+		*out = make([]BackupStorageS3Alternate, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupStorageS3Spec.
@@ -117,7 +322,7 @@ func (in *BackupStorageSpec) DeepCopyInto(out *BackupStorageSpec) {
 	if in.S3 != nil {
 		in, out := &in.S3, &out.S3
 		*out = new(BackupStorageS3Spec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Azure != nil {
 		in, out := &in.Azure, &out.Azure
@@ -208,817 +413,738 @@ func (in *BackupStorageSpec) DeepCopy() *BackupStorageSpec {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterCondition) DeepCopyInto(out *ClusterCondition) {
+func (in *BinlogRetentionSpec) DeepCopyInto(out *BinlogRetentionSpec) {
 	*out = *in
-	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCondition.
-func (in *ClusterCondition) DeepCopy() *ClusterCondition {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BinlogRetentionSpec.
+func (in *BinlogRetentionSpec) DeepCopy() *BinlogRetentionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterCondition)
+	out := new(BinlogRetentionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+func (in *BinlogRetentionStatus) DeepCopyInto(out *BinlogRetentionStatus) {
 	*out = *in
+	if in.PurgedBefore != nil {
+		in, out := &in.PurgedBefore, &out.PurgedBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.LastPurgeTime != nil {
+		in, out := &in.LastPurgeTime, &out.LastPurgeTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ReplicasSeenAt != nil {
+		in, out := &in.ReplicasSeenAt, &out.ReplicasSeenAt
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
-func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BinlogRetentionStatus.
+func (in *BinlogRetentionStatus) DeepCopy() *BinlogRetentionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ComponentStatus)
+	out := new(BinlogRetentionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HAProxySpec) DeepCopyInto(out *HAProxySpec) {
+func (in *BlackoutPeriod) DeepCopyInto(out *BlackoutPeriod) {
 	*out = *in
-	in.PodSpec.DeepCopyInto(&out.PodSpec)
-	in.ExposePrimary.DeepCopyInto(&out.ExposePrimary)
-	if in.ExposeReplicas != nil {
-		in, out := &in.ExposeReplicas, &out.ExposeReplicas
-		*out = new(ReplicasServiceExpose)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ReplicasServiceEnabled != nil {
-		in, out := &in.ReplicasServiceEnabled, &out.ReplicasServiceEnabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.ReplicasLoadBalancerSourceRanges != nil {
-		in, out := &in.ReplicasLoadBalancerSourceRanges, &out.ReplicasLoadBalancerSourceRanges
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
+	in.Start.DeepCopyInto(&out.Start)
+	in.End.DeepCopyInto(&out.End)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HAProxySpec.
-func (in *HAProxySpec) DeepCopy() *HAProxySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackoutPeriod.
+func (in *BlackoutPeriod) DeepCopy() *BlackoutPeriod {
 	if in == nil {
 		return nil
 	}
-	out := new(HAProxySpec)
+	out := new(BlackoutPeriod)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *InitContainerSpec) DeepCopyInto(out *InitContainerSpec) {
+func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ContainerSecurityContext != nil {
-		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
-		*out = new(corev1.SecurityContext)
-		(*in).DeepCopyInto(*out)
+	if in.SoakPeriod != nil {
+		in, out := &in.SoakPeriod, &out.SoakPeriod
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitContainerSpec.
-func (in *InitContainerSpec) DeepCopy() *InitContainerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanarySpec.
+func (in *CanarySpec) DeepCopy() *CanarySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(InitContainerSpec)
+	out := new(CanarySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogCollectorSpec) DeepCopyInto(out *LogCollectorSpec) {
+func (in *CanaryStatus) DeepCopyInto(out *CanaryStatus) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.ContainerSecurityContext != nil {
-		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
-		*out = new(corev1.SecurityContext)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RuntimeClassName != nil {
-		in, out := &in.RuntimeClassName, &out.RuntimeClassName
-		*out = new(string)
-		**out = **in
-	}
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectorSpec.
-func (in *LogCollectorSpec) DeepCopy() *LogCollectorSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryStatus.
+func (in *CanaryStatus) DeepCopy() *CanaryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LogCollectorSpec)
+	out := new(CanaryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PITR) DeepCopyInto(out *PITR) {
+func (in *ClusterCondition) DeepCopyInto(out *ClusterCondition) {
 	*out = *in
-	if in.BackupSource != nil {
-		in, out := &in.BackupSource, &out.BackupSource
-		*out = new(PXCBackupStatus)
-		(*in).DeepCopyInto(*out)
-	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PITR.
-func (in *PITR) DeepCopy() *PITR {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCondition.
+func (in *ClusterCondition) DeepCopy() *ClusterCondition {
 	if in == nil {
 		return nil
 	}
-	out := new(PITR)
+	out := new(ClusterCondition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PITRSpec) DeepCopyInto(out *PITRSpec) {
+func (in *ClusterReference) DeepCopyInto(out *ClusterReference) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PITRSpec.
-func (in *PITRSpec) DeepCopy() *PITRSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterReference.
+func (in *ClusterReference) DeepCopy() *ClusterReference {
 	if in == nil {
 		return nil
 	}
-	out := new(PITRSpec)
+	out := new(ClusterReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PMMSpec) DeepCopyInto(out *PMMSpec) {
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.ContainerSecurityContext != nil {
-		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
-		*out = new(corev1.SecurityContext)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.RuntimeClassName != nil {
-		in, out := &in.RuntimeClassName, &out.RuntimeClassName
-		*out = new(string)
-		**out = **in
-	}
-	if in.LivenessProbes != nil {
-		in, out := &in.LivenessProbes, &out.LivenessProbes
-		*out = new(corev1.Probe)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ReadinessProbes != nil {
-		in, out := &in.ReadinessProbes, &out.ReadinessProbes
-		*out = new(corev1.Probe)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PMMSpec.
-func (in *PMMSpec) DeepCopy() *PMMSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PMMSpec)
+	out := new(ComponentStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PXCBackupSpec) DeepCopyInto(out *PXCBackupSpec) {
+func (in *ConsistencyCheckSpec) DeepCopyInto(out *ConsistencyCheckSpec) {
 	*out = *in
-	if in.ContainerOptions != nil {
-		in, out := &in.ContainerOptions, &out.ContainerOptions
-		*out = new(BackupContainerOptions)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.StartingDeadlineSeconds != nil {
-		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
-		*out = new(int64)
-		**out = **in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.ActiveDeadlineSeconds != nil {
-		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
-		*out = new(int64)
-		**out = **in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
+	in.Resources.DeepCopyInto(&out.Resources)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PXCBackupSpec.
-func (in *PXCBackupSpec) DeepCopy() *PXCBackupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistencyCheckSpec.
+func (in *ConsistencyCheckSpec) DeepCopy() *ConsistencyCheckSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PXCBackupSpec)
+	out := new(ConsistencyCheckSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PXCBackupStatus) DeepCopyInto(out *PXCBackupStatus) {
+func (in *ConsistencyCheckStatus) DeepCopyInto(out *ConsistencyCheckStatus) {
 	*out = *in
-	if in.CompletedAt != nil {
-		in, out := &in.CompletedAt, &out.CompletedAt
-		*out = (*in).DeepCopy()
-	}
-	if in.LastScheduled != nil {
-		in, out := &in.LastScheduled, &out.LastScheduled
+	if in.LastChecksumTime != nil {
+		in, out := &in.LastChecksumTime, &out.LastChecksumTime
 		*out = (*in).DeepCopy()
 	}
-	if in.S3 != nil {
-		in, out := &in.S3, &out.S3
-		*out = new(BackupStorageS3Spec)
-		**out = **in
-	}
-	if in.Azure != nil {
-		in, out := &in.Azure, &out.Azure
-		*out = new(BackupStorageAzureSpec)
-		**out = **in
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.VerifyTLS != nil {
-		in, out := &in.VerifyTLS, &out.VerifyTLS
-		*out = new(bool)
-		**out = **in
-	}
-	if in.LatestRestorableTime != nil {
-		in, out := &in.LatestRestorableTime, &out.LatestRestorableTime
-		*out = (*in).DeepCopy()
+	if in.DiffTables != nil {
+		in, out := &in.DiffTables, &out.DiffTables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PXCBackupStatus.
-func (in *PXCBackupStatus) DeepCopy() *PXCBackupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsistencyCheckStatus.
+func (in *ConsistencyCheckStatus) DeepCopy() *ConsistencyCheckStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PXCBackupStatus)
+	out := new(ConsistencyCheckStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PXCScheduledBackup) DeepCopyInto(out *PXCScheduledBackup) {
+func (in *DashboardSpec) DeepCopyInto(out *DashboardSpec) {
 	*out = *in
-	if in.AllowParallel != nil {
-		in, out := &in.AllowParallel, &out.AllowParallel
-		*out = new(bool)
-		**out = **in
-	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]corev1.LocalObjectReference, len(*in))
-		copy(*out, *in)
-	}
-	if in.Schedule != nil {
-		in, out := &in.Schedule, &out.Schedule
-		*out = make([]PXCScheduledBackupSchedule, len(*in))
-		copy(*out, *in)
-	}
-	if in.Storages != nil {
-		in, out := &in.Storages, &out.Storages
-		*out = make(map[string]*BackupStorageSpec, len(*in))
-		for key, val := range *in {
-			var outVal *BackupStorageSpec
-			if val == nil {
-				(*out)[key] = nil
-			} else {
-				inVal := (*in)[key]
-				in, out := &inVal, &outVal
-				*out = new(BackupStorageSpec)
-				(*in).DeepCopyInto(*out)
-			}
-			(*out)[key] = outVal
-		}
-	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	in.PITR.DeepCopyInto(&out.PITR)
-	if in.BackoffLimit != nil {
-		in, out := &in.BackoffLimit, &out.BackoffLimit
-		*out = new(int32)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardSpec.
+func (in *DashboardSpec) DeepCopy() *DashboardSpec {
+	if in == nil {
+		return nil
 	}
-	if in.ActiveDeadlineSeconds != nil {
-		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
-		*out = new(int64)
-		**out = **in
+	out := new(DashboardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataVolumesSpec) DeepCopyInto(out *DataVolumesSpec) {
+	*out = *in
+	if in.Binlog != nil {
+		in, out := &in.Binlog, &out.Binlog
+		*out = new(corev1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.StartingDeadlineSeconds != nil {
-		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
-		*out = new(int64)
-		**out = **in
+	if in.Tmpdir != nil {
+		in, out := &in.Tmpdir, &out.Tmpdir
+		*out = new(corev1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InnoDBLogs != nil {
+		in, out := &in.InnoDBLogs, &out.InnoDBLogs
+		*out = new(corev1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PXCScheduledBackup.
-func (in *PXCScheduledBackup) DeepCopy() *PXCScheduledBackup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataVolumesSpec.
+func (in *DataVolumesSpec) DeepCopy() *DataVolumesSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PXCScheduledBackup)
+	out := new(DataVolumesSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PXCScheduledBackupSchedule) DeepCopyInto(out *PXCScheduledBackupSchedule) {
+func (in *DatabaseConnectionSecret) DeepCopyInto(out *DatabaseConnectionSecret) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PXCScheduledBackupSchedule.
-func (in *PXCScheduledBackupSchedule) DeepCopy() *PXCScheduledBackupSchedule {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseConnectionSecret.
+func (in *DatabaseConnectionSecret) DeepCopy() *DatabaseConnectionSecret {
 	if in == nil {
 		return nil
 	}
-	out := new(PXCScheduledBackupSchedule)
+	out := new(DatabaseConnectionSecret)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PXCSpec) DeepCopyInto(out *PXCSpec) {
+func (in *DatabaseOwner) DeepCopyInto(out *DatabaseOwner) {
 	*out = *in
-	if in.AutoRecovery != nil {
-		in, out := &in.AutoRecovery, &out.AutoRecovery
-		*out = new(bool)
-		**out = **in
-	}
-	if in.ReplicationChannels != nil {
-		in, out := &in.ReplicationChannels, &out.ReplicationChannels
-		*out = make([]ReplicationChannel, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	in.Expose.DeepCopyInto(&out.Expose)
-	if in.PodSpec != nil {
-		in, out := &in.PodSpec, &out.PodSpec
-		*out = new(PodSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PXCSpec.
-func (in *PXCSpec) DeepCopy() *PXCSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseOwner.
+func (in *DatabaseOwner) DeepCopy() *DatabaseOwner {
 	if in == nil {
 		return nil
 	}
-	out := new(PXCSpec)
+	out := new(DatabaseOwner)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PerconaXtraDBCluster) DeepCopyInto(out *PerconaXtraDBCluster) {
+func (in *DiagnosticBundle) DeepCopyInto(out *DiagnosticBundle) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.CollectedAt != nil {
+		in, out := &in.CollectedAt, &out.CollectedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBCluster.
-func (in *PerconaXtraDBCluster) DeepCopy() *PerconaXtraDBCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticBundle.
+func (in *DiagnosticBundle) DeepCopy() *DiagnosticBundle {
 	if in == nil {
 		return nil
 	}
-	out := new(PerconaXtraDBCluster)
+	out := new(DiagnosticBundle)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PerconaXtraDBCluster) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PerconaXtraDBClusterBackup) DeepCopyInto(out *PerconaXtraDBClusterBackup) {
+func (in *DiagnosticsSpec) DeepCopyInto(out *DiagnosticsSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(corev1.PersistentVolumeClaimSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterBackup.
-func (in *PerconaXtraDBClusterBackup) DeepCopy() *PerconaXtraDBClusterBackup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticsSpec.
+func (in *DiagnosticsSpec) DeepCopy() *DiagnosticsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PerconaXtraDBClusterBackup)
+	out := new(DiagnosticsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PerconaXtraDBClusterBackup) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PerconaXtraDBClusterBackupList) DeepCopyInto(out *PerconaXtraDBClusterBackupList) {
+func (in *DiagnosticsStatus) DeepCopyInto(out *DiagnosticsStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PerconaXtraDBClusterBackup, len(*in))
+	if in.Bundles != nil {
+		in, out := &in.Bundles, &out.Bundles
+		*out = make([]DiagnosticBundle, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterBackupList.
-func (in *PerconaXtraDBClusterBackupList) DeepCopy() *PerconaXtraDBClusterBackupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiagnosticsStatus.
+func (in *DiagnosticsStatus) DeepCopy() *DiagnosticsStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PerconaXtraDBClusterBackupList)
+	out := new(DiagnosticsStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PerconaXtraDBClusterBackupList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PerconaXtraDBClusterList) DeepCopyInto(out *PerconaXtraDBClusterList) {
+func (in *EVSTimeouts) DeepCopyInto(out *EVSTimeouts) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PerconaXtraDBCluster, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.KeepalivePeriod != nil {
+		in, out := &in.KeepalivePeriod, &out.KeepalivePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.InactiveCheckPeriod != nil {
+		in, out := &in.InactiveCheckPeriod, &out.InactiveCheckPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.SuspectTimeout != nil {
+		in, out := &in.SuspectTimeout, &out.SuspectTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.InactiveTimeout != nil {
+		in, out := &in.InactiveTimeout, &out.InactiveTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.InstallTimeout != nil {
+		in, out := &in.InstallTimeout, &out.InstallTimeout
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterList.
-func (in *PerconaXtraDBClusterList) DeepCopy() *PerconaXtraDBClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EVSTimeouts.
+func (in *EVSTimeouts) DeepCopy() *EVSTimeouts {
 	if in == nil {
 		return nil
 	}
-	out := new(PerconaXtraDBClusterList)
+	out := new(EVSTimeouts)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PerconaXtraDBClusterList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictionProtectionSpec) DeepCopyInto(out *EvictionProtectionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionProtectionSpec.
+func (in *EvictionProtectionSpec) DeepCopy() *EvictionProtectionSpec {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(EvictionProtectionSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PerconaXtraDBClusterRestore) DeepCopyInto(out *PerconaXtraDBClusterRestore) {
+func (in *ExternalDNSSpec) DeepCopyInto(out *ExternalDNSSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterRestore.
-func (in *PerconaXtraDBClusterRestore) DeepCopy() *PerconaXtraDBClusterRestore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSSpec.
+func (in *ExternalDNSSpec) DeepCopy() *ExternalDNSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PerconaXtraDBClusterRestore)
+	out := new(ExternalDNSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PerconaXtraDBClusterRestore) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PerconaXtraDBClusterRestoreList) DeepCopyInto(out *PerconaXtraDBClusterRestoreList) {
+func (in *ExternalReplicationSpec) DeepCopyInto(out *ExternalReplicationSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PerconaXtraDBClusterRestore, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Hosts != nil {
+		in, out := &in.Hosts, &out.Hosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
 	}
+	in.Expose.DeepCopyInto(&out.Expose)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterRestoreList.
-func (in *PerconaXtraDBClusterRestoreList) DeepCopy() *PerconaXtraDBClusterRestoreList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalReplicationSpec.
+func (in *ExternalReplicationSpec) DeepCopy() *ExternalReplicationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PerconaXtraDBClusterRestoreList)
+	out := new(ExternalReplicationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PerconaXtraDBClusterRestoreList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPSecretManagerSource) DeepCopyInto(out *GCPSecretManagerSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPSecretManagerSource.
+func (in *GCPSecretManagerSource) DeepCopy() *GCPSecretManagerSource {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(GCPSecretManagerSource)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PerconaXtraDBClusterRestoreSpec) DeepCopyInto(out *PerconaXtraDBClusterRestoreSpec) {
+func (in *GaleraSegmentsSpec) DeepCopyInto(out *GaleraSegmentsSpec) {
 	*out = *in
-	if in.ContainerOptions != nil {
-		in, out := &in.ContainerOptions, &out.ContainerOptions
-		*out = new(BackupContainerOptions)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.BackupSource != nil {
-		in, out := &in.BackupSource, &out.BackupSource
-		*out = new(PXCBackupStatus)
-		(*in).DeepCopyInto(*out)
+	if in.Segments != nil {
+		in, out := &in.Segments, &out.Segments
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.PITR != nil {
-		in, out := &in.PITR, &out.PITR
-		*out = new(PITR)
-		(*in).DeepCopyInto(*out)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]PodSegmentOverride, len(*in))
+		copy(*out, *in)
 	}
-	in.Resources.DeepCopyInto(&out.Resources)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterRestoreSpec.
-func (in *PerconaXtraDBClusterRestoreSpec) DeepCopy() *PerconaXtraDBClusterRestoreSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GaleraSegmentsSpec.
+func (in *GaleraSegmentsSpec) DeepCopy() *GaleraSegmentsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PerconaXtraDBClusterRestoreSpec)
+	out := new(GaleraSegmentsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PerconaXtraDBClusterRestoreStatus) DeepCopyInto(out *PerconaXtraDBClusterRestoreStatus) {
+func (in *GatewayParentReference) DeepCopyInto(out *GatewayParentReference) {
 	*out = *in
-	if in.CompletedAt != nil {
-		in, out := &in.CompletedAt, &out.CompletedAt
-		*out = (*in).DeepCopy()
-	}
-	if in.LastScheduled != nil {
-		in, out := &in.LastScheduled, &out.LastScheduled
-		*out = (*in).DeepCopy()
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterRestoreStatus.
-func (in *PerconaXtraDBClusterRestoreStatus) DeepCopy() *PerconaXtraDBClusterRestoreStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayParentReference.
+func (in *GatewayParentReference) DeepCopy() *GatewayParentReference {
 	if in == nil {
 		return nil
 	}
-	out := new(PerconaXtraDBClusterRestoreStatus)
+	out := new(GatewayParentReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PerconaXtraDBClusterSpec) DeepCopyInto(out *PerconaXtraDBClusterSpec) {
+func (in *GatewayRouteSpec) DeepCopyInto(out *GatewayRouteSpec) {
 	*out = *in
-	if in.TLS != nil {
-		in, out := &in.TLS, &out.TLS
-		*out = new(TLSSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PXC != nil {
-		in, out := &in.PXC, &out.PXC
-		*out = new(PXCSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ProxySQL != nil {
-		in, out := &in.ProxySQL, &out.ProxySQL
-		*out = new(ProxySQLSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.HAProxy != nil {
-		in, out := &in.HAProxy, &out.HAProxy
-		*out = new(HAProxySpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.PMM != nil {
-		in, out := &in.PMM, &out.PMM
-		*out = new(PMMSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.LogCollector != nil {
-		in, out := &in.LogCollector, &out.LogCollector
-		*out = new(LogCollectorSpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Backup != nil {
-		in, out := &in.Backup, &out.Backup
-		*out = new(PXCScheduledBackup)
-		(*in).DeepCopyInto(*out)
-	}
-	out.UpgradeOptions = in.UpgradeOptions
-	out.Unsafe = in.Unsafe
-	in.InitContainer.DeepCopyInto(&out.InitContainer)
-	if in.EnableCRValidationWebhook != nil {
-		in, out := &in.EnableCRValidationWebhook, &out.EnableCRValidationWebhook
-		*out = new(bool)
-		**out = **in
-	}
-	if in.IgnoreAnnotations != nil {
-		in, out := &in.IgnoreAnnotations, &out.IgnoreAnnotations
-		*out = make([]string, len(*in))
+	if in.ParentRefs != nil {
+		in, out := &in.ParentRefs, &out.ParentRefs
+		*out = make([]GatewayParentReference, len(*in))
 		copy(*out, *in)
 	}
-	if in.IgnoreLabels != nil {
-		in, out := &in.IgnoreLabels, &out.IgnoreLabels
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Users != nil {
-		in, out := &in.Users, &out.Users
-		*out = make([]User, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterSpec.
-func (in *PerconaXtraDBClusterSpec) DeepCopy() *PerconaXtraDBClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayRouteSpec.
+func (in *GatewayRouteSpec) DeepCopy() *GatewayRouteSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PerconaXtraDBClusterSpec)
+	out := new(GatewayRouteSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PerconaXtraDBClusterStatus) DeepCopyInto(out *PerconaXtraDBClusterStatus) {
+func (in *GitOpsSpec) DeepCopyInto(out *GitOpsSpec) {
 	*out = *in
-	out.PXC = in.PXC
-	if in.PXCReplication != nil {
-		in, out := &in.PXCReplication, &out.PXCReplication
-		*out = new(ReplicationStatus)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsSpec.
+func (in *GitOpsSpec) DeepCopy() *GitOpsSpec {
+	if in == nil {
+		return nil
 	}
-	out.ProxySQL = in.ProxySQL
-	out.HAProxy = in.HAProxy
-	out.Backup = in.Backup
-	out.PMM = in.PMM
-	out.LogCollector = in.LogCollector
-	if in.Messages != nil {
-		in, out := &in.Messages, &out.Messages
-		*out = make([]string, len(*in))
+	out := new(GitOpsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsStatus) DeepCopyInto(out *GitOpsStatus) {
+	*out = *in
+	if in.SuggestedChanges != nil {
+		in, out := &in.SuggestedChanges, &out.SuggestedChanges
+		*out = make([]SpecChange, len(*in))
 		copy(*out, *in)
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]ClusterCondition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterStatus.
-func (in *PerconaXtraDBClusterStatus) DeepCopy() *PerconaXtraDBClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsStatus.
+func (in *GitOpsStatus) DeepCopy() *GitOpsStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PerconaXtraDBClusterStatus)
+	out := new(GitOpsStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodAffinity) DeepCopyInto(out *PodAffinity) {
+func (in *GracefulShutdownSpec) DeepCopyInto(out *GracefulShutdownSpec) {
 	*out = *in
-	if in.TopologyKey != nil {
-		in, out := &in.TopologyKey, &out.TopologyKey
-		*out = new(string)
-		**out = **in
-	}
-	if in.Advanced != nil {
-		in, out := &in.Advanced, &out.Advanced
-		*out = new(corev1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodAffinity.
-func (in *PodAffinity) DeepCopy() *PodAffinity {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GracefulShutdownSpec.
+func (in *GracefulShutdownSpec) DeepCopy() *GracefulShutdownSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PodAffinity)
+	out := new(GracefulShutdownSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
+func (in *HAProxySpec) DeepCopyInto(out *HAProxySpec) {
 	*out = *in
-	if in.MinAvailable != nil {
-		in, out := &in.MinAvailable, &out.MinAvailable
-		*out = new(intstr.IntOrString)
-		**out = **in
+	in.PodSpec.DeepCopyInto(&out.PodSpec)
+	in.ExposePrimary.DeepCopyInto(&out.ExposePrimary)
+	if in.ExposeReplicas != nil {
+		in, out := &in.ExposeReplicas, &out.ExposeReplicas
+		*out = new(ReplicasServiceExpose)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.MaxUnavailable != nil {
-		in, out := &in.MaxUnavailable, &out.MaxUnavailable
-		*out = new(intstr.IntOrString)
+	if in.ReplicasServiceEnabled != nil {
+		in, out := &in.ReplicasServiceEnabled, &out.ReplicasServiceEnabled
+		*out = new(bool)
 		**out = **in
 	}
+	if in.ReplicasLoadBalancerSourceRanges != nil {
+		in, out := &in.ReplicasLoadBalancerSourceRanges, &out.ReplicasLoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetSpec.
-func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HAProxySpec.
+func (in *HAProxySpec) DeepCopy() *HAProxySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PodDisruptionBudgetSpec)
+	out := new(HAProxySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodSpec) DeepCopyInto(out *PodSpec) {
+func (in *InitContainerSpec) DeepCopyInto(out *InitContainerSpec) {
 	*out = *in
-	in.Resources.DeepCopyInto(&out.Resources)
-	in.SidecarResources.DeepCopyInto(&out.SidecarResources)
-	if in.VolumeSpec != nil {
-		in, out := &in.VolumeSpec, &out.VolumeSpec
-		*out = new(VolumeSpec)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(PodAffinity)
+	if in.ContainerSecurityContext != nil {
+		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
+		*out = new(corev1.SecurityContext)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InitContainerSpec.
+func (in *InitContainerSpec) DeepCopy() *InitContainerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InitContainerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LDAPAuthSpec) DeepCopyInto(out *LDAPAuthSpec) {
+	*out = *in
+	if in.ServerURIs != nil {
+		in, out := &in.ServerURIs, &out.ServerURIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GroupRoleMapping != nil {
+		in, out := &in.GroupRoleMapping, &out.GroupRoleMapping
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]corev1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LDAPAuthSpec.
+func (in *LDAPAuthSpec) DeepCopy() *LDAPAuthSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	out := new(LDAPAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LastBackupStatus) DeepCopyInto(out *LastBackupStatus) {
+	*out = *in
+	if in.Completed != nil {
+		in, out := &in.Completed, &out.Completed
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastBackupStatus.
+func (in *LastBackupStatus) DeepCopy() *LastBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LastBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogCollectorSpec) DeepCopyInto(out *LogCollectorSpec) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.ContainerSecurityContext != nil {
+		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SlowQueryLog != nil {
+		in, out := &in.SlowQueryLog, &out.SlowQueryLog
+		*out = new(SlowQueryLogSpec)
+		**out = **in
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = new(AuditLogSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sink != nil {
+		in, out := &in.Sink, &out.Sink
+		*out = new(LogSinkSpec)
+		(*in).DeepCopyInto(*out)
 	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogCollectorSpec.
+func (in *LogCollectorSpec) DeepCopy() *LogCollectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogCollectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogSinkSpec) DeepCopyInto(out *LogSinkSpec) {
+	*out = *in
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -1026,321 +1152,3180 @@ func (in *PodSpec) DeepCopyInto(out *PodSpec) {
 			(*out)[key] = val
 		}
 	}
-	if in.ImagePullSecrets != nil {
-		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
-		*out = make([]corev1.LocalObjectReference, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogSinkSpec.
+func (in *LogSinkSpec) DeepCopy() *LogSinkSpec {
+	if in == nil {
+		return nil
 	}
-	if in.PodDisruptionBudget != nil {
-		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
-		*out = new(PodDisruptionBudgetSpec)
-		(*in).DeepCopyInto(*out)
+	out := new(LogSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
 	}
-	if in.TerminationGracePeriodSeconds != nil {
-		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
-		*out = new(int64)
-		**out = **in
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaskingRule) DeepCopyInto(out *MaskingRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaskingRule.
+func (in *MaskingRule) DeepCopy() *MaskingRule {
+	if in == nil {
+		return nil
 	}
-	if in.LoadBalancerSourceRanges != nil {
-		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+	out := new(MaskingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsSpec) DeepCopyInto(out *MetricsSpec) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.ServiceAnnotations != nil {
-		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.ContainerSecurityContext != nil {
+		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.ServiceLabels != nil {
-		in, out := &in.ServiceLabels, &out.ServiceLabels
+	if in.PodMonitorLabels != nil {
+		in, out := &in.PodMonitorLabels, &out.PodMonitorLabels
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.ReplicasServiceAnnotations != nil {
-		in, out := &in.ReplicasServiceAnnotations, &out.ReplicasServiceAnnotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsSpec.
+func (in *MetricsSpec) DeepCopy() *MetricsSpec {
+	if in == nil {
+		return nil
 	}
-	if in.ReplicasServiceLabels != nil {
-		in, out := &in.ReplicasServiceLabels, &out.ReplicasServiceLabels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	out := new(MetricsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsTLSSpec) DeepCopyInto(out *MetricsTLSSpec) {
+	*out = *in
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.ReadinessInitialDelaySeconds != nil {
-		in, out := &in.ReadinessInitialDelaySeconds, &out.ReadinessInitialDelaySeconds
-		*out = new(int32)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsTLSSpec.
+func (in *MetricsTLSSpec) DeepCopy() *MetricsTLSSpec {
+	if in == nil {
+		return nil
 	}
-	in.ReadinessProbes.DeepCopyInto(&out.ReadinessProbes)
-	if in.LivenessInitialDelaySeconds != nil {
-		in, out := &in.LivenessInitialDelaySeconds, &out.LivenessInitialDelaySeconds
-		*out = new(int32)
-		**out = **in
+	out := new(MetricsTLSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringSpec) DeepCopyInto(out *MonitoringSpec) {
+	*out = *in
+	if in.PrometheusRule != nil {
+		in, out := &in.PrometheusRule, &out.PrometheusRule
+		*out = new(PrometheusRuleSpec)
+		(*in).DeepCopyInto(*out)
 	}
-	in.LivenessProbes.DeepCopyInto(&out.LivenessProbes)
-	if in.PodSecurityContext != nil {
-		in, out := &in.PodSecurityContext, &out.PodSecurityContext
-		*out = new(corev1.PodSecurityContext)
+	if in.Dashboard != nil {
+		in, out := &in.Dashboard, &out.Dashboard
+		*out = new(DashboardSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ContainerSecurityContext != nil {
-		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
-		*out = new(corev1.SecurityContext)
+	if in.ServiceMonitor != nil {
+		in, out := &in.ServiceMonitor, &out.ServiceMonitor
+		*out = new(ServiceMonitorSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Sidecars != nil {
-		in, out := &in.Sidecars, &out.Sidecars
-		*out = make([]corev1.Container, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringSpec.
+func (in *MonitoringSpec) DeepCopy() *MonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicyIngress) DeepCopyInto(out *NetworkPolicyIngress) {
+	*out = *in
+	if in.PXC != nil {
+		in, out := &in.PXC, &out.PXC
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.SidecarVolumes != nil {
-		in, out := &in.SidecarVolumes, &out.SidecarVolumes
-		*out = make([]corev1.Volume, len(*in))
+	if in.HAProxy != nil {
+		in, out := &in.HAProxy, &out.HAProxy
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.SidecarPVCs != nil {
-		in, out := &in.SidecarPVCs, &out.SidecarPVCs
-		*out = make([]corev1.PersistentVolumeClaim, len(*in))
+	if in.ProxySQL != nil {
+		in, out := &in.ProxySQL, &out.ProxySQL
+		*out = make([]networkingv1.NetworkPolicyIngressRule, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.RuntimeClassName != nil {
-		in, out := &in.RuntimeClassName, &out.RuntimeClassName
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicyIngress.
+func (in *NetworkPolicyIngress) DeepCopy() *NetworkPolicyIngress {
+	if in == nil {
+		return nil
 	}
-	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
-	if in.TopologySpreadConstraints != nil {
-		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]corev1.TopologySpreadConstraint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(NetworkPolicyIngress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
+	*out = *in
+	in.AdditionalIngress.DeepCopyInto(&out.AdditionalIngress)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySpec.
+func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCAuthSpec) DeepCopyInto(out *OIDCAuthSpec) {
+	*out = *in
+	if in.Providers != nil {
+		in, out := &in.Providers, &out.Providers
+		*out = make([]OIDCProvider, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCAuthSpec.
+func (in *OIDCAuthSpec) DeepCopy() *OIDCAuthSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCAuthSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCProvider) DeepCopyInto(out *OIDCProvider) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCProvider.
+func (in *OIDCProvider) DeepCopy() *OIDCProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationsHistorySpec) DeepCopyInto(out *OperationsHistorySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationsHistorySpec.
+func (in *OperationsHistorySpec) DeepCopy() *OperationsHistorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationsHistorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrphanedBackup) DeepCopyInto(out *OrphanedBackup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrphanedBackup.
+func (in *OrphanedBackup) DeepCopy() *OrphanedBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(OrphanedBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PITR) DeepCopyInto(out *PITR) {
+	*out = *in
+	if in.BackupSource != nil {
+		in, out := &in.BackupSource, &out.BackupSource
+		*out = new(PXCBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PITR.
+func (in *PITR) DeepCopy() *PITR {
+	if in == nil {
+		return nil
+	}
+	out := new(PITR)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PITRSpec) DeepCopyInto(out *PITRSpec) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PITRSpec.
+func (in *PITRSpec) DeepCopy() *PITRSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PITRSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PITRStatus) DeepCopyInto(out *PITRStatus) {
+	*out = *in
+	if in.LatestRestorableTime != nil {
+		in, out := &in.LatestRestorableTime, &out.LatestRestorableTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PITRStatus.
+func (in *PITRStatus) DeepCopy() *PITRStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PITRStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PMMSpec) DeepCopyInto(out *PMMSpec) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.ContainerSecurityContext != nil {
+		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.LivenessProbes != nil {
+		in, out := &in.LivenessProbes, &out.LivenessProbes
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReadinessProbes != nil {
+		in, out := &in.ReadinessProbes, &out.ReadinessProbes
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PMMSpec.
+func (in *PMMSpec) DeepCopy() *PMMSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PMMSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PXCBackupSpec) DeepCopyInto(out *PXCBackupSpec) {
+	*out = *in
+	if in.ContainerOptions != nil {
+		in, out := &in.ContainerOptions, &out.ContainerOptions
+		*out = new(BackupContainerOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PXCBackupSpec.
+func (in *PXCBackupSpec) DeepCopy() *PXCBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PXCBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PXCBackupStatus) DeepCopyInto(out *PXCBackupStatus) {
+	*out = *in
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScheduled != nil {
+		in, out := &in.LastScheduled, &out.LastScheduled
+		*out = (*in).DeepCopy()
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(BackupStorageS3Spec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(BackupStorageAzureSpec)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VerifyTLS != nil {
+		in, out := &in.VerifyTLS, &out.VerifyTLS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LatestRestorableTime != nil {
+		in, out := &in.LatestRestorableTime, &out.LatestRestorableTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Layout != nil {
+		in, out := &in.Layout, &out.Layout
+		*out = new(BackupLayout)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PXCBackupStatus.
+func (in *PXCBackupStatus) DeepCopy() *PXCBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PXCBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PXCScheduledBackup) DeepCopyInto(out *PXCScheduledBackup) {
+	*out = *in
+	if in.AllowParallel != nil {
+		in, out := &in.AllowParallel, &out.AllowParallel
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = make([]PXCScheduledBackupSchedule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Storages != nil {
+		in, out := &in.Storages, &out.Storages
+		*out = make(map[string]*BackupStorageSpec, len(*in))
+		for key, val := range *in {
+			var outVal *BackupStorageSpec
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(BackupStorageSpec)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.PITR.DeepCopyInto(&out.PITR)
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StartingDeadlineSeconds != nil {
+		in, out := &in.StartingDeadlineSeconds, &out.StartingDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.GarbageCollection != nil {
+		in, out := &in.GarbageCollection, &out.GarbageCollection
+		*out = new(BackupGarbageCollectionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PXCScheduledBackup.
+func (in *PXCScheduledBackup) DeepCopy() *PXCScheduledBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(PXCScheduledBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PXCScheduledBackupSchedule) DeepCopyInto(out *PXCScheduledBackupSchedule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PXCScheduledBackupSchedule.
+func (in *PXCScheduledBackupSchedule) DeepCopy() *PXCScheduledBackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(PXCScheduledBackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PXCSpec) DeepCopyInto(out *PXCSpec) {
+	*out = *in
+	if in.AutoRecovery != nil {
+		in, out := &in.AutoRecovery, &out.AutoRecovery
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReplicationChannels != nil {
+		in, out := &in.ReplicationChannels, &out.ReplicationChannels
+		*out = make([]ReplicationChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExternalReplication != nil {
+		in, out := &in.ExternalReplication, &out.ExternalReplication
+		*out = new(ExternalReplicationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reporting != nil {
+		in, out := &in.Reporting, &out.Reporting
+		*out = new(ReportingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Expose.DeepCopyInto(&out.Expose)
+	if in.Authentication != nil {
+		in, out := &in.Authentication, &out.Authentication
+		*out = new(AuthenticationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordPolicy != nil {
+		in, out := &in.PasswordPolicy, &out.PasswordPolicy
+		*out = new(PasswordPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = new(MetricsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictionProtection != nil {
+		in, out := &in.EvictionProtection, &out.EvictionProtection
+		*out = new(EvictionProtectionSpec)
+		**out = **in
+	}
+	if in.StorageLocality != nil {
+		in, out := &in.StorageLocality, &out.StorageLocality
+		*out = new(StorageLocalitySpec)
+		**out = **in
+	}
+	if in.VolumeOverrides != nil {
+		in, out := &in.VolumeOverrides, &out.VolumeOverrides
+		*out = make([]PodVolumeOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DataVolumes != nil {
+		in, out := &in.DataVolumes, &out.DataVolumes
+		*out = new(DataVolumesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SST != nil {
+		in, out := &in.SST, &out.SST
+		*out = new(SSTSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Diagnostics != nil {
+		in, out := &in.Diagnostics, &out.Diagnostics
+		*out = new(DiagnosticsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GaleraSegments != nil {
+		in, out := &in.GaleraSegments, &out.GaleraSegments
+		*out = new(GaleraSegmentsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProviderOptions != nil {
+		in, out := &in.ProviderOptions, &out.ProviderOptions
+		*out = new(ProviderOptionsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GracefulShutdown != nil {
+		in, out := &in.GracefulShutdown, &out.GracefulShutdown
+		*out = new(GracefulShutdownSpec)
+		**out = **in
+	}
+	if in.PodSpec != nil {
+		in, out := &in.PodSpec, &out.PodSpec
+		*out = new(PodSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PXCSpec.
+func (in *PXCSpec) DeepCopy() *PXCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PXCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordPolicySpec) DeepCopyInto(out *PasswordPolicySpec) {
+	*out = *in
+	if in.MixedCaseCount != nil {
+		in, out := &in.MixedCaseCount, &out.MixedCaseCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NumberCount != nil {
+		in, out := &in.NumberCount, &out.NumberCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SpecialCharCount != nil {
+		in, out := &in.SpecialCharCount, &out.SpecialCharCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordPolicySpec.
+func (in *PasswordPolicySpec) DeepCopy() *PasswordPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingAction) DeepCopyInto(out *PendingAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingAction.
+func (in *PendingAction) DeepCopy() *PendingAction {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingChangesStatus) DeepCopyInto(out *PendingChangesStatus) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]PendingAction, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingChangesStatus.
+func (in *PendingChangesStatus) DeepCopy() *PendingChangesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingChangesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBCluster) DeepCopyInto(out *PerconaXtraDBCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBCluster.
+func (in *PerconaXtraDBCluster) DeepCopy() *PerconaXtraDBCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterBackup) DeepCopyInto(out *PerconaXtraDBClusterBackup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterBackup.
+func (in *PerconaXtraDBClusterBackup) DeepCopy() *PerconaXtraDBClusterBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterBackup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterBackupList) DeepCopyInto(out *PerconaXtraDBClusterBackupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PerconaXtraDBClusterBackup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterBackupList.
+func (in *PerconaXtraDBClusterBackupList) DeepCopy() *PerconaXtraDBClusterBackupList {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterBackupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterBackupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterExport) DeepCopyInto(out *PerconaXtraDBClusterExport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterExport.
+func (in *PerconaXtraDBClusterExport) DeepCopy() *PerconaXtraDBClusterExport {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterExport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterExportList) DeepCopyInto(out *PerconaXtraDBClusterExportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PerconaXtraDBClusterExport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterExportList.
+func (in *PerconaXtraDBClusterExportList) DeepCopy() *PerconaXtraDBClusterExportList {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterExportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterExportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterExportSpec) DeepCopyInto(out *PerconaXtraDBClusterExportSpec) {
+	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tables != nil {
+		in, out := &in.Tables, &out.Tables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterExportSpec.
+func (in *PerconaXtraDBClusterExportSpec) DeepCopy() *PerconaXtraDBClusterExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterExportStatus) DeepCopyInto(out *PerconaXtraDBClusterExportStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulTime != nil {
+		in, out := &in.LastSuccessfulTime, &out.LastSuccessfulTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterExportStatus.
+func (in *PerconaXtraDBClusterExportStatus) DeepCopy() *PerconaXtraDBClusterExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterList) DeepCopyInto(out *PerconaXtraDBClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PerconaXtraDBCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterList.
+func (in *PerconaXtraDBClusterList) DeepCopy() *PerconaXtraDBClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterRestore) DeepCopyInto(out *PerconaXtraDBClusterRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterRestore.
+func (in *PerconaXtraDBClusterRestore) DeepCopy() *PerconaXtraDBClusterRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterRestoreList) DeepCopyInto(out *PerconaXtraDBClusterRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PerconaXtraDBClusterRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterRestoreList.
+func (in *PerconaXtraDBClusterRestoreList) DeepCopy() *PerconaXtraDBClusterRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterRestoreSpec) DeepCopyInto(out *PerconaXtraDBClusterRestoreSpec) {
+	*out = *in
+	if in.ContainerOptions != nil {
+		in, out := &in.ContainerOptions, &out.ContainerOptions
+		*out = new(BackupContainerOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackupSource != nil {
+		in, out := &in.BackupSource, &out.BackupSource
+		*out = new(PXCBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PITR != nil {
+		in, out := &in.PITR, &out.PITR
+		*out = new(PITR)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.Masking != nil {
+		in, out := &in.Masking, &out.Masking
+		*out = new(RestoreMasking)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rehearsal != nil {
+		in, out := &in.Rehearsal, &out.Rehearsal
+		*out = new(RestoreRehearsal)
+		**out = **in
+	}
+	if in.Retrieval != nil {
+		in, out := &in.Retrieval, &out.Retrieval
+		*out = new(RestoreRetrieval)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterRestoreSpec.
+func (in *PerconaXtraDBClusterRestoreSpec) DeepCopy() *PerconaXtraDBClusterRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterRestoreStatus) DeepCopyInto(out *PerconaXtraDBClusterRestoreStatus) {
+	*out = *in
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScheduled != nil {
+		in, out := &in.LastScheduled, &out.LastScheduled
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StateStartedAt != nil {
+		in, out := &in.StateStartedAt, &out.StateStartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.OriginalCluster != nil {
+		in, out := &in.OriginalCluster, &out.OriginalCluster
+		*out = new(RestoreOriginalCluster)
+		**out = **in
+	}
+	if in.LatestLogLines != nil {
+		in, out := &in.LatestLogLines, &out.LatestLogLines
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rehearsal != nil {
+		in, out := &in.Rehearsal, &out.Rehearsal
+		*out = new(RestoreRehearsalStatus)
+		**out = **in
+	}
+	if in.Retrieval != nil {
+		in, out := &in.Retrieval, &out.Retrieval
+		*out = new(RestoreRetrievalStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(RestoreProgress)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterRestoreStatus.
+func (in *PerconaXtraDBClusterRestoreStatus) DeepCopy() *PerconaXtraDBClusterRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterSQLJob) DeepCopyInto(out *PerconaXtraDBClusterSQLJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterSQLJob.
+func (in *PerconaXtraDBClusterSQLJob) DeepCopy() *PerconaXtraDBClusterSQLJob {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterSQLJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterSQLJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterSQLJobList) DeepCopyInto(out *PerconaXtraDBClusterSQLJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PerconaXtraDBClusterSQLJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterSQLJobList.
+func (in *PerconaXtraDBClusterSQLJobList) DeepCopy() *PerconaXtraDBClusterSQLJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterSQLJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterSQLJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterSQLJobSpec) DeepCopyInto(out *PerconaXtraDBClusterSQLJobSpec) {
+	*out = *in
+	in.Script.DeepCopyInto(&out.Script)
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterSQLJobSpec.
+func (in *PerconaXtraDBClusterSQLJobSpec) DeepCopy() *PerconaXtraDBClusterSQLJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterSQLJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterSQLJobStatus) DeepCopyInto(out *PerconaXtraDBClusterSQLJobStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterSQLJobStatus.
+func (in *PerconaXtraDBClusterSQLJobStatus) DeepCopy() *PerconaXtraDBClusterSQLJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterSQLJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterSchemaMigration) DeepCopyInto(out *PerconaXtraDBClusterSchemaMigration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterSchemaMigration.
+func (in *PerconaXtraDBClusterSchemaMigration) DeepCopy() *PerconaXtraDBClusterSchemaMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterSchemaMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterSchemaMigration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterSchemaMigrationList) DeepCopyInto(out *PerconaXtraDBClusterSchemaMigrationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PerconaXtraDBClusterSchemaMigration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterSchemaMigrationList.
+func (in *PerconaXtraDBClusterSchemaMigrationList) DeepCopy() *PerconaXtraDBClusterSchemaMigrationList {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterSchemaMigrationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterSchemaMigrationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterSchemaMigrationSpec) DeepCopyInto(out *PerconaXtraDBClusterSchemaMigrationSpec) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.FlowControl = in.FlowControl
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterSchemaMigrationSpec.
+func (in *PerconaXtraDBClusterSchemaMigrationSpec) DeepCopy() *PerconaXtraDBClusterSchemaMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterSchemaMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterSchemaMigrationStatus) DeepCopyInto(out *PerconaXtraDBClusterSchemaMigrationStatus) {
+	*out = *in
+	if in.FlowControlSampledAt != nil {
+		in, out := &in.FlowControlSampledAt, &out.FlowControlSampledAt
+		*out = (*in).DeepCopy()
+	}
+	if in.OverloadedSince != nil {
+		in, out := &in.OverloadedSince, &out.OverloadedSince
+		*out = (*in).DeepCopy()
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterSchemaMigrationStatus.
+func (in *PerconaXtraDBClusterSchemaMigrationStatus) DeepCopy() *PerconaXtraDBClusterSchemaMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterSchemaMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterSpec) DeepCopyInto(out *PerconaXtraDBClusterSpec) {
+	*out = *in
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PXC != nil {
+		in, out := &in.PXC, &out.PXC
+		*out = new(PXCSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProxySQL != nil {
+		in, out := &in.ProxySQL, &out.ProxySQL
+		*out = new(ProxySQLSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HAProxy != nil {
+		in, out := &in.HAProxy, &out.HAProxy
+		*out = new(HAProxySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PMM != nil {
+		in, out := &in.PMM, &out.PMM
+		*out = new(PMMSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogCollector != nil {
+		in, out := &in.LogCollector, &out.LogCollector
+		*out = new(LogCollectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(PXCScheduledBackup)
+		(*in).DeepCopyInto(*out)
+	}
+	in.UpgradeOptions.DeepCopyInto(&out.UpgradeOptions)
+	out.Unsafe = in.Unsafe
+	in.InitContainer.DeepCopyInto(&out.InitContainer)
+	if in.EnableCRValidationWebhook != nil {
+		in, out := &in.EnableCRValidationWebhook, &out.EnableCRValidationWebhook
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableCRDefaultingWebhook != nil {
+		in, out := &in.EnableCRDefaultingWebhook, &out.EnableCRDefaultingWebhook
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreAnnotations != nil {
+		in, out := &in.IgnoreAnnotations, &out.IgnoreAnnotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreLabels != nil {
+		in, out := &in.IgnoreLabels, &out.IgnoreLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Teardown != nil {
+		in, out := &in.Teardown, &out.Teardown
+		*out = new(TeardownSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]User, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VaultDBSecrets != nil {
+		in, out := &in.VaultDBSecrets, &out.VaultDBSecrets
+		*out = new(VaultDBSecretsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsersSecretSource != nil {
+		in, out := &in.UsersSecretSource, &out.UsersSecretSource
+		*out = new(UsersSecretSourceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AuditTrail != nil {
+		in, out := &in.AuditTrail, &out.AuditTrail
+		*out = new(AuditTrailSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperationsHistory != nil {
+		in, out := &in.OperationsHistory, &out.OperationsHistory
+		*out = new(OperationsHistorySpec)
+		**out = **in
+	}
+	if in.Monitoring != nil {
+		in, out := &in.Monitoring, &out.Monitoring
+		*out = new(MonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConsistencyCheck != nil {
+		in, out := &in.ConsistencyCheck, &out.ConsistencyCheck
+		*out = new(ConsistencyCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Standby != nil {
+		in, out := &in.Standby, &out.Standby
+		*out = new(StandbySpec)
+		**out = **in
+	}
+	if in.QueryKiller != nil {
+		in, out := &in.QueryKiller, &out.QueryKiller
+		*out = new(QueryKillerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TableMaintenance != nil {
+		in, out := &in.TableMaintenance, &out.TableMaintenance
+		*out = new(TableMaintenanceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BinlogRetention != nil {
+		in, out := &in.BinlogRetention, &out.BinlogRetention
+		*out = new(BinlogRetentionSpec)
+		**out = **in
+	}
+	if in.Velero != nil {
+		in, out := &in.Velero, &out.Velero
+		*out = new(VeleroSpec)
+		**out = **in
+	}
+	if in.GitOps != nil {
+		in, out := &in.GitOps, &out.GitOps
+		*out = new(GitOpsSpec)
+		**out = **in
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PropagateLabels != nil {
+		in, out := &in.PropagateLabels, &out.PropagateLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterSpec.
+func (in *PerconaXtraDBClusterSpec) DeepCopy() *PerconaXtraDBClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterStatus) DeepCopyInto(out *PerconaXtraDBClusterStatus) {
+	*out = *in
+	in.PXC.DeepCopyInto(&out.PXC)
+	if in.PXCReplication != nil {
+		in, out := &in.PXCReplication, &out.PXCReplication
+		*out = new(ReplicationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ProxySQL.DeepCopyInto(&out.ProxySQL)
+	in.HAProxy.DeepCopyInto(&out.HAProxy)
+	out.Backup = in.Backup
+	out.PMM = in.PMM
+	out.LogCollector = in.LogCollector
+	if in.Messages != nil {
+		in, out := &in.Messages, &out.Messages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]ClusterCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanaryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreUpgradeBackup != nil {
+		in, out := &in.PreUpgradeBackup, &out.PreUpgradeBackup
+		*out = new(PreUpgradeBackupStatus)
+		**out = **in
+	}
+	if in.PreUpgradeCheck != nil {
+		in, out := &in.PreUpgradeCheck, &out.PreUpgradeCheck
+		*out = new(PreUpgradeCheckStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SmartUpdate != nil {
+		in, out := &in.SmartUpdate, &out.SmartUpdate
+		*out = new(SmartUpdateStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = new(PendingChangesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConsistencyCheck != nil {
+		in, out := &in.ConsistencyCheck, &out.ConsistencyCheck
+		*out = new(ConsistencyCheckStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Standby != nil {
+		in, out := &in.Standby, &out.Standby
+		*out = new(StandbyStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TableMaintenance != nil {
+		in, out := &in.TableMaintenance, &out.TableMaintenance
+		*out = new(TableMaintenanceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BinlogRetention != nil {
+		in, out := &in.BinlogRetention, &out.BinlogRetention
+		*out = new(BinlogRetentionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Velero != nil {
+		in, out := &in.Velero, &out.Velero
+		*out = new(VeleroStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitOps != nil {
+		in, out := &in.GitOps, &out.GitOps
+		*out = new(GitOpsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Diagnostics != nil {
+		in, out := &in.Diagnostics, &out.Diagnostics
+		*out = new(DiagnosticsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastBackup != nil {
+		in, out := &in.LastBackup, &out.LastBackup
+		*out = new(LastBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PITR != nil {
+		in, out := &in.PITR, &out.PITR
+		*out = new(PITRStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackupGarbageCollection != nil {
+		in, out := &in.BackupGarbageCollection, &out.BackupGarbageCollection
+		*out = new(BackupGarbageCollectionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterStatus.
+func (in *PerconaXtraDBClusterStatus) DeepCopy() *PerconaXtraDBClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterSwitchover) DeepCopyInto(out *PerconaXtraDBClusterSwitchover) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterSwitchover.
+func (in *PerconaXtraDBClusterSwitchover) DeepCopy() *PerconaXtraDBClusterSwitchover {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterSwitchover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterSwitchover) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterSwitchoverList) DeepCopyInto(out *PerconaXtraDBClusterSwitchoverList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PerconaXtraDBClusterSwitchover, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterSwitchoverList.
+func (in *PerconaXtraDBClusterSwitchoverList) DeepCopy() *PerconaXtraDBClusterSwitchoverList {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterSwitchoverList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterSwitchoverList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterSwitchoverSpec) DeepCopyInto(out *PerconaXtraDBClusterSwitchoverSpec) {
+	*out = *in
+	if in.SourcesList != nil {
+		in, out := &in.SourcesList, &out.SourcesList
+		*out = make([]ReplicationSource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterSwitchoverSpec.
+func (in *PerconaXtraDBClusterSwitchoverSpec) DeepCopy() *PerconaXtraDBClusterSwitchoverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterSwitchoverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterSwitchoverStatus) DeepCopyInto(out *PerconaXtraDBClusterSwitchoverStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ReversedAt != nil {
+		in, out := &in.ReversedAt, &out.ReversedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterSwitchoverStatus.
+func (in *PerconaXtraDBClusterSwitchoverStatus) DeepCopy() *PerconaXtraDBClusterSwitchoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterSwitchoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterUpgrade) DeepCopyInto(out *PerconaXtraDBClusterUpgrade) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterUpgrade.
+func (in *PerconaXtraDBClusterUpgrade) DeepCopy() *PerconaXtraDBClusterUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterUpgrade) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterUpgradeList) DeepCopyInto(out *PerconaXtraDBClusterUpgradeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PerconaXtraDBClusterUpgrade, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterUpgradeList.
+func (in *PerconaXtraDBClusterUpgradeList) DeepCopy() *PerconaXtraDBClusterUpgradeList {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterUpgradeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBClusterUpgradeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterUpgradeSpec) DeepCopyInto(out *PerconaXtraDBClusterUpgradeSpec) {
+	*out = *in
+	out.Target = in.Target
+	out.Seed = in.Seed
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterUpgradeSpec.
+func (in *PerconaXtraDBClusterUpgradeSpec) DeepCopy() *PerconaXtraDBClusterUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBClusterUpgradeStatus) DeepCopyInto(out *PerconaXtraDBClusterUpgradeStatus) {
+	*out = *in
+	if in.CutoverStartedAt != nil {
+		in, out := &in.CutoverStartedAt, &out.CutoverStartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBClusterUpgradeStatus.
+func (in *PerconaXtraDBClusterUpgradeStatus) DeepCopy() *PerconaXtraDBClusterUpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBClusterUpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBDatabase) DeepCopyInto(out *PerconaXtraDBDatabase) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBDatabase.
+func (in *PerconaXtraDBDatabase) DeepCopy() *PerconaXtraDBDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBDatabase) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBDatabaseList) DeepCopyInto(out *PerconaXtraDBDatabaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PerconaXtraDBDatabase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBDatabaseList.
+func (in *PerconaXtraDBDatabaseList) DeepCopy() *PerconaXtraDBDatabaseList {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBDatabaseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PerconaXtraDBDatabaseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBDatabaseSpec) DeepCopyInto(out *PerconaXtraDBDatabaseSpec) {
+	*out = *in
+	out.ClusterRef = in.ClusterRef
+	in.Owner.DeepCopyInto(&out.Owner)
+	out.ConnectionSecret = in.ConnectionSecret
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBDatabaseSpec.
+func (in *PerconaXtraDBDatabaseSpec) DeepCopy() *PerconaXtraDBDatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBDatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerconaXtraDBDatabaseStatus) DeepCopyInto(out *PerconaXtraDBDatabaseStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerconaXtraDBDatabaseStatus.
+func (in *PerconaXtraDBDatabaseStatus) DeepCopy() *PerconaXtraDBDatabaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PerconaXtraDBDatabaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodAffinity) DeepCopyInto(out *PodAffinity) {
+	*out = *in
+	if in.TopologyKey != nil {
+		in, out := &in.TopologyKey, &out.TopologyKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.Advanced != nil {
+		in, out := &in.Advanced, &out.Advanced
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodAffinity.
+func (in *PodAffinity) DeepCopy() *PodAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(PodAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetSpec.
+func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodMetadata) DeepCopyInto(out *PodMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMetadata.
+func (in *PodMetadata) DeepCopy() *PodMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(PodMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSegmentOverride) DeepCopyInto(out *PodSegmentOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSegmentOverride.
+func (in *PodSegmentOverride) DeepCopy() *PodSegmentOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSegmentOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSpec) DeepCopyInto(out *PodSpec) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	in.SidecarResources.DeepCopyInto(&out.SidecarResources)
+	if in.VolumeSpec != nil {
+		in, out := &in.VolumeSpec, &out.VolumeSpec
+		*out = new(VolumeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(PodAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAnnotations != nil {
+		in, out := &in.ServiceAnnotations, &out.ServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceLabels != nil {
+		in, out := &in.ServiceLabels, &out.ServiceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReplicasServiceAnnotations != nil {
+		in, out := &in.ReplicasServiceAnnotations, &out.ReplicasServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReplicasServiceLabels != nil {
+		in, out := &in.ReplicasServiceLabels, &out.ReplicasServiceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReadinessInitialDelaySeconds != nil {
+		in, out := &in.ReadinessInitialDelaySeconds, &out.ReadinessInitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	in.ReadinessProbes.DeepCopyInto(&out.ReadinessProbes)
+	if in.LivenessInitialDelaySeconds != nil {
+		in, out := &in.LivenessInitialDelaySeconds, &out.LivenessInitialDelaySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	in.LivenessProbes.DeepCopyInto(&out.LivenessProbes)
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(corev1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerSecurityContext != nil {
+		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
+		*out = new(corev1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]corev1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarVolumes != nil {
+		in, out := &in.SidecarVolumes, &out.SidecarVolumes
+		*out = make([]corev1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SidecarPVCs != nil {
+		in, out := &in.SidecarPVCs, &out.SidecarPVCs
+		*out = make([]corev1.PersistentVolumeClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodMetadata != nil {
+		in, out := &in.PodMetadata, &out.PodMetadata
+		*out = make([]PodMetadata, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSpec.
+func (in *PodSpec) DeepCopy() *PodSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodVolumeOverride) DeepCopyInto(out *PodVolumeOverride) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodVolumeOverride.
+func (in *PodVolumeOverride) DeepCopy() *PodVolumeOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(PodVolumeOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreUpgradeBackupSpec) DeepCopyInto(out *PreUpgradeBackupSpec) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreUpgradeBackupSpec.
+func (in *PreUpgradeBackupSpec) DeepCopy() *PreUpgradeBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PreUpgradeBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreUpgradeBackupStatus) DeepCopyInto(out *PreUpgradeBackupStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreUpgradeBackupStatus.
+func (in *PreUpgradeBackupStatus) DeepCopy() *PreUpgradeBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PreUpgradeBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreUpgradeCheckSpec) DeepCopyInto(out *PreUpgradeCheckSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreUpgradeCheckSpec.
+func (in *PreUpgradeCheckSpec) DeepCopy() *PreUpgradeCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PreUpgradeCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreUpgradeCheckStatus) DeepCopyInto(out *PreUpgradeCheckStatus) {
+	*out = *in
+	if in.Report != nil {
+		in, out := &in.Report, &out.Report
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreUpgradeCheckStatus.
+func (in *PreUpgradeCheckStatus) DeepCopy() *PreUpgradeCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PreUpgradeCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusRuleSpec) DeepCopyInto(out *PrometheusRuleSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusRuleSpec.
+func (in *PrometheusRuleSpec) DeepCopy() *PrometheusRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderOptionsSpec) DeepCopyInto(out *ProviderOptionsSpec) {
+	*out = *in
+	if in.GCacheSize != nil {
+		in, out := &in.GCacheSize, &out.GCacheSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.GCSFCLimit != nil {
+		in, out := &in.GCSFCLimit, &out.GCSFCLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EVS != nil {
+		in, out := &in.EVS, &out.EVS
+		*out = new(EVSTimeouts)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderOptionsSpec.
+func (in *ProviderOptionsSpec) DeepCopy() *ProviderOptionsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderOptionsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxySQLSpec) DeepCopyInto(out *ProxySQLSpec) {
+	*out = *in
+	in.PodSpec.DeepCopyInto(&out.PodSpec)
+	in.Expose.DeepCopyInto(&out.Expose)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySQLSpec.
+func (in *ProxySQLSpec) DeepCopy() *ProxySQLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxySQLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryKillerRule) DeepCopyInto(out *QueryKillerRule) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryKillerRule.
+func (in *QueryKillerRule) DeepCopy() *QueryKillerRule {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryKillerRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryKillerSpec) DeepCopyInto(out *QueryKillerSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]QueryKillerRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryKillerSpec.
+func (in *QueryKillerSpec) DeepCopy() *QueryKillerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryKillerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicasServiceExpose) DeepCopyInto(out *ReplicasServiceExpose) {
+	*out = *in
+	in.ServiceExpose.DeepCopyInto(&out.ServiceExpose)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicasServiceExpose.
+func (in *ReplicasServiceExpose) DeepCopy() *ReplicasServiceExpose {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicasServiceExpose)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationChannel) DeepCopyInto(out *ReplicationChannel) {
+	*out = *in
+	if in.SourcesList != nil {
+		in, out := &in.SourcesList, &out.SourcesList
+		*out = make([]ReplicationSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(ReplicationChannelConfig)
+		**out = **in
+	}
+	if in.Failover != nil {
+		in, out := &in.Failover, &out.Failover
+		*out = new(ReplicationFailover)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reposition != nil {
+		in, out := &in.Reposition, &out.Reposition
+		*out = new(ReplicationReposition)
+		**out = **in
+	}
+	if in.Rebuild != nil {
+		in, out := &in.Rebuild, &out.Rebuild
+		*out = new(ReplicationRebuild)
+		**out = **in
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = new(ReplicationFilters)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationChannel.
+func (in *ReplicationChannel) DeepCopy() *ReplicationChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationChannelConfig) DeepCopyInto(out *ReplicationChannelConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationChannelConfig.
+func (in *ReplicationChannelConfig) DeepCopy() *ReplicationChannelConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationChannelConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationChannelStatus) DeepCopyInto(out *ReplicationChannelStatus) {
+	*out = *in
+	out.ReplicationChannelConfig = in.ReplicationChannelConfig
+	if in.SourceDownSince != nil {
+		in, out := &in.SourceDownSince, &out.SourceDownSince
+		*out = (*in).DeepCopy()
+	}
+	if in.SecondsBehindSource != nil {
+		in, out := &in.SecondsBehindSource, &out.SecondsBehindSource
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LastRepositionTime != nil {
+		in, out := &in.LastRepositionTime, &out.LastRepositionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = new(ReplicationFilters)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationChannelStatus.
+func (in *ReplicationChannelStatus) DeepCopy() *ReplicationChannelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationChannelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationFailover) DeepCopyInto(out *ReplicationFailover) {
+	*out = *in
+	if in.PromotedServiceAnnotations != nil {
+		in, out := &in.PromotedServiceAnnotations, &out.PromotedServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationFailover.
+func (in *ReplicationFailover) DeepCopy() *ReplicationFailover {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationFailover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationFailoverRecord) DeepCopyInto(out *ReplicationFailoverRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationFailoverRecord.
+func (in *ReplicationFailoverRecord) DeepCopy() *ReplicationFailoverRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationFailoverRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationFilters) DeepCopyInto(out *ReplicationFilters) {
+	*out = *in
+	if in.DoDB != nil {
+		in, out := &in.DoDB, &out.DoDB
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreDB != nil {
+		in, out := &in.IgnoreDB, &out.IgnoreDB
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DoTable != nil {
+		in, out := &in.DoTable, &out.DoTable
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IgnoreTable != nil {
+		in, out := &in.IgnoreTable, &out.IgnoreTable
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WildDoTable != nil {
+		in, out := &in.WildDoTable, &out.WildDoTable
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WildIgnoreTable != nil {
+		in, out := &in.WildIgnoreTable, &out.WildIgnoreTable
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RewriteDB != nil {
+		in, out := &in.RewriteDB, &out.RewriteDB
+		*out = make([]ReplicationRewriteDB, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationFilters.
+func (in *ReplicationFilters) DeepCopy() *ReplicationFilters {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationFilters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationRebuild) DeepCopyInto(out *ReplicationRebuild) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationRebuild.
+func (in *ReplicationRebuild) DeepCopy() *ReplicationRebuild {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationRebuild)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationReposition) DeepCopyInto(out *ReplicationReposition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationReposition.
+func (in *ReplicationReposition) DeepCopy() *ReplicationReposition {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationReposition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationRewriteDB) DeepCopyInto(out *ReplicationRewriteDB) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationRewriteDB.
+func (in *ReplicationRewriteDB) DeepCopy() *ReplicationRewriteDB {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationRewriteDB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSource) DeepCopyInto(out *ReplicationSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSource.
+func (in *ReplicationSource) DeepCopy() *ReplicationSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationStatus) DeepCopyInto(out *ReplicationStatus) {
+	*out = *in
+	if in.Channels != nil {
+		in, out := &in.Channels, &out.Channels
+		*out = make([]ReplicationChannelStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Failovers != nil {
+		in, out := &in.Failovers, &out.Failovers
+		*out = make([]ReplicationFailoverRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationStatus.
+func (in *ReplicationStatus) DeepCopy() *ReplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportingSpec) DeepCopyInto(out *ReportingSpec) {
+	*out = *in
+	in.Expose.DeepCopyInto(&out.Expose)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportingSpec.
+func (in *ReportingSpec) DeepCopy() *ReportingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreMasking) DeepCopyInto(out *RestoreMasking) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]MaskingRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Script != nil {
+		in, out := &in.Script, &out.Script
+		*out = new(SQLJobScript)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreMasking.
+func (in *RestoreMasking) DeepCopy() *RestoreMasking {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreMasking)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreOriginalCluster) DeepCopyInto(out *RestoreOriginalCluster) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreOriginalCluster.
+func (in *RestoreOriginalCluster) DeepCopy() *RestoreOriginalCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreOriginalCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreProgress) DeepCopyInto(out *RestoreProgress) {
+	*out = *in
+	if in.DownloadStartedAt != nil {
+		in, out := &in.DownloadStartedAt, &out.DownloadStartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.PrepareStartedAt != nil {
+		in, out := &in.PrepareStartedAt, &out.PrepareStartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.RestoredAt != nil {
+		in, out := &in.RestoredAt, &out.RestoredAt
+		*out = (*in).DeepCopy()
+	}
+	if in.EstimatedCompletionTime != nil {
+		in, out := &in.EstimatedCompletionTime, &out.EstimatedCompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreProgress.
+func (in *RestoreProgress) DeepCopy() *RestoreProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreRehearsal) DeepCopyInto(out *RestoreRehearsal) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreRehearsal.
+func (in *RestoreRehearsal) DeepCopy() *RestoreRehearsal {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreRehearsal)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreRehearsalStatus) DeepCopyInto(out *RestoreRehearsalStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreRehearsalStatus.
+func (in *RestoreRehearsalStatus) DeepCopy() *RestoreRehearsalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreRehearsalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreRetrieval) DeepCopyInto(out *RestoreRetrieval) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreRetrieval.
+func (in *RestoreRetrieval) DeepCopy() *RestoreRetrieval {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreRetrieval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreRetrievalStatus) DeepCopyInto(out *RestoreRetrievalStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ETA != nil {
+		in, out := &in.ETA, &out.ETA
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreRetrievalStatus.
+func (in *RestoreRetrievalStatus) DeepCopy() *RestoreRetrievalStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreRetrievalStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLJobScript) DeepCopyInto(out *SQLJobScript) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLJobScript.
+func (in *SQLJobScript) DeepCopy() *SQLJobScript {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLJobScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSTSpec) DeepCopyInto(out *SSTSpec) {
+	*out = *in
+	if in.Encrypt != nil {
+		in, out := &in.Encrypt, &out.Encrypt
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSTSpec.
+func (in *SSTSpec) DeepCopy() *SSTSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SSTSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchemaMigrationFlowControl) DeepCopyInto(out *SchemaMigrationFlowControl) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchemaMigrationFlowControl.
+func (in *SchemaMigrationFlowControl) DeepCopy() *SchemaMigrationFlowControl {
+	if in == nil {
+		return nil
+	}
+	out := new(SchemaMigrationFlowControl)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeySelector) DeepCopyInto(out *SecretKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeySelector.
+func (in *SecretKeySelector) DeepCopy() *SecretKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceExpose) DeepCopyInto(out *ServiceExpose) {
+	*out = *in
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(GatewayRouteSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalDNS != nil {
+		in, out := &in.ExternalDNS, &out.ExternalDNS
+		*out = new(ExternalDNSSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceExpose.
+func (in *ServiceExpose) DeepCopy() *ServiceExpose {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceExpose)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceMonitorSpec) DeepCopyInto(out *ServiceMonitorSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(MetricsTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMonitorSpec.
+func (in *ServiceMonitorSpec) DeepCopy() *ServiceMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlowQueryLogSpec) DeepCopyInto(out *SlowQueryLogSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlowQueryLogSpec.
+func (in *SlowQueryLogSpec) DeepCopy() *SlowQueryLogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlowQueryLogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SmartUpdateStatus) DeepCopyInto(out *SmartUpdateStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSpec.
-func (in *PodSpec) DeepCopy() *PodSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmartUpdateStatus.
+func (in *SmartUpdateStatus) DeepCopy() *SmartUpdateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PodSpec)
+	out := new(SmartUpdateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxySQLSpec) DeepCopyInto(out *ProxySQLSpec) {
+func (in *SpecChange) DeepCopyInto(out *SpecChange) {
 	*out = *in
-	in.PodSpec.DeepCopyInto(&out.PodSpec)
-	in.Expose.DeepCopyInto(&out.Expose)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySQLSpec.
-func (in *ProxySQLSpec) DeepCopy() *ProxySQLSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecChange.
+func (in *SpecChange) DeepCopy() *SpecChange {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxySQLSpec)
+	out := new(SpecChange)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicasServiceExpose) DeepCopyInto(out *ReplicasServiceExpose) {
+func (in *StandbySpec) DeepCopyInto(out *StandbySpec) {
 	*out = *in
-	in.ServiceExpose.DeepCopyInto(&out.ServiceExpose)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicasServiceExpose.
-func (in *ReplicasServiceExpose) DeepCopy() *ReplicasServiceExpose {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StandbySpec.
+func (in *StandbySpec) DeepCopy() *StandbySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicasServiceExpose)
+	out := new(StandbySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationChannel) DeepCopyInto(out *ReplicationChannel) {
+func (in *StandbyStatus) DeepCopyInto(out *StandbyStatus) {
 	*out = *in
-	if in.SourcesList != nil {
-		in, out := &in.SourcesList, &out.SourcesList
-		*out = make([]ReplicationSource, len(*in))
-		copy(*out, *in)
-	}
-	if in.Config != nil {
-		in, out := &in.Config, &out.Config
-		*out = new(ReplicationChannelConfig)
-		**out = **in
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationChannel.
-func (in *ReplicationChannel) DeepCopy() *ReplicationChannel {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StandbyStatus.
+func (in *StandbyStatus) DeepCopy() *StandbyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationChannel)
+	out := new(StandbyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationChannelConfig) DeepCopyInto(out *ReplicationChannelConfig) {
+func (in *StorageLocalitySpec) DeepCopyInto(out *StorageLocalitySpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationChannelConfig.
-func (in *ReplicationChannelConfig) DeepCopy() *ReplicationChannelConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageLocalitySpec.
+func (in *StorageLocalitySpec) DeepCopy() *StorageLocalitySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationChannelConfig)
+	out := new(StorageLocalitySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationChannelStatus) DeepCopyInto(out *ReplicationChannelStatus) {
+func (in *TLSSpec) DeepCopyInto(out *TLSSpec) {
 	*out = *in
-	out.ReplicationChannelConfig = in.ReplicationChannelConfig
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SANs != nil {
+		in, out := &in.SANs, &out.SANs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IssuerConf != nil {
+		in, out := &in.IssuerConf, &out.IssuerConf
+		*out = new(apismetav1.ObjectReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationChannelStatus.
-func (in *ReplicationChannelStatus) DeepCopy() *ReplicationChannelStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSSpec.
+func (in *TLSSpec) DeepCopy() *TLSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationChannelStatus)
+	out := new(TLSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationSource) DeepCopyInto(out *ReplicationSource) {
+func (in *TableMaintenanceSpec) DeepCopyInto(out *TableMaintenanceSpec) {
 	*out = *in
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]TableMaintenanceOperation, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSource.
-func (in *ReplicationSource) DeepCopy() *ReplicationSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableMaintenanceSpec.
+func (in *TableMaintenanceSpec) DeepCopy() *TableMaintenanceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationSource)
+	out := new(TableMaintenanceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ReplicationStatus) DeepCopyInto(out *ReplicationStatus) {
+func (in *TableMaintenanceStatus) DeepCopyInto(out *TableMaintenanceStatus) {
 	*out = *in
-	if in.Channels != nil {
-		in, out := &in.Channels, &out.Channels
-		*out = make([]ReplicationChannelStatus, len(*in))
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastCompletedTime != nil {
+		in, out := &in.LastCompletedTime, &out.LastCompletedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PendingPods != nil {
+		in, out := &in.PendingPods, &out.PendingPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedPods != nil {
+		in, out := &in.FailedPods, &out.FailedPods
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationStatus.
-func (in *ReplicationStatus) DeepCopy() *ReplicationStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TableMaintenanceStatus.
+func (in *TableMaintenanceStatus) DeepCopy() *TableMaintenanceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ReplicationStatus)
+	out := new(TableMaintenanceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretKeySelector) DeepCopyInto(out *SecretKeySelector) {
+func (in *TeardownFinalBackupSpec) DeepCopyInto(out *TeardownFinalBackupSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeySelector.
-func (in *SecretKeySelector) DeepCopy() *SecretKeySelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownFinalBackupSpec.
+func (in *TeardownFinalBackupSpec) DeepCopy() *TeardownFinalBackupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretKeySelector)
+	out := new(TeardownFinalBackupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceExpose) DeepCopyInto(out *ServiceExpose) {
+func (in *TeardownSpec) DeepCopyInto(out *TeardownSpec) {
 	*out = *in
-	if in.LoadBalancerSourceRanges != nil {
-		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.FinalBackup != nil {
+		in, out := &in.FinalBackup, &out.FinalBackup
+		*out = new(TeardownFinalBackupSpec)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceExpose.
-func (in *ServiceExpose) DeepCopy() *ServiceExpose {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeardownSpec.
+func (in *TeardownSpec) DeepCopy() *TeardownSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceExpose)
+	out := new(TeardownSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TLSSpec) DeepCopyInto(out *TLSSpec) {
+func (in *UnhealthyPod) DeepCopyInto(out *UnhealthyPod) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
-	}
-	if in.SANs != nil {
-		in, out := &in.SANs, &out.SANs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.IssuerConf != nil {
-		in, out := &in.IssuerConf, &out.IssuerConf
-		*out = new(apismetav1.ObjectReference)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSSpec.
-func (in *TLSSpec) DeepCopy() *TLSSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnhealthyPod.
+func (in *UnhealthyPod) DeepCopy() *UnhealthyPod {
 	if in == nil {
 		return nil
 	}
-	out := new(TLSSpec)
+	out := new(UnhealthyPod)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1363,6 +4348,33 @@ func (in *UnsafeFlags) DeepCopy() *UnsafeFlags {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpgradeOptions) DeepCopyInto(out *UpgradeOptions) {
 	*out = *in
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanarySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreUpgradeBackup != nil {
+		in, out := &in.PreUpgradeBackup, &out.PreUpgradeBackup
+		*out = new(PreUpgradeBackupSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PreUpgradeCheck != nil {
+		in, out := &in.PreUpgradeCheck, &out.PreUpgradeCheck
+		*out = new(PreUpgradeCheckSpec)
+		**out = **in
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.Blackouts != nil {
+		in, out := &in.Blackouts, &out.Blackouts
+		*out = make([]BlackoutPeriod, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeOptions.
@@ -1375,6 +4387,36 @@ func (in *UpgradeOptions) DeepCopy() *UpgradeOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeSeedSpec) DeepCopyInto(out *UpgradeSeedSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeSeedSpec.
+func (in *UpgradeSeedSpec) DeepCopy() *UpgradeSeedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeSeedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeTarget) DeepCopyInto(out *UpgradeTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeTarget.
+func (in *UpgradeTarget) DeepCopy() *UpgradeTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *User) DeepCopyInto(out *User) {
 	*out = *in
@@ -1410,6 +4452,127 @@ func (in *User) DeepCopy() *User {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsersSecretSourceSpec) DeepCopyInto(out *UsersSecretSourceSpec) {
+	*out = *in
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSSecretsManagerSource)
+		**out = **in
+	}
+	if in.GCP != nil {
+		in, out := &in.GCP, &out.GCP
+		*out = new(GCPSecretManagerSource)
+		**out = **in
+	}
+	if in.Azure != nil {
+		in, out := &in.Azure, &out.Azure
+		*out = new(AzureKeyVaultSource)
+		**out = **in
+	}
+	if in.RefreshInterval != nil {
+		in, out := &in.RefreshInterval, &out.RefreshInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsersSecretSourceSpec.
+func (in *UsersSecretSourceSpec) DeepCopy() *UsersSecretSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UsersSecretSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultDBRole) DeepCopyInto(out *VaultDBRole) {
+	*out = *in
+	if in.CreationStatements != nil {
+		in, out := &in.CreationStatements, &out.CreationStatements
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RevocationStatements != nil {
+		in, out := &in.RevocationStatements, &out.RevocationStatements
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultDBRole.
+func (in *VaultDBRole) DeepCopy() *VaultDBRole {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultDBRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultDBSecretsSpec) DeepCopyInto(out *VaultDBSecretsSpec) {
+	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]VaultDBRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultDBSecretsSpec.
+func (in *VaultDBSecretsSpec) DeepCopy() *VaultDBSecretsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultDBSecretsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VeleroSpec) DeepCopyInto(out *VeleroSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VeleroSpec.
+func (in *VeleroSpec) DeepCopy() *VeleroSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VeleroSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VeleroStatus) DeepCopyInto(out *VeleroStatus) {
+	*out = *in
+	if in.RecoveredAt != nil {
+		in, out := &in.RecoveredAt, &out.RecoveredAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VeleroStatus.
+func (in *VeleroStatus) DeepCopy() *VeleroStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VeleroStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Volume) DeepCopyInto(out *Volume) {
 	*out = *in
@@ -1468,3 +4631,28 @@ func (in *VolumeSpec) DeepCopy() *VolumeSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XtrabackupTuning) DeepCopyInto(out *XtrabackupTuning) {
+	*out = *in
+	if in.UseMemory != nil {
+		in, out := &in.UseMemory, &out.UseMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.PrepareResources != nil {
+		in, out := &in.PrepareResources, &out.PrepareResources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XtrabackupTuning.
+func (in *XtrabackupTuning) DeepCopy() *XtrabackupTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(XtrabackupTuning)
+	in.DeepCopyInto(out)
+	return out
+}
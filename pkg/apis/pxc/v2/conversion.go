@@ -0,0 +1,40 @@
+package v2
+
+import (
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	v1 "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+// ConvertTo converts the cluster to the v1 storage version.
+func (cr *PerconaXtraDBCluster) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1.PerconaXtraDBCluster)
+	if !ok {
+		return errors.Errorf("unexpected conversion target %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *cr.ObjectMeta.DeepCopy()
+	dst.Spec = *cr.Spec.DeepCopy()
+	dst.Status = *cr.Status.DeepCopy()
+	dst.APIVersion = v1.SchemeGroupVersion.String()
+	dst.Kind = "PerconaXtraDBCluster"
+
+	return nil
+}
+
+// ConvertFrom converts the cluster from the v1 storage version.
+func (cr *PerconaXtraDBCluster) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1.PerconaXtraDBCluster)
+	if !ok {
+		return errors.Errorf("unexpected conversion source %T", srcRaw)
+	}
+
+	cr.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	cr.Spec = *src.Spec.DeepCopy()
+	cr.Status = *src.Status.DeepCopy()
+	cr.APIVersion = SchemeGroupVersion.String()
+	cr.Kind = "PerconaXtraDBCluster"
+
+	return nil
+}
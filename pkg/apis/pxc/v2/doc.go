@@ -0,0 +1,5 @@
+// Package v2 contains API Schema definitions for the pxc v2 API group
+// +k8s:deepcopy-gen=package,register
+// +kubebuilder:skipversion
+// +groupName=pxc.percona.com
+package v2
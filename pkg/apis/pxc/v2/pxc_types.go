@@ -0,0 +1,34 @@
+package v2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	v1 "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+// PerconaXtraDBClusterSpec is shared with v1 until v2 introduces breaking changes.
+// Replace the alias with a v2 type and extend ConvertTo/ConvertFrom when it does.
+type PerconaXtraDBClusterSpec = v1.PerconaXtraDBClusterSpec
+
+// PerconaXtraDBClusterStatus is shared with v1.
+type PerconaXtraDBClusterStatus = v1.PerconaXtraDBClusterStatus
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBCluster is the Schema for the perconaxtradbclusters API
+type PerconaXtraDBCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PerconaXtraDBClusterSpec   `json:"spec,omitempty"`
+	Status PerconaXtraDBClusterStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PerconaXtraDBClusterList contains a list of PerconaXtraDBCluster
+type PerconaXtraDBClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PerconaXtraDBCluster `json:"items"`
+}
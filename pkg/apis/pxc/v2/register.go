@@ -0,0 +1,26 @@
+// NOTE: Boilerplate only.  Ignore this file.
+
+// Package v2 contains API Schema definitions for the pxc v2 API group
+// +k8s:deepcopy-gen=package,register
+// +groupName=pxc.percona.com
+package v2
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// SchemeGroupVersion is group version used to register these objects
+	SchemeGroupVersion = schema.GroupVersion{Group: "pxc.percona.com", Version: "v2"}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+)
+
+func init() {
+	SchemeBuilder.Register(
+		&PerconaXtraDBCluster{},
+		&PerconaXtraDBClusterList{},
+	)
+}
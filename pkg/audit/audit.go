@@ -0,0 +1,159 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+type Action string
+
+const (
+	ActionPasswordChanged Action = "PasswordChanged"
+	ActionUserCreated     Action = "UserCreated"
+	ActionUserDropped     Action = "UserDropped"
+	ActionGrantsChanged   Action = "GrantsChanged"
+)
+
+// Entry describes a single credential or privilege change.
+type Entry struct {
+	Action    Action    `json:"action"`
+	User      string    `json:"user"`
+	Hosts     []string  `json:"hosts,omitempty"`
+	Cluster   string    `json:"cluster"`
+	Namespace string    `json:"namespace"`
+	Trigger   string    `json:"trigger"`
+	Actor     string    `json:"actor,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+func (e Entry) message() string {
+	msg := fmt.Sprintf("user %s: %s, triggered by %s", e.User, e.Action, e.Trigger)
+	if e.Actor != "" {
+		msg += " (" + e.Actor + ")"
+	}
+	return msg
+}
+
+// Recorder reports audit entries as Kubernetes events
+// and sends them to the webhook if it's configured in the cluster.
+type Recorder struct {
+	client   client.Client
+	recorder record.EventRecorder
+}
+
+func NewRecorder(cl client.Client, recorder record.EventRecorder) *Recorder {
+	return &Recorder{
+		client:   cl,
+		recorder: recorder,
+	}
+}
+
+// Record never fails: audit is best effort and shouldn't block the reconcile.
+// The event is attached to obj, which is the cluster if obj is nil.
+func (r *Recorder) Record(ctx context.Context, cr *api.PerconaXtraDBCluster, obj client.Object, e Entry) {
+	if r == nil {
+		return
+	}
+
+	log := logf.FromContext(ctx)
+
+	e.Cluster = cr.Name
+	e.Namespace = cr.Namespace
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	if obj == nil {
+		obj = cr
+	}
+	if r.recorder != nil {
+		r.recorder.Event(obj, corev1.EventTypeNormal, string(e.Action), e.message())
+	}
+
+	if cr.Spec.AuditTrail == nil || cr.Spec.AuditTrail.Webhook == nil {
+		return
+	}
+
+	if err := r.send(ctx, cr, e); err != nil {
+		log.Error(err, "failed to send audit entry to webhook", "user", e.User, "action", e.Action)
+	}
+}
+
+func (r *Recorder) send(ctx context.Context, cr *api.PerconaXtraDBCluster, e Entry) error {
+	wh := cr.Spec.AuditTrail.Webhook
+
+	body, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshal entry")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "new request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if wh.AuthSecretName != "" {
+		secret := new(corev1.Secret)
+		err := r.client.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: wh.AuthSecretName}, secret)
+		if err != nil {
+			return errors.Wrapf(err, "get secret %s", wh.AuthSecretName)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(secret.Data["token"])))
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if wh.TLSSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // nolint:gosec
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Actor returns the field manager which changed obj last,
+// it's the best guess about who triggered the change.
+func Actor(obj metav1.Object) string {
+	var (
+		actor string
+		last  time.Time
+	)
+	for _, f := range obj.GetManagedFields() {
+		if f.Time == nil || f.Manager == "" {
+			continue
+		}
+		if f.Time.Time.After(last) || f.Time.Time.Equal(last) {
+			actor = f.Manager
+			last = f.Time.Time
+		}
+	}
+	return actor
+}
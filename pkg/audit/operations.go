@@ -0,0 +1,157 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+type Operation string
+
+const (
+	OperationBackup        Operation = "Backup"
+	OperationRestore       Operation = "Restore"
+	OperationUpgrade       Operation = "Upgrade"
+	OperationFailover      Operation = "Failover"
+	OperationSwitchover    Operation = "Switchover"
+	OperationCrashRecovery Operation = "CrashRecovery"
+)
+
+type OperationResult string
+
+const (
+	OperationStarted   OperationResult = "Started"
+	OperationSucceeded OperationResult = "Succeeded"
+	OperationFailed    OperationResult = "Failed"
+)
+
+// OperationsHistoryKey is the key of the history in the ConfigMap,
+// the entries are stored as JSON lines, the oldest first.
+const OperationsHistoryKey = "history.jsonl"
+
+// OperationEntry describes a single operational action on the cluster.
+type OperationEntry struct {
+	Time      time.Time       `json:"time"`
+	Operation Operation       `json:"operation"`
+	Result    OperationResult `json:"result"`
+	// Object is the name of the backup, restore or other object of the operation, if any.
+	Object  string `json:"object,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// RecordOperation appends e to the operations history of the cluster if the history is enabled.
+// The history is kept in a ConfigMap owned by the cluster, so it outlives the backup and restore objects.
+// It never fails: the history is best effort and shouldn't block the reconcile.
+func RecordOperation(ctx context.Context, cl client.Client, namespace, clusterName string, e OperationEntry) {
+	log := logf.FromContext(ctx)
+
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		return recordOperation(ctx, cl, namespace, clusterName, e)
+	})
+	if err != nil {
+		log.Error(err, "failed to record operation", "operation", e.Operation, "result", e.Result)
+	}
+}
+
+func recordOperation(ctx context.Context, cl client.Client, namespace, clusterName string, e OperationEntry) error {
+	cr := new(api.PerconaXtraDBCluster)
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: clusterName}, cr); err != nil {
+		return errors.Wrap(client.IgnoreNotFound(err), "get cluster")
+	}
+	spec := cr.Spec.OperationsHistory
+	if !spec.IsEnabled() || !cr.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	limit := int(spec.Limit)
+	if limit <= 0 {
+		limit = api.DefaultOperationsHistoryLimit
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return errors.Wrap(err, "marshal entry")
+	}
+
+	cm := new(corev1.ConfigMap)
+	err = cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: naming.OperationsHistoryConfigMapName(clusterName)}, cm)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "get operations history")
+	}
+	if k8serrors.IsNotFound(err) {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      naming.OperationsHistoryConfigMapName(clusterName),
+				Namespace: namespace,
+				Labels:    naming.LabelsCluster(cr),
+			},
+			Data: map[string]string{
+				OperationsHistoryKey: string(line) + "\n",
+			},
+		}
+		if err := k8s.SetControllerReference(cr, cm, cl.Scheme()); err != nil {
+			return errors.Wrap(err, "set controller reference")
+		}
+		return errors.Wrap(cl.Create(ctx, cm), "create operations history")
+	}
+
+	lines := append(historyLines(cm.Data[OperationsHistoryKey]), string(line))
+	if len(lines) > limit {
+		lines = lines[len(lines)-limit:]
+	}
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[OperationsHistoryKey] = strings.Join(lines, "\n") + "\n"
+
+	return errors.Wrap(cl.Update(ctx, cm), "update operations history")
+}
+
+func historyLines(data string) []string {
+	var lines []string
+	s := bufio.NewScanner(strings.NewReader(data))
+	s.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for s.Scan() {
+		if l := strings.TrimSpace(s.Text()); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+// OperationsHistory returns the recorded entries of the cluster, the oldest first.
+func OperationsHistory(ctx context.Context, cl client.Client, namespace, clusterName string) ([]OperationEntry, error) {
+	cm := new(corev1.ConfigMap)
+	err := cl.Get(ctx, types.NamespacedName{Namespace: namespace, Name: naming.OperationsHistoryConfigMapName(clusterName)}, cm)
+	if err != nil {
+		return nil, errors.Wrap(client.IgnoreNotFound(err), "get operations history")
+	}
+
+	var entries []OperationEntry
+	for _, l := range historyLines(cm.Data[OperationsHistoryKey]) {
+		var e OperationEntry
+		if err := json.Unmarshal([]byte(l), &e); err != nil {
+			return nil, errors.Wrap(err, "unmarshal entry")
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
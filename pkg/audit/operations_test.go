@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+func TestRecordOperation(t *testing.T) {
+	ctx := context.Background()
+
+	cr := &api.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "test", UID: "cluster-uid"},
+		Spec: api.PerconaXtraDBClusterSpec{
+			OperationsHistory: &api.OperationsHistorySpec{Enabled: true, Limit: 3},
+		},
+	}
+	disabled := &api.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster2", Namespace: "test"},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(api.SchemeGroupVersion, new(api.PerconaXtraDBCluster))
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(cr, disabled).Build()
+
+	for i := 0; i < 5; i++ {
+		RecordOperation(ctx, cl, cr.Namespace, cr.Name, OperationEntry{
+			Operation: OperationBackup,
+			Result:    OperationSucceeded,
+			Object:    fmt.Sprintf("backup%d", i),
+		})
+	}
+
+	entries, err := OperationsHistory(ctx, cl, cr.Namespace, cr.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected the history to be limited to 3 entries, got %d", len(entries))
+	}
+	if entries[0].Object != "backup2" || entries[2].Object != "backup4" {
+		t.Errorf("expected the latest entries, got %+v", entries)
+	}
+	if entries[2].Time.IsZero() {
+		t.Error("expected the time to be set")
+	}
+
+	cm := new(corev1.ConfigMap)
+	if err := cl.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: naming.OperationsHistoryConfigMapName(cr.Name)}, cm); err != nil {
+		t.Fatal(err)
+	}
+	if refs := cm.GetOwnerReferences(); len(refs) != 1 || refs[0].UID != cr.UID {
+		t.Errorf("expected the history to be owned by the cluster, got %+v", refs)
+	}
+
+	RecordOperation(ctx, cl, disabled.Namespace, disabled.Name, OperationEntry{Operation: OperationRestore, Result: OperationFailed})
+	entries, err = OperationsHistory(ctx, cl, disabled.Namespace, disabled.Name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no history of the cluster with disabled history, got %+v", entries)
+	}
+}
@@ -0,0 +1,147 @@
+package pxc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/config"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+const validatePasswordInstalledAnnotation = "percona.com/validate-password-installed"
+
+// reconcileAuthConfig creates the secret with authentication plugin and password policy
+// options which is mounted to PXC pods alongside autotune config.
+func (r *ReconcilePerconaXtraDBCluster) reconcileAuthConfig(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	if !config.AuthConfigEnabled(cr) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      config.AuthConfigSecretName(cr.Name),
+				Namespace: cr.Namespace,
+			},
+		}
+		return client.IgnoreNotFound(r.client.Delete(ctx, secret))
+	}
+
+	var bindSecret *corev1.Secret
+	if ldap := ldapSpec(cr); ldap != nil && ldap.BindSecretName != "" {
+		bindSecret = new(corev1.Secret)
+		err := r.client.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: ldap.BindSecretName}, bindSecret)
+		if err != nil {
+			return errors.Wrapf(err, "get ldap bind secret %s", ldap.BindSecretName)
+		}
+	}
+
+	secret, err := config.NewAuthConfigSecret(cr, bindSecret)
+	if err != nil {
+		return errors.Wrap(err, "new auth config secret")
+	}
+
+	current := new(corev1.Secret)
+	err = r.client.Get(ctx, client.ObjectKeyFromObject(secret), current)
+	if client.IgnoreNotFound(err) != nil {
+		return errors.Wrap(err, "get auth config secret")
+	}
+	if v, ok := current.Annotations[validatePasswordInstalledAnnotation]; ok && cr.Spec.PXC.PasswordPolicy != nil {
+		secret.Annotations = map[string]string{validatePasswordInstalledAnnotation: v}
+	}
+
+	if err := k8s.SetControllerReference(cr, secret, r.scheme); err != nil {
+		return errors.Wrap(err, "set controller reference")
+	}
+
+	return r.createOrUpdate(ctx, cr, secret)
+}
+
+// reconcilePasswordPolicy installs the validate_password component. Its options
+// are applied only after PXC pods are restarted, so the auth config secret is
+// annotated to change the auth config hash and trigger a rolling restart.
+func (r *ReconcilePerconaXtraDBCluster) reconcilePasswordPolicy(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	if cr.Spec.PXC.PasswordPolicy == nil || cr.Status.Status != api.AppStateReady {
+		return nil
+	}
+
+	secret := new(corev1.Secret)
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: config.AuthConfigSecretName(cr.Name)}, secret)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if _, ok := secret.Annotations[validatePasswordInstalledAnnotation]; ok {
+		return nil
+	}
+
+	internalSecrets := new(corev1.Secret)
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: users.InternalSecretsPrefix + cr.Name}, internalSecrets)
+	if err != nil {
+		return errors.Wrap(err, "get internal sys users secret")
+	}
+
+	um, err := pxc.NewUsersManager(cr, internalSecrets)
+	if err != nil {
+		return err
+	}
+	defer um.Close()
+
+	if err := um.InstallComponent(ctx, validatePasswordComponent); err != nil {
+		return errors.Wrap(err, "install validate_password component")
+	}
+
+	err = k8s.AnnotateObject(ctx, r.client, secret, map[string]string{validatePasswordInstalledAnnotation: "true"})
+	if err != nil {
+		return errors.Wrap(err, "annotate auth config secret")
+	}
+
+	logf.FromContext(ctx).Info("validate_password component installed, PXC pods will be restarted to apply password policy")
+
+	return nil
+}
+
+const validatePasswordComponent = "file://component_validate_password"
+
+func ldapSpec(cr *api.PerconaXtraDBCluster) *api.LDAPAuthSpec {
+	if cr.Spec.PXC.Authentication == nil {
+		return nil
+	}
+	return cr.Spec.PXC.Authentication.LDAP
+}
+
+// getAuthConfigHash returns the hash of authentication plugin and password policy
+// options and LDAP CA, so PXC pods are restarted when any of them changes.
+func (r *ReconcilePerconaXtraDBCluster) getAuthConfigHash(cr *api.PerconaXtraDBCluster) (string, error) {
+	if !config.AuthConfigEnabled(cr) {
+		return "", nil
+	}
+
+	hash, err := r.getSecretHash(cr, config.AuthConfigSecretName(cr.Name), false)
+	if err != nil {
+		return "", err
+	}
+
+	if cr.Spec.PXC.PasswordPolicy != nil {
+		secret := new(corev1.Secret)
+		err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cr.Namespace, Name: config.AuthConfigSecretName(cr.Name)}, secret)
+		if err != nil {
+			return "", err
+		}
+		hash += secret.Annotations[validatePasswordInstalledAnnotation]
+	}
+
+	if ldap := ldapSpec(cr); ldap != nil && ldap.CASecretName != "" {
+		caHash, err := r.getSecretHash(cr, ldap.CASecretName, true)
+		if err != nil {
+			return "", err
+		}
+		hash += caHash
+	}
+
+	return hash, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/robfig/cron/v3"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -30,6 +31,10 @@ type BackupScheduleJob struct {
 func (r *ReconcilePerconaXtraDBCluster) reconcileBackups(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
 	log := logf.FromContext(ctx)
 
+	if err := r.reconcileBackupNow(ctx, cr); err != nil {
+		return errors.Wrap(err, "backup now")
+	}
+
 	backups := make(map[string]api.PXCScheduledBackupSchedule)
 	backupNamePrefix := backupJobClusterPrefix(cr.Namespace + "-" + cr.Name)
 
@@ -58,6 +63,8 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileBackups(ctx context.Context, cr
 			strg, ok := cr.Spec.Backup.Storages[bcp.StorageName]
 			if !ok {
 				log.Info("invalid storage name for backup", "backup name", cr.Spec.Backup.Schedule[i].Name, "storage name", bcp.StorageName)
+				r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventInvalidBackupSchedule,
+					"Scheduled backup %s is skipped: storage %s doesn't exist", cr.Spec.Backup.Schedule[i].Name, bcp.StorageName)
 				continue
 			}
 
@@ -74,6 +81,8 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileBackups(ctx context.Context, cr
 				jobID, err := r.crons.AddFuncWithSeconds(bcp.Schedule, r.createBackupJob(ctx, cr, bcp, strg.Type))
 				if err != nil {
 					log.Error(err, "can't parse cronjob schedule", "backup name", cr.Spec.Backup.Schedule[i].Name, "schedule", bcp.Schedule)
+					r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventInvalidBackupSchedule,
+						"Scheduled backup %s is skipped: invalid schedule %q", cr.Spec.Backup.Schedule[i].Name, bcp.Schedule)
 					continue
 				}
 
@@ -167,9 +176,51 @@ func (r *ReconcilePerconaXtraDBCluster) oldScheduledBackups(ctx context.Context,
 	return ret, nil
 }
 
-func (r *ReconcilePerconaXtraDBCluster) createBackupJob(ctx context.Context, cr *api.PerconaXtraDBCluster, backupJob api.PXCScheduledBackupSchedule, storageType api.BackupStorageType) func() {
+// reconcileBackupNow creates the backup requested with the backup-now annotation
+// and removes the annotation, so the backup is created once.
+func (r *ReconcilePerconaXtraDBCluster) reconcileBackupNow(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	storageName, ok := cr.Annotations[naming.AnnotationBackupNow]
+	if !ok {
+		return nil
+	}
+
 	log := logf.FromContext(ctx)
 
+	var storage *api.BackupStorageSpec
+	if cr.Spec.Backup != nil {
+		storage = cr.Spec.Backup.Storages[storageName]
+	}
+	if storage == nil {
+		log.Info("invalid storage name for requested backup", "storage name", storageName)
+		r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventInvalidBackupRequest,
+			"Backup requested with %s is skipped: storage %s doesn't exist", naming.AnnotationBackupNow, storageName)
+	} else {
+		bcp := &api.PerconaXtraDBClusterBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Finalizers: backupFinalizers(cr, storage.Type),
+				Namespace:  cr.Namespace,
+				Name:       naming.BackupNowName(cr.Name, storageName),
+				Labels:     naming.LabelsBackupNow(cr),
+			},
+			Spec: api.PXCBackupSpec{
+				PXCCluster:              cr.Name,
+				StorageName:             storageName,
+				StartingDeadlineSeconds: cr.Spec.Backup.StartingDeadlineSeconds,
+			},
+		}
+		if err := r.client.Create(ctx, bcp); err != nil {
+			return errors.Wrap(err, "create backup")
+		}
+		log.Info("Created requested backup", "backup", bcp.Name, "storage", storageName)
+	}
+
+	c := cr.DeepCopy()
+	delete(c.Annotations, naming.AnnotationBackupNow)
+	return errors.Wrap(r.client.Patch(ctx, c, client.MergeFrom(cr)), "remove annotation")
+}
+
+// backupFinalizers returns the finalizers deleting the backup from the storage with the backup object.
+func backupFinalizers(cr *api.PerconaXtraDBCluster, storageType api.BackupStorageType) []string {
 	var fins []string
 	switch storageType {
 	case api.BackupStorageS3, api.BackupStorageAzure:
@@ -179,6 +230,13 @@ func (r *ReconcilePerconaXtraDBCluster) createBackupJob(ctx context.Context, cr
 			fins = append(fins, naming.FinalizerDeleteBackup)
 		}
 	}
+	return fins
+}
+
+func (r *ReconcilePerconaXtraDBCluster) createBackupJob(ctx context.Context, cr *api.PerconaXtraDBCluster, backupJob api.PXCScheduledBackupSchedule, storageType api.BackupStorageType) func() {
+	log := logf.FromContext(ctx)
+
+	fins := backupFinalizers(cr, storageType)
 
 	return func() {
 		localCr := &api.PerconaXtraDBCluster{}
@@ -0,0 +1,190 @@
+package pxc
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+)
+
+// maxOrphanedBackupsInStatus limits the orphaned backups listed in the status.
+const maxOrphanedBackupsInStatus = 50
+
+// reconcileBackupGarbageCollection scans the backup storages of the cluster for orphaned backups
+// and deletes them, or only reports them in the dry run mode. Failed scans are reported in the status.
+func (r *ReconcilePerconaXtraDBCluster) reconcileBackupGarbageCollection(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	if cr.Spec.Backup == nil || !cr.Spec.Backup.GarbageCollection.IsEnabled() {
+		cr.Status.BackupGarbageCollection = nil
+		return nil
+	}
+	gc := cr.Spec.Backup.GarbageCollection
+
+	key := cr.Namespace + "/" + cr.Name
+	interval := time.Duration(gc.IntervalSeconds) * time.Second
+	if last, ok := r.backupGCAt.Load(key); ok && time.Since(last.(time.Time)) < interval {
+		return nil
+	}
+	r.backupGCAt.Store(key, time.Now())
+
+	log := logf.FromContext(ctx)
+
+	backups := new(api.PerconaXtraDBClusterBackupList)
+	if err := r.client.List(ctx, backups, &client.ListOptions{Namespace: cr.Namespace}); err != nil {
+		return errors.Wrap(err, "list backups")
+	}
+	// backups are matched by name only, the same backups can be in the alternate S3 endpoints
+	referenced := make(map[string]struct{}, len(backups.Items))
+	for _, bcp := range backups.Items {
+		if name := bcp.Status.Destination.BackupName(); name != "" {
+			referenced[name] = struct{}{}
+		}
+	}
+
+	clusters := new(api.PerconaXtraDBClusterList)
+	if err := r.client.List(ctx, clusters, &client.ListOptions{Namespace: cr.Namespace}); err != nil {
+		return errors.Wrap(err, "list clusters")
+	}
+	existing := make(map[string]struct{}, len(clusters.Items))
+	for _, c := range clusters.Items {
+		existing[c.Name] = struct{}{}
+	}
+
+	now := time.Now()
+	status := &api.BackupGarbageCollectionStatus{LastScanTime: &metav1.Time{Time: now}}
+	var errs []string
+
+	for _, stgName := range backupGCStorages(cr) {
+		stg, orphaned, err := r.orphanedBackups(ctx, cr, stgName, func(name string) bool {
+			cluster, created, ok := parseBackupName(name)
+			if !ok {
+				return false
+			}
+			if _, ok := referenced[name]; ok {
+				return false
+			}
+			if _, ok := existing[cluster]; ok && cluster != cr.Name {
+				return false
+			}
+			return now.Sub(created) >= time.Duration(gc.MinAgeSeconds)*time.Second
+		})
+		if err != nil {
+			errs = append(errs, "storage "+stgName+": "+err.Error())
+			continue
+		}
+
+		for _, name := range orphaned {
+			if gc.DryRun {
+				log.Info("Orphaned backup found", "storage", stgName, "backup", name)
+				r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventOrphanedBackupFound,
+					"Backup %s in storage %s isn't referred to by any backup object", name, stgName)
+				status.Orphaned = append(status.Orphaned, api.OrphanedBackup{Storage: stgName, Name: name})
+				continue
+			}
+
+			if err := storage.DeleteBackupObjects(ctx, stg, name+"/"); err != nil {
+				errs = append(errs, "storage "+stgName+": delete backup "+name+": "+err.Error())
+				status.Orphaned = append(status.Orphaned, api.OrphanedBackup{Storage: stgName, Name: name})
+				continue
+			}
+			log.Info("Orphaned backup deleted", "storage", stgName, "backup", name)
+			r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventOrphanedBackupDeleted,
+				"Orphaned backup %s is deleted from storage %s", name, stgName)
+			status.Deleted++
+		}
+	}
+
+	if len(status.Orphaned) > maxOrphanedBackupsInStatus {
+		status.Orphaned = status.Orphaned[:maxOrphanedBackupsInStatus]
+	}
+	if len(errs) > 0 {
+		status.Error = strings.Join(errs, "; ")
+		log.Error(errors.New(status.Error), "backup garbage collection")
+	}
+	cr.Status.BackupGarbageCollection = status
+
+	return nil
+}
+
+// orphanedBackups returns the client of the storage and the sorted names of the backups in it orphaned returns true for.
+func (r *ReconcilePerconaXtraDBCluster) orphanedBackups(ctx context.Context, cr *api.PerconaXtraDBCluster, stgName string, orphaned func(string) bool) (storage.Storage, []string, error) {
+	opts, err := storage.GetOptions(ctx, r.client, cr, stgName)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "get storage options")
+	}
+
+	newClient := r.newStorageClient
+	if newClient == nil {
+		newClient = storage.NewClient
+	}
+	stg, err := newClient(ctx, opts)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "new storage client")
+	}
+
+	objects, err := stg.ListObjects(ctx, "")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "list objects")
+	}
+
+	seen := make(map[string]struct{})
+	var names []string
+	for _, o := range objects {
+		name, _, _ := strings.Cut(o, "/")
+		name = strings.TrimSuffix(name, ".md5")
+		name = strings.TrimSuffix(name, ".sst_info")
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		if orphaned(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return stg, names, nil
+}
+
+// backupGCStorages returns the sorted names of the storages scanned by the garbage collection.
+func backupGCStorages(cr *api.PerconaXtraDBCluster) []string {
+	if names := cr.Spec.Backup.GarbageCollection.Storages; len(names) > 0 {
+		return names
+	}
+
+	var names []string
+	for name, stg := range cr.Spec.Backup.Storages {
+		if stg == nil || stg.Type == api.BackupStorageFilesystem {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseBackupName returns the cluster and the creation time of a full backup named <cluster>-<creation time>-full.
+func parseBackupName(name string) (string, time.Time, bool) {
+	name, ok := strings.CutSuffix(name, "-full")
+	if !ok || len(name) < len(backupTimeFormat)+2 {
+		return "", time.Time{}, false
+	}
+	i := len(name) - len(backupTimeFormat)
+	if name[i-1] != '-' {
+		return "", time.Time{}, false
+	}
+	created, err := time.Parse(backupTimeFormat, name[i:])
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return name[:i-1], created, true
+}
@@ -0,0 +1,144 @@
+package pxc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage/fake"
+)
+
+type gcStorage struct {
+	fake.FakeStorageClient
+	objects []string
+	deleted []string
+}
+
+func (s *gcStorage) ListObjects(_ context.Context, prefix string) ([]string, error) {
+	var list []string
+	for _, o := range s.objects {
+		if strings.HasPrefix(o, prefix) {
+			list = append(list, o)
+		}
+	}
+	return list, nil
+}
+
+func (s *gcStorage) DeleteObject(_ context.Context, name string) error {
+	s.deleted = append(s.deleted, name)
+	return nil
+}
+
+func TestReconcileBackupGarbageCollection(t *testing.T) {
+	ctx := context.Background()
+
+	scheme.Scheme.AddKnownTypes(api.SchemeGroupVersion,
+		new(api.PerconaXtraDBClusterList), new(api.PerconaXtraDBClusterBackupList))
+
+	cr := newCR("cluster1", "pxc")
+	cr.Spec.Backup = &api.PXCScheduledBackup{
+		Storages: map[string]*api.BackupStorageSpec{
+			"s3": {
+				Type: api.BackupStorageS3,
+				S3:   &api.BackupStorageS3Spec{Bucket: "backups", CredentialsSecret: "s3-secret"},
+			},
+			"fs": {Type: api.BackupStorageFilesystem},
+		},
+		GarbageCollection: &api.BackupGarbageCollectionSpec{
+			Enabled:         true,
+			DryRun:          true,
+			IntervalSeconds: 1,
+			MinAgeSeconds:   3600,
+		},
+	}
+	other := newCR("cluster2", "pxc")
+
+	bcp := &api.PerconaXtraDBClusterBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup1", Namespace: cr.Namespace},
+		Spec:       api.PXCBackupSpec{PXCCluster: cr.Name, StorageName: "s3"},
+	}
+	bcp.Status.Destination.SetS3Destination("backups", "cluster1-2026-10-01-03:00:00-full")
+
+	stg := &gcStorage{objects: []string{
+		// referred to by backup1
+		"cluster1-2026-10-01-03:00:00-full/xtrabackup_checkpoints",
+		"cluster1-2026-10-01-03:00:00-full.md5",
+		// left by a crashed job
+		"cluster1-2026-10-02-03:00:00-full/xtrabackup_checkpoints",
+		"cluster1-2026-10-02-03:00:00-full.sst_info/sst_info",
+		// the cluster is deleted
+		"deleted-cluster-2026-10-03-03:00:00-full.md5",
+		// the cluster exists and manages its own backups
+		"cluster2-2026-10-03-03:00:00-full.md5",
+		// too young
+		"cluster1-2999-01-01-03:00:00-full.md5",
+		"binlog_1728000000_abc",
+	}}
+
+	r := buildFakeClient([]runtime.Object{cr.DeepCopy(), other, bcp})
+	r.newStorageClient = func(context.Context, storage.Options) (storage.Storage, error) {
+		return stg, nil
+	}
+
+	if err := r.reconcileBackupGarbageCollection(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	status := cr.Status.BackupGarbageCollection
+	if status == nil || status.LastScanTime == nil || status.Error != "" {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	expected := []api.OrphanedBackup{
+		{Storage: "s3", Name: "cluster1-2026-10-02-03:00:00-full"},
+		{Storage: "s3", Name: "deleted-cluster-2026-10-03-03:00:00-full"},
+	}
+	if len(status.Orphaned) != len(expected) || status.Orphaned[0] != expected[0] || status.Orphaned[1] != expected[1] {
+		t.Fatalf("expected orphaned backups %+v, got %+v", expected, status.Orphaned)
+	}
+	if len(stg.deleted) != 0 {
+		t.Fatalf("dry run shouldn't delete objects, deleted %v", stg.deleted)
+	}
+
+	cr.Spec.Backup.GarbageCollection.DryRun = false
+	r.backupGCAt.Delete(cr.Namespace + "/" + cr.Name)
+	if err := r.reconcileBackupGarbageCollection(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	status = cr.Status.BackupGarbageCollection
+	if status.Deleted != 2 || len(status.Orphaned) != 0 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	for _, o := range stg.deleted {
+		if strings.HasPrefix(o, "cluster1-2026-10-01") || strings.HasPrefix(o, "cluster2") {
+			t.Errorf("object %s shouldn't be deleted", o)
+		}
+	}
+	if len(stg.deleted) != 6 {
+		t.Errorf("expected the objects and checksums of the orphaned backups to be deleted, got %v", stg.deleted)
+	}
+
+	cr.Spec.Backup.GarbageCollection.Enabled = false
+	if err := r.reconcileBackupGarbageCollection(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	if cr.Status.BackupGarbageCollection != nil {
+		t.Error("expected the status to be removed")
+	}
+}
+
+func TestParseBackupName(t *testing.T) {
+	cluster, created, ok := parseBackupName("my-cluster-2026-10-01-03:00:00-full")
+	if !ok || cluster != "my-cluster" || created.Day() != 1 || created.Hour() != 3 {
+		t.Errorf("unexpected result: %s %v %v", cluster, created, ok)
+	}
+	for _, name := range []string{"binlog_1728000000_abc", "2026-10-01-03:00:00-full", "cluster1-2026-10-01-03:00:00-incr"} {
+		if _, _, ok := parseBackupName(name); ok {
+			t.Errorf("%s isn't a full backup name", name)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package pxc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+func TestReconcileBackupNow(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		storage string
+		backups int
+	}{
+		"existing storage": {storage: "s3", backups: 1},
+		"unknown storage":  {storage: "gcs", backups: 0},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := newCR("cluster1", "pxc")
+			cr.Spec.CRVersion = "1.16.0"
+			cr.Annotations = map[string]string{naming.AnnotationBackupNow: tt.storage}
+			cr.Spec.Backup = &api.PXCScheduledBackup{
+				Storages: map[string]*api.BackupStorageSpec{
+					"s3": {Type: api.BackupStorageS3, S3: &api.BackupStorageS3Spec{Bucket: "backups"}},
+				},
+			}
+
+			r := buildFakeClient([]runtime.Object{cr})
+			if err := r.reconcileBackupNow(ctx, cr); err != nil {
+				t.Fatal(err)
+			}
+
+			backups := new(api.PerconaXtraDBClusterBackupList)
+			if err := r.client.List(ctx, backups); err != nil {
+				t.Fatal(err)
+			}
+			if len(backups.Items) != tt.backups {
+				t.Fatalf("expected %d backups, got %d", tt.backups, len(backups.Items))
+			}
+			for _, bcp := range backups.Items {
+				if !strings.HasPrefix(bcp.Name, "now-cluster1-s3-") || bcp.Spec.StorageName != "s3" || bcp.Spec.PXCCluster != "cluster1" {
+					t.Errorf("unexpected backup %s: %+v", bcp.Name, bcp.Spec)
+				}
+				if bcp.Labels[naming.LabelPerconaBackupType] != "now" || bcp.Labels[naming.LabelPerconaClusterName] != "cluster1" {
+					t.Errorf("unexpected labels %v", bcp.Labels)
+				}
+				if len(bcp.Finalizers) != 1 || bcp.Finalizers[0] != naming.FinalizerDeleteBackup {
+					t.Errorf("unexpected finalizers %v", bcp.Finalizers)
+				}
+			}
+
+			updated := new(api.PerconaXtraDBCluster)
+			if err := r.client.Get(ctx, client.ObjectKeyFromObject(cr), updated); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := updated.Annotations[naming.AnnotationBackupNow]; ok {
+				t.Error("expected the annotation to be removed")
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package pxc
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+// reconcileBinlogRetention purges the binary logs of the ready PXC nodes according to the binlog retention policy.
+// binlog_expire_logs_seconds is kept in line with the purge cutoff, so the server doesn't purge binary logs
+// the operator would keep. While purging is held the automatic purge of the server is disabled.
+func (r *ReconcilePerconaXtraDBCluster) reconcileBinlogRetention(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	br := cr.Spec.BinlogRetention
+	if !br.IsEnabled() {
+		cr.Status.BinlogRetention = nil
+		return nil
+	}
+	if cr.Spec.Pause || cr.Status.PXC.Ready < 1 {
+		return nil
+	}
+
+	key := cr.Namespace + "/" + cr.Name
+	interval := time.Duration(br.IntervalSeconds) * time.Second
+	if last, ok := r.binlogPurgedAt.Load(key); ok && time.Since(last.(time.Time)) < interval {
+		return nil
+	}
+	r.binlogPurgedAt.Store(key, time.Now())
+
+	log := logf.FromContext(ctx)
+
+	pods := corev1.PodList{}
+	err := r.client.List(ctx, &pods, &client.ListOptions{
+		Namespace:     cr.Namespace,
+		LabelSelector: labels.SelectorFromSet(naming.LabelsPXC(cr)),
+	})
+	if err != nil {
+		return errors.Wrap(err, "get pod list")
+	}
+
+	var ready []corev1.Pod
+	for _, pod := range pods.Items {
+		if isPodReady(pod) {
+			ready = append(ready, pod)
+		}
+	}
+
+	if cr.Status.BinlogRetention == nil {
+		cr.Status.BinlogRetention = new(api.BinlogRetentionStatus)
+	}
+	status := cr.Status.BinlogRetention
+
+	now := time.Now()
+
+	if isReplicationSource(cr) {
+		connected, err := r.replicasConnected(ctx, cr, ready)
+		if err != nil {
+			return errors.Wrap(err, "check connected replicas")
+		}
+		if connected {
+			status.ReplicasSeenAt = &metav1.Time{Time: now}
+		}
+	}
+
+	var pitrUploaded *metav1.Time
+	if cr.Spec.Backup != nil && cr.Spec.Backup.PITR.Enabled {
+		pitrUploaded, err = backup.LatestRestorableTime(ctx, r.client, cr)
+		if err != nil {
+			return errors.Wrap(err, "get latest restorable time")
+		}
+	}
+
+	cutoff, heldBy := binlogPurgeCutoff(cr, now, pitrUploaded, status.ReplicasSeenAt)
+	if heldBy != "" && heldBy != status.HeldBy {
+		r.recorder.Event(cr, corev1.EventTypeWarning, naming.EventBinlogPurgeHeld, "Binlog purge is held: "+heldBy)
+	}
+	status.HeldBy = heldBy
+
+	// the server purges binary logs on rotation, the cutoff is moved by the next purge only
+	var expire int64
+	if heldBy == "" {
+		expire = int64(math.Ceil(now.Sub(cutoff).Seconds())) + int64(br.IntervalSeconds)
+	}
+
+	for _, pod := range ready {
+		err := r.purgeBinlogs(ctx, cr, pod.Name, expire, cutoff)
+		if err != nil {
+			return errors.Wrapf(err, "pod %s", pod.Name)
+		}
+	}
+
+	if heldBy != "" {
+		log.Info("Binlog purge is held", "reason", heldBy)
+		return nil
+	}
+
+	status.PurgedBefore = &metav1.Time{Time: cutoff}
+	status.LastPurgeTime = &metav1.Time{Time: now}
+
+	return nil
+}
+
+// purgeBinlogs sets binlog_expire_logs_seconds of the node and purges the binary logs older than cutoff.
+// The binary logs aren't purged if expire is 0.
+func (r *ReconcilePerconaXtraDBCluster) purgeBinlogs(ctx context.Context, cr *api.PerconaXtraDBCluster, podName string, expire int64, cutoff time.Time) error {
+	host := podName + "." + cr.Name + "-pxc." + cr.Namespace
+	db, err := queries.New(r.client, cr.Namespace, users.InternalSecretsPrefix+cr.Name, users.Operator, host, 33062, cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
+	if err != nil {
+		return errors.Wrap(err, "connect")
+	}
+	defer db.Close()
+
+	current, err := db.BinlogExpireSeconds()
+	if err != nil {
+		return err
+	}
+	if current != expire {
+		if err := db.SetBinlogExpireSeconds(expire); err != nil {
+			return err
+		}
+	}
+
+	if expire == 0 {
+		return nil
+	}
+
+	return db.PurgeBinaryLogsBefore(ctx, cutoff)
+}
+
+// replicasConnected returns true if a replica reads the binary logs of any of the pods.
+func (r *ReconcilePerconaXtraDBCluster) replicasConnected(ctx context.Context, cr *api.PerconaXtraDBCluster, pods []corev1.Pod) (bool, error) {
+	for _, pod := range pods {
+		host := pod.Name + "." + cr.Name + "-pxc." + cr.Namespace
+		db, err := queries.New(r.client, cr.Namespace, users.InternalSecretsPrefix+cr.Name, users.Operator, host, 33062, cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
+		if err != nil {
+			return false, errors.Wrapf(err, "connect to pod %s", pod.Name)
+		}
+
+		count, err := db.BinlogDumpThreads(ctx)
+		db.Close()
+		if err != nil {
+			return false, errors.Wrapf(err, "pod %s", pod.Name)
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// binlogPurgeCutoff returns the time the binary logs can be purged before, or the reason purging
+// has to be held. Binary logs are kept for the retention of the policy and of the external replication,
+// until they are uploaded by the PITR collector and since the replicas were connected the last time.
+// The server itself keeps the binary logs connected replicas are reading.
+func binlogPurgeCutoff(cr *api.PerconaXtraDBCluster, now time.Time, pitrUploaded, replicasSeenAt *metav1.Time) (time.Time, string) {
+	cutoff := now.Add(-time.Duration(cr.Spec.BinlogRetention.Seconds) * time.Second)
+
+	if er := cr.Spec.PXC.ExternalReplication; er.IsEnabled() && er.BinlogRetentionSeconds > 0 {
+		if t := now.Add(-time.Duration(er.BinlogRetentionSeconds) * time.Second); t.Before(cutoff) {
+			cutoff = t
+		}
+	}
+
+	if cr.Spec.Backup != nil && cr.Spec.Backup.PITR.Enabled {
+		if pitrUploaded == nil {
+			return time.Time{}, "binary logs aren't uploaded by the PITR collector yet"
+		}
+		if pitrUploaded.Time.Before(cutoff) {
+			cutoff = pitrUploaded.Time
+		}
+	}
+
+	if isReplicationSource(cr) {
+		if replicasSeenAt == nil {
+			return time.Time{}, "no replica has connected to the cluster yet"
+		}
+		if replicasSeenAt.Time.Before(cutoff) {
+			cutoff = replicasSeenAt.Time
+		}
+	}
+
+	return cutoff, ""
+}
+
+func isReplicationSource(cr *api.PerconaXtraDBCluster) bool {
+	channels := cr.Spec.PXC.ReplicationChannels
+	return len(channels) > 0 && channels[0].IsSource
+}
@@ -0,0 +1,92 @@
+package pxc
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestBinlogPurgeCutoff(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	hoursAgo := func(h int) *metav1.Time {
+		return &metav1.Time{Time: now.Add(-time.Duration(h) * time.Hour)}
+	}
+
+	tests := map[string]struct {
+		modify         func(cr *api.PerconaXtraDBCluster)
+		pitrUploaded   *metav1.Time
+		replicasSeenAt *metav1.Time
+		expected       time.Time
+		held           bool
+	}{
+		"retention only": {
+			expected: hoursAgo(24).Time,
+		},
+		"external replication keeps longer": {
+			modify: func(cr *api.PerconaXtraDBCluster) {
+				cr.Spec.PXC.ExternalReplication = &api.ExternalReplicationSpec{Enabled: true, BinlogRetentionSeconds: 48 * 3600}
+			},
+			expected: hoursAgo(48).Time,
+		},
+		"pitr collector behind": {
+			modify: func(cr *api.PerconaXtraDBCluster) {
+				cr.Spec.Backup = &api.PXCScheduledBackup{PITR: api.PITRSpec{Enabled: true}}
+			},
+			pitrUploaded: hoursAgo(30),
+			expected:     hoursAgo(30).Time,
+		},
+		"pitr collector up to date": {
+			modify: func(cr *api.PerconaXtraDBCluster) {
+				cr.Spec.Backup = &api.PXCScheduledBackup{PITR: api.PITRSpec{Enabled: true}}
+			},
+			pitrUploaded: hoursAgo(0),
+			expected:     hoursAgo(24).Time,
+		},
+		"nothing uploaded by pitr collector": {
+			modify: func(cr *api.PerconaXtraDBCluster) {
+				cr.Spec.Backup = &api.PXCScheduledBackup{PITR: api.PITRSpec{Enabled: true}}
+			},
+			held: true,
+		},
+		"replica disconnected": {
+			modify: func(cr *api.PerconaXtraDBCluster) {
+				cr.Spec.PXC.ReplicationChannels = []api.ReplicationChannel{{Name: "ch", IsSource: true}}
+			},
+			replicasSeenAt: hoursAgo(36),
+			expected:       hoursAgo(36).Time,
+		},
+		"replica never connected": {
+			modify: func(cr *api.PerconaXtraDBCluster) {
+				cr.Spec.PXC.ReplicationChannels = []api.ReplicationChannel{{Name: "ch", IsSource: true}}
+			},
+			held: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := newCR("cr-mock", "pxc")
+			cr.Spec.BinlogRetention = &api.BinlogRetentionSpec{Enabled: true, Seconds: 24 * 3600}
+			if tt.modify != nil {
+				tt.modify(cr)
+			}
+
+			cutoff, heldBy := binlogPurgeCutoff(cr, now, tt.pitrUploaded, tt.replicasSeenAt)
+			if tt.held {
+				if heldBy == "" {
+					t.Fatalf("expected purge to be held, got cutoff %s", cutoff)
+				}
+				return
+			}
+			if heldBy != "" {
+				t.Fatalf("unexpected hold: %s", heldBy)
+			}
+			if !cutoff.Equal(tt.expected) {
+				t.Errorf("expected cutoff %s, got %s", tt.expected, cutoff)
+			}
+		})
+	}
+}
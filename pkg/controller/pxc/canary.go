@@ -0,0 +1,152 @@
+package pxc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+// canaryUpdate restarts the first secondary pod with the new revision and soaks it
+// for the configured period. It returns true once the canary passed and
+// the rest of the pods can be updated.
+func (r *ReconcilePerconaXtraDBCluster) canaryUpdate(ctx context.Context, cr *api.PerconaXtraDBCluster, sts *appsv1.StatefulSet, pods []corev1.Pod, primary string, waitLimit int) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	spec := cr.Spec.UpgradeOptions.Canary
+	revision := sts.Status.UpdateRevision
+
+	status := cr.Status.Canary
+	if status == nil || status.Revision != revision {
+		var canary *corev1.Pod
+		for i := range pods {
+			if !strings.HasPrefix(primary, fmt.Sprintf("%s.%s.%s", pods[i].Name, sts.Name, sts.Namespace)) {
+				canary = &pods[i]
+				break
+			}
+		}
+		if canary == nil {
+			log.Info("no secondary pods, skipping canary")
+			return true, nil
+		}
+
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventSmartUpdateStarted,
+			"Smart update of %s started to revision %s, canary %s will be soaked for %s", sts.Name, revision, canary.Name, spec.SoakPeriod.Duration)
+
+		log.Info("apply changes to canary pod", "pod", canary.Name)
+		r.setSmartUpdateProgress(ctx, cr, sts, api.SmartUpdateCanary, canary.Name)
+		if err := r.applyNWait(ctx, cr, sts, canary, waitLimit); err != nil {
+			return false, errors.Wrap(err, "failed to apply changes")
+		}
+
+		pod := new(corev1.Pod)
+		if err := r.client.Get(ctx, types.NamespacedName{Name: canary.Name, Namespace: canary.Namespace}, pod); err != nil {
+			return false, errors.Wrap(err, "get canary pod")
+		}
+
+		cr.Status.Canary = &api.CanaryStatus{
+			Pod:       pod.Name,
+			Revision:  revision,
+			State:     api.CanarySoaking,
+			StartedAt: metav1.NewTime(time.Now().Truncate(time.Second)),
+			Restarts:  pxcRestarts(pod),
+		}
+		return false, nil
+	}
+
+	switch status.State {
+	case api.CanaryPassed:
+		return true, nil
+	case api.CanaryFailed:
+		log.Info("smart update is halted: canary failed", "pod", status.Pod, "revision", revision, "reason", status.Message)
+		r.setSmartUpdateProgress(ctx, cr, sts, api.SmartUpdateHalted, "")
+		return false, nil
+	}
+
+	reason, err := r.canaryFailure(ctx, cr, status)
+	if err != nil {
+		return false, errors.Wrap(err, "check canary")
+	}
+	if reason != "" {
+		status.State = api.CanaryFailed
+		status.Message = reason
+		log.Info("canary failed, smart update is halted", "pod", status.Pod, "reason", reason)
+		r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventSmartUpdateCanaryFailed,
+			"Smart update of %s is halted: canary %s failed: %s", sts.Name, status.Pod, reason)
+		r.setSmartUpdateProgress(ctx, cr, sts, api.SmartUpdateHalted, "")
+		return false, nil
+	}
+
+	if time.Since(status.StartedAt.Time) < spec.SoakPeriod.Duration {
+		log.V(1).Info("canary is soaking", "pod", status.Pod, "startedAt", status.StartedAt)
+		r.setSmartUpdateProgress(ctx, cr, sts, api.SmartUpdateCanary, status.Pod)
+		return false, nil
+	}
+
+	status.State = api.CanaryPassed
+	status.Message = ""
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventSmartUpdateCanaryPassed,
+		"Canary %s passed the soak period, continuing smart update of %s", status.Pod, sts.Name)
+
+	return true, nil
+}
+
+// canaryFailure returns the reason why the canary is considered unhealthy
+// or an empty string if it behaves well.
+func (r *ReconcilePerconaXtraDBCluster) canaryFailure(ctx context.Context, cr *api.PerconaXtraDBCluster, status *api.CanaryStatus) (string, error) {
+	pod := new(corev1.Pod)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: status.Pod, Namespace: cr.Namespace}, pod); err != nil {
+		return "", errors.Wrap(err, "get canary pod")
+	}
+
+	if restarts := pxcRestarts(pod); restarts > status.Restarts {
+		return fmt.Sprintf("pxc container restarted %d times", restarts-status.Restarts), nil
+	}
+	if !isPodReady(*pod) {
+		return "pod is not ready", nil
+	}
+
+	wsrep, err := r.wsrepStatus(ctx, cr, pod.Name)
+	if err != nil {
+		return "", errors.Wrap(err, "get wsrep status")
+	}
+	if v := wsrep["cluster_status"]; v != "Primary" {
+		return "wsrep_cluster_status is " + v, nil
+	}
+	if v := wsrep["local_state"]; v != "4" {
+		return "wsrep_local_state is " + v, nil
+	}
+
+	maxFailures := cr.Spec.UpgradeOptions.Canary.MaxCertFailures
+	if maxFailures > 0 {
+		failures, err := strconv.ParseInt(wsrep["local_cert_failures"], 10, 64)
+		if err != nil {
+			return "", errors.Wrap(err, "parse wsrep_local_cert_failures")
+		}
+		if failures > maxFailures {
+			return fmt.Sprintf("%d certification failures, allowed %d", failures, maxFailures), nil
+		}
+	}
+
+	return "", nil
+}
+
+func pxcRestarts(pod *corev1.Pod) int32 {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name == "pxc" {
+			return cs.RestartCount
+		}
+	}
+	return 0
+}
@@ -0,0 +1,203 @@
+package pxc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+// maxConsistencyDiffTables limits the number of inconsistent tables stored in the cluster status.
+const maxConsistencyDiffTables = 20
+
+// reconcileConsistencyCheck manages the pt-table-checksum CronJob.
+// The checksums are calculated only on source clusters, replicas receive them with the replication.
+func (r *ReconcilePerconaXtraDBCluster) reconcileConsistencyCheck(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	if !cr.Spec.ConsistencyCheck.IsEnabled() || isReplicaCluster(cr) {
+		return r.deleteConsistencyCheckCronJob(ctx, cr)
+	}
+
+	cronJob, err := r.consistencyCheckCronJob(cr)
+	if err != nil {
+		return errors.Wrap(err, "build consistency check cronjob")
+	}
+
+	return errors.Wrap(r.createOrUpdate(ctx, cr, cronJob), "create or update consistency check cronjob")
+}
+
+func (r *ReconcilePerconaXtraDBCluster) deleteConsistencyCheckCronJob(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	cronJob := new(batchv1.CronJob)
+	err := r.client.Get(ctx, types.NamespacedName{Name: naming.ConsistencyCheckCronJobName(cr), Namespace: cr.Namespace}, cronJob)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "get consistency check cronjob")
+	}
+
+	if !metav1.IsControlledBy(cronJob, cr) {
+		return nil
+	}
+
+	err = r.client.Delete(ctx, cronJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "delete consistency check cronjob")
+	}
+	return nil
+}
+
+// checkReplicaConsistency compares the checksums replicated from the source
+// and reports the tables that differ with the ReplicaConsistent condition and events.
+func (r *ReconcilePerconaXtraDBCluster) checkReplicaConsistency(ctx context.Context, cr *api.PerconaXtraDBCluster, primaryDB queries.Database) error {
+	if !cr.Spec.ConsistencyCheck.IsEnabled() {
+		cr.Status.ConsistencyCheck = nil
+		cr.Status.RemoveCondition(api.ConditionReplicaConsistent)
+		return nil
+	}
+
+	lastChecksum, diffs, err := primaryDB.ChecksumDiffs()
+	if err == queries.ErrNotFound || (err == nil && lastChecksum.IsZero()) {
+		// no checksums are replicated yet
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "get checksum diffs")
+	}
+
+	if len(diffs) > maxConsistencyDiffTables {
+		diffs = diffs[:maxConsistencyDiffTables]
+	}
+
+	status := cr.Status.ConsistencyCheck
+	if status == nil || !slices.Equal(status.DiffTables, diffs) {
+		logf.FromContext(ctx).Info("Replica consistency is changed", "diffTables", diffs)
+		if len(diffs) > 0 {
+			r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventReplicaInconsistent,
+				"Tables differ from the replication source: %s", strings.Join(diffs, ", "))
+		} else {
+			r.recorder.Event(cr, corev1.EventTypeNormal, naming.EventReplicaConsistent, "Checksums of all tables match the replication source")
+		}
+	}
+
+	checksumTime := metav1.NewTime(lastChecksum)
+	cr.Status.ConsistencyCheck = &api.ConsistencyCheckStatus{
+		LastChecksumTime: &checksumTime,
+		DiffTables:       diffs,
+	}
+
+	condition := api.ClusterCondition{
+		Type:               api.ConditionReplicaConsistent,
+		Status:             api.ConditionTrue,
+		Reason:             "ChecksumsMatch",
+		LastTransitionTime: metav1.NewTime(time.Now().Truncate(time.Second)),
+	}
+	if len(diffs) > 0 {
+		condition.Status = api.ConditionFalse
+		condition.Reason = "ChecksumsDiffer"
+		condition.Message = fmt.Sprintf("%d tables differ from the replication source", len(diffs))
+	}
+	cr.Status.SetCondition(condition)
+
+	return nil
+}
+
+func (r *ReconcilePerconaXtraDBCluster) consistencyCheckCronJob(cr *api.PerconaXtraDBCluster) (*batchv1.CronJob, error) {
+	spec := cr.Spec.ConsistencyCheck
+
+	host := spec.Host
+	if host == "" {
+		host = cr.Name + "-pxc-0." + cr.Name + "-pxc." + cr.Namespace
+	}
+
+	args := []string{
+		"h=$(PXC_HOST),P=3306,u=" + users.Operator + ",p=$(OPERATOR_PASS)",
+		"--replicate=percona.checksums",
+		// async replicas can't be discovered from the source, they receive the checksum queries with the replication
+		"--recursion-method=none",
+		"--no-version-check",
+	}
+	if len(spec.Databases) > 0 {
+		args = append(args, "--databases="+strings.Join(spec.Databases, ","))
+	}
+	args = append(args, spec.Args...)
+
+	ls := naming.LabelsConsistencyCheck(cr)
+	backoffLimit := int32(0)
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.ConsistencyCheckCronJobName(cr),
+			Namespace: cr.Namespace,
+			Labels:    ls,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:          spec.Schedule,
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: ls},
+				Spec: batchv1.JobSpec{
+					BackoffLimit: &backoffLimit,
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: ls},
+						Spec: corev1.PodSpec{
+							RestartPolicy:    corev1.RestartPolicyNever,
+							SecurityContext:  cr.Spec.PXC.PodSecurityContext,
+							ImagePullSecrets: cr.Spec.PXC.ImagePullSecrets,
+							Containers: []corev1.Container{
+								{
+									Name:            "pt-table-checksum",
+									Image:           spec.Image,
+									ImagePullPolicy: cr.Spec.PXC.ImagePullPolicy,
+									SecurityContext: cr.Spec.PXC.ContainerSecurityContext,
+									Command:         []string{"pt-table-checksum"},
+									Args:            args,
+									Resources:       spec.Resources,
+									Env: []corev1.EnvVar{
+										{
+											Name:  "PXC_HOST",
+											Value: host,
+										},
+										{
+											Name: "OPERATOR_PASS",
+											ValueFrom: &corev1.EnvVarSource{
+												SecretKeyRef: app.SecretKeySelector(cr.Spec.SecretsName, users.Operator),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	cr.ApplyImageOverrides(&cronJob.Spec.JobTemplate.Spec.Template.Spec)
+
+	if err := k8s.SetControllerReference(cr, cronJob, r.scheme); err != nil {
+		return nil, errors.Wrap(err, "set controller reference")
+	}
+
+	return cronJob, nil
+}
+
+func isReplicaCluster(cr *api.PerconaXtraDBCluster) bool {
+	channels := cr.Spec.PXC.ReplicationChannels
+	return len(channels) > 0 && !channels[0].IsSource || cr.Spec.Standby.IsEnabled()
+}
@@ -0,0 +1,59 @@
+package pxc
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestConsistencyCheckCronJob(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.ConsistencyCheck = &api.ConsistencyCheckSpec{
+		Enabled:   true,
+		Schedule:  "0 3 * * 0",
+		Image:     "percona/percona-toolkit",
+		Databases: []string{"app", "billing"},
+		Args:      []string{"--chunk-time=1"},
+	}
+
+	r := buildFakeClient([]runtime.Object{cr})
+
+	cronJob, err := r.consistencyCheckCronJob(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Args
+	for _, arg := range []string{"--replicate=percona.checksums", "--recursion-method=none", "--databases=app,billing", "--chunk-time=1"} {
+		if !slices.Contains(args, arg) {
+			t.Errorf("expected %s in args %v", arg, args)
+		}
+	}
+	if cronJob.Spec.ConcurrencyPolicy != batchv1.ForbidConcurrent {
+		t.Errorf("unexpected concurrency policy %s", cronJob.Spec.ConcurrencyPolicy)
+	}
+
+	if err := r.client.Create(ctx, cronJob); err != nil {
+		t.Fatal(err)
+	}
+
+	// replicas receive the checksums from the source and don't run the check
+	cr.Spec.PXC.ReplicationChannels = []api.ReplicationChannel{{Name: "dr"}}
+	if err := r.reconcileConsistencyCheck(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.client.Get(ctx, client.ObjectKeyFromObject(cronJob), new(batchv1.CronJob))
+	if !k8serrors.IsNotFound(err) {
+		t.Fatalf("expected the cronjob to be deleted, got %v", err)
+	}
+}
@@ -2,9 +2,8 @@ package pxc
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/json"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -15,7 +14,6 @@ import (
 	"github.com/robfig/cron/v3"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
-	policyv1 "k8s.io/api/policy/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -25,20 +23,27 @@ import (
 	k8sretry "k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/percona/percona-xtradb-cluster-operator/clientcmd"
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/audit"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/config"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/secretmanager"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/tracing"
 	"github.com/percona/percona-xtradb-cluster-operator/version"
 )
 
@@ -65,23 +70,49 @@ func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
 		return nil, errors.Wrap(err, "create clientcmd")
 	}
 
+	recorder := mgr.GetEventRecorderFor(naming.OperatorController)
+
 	return &ReconcilePerconaXtraDBCluster{
-		client:        mgr.GetClient(),
+		client:        tracing.WrapClient(mgr.GetClient()),
 		scheme:        mgr.GetScheme(),
 		crons:         NewCronRegistry(),
 		serverVersion: sv,
 		clientcmd:     cli,
 		lockers:       newLockStore(),
-		recorder:      mgr.GetEventRecorderFor(naming.OperatorController),
+		recorder:      recorder,
+		audit:         audit.NewRecorder(mgr.GetClient(), recorder),
+		secretsCache:  secretmanager.NewCache(),
+		operatorID:    operatorIdentity(),
 	}, nil
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
-	return builder.ControllerManagedBy(mgr).
+	maxConcurrentReconciles, err := k8s.GetMaxConcurrentReconciles(k8s.MaxConcurrentReconcilesPXCEnvVar)
+	if err != nil {
+		return err
+	}
+	rateLimiter, err := k8s.NewRateLimiter()
+	if err != nil {
+		return err
+	}
+
+	b := builder.ControllerManagedBy(mgr).
 		Named(naming.OperatorController).
-		Watches(&api.PerconaXtraDBCluster{}, &handler.EnqueueRequestForObject{}).
-		Complete(r)
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             rateLimiter,
+		}).
+		// the cluster is requeued periodically, status updates shouldn't bypass the backoff of failed reconciles
+		Watches(&api.PerconaXtraDBCluster{}, &handler.EnqueueRequestForObject{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{},
+			predicate.AnnotationChangedPredicate{},
+			predicate.LabelChangedPredicate{},
+		), k8s.NamespaceSelectorPredicate(mgr.GetClient()))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(usersSecretToClusters(mgr.GetClient())))
+
+	return k8s.WatchNamespaces(b, mgr.GetClient(), func() client.ObjectList { return new(api.PerconaXtraDBClusterList) }).
+		Complete(k8s.DetachReconciler(metrics.InstrumentReconciler(naming.OperatorController, tracing.InstrumentReconciler(naming.OperatorController, r))))
 }
 
 var _ reconcile.Reconciler = &ReconcilePerconaXtraDBCluster{}
@@ -98,6 +129,25 @@ type ReconcilePerconaXtraDBCluster struct {
 	serverVersion  *version.ServerVersion
 	lockers        lockStore
 	recorder       record.EventRecorder
+	audit          *audit.Recorder
+
+	secretsCache      *secretmanager.Cache
+	newSecretProvider secretmanager.NewProviderFunc
+	newStorageClient  storage.NewClientFunc
+
+	// operatorID identifies this operator in the ownership annotations of clusters
+	operatorID string
+
+	// wsrepCollectedAt holds the last time wsrep metrics were collected per cluster
+	wsrepCollectedAt sync.Map
+	// wsrepSamples holds the history of the wsrep status of the PXC pods per cluster
+	wsrepSamples sync.Map
+	// queryKillerCheckedAt holds the last time the query killer policy was enforced per cluster
+	queryKillerCheckedAt sync.Map
+	// binlogPurgedAt holds the last time the binlog retention policy was enforced per cluster
+	binlogPurgedAt sync.Map
+	// backupGCAt holds the last time the backup storages were scanned for orphaned backups per cluster
+	backupGCAt sync.Map
 }
 
 type lockStore struct {
@@ -195,12 +245,31 @@ func (r *ReconcilePerconaXtraDBCluster) Reconcile(ctx context.Context, request r
 		if k8serrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
+			metrics.DeleteCluster(request.Namespace, request.Name)
 			return rr, nil
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
 
+	watched, err := k8s.NamespaceWatched(ctx, r.client, o.Namespace)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "check namespace")
+	}
+	if !watched {
+		// the namespace is unlabeled, it's requeued on labels change
+		r.stopClusterJobs(o)
+		return reconcile.Result{}, nil
+	}
+
+	owned, err := r.claimCluster(ctx, o)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "claim cluster")
+	}
+	if !owned {
+		return reconcile.Result{RequeueAfter: ownershipRenewPeriod}, nil
+	}
+
 	defer func() {
 		uerr := r.updateStatus(ctx, o, false, err)
 		if uerr != nil {
@@ -208,20 +277,54 @@ func (r *ReconcilePerconaXtraDBCluster) Reconcile(ctx context.Context, request r
 		}
 	}()
 
-	if err := r.setCRVersion(ctx, o); err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "set CR version")
-	}
-
-	err = o.CheckNSetDefaults(r.serverVersion, log)
+	err = tracing.Phase(ctx, "defaults", func(ctx context.Context) error {
+		// the spec is checked before it's defaulted
+		if err := o.PruneAppliedSpecChanges(); err != nil {
+			return errors.Wrap(err, "prune applied spec changes")
+		}
+		if err := r.setCRVersion(ctx, o); err != nil {
+			return errors.Wrap(err, "set CR version")
+		}
+		return errors.Wrap(o.CheckNSetDefaults(r.serverVersion, log), "wrong PXC options")
+	})
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "wrong PXC options")
+		return reconcile.Result{}, err
 	}
 
 	if o.ObjectMeta.DeletionTimestamp != nil {
+		// the ordered teardown runs before the other finalizers remove the workloads
+		if slices.Contains(o.GetFinalizers(), naming.FinalizerOrderedTeardown) {
+			var blocker string
+			blocker, err = r.orderedTeardown(ctx, o)
+			if err != nil {
+				return reconcile.Result{}, errors.Wrap(err, "ordered teardown")
+			}
+			if blocker != "" {
+				log.Info("cluster deletion is postponed", "reason", blocker)
+				r.recorder.Eventf(o, corev1.EventTypeNormal, naming.EventDeletionPostponed, "Cluster deletion is postponed: %s", blocker)
+				return rr, nil
+			}
+		}
+
+		if slices.Contains(o.GetFinalizers(), naming.FinalizerWaitForBackupRestore) {
+			var blocker string
+			blocker, err = r.deletionBlocker(o)
+			if err != nil {
+				return reconcile.Result{}, errors.Wrap(err, "check running backups and restores")
+			}
+			if blocker != "" {
+				log.Info("cluster deletion is postponed", "reason", blocker)
+				r.recorder.Eventf(o, corev1.EventTypeNormal, naming.EventDeletionPostponed, "Cluster deletion is postponed: %s", blocker)
+				return rr, nil
+			}
+		}
+
 		finalizers := []string{}
 		for _, fnlz := range o.GetFinalizers() {
 			var sfs api.StatefulApp
 			switch fnlz {
+			case naming.FinalizerWaitForBackupRestore, naming.FinalizerOrderedTeardown:
+				err = nil
 			case "delete-ssl":
 				log.Info("The finalizer delete-ssl is deprecated and will be deleted in 1.18.0. Use percona.com/delete-ssl")
 				fallthrough
@@ -272,6 +375,13 @@ func (r *ReconcilePerconaXtraDBCluster) Reconcile(ctx context.Context, request r
 		return rr, nil
 	}
 
+	if o.Spec.Suspend {
+		// the status is still updated by the deferred updateStatus
+		log.V(1).Info("Reconciliation is suspended")
+		r.stopClusterJobs(o)
+		return rr, nil
+	}
+
 	// wait until token issued to run PXC in data encrypted mode.
 	if o.ShouldWaitForTokenIssue() {
 		log.Info("wait for token issuing")
@@ -285,6 +395,11 @@ func (r *ReconcilePerconaXtraDBCluster) Reconcile(ctx context.Context, request r
 		}
 	}
 
+	// the users can't be reconciled while the cluster is down, the failure is diagnosed before
+	if err := r.reconcileDiagnostics(ctx, o); err != nil {
+		log.Error(err, "failed to reconcile diagnostics")
+	}
+
 	if o.Spec.ProxySQLEnabled() {
 		haproxySts := appsv1.StatefulSet{
 			ObjectMeta: metav1.ObjectMeta{
@@ -298,30 +413,51 @@ func (r *ReconcilePerconaXtraDBCluster) Reconcile(ctx context.Context, request r
 		}
 	}
 
-	err = r.reconcileUsersSecret(ctx, o)
+	err = tracing.Phase(ctx, "secrets", func(ctx context.Context) error {
+		if err := r.syncUsersSecretFromSource(ctx, o); err != nil {
+			return errors.Wrap(err, "sync users secret from source")
+		}
+		return errors.Wrap(r.reconcileUsersSecret(ctx, o), "reconcile users secret")
+	})
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "reconcile users secret")
+		return reconcile.Result{}, err
 	}
 
 	// TODO: We should not use ReconcileUsersResult. Instead, we should update the statefulset annotations in the reconcileUsers method as soon as possible.
 	// Currently, if an error occurs before the statefulsets are updated with annotations, and reconcileUsers has a different result on the next reconcile, the statefulsets will not have the required annotations.
 	userReconcileResult := &ReconcileUsersResult{}
 
-	urr, err := r.reconcileUsers(ctx, o)
+	err = tracing.Phase(ctx, "users", func(ctx context.Context) error {
+		urr, err := r.reconcileUsers(ctx, o)
+		if err != nil {
+			return errors.Wrap(err, "reconcile users")
+		}
+		if urr != nil {
+			userReconcileResult = urr
+		}
+
+		return errors.Wrap(r.reconcileCustomUsers(ctx, o), "reconcile custom users")
+	})
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "reconcile users")
+		return reconcile.Result{}, err
 	}
-	if urr != nil {
-		userReconcileResult = urr
+
+	err = r.reconcilePasswordPolicy(ctx, o)
+	if err != nil {
+		log.Error(err, "failed to reconcile password policy")
 	}
 
-	err = r.reconcileCustomUsers(ctx, o)
+	err = r.reconcileVaultDBSecrets(ctx, o)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "reconcile custom users")
+		log.Error(err, "failed to reconcile vault database secrets")
 	}
 
 	r.resyncPXCUsersWithProxySQL(ctx, o)
 
+	r.collectWsrepMetrics(ctx, o)
+
+	r.enforceQueryKiller(ctx, o)
+
 	if o.Status.PXC.Version == "" || strings.HasSuffix(o.Status.PXC.Version, "intermediate") {
 		err := r.ensurePXCVersion(ctx, o, VersionServiceClient{OpVersion: o.Version().String()})
 		if err != nil {
@@ -333,20 +469,30 @@ func (r *ReconcilePerconaXtraDBCluster) Reconcile(ctx context.Context, request r
 		return reconcile.Result{}, errors.Wrap(err, "reconcile persistent volumes")
 	}
 
-	err = r.reconcileSSL(ctx, o)
+	err = r.reconcileVolumeOverrides(ctx, o)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile SSL. Please create your TLS secret %s and %s manually or setup cert-manager correctly", o.Spec.PXC.SSLSecretName, o.Spec.PXC.SSLInternalSecretName)
+		return reconcile.Result{}, errors.Wrap(err, "reconcile volume overrides")
 	}
 
-	err = r.deploy(ctx, o)
+	err = r.reconcileGaleraSegments(ctx, o)
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, errors.Wrap(err, "reconcile galera segments")
+	}
+
+	err = r.reconcileSSL(ctx, o)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "failed to reconcile SSL. Please create your TLS secret %s and %s manually or setup cert-manager correctly", o.Spec.PXC.SSLSecretName, o.Spec.PXC.SSLInternalSecretName)
 	}
 
 	pxcSet := statefulset.NewNode(o)
-	err = r.updatePod(ctx, pxcSet, o.Spec.PXC.PodSpec, o, userReconcileResult.pxcAnnotations, true)
+	err = tracing.Phase(ctx, "statefulsets", func(ctx context.Context) error {
+		if err := r.deploy(ctx, o); err != nil {
+			return err
+		}
+		return errors.Wrap(r.updatePod(ctx, pxcSet, o.Spec.PXC.PodSpec, o, userReconcileResult.pxcAnnotations, true), "pxc upgrade error")
+	})
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "pxc upgrade error")
+		return reconcile.Result{}, err
 	}
 
 	saveOldSvcMeta := true
@@ -362,6 +508,54 @@ func (r *ReconcilePerconaXtraDBCluster) Reconcile(ctx context.Context, request r
 		return reconcile.Result{}, errors.Wrap(err, "PXC service upgrade error")
 	}
 
+	if err := r.reconcileMonitoring(ctx, o); err != nil {
+		log.Error(err, "failed to reconcile monitoring objects")
+	}
+
+	if err := r.reconcileConsistencyCheck(ctx, o); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "reconcile consistency check")
+	}
+
+	if err := r.reconcileExternalReplication(ctx, o); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "reconcile external replication")
+	}
+
+	if err := r.reconcileReporting(ctx, o); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "reconcile reporting")
+	}
+
+	if err := r.reconcileStandby(ctx, o); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "reconcile standby")
+	}
+
+	if err := r.reconcileTableMaintenance(ctx, o); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "reconcile table maintenance")
+	}
+
+	if err := r.reconcileBinlogRetention(ctx, o); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "reconcile binlog retention")
+	}
+
+	if err := r.reconcileProviderOptions(ctx, o); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "reconcile provider options")
+	}
+
+	if err := r.reconcileBackupGarbageCollection(ctx, o); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "reconcile backup garbage collection")
+	}
+
+	if err := r.reconcileVelero(ctx, o); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "reconcile velero")
+	}
+
+	if err := r.reconcileGatewayRoutes(ctx, o); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "reconcile gateway routes")
+	}
+
+	if err := r.reconcileNetworkPolicies(ctx, o); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "reconcile network policies")
+	}
+
 	if o.Spec.PXC.Expose.Enabled {
 		err = r.ensurePxcPodServices(ctx, o)
 		if err != nil {
@@ -428,6 +622,10 @@ func (r *ReconcilePerconaXtraDBCluster) Reconcile(ctx context.Context, request r
 		}
 	}
 
+	if err := r.reconcileMetadata(ctx, o); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "reconcile metadata")
+	}
+
 	if o.CompareVersionWith("1.9.0") >= 0 {
 		err = r.reconcileReplication(ctx, o, userReconcileResult.updateReplicationPassword)
 		if err != nil {
@@ -435,17 +633,15 @@ func (r *ReconcilePerconaXtraDBCluster) Reconcile(ctx context.Context, request r
 		}
 	}
 
-	err = r.reconcileBackups(ctx, o)
-	if err != nil {
-		return reconcile.Result{}, err
-	}
-
-	err = backup.CheckPITRErrors(ctx, r.client, r.clientcmd, o)
-	if err != nil {
-		return reconcile.Result{}, err
-	}
-
-	err = backup.UpdatePITRTimeline(ctx, r.client, r.clientcmd, o)
+	err = tracing.Phase(ctx, "backup", func(ctx context.Context) error {
+		if err := r.reconcileBackups(ctx, o); err != nil {
+			return err
+		}
+		if err := backup.CheckPITRErrors(ctx, r.client, r.clientcmd, r.recorder, o); err != nil {
+			return err
+		}
+		return backup.UpdatePITRTimeline(ctx, r.client, r.clientcmd, o)
+	})
 	if err != nil {
 		return reconcile.Result{}, err
 	}
@@ -694,8 +890,8 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileConfigMap(cr *api.PerconaXtraDB
 	}
 
 	logCollectorConfigName := config.CustomConfigMapName(cr.Name, "logcollector")
-	if cr.Spec.LogCollector != nil && cr.Spec.LogCollector.Configuration != "" {
-		configMap := config.NewConfigMap(cr, logCollectorConfigName, "fluentbit_custom.conf", cr.Spec.LogCollector.Configuration)
+	if config.LogCollectorConfigEnabled(cr) {
+		configMap := config.NewLogCollectorConfigMap(cr)
 		err := k8s.SetControllerReference(cr, configMap, r.scheme)
 		if err != nil {
 			return errors.Wrap(err, "set controller ref LogCollector")
@@ -710,6 +906,91 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileConfigMap(cr *api.PerconaXtraDB
 		}
 	}
 
+	reportingConfigName := config.ReportingConfigMapName(cr.Name)
+	if reporting := cr.Spec.PXC.Reporting; reporting.IsEnabled() && reporting.Configuration != "" {
+		configMap := config.NewConfigMap(cr, reportingConfigName, config.ReportingConfigFileName, reporting.Configuration)
+		err := k8s.SetControllerReference(cr, configMap, r.scheme)
+		if err != nil {
+			return errors.Wrap(err, "set controller ref reporting config")
+		}
+		err = createOrUpdateConfigmap(r.client, configMap)
+		if err != nil {
+			return errors.Wrap(err, "reporting config map")
+		}
+	} else {
+		if err := deleteConfigMapIfExists(r.client, cr, reportingConfigName); err != nil {
+			return errors.Wrap(err, "delete reporting config map")
+		}
+	}
+
+	loggingConfigName := config.LoggingConfigMapName(cr.Name)
+	if config.LoggingConfigEnabled(cr) {
+		configMap := config.NewLoggingConfigMap(cr)
+		err := k8s.SetControllerReference(cr, configMap, r.scheme)
+		if err != nil {
+			return errors.Wrap(err, "set controller ref logging config")
+		}
+		err = createOrUpdateConfigmap(r.client, configMap)
+		if err != nil {
+			return errors.Wrap(err, "logging config map")
+		}
+	} else {
+		if err := deleteConfigMapIfExists(r.client, cr, loggingConfigName); err != nil {
+			return errors.Wrap(err, "delete logging config map")
+		}
+	}
+
+	dataVolumesConfigName := config.DataVolumesConfigMapName(cr.Name)
+	if config.DataVolumesConfigEnabled(cr) {
+		configMap := config.NewDataVolumesConfigMap(cr)
+		err := k8s.SetControllerReference(cr, configMap, r.scheme)
+		if err != nil {
+			return errors.Wrap(err, "set controller ref data volumes config")
+		}
+		err = createOrUpdateConfigmap(r.client, configMap)
+		if err != nil {
+			return errors.Wrap(err, "data volumes config map")
+		}
+	} else {
+		if err := deleteConfigMapIfExists(r.client, cr, dataVolumesConfigName); err != nil {
+			return errors.Wrap(err, "delete data volumes config map")
+		}
+	}
+
+	sstConfigName := config.SSTConfigMapName(cr.Name)
+	if config.SSTConfigEnabled(cr) {
+		configMap := config.NewSSTConfigMap(cr)
+		err := k8s.SetControllerReference(cr, configMap, r.scheme)
+		if err != nil {
+			return errors.Wrap(err, "set controller ref SST config")
+		}
+		err = createOrUpdateConfigmap(r.client, configMap)
+		if err != nil {
+			return errors.Wrap(err, "SST config map")
+		}
+	} else {
+		if err := deleteConfigMapIfExists(r.client, cr, sstConfigName); err != nil {
+			return errors.Wrap(err, "delete SST config map")
+		}
+	}
+
+	providerOptionsConfigName := config.ProviderOptionsConfigMapName(cr.Name)
+	if cr.Spec.PXC.ProviderOptions != nil {
+		configMap := config.NewProviderOptionsConfigMap(cr)
+		err := k8s.SetControllerReference(cr, configMap, r.scheme)
+		if err != nil {
+			return errors.Wrap(err, "set controller ref provider options config")
+		}
+		err = createOrUpdateConfigmap(r.client, configMap)
+		if err != nil {
+			return errors.Wrap(err, "provider options config map")
+		}
+	} else {
+		if err := deleteConfigMapIfExists(r.client, cr, providerOptionsConfigName); err != nil {
+			return errors.Wrap(err, "delete provider options config map")
+		}
+	}
+
 	return nil
 }
 
@@ -886,10 +1167,13 @@ func (r *ReconcilePerconaXtraDBCluster) deletePVC(namespace string, lbls map[str
 
 func (r *ReconcilePerconaXtraDBCluster) deleteSecrets(cr *api.PerconaXtraDBCluster) error {
 	secrets := []string{
-		cr.Spec.SecretsName,
 		"internal-" + cr.Name,
 		cr.Name + "-mysql-init",
 	}
+	// externally managed users secret is owned by another tool
+	if !cr.Spec.ExternalUsersSecret {
+		secrets = append(secrets, cr.Spec.SecretsName)
+	}
 
 	for _, secretName := range secrets {
 		secret := &corev1.Secret{}
@@ -1010,12 +1294,8 @@ func createOrUpdateConfigmap(cl client.Client, configMap *corev1.ConfigMap) erro
 		return errors.Wrap(err, "get current configmap")
 	}
 
-	if k8serrors.IsNotFound(err) {
-		return cl.Create(context.TODO(), configMap)
-	}
-
-	if !reflect.DeepEqual(currMap.Data, configMap.Data) {
-		return cl.Update(context.TODO(), configMap)
+	if k8serrors.IsNotFound(err) || !reflect.DeepEqual(currMap.Data, configMap.Data) {
+		return k8s.Apply(context.TODO(), cl, configMap)
 	}
 
 	return nil
@@ -1046,30 +1326,13 @@ func deleteConfigMapIfExists(cl client.Client, cr *api.PerconaXtraDBCluster, cmN
 func (r *ReconcilePerconaXtraDBCluster) createOrUpdate(ctx context.Context, cr *api.PerconaXtraDBCluster, obj client.Object) error {
 	log := logf.FromContext(ctx)
 
-	if obj.GetAnnotations() == nil {
-		obj.SetAnnotations(make(map[string]string))
-	}
-
-	objAnnotations := obj.GetAnnotations()
-	delete(objAnnotations, "percona.com/last-config-hash")
-	obj.SetAnnotations(objAnnotations)
-
-	hash, err := getObjectHash(obj)
-	if err != nil {
-		return errors.Wrap(err, "calculate object hash")
-	}
-
-	objAnnotations = obj.GetAnnotations()
-	objAnnotations["percona.com/last-config-hash"] = hash
-	obj.SetAnnotations(objAnnotations)
-
 	val := reflect.ValueOf(obj)
 	if val.Kind() == reflect.Ptr {
 		val = reflect.Indirect(val)
 	}
 	oldObject := reflect.New(val.Type()).Interface().(client.Object)
 
-	err = r.client.Get(ctx, types.NamespacedName{
+	err := r.client.Get(ctx, types.NamespacedName{
 		Name:      obj.GetName(),
 		Namespace: obj.GetNamespace(),
 	}, oldObject)
@@ -1080,25 +1343,26 @@ func (r *ReconcilePerconaXtraDBCluster) createOrUpdate(ctx context.Context, cr *
 
 	if k8serrors.IsNotFound(err) {
 		log.V(1).Info("Creating object", "object", obj.GetName(), "kind", obj.GetObjectKind())
-		return r.client.Create(ctx, obj)
+		if err := k8s.Apply(ctx, r.client, obj); err != nil {
+			return err
+		}
+		if _, ok := obj.(*appsv1.StatefulSet); ok {
+			r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventStatefulSetCreated, "StatefulSet %s created", obj.GetName())
+		}
+		return nil
 	}
 
-	if oldObject.GetAnnotations()["percona.com/last-config-hash"] != hash ||
-		!isObjectMetaEqual(obj, oldObject) {
+	// the API server doesn't change the object if the applied fields are the same,
+	// fields set by other controllers are kept
+	if err := k8s.Apply(ctx, r.client, obj); err != nil {
+		return err
+	}
 
-		switch object := obj.(type) {
-		case *corev1.Service:
-			object.Spec.ClusterIP = oldObject.(*corev1.Service).Spec.ClusterIP
-			if object.Spec.Type == corev1.ServiceTypeLoadBalancer {
-				object.Spec.HealthCheckNodePort = oldObject.(*corev1.Service).Spec.HealthCheckNodePort
-			}
-		case *policyv1.PodDisruptionBudget:
-			obj.SetResourceVersion(oldObject.GetResourceVersion())
+	if obj.GetResourceVersion() != oldObject.GetResourceVersion() {
+		log.V(1).Info("Updated object", "object", obj.GetName(), "kind", obj.GetObjectKind())
+		if _, ok := obj.(*appsv1.StatefulSet); ok {
+			r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventStatefulSetUpdated, "StatefulSet %s updated", obj.GetName())
 		}
-
-		log.V(1).Info("Updating object", "object", obj.GetName(), "kind", obj.GetObjectKind())
-
-		return r.client.Update(ctx, obj)
 	}
 
 	return nil
@@ -1153,6 +1417,17 @@ func (r *ReconcilePerconaXtraDBCluster) createOrUpdateService(ctx context.Contex
 	if err != nil {
 		return errors.Wrap(err, "set controller reference")
 	}
+	// clients of the cluster are switched to the cluster it was upgraded to
+	if target := cr.Annotations[api.AnnotationCutoverTo]; target != "" && svc.Spec.Selector[naming.LabelAppKubernetesInstance] == cr.Name {
+		// the selector can share the map with labels of the service
+		selector := make(map[string]string, len(svc.Spec.Selector))
+		for k, v := range svc.Spec.Selector {
+			selector[k] = v
+		}
+		selector[naming.LabelAppKubernetesInstance] = target
+		svc.Spec.Selector = selector
+	}
+	setExternalDNSAnnotations(ctx, cr, svc)
 	if !saveOldMeta && len(cr.Spec.IgnoreAnnotations) == 0 && len(cr.Spec.IgnoreLabels) == 0 {
 		return r.createOrUpdate(ctx, cr, svc)
 	}
@@ -1171,51 +1446,14 @@ func (r *ReconcilePerconaXtraDBCluster) createOrUpdateService(ctx context.Contex
 	if saveOldMeta {
 		svc.SetAnnotations(mergeMaps(svc.GetAnnotations(), oldSvc.GetAnnotations()))
 		svc.SetLabels(mergeMaps(svc.GetLabels(), oldSvc.GetLabels()))
+		// the merged annotations can keep the hostname of the disabled external-dns
+		setExternalDNSAnnotations(ctx, cr, svc)
 	}
 	setIgnoredAnnotationsAndLabels(cr, svc, oldSvc)
 
 	return r.createOrUpdate(ctx, cr, svc)
 }
 
-func getObjectHash(obj runtime.Object) (string, error) {
-	var dataToMarshall interface{}
-	switch object := obj.(type) {
-	case *appsv1.StatefulSet:
-		dataToMarshall = object.Spec
-	case *appsv1.Deployment:
-		dataToMarshall = object.Spec
-	case *corev1.Service:
-		dataToMarshall = object.Spec
-	default:
-		dataToMarshall = obj
-	}
-	data, err := json.Marshal(dataToMarshall)
-	if err != nil {
-		return "", err
-	}
-	return base64.StdEncoding.EncodeToString(data), nil
-}
-
-func isObjectMetaEqual(old, new metav1.Object) bool {
-	return compareMaps(old.GetAnnotations(), new.GetAnnotations()) &&
-		compareMaps(old.GetLabels(), new.GetLabels())
-}
-
-func compareMaps(x, y map[string]string) bool {
-	if len(x) != len(y) {
-		return false
-	}
-
-	for k, v := range x {
-		yVal, ok := y[k]
-		if !ok || yVal != v {
-			return false
-		}
-	}
-
-	return true
-}
-
 func (r *ReconcilePerconaXtraDBCluster) getConfigVolume(nsName, cvName, cmName string, useDefaultVolume bool) (corev1.Volume, error) {
 	n := types.NamespacedName{
 		Namespace: nsName,
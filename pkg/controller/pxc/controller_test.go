@@ -1702,3 +1702,72 @@ var _ = Describe("Liveness/Readiness Probes", Ordered, func() {
 		}),
 	)
 })
+
+var _ = Describe("Suspended reconciliation", Ordered, func() {
+	ctx := context.Background()
+
+	const crName = "suspend"
+	const ns = "suspend"
+	crNamespacedName := types.NamespacedName{Name: crName, Namespace: ns}
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ns,
+			Namespace: ns,
+		},
+	}
+
+	BeforeAll(func() {
+		By("Creating the Namespace to perform the tests")
+		err := k8sClient.Create(ctx, namespace)
+		Expect(err).To(Not(HaveOccurred()))
+	})
+
+	AfterAll(func() {
+		By("Deleting the Namespace to perform the tests")
+		_ = k8sClient.Delete(ctx, namespace)
+	})
+
+	cr, err := readDefaultCR(crName, ns)
+	It("should read default cr.yaml", func() {
+		Expect(err).NotTo(HaveOccurred())
+	})
+	cr.Spec.Suspend = true
+
+	It("Should create PerconaXtraDBCluster", func() {
+		Expect(k8sClient.Create(ctx, cr)).Should(Succeed())
+	})
+
+	It("should not create objects while suspended", func() {
+		_, err := reconciler().Reconcile(ctx, ctrl.Request{NamespacedName: crNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = k8sClient.Get(ctx, types.NamespacedName{Name: cr.Name + "-pxc", Namespace: ns}, new(appsv1.StatefulSet))
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should report the Suspended condition", func() {
+		c := new(api.PerconaXtraDBCluster)
+		Expect(k8sClient.Get(ctx, crNamespacedName, c)).Should(Succeed())
+		Expect(c.Status.Conditions).To(ContainElement(gs.MatchFields(gs.IgnoreExtras, gs.Fields{
+			"Type":   Equal(api.AppState(api.ConditionSuspended)),
+			"Status": Equal(api.ConditionTrue),
+		})))
+	})
+
+	It("should reconcile the cluster once resumed", func() {
+		c := new(api.PerconaXtraDBCluster)
+		Expect(k8sClient.Get(ctx, crNamespacedName, c)).Should(Succeed())
+		c.Spec.Suspend = false
+		Expect(k8sClient.Update(ctx, c)).Should(Succeed())
+
+		_, err := reconciler().Reconcile(ctx, ctrl.Request{NamespacedName: crNamespacedName})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: cr.Name + "-pxc", Namespace: ns}, new(appsv1.StatefulSet))).Should(Succeed())
+		Expect(k8sClient.Get(ctx, crNamespacedName, c)).Should(Succeed())
+		for _, cond := range c.Status.Conditions {
+			Expect(cond.Type).NotTo(Equal(api.AppState(api.ConditionSuspended)))
+		}
+	})
+})
@@ -0,0 +1,513 @@
+package pxc
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+)
+
+const (
+	diagnosticReasonCrashLoop  = "CrashLoop"
+	diagnosticReasonQuorumLost = "QuorumLost"
+
+	// diagnosticsPrefix is the prefix of the bundles in the backup storage, followed by the cluster name.
+	diagnosticsPrefix = "diagnostics"
+	// diagnosticsMountPath is the path the diagnostics PVC is mounted to in the job saving the bundles.
+	diagnosticsMountPath = "/diagnostics"
+	// maxDiagnosticEvents limits the events added to the bundle.
+	maxDiagnosticEvents = 200
+	// maxDiagnosticSecretSize is the size of the bundles a secret can pass to the PVC, secrets are limited to 1MiB.
+	maxDiagnosticSecretSize = 1000 << 10
+)
+
+// diagnosticManifest describes the bundle in manifest.json of the archive.
+type diagnosticManifest struct {
+	Name        string    `json:"name"`
+	Cluster     string    `json:"cluster"`
+	Reason      string    `json:"reason"`
+	Pod         string    `json:"pod,omitempty"`
+	CollectedAt time.Time `json:"collectedAt"`
+	// Errors are the parts of the bundle which couldn't be collected.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// reconcileDiagnostics collects a diagnostic bundle when a PXC pod crash-loops or the cluster loses quorum.
+// Bundles are collected not more often than the interval of the spec, the failed ones included,
+// so a long outage produces a single bundle per interval.
+func (r *ReconcilePerconaXtraDBCluster) reconcileDiagnostics(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	d := cr.Spec.PXC.Diagnostics
+	if !d.IsEnabled() {
+		cr.Status.Diagnostics = nil
+		cr.Status.RemoveCondition(api.ConditionDiagnosticBundle)
+		return nil
+	}
+	if cr.Spec.Pause || cr.DeletionTimestamp != nil {
+		return nil
+	}
+
+	log := logf.FromContext(ctx)
+
+	now := time.Now().Truncate(time.Second)
+	if last := cr.Status.Diagnostics.LastBundle(); last != nil && last.CollectedAt != nil &&
+		now.Sub(last.CollectedAt.Time) < time.Duration(d.IntervalSeconds)*time.Second {
+		return nil
+	}
+
+	pods := new(corev1.PodList)
+	err := r.client.List(ctx, pods, &client.ListOptions{
+		Namespace:     cr.Namespace,
+		LabelSelector: labels.SelectorFromSet(naming.LabelsPXC(cr)),
+	})
+	if err != nil {
+		return errors.Wrap(err, "list pods")
+	}
+
+	history := r.wsrepHistory(cr.Namespace + "/" + cr.Name)
+	reason, podName := diagnosticTrigger(cr, pods.Items, history)
+	if reason == "" {
+		return nil
+	}
+
+	bundle := api.DiagnosticBundle{
+		Name:        cr.Name + "-diag-" + now.UTC().Format("20060102150405"),
+		Reason:      reason,
+		Pod:         podName,
+		CollectedAt: &metav1.Time{Time: now},
+	}
+	log.Info("Collecting diagnostic bundle", "bundle", bundle.Name, "reason", reason, "pod", podName)
+
+	files := r.diagnosticFiles(ctx, cr, pods.Items, history, bundle)
+	data, err := archiveFiles(files)
+	if err == nil {
+		bundle.Location, err = r.saveDiagnosticBundle(ctx, cr, bundle, data)
+	}
+
+	condition := api.ClusterCondition{
+		Type:               api.ConditionDiagnosticBundle,
+		Status:             api.ConditionTrue,
+		Reason:             reason,
+		Message:            fmt.Sprintf("diagnostic bundle %s is saved to %s", bundle.Name, bundle.Location),
+		LastTransitionTime: metav1.NewTime(now),
+	}
+	if err != nil {
+		bundle.Error = err.Error()
+		condition.Status = api.ConditionFalse
+		condition.Message = fmt.Sprintf("diagnostic bundle %s isn't saved: %s", bundle.Name, bundle.Error)
+		log.Error(err, "failed to save diagnostic bundle", "bundle", bundle.Name)
+		r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventDiagnosticBundleFailed, "Diagnostic bundle %s isn't saved: %s", bundle.Name, bundle.Error)
+	} else {
+		log.Info("Diagnostic bundle saved", "bundle", bundle.Name, "location", bundle.Location)
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventDiagnosticBundleCollected, "Diagnostic bundle %s is saved to %s", bundle.Name, bundle.Location)
+	}
+
+	if cr.Status.Diagnostics == nil {
+		cr.Status.Diagnostics = new(api.DiagnosticsStatus)
+	}
+	bundles := append(cr.Status.Diagnostics.Bundles, bundle)
+	if len(bundles) > int(d.Keep) {
+		bundles = bundles[len(bundles)-int(d.Keep):]
+	}
+	cr.Status.Diagnostics.Bundles = bundles
+	cr.Status.SetCondition(condition)
+
+	return nil
+}
+
+// diagnosticTrigger returns the reason to collect a diagnostic bundle and the crash-looping pod,
+// an empty reason if the cluster is healthy. The quorum is considered lost if a pod reports
+// a non-primary component or the majority of the pods of a cluster which was ready isn't ready.
+func diagnosticTrigger(cr *api.PerconaXtraDBCluster, pods []corev1.Pod, history []wsrepSample) (string, string) {
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+	for _, pod := range pods {
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.Name == app.Name && cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff" {
+				return diagnosticReasonCrashLoop, pod.Name
+			}
+		}
+	}
+
+	if len(history) > 0 {
+		for _, status := range history[len(history)-1].Pods {
+			if s := status["wsrep_cluster_status"]; s != "" && s != "Primary" {
+				return diagnosticReasonQuorumLost, ""
+			}
+		}
+	}
+
+	size := cr.Spec.PXC.Size
+	if size < 3 || cr.Status.FindCondition(api.AppStateReady) == nil || int32(len(pods)) < size {
+		return "", ""
+	}
+	ready := int32(0)
+	for _, pod := range pods {
+		if isPodReady(pod) {
+			ready++
+		}
+	}
+	if ready*2 <= size {
+		return diagnosticReasonQuorumLost, ""
+	}
+
+	return "", ""
+}
+
+// diagnosticFiles returns the files of the bundle by their paths in the archive.
+// The parts which can't be collected are listed in the manifest.
+func (r *ReconcilePerconaXtraDBCluster) diagnosticFiles(ctx context.Context, cr *api.PerconaXtraDBCluster, pods []corev1.Pod, history []wsrepSample, bundle api.DiagnosticBundle) map[string][]byte {
+	manifest := diagnosticManifest{
+		Name:        bundle.Name,
+		Cluster:     cr.Name,
+		Reason:      bundle.Reason,
+		Pod:         bundle.Pod,
+		CollectedAt: bundle.CollectedAt.Time,
+	}
+	files := make(map[string][]byte)
+
+	tail := cr.Spec.PXC.Diagnostics.LogLines
+	for _, pod := range pods {
+		opts := []*corev1.PodLogOptions{{Container: app.Name, TailLines: &tail}}
+		if pod.Name == bundle.Pod {
+			opts = append(opts, &corev1.PodLogOptions{Container: app.Name, TailLines: &tail, Previous: true})
+		}
+		for _, o := range opts {
+			name := "logs/" + pod.Name + ".log"
+			if o.Previous {
+				name = "logs/" + pod.Name + ".previous.log"
+			}
+			lines, err := r.clientcmd.PodLogs(cr.Namespace, pod.Name, o)
+			if err != nil {
+				manifest.Errors = append(manifest.Errors, name+": "+err.Error())
+				continue
+			}
+			files[name] = []byte(strings.Join(lines, "\n"))
+		}
+	}
+
+	statuses := make(map[string]corev1.PodStatus, len(pods))
+	for _, pod := range pods {
+		statuses[pod.Name] = pod.Status
+	}
+	files["pods.json"], _ = json.MarshalIndent(statuses, "", "  ")
+	files["wsrep-history.json"], _ = json.MarshalIndent(history, "", "  ")
+
+	events, err := r.clusterEvents(ctx, cr)
+	if err != nil {
+		manifest.Errors = append(manifest.Errors, "events.txt: "+err.Error())
+	} else {
+		files["events.txt"] = []byte(events)
+	}
+
+	files["manifest.json"], _ = json.MarshalIndent(manifest, "", "  ")
+
+	return files
+}
+
+// clusterEvents returns the recent events of the cluster and its objects, one per line, the oldest first.
+func (r *ReconcilePerconaXtraDBCluster) clusterEvents(ctx context.Context, cr *api.PerconaXtraDBCluster) (string, error) {
+	list := new(corev1.EventList)
+	if err := r.client.List(ctx, list, client.InNamespace(cr.Namespace)); err != nil {
+		return "", errors.Wrap(err, "list events")
+	}
+
+	var events []corev1.Event
+	for _, e := range list.Items {
+		if e.InvolvedObject.Name == cr.Name || strings.HasPrefix(e.InvolvedObject.Name, cr.Name+"-") {
+			events = append(events, e)
+		}
+	}
+	eventTime := func(e corev1.Event) time.Time {
+		if !e.LastTimestamp.IsZero() {
+			return e.LastTimestamp.Time
+		}
+		return e.EventTime.Time
+	}
+	sort.SliceStable(events, func(i, j int) bool { return eventTime(events[i]).Before(eventTime(events[j])) })
+	if len(events) > maxDiagnosticEvents {
+		events = events[len(events)-maxDiagnosticEvents:]
+	}
+
+	var b strings.Builder
+	for _, e := range events {
+		fmt.Fprintf(&b, "%s\t%s\t%s\t%s/%s\tx%d\t%s\n", eventTime(e).UTC().Format(time.RFC3339), e.Type, e.Reason,
+			e.InvolvedObject.Kind, e.InvolvedObject.Name, e.Count, e.Message)
+	}
+	return b.String(), nil
+}
+
+// archiveFiles returns the tar.gz archive of the files.
+func archiveFiles(files map[string][]byte) ([]byte, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := new(bytes.Buffer)
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	for _, name := range names {
+		hdr := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(files[name])), ModTime: now}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, errors.Wrapf(err, "write header of %s", name)
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return nil, errors.Wrapf(err, "write %s", name)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "close tar")
+	}
+	if err := gz.Close(); err != nil {
+		return nil, errors.Wrap(err, "close gzip")
+	}
+	return buf.Bytes(), nil
+}
+
+// saveDiagnosticBundle saves the archive to the storage or the PVC of the diagnostics and returns its location.
+func (r *ReconcilePerconaXtraDBCluster) saveDiagnosticBundle(ctx context.Context, cr *api.PerconaXtraDBCluster, bundle api.DiagnosticBundle, data []byte) (string, error) {
+	if cr.Spec.PXC.Diagnostics.StorageName != "" {
+		return r.uploadDiagnosticBundle(ctx, cr, bundle, data)
+	}
+	return r.writeDiagnosticBundleToPVC(ctx, cr, bundle, data)
+}
+
+// uploadDiagnosticBundle uploads the archive and the core dump of the crashed pod to the backup storage
+// and deletes the bundles exceeding the number of the kept ones.
+func (r *ReconcilePerconaXtraDBCluster) uploadDiagnosticBundle(ctx context.Context, cr *api.PerconaXtraDBCluster, bundle api.DiagnosticBundle, data []byte) (string, error) {
+	d := cr.Spec.PXC.Diagnostics
+
+	opts, err := storage.GetOptions(ctx, r.client, cr, d.StorageName)
+	if err != nil {
+		return "", errors.Wrap(err, "get storage options")
+	}
+	newClient := r.newStorageClient
+	if newClient == nil {
+		newClient = storage.NewClient
+	}
+	stg, err := newClient(ctx, opts)
+	if err != nil {
+		return "", errors.Wrap(err, "new storage client")
+	}
+
+	prefix := path.Join(diagnosticsPrefix, cr.Name)
+	object := path.Join(prefix, bundle.Name+".tar.gz")
+	if err := stg.PutObject(ctx, object, bytes.NewReader(data), int64(len(data))); err != nil {
+		return "", errors.Wrap(err, "upload bundle")
+	}
+
+	if d.CoreDumps && bundle.Pod != "" {
+		core := path.Join(prefix, bundle.Name+"-"+bundle.Pod+".core.gz")
+		if err := r.uploadCoreDump(ctx, stg, cr, bundle.Pod, core); err != nil {
+			// the bundle is useful without the core dump
+			logf.FromContext(ctx).Info("Core dump isn't uploaded", "pod", bundle.Pod, "error", err.Error())
+		}
+	}
+
+	objects, err := stg.ListObjects(ctx, prefix+"/")
+	if err != nil {
+		return "", errors.Wrap(err, "list bundles")
+	}
+	for _, o := range expiredDiagnosticObjects(objects, int(d.Keep)) {
+		if err := stg.DeleteObject(ctx, path.Join(prefix, o)); err != nil {
+			return "", errors.Wrapf(err, "delete expired bundle object %s", o)
+		}
+	}
+
+	stgSpec := cr.Spec.Backup.Storages[d.StorageName]
+	if stgSpec.Type == api.BackupStorageAzure {
+		return api.AzureBlobStoragePrefix + path.Join(stgSpec.Azure.ContainerPath, object), nil
+	}
+	return api.AwsBlobStoragePrefix + path.Join(stgSpec.S3.Bucket, object), nil
+}
+
+// expiredDiagnosticObjects returns the objects of the bundles older than the kept ones.
+// The objects are relative to the prefix of the cluster and start with the bundle name,
+// which sorts in the order the bundles were collected.
+func expiredDiagnosticObjects(objects []string, keep int) []string {
+	bundleOf := func(o string) string {
+		o = path.Base(o)
+		if i := strings.Index(o, "-diag-"); i >= 0 && len(o) >= i+len("-diag-")+14 {
+			return o[:i+len("-diag-")+14]
+		}
+		return ""
+	}
+
+	var bundles []string
+	for _, o := range objects {
+		if b := bundleOf(o); b != "" && !slices.Contains(bundles, b) {
+			bundles = append(bundles, b)
+		}
+	}
+	sort.Strings(bundles)
+	if len(bundles) <= keep {
+		return nil
+	}
+	expired := bundles[:len(bundles)-keep]
+
+	var out []string
+	for _, o := range objects {
+		if b := bundleOf(o); b != "" && slices.Contains(expired, b) {
+			out = append(out, path.Base(o))
+		}
+	}
+	return out
+}
+
+// uploadCoreDump streams the latest core dump in the datadir of the pod to the object.
+// The pxc container restarts if it crash-loops, so the dump can be taken only while it's running.
+func (r *ReconcilePerconaXtraDBCluster) uploadCoreDump(ctx context.Context, stg storage.Storage, cr *api.PerconaXtraDBCluster, podName, object string) error {
+	pod := new(corev1.Pod)
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: cr.Namespace, Name: podName}, pod); err != nil {
+		return errors.Wrap(err, "get pod")
+	}
+
+	cmd := []string{"bash", "-c", `f=$(ls -1t /var/lib/mysql/core.* 2>/dev/null | head -n1); [ -n "$f" ] || exit 2; gzip -c "$f"`}
+
+	pr, pw := io.Pipe()
+	stderr := new(bytes.Buffer)
+	go func() {
+		pw.CloseWithError(r.clientcmd.Exec(pod, app.Name, cmd, nil, pw, stderr, false))
+	}()
+
+	if err := stg.PutObject(ctx, object, pr, -1); err != nil {
+		pr.CloseWithError(err)
+		return errors.Wrapf(err, "upload core dump: %s", stderr.String())
+	}
+	return nil
+}
+
+// writeDiagnosticBundleToPVC passes the archive to a job with the diagnostics PVC mounted in a secret.
+// The job copies the archive to the PVC and deletes the bundles exceeding the number of the kept ones,
+// the secret is deleted together with the job.
+func (r *ReconcilePerconaXtraDBCluster) writeDiagnosticBundleToPVC(ctx context.Context, cr *api.PerconaXtraDBCluster, bundle api.DiagnosticBundle, data []byte) (string, error) {
+	if len(data) > maxDiagnosticSecretSize {
+		return "", errors.Errorf("bundle size %d exceeds %d bytes, reduce logLines or use storageName", len(data), maxDiagnosticSecretSize)
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.DiagnosticsPVCName(cr),
+			Namespace: cr.Namespace,
+			Labels:    naming.LabelsDiagnostics(cr),
+		},
+		Spec: *cr.Spec.PXC.Diagnostics.PersistentVolumeClaim.DeepCopy(),
+	}
+	if err := k8s.SetControllerReference(cr, pvc, r.scheme); err != nil {
+		return "", errors.Wrap(err, "set PVC controller reference")
+	}
+	if err := r.client.Create(ctx, pvc); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return "", errors.Wrap(err, "create PVC")
+	}
+
+	job := diagnosticsJob(cr, bundle.Name)
+	if err := k8s.SetControllerReference(cr, job, r.scheme); err != nil {
+		return "", errors.Wrap(err, "set job controller reference")
+	}
+	if err := r.client.Create(ctx, job); err != nil {
+		return "", errors.Wrap(err, "create job")
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bundle.Name,
+			Namespace: cr.Namespace,
+			Labels:    naming.LabelsDiagnostics(cr),
+		},
+		Data: map[string][]byte{"bundle.tar.gz": data},
+	}
+	if err := k8s.SetControllerReference(job, secret, r.scheme); err != nil {
+		return "", errors.Wrap(err, "set secret controller reference")
+	}
+	if err := r.client.Create(ctx, secret); err != nil {
+		return "", errors.Wrap(err, "create secret")
+	}
+
+	return api.PVCStoragePrefix + path.Join(pvc.Name, bundle.Name+".tar.gz"), nil
+}
+
+func diagnosticsJob(cr *api.PerconaXtraDBCluster, name string) *batchv1.Job {
+	ls := naming.LabelsDiagnostics(cr)
+	keep := strconv.Itoa(int(cr.Spec.PXC.Diagnostics.Keep) + 1)
+	archive := path.Join(diagnosticsMountPath, name+".tar.gz")
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    ls,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            ptr.To(int32(3)),
+			TTLSecondsAfterFinished: ptr.To(int32(3600)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: ls},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					SecurityContext:  cr.Spec.PXC.PodSecurityContext,
+					ImagePullSecrets: cr.Spec.PXC.ImagePullSecrets,
+					Containers: []corev1.Container{
+						{
+							Name:            "diagnostics",
+							Image:           cr.Spec.PXC.Image,
+							ImagePullPolicy: cr.Spec.PXC.ImagePullPolicy,
+							SecurityContext: cr.Spec.PXC.ContainerSecurityContext,
+							Command:         []string{"bash", "-c"},
+							Args: []string{
+								`cp /bundle/bundle.tar.gz ` + archive + `.tmp && mv ` + archive + `.tmp ` + archive +
+									` && ls -1 ` + diagnosticsMountPath + `/*.tar.gz | sort -r | tail -n +` + keep + ` | xargs -r rm -f`,
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "bundle", MountPath: "/bundle", ReadOnly: true},
+								{Name: "diagnostics", MountPath: diagnosticsMountPath},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: "bundle",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{SecretName: name},
+							},
+						},
+						{
+							Name: "diagnostics",
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: naming.DiagnosticsPVCName(cr)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	cr.ApplyImageOverrides(&job.Spec.Template.Spec)
+
+	return job
+}
@@ -0,0 +1,208 @@
+package pxc
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"slices"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestDiagnosticTrigger(t *testing.T) {
+	pod := func(name string, ready bool, waiting string) corev1.Pod {
+		p := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		p.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}
+		cs := corev1.ContainerStatus{Name: "pxc"}
+		if waiting != "" {
+			cs.State.Waiting = &corev1.ContainerStateWaiting{Reason: waiting}
+		}
+		p.Status.ContainerStatuses = []corev1.ContainerStatus{cs}
+		return p
+	}
+	sample := func(status string) []wsrepSample {
+		return []wsrepSample{{Pods: map[string]map[string]string{"cr-mock-pxc-0": {"wsrep_cluster_status": status}}}}
+	}
+
+	tests := []struct {
+		name      string
+		wasReady  bool
+		pods      []corev1.Pod
+		history   []wsrepSample
+		reason    string
+		crashLoop string
+	}{
+		{
+			name:     "healthy",
+			wasReady: true,
+			pods:     []corev1.Pod{pod("cr-mock-pxc-0", true, ""), pod("cr-mock-pxc-1", true, ""), pod("cr-mock-pxc-2", false, "")},
+			history:  sample("Primary"),
+		},
+		{
+			name:      "crash loop",
+			wasReady:  true,
+			pods:      []corev1.Pod{pod("cr-mock-pxc-0", true, ""), pod("cr-mock-pxc-1", true, ""), pod("cr-mock-pxc-2", false, "CrashLoopBackOff")},
+			reason:    diagnosticReasonCrashLoop,
+			crashLoop: "cr-mock-pxc-2",
+		},
+		{
+			name:     "non-primary component",
+			wasReady: true,
+			pods:     []corev1.Pod{pod("cr-mock-pxc-0", true, ""), pod("cr-mock-pxc-1", true, ""), pod("cr-mock-pxc-2", true, "")},
+			history:  sample("non-Primary"),
+			reason:   diagnosticReasonQuorumLost,
+		},
+		{
+			name:     "majority isn't ready",
+			wasReady: true,
+			pods:     []corev1.Pod{pod("cr-mock-pxc-0", true, ""), pod("cr-mock-pxc-1", false, ""), pod("cr-mock-pxc-2", false, "")},
+			reason:   diagnosticReasonQuorumLost,
+		},
+		{
+			name: "cluster is initializing",
+			pods: []corev1.Pod{pod("cr-mock-pxc-0", true, ""), pod("cr-mock-pxc-1", false, ""), pod("cr-mock-pxc-2", false, "")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := newCR("cr-mock", "pxc")
+			cr.Spec.PXC.Size = 3
+			if tt.wasReady {
+				cr.Status.AddCondition(api.ClusterCondition{Type: api.AppStateReady, Status: api.ConditionTrue})
+			}
+
+			reason, pod := diagnosticTrigger(cr, tt.pods, tt.history)
+			if reason != tt.reason || pod != tt.crashLoop {
+				t.Errorf("expected %q of pod %q, got %q of pod %q", tt.reason, tt.crashLoop, reason, pod)
+			}
+		})
+	}
+}
+
+func TestExpiredDiagnosticObjects(t *testing.T) {
+	objects := []string{
+		"diagnostics/cr-mock/cr-mock-diag-20240103000000.tar.gz",
+		"diagnostics/cr-mock/cr-mock-diag-20240101000000.tar.gz",
+		"diagnostics/cr-mock/cr-mock-diag-20240101000000-cr-mock-pxc-1.core.gz",
+		"diagnostics/cr-mock/cr-mock-diag-20240102000000.tar.gz",
+	}
+
+	expired := expiredDiagnosticObjects(objects, 2)
+	expected := []string{"cr-mock-diag-20240101000000.tar.gz", "cr-mock-diag-20240101000000-cr-mock-pxc-1.core.gz"}
+	if !slices.Equal(expired, expected) {
+		t.Errorf("expected %v, got %v", expected, expired)
+	}
+
+	if expired := expiredDiagnosticObjects(objects, 3); len(expired) > 0 {
+		t.Errorf("expected no expired objects, got %v", expired)
+	}
+}
+
+func TestArchiveFiles(t *testing.T) {
+	files := map[string][]byte{
+		"manifest.json":          []byte(`{"name":"cr-mock-diag-20240101000000"}`),
+		"logs/cr-mock-pxc-0.log": []byte("line 1\nline 2"),
+	}
+
+	data, err := archiveFiles(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	got := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[hdr.Name] = string(b)
+	}
+
+	for name, content := range files {
+		if got[name] != string(content) {
+			t.Errorf("file %s: expected %q, got %q", name, content, got[name])
+		}
+	}
+}
+
+func TestWriteDiagnosticBundleToPVC(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.PXC.Diagnostics = &api.DiagnosticsSpec{
+		Enabled: true,
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			},
+		},
+		Keep: 5,
+	}
+
+	r := buildFakeClient([]runtime.Object{cr})
+
+	bundle := api.DiagnosticBundle{Name: "cr-mock-diag-20240101000000"}
+	location, err := r.writeDiagnosticBundleToPVC(ctx, cr, bundle, []byte("archive"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if location != "pvc/cr-mock-diagnostics/cr-mock-diag-20240101000000.tar.gz" {
+		t.Errorf("unexpected location %s", location)
+	}
+
+	key := func(name string) types.NamespacedName {
+		return types.NamespacedName{Name: name, Namespace: cr.Namespace}
+	}
+	if err := r.client.Get(ctx, key("cr-mock-diagnostics"), new(corev1.PersistentVolumeClaim)); err != nil {
+		t.Errorf("get PVC: %v", err)
+	}
+	job := new(batchv1.Job)
+	if err := r.client.Get(ctx, key(bundle.Name), job); err != nil {
+		t.Fatalf("get job: %v", err)
+	}
+	if claim := job.Spec.Template.Spec.Volumes[1].PersistentVolumeClaim; claim == nil || claim.ClaimName != "cr-mock-diagnostics" {
+		t.Errorf("expected the diagnostics PVC to be mounted, got %v", job.Spec.Template.Spec.Volumes)
+	}
+	secret := new(corev1.Secret)
+	if err := r.client.Get(ctx, key(bundle.Name), secret); err != nil {
+		t.Fatalf("get secret: %v", err)
+	}
+	if string(secret.Data["bundle.tar.gz"]) != "archive" {
+		t.Errorf("unexpected secret data %q", secret.Data["bundle.tar.gz"])
+	}
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].Kind != "Job" {
+		t.Errorf("expected the secret to be owned by the job, got %v", secret.OwnerReferences)
+	}
+
+	_, err = r.writeDiagnosticBundleToPVC(ctx, cr, api.DiagnosticBundle{Name: "cr-mock-diag-20240102000000"}, make([]byte, maxDiagnosticSecretSize+1))
+	if err == nil {
+		t.Error("expected the bundle exceeding the secret size to fail")
+	}
+}
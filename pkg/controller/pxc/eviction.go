@@ -0,0 +1,133 @@
+package pxc
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+const (
+	evictionRoleDonor            = "donor"
+	evictionRoleOnlySyncedMember = "only-synced-member"
+	evictionRolePrimary          = "primary"
+)
+
+const (
+	wsrepStateDonor  = "2"
+	wsrepStateSynced = "4"
+)
+
+// reconcileEvictionProtection annotates the PXC pods whose eviction would break the cluster quorum
+// or interrupt a state transfer, so cluster-autoscaler and descheduler skip them.
+// statuses are the wsrep status of the pods by pod name, the annotations of the ready pods
+// without status are left as they are.
+func (r *ReconcilePerconaXtraDBCluster) reconcileEvictionProtection(ctx context.Context, cr *api.PerconaXtraDBCluster, pods []corev1.Pod, statuses map[string]map[string]string) error {
+	log := logf.FromContext(ctx)
+
+	enabled := cr.Spec.PXC.EvictionProtection.IsEnabled()
+
+	roles := make(map[string]string)
+	if enabled {
+		primary := ""
+		if cr.HAProxyEnabled() || cr.ProxySQLEnabled() {
+			host, err := r.getPrimaryPod(cr)
+			if err != nil {
+				log.V(1).Info("Failed to get primary pod for eviction protection", "error", err.Error())
+			}
+			primary = host
+		}
+		roles = evictionProtectedRoles(pods, statuses, primary)
+	}
+
+	for i := range pods {
+		pod := &pods[i]
+		if _, ok := statuses[pod.Name]; enabled && !ok && isPodReady(*pod) {
+			continue
+		}
+
+		if err := r.setEvictionProtection(ctx, cr, pod, roles[pod.Name]); err != nil {
+			return errors.Wrapf(err, "set eviction protection of pod %s", pod.Name)
+		}
+	}
+
+	return nil
+}
+
+// evictionProtectedRoles returns the comma separated roles of the pods that must not be evicted by pod name.
+func evictionProtectedRoles(pods []corev1.Pod, statuses map[string]map[string]string, primary string) map[string]string {
+	roles := make(map[string][]string)
+
+	var synced []string
+	for name, status := range statuses {
+		switch status["wsrep_local_state"] {
+		case wsrepStateDonor:
+			roles[name] = append(roles[name], evictionRoleDonor)
+		case wsrepStateSynced:
+			synced = append(synced, name)
+		}
+	}
+	if len(synced) == 1 {
+		roles[synced[0]] = append(roles[synced[0]], evictionRoleOnlySyncedMember)
+	}
+
+	if primary != "" {
+		for i := range pods {
+			if isPrimaryPod(&pods[i], primary) {
+				roles[pods[i].Name] = append(roles[pods[i].Name], evictionRolePrimary)
+				break
+			}
+		}
+	}
+
+	joined := make(map[string]string, len(roles))
+	for name, rs := range roles {
+		sort.Strings(rs)
+		joined[name] = strings.Join(rs, ",")
+	}
+
+	return joined
+}
+
+// setEvictionProtection patches the eviction annotations of the pod if its roles changed.
+// If the pod isn't protected anymore, the annotations set in the spec are restored.
+func (r *ReconcilePerconaXtraDBCluster) setEvictionProtection(ctx context.Context, cr *api.PerconaXtraDBCluster, pod *corev1.Pod, roles string) error {
+	if pod.Annotations[naming.AnnotationEvictionProtection] == roles {
+		return nil
+	}
+
+	orig := pod.DeepCopy()
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+
+	if roles != "" {
+		pod.Annotations[naming.AnnotationEvictionProtection] = roles
+		pod.Annotations[naming.AnnotationSafeToEvict] = "false"
+		pod.Annotations[naming.AnnotationDeschedulerPreventEviction] = "true"
+	} else {
+		delete(pod.Annotations, naming.AnnotationEvictionProtection)
+		for _, key := range []string{naming.AnnotationSafeToEvict, naming.AnnotationDeschedulerPreventEviction} {
+			if v, ok := cr.Spec.PXC.Annotations[key]; ok {
+				pod.Annotations[key] = v
+				continue
+			}
+			delete(pod.Annotations, key)
+		}
+	}
+
+	if err := r.client.Patch(ctx, pod, client.MergeFrom(orig)); err != nil {
+		return err
+	}
+
+	logf.FromContext(ctx).Info("Eviction protection of pod changed", "pod", pod.Name, "roles", roles)
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package pxc
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+func TestEvictionProtectedRoles(t *testing.T) {
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "cr-mock-pxc-0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cr-mock-pxc-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "cr-mock-pxc-2"}},
+	}
+
+	tests := []struct {
+		name     string
+		statuses map[string]map[string]string
+		primary  string
+		expected map[string]string
+	}{
+		{
+			name: "all synced",
+			statuses: map[string]map[string]string{
+				"cr-mock-pxc-0": {"wsrep_local_state": "4"},
+				"cr-mock-pxc-1": {"wsrep_local_state": "4"},
+				"cr-mock-pxc-2": {"wsrep_local_state": "4"},
+			},
+			primary:  "cr-mock-pxc-0.cr-mock-pxc.pxc",
+			expected: map[string]string{"cr-mock-pxc-0": "primary"},
+		},
+		{
+			name: "state transfer",
+			statuses: map[string]map[string]string{
+				"cr-mock-pxc-0": {"wsrep_local_state": "4"},
+				"cr-mock-pxc-1": {"wsrep_local_state": "2"},
+				"cr-mock-pxc-2": {"wsrep_local_state": "1"},
+			},
+			primary: "cr-mock-pxc-0",
+			expected: map[string]string{
+				"cr-mock-pxc-0": "only-synced-member,primary",
+				"cr-mock-pxc-1": "donor",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles := evictionProtectedRoles(pods, tt.statuses, tt.primary)
+			if len(roles) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, roles)
+			}
+			for name, r := range tt.expected {
+				if roles[name] != r {
+					t.Errorf("pod %s: expected roles %q, got %q", name, r, roles[name])
+				}
+			}
+		})
+	}
+}
+
+func TestSetEvictionProtection(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.PXC.Annotations = map[string]string{naming.AnnotationSafeToEvict: "true"}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cr-mock-pxc-0",
+			Namespace:   cr.Namespace,
+			Annotations: map[string]string{naming.AnnotationSafeToEvict: "true"},
+		},
+	}
+
+	r := buildFakeClient([]runtime.Object{cr, pod})
+
+	get := func() *corev1.Pod {
+		p := new(corev1.Pod)
+		if err := r.client.Get(ctx, types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, p); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	if err := r.setEvictionProtection(ctx, cr, get(), "donor"); err != nil {
+		t.Fatal(err)
+	}
+	p := get()
+	if p.Annotations[naming.AnnotationSafeToEvict] != "false" || p.Annotations[naming.AnnotationDeschedulerPreventEviction] != "true" {
+		t.Errorf("expected the pod to be protected, got %v", p.Annotations)
+	}
+	if p.Annotations[naming.AnnotationEvictionProtection] != "donor" {
+		t.Errorf("unexpected roles %q", p.Annotations[naming.AnnotationEvictionProtection])
+	}
+
+	if err := r.setEvictionProtection(ctx, cr, p, ""); err != nil {
+		t.Fatal(err)
+	}
+	p = get()
+	if p.Annotations[naming.AnnotationSafeToEvict] != "true" {
+		t.Errorf("expected the annotation of the spec to be restored, got %q", p.Annotations[naming.AnnotationSafeToEvict])
+	}
+	if _, ok := p.Annotations[naming.AnnotationDeschedulerPreventEviction]; ok {
+		t.Error("expected the descheduler annotation to be removed")
+	}
+	if _, ok := p.Annotations[naming.AnnotationEvictionProtection]; ok {
+		t.Error("expected the roles annotation to be removed")
+	}
+}
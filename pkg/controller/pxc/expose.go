@@ -0,0 +1,101 @@
+package pxc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+const (
+	annotationExternalDNSHostname = "external-dns.alpha.kubernetes.io/hostname"
+	annotationExternalDNSTTL      = "external-dns.alpha.kubernetes.io/ttl"
+)
+
+// exposedService is a service of the cluster clients connect to.
+type exposedService struct {
+	name   string
+	role   string
+	expose *api.ServiceExpose
+}
+
+// exposedServices returns the enabled exposed services of the cluster by their names.
+func exposedServices(cr *api.PerconaXtraDBCluster) map[string]exposedService {
+	svcs := make(map[string]exposedService)
+	add := func(name, role string, expose *api.ServiceExpose) {
+		svcs[name] = exposedService{name: name, role: role, expose: expose}
+	}
+
+	if cr.Spec.PXC.Expose.Enabled {
+		for i := 0; i < int(cr.Spec.PXC.Size); i++ {
+			name := fmt.Sprintf("%s-pxc-%d", cr.Name, i)
+			add(name, name, &cr.Spec.PXC.Expose)
+		}
+	}
+	if er := cr.Spec.PXC.ExternalReplication; er.IsEnabled() {
+		add(naming.ReplicationSourceServiceName(cr), "replication-source", &er.Expose)
+	}
+	if rp := cr.Spec.PXC.Reporting; rp.IsEnabled() {
+		add(naming.ReportingServiceName(cr), "reporting", &rp.Expose)
+	}
+	if cr.HAProxyEnabled() {
+		add(cr.HaproxyServiceNamespacedName().Name, "writer", &cr.Spec.HAProxy.ExposePrimary)
+		if cr.Spec.HAProxy.ExposeReplicas != nil && cr.HAProxyReplicasServiceEnabled() {
+			add(cr.HAProxyReplicasNamespacedName().Name, "reader", &cr.Spec.HAProxy.ExposeReplicas.ServiceExpose)
+		}
+	}
+	if cr.ProxySQLEnabled() {
+		add(cr.ProxySQLServiceNamespacedName().Name, "writer", &cr.Spec.ProxySQL.Expose)
+	}
+
+	return svcs
+}
+
+// setExternalDNSAnnotations sets the external-dns annotations of the exposed service,
+// or removes them if external-dns is disabled for the service. The annotations
+// set in the annotations of the expose section aren't changed.
+// The annotations map of the service can be shared with the spec, so it's copied.
+func setExternalDNSAnnotations(ctx context.Context, cr *api.PerconaXtraDBCluster, svc *corev1.Service) {
+	exposed, ok := exposedServices(cr)[svc.Name]
+	if !ok || exposed.expose.ExternalDNS == nil {
+		return
+	}
+	spec := exposed.expose.ExternalDNS
+
+	annotations := make(map[string]string, len(svc.Annotations)+2)
+	for k, v := range svc.Annotations {
+		annotations[k] = v
+	}
+	for _, k := range []string{annotationExternalDNSHostname, annotationExternalDNSTTL} {
+		if _, ok := exposed.expose.Annotations[k]; ok {
+			return
+		}
+		delete(annotations, k)
+	}
+
+	if spec.IsEnabled() {
+		hostname, err := spec.RenderHostname(api.ExternalDNSTemplateData{
+			Cluster:     cr.Name,
+			Namespace:   cr.Namespace,
+			Service:     svc.Name,
+			Role:        exposed.role,
+			ClusterRole: cr.ClusterRole(),
+		})
+		if err != nil {
+			// the template is validated, only the execution with the actual data can fail
+			logf.FromContext(ctx).Error(err, "failed to render external-dns hostname", "service", svc.Name)
+		} else if hostname != "" {
+			annotations[annotationExternalDNSHostname] = hostname
+			if spec.TTL > 0 {
+				annotations[annotationExternalDNSTTL] = strconv.FormatInt(spec.TTL, 10)
+			}
+		}
+	}
+
+	svc.Annotations = annotations
+}
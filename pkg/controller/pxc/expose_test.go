@@ -0,0 +1,61 @@
+package pxc
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestSetExternalDNSAnnotations(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.CRVersion = "1.17.0"
+	cr.Spec.HAProxy.ExposePrimary.Annotations = map[string]string{"foo": "bar"}
+	cr.Spec.HAProxy.ExposePrimary.ExternalDNS = &api.ExternalDNSSpec{
+		Enabled:  true,
+		Hostname: `{{ if eq .ClusterRole "primary" }}writer{{ else }}{{ .Cluster }}-{{ .Role }}{{ end }}.db.example.com`,
+		TTL:      60,
+	}
+
+	newSvc := func() *corev1.Service {
+		return &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        cr.Name + "-haproxy",
+				Annotations: cr.Spec.HAProxy.ExposePrimary.Annotations,
+			},
+		}
+	}
+
+	svc := newSvc()
+	setExternalDNSAnnotations(ctx, cr, svc)
+	if h := svc.Annotations[annotationExternalDNSHostname]; h != "writer.db.example.com" {
+		t.Errorf("unexpected hostname %q", h)
+	}
+	if ttl := svc.Annotations[annotationExternalDNSTTL]; ttl != "60" {
+		t.Errorf("unexpected ttl %q", ttl)
+	}
+	if svc.Annotations["foo"] != "bar" {
+		t.Error("expected the annotations of the spec to be kept")
+	}
+	if _, ok := cr.Spec.HAProxy.ExposePrimary.Annotations[annotationExternalDNSHostname]; ok {
+		t.Error("the annotations of the spec are modified")
+	}
+
+	cr.Spec.PXC.ReplicationChannels = []api.ReplicationChannel{{Name: "ch1", IsSource: false}}
+	svc = newSvc()
+	setExternalDNSAnnotations(ctx, cr, svc)
+	if h := svc.Annotations[annotationExternalDNSHostname]; h != "cr-mock-writer.db.example.com" {
+		t.Errorf("unexpected hostname of the replica cluster %q", h)
+	}
+
+	cr.Spec.HAProxy.ExposePrimary.ExternalDNS.Enabled = false
+	setExternalDNSAnnotations(ctx, cr, svc)
+	if _, ok := svc.Annotations[annotationExternalDNSHostname]; ok {
+		t.Error("expected the hostname to be removed")
+	}
+}
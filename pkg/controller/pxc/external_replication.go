@@ -0,0 +1,128 @@
+package pxc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+// externalReplicationGrants are the privileges CDC tools need to take
+// the initial snapshot and read the binary logs.
+var externalReplicationGrants = []string{"SELECT", "RELOAD", "SHOW DATABASES", "REPLICATION SLAVE", "REPLICATION CLIENT"}
+
+// reconcileExternalReplication exposes the cluster to replication consumers
+// that aren't managed by the operator and keeps the binary logs they need.
+// The replication user is managed with the custom users, see externalReplicationUser.
+func (r *ReconcilePerconaXtraDBCluster) reconcileExternalReplication(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	spec := cr.Spec.PXC.ExternalReplication
+	if !spec.IsEnabled() {
+		return r.deleteReplicationSourceService(ctx, cr)
+	}
+
+	svc := pxc.NewServiceReplicationSource(cr)
+	if err := r.createOrUpdateService(ctx, cr, svc, len(spec.Expose.Annotations) == 0); err != nil {
+		return errors.Wrap(err, "create or update replication source service")
+	}
+
+	// the binlog retention policy keeps the binary logs for the consumers itself
+	if spec.BinlogRetentionSeconds == 0 || cr.Spec.BinlogRetention.IsEnabled() || cr.Spec.Pause || cr.Status.PXC.Ready < 1 {
+		return nil
+	}
+
+	return errors.Wrap(r.ensureBinlogRetention(ctx, cr, spec.BinlogRetentionSeconds), "ensure binlog retention")
+}
+
+func (r *ReconcilePerconaXtraDBCluster) deleteReplicationSourceService(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	svc := new(corev1.Service)
+	err := r.client.Get(ctx, types.NamespacedName{Name: naming.ReplicationSourceServiceName(cr), Namespace: cr.Namespace}, svc)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "get replication source service")
+	}
+
+	if !metav1.IsControlledBy(svc, cr) {
+		return nil
+	}
+
+	return errors.Wrap(client.IgnoreNotFound(r.client.Delete(ctx, svc)), "delete replication source service")
+}
+
+// ensureBinlogRetention makes sure binary logs are kept at least for the given time on every ready node.
+// The retention isn't decreased if the nodes are configured to keep binary logs longer.
+// The variable isn't persisted, it's set again by the following reconciles if a node is restarted.
+func (r *ReconcilePerconaXtraDBCluster) ensureBinlogRetention(ctx context.Context, cr *api.PerconaXtraDBCluster, seconds int64) error {
+	log := logf.FromContext(ctx)
+
+	pods := corev1.PodList{}
+	err := r.client.List(ctx, &pods, &client.ListOptions{
+		Namespace:     cr.Namespace,
+		LabelSelector: labels.SelectorFromSet(statefulset.NewNode(cr).Labels()),
+	})
+	if err != nil {
+		return errors.Wrap(err, "get pod list")
+	}
+
+	for _, pod := range pods.Items {
+		if !isPodReady(pod) {
+			continue
+		}
+
+		db, err := queries.New(r.client, cr.Namespace, users.InternalSecretsPrefix+cr.Name, users.Operator, pod.Name+"."+cr.Name+"-pxc."+cr.Namespace, 33062, cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
+		if err != nil {
+			return errors.Wrapf(err, "connect to pod %s", pod.Name)
+		}
+
+		err = func() error {
+			defer db.Close()
+
+			current, err := db.BinlogExpireSeconds()
+			if err != nil {
+				return err
+			}
+			if current == 0 || current >= seconds {
+				return nil
+			}
+
+			log.Info("Increasing binlog retention for external replication", "pod", pod.Name, "current", current, "retention", seconds)
+			return db.SetBinlogExpireSeconds(seconds)
+		}()
+		if err != nil {
+			return errors.Wrapf(err, "pod %s", pod.Name)
+		}
+	}
+
+	return nil
+}
+
+// externalReplicationUser returns the user replication consumers connect with
+// or nil if external replication is disabled. Like other custom users, it isn't
+// dropped once external replication is disabled.
+func externalReplicationUser(cr *api.PerconaXtraDBCluster) *api.User {
+	spec := cr.Spec.PXC.ExternalReplication
+	if !spec.IsEnabled() {
+		return nil
+	}
+
+	return &api.User{
+		Name:              spec.User,
+		PasswordSecretRef: spec.PasswordSecretRef.DeepCopy(),
+		Hosts:             spec.Hosts,
+		Grants:            externalReplicationGrants,
+	}
+}
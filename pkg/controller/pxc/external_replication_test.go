@@ -0,0 +1,65 @@
+package pxc
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc"
+)
+
+func TestExternalReplication(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.PXC.ExternalReplication = &api.ExternalReplicationSpec{
+		Enabled: true,
+		User:    "cdc",
+		Hosts:   []string{"10.0.0.%"},
+		Expose:  api.ServiceExpose{Type: corev1.ServiceTypeLoadBalancer},
+	}
+
+	user := externalReplicationUser(cr)
+	if user == nil || user.Name != "cdc" || !slices.Equal(user.Hosts, []string{"10.0.0.%"}) {
+		t.Fatalf("unexpected external replication user: %+v", user)
+	}
+	if !slices.Contains(user.Grants, "REPLICATION SLAVE") {
+		t.Errorf("replication grant is missing: %v", user.Grants)
+	}
+
+	svc := pxc.NewServiceReplicationSource(cr)
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer || svc.Spec.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyTypeCluster {
+		t.Errorf("unexpected service spec: %+v", svc.Spec)
+	}
+	if svc.Spec.SessionAffinity != corev1.ServiceAffinityClientIP {
+		t.Errorf("consumers have to reconnect to the same node, got session affinity %s", svc.Spec.SessionAffinity)
+	}
+
+	r := buildFakeClient([]runtime.Object{cr})
+	if err := k8s.SetControllerReference(cr, svc, r.scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.client.Create(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+
+	cr.Spec.PXC.ExternalReplication.Enabled = false
+	if externalReplicationUser(cr) != nil {
+		t.Errorf("user is returned for disabled external replication")
+	}
+	if err := r.reconcileExternalReplication(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(svc), new(corev1.Service))
+	if !k8serrors.IsNotFound(err) {
+		t.Fatalf("expected the service to be deleted, got %v", err)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	v1 "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/audit"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -42,7 +44,7 @@ func (r *ReconcilePerconaXtraDBCluster) recoverFullClusterCrashIfNeeded(ctx cont
 	}
 
 	if isWaiting {
-		return r.doFullCrashRecovery(ctx, cr.Name, cr.Namespace, int(cr.Spec.PXC.Size))
+		return r.doFullCrashRecovery(ctx, cr)
 	}
 
 	return nil
@@ -82,13 +84,13 @@ func parseSequence(log string) (int64, error) {
 	return seq, nil
 }
 
-func (r *ReconcilePerconaXtraDBCluster) doFullCrashRecovery(ctx context.Context, crName, namespace string, pxcSize int) error {
+func (r *ReconcilePerconaXtraDBCluster) doFullCrashRecovery(ctx context.Context, cr *v1.PerconaXtraDBCluster) error {
 	maxSeq := int64(-100)
 	maxSeqPod := ""
 
-	for i := 0; i < pxcSize; i++ {
-		podName := fmt.Sprintf("%s-pxc-%d", crName, i)
-		isPodWaitingForRecovery, seq, err := r.isPodWaitingForRecovery(namespace, podName)
+	for i := 0; i < int(cr.Spec.PXC.Size); i++ {
+		podName := fmt.Sprintf("%s-pxc-%d", cr.Name, i)
+		isPodWaitingForRecovery, seq, err := r.isPodWaitingForRecovery(cr.Namespace, podName)
 		if err != nil {
 			return errors.Wrapf(err, "parse %s pod logs", podName)
 		}
@@ -106,9 +108,12 @@ func (r *ReconcilePerconaXtraDBCluster) doFullCrashRecovery(ctx context.Context,
 	log.Info("We are in full cluster crash, starting recovery")
 	log.Info("Results of scanning sequences", "pod", maxSeqPod, "maxSeq", maxSeq)
 
+	r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventFullClusterCrashRecovery,
+		"Full cluster crash detected, bootstrapping the cluster from pod %s with the highest sequence number %d", maxSeqPod, maxSeq)
+
 	pod := &corev1.Pod{}
 	err := r.client.Get(context.TODO(), types.NamespacedName{
-		Namespace: namespace,
+		Namespace: cr.Namespace,
 		Name:      maxSeqPod,
 	}, pod)
 	if err != nil {
@@ -125,6 +130,13 @@ func (r *ReconcilePerconaXtraDBCluster) doFullCrashRecovery(ctx context.Context,
 		return errors.New("invalid exec command return: " + stderrBuf.String())
 	}
 
+	audit.RecordOperation(ctx, r.client, cr.Namespace, cr.Name, audit.OperationEntry{
+		Operation: audit.OperationCrashRecovery,
+		Result:    audit.OperationStarted,
+		Object:    maxSeqPod,
+		Message:   fmt.Sprintf("bootstrapping the cluster from pod %s with the highest sequence number %d", maxSeqPod, maxSeq),
+	})
+
 	// sleep there a little to start script and do not send
 	// a lot of signals to the same pod
 	time.Sleep(30 * time.Second)
@@ -0,0 +1,171 @@
+package pxc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/config"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
+)
+
+// galeraSegmentsTopologyKey is the key of the galera segments config map with the segments
+// assigned to the values of the topology label, one "value=segment" per line.
+// The pod names always have an ordinal suffix, so the key doesn't clash with them.
+const galeraSegmentsTopologyKey = "topology"
+
+// reconcileGaleraSegments writes the gmcast.segment of each PXC pod to the galera segments config map,
+// which is mounted to the pods and read by pxc-configure-pxc.sh when mysqld starts.
+// The pods which aren't scheduled yet or whose node can't be read are left out until a later reconcile,
+// the pods with an override get their segment before they are created.
+func (r *ReconcilePerconaXtraDBCluster) reconcileGaleraSegments(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	cmName := config.GaleraSegmentsConfigMapName(cr.Name)
+
+	spec := cr.Spec.PXC.GaleraSegments
+	if !spec.IsEnabled() {
+		return errors.Wrap(deleteConfigMapIfExists(r.client, cr, cmName), "delete galera segments config map")
+	}
+
+	log := logf.FromContext(ctx)
+
+	current := new(corev1.ConfigMap)
+	err := r.client.Get(ctx, types.NamespacedName{Name: cmName, Namespace: cr.Namespace}, current)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "get galera segments config map")
+	}
+	topology := parseTopologySegments(current.Data[galeraSegmentsTopologyKey])
+	for value := range spec.Segments {
+		delete(topology, value)
+	}
+
+	stsName := statefulset.NewNode(cr).StatefulSet().Name
+	data := make(map[string]string)
+	for i := int32(0); i < cr.Spec.PXC.Size; i++ {
+		if o := spec.Override(i); o != nil {
+			data[fmt.Sprintf("%s-%d", stsName, i)] = strconv.Itoa(int(o.Segment))
+		}
+	}
+
+	pods := new(corev1.PodList)
+	if err := r.client.List(ctx, pods, client.InNamespace(cr.Namespace), client.MatchingLabels(naming.SelectorPXC(cr))); err != nil {
+		return errors.Wrap(err, "list pods")
+	}
+	slices.SortFunc(pods.Items, func(a, b corev1.Pod) int { return strings.Compare(a.Name, b.Name) })
+
+	for _, pod := range pods.Items {
+		ordinal, err := strconv.ParseInt(strings.TrimPrefix(pod.Name, stsName+"-"), 10, 32)
+		if err != nil || spec.Override(int32(ordinal)) != nil || pod.Spec.NodeName == "" {
+			continue
+		}
+
+		node := new(corev1.Node)
+		if err := r.client.Get(ctx, types.NamespacedName{Name: pod.Spec.NodeName}, node); err != nil {
+			if k8serrors.IsForbidden(err) {
+				log.V(1).Info("Galera segments aren't assigned by topology, nodes can't be read", "error", err.Error())
+				break
+			}
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return errors.Wrapf(err, "get node %s", pod.Spec.NodeName)
+		}
+
+		value, ok := node.Labels[spec.TopologyKey]
+		if !ok {
+			log.V(1).Info("Node of the pod doesn't have the topology label, galera segment isn't assigned",
+				"pod", pod.Name, "node", node.Name, "label", spec.TopologyKey)
+			continue
+		}
+
+		segment, assigned := assignGaleraSegment(spec, topology, value)
+		if assigned {
+			log.Info("Galera segment is assigned", "label", spec.TopologyKey, "value", value, "segment", segment)
+		}
+		data[pod.Name] = strconv.Itoa(int(segment))
+	}
+
+	if len(topology) > 0 {
+		data[galeraSegmentsTopologyKey] = formatTopologySegments(topology)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cmName,
+			Namespace: cr.Namespace,
+			Labels:    naming.LabelsCluster(cr),
+		},
+		Data: data,
+	}
+	if err := k8s.SetControllerReference(cr, configMap, r.scheme); err != nil {
+		return errors.Wrap(err, "set controller ref galera segments config")
+	}
+
+	return errors.Wrap(createOrUpdateConfigmap(r.client, configMap), "galera segments config map")
+}
+
+// assignGaleraSegment returns the segment of the topology label value: the segment of the spec,
+// the segment assigned before or the lowest segment used by neither. It returns true if the
+// segment is assigned now, it's added to topology then.
+func assignGaleraSegment(spec *api.GaleraSegmentsSpec, topology map[string]int32, value string) (int32, bool) {
+	if segment, ok := spec.Segments[value]; ok {
+		return segment, false
+	}
+	if segment, ok := topology[value]; ok {
+		return segment, false
+	}
+
+	used := make(map[int32]struct{}, len(spec.Segments)+len(topology))
+	for _, segment := range spec.Segments {
+		used[segment] = struct{}{}
+	}
+	for _, segment := range topology {
+		used[segment] = struct{}{}
+	}
+	for segment := int32(0); segment <= api.MaxGaleraSegment; segment++ {
+		if _, ok := used[segment]; !ok {
+			topology[value] = segment
+			return segment, true
+		}
+	}
+
+	// all segments are used, the pod stays in the default one
+	return 0, false
+}
+
+func parseTopologySegments(data string) map[string]int32 {
+	topology := make(map[string]int32)
+	for _, line := range strings.Split(data, "\n") {
+		value, segment, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		s, err := strconv.ParseInt(segment, 10, 32)
+		if err != nil || s < 0 || s > api.MaxGaleraSegment {
+			continue
+		}
+		topology[value] = int32(s)
+	}
+	return topology
+}
+
+func formatTopologySegments(topology map[string]int32) string {
+	lines := make([]string, 0, len(topology))
+	for value, segment := range topology {
+		lines = append(lines, fmt.Sprintf("%s=%d", value, segment))
+	}
+	slices.Sort(lines)
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,78 @@
+package pxc
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+func TestReconcileGaleraSegments(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.PXC.Size = 4
+	cr.Spec.PXC.GaleraSegments = &api.GaleraSegmentsSpec{
+		Enabled:     true,
+		TopologyKey: corev1.LabelTopologyZone,
+		Segments:    map[string]int32{"zone-b": 0},
+		Overrides:   []api.PodSegmentOverride{{Ordinal: 3, Segment: 7}},
+	}
+
+	node := func(name, zone string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{corev1.LabelTopologyZone: zone}}}
+	}
+	pod := func(name, nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cr.Namespace, Labels: naming.SelectorPXC(cr)},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+	existing := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "cr-mock-pxc-segments", Namespace: cr.Namespace},
+		Data:       map[string]string{galeraSegmentsTopologyKey: "zone-c=1\n"},
+	}
+
+	r := buildFakeClient([]runtime.Object{
+		cr, existing,
+		node("node-a", "zone-a"), node("node-b", "zone-b"), node("node-c", "zone-c"),
+		pod("cr-mock-pxc-0", "node-a"), pod("cr-mock-pxc-1", "node-b"), pod("cr-mock-pxc-2", "node-c"),
+		pod("cr-mock-pxc-3", "node-a"), pod("cr-mock-pxc-4", ""),
+	})
+
+	if err := r.reconcileGaleraSegments(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+
+	cm := new(corev1.ConfigMap)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: "cr-mock-pxc-segments", Namespace: cr.Namespace}, cm); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"cr-mock-pxc-0":           "2",
+		"cr-mock-pxc-1":           "0",
+		"cr-mock-pxc-2":           "1",
+		"cr-mock-pxc-3":           "7",
+		galeraSegmentsTopologyKey: "zone-a=2\nzone-c=1\n",
+	}
+	if !maps.Equal(cm.Data, expected) {
+		t.Errorf("expected %v, got %v", expected, cm.Data)
+	}
+
+	cr.Spec.PXC.GaleraSegments.Enabled = false
+	if err := r.reconcileGaleraSegments(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	err := r.client.Get(ctx, types.NamespacedName{Name: "cr-mock-pxc-segments", Namespace: cr.Namespace}, new(corev1.ConfigMap))
+	if !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the config map to be deleted, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package pxc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
+)
+
+// reconcileGatewayRoutes manages the Gateway API routes of the exposed services.
+// Routes are skipped if the Gateway API CRDs aren't installed.
+func (r *ReconcilePerconaXtraDBCluster) reconcileGatewayRoutes(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	desired := gatewayRoutes(cr)
+
+	for _, route := range desired {
+		if err := r.applyOptionalObject(ctx, cr, route); err != nil {
+			return errors.Wrapf(err, "apply %s %s", route.GetKind(), route.GetName())
+		}
+	}
+
+	for _, kind := range []api.GatewayRouteKind{api.GatewayRouteTCP, api.GatewayRouteTLS} {
+		gvk := app.GatewayRouteGVK(kind)
+
+		list := new(unstructured.UnstructuredList)
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		err := r.client.List(ctx, list, &client.ListOptions{
+			Namespace:     cr.Namespace,
+			LabelSelector: labels.SelectorFromSet(naming.LabelsGatewayRoute(cr)),
+		})
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "get %s list", gvk.Kind)
+		}
+
+		for i := range list.Items {
+			route := &list.Items[i]
+			if !metav1.IsControlledBy(route, cr) {
+				continue
+			}
+			if d, ok := desired[route.GetName()]; ok && d.GetKind() == gvk.Kind {
+				continue
+			}
+			if err := r.deleteOptionalObject(ctx, gvk, route.GetNamespace(), route.GetName()); err != nil {
+				return errors.Wrapf(err, "delete %s %s", gvk.Kind, route.GetName())
+			}
+		}
+	}
+
+	return nil
+}
+
+// gatewayRoutes returns the routes of the exposed services of the cluster by their names.
+func gatewayRoutes(cr *api.PerconaXtraDBCluster) map[string]*unstructured.Unstructured {
+	routes := make(map[string]*unstructured.Unstructured)
+	for name, svc := range exposedServices(cr) {
+		if !svc.expose.Gateway.IsEnabled() {
+			continue
+		}
+		routes[name] = app.GatewayRoute(cr, name, 3306, svc.expose.Gateway)
+	}
+	return routes
+}
@@ -0,0 +1,67 @@
+package pxc
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestGatewayRoutes(t *testing.T) {
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.CRVersion = "1.17.0"
+	cr.Spec.HAProxy.ExposePrimary.Gateway = &api.GatewayRouteSpec{
+		Enabled:    true,
+		Kind:       api.GatewayRouteTCP,
+		ParentRefs: []api.GatewayParentReference{{Name: "db-gateway", Namespace: "infra", SectionName: "mysql"}},
+	}
+	cr.Spec.HAProxy.ExposeReplicas.Gateway = &api.GatewayRouteSpec{
+		Enabled:    true,
+		Kind:       api.GatewayRouteTLS,
+		ParentRefs: []api.GatewayParentReference{{Name: "db-gateway"}},
+		Hostnames:  []string{"replicas.db.example.com"},
+	}
+	cr.Spec.HAProxy.ExposeReplicas.Enabled = true
+
+	routes := gatewayRoutes(cr)
+	if len(routes) != 2 {
+		t.Fatalf("expected 2 routes, got %d", len(routes))
+	}
+
+	primary, ok := routes["cr-mock-haproxy"]
+	if !ok {
+		t.Fatal("expected a route of the primary service")
+	}
+	if primary.GetKind() != "TCPRoute" {
+		t.Errorf("expected TCPRoute, got %s", primary.GetKind())
+	}
+	refs, _, _ := unstructured.NestedSlice(primary.Object, "spec", "parentRefs")
+	expectedRefs := []interface{}{
+		map[string]interface{}{"name": "db-gateway", "namespace": "infra", "sectionName": "mysql"},
+	}
+	if !reflect.DeepEqual(refs, expectedRefs) {
+		t.Errorf("expected parentRefs %v, got %v", expectedRefs, refs)
+	}
+	if _, ok, _ := unstructured.NestedSlice(primary.Object, "spec", "hostnames"); ok {
+		t.Error("expected no hostnames for TCPRoute")
+	}
+
+	replicas, ok := routes["cr-mock-haproxy-replicas"]
+	if !ok {
+		t.Fatal("expected a route of the replicas service")
+	}
+	if replicas.GetKind() != "TLSRoute" {
+		t.Errorf("expected TLSRoute, got %s", replicas.GetKind())
+	}
+	hostnames, _, _ := unstructured.NestedStringSlice(replicas.Object, "spec", "hostnames")
+	if !reflect.DeepEqual(hostnames, []string{"replicas.db.example.com"}) {
+		t.Errorf("unexpected hostnames %v", hostnames)
+	}
+
+	cr.Spec.HAProxy.Enabled = false
+	if routes := gatewayRoutes(cr); len(routes) != 0 {
+		t.Errorf("expected no routes with disabled HAProxy, got %d", len(routes))
+	}
+}
@@ -0,0 +1,136 @@
+package pxc
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+const wsrepMetricsInterval = 30 * time.Second
+
+// wsrepHistoryLength is the number of the wsrep status samples kept per cluster for the diagnostic bundles.
+const wsrepHistoryLength = 60
+
+// wsrepHistoryKeys are the wsrep status variables kept in the history.
+var wsrepHistoryKeys = []string{
+	"wsrep_cluster_status",
+	"wsrep_cluster_size",
+	"wsrep_cluster_conf_id",
+	"wsrep_connected",
+	"wsrep_ready",
+	"wsrep_local_state_comment",
+	"wsrep_last_committed",
+	"wsrep_local_recv_queue",
+	"wsrep_local_send_queue",
+	"wsrep_flow_control_paused",
+	"wsrep_evs_state",
+}
+
+// wsrepSample is the wsrep status of the ready PXC pods collected at the time.
+type wsrepSample struct {
+	Time time.Time                    `json:"time"`
+	Pods map[string]map[string]string `json:"pods"`
+}
+
+// collectWsrepMetrics exports wsrep status of PXC pods as operator metrics,
+// so basic Galera health can be monitored without PMM.
+// Reconcile runs every few seconds, so pods are queried not more often than wsrepMetricsInterval.
+// The collected status is also used to protect the critical pods from eviction.
+func (r *ReconcilePerconaXtraDBCluster) collectWsrepMetrics(ctx context.Context, cr *api.PerconaXtraDBCluster) {
+	if cr.Spec.Pause || cr.Status.PXC.Ready < 1 || cr.CompareVersionWith("1.6.0") < 0 {
+		return
+	}
+
+	key := cr.Namespace + "/" + cr.Name
+	if last, ok := r.wsrepCollectedAt.Load(key); ok && time.Since(last.(time.Time)) < wsrepMetricsInterval {
+		return
+	}
+	r.wsrepCollectedAt.Store(key, time.Now())
+
+	log := logf.FromContext(ctx)
+
+	pods := corev1.PodList{}
+	err := r.client.List(ctx, &pods, &client.ListOptions{
+		Namespace:     cr.Namespace,
+		LabelSelector: labels.SelectorFromSet(naming.LabelsPXC(cr)),
+	})
+	if err != nil {
+		log.Error(err, "failed to list pods for wsrep metrics")
+		return
+	}
+
+	statuses := make(map[string]map[string]string, len(pods.Items))
+	for _, pod := range pods.Items {
+		if !isPodReady(pod) {
+			metrics.DeleteWsrepStatus(cr.Namespace, cr.Name, pod.Name)
+			continue
+		}
+
+		status, err := r.wsrepStatus(ctx, cr, pod.Name)
+		if err != nil {
+			log.V(1).Info("Failed to get wsrep status", "pod", pod.Name, "error", err.Error())
+			metrics.DeleteWsrepStatus(cr.Namespace, cr.Name, pod.Name)
+			continue
+		}
+
+		metrics.SetWsrepStatus(cr.Namespace, cr.Name, pod.Name, status)
+		statuses[pod.Name] = status
+	}
+
+	r.recordWsrepHistory(key, statuses)
+
+	if err := r.reconcileEvictionProtection(ctx, cr, pods.Items, statuses); err != nil {
+		log.Error(err, "failed to reconcile eviction protection")
+	}
+}
+
+// recordWsrepHistory appends the wsrep status of the pods to the history of the cluster.
+func (r *ReconcilePerconaXtraDBCluster) recordWsrepHistory(key string, statuses map[string]map[string]string) {
+	sample := wsrepSample{Time: time.Now().Truncate(time.Second), Pods: make(map[string]map[string]string, len(statuses))}
+	for pod, status := range statuses {
+		s := make(map[string]string, len(wsrepHistoryKeys))
+		for _, k := range wsrepHistoryKeys {
+			if v, ok := status[k]; ok {
+				s[k] = v
+			}
+		}
+		sample.Pods[pod] = s
+	}
+
+	history := r.wsrepHistory(key)
+	if len(history) >= wsrepHistoryLength {
+		history = history[len(history)-wsrepHistoryLength+1:]
+	}
+	r.wsrepSamples.Store(key, append(history, sample))
+}
+
+// wsrepHistory returns a copy of the wsrep status history of the cluster, the oldest sample first.
+func (r *ReconcilePerconaXtraDBCluster) wsrepHistory(key string) []wsrepSample {
+	v, ok := r.wsrepSamples.Load(key)
+	if !ok {
+		return nil
+	}
+	return slices.Clone(v.([]wsrepSample))
+}
+
+func (r *ReconcilePerconaXtraDBCluster) wsrepStatus(ctx context.Context, cr *api.PerconaXtraDBCluster, podName string) (map[string]string, error) {
+	host := podName + "." + cr.Name + "-pxc." + cr.Namespace
+	db, err := queries.New(r.client, cr.Namespace, users.InternalSecretsPrefix+cr.Name, users.Monitor, host, 33062, cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	return db.WsrepStatus(ctx)
+}
@@ -0,0 +1,35 @@
+package pxc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/config"
+)
+
+// getLoggingConfigHash returns the hash of managed logs options and the log shipping
+// pipeline, so PXC pods are restarted when any of them changes.
+func (r *ReconcilePerconaXtraDBCluster) getLoggingConfigHash(cr *api.PerconaXtraDBCluster) (string, error) {
+	if !config.LoggingConfigEnabled(cr) {
+		return "", nil
+	}
+
+	data := make(map[string]string)
+	for _, name := range []string{config.LoggingConfigMapName(cr.Name), config.CustomConfigMapName(cr.Name, "logcollector")} {
+		cm := new(corev1.ConfigMap)
+		err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: cr.Namespace, Name: name}, cm)
+		if client.IgnoreNotFound(err) != nil {
+			return "", errors.Wrapf(err, "get config map %s", name)
+		}
+		for k, v := range cm.Data {
+			data[name+"/"+k] = v
+		}
+	}
+
+	return getCustomConfigHashHex(data, nil)
+}
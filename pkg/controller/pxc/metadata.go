@@ -0,0 +1,205 @@
+package pxc
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
+)
+
+// managedMetadata are the keys of the labels and annotations patched by the operator,
+// stored in the naming.AnnotationManagedMetadata annotation of the object.
+type managedMetadata struct {
+	Labels      []string `json:"labels,omitempty"`
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// reconcileMetadata patches the per-pod labels and annotations to the pods of the cluster and copies
+// the propagated labels of the cluster to its pods, statefulsets, services and PVCs.
+// The objects are patched instead of their templates, so the changes don't recreate the statefulsets
+// or restart the pods.
+func (r *ReconcilePerconaXtraDBCluster) reconcileMetadata(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	propagated := make(map[string]string, len(cr.Spec.PropagateLabels))
+	for _, key := range cr.Spec.PropagateLabels {
+		if v, ok := cr.Labels[key]; ok {
+			propagated[key] = v
+		}
+	}
+
+	type component struct {
+		sts  *appsv1.StatefulSet
+		spec *api.PodSpec
+	}
+	components := []component{{statefulset.NewNode(cr).StatefulSet(), cr.Spec.PXC.PodSpec}}
+	if cr.HAProxyEnabled() {
+		components = append(components, component{statefulset.NewHAProxy(cr).StatefulSet(), &cr.Spec.HAProxy.PodSpec})
+	}
+	if cr.ProxySQLEnabled() {
+		components = append(components, component{statefulset.NewProxy(cr).StatefulSet(), &cr.Spec.ProxySQL.PodSpec})
+	}
+
+	for _, c := range components {
+		if err := r.reconcilePodMetadata(ctx, c.sts, c.spec, propagated); err != nil {
+			return errors.Wrapf(err, "reconcile metadata of %s pods", c.sts.Name)
+		}
+	}
+
+	clusterLabels := naming.LabelsCluster(cr)
+	selector := client.MatchingLabels{
+		naming.LabelAppKubernetesName:     clusterLabels[naming.LabelAppKubernetesName],
+		naming.LabelAppKubernetesInstance: cr.Name,
+	}
+	for _, list := range []client.ObjectList{
+		new(appsv1.StatefulSetList),
+		new(corev1.ServiceList),
+		new(corev1.PersistentVolumeClaimList),
+	} {
+		if err := r.client.List(ctx, list, client.InNamespace(cr.Namespace), selector); err != nil {
+			return errors.Wrapf(err, "list %T", list)
+		}
+		err := meta.EachListItem(list, func(o runtime.Object) error {
+			obj := o.(client.Object)
+			// the labels of the objects are applied by the operator, taking them over would make
+			// the next apply revert them
+			return r.patchManagedMetadata(ctx, obj, propagated, nil, metav1.ObjectMeta{}, false)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcilePodMetadata patches the pods of the statefulset with the metadata of their ordinals
+// and the propagated labels of the cluster.
+func (r *ReconcilePerconaXtraDBCluster) reconcilePodMetadata(ctx context.Context, desired *appsv1.StatefulSet, spec *api.PodSpec, propagated map[string]string) error {
+	sts := new(appsv1.StatefulSet)
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), sts); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "get statefulset")
+	}
+	selector, err := metav1.LabelSelectorAsSelector(sts.Spec.Selector)
+	if err != nil {
+		return errors.Wrap(err, "parse statefulset selector")
+	}
+
+	pods := new(corev1.PodList)
+	if err := r.client.List(ctx, pods, client.InNamespace(sts.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return errors.Wrap(err, "list pods")
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		ordinal, err := strconv.ParseInt(strings.TrimPrefix(pod.Name, sts.Name+"-"), 10, 32)
+		if err != nil {
+			continue
+		}
+
+		labels := maps.Clone(propagated)
+		var annotations map[string]string
+		if m := spec.PodMetadataFor(int32(ordinal)); m != nil {
+			maps.Copy(labels, m.Labels)
+			annotations = m.Annotations
+		}
+
+		if err := r.patchManagedMetadata(ctx, pod, labels, annotations, sts.Spec.Template.ObjectMeta, true); err != nil {
+			return errors.Wrapf(err, "patch pod %s", pod.Name)
+		}
+	}
+
+	return nil
+}
+
+// patchManagedMetadata patches the labels and annotations of the object if they differ from the given ones.
+func (r *ReconcilePerconaXtraDBCluster) patchManagedMetadata(ctx context.Context, obj client.Object, labels, annotations map[string]string, template metav1.ObjectMeta, takeOver bool) error {
+	orig := obj.DeepCopyObject().(client.Object)
+	if !setManagedMetadata(obj, labels, annotations, template, takeOver) {
+		return nil
+	}
+
+	if err := r.client.Patch(ctx, obj, client.MergeFrom(orig)); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	logf.FromContext(ctx).V(1).Info("Metadata of object changed", "object", obj.GetName(), "kind", obj.GetObjectKind().GroupVersionKind().Kind)
+
+	return nil
+}
+
+// setManagedMetadata sets the labels and annotations to the object and removes the ones
+// set before which aren't given anymore, restoring the values of the template.
+// The keys the object already has with other values are only overwritten with takeOver.
+// It returns true if the object changed.
+func setManagedMetadata(obj metav1.Object, labels, annotations map[string]string, template metav1.ObjectMeta, takeOver bool) bool {
+	var managed managedMetadata
+	if v, ok := obj.GetAnnotations()[naming.AnnotationManagedMetadata]; ok {
+		// the keys set before are forgotten if the annotation is broken
+		_ = json.Unmarshal([]byte(v), &managed)
+	}
+
+	objLabels, labelKeys := setManagedKeys(obj.GetLabels(), labels, template.Labels, managed.Labels, takeOver)
+	objAnnotations, annotationKeys := setManagedKeys(obj.GetAnnotations(), annotations, template.Annotations, managed.Annotations, takeOver)
+
+	delete(objAnnotations, naming.AnnotationManagedMetadata)
+	if len(labelKeys) > 0 || len(annotationKeys) > 0 {
+		data, _ := json.Marshal(managedMetadata{Labels: labelKeys, Annotations: annotationKeys})
+		objAnnotations[naming.AnnotationManagedMetadata] = string(data)
+	}
+
+	changed := !maps.Equal(obj.GetLabels(), objLabels) || !maps.Equal(obj.GetAnnotations(), objAnnotations)
+	obj.SetLabels(objLabels)
+	obj.SetAnnotations(objAnnotations)
+
+	return changed
+}
+
+// setManagedKeys returns a copy of m with the desired keys set and the managed keys which aren't
+// desired anymore restored to the values of the template or removed, and the keys managed now.
+func setManagedKeys(m, desired, template map[string]string, managed []string, takeOver bool) (map[string]string, []string) {
+	out := make(map[string]string, len(m)+len(desired))
+	maps.Copy(out, m)
+
+	var keys []string
+	for k, v := range desired {
+		if cur, ok := out[k]; ok && cur != v && !takeOver && !slices.Contains(managed, k) {
+			continue
+		}
+		out[k] = v
+		keys = append(keys, k)
+	}
+	for _, k := range managed {
+		if _, ok := desired[k]; ok {
+			continue
+		}
+		if v, ok := template[k]; ok {
+			out[k] = v
+			continue
+		}
+		delete(out, k)
+	}
+
+	slices.Sort(keys)
+	return out, keys
+}
@@ -0,0 +1,105 @@
+package pxc
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+func TestReconcileMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.Labels = map[string]string{"team": "db", "unrelated": "true"}
+	cr.Spec.PropagateLabels = []string{"team"}
+	cr.Spec.PXC.PodMetadata = []api.PodMetadata{
+		{Ordinal: 1, Labels: map[string]string{"zone": "b"}, Annotations: map[string]string{"note": "canary"}},
+	}
+
+	selector := naming.SelectorPXC(cr)
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "cr-mock-pxc", Namespace: cr.Namespace, Labels: naming.LabelsPXC(cr)},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"zone": "a"}},
+			},
+		},
+	}
+	newPod := func(name string) *corev1.Pod {
+		labels := map[string]string{"zone": "a"}
+		for k, v := range selector {
+			labels[k] = v
+		}
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cr.Namespace, Labels: labels}}
+	}
+	svcLabels := naming.LabelsPXC(cr)
+	svcLabels["team"] = "applied"
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "cr-mock-pxc", Namespace: cr.Namespace, Labels: svcLabels}}
+
+	r := buildFakeClient([]runtime.Object{cr, sts, svc, newPod("cr-mock-pxc-0"), newPod("cr-mock-pxc-1")})
+
+	get := func(name string, obj client.Object) client.Object {
+		if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: cr.Namespace}, obj); err != nil {
+			t.Fatal(err)
+		}
+		return obj
+	}
+
+	if err := r.reconcileMetadata(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+
+	pod := get("cr-mock-pxc-1", new(corev1.Pod))
+	if l := pod.GetLabels(); l["zone"] != "b" || l["team"] != "db" {
+		t.Errorf("unexpected labels of the pod with metadata: %v", l)
+	}
+	if pod.GetAnnotations()["note"] != "canary" {
+		t.Errorf("unexpected annotations of the pod with metadata: %v", pod.GetAnnotations())
+	}
+	pod = get("cr-mock-pxc-0", new(corev1.Pod))
+	if l := pod.GetLabels(); l["zone"] != "a" || l["team"] != "db" {
+		t.Errorf("unexpected labels of the pod without metadata: %v", l)
+	}
+	if _, ok := pod.GetLabels()["unrelated"]; ok {
+		t.Error("label which isn't propagated is copied")
+	}
+	if l := get("cr-mock-pxc", new(appsv1.StatefulSet)).GetLabels(); l["team"] != "db" {
+		t.Errorf("expected the label to be propagated to the statefulset, got %v", l)
+	}
+	if l := get("cr-mock-pxc", new(corev1.Service)).GetLabels(); l["team"] != "applied" {
+		t.Errorf("expected the applied label of the service to be kept, got %v", l)
+	}
+
+	cr.Spec.PXC.PodMetadata = nil
+	cr.Spec.PropagateLabels = nil
+	if err := r.reconcileMetadata(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+
+	pod = get("cr-mock-pxc-1", new(corev1.Pod))
+	if l := pod.GetLabels(); l["zone"] != "a" {
+		t.Errorf("expected the label of the template to be restored, got %v", l)
+	}
+	if _, ok := pod.GetLabels()["team"]; ok {
+		t.Error("expected the propagated label to be removed")
+	}
+	if _, ok := pod.GetAnnotations()["note"]; ok {
+		t.Error("expected the annotation to be removed")
+	}
+	if _, ok := pod.GetAnnotations()[naming.AnnotationManagedMetadata]; ok {
+		t.Error("expected the managed metadata annotation to be removed")
+	}
+	if _, ok := get("cr-mock-pxc", new(appsv1.StatefulSet)).GetLabels()["team"]; ok {
+		t.Error("expected the propagated label to be removed from the statefulset")
+	}
+}
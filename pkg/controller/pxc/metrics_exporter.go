@@ -0,0 +1,40 @@
+package pxc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
+)
+
+// reconcilePodMonitor creates the PodMonitor for mysqld_exporter sidecars
+// and the PodMonitors of the other metrics endpoints of the cluster.
+// It's skipped if prometheus-operator CRDs are not installed.
+func (r *ReconcilePerconaXtraDBCluster) reconcilePodMonitor(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	if !cr.Spec.PXC.Metrics.IsEnabled() {
+		if err := r.deleteOptionalObject(ctx, app.PodMonitorGVK, cr.Namespace, naming.PXCPodMonitorName(cr)); err != nil {
+			return errors.Wrap(err, "delete PodMonitor")
+		}
+	} else if err := r.applyOptionalObject(ctx, cr, app.PXCPodMonitor(cr)); err != nil {
+		return errors.Wrap(err, "apply PodMonitor")
+	}
+
+	desired := app.ComponentPodMonitors(cr)
+	for _, name := range []string{naming.HAProxyPodMonitorName(cr), naming.ProxySQLPodMonitorName(cr), naming.PITRPodMonitorName(cr)} {
+		pm, ok := desired[name]
+		if !ok {
+			if err := r.deleteOptionalObject(ctx, app.PodMonitorGVK, cr.Namespace, name); err != nil {
+				return errors.Wrapf(err, "delete PodMonitor %s", name)
+			}
+			continue
+		}
+		if err := r.applyOptionalObject(ctx, cr, pm); err != nil {
+			return errors.Wrapf(err, "apply PodMonitor %s", name)
+		}
+	}
+
+	return nil
+}
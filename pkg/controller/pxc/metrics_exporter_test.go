@@ -0,0 +1,62 @@
+package pxc
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
+)
+
+func TestPodMonitors(t *testing.T) {
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.CRVersion = "1.17.0"
+	cr.Spec.PXC.Metrics = &api.MetricsSpec{Enabled: true, PodMonitorLabels: map[string]string{"team": "db"}}
+
+	if monitors := app.ComponentPodMonitors(cr); len(monitors) != 0 {
+		t.Errorf("expected no monitors without serviceMonitor, got %d", len(monitors))
+	}
+
+	cr.Spec.Monitoring = &api.MonitoringSpec{
+		ServiceMonitor: &api.ServiceMonitorSpec{
+			Enabled:  true,
+			Labels:   map[string]string{"release": "prometheus"},
+			Interval: "30s",
+			TLS:      &api.MetricsTLSSpec{ServerName: "cr-mock-pxc"},
+		},
+	}
+
+	monitors := app.ComponentPodMonitors(cr)
+	if len(monitors) != 1 {
+		t.Fatalf("expected the HAProxy monitor only, got %d", len(monitors))
+	}
+	haproxy, ok := monitors["cr-mock-haproxy"]
+	if !ok {
+		t.Fatal("expected the HAProxy monitor")
+	}
+	if haproxy.GetLabels()["release"] != "prometheus" {
+		t.Errorf("expected serviceMonitor labels, got %v", haproxy.GetLabels())
+	}
+	endpoints, _, _ := unstructured.NestedSlice(haproxy.Object, "spec", "podMetricsEndpoints")
+	endpoint := endpoints[0].(map[string]interface{})
+	if endpoint["port"] != "stats" || endpoint["interval"] != "30s" {
+		t.Errorf("unexpected endpoint %v", endpoint)
+	}
+	if _, ok := endpoint["tlsConfig"]; ok {
+		t.Error("expected no TLS for HAProxy stats")
+	}
+
+	pxc := app.PXCPodMonitor(cr)
+	if ls := pxc.GetLabels(); ls["release"] != "prometheus" || ls["team"] != "db" {
+		t.Errorf("expected serviceMonitor and podMonitor labels, got %v", ls)
+	}
+	endpoints, _, _ = unstructured.NestedSlice(pxc.Object, "spec", "podMetricsEndpoints")
+	endpoint = endpoints[0].(map[string]interface{})
+	if endpoint["scheme"] != "https" {
+		t.Errorf("expected https scheme, got %v", endpoint["scheme"])
+	}
+	if name, _, _ := unstructured.NestedString(endpoint, "tlsConfig", "serverName"); name != "cr-mock-pxc" {
+		t.Errorf("unexpected server name %q", name)
+	}
+}
@@ -0,0 +1,97 @@
+package pxc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
+)
+
+// reconcileMonitoring creates PrometheusRule and Grafana dashboard of the cluster.
+func (r *ReconcilePerconaXtraDBCluster) reconcileMonitoring(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	if err := r.reconcilePodMonitor(ctx, cr); err != nil {
+		return err
+	}
+
+	if m := cr.Spec.Monitoring; m != nil && m.PrometheusRule.IsEnabled() {
+		rule, err := app.PrometheusRule(cr)
+		if err != nil {
+			return errors.Wrap(err, "new PrometheusRule")
+		}
+		if err := r.applyOptionalObject(ctx, cr, rule); err != nil {
+			return errors.Wrap(err, "apply PrometheusRule")
+		}
+	} else {
+		err := r.deleteOptionalObject(ctx, app.PrometheusRuleGVK, cr.Namespace, naming.PrometheusRuleName(cr))
+		if err != nil {
+			return errors.Wrap(err, "delete PrometheusRule")
+		}
+	}
+
+	if m := cr.Spec.Monitoring; m != nil && m.Dashboard.IsEnabled() {
+		cm, err := app.DashboardConfigMap(cr)
+		if err != nil {
+			return errors.Wrap(err, "new dashboard config map")
+		}
+		if err := k8s.SetControllerReference(cr, cm, r.scheme); err != nil {
+			return errors.Wrap(err, "set controller reference")
+		}
+		if err := createOrUpdateConfigmap(r.client, cm); err != nil {
+			return errors.Wrap(err, "create or update dashboard config map")
+		}
+	} else {
+		if err := deleteConfigMapIfExists(r.client, cr, naming.DashboardConfigMapName(cr)); err != nil {
+			return errors.Wrap(err, "delete dashboard config map")
+		}
+	}
+
+	return nil
+}
+
+// applyOptionalObject creates or updates an object of a third-party CRD.
+// It's skipped if the CRD is not installed.
+func (r *ReconcilePerconaXtraDBCluster) applyOptionalObject(ctx context.Context, cr *api.PerconaXtraDBCluster, obj *unstructured.Unstructured) error {
+	if err := k8s.SetControllerReference(cr, obj, r.scheme); err != nil {
+		return errors.Wrap(err, "set controller reference")
+	}
+
+	existing := new(unstructured.Unstructured)
+	existing.SetGroupVersionKind(obj.GroupVersionKind())
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+	switch {
+	case meta.IsNoMatchError(err):
+		logf.FromContext(ctx).V(1).Info("CRD is not installed, skipping", "kind", obj.GetKind(), "name", obj.GetName())
+		return nil
+	case k8serrors.IsNotFound(err):
+		return r.client.Create(ctx, obj)
+	case err != nil:
+		return errors.Wrap(err, "get object")
+	}
+
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return r.client.Update(ctx, obj)
+}
+
+func (r *ReconcilePerconaXtraDBCluster) deleteOptionalObject(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) error {
+	obj := new(unstructured.Unstructured)
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(name)
+	obj.SetNamespace(namespace)
+
+	err := r.client.Delete(ctx, obj)
+	if err != nil && !k8serrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return err
+	}
+
+	return nil
+}
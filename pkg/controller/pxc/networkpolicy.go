@@ -0,0 +1,64 @@
+package pxc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc"
+)
+
+// reconcileNetworkPolicies manages the NetworkPolicies of the cluster pods.
+// The policies of the disabled components, or all of them if the policies are disabled, are deleted.
+func (r *ReconcilePerconaXtraDBCluster) reconcileNetworkPolicies(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	desired := make(map[string]struct{})
+
+	if spec := cr.Spec.NetworkPolicy; spec.IsEnabled() {
+		operatorNamespace := spec.OperatorNamespace
+		if operatorNamespace == "" {
+			ns, err := k8s.GetOperatorNamespace()
+			if err != nil {
+				return errors.Wrap(err, "get operator namespace")
+			}
+			operatorNamespace = ns
+		}
+
+		for _, policy := range pxc.NetworkPolicies(cr, operatorNamespace) {
+			if err := k8s.SetControllerReference(cr, policy, r.scheme); err != nil {
+				return errors.Wrap(err, "set controller reference")
+			}
+			if err := r.createOrUpdate(ctx, cr, policy); err != nil {
+				return errors.Wrapf(err, "create or update network policy %s", policy.Name)
+			}
+			desired[policy.Name] = struct{}{}
+		}
+	}
+
+	list := new(networkingv1.NetworkPolicyList)
+	err := r.client.List(ctx, list, &client.ListOptions{
+		Namespace:     cr.Namespace,
+		LabelSelector: labels.SelectorFromSet(naming.LabelsNetworkPolicy(cr)),
+	})
+	if err != nil {
+		return errors.Wrap(err, "get network policy list")
+	}
+
+	for i := range list.Items {
+		policy := &list.Items[i]
+		if _, ok := desired[policy.Name]; ok || !metav1.IsControlledBy(policy, cr) {
+			continue
+		}
+		if err := r.client.Delete(ctx, policy); client.IgnoreNotFound(err) != nil {
+			return errors.Wrapf(err, "delete network policy %s", policy.Name)
+		}
+	}
+
+	return nil
+}
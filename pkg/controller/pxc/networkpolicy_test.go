@@ -0,0 +1,66 @@
+package pxc
+
+import (
+	"context"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestReconcileNetworkPolicies(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.UID = "cr-uid"
+	port := intstr.FromInt32(3306)
+	cr.Spec.NetworkPolicy = &api.NetworkPolicySpec{
+		Enabled:           true,
+		OperatorNamespace: "pxc-operator",
+		AdditionalIngress: api.NetworkPolicyIngress{
+			PXC: []networkingv1.NetworkPolicyIngressRule{{Ports: []networkingv1.NetworkPolicyPort{{Port: &port}}}},
+		},
+	}
+
+	r := buildFakeClient([]runtime.Object{cr})
+
+	if err := r.reconcileNetworkPolicies(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+
+	policies := new(networkingv1.NetworkPolicyList)
+	if err := r.client.List(ctx, policies, client.InNamespace(cr.Namespace)); err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]*networkingv1.NetworkPolicy)
+	for i := range policies.Items {
+		names[policies.Items[i].Name] = &policies.Items[i]
+	}
+	if len(names) != 2 || names["cr-mock-pxc"] == nil || names["cr-mock-haproxy"] == nil {
+		t.Fatalf("expected policies of PXC and HAProxy, got %v", names)
+	}
+
+	pxcPolicy := names["cr-mock-pxc"]
+	if got := pxcPolicy.Spec.Ingress[len(pxcPolicy.Spec.Ingress)-1]; len(got.From) != 0 || got.Ports[0].Port.IntValue() != 3306 {
+		t.Errorf("expected the additional rule to be the last one, got %+v", got)
+	}
+	operatorRule := pxcPolicy.Spec.Ingress[1]
+	if ns := operatorRule.From[0].NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"]; ns != "pxc-operator" {
+		t.Errorf("expected the operator namespace in the rule, got %q", ns)
+	}
+
+	cr.Spec.NetworkPolicy.Enabled = false
+	if err := r.reconcileNetworkPolicies(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.client.List(ctx, policies, client.InNamespace(cr.Namespace)); err != nil {
+		t.Fatal(err)
+	}
+	if len(policies.Items) != 0 {
+		t.Errorf("expected policies to be deleted, got %d", len(policies.Items))
+	}
+}
@@ -0,0 +1,114 @@
+package pxc
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	gover "github.com/hashicorp/go-version"
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/version"
+)
+
+const (
+	ownershipLeaseDuration = 2 * time.Minute
+	ownershipRenewPeriod   = 30 * time.Second
+)
+
+// operatorIdentity returns namespace/name of the operator deployment.
+// It's empty if the operator runs outside of the cluster.
+func operatorIdentity() string {
+	ns, err := k8s.GetOperatorNamespace()
+	if err != nil {
+		return ""
+	}
+
+	name := os.Getenv("OPERATOR_NAME")
+	if name == "" {
+		name = "percona-xtradb-cluster-operator"
+	}
+	return ns + "/" + name
+}
+
+// claimCluster returns true if this operator owns the cluster and can reconcile it.
+// The owner is recorded in the cluster annotations and renewed periodically.
+// A live owner keeps the cluster unless this operator has a newer version,
+// an owner that stopped renewing for ownershipLeaseDuration can be replaced by any operator.
+func (r *ReconcilePerconaXtraDBCluster) claimCluster(ctx context.Context, cr *api.PerconaXtraDBCluster) (bool, error) {
+	if r.operatorID == "" {
+		return true, nil
+	}
+
+	log := logf.FromContext(ctx)
+
+	owner := cr.Annotations[naming.AnnotationOperatorOwner]
+	ownerVersion := cr.Annotations[naming.AnnotationOperatorVersion]
+	renewedAt, _ := time.Parse(time.RFC3339, cr.Annotations[naming.AnnotationOperatorRenewedAt])
+
+	if owner == r.operatorID && ownerVersion == version.Version && time.Since(renewedAt) < ownershipRenewPeriod {
+		return true, nil
+	}
+
+	if owner != "" && owner != r.operatorID && time.Since(renewedAt) < ownershipLeaseDuration {
+		if !newerThan(version.Version, ownerVersion) {
+			log.Info("cluster is reconciled by another operator", "owner", owner, "ownerVersion", ownerVersion)
+			r.stopClusterJobs(cr)
+			return false, nil
+		}
+		log.Info("taking over cluster from an older operator", "owner", owner, "ownerVersion", ownerVersion)
+	}
+
+	orig := cr.DeepCopy()
+	if cr.Annotations == nil {
+		cr.Annotations = make(map[string]string)
+	}
+	cr.Annotations[naming.AnnotationOperatorOwner] = r.operatorID
+	cr.Annotations[naming.AnnotationOperatorVersion] = version.Version
+	cr.Annotations[naming.AnnotationOperatorRenewedAt] = time.Now().UTC().Format(time.RFC3339)
+
+	err := r.client.Patch(ctx, cr, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{}))
+	if k8serrors.IsConflict(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "patch operator owner")
+	}
+
+	return true, nil
+}
+
+// stopClusterJobs removes cron jobs of the cluster, the owner schedules its own.
+func (r *ReconcilePerconaXtraDBCluster) stopClusterJobs(cr *api.PerconaXtraDBCluster) {
+	r.deleteCronJob(versionJobName(cr))
+	r.deleteCronJob(telemetryJobName(cr))
+
+	prefix := backupJobClusterPrefix(cr.Namespace + "-" + cr.Name)
+	r.crons.backupJobs.Range(func(k, v interface{}) bool {
+		if name := v.(BackupScheduleJob).Name; strings.HasPrefix(name, prefix) {
+			r.deleteBackupJob(name)
+		}
+		return true
+	})
+}
+
+// newerThan returns true if version a is newer than b.
+// Versions that can't be parsed are treated as the oldest.
+func newerThan(a, b string) bool {
+	va, err := gover.NewVersion(a)
+	if err != nil {
+		return false
+	}
+	vb, err := gover.NewVersion(b)
+	if err != nil {
+		return true
+	}
+	return va.GreaterThan(vb)
+}
@@ -0,0 +1,73 @@
+package pxc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/version"
+)
+
+func TestClaimCluster(t *testing.T) {
+	live := time.Now().UTC().Format(time.RFC3339)
+	expired := time.Now().Add(-ownershipLeaseDuration - time.Minute).UTC().Format(time.RFC3339)
+
+	tests := map[string]struct {
+		owner, ownerVersion, renewedAt string
+		expected                       bool
+	}{
+		"no owner":                  {"", "", "", true},
+		"owned by this operator":    {"ns/operator", version.Version, live, true},
+		"live owner, same version":  {"other/operator", version.Version, live, false},
+		"live owner, newer version": {"other/operator", "99.0.0", live, false},
+		"live owner, older version": {"other/operator", "1.0.0", live, true},
+		"expired owner":             {"other/operator", "99.0.0", expired, true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			cr := newCR("cluster1", "pxc")
+			if tt.owner != "" {
+				cr.Annotations = map[string]string{
+					naming.AnnotationOperatorOwner:     tt.owner,
+					naming.AnnotationOperatorVersion:   tt.ownerVersion,
+					naming.AnnotationOperatorRenewedAt: tt.renewedAt,
+				}
+			}
+
+			r := buildFakeClient([]runtime.Object{cr})
+			r.operatorID = "ns/operator"
+			r.crons = NewCronRegistry()
+
+			current := new(api.PerconaXtraDBCluster)
+			if err := r.client.Get(ctx, client.ObjectKeyFromObject(cr), current); err != nil {
+				t.Fatal(err)
+			}
+
+			owned, err := r.claimCluster(ctx, current)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if owned != tt.expected {
+				t.Fatalf("expected owned %t, got %t", tt.expected, owned)
+			}
+
+			if err := r.client.Get(ctx, client.ObjectKeyFromObject(cr), current); err != nil {
+				t.Fatal(err)
+			}
+			owner := current.Annotations[naming.AnnotationOperatorOwner]
+			if owned && (owner != r.operatorID || current.Annotations[naming.AnnotationOperatorVersion] != version.Version) {
+				t.Errorf("cluster is not claimed: %v", current.Annotations)
+			}
+			if !owned && owner != tt.owner {
+				t.Errorf("owner is changed to %s", owner)
+			}
+		})
+	}
+}
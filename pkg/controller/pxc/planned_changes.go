@@ -0,0 +1,81 @@
+package pxc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// stsPendingActions describes what updating current to desired does with the pods.
+// It returns nil if the statefulset doesn't exist yet or won't be changed.
+func stsPendingActions(current, desired *appsv1.StatefulSet) []string {
+	if current.ResourceVersion == "" {
+		return nil
+	}
+
+	// the fields defaulted by the API server or set by other controllers aren't in desired
+	if equality.Semantic.DeepDerivative(desired.Spec, current.Spec) {
+		return nil
+	}
+
+	var actions []string
+
+	replicas, currentReplicas := int32(1), int32(1)
+	if desired.Spec.Replicas != nil {
+		replicas = *desired.Spec.Replicas
+	}
+	if current.Spec.Replicas != nil {
+		currentReplicas = *current.Spec.Replicas
+	}
+	if replicas != currentReplicas {
+		actions = append(actions, fmt.Sprintf("will scale %s from %d to %d pods", current.Name, currentReplicas, replicas))
+	}
+
+	if reasons := templateChanges(current, desired); len(reasons) > 0 {
+		actions = append(actions, fmt.Sprintf("will restart %d %s pods: %s", currentReplicas, current.Name, strings.Join(reasons, ", ")))
+	}
+
+	if len(actions) == 0 {
+		actions = append(actions, fmt.Sprintf("will update statefulset %s without restarting pods", current.Name))
+	}
+
+	return actions
+}
+
+func templateChanges(current, desired *appsv1.StatefulSet) []string {
+	var reasons []string
+
+	images := make(map[string]string)
+	for _, c := range current.Spec.Template.Spec.Containers {
+		images[c.Name] = c.Image
+	}
+	for _, c := range desired.Spec.Template.Spec.Containers {
+		image, ok := images[c.Name]
+		switch {
+		case !ok:
+			reasons = append(reasons, fmt.Sprintf("container %s added", c.Name))
+		case image != c.Image:
+			reasons = append(reasons, fmt.Sprintf("image of %s changed to %s", c.Name, c.Image))
+		}
+	}
+
+	var hashes []string
+	for k, v := range desired.Spec.Template.Annotations {
+		if strings.HasPrefix(k, "percona.com/") && strings.HasSuffix(k, "-hash") && current.Spec.Template.Annotations[k] != v {
+			hashes = append(hashes, strings.TrimSuffix(strings.TrimPrefix(k, "percona.com/"), "-hash"))
+		}
+	}
+	sort.Strings(hashes)
+	for _, h := range hashes {
+		reasons = append(reasons, h+" changed")
+	}
+
+	if len(reasons) == 0 && !equality.Semantic.DeepDerivative(desired.Spec.Template, current.Spec.Template) {
+		reasons = append(reasons, "pod template changed")
+	}
+
+	return reasons
+}
@@ -0,0 +1,104 @@
+package pxc
+
+import (
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestStsPendingActions(t *testing.T) {
+	newSts := func(replicas int32, image, configHash string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster1-pxc"},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: &replicas,
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{"percona.com/configuration-hash": configHash},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "pxc", Image: image}},
+					},
+				},
+			},
+		}
+	}
+	existing := func(sts *appsv1.StatefulSet) *appsv1.StatefulSet {
+		sts.ResourceVersion = "1"
+		// defaulted by the API server
+		sts.Spec.RevisionHistoryLimit = ptr.To(int32(10))
+		return sts
+	}
+
+	tests := map[string]struct {
+		current *appsv1.StatefulSet
+		desired *appsv1.StatefulSet
+		actions []string
+	}{
+		"not created": {
+			current: newSts(3, "pxc:8.0", "a"),
+			desired: newSts(3, "pxc:8.0", "a"),
+		},
+		"unchanged": {
+			current: existing(newSts(3, "pxc:8.0", "a")),
+			desired: newSts(3, "pxc:8.0", "a"),
+		},
+		"scale": {
+			current: existing(newSts(3, "pxc:8.0", "a")),
+			desired: newSts(5, "pxc:8.0", "a"),
+			actions: []string{"will scale cluster1-pxc from 3 to 5 pods"},
+		},
+		"image and config": {
+			current: existing(newSts(3, "pxc:8.0", "a")),
+			desired: newSts(3, "pxc:8.4", "b"),
+			actions: []string{"will restart 3 cluster1-pxc pods: image of pxc changed to pxc:8.4, configuration changed"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			actions := stsPendingActions(tt.current, tt.desired)
+			if !reflect.DeepEqual(actions, tt.actions) {
+				t.Errorf("got %q, want %q", actions, tt.actions)
+			}
+		})
+	}
+}
+
+func TestChangesApproval(t *testing.T) {
+	cr := &api.PerconaXtraDBCluster{
+		Spec: api.PerconaXtraDBClusterSpec{RequireChangesApproval: true},
+	}
+
+	cr.SetPendingActions("pxc", []string{"will restart 3 cluster1-pxc pods: configuration changed"})
+	cr.SetPendingActions("haproxy", nil)
+	if cr.Status.PendingChanges == nil || len(cr.Status.PendingChanges.Actions) != 1 {
+		t.Fatalf("unexpected pending changes: %+v", cr.Status.PendingChanges)
+	}
+	if cr.ChangesApproved() {
+		t.Fatal("changes are approved without annotation")
+	}
+
+	id := cr.Status.PendingChanges.ID
+	cr.Annotations = map[string]string{api.AnnotationApprovedChanges: id}
+	if !cr.ChangesApproved() {
+		t.Fatal("changes are not approved")
+	}
+
+	cr.SetPendingActions("haproxy", []string{"will scale cluster1-haproxy from 3 to 2 pods"})
+	if cr.ChangesApproved() {
+		t.Fatal("new changes are approved with the old id")
+	}
+
+	cr.SetPendingActions("pxc", nil)
+	cr.SetPendingActions("haproxy", nil)
+	if cr.Status.PendingChanges != nil {
+		t.Errorf("pending changes are not cleared: %+v", cr.Status.PendingChanges)
+	}
+}
@@ -0,0 +1,106 @@
+package pxc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/config"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+// getProviderOptionsHash returns the hash of the provider options which are applied on restart only,
+// so changing the dynamic ones doesn't restart the pods.
+func getProviderOptionsHash(cr *api.PerconaXtraDBCluster) (string, error) {
+	var static []config.ProviderOption
+	for _, o := range config.ProviderOptions(cr.Spec.PXC.ProviderOptions) {
+		if !o.Dynamic {
+			static = append(static, o)
+		}
+	}
+	if len(static) == 0 {
+		return "", nil
+	}
+
+	return getCustomConfigHashHex(map[string]string{"options": config.JoinProviderOptions(static)}, nil)
+}
+
+// reconcileProviderOptions sets the dynamic provider options on the ready PXC nodes where they differ,
+// the nodes started later get them from the provider options config map.
+func (r *ReconcilePerconaXtraDBCluster) reconcileProviderOptions(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	var dynamic []config.ProviderOption
+	for _, o := range config.ProviderOptions(cr.Spec.PXC.ProviderOptions) {
+		if o.Dynamic {
+			dynamic = append(dynamic, o)
+		}
+	}
+	if len(dynamic) == 0 || cr.Spec.Pause || cr.Status.PXC.Ready < 1 {
+		return nil
+	}
+
+	pods := corev1.PodList{}
+	err := r.client.List(ctx, &pods, &client.ListOptions{
+		Namespace:     cr.Namespace,
+		LabelSelector: labels.SelectorFromSet(naming.LabelsPXC(cr)),
+	})
+	if err != nil {
+		return errors.Wrap(err, "get pod list")
+	}
+
+	for _, pod := range pods.Items {
+		if !isPodReady(pod) {
+			continue
+		}
+
+		if err := r.setProviderOptions(ctx, cr, pod.Name, dynamic); err != nil {
+			return errors.Wrapf(err, "pod %s", pod.Name)
+		}
+	}
+
+	return nil
+}
+
+// setProviderOptions sets the options of the node which differ from its current ones.
+func (r *ReconcilePerconaXtraDBCluster) setProviderOptions(ctx context.Context, cr *api.PerconaXtraDBCluster, podName string, opts []config.ProviderOption) error {
+	host := podName + "." + cr.Name + "-pxc." + cr.Namespace
+	db, err := queries.New(r.client, cr.Namespace, users.InternalSecretsPrefix+cr.Name, users.Operator, host, 33062, cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
+	if err != nil {
+		return errors.Wrap(err, "connect")
+	}
+	defer db.Close()
+
+	current, err := db.WsrepProviderOptions()
+	if err != nil {
+		return err
+	}
+	changed := changedProviderOptions(current, opts)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	options := config.JoinProviderOptions(changed)
+	if err := db.SetWsrepProviderOptions(options); err != nil {
+		return err
+	}
+	logf.FromContext(ctx).Info("Provider options are applied", "pod", podName, "options", options)
+
+	return nil
+}
+
+// changedProviderOptions returns the options which differ from the current options of the node.
+func changedProviderOptions(current map[string]string, opts []config.ProviderOption) []config.ProviderOption {
+	var changed []config.ProviderOption
+	for _, o := range opts {
+		if current[o.Name] != o.Value {
+			changed = append(changed, o)
+		}
+	}
+	return changed
+}
@@ -0,0 +1,51 @@
+package pxc
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/config"
+)
+
+func TestProviderOptions(t *testing.T) {
+	size := resource.MustParse("2Gi")
+	limit := int32(100)
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.PXC.ProviderOptions = &api.ProviderOptionsSpec{
+		GCacheSize: &size,
+		GCSFCLimit: &limit,
+		EVS:        &api.EVSTimeouts{SuspectTimeout: &metav1.Duration{Duration: 7500 * time.Millisecond}},
+	}
+
+	opts := config.ProviderOptions(cr.Spec.PXC.ProviderOptions)
+	expected := "evs.suspect_timeout=PT7.5S;gcache.size=2G;gcs.fc_limit=100"
+	if got := config.JoinProviderOptions(opts); got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+
+	hash, err := getProviderOptionsHash(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	limit = 200
+	changedHash, err := getProviderOptionsHash(cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash == "" || hash != changedHash {
+		t.Errorf("expected the hash to ignore the dynamic options, got %q and %q", hash, changedHash)
+	}
+
+	current := map[string]string{"gcs.fc_limit": "100", "gcache.size": "128M"}
+	changed := changedProviderOptions(current, []config.ProviderOption{{Name: "gcs.fc_limit", Value: "200", Dynamic: true}})
+	if len(changed) != 1 || changed[0].String() != "gcs.fc_limit=200" {
+		t.Errorf("unexpected changed options %v", changed)
+	}
+	if changed := changedProviderOptions(current, []config.ProviderOption{{Name: "gcs.fc_limit", Value: "100", Dynamic: true}}); len(changed) > 0 {
+		t.Errorf("expected no changed options, got %v", changed)
+	}
+}
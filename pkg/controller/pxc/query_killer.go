@@ -0,0 +1,159 @@
+package pxc
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+const (
+	idleTransactionRule = "idle-transaction"
+	maxKilledQueryLen   = 100
+)
+
+// processes of the server itself and of the operator managed users are never killed
+var queryKillerSkipUsers = append([]string{"system user", "event_scheduler"}, users.UserNames...)
+
+type queryKill struct {
+	proc queries.Process
+	rule string
+	// query is true if only the statement has to be killed, not the connection
+	query bool
+}
+
+// enforceQueryKiller kills queries and idle transactions violating the
+// query killer policy on every ready PXC pod.
+// Reconcile runs every few seconds, so pods are checked not more often than the policy interval.
+func (r *ReconcilePerconaXtraDBCluster) enforceQueryKiller(ctx context.Context, cr *api.PerconaXtraDBCluster) {
+	qk := cr.Spec.QueryKiller
+	if !qk.IsEnabled() || cr.Spec.Pause || cr.Status.PXC.Ready < 1 {
+		return
+	}
+
+	key := cr.Namespace + "/" + cr.Name
+	interval := time.Duration(qk.IntervalSeconds) * time.Second
+	if last, ok := r.queryKillerCheckedAt.Load(key); ok && time.Since(last.(time.Time)) < interval {
+		return
+	}
+	r.queryKillerCheckedAt.Store(key, time.Now())
+
+	log := logf.FromContext(ctx)
+
+	pods := corev1.PodList{}
+	err := r.client.List(ctx, &pods, &client.ListOptions{
+		Namespace:     cr.Namespace,
+		LabelSelector: labels.SelectorFromSet(naming.LabelsPXC(cr)),
+	})
+	if err != nil {
+		log.Error(err, "failed to list pods for query killer")
+		return
+	}
+
+	for _, pod := range pods.Items {
+		if !isPodReady(pod) {
+			continue
+		}
+		if err := r.killQueries(ctx, cr, pod.Name); err != nil {
+			log.Error(err, "failed to enforce query killer policy", "pod", pod.Name)
+		}
+	}
+}
+
+func (r *ReconcilePerconaXtraDBCluster) killQueries(ctx context.Context, cr *api.PerconaXtraDBCluster, podName string) error {
+	host := podName + "." + cr.Name + "-pxc." + cr.Namespace
+	db, err := queries.New(r.client, cr.Namespace, users.InternalSecretsPrefix+cr.Name, users.Operator, host, 33062, cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	procs, err := db.Processlist(ctx)
+	if err != nil {
+		return err
+	}
+
+	qk := cr.Spec.QueryKiller
+	for _, k := range queriesToKill(qk, procs) {
+		what := "connection"
+		if k.query {
+			what = "query"
+		}
+		msg := fmt.Sprintf("%s %d of %s@%s on %s, %ds (%s): %s",
+			what, k.proc.ID, k.proc.User, k.proc.Host, podName, k.proc.Time, k.rule, truncateQuery(k.proc.Info))
+
+		if qk.DryRun {
+			r.recorder.Event(cr, corev1.EventTypeNormal, naming.EventQueryKilled, "Dry run, would kill "+msg)
+			continue
+		}
+
+		if err := db.Kill(ctx, k.proc.ID, k.query); err != nil {
+			return err
+		}
+		metrics.IncQueriesKilled(cr, k.rule)
+		r.recorder.Event(cr, corev1.EventTypeWarning, naming.EventQueryKilled, "Killed "+msg)
+	}
+
+	return nil
+}
+
+// queriesToKill returns the processes violating the policy with the rule they violate
+func queriesToKill(qk *api.QueryKillerSpec, procs []queries.Process) []queryKill {
+	var kills []queryKill
+	for _, p := range procs {
+		if slices.Contains(queryKillerSkipUsers, p.User) {
+			continue
+		}
+
+		switch p.Command {
+		case "Query", "Execute":
+			if p.Info == "" {
+				continue
+			}
+			for i, rule := range qk.Rules {
+				if len(rule.Users) > 0 && !slices.Contains(rule.Users, p.User) {
+					continue
+				}
+				if rule.Match != "" {
+					re, err := regexp.Compile(rule.Match)
+					if err != nil || !re.MatchString(p.Info) {
+						continue
+					}
+				}
+				if p.Time > int64(rule.MaxQueryTimeSeconds) {
+					name := rule.Name
+					if name == "" {
+						name = fmt.Sprintf("rule-%d", i)
+					}
+					kills = append(kills, queryKill{proc: p, rule: name, query: true})
+				}
+				break
+			}
+		case "Sleep":
+			if qk.IdleTransactionTimeoutSeconds > 0 && p.InTransaction && p.Time > int64(qk.IdleTransactionTimeoutSeconds) {
+				kills = append(kills, queryKill{proc: p, rule: idleTransactionRule})
+			}
+		}
+	}
+
+	return kills
+}
+
+func truncateQuery(q string) string {
+	if len(q) <= maxKilledQueryLen {
+		return q
+	}
+	return q[:maxKilledQueryLen] + "..."
+}
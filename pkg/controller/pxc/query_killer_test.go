@@ -0,0 +1,51 @@
+package pxc
+
+import (
+	"testing"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
+)
+
+func TestQueriesToKill(t *testing.T) {
+	qk := &api.QueryKillerSpec{
+		Enabled: true,
+		Rules: []api.QueryKillerRule{
+			{Name: "analytics", Users: []string{"analyst"}, MaxQueryTimeSeconds: 60, Match: "(?i)^select"},
+			{MaxQueryTimeSeconds: 600},
+		},
+		IdleTransactionTimeoutSeconds: 300,
+	}
+
+	procs := []queries.Process{
+		{ID: 1, User: "analyst", Command: "Query", Time: 120, Info: "select * from orders"},
+		{ID: 2, User: "analyst", Command: "Query", Time: 30, Info: "select * from orders"},
+		{ID: 3, User: "analyst", Command: "Query", Time: 120, Info: "update orders set x = 1"},
+		{ID: 4, User: "app", Command: "Query", Time: 700, Info: "select sleep(1000)"},
+		{ID: 5, User: "app", Command: "Sleep", Time: 400, InTransaction: true},
+		{ID: 6, User: "app", Command: "Sleep", Time: 400},
+		{ID: 7, User: "system user", Command: "Query", Time: 10000, Info: "insert into t values (1)"},
+		{ID: 8, User: "xtrabackup", Command: "Query", Time: 10000, Info: "select 1"},
+	}
+
+	kills := queriesToKill(qk, procs)
+
+	expected := map[int64]queryKill{
+		1: {rule: "analytics", query: true},
+		4: {rule: "rule-1", query: true},
+		5: {rule: idleTransactionRule},
+	}
+	if len(kills) != len(expected) {
+		t.Fatalf("expected %d kills, got %+v", len(expected), kills)
+	}
+	for _, k := range kills {
+		e, ok := expected[k.proc.ID]
+		if !ok {
+			t.Errorf("process %d shouldn't be killed", k.proc.ID)
+			continue
+		}
+		if k.rule != e.rule || k.query != e.query {
+			t.Errorf("process %d: expected rule %s query %t, got rule %s query %t", k.proc.ID, e.rule, e.query, k.rule, k.query)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package pxc
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/go-version"
@@ -16,6 +17,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
@@ -145,7 +147,7 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileReplication(ctx context.Context
 
 	port := int32(33062)
 
-	primaryDB, err := queries.New(r.client, cr.Namespace, internalSecretsPrefix+cr.Name, users.Operator, primaryPod.Name+"."+cr.Name+"-pxc."+cr.Namespace, port, cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
+	primaryDB, err := queries.New(r.client, cr.Namespace, users.InternalSecretsPrefix+cr.Name, users.Operator, primaryPod.Name+"."+cr.Name+"-pxc."+cr.Namespace, port, cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
 	if err != nil {
 		return errors.Wrapf(err, "failed to connect to pod %s", primaryPod.Name)
 	}
@@ -171,6 +173,17 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileReplication(ctx context.Context
 		return errors.Wrap(err, "failed to ensure cluster readonly status")
 	}
 
+	promoted, err := r.reconcileReplicationFailover(ctx, cr, primaryDB)
+	if err != nil {
+		return errors.Wrap(err, "replication failover")
+	}
+	if promoted {
+		// replication channels are stopped and read-only mode is disabled on the next reconcile
+		return r.updateStatus(ctx, cr, false, nil)
+	}
+
+	resetReplicationHealth(cr)
+
 	if len(cr.Spec.PXC.ReplicationChannels) == 0 {
 		return deleteReplicaLabels(r.client, podList)
 	}
@@ -185,7 +198,7 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileReplication(ctx context.Context
 			continue
 		}
 		if _, ok := pod.Labels[replicationPodLabel]; ok {
-			db, err := queries.New(r.client, cr.Namespace, internalSecretsPrefix+cr.Name, users.Operator, pod.Name+"."+cr.Name+"-pxc."+cr.Namespace, port, cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
+			db, err := queries.New(r.client, cr.Namespace, users.InternalSecretsPrefix+cr.Name, users.Operator, pod.Name+"."+cr.Name+"-pxc."+cr.Namespace, port, cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
 			if err != nil {
 				return errors.Wrapf(err, "failed to connect to pod %s", pod.Name)
 			}
@@ -211,13 +224,15 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileReplication(ctx context.Context
 			return errors.Wrap(err, "add label to main replica pod")
 		}
 		log.Info("Replication pod has changed", "new replication pod", primaryPod.Name)
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventReplicationSourceChanged,
+			"Pod %s is the primary now and replicates from the source cluster", primaryPod.Name)
 	}
 
 	sysUsersSecretObj := corev1.Secret{}
 	err = r.client.Get(context.TODO(),
 		types.NamespacedName{
 			Namespace: cr.Namespace,
-			Name:      internalSecretsPrefix + cr.Name,
+			Name:      users.InternalSecretsPrefix + cr.Name,
 		},
 		&sysUsersSecretObj,
 	)
@@ -245,12 +260,29 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileReplication(ctx context.Context
 		}
 
 		currConf := currentReplicaConfig(channel.Name, cr.Status.PXCReplication)
+		currFilters := currentReplicationFilters(channel.Name, cr.Status.PXCReplication)
 
-		err = manageReplicationChannel(ctx, primaryDB, channel, currConf, string(sysUsersSecretObj.Data[users.Replication]), shouldGetMasterKey)
+		err = manageReplicationChannel(ctx, primaryDB, channel, currConf, currFilters, string(sysUsersSecretObj.Data[users.Replication]), shouldGetMasterKey)
 		if err != nil {
 			return errors.Wrapf(err, "manage replication channel %s", channel.Name)
 		}
 		setReplicationChannelStatus(cr, channel)
+
+		replicaStatus, err := primaryDB.ShowReplicaStatus(ctx, channel.Name)
+		if err != nil {
+			return errors.Wrapf(err, "get replica status for channel %s", channel.Name)
+		}
+		setReplicationChannelHealth(cr, channel.Name, replicaStatus)
+
+		r.repositionReplicationChannel(ctx, cr, primaryDB, channel, replicaStatus, string(sysUsersSecretObj.Data[users.Replication]), shouldGetMasterKey)
+
+		if err := r.rebuildReplica(ctx, cr, channel, replicaStatus); err != nil {
+			return errors.Wrapf(err, "rebuild replica for channel %s", channel.Name)
+		}
+	}
+
+	if err := r.checkReplicaConsistency(ctx, cr, primaryDB); err != nil {
+		return errors.Wrap(err, "check replica consistency")
 	}
 
 	return r.updateStatus(ctx, cr, false, nil)
@@ -278,9 +310,17 @@ func checkReadonlyStatus(ctx context.Context, channels []api.ReplicationChannel,
 	if len(channels) > 0 {
 		isReplica = !channels[0].IsSource
 	}
+	// the cluster is switched over to another one, writes are not allowed
+	if cr.Annotations[api.AnnotationReadOnly] == "true" {
+		isReplica = true
+	}
+	// a standby applies binlogs of the source until it's promoted
+	if cr.Spec.Standby.IsEnabled() {
+		isReplica = true
+	}
 
 	for _, pod := range pods {
-		db, err := queries.New(client, cr.Namespace, internalSecretsPrefix+cr.Name, users.Operator, pod.Name+"."+cr.Name+"-pxc."+cr.Namespace, 33062, cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
+		db, err := queries.New(client, cr.Namespace, users.InternalSecretsPrefix+cr.Name, users.Operator, pod.Name+"."+cr.Name+"-pxc."+cr.Namespace, 33062, cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
 		if err != nil {
 			return errors.Wrapf(err, "connect to pod %s", pod.Name)
 		}
@@ -360,7 +400,7 @@ func removeOutdatedChannels(ctx context.Context, db queries.Database, currentCha
 	return nil
 }
 
-func manageReplicationChannel(ctx context.Context, primaryDB queries.Database, channel api.ReplicationChannel, currConf api.ReplicationChannelConfig, replicaPW string, shouldGetMasterKey bool) error {
+func manageReplicationChannel(ctx context.Context, primaryDB queries.Database, channel api.ReplicationChannel, currConf api.ReplicationChannelConfig, currFilters *api.ReplicationFilters, replicaPW string, shouldGetMasterKey bool) error {
 	log := logf.FromContext(ctx)
 	currentSources, err := primaryDB.ReplicationChannelSources(channel.Name)
 	if err != nil && err != queries.ErrNotFound {
@@ -383,7 +423,7 @@ func manageReplicationChannel(ctx context.Context, primaryDB queries.Database, c
 		}
 
 		if replicationStatus == queries.ReplicationStatusActive &&
-			*channel.Config == currConf {
+			*channel.Config == currConf && !replicationFiltersChanged(channel.Filters, currFilters) {
 			return nil
 		}
 	}
@@ -415,18 +455,57 @@ func manageReplicationChannel(ctx context.Context, primaryDB queries.Database, c
 		}
 	}
 
-	return primaryDB.StartReplication(replicaPW, queries.ReplicationConfig{
+	config := replicationConfig(channel, maxWeightSrc)
+	if config.Filters == nil && currFilters != nil {
+		// the filters are removed from the spec
+		config.Filters = new(queries.ReplicationFilters)
+	}
+
+	return primaryDB.StartReplication(replicaPW, config, shouldGetMasterKey)
+}
+
+func replicationConfig(channel api.ReplicationChannel, src api.ReplicationSource) queries.ReplicationConfig {
+	return queries.ReplicationConfig{
 		Source: queries.ReplicationChannelSource{
 			Name: channel.Name,
-			Host: maxWeightSrc.Host,
-			Port: maxWeightSrc.Port,
+			Host: src.Host,
+			Port: src.Port,
 		},
-		SourceRetryCount:   channel.Config.SourceRetryCount,
-		SourceConnectRetry: channel.Config.SourceConnectRetry,
-		SSL:                channel.Config.SSL,
-		SSLSkipVerify:      channel.Config.SSLSkipVerify,
-		CA:                 channel.Config.CA,
-	}, shouldGetMasterKey)
+		SourceRetryCount:      channel.Config.SourceRetryCount,
+		SourceConnectRetry:    channel.Config.SourceConnectRetry,
+		SSL:                   channel.Config.SSL,
+		SSLSkipVerify:         channel.Config.SSLSkipVerify,
+		CA:                    channel.Config.CA,
+		SSLCert:               channel.Config.SSLCert,
+		SSLKey:                channel.Config.SSLKey,
+		SSLCipher:             channel.Config.SSLCipher,
+		TLSVersion:            channel.Config.TLSVersion,
+		HeartbeatPeriod:       channel.Config.HeartbeatPeriod,
+		CompressionAlgorithms: channel.Config.CompressionAlgorithms,
+		ZstdCompressionLevel:  channel.Config.ZstdCompressionLevel,
+		Filters:               replicationFilters(channel.Filters),
+	}
+}
+
+func replicationFilters(f *api.ReplicationFilters) *queries.ReplicationFilters {
+	if f == nil {
+		return nil
+	}
+
+	rewrite := make([][2]string, 0, len(f.RewriteDB))
+	for _, r := range f.RewriteDB {
+		rewrite = append(rewrite, [2]string{r.From, r.To})
+	}
+
+	return &queries.ReplicationFilters{
+		DoDB:            f.DoDB,
+		IgnoreDB:        f.IgnoreDB,
+		DoTable:         f.DoTable,
+		IgnoreTable:     f.IgnoreTable,
+		WildDoTable:     f.WildDoTable,
+		WildIgnoreTable: f.WildIgnoreTable,
+		RewriteDB:       rewrite,
+	}
 }
 
 func isSourcesChanged(new []api.ReplicationSource, old []queries.ReplicationChannelSource) bool {
@@ -583,10 +662,36 @@ func currentReplicaConfig(name string, status *api.ReplicationStatus) api.Replic
 	return res
 }
 
+// replicationFiltersChanged compares the rendered filters, so nil and empty filters are equal.
+func replicationFiltersChanged(desired, current *api.ReplicationFilters) bool {
+	query := func(f *api.ReplicationFilters) string {
+		filters := replicationFilters(f)
+		if filters == nil {
+			filters = new(queries.ReplicationFilters)
+		}
+		return filters.ChangeFilterQuery()
+	}
+	return query(desired) != query(current)
+}
+
+func currentReplicationFilters(name string, status *api.ReplicationStatus) *api.ReplicationFilters {
+	if status == nil {
+		return nil
+	}
+
+	for _, v := range status.Channels {
+		if v.Name == name {
+			return v.Filters
+		}
+	}
+	return nil
+}
+
 func setReplicationChannelStatus(cr *api.PerconaXtraDBCluster, channel api.ReplicationChannel) {
 	status := api.ReplicationChannelStatus{
 		Name:                     channel.Name,
 		ReplicationChannelConfig: *channel.Config,
+		Filters:                  channel.Filters.DeepCopy(),
 	}
 
 	if cr.Status.PXCReplication == nil {
@@ -596,12 +701,61 @@ func setReplicationChannelStatus(cr *api.PerconaXtraDBCluster, channel api.Repli
 		return
 	}
 
+	// the rest of the channel status is kept, it's updated separately
 	for k, v := range cr.Status.PXCReplication.Channels {
 		if channel.Name == v.Name {
-			cr.Status.PXCReplication.Channels[k] = status
+			cr.Status.PXCReplication.Channels[k].ReplicationChannelConfig = status.ReplicationChannelConfig
+			cr.Status.PXCReplication.Channels[k].Filters = status.Filters
 			return
 		}
 	}
 
 	cr.Status.PXCReplication.Channels = append(cr.Status.PXCReplication.Channels, status)
 }
+
+// setReplicationChannelHealth updates the channel status and metrics from the SHOW REPLICA STATUS output
+func setReplicationChannelHealth(cr *api.PerconaXtraDBCluster, name string, replicaStatus map[string]string) {
+	for i := range cr.Status.PXCReplication.Channels {
+		ch := &cr.Status.PXCReplication.Channels[i]
+		if ch.Name != name {
+			continue
+		}
+
+		ch.IOThread = replicaStatus["Replica_IO_Running"]
+		ch.SQLThread = replicaStatus["Replica_SQL_Running"]
+		ch.LastError = replicaStatus["Last_IO_Error"]
+		if ch.LastError == "" {
+			ch.LastError = replicaStatus["Last_SQL_Error"]
+		}
+		ch.SecondsBehindSource = nil
+		if v, err := strconv.ParseInt(replicaStatus["Seconds_Behind_Source"], 10, 64); err == nil {
+			ch.SecondsBehindSource = &v
+		}
+
+		metrics.SetReplicationStatus(cr.Namespace, cr.Name, ch)
+		return
+	}
+}
+
+// resetReplicationHealth clears the health of status channels the cluster doesn't replicate from anymore
+func resetReplicationHealth(cr *api.PerconaXtraDBCluster) {
+	if cr.Status.PXCReplication == nil {
+		return
+	}
+
+	replicated := make(map[string]bool)
+	for _, channel := range cr.Spec.PXC.ReplicationChannels {
+		if !channel.IsSource {
+			replicated[channel.Name] = true
+		}
+	}
+
+	for i := range cr.Status.PXCReplication.Channels {
+		ch := &cr.Status.PXCReplication.Channels[i]
+		if replicated[ch.Name] {
+			continue
+		}
+		ch.IOThread, ch.SQLThread, ch.LastError, ch.SecondsBehindSource = "", "", "", nil
+		metrics.DeleteReplicationStatus(cr.Namespace, cr.Name, ch.Name)
+	}
+}
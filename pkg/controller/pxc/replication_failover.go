@@ -0,0 +1,219 @@
+package pxc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/audit"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+const (
+	// maxFailoverRecords limits the failover trail kept in the cluster status.
+	maxFailoverRecords = 10
+
+	sourceDialTimeout = 5 * time.Second
+)
+
+// reconcileReplicationFailover promotes the replica cluster if replication of a channel
+// with enabled failover is broken and all its sources are unreachable for longer than
+// failover.sourceDownSeconds. It returns true if the cluster was promoted.
+func (r *ReconcilePerconaXtraDBCluster) reconcileReplicationFailover(ctx context.Context, cr *api.PerconaXtraDBCluster, primaryDB queries.Database) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	for _, channel := range cr.Spec.PXC.ReplicationChannels {
+		if channel.IsSource || channel.Failover == nil || !channel.Failover.Enabled {
+			continue
+		}
+
+		replicationStatus, err := primaryDB.ReplicationStatus(ctx, channel.Name)
+		if err != nil {
+			return false, errors.Wrapf(err, "get replication status for channel %s", channel.Name)
+		}
+
+		var unreachable []string
+		if replicationStatus == queries.ReplicationStatusError {
+			unreachable = unreachableSources(channel.SourcesList)
+		}
+
+		chStatus := replicationChannelStatus(cr, channel)
+		if len(unreachable) < len(channel.SourcesList) {
+			if chStatus.SourceDownSince != nil {
+				log.Info("Replication source is reachable again", "channel", channel.Name)
+				addFailoverRecord(cr, channel.Name, "sources are reachable again", "", false)
+				chStatus.SourceDownSince = nil
+			}
+			continue
+		}
+
+		reason := "replication is broken and sources are unreachable: " + strings.Join(unreachable, "; ")
+		if chStatus.SourceDownSince == nil {
+			now := metav1.Now()
+			chStatus.SourceDownSince = &now
+
+			log.Info("Replication source is down", "channel", channel.Name, "failoverAfterSeconds", channel.Failover.SourceDownSeconds)
+			r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventReplicationSourceDown,
+				"Sources of replication channel %s are down, the cluster will be promoted in %ds", channel.Name, channel.Failover.SourceDownSeconds)
+			addFailoverRecord(cr, channel.Name, reason, "", false)
+			continue
+		}
+
+		downFor := time.Since(chStatus.SourceDownSince.Time).Round(time.Second)
+		if downFor < time.Duration(channel.Failover.SourceDownSeconds)*time.Second {
+			continue
+		}
+
+		fencing := ""
+		if channel.Failover.Fence {
+			fencing = r.fenceSources(ctx, cr, channel)
+		}
+
+		if err := r.promoteCluster(ctx, cr, channel); err != nil {
+			return false, errors.Wrap(err, "promote cluster")
+		}
+
+		log.Info("Cluster is promoted", "channel", channel.Name, "sourceDownFor", downFor.String(), "fencing", fencing)
+		r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventReplicationFailover,
+			"Cluster is promoted, sources of replication channel %s were down for %s", channel.Name, downFor)
+		addFailoverRecord(cr, channel.Name, fmt.Sprintf("%s for %s", reason, downFor), fencing, true)
+		audit.RecordOperation(ctx, r.client, cr.Namespace, cr.Name, audit.OperationEntry{
+			Operation: audit.OperationFailover,
+			Result:    audit.OperationSucceeded,
+			Object:    channel.Name,
+			Message:   fmt.Sprintf("cluster is promoted, %s for %s", reason, downFor),
+		})
+		chStatus.SourceDownSince = nil
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// promoteCluster makes the cluster a replication source and
+// adds the promoted service annotations to the exposed services.
+func (r *ReconcilePerconaXtraDBCluster) promoteCluster(ctx context.Context, cr *api.PerconaXtraDBCluster, channel api.ReplicationChannel) error {
+	promote := func(c *api.PerconaXtraDBCluster) {
+		c.Spec.PXC.ReplicationChannels = []api.ReplicationChannel{{Name: channel.Name, IsSource: true}}
+
+		for k, v := range channel.Failover.PromotedServiceAnnotations {
+			if c.Spec.PXC.Expose.Enabled {
+				setAnnotation(&c.Spec.PXC.Expose.Annotations, k, v)
+			}
+			if c.Spec.HAProxy != nil && c.Spec.HAProxy.Enabled {
+				setAnnotation(&c.Spec.HAProxy.ExposePrimary.Annotations, k, v)
+			}
+			if c.Spec.ProxySQL != nil && c.Spec.ProxySQL.Enabled {
+				setAnnotation(&c.Spec.ProxySQL.Expose.Annotations, k, v)
+			}
+		}
+	}
+
+	c := new(api.PerconaXtraDBCluster)
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(cr), c); err != nil {
+		return errors.Wrap(err, "get cluster")
+	}
+	patch := client.MergeFrom(c.DeepCopy())
+	promote(c)
+	if err := r.client.Patch(ctx, c, patch); err != nil {
+		return errors.Wrap(err, "patch cluster")
+	}
+
+	promote(cr)
+
+	return nil
+}
+
+// fenceSources makes the reachable sources of the channel read-only
+// and returns the outcome for every source.
+func (r *ReconcilePerconaXtraDBCluster) fenceSources(ctx context.Context, cr *api.PerconaXtraDBCluster, channel api.ReplicationChannel) string {
+	log := logf.FromContext(ctx)
+
+	results := make([]string, 0, len(channel.SourcesList))
+	for _, src := range channel.SourcesList {
+		addr := net.JoinHostPort(src.Host, strconv.Itoa(src.Port))
+
+		db, err := queries.New(r.client, cr.Namespace, users.InternalSecretsPrefix+cr.Name, users.Operator, src.Host, int32(src.Port), cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
+		if err == nil {
+			err = db.EnableReadonly()
+			db.Close()
+		}
+		if err != nil {
+			log.Info("Failed to fence replication source", "channel", channel.Name, "source", addr, "error", err.Error())
+			results = append(results, fmt.Sprintf("%s: %v", addr, err))
+			continue
+		}
+		results = append(results, addr+": read-only")
+	}
+
+	return strings.Join(results, "; ")
+}
+
+func unreachableSources(sources []api.ReplicationSource) []string {
+	var unreachable []string
+	for _, src := range sources {
+		addr := net.JoinHostPort(src.Host, strconv.Itoa(src.Port))
+		conn, err := net.DialTimeout("tcp", addr, sourceDialTimeout)
+		if err != nil {
+			unreachable = append(unreachable, err.Error())
+			continue
+		}
+		conn.Close()
+	}
+	return unreachable
+}
+
+// replicationChannelStatus returns the status of the channel, adding it if it's missing.
+// The configuration of an added channel is left empty until it's applied.
+func replicationChannelStatus(cr *api.PerconaXtraDBCluster, channel api.ReplicationChannel) *api.ReplicationChannelStatus {
+	if cr.Status.PXCReplication == nil {
+		cr.Status.PXCReplication = new(api.ReplicationStatus)
+	}
+
+	for i := range cr.Status.PXCReplication.Channels {
+		if cr.Status.PXCReplication.Channels[i].Name == channel.Name {
+			return &cr.Status.PXCReplication.Channels[i]
+		}
+	}
+
+	cr.Status.PXCReplication.Channels = append(cr.Status.PXCReplication.Channels, api.ReplicationChannelStatus{Name: channel.Name})
+	return &cr.Status.PXCReplication.Channels[len(cr.Status.PXCReplication.Channels)-1]
+}
+
+func addFailoverRecord(cr *api.PerconaXtraDBCluster, channel, reason, fencing string, promoted bool) {
+	if cr.Status.PXCReplication == nil {
+		cr.Status.PXCReplication = new(api.ReplicationStatus)
+	}
+
+	records := append(cr.Status.PXCReplication.Failovers, api.ReplicationFailoverRecord{
+		Channel:  channel,
+		Time:     metav1.Now(),
+		Reason:   reason,
+		Fencing:  fencing,
+		Promoted: promoted,
+	})
+	if len(records) > maxFailoverRecords {
+		records = records[len(records)-maxFailoverRecords:]
+	}
+	cr.Status.PXCReplication.Failovers = records
+}
+
+func setAnnotation(annotations *map[string]string, key, value string) {
+	if *annotations == nil {
+		*annotations = make(map[string]string)
+	}
+	(*annotations)[key] = value
+}
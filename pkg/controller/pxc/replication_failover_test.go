@@ -0,0 +1,94 @@
+package pxc
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestPromoteCluster(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	channel := api.ReplicationChannel{
+		Name:        "dr",
+		SourcesList: []api.ReplicationSource{{Host: "primary.example.com", Port: 3306, Weight: 100}},
+		Failover: &api.ReplicationFailover{
+			Enabled:                    true,
+			PromotedServiceAnnotations: map[string]string{"external-dns.alpha.kubernetes.io/hostname": "db.example.com"},
+		},
+	}
+	cr.Spec.PXC.ReplicationChannels = []api.ReplicationChannel{channel}
+
+	r := buildFakeClient([]runtime.Object{cr.DeepCopy()})
+
+	if err := r.promoteCluster(ctx, cr, channel); err != nil {
+		t.Fatal(err)
+	}
+
+	promoted := new(api.PerconaXtraDBCluster)
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(cr), promoted); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []*api.PerconaXtraDBCluster{cr, promoted} {
+		channels := c.Spec.PXC.ReplicationChannels
+		if len(channels) != 1 || !channels[0].IsSource || channels[0].Name != "dr" || len(channels[0].SourcesList) != 0 {
+			t.Fatalf("unexpected replication channels: %+v", channels)
+		}
+		if c.Spec.HAProxy.ExposePrimary.Annotations["external-dns.alpha.kubernetes.io/hostname"] != "db.example.com" {
+			t.Fatalf("haproxy service isn't annotated: %v", c.Spec.HAProxy.ExposePrimary.Annotations)
+		}
+		if c.Spec.ProxySQL.Expose.Annotations != nil {
+			t.Fatalf("disabled proxysql service is annotated: %v", c.Spec.ProxySQL.Expose.Annotations)
+		}
+	}
+}
+
+func TestUnreachableSources(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	port := l.Addr().(*net.TCPAddr).Port
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedPort := closed.Addr().(*net.TCPAddr).Port
+	closed.Close()
+
+	sources := []api.ReplicationSource{
+		{Host: "127.0.0.1", Port: port},
+		{Host: "127.0.0.1", Port: closedPort},
+	}
+
+	unreachable := unreachableSources(sources)
+	if len(unreachable) != 1 {
+		t.Fatalf("expected only port %s to be unreachable, got %v", strconv.Itoa(closedPort), unreachable)
+	}
+}
+
+func TestAddFailoverRecord(t *testing.T) {
+	cr := newCR("cr-mock", "pxc")
+
+	for i := 0; i < maxFailoverRecords+3; i++ {
+		addFailoverRecord(cr, "dr", strconv.Itoa(i), "", false)
+	}
+
+	records := cr.Status.PXCReplication.Failovers
+	if len(records) != maxFailoverRecords {
+		t.Fatalf("expected %d records, got %d", maxFailoverRecords, len(records))
+	}
+	if records[0].Reason != "3" {
+		t.Fatalf("expected the oldest records to be dropped, first record is %q", records[0].Reason)
+	}
+}
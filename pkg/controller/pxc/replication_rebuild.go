@@ -0,0 +1,204 @@
+package pxc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+)
+
+// backupTimeFormat is the creation time format in backup names, see the backup controller.
+const backupTimeFormat = "2006-01-02-15:04:05"
+
+// rebuildReplica restores the cluster from the latest backup of the source cluster
+// if replication of the channel is unrecoverable. Replication is resumed by the
+// following reconciles once the restored cluster is ready.
+func (r *ReconcilePerconaXtraDBCluster) rebuildReplica(ctx context.Context, cr *api.PerconaXtraDBCluster, channel api.ReplicationChannel, replicaStatus map[string]string) error {
+	log := logf.FromContext(ctx)
+
+	rebuild := channel.Rebuild
+	if rebuild == nil || !rebuild.Enabled {
+		return nil
+	}
+
+	chStatus := replicationChannelStatus(cr, channel)
+	if chStatus.RebuildRestore != "" {
+		restore := new(api.PerconaXtraDBClusterRestore)
+		err := r.client.Get(ctx, types.NamespacedName{Name: chStatus.RebuildRestore, Namespace: cr.Namespace}, restore)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "get restore %s", chStatus.RebuildRestore)
+		}
+
+		switch {
+		case k8serrors.IsNotFound(err):
+			log.Info("Rebuild restore is deleted", "restore", chStatus.RebuildRestore)
+		case restore.Status.State == api.RestoreSucceeded:
+			log.Info("Cluster is rebuilt from backup", "channel", channel.Name, "backup", chStatus.LastRebuildBackup)
+			r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventReplicationRebuilt,
+				"Cluster is restored from backup %s, replication channel %s is resumed", chStatus.LastRebuildBackup, channel.Name)
+			chStatus.RepositionAttempts = 0
+			chStatus.LastRepositionTime = nil
+		default:
+			// the restore is running or failed and has to be deleted to rebuild the cluster again
+			return nil
+		}
+		chStatus.RebuildRestore = ""
+		return nil
+	}
+
+	if !chStatus.Broken() || !unrecoverableReplication(channel, chStatus, replicaStatus) {
+		return nil
+	}
+
+	for _, ch := range cr.Status.PXCReplication.Channels {
+		if ch.RebuildRestore != "" {
+			// the cluster is already rebuilt for another channel
+			return nil
+		}
+	}
+
+	bcp, err := r.latestSourceBackup(ctx, cr, rebuild.StorageName, rebuild.SourceCluster)
+	if err != nil {
+		return errors.Wrap(err, "get latest backup of the source cluster")
+	}
+	if bcp == "" || bcp == chStatus.LastRebuildBackup {
+		log.Info("Replication is unrecoverable, but there is no new backup to rebuild the cluster from", "channel", channel.Name, "lastRebuildBackup", chStatus.LastRebuildBackup)
+		return nil
+	}
+
+	restore, err := sourceBackupRestore(cr, rebuild.StorageName, bcp, "rebuild")
+	if err != nil {
+		return errors.Wrap(err, "build restore")
+	}
+	if err := r.client.Create(ctx, restore); err != nil {
+		return errors.Wrap(err, "create restore")
+	}
+
+	chStatus.RebuildRestore = restore.Name
+	chStatus.LastRebuildBackup = bcp
+
+	log.Info("Rebuilding the cluster from backup of the source cluster", "channel", channel.Name, "backup", bcp, "restore", restore.Name, "error", chStatus.LastError)
+	r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventReplicationRebuildStarted,
+		"Replication channel %s is unrecoverable, the cluster is restored from backup %s: %s", channel.Name, bcp, chStatus.LastError)
+
+	return nil
+}
+
+// unrecoverableReplication returns true if the source purged binary logs the replica needs
+// or the channel is still broken after all repositioning attempts.
+func unrecoverableReplication(channel api.ReplicationChannel, chStatus *api.ReplicationChannelStatus, replicaStatus map[string]string) bool {
+	if recoverableReplicationErrors["Last_IO_Errno"][replicaStatus["Last_IO_Errno"]] &&
+		strings.Contains(replicaStatus["Last_IO_Error"], "purged") {
+		return true
+	}
+
+	reposition := channel.Reposition
+	return reposition != nil && reposition.Enabled && chStatus.RepositionAttempts >= reposition.MaxAttempts
+}
+
+func (r *ReconcilePerconaXtraDBCluster) latestSourceBackup(ctx context.Context, cr *api.PerconaXtraDBCluster, storageName, sourceCluster string) (string, error) {
+	opts, err := storage.GetOptions(ctx, r.client, cr, storageName)
+	if err != nil {
+		return "", errors.Wrap(err, "get storage options")
+	}
+
+	newClient := r.newStorageClient
+	if newClient == nil {
+		newClient = storage.NewClient
+	}
+	stg, err := newClient(ctx, opts)
+	if err != nil {
+		return "", errors.Wrap(err, "new storage client")
+	}
+
+	objects, err := stg.ListObjects(ctx, sourceCluster+"-")
+	if err != nil {
+		return "", errors.Wrap(err, "list backups")
+	}
+
+	return latestBackupName(objects, sourceCluster), nil
+}
+
+// latestBackupName returns the newest complete backup of the cluster.
+// Backups are named <cluster>-<creation time>-full, the .md5 file is uploaded once the backup is complete.
+func latestBackupName(objects []string, cluster string) string {
+	latest, latestTime := "", time.Time{}
+	for _, o := range objects {
+		name, ok := strings.CutSuffix(o, ".md5")
+		if !ok {
+			continue
+		}
+		t, ok := backupCreationTime(name, cluster)
+		if !ok || !t.After(latestTime) {
+			continue
+		}
+		latest, latestTime = name, t
+	}
+	return latest
+}
+
+// backupCreationTime parses the creation time from the name of a full backup of the cluster.
+func backupCreationTime(name, cluster string) (time.Time, bool) {
+	name, ok := strings.CutSuffix(name, "-full")
+	if !ok {
+		return time.Time{}, false
+	}
+	created, ok := strings.CutPrefix(name, cluster+"-")
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(backupTimeFormat, created)
+	return t, err == nil
+}
+
+// sourceBackupRestore restores the cluster from a backup of another cluster in the storage.
+func sourceBackupRestore(cr *api.PerconaXtraDBCluster, storageName, backupName, purpose string) (*api.PerconaXtraDBClusterRestore, error) {
+	source, err := sourceBackupStatus(cr, storageName, backupName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &api.PerconaXtraDBClusterRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-" + purpose + "-" + time.Now().UTC().Format("20060102150405"),
+			Namespace: cr.Namespace,
+			Labels:    naming.LabelsCluster(cr),
+		},
+		Spec: api.PerconaXtraDBClusterRestoreSpec{
+			PXCCluster:   cr.Name,
+			BackupSource: source,
+		},
+	}, nil
+}
+
+func sourceBackupStatus(cr *api.PerconaXtraDBCluster, storageName, backupName string) (*api.PXCBackupStatus, error) {
+	stg := cr.Spec.Backup.Storages[storageName]
+
+	source := &api.PXCBackupStatus{
+		StorageName: storageName,
+		StorageType: stg.Type,
+		VerifyTLS:   stg.VerifyTLS,
+	}
+	switch stg.Type {
+	case api.BackupStorageS3:
+		source.S3 = stg.S3.DeepCopy()
+		source.Destination.SetS3Destination(stg.S3.Bucket, backupName)
+	case api.BackupStorageAzure:
+		source.Azure = stg.Azure.DeepCopy()
+		source.Destination.SetAzureDestination(stg.Azure.ContainerPath, backupName)
+	default:
+		return nil, errors.Errorf("unsupported storage type %s", stg.Type)
+	}
+
+	return source, nil
+}
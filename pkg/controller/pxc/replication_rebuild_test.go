@@ -0,0 +1,100 @@
+package pxc
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage/fake"
+)
+
+type backupListStorage struct {
+	fake.FakeStorageClient
+	objects []string
+}
+
+func (s *backupListStorage) ListObjects(_ context.Context, _ string) ([]string, error) {
+	return s.objects, nil
+}
+
+func TestLatestBackupName(t *testing.T) {
+	objects := []string{
+		"src-2026-10-01-03:00:00-full.md5",
+		"src-2026-10-01-03:00:00-full/xtrabackup_checkpoints.00000000000000000000",
+		// the newest backup isn't complete
+		"src-2026-10-03-03:00:00-full/xtrabackup_checkpoints.00000000000000000000",
+		"src-2026-10-02-03:00:00-full.md5",
+		"src-2-2026-10-04-03:00:00-full.md5",
+	}
+
+	if name := latestBackupName(objects, "src"); name != "src-2026-10-02-03:00:00-full" {
+		t.Fatalf("unexpected latest backup %s", name)
+	}
+}
+
+func TestRebuildReplica(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.Backup = &api.PXCScheduledBackup{
+		Storages: map[string]*api.BackupStorageSpec{
+			"source-s3": {
+				Type: api.BackupStorageS3,
+				S3:   &api.BackupStorageS3Spec{Bucket: "backups/source", CredentialsSecret: "s3-secret"},
+			},
+		},
+	}
+	channel := api.ReplicationChannel{
+		Name:    "dr",
+		Rebuild: &api.ReplicationRebuild{Enabled: true, SourceCluster: "src", StorageName: "source-s3"},
+	}
+	cr.Status.PXCReplication = &api.ReplicationStatus{Channels: []api.ReplicationChannelStatus{{
+		Name:      "dr",
+		IOThread:  "No",
+		SQLThread: "Yes",
+		LastError: "Got fatal error 1236 from source: Cannot replicate because the source purged required binary logs",
+	}}}
+	replicaStatus := map[string]string{
+		"Last_IO_Errno": "13114",
+		"Last_IO_Error": cr.Status.PXCReplication.Channels[0].LastError,
+	}
+
+	r := buildFakeClient([]runtime.Object{cr.DeepCopy()})
+	r.newStorageClient = func(context.Context, storage.Options) (storage.Storage, error) {
+		return &backupListStorage{objects: []string{"src-2026-10-01-03:00:00-full.md5"}}, nil
+	}
+
+	if err := r.rebuildReplica(ctx, cr, channel, replicaStatus); err != nil {
+		t.Fatal(err)
+	}
+
+	chStatus := cr.Status.PXCReplication.Channels[0]
+	if chStatus.RebuildRestore == "" || chStatus.LastRebuildBackup != "src-2026-10-01-03:00:00-full" {
+		t.Fatalf("unexpected channel status: %+v", chStatus)
+	}
+
+	restore := new(api.PerconaXtraDBClusterRestore)
+	if err := r.client.Get(ctx, client.ObjectKey{Name: chStatus.RebuildRestore, Namespace: cr.Namespace}, restore); err != nil {
+		t.Fatal(err)
+	}
+	if dest := restore.Spec.BackupSource.Destination.String(); dest != "s3://backups/source/src-2026-10-01-03:00:00-full" {
+		t.Fatalf("unexpected restore destination %s", dest)
+	}
+
+	// the same backup isn't restored twice
+	if err := r.client.Delete(ctx, restore); err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		if err := r.rebuildReplica(ctx, cr, channel, replicaStatus); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if cr.Status.PXCReplication.Channels[0].RebuildRestore != "" {
+		t.Fatalf("the cluster is rebuilt from the same backup twice")
+	}
+}
@@ -0,0 +1,119 @@
+package pxc
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
+)
+
+// recoverableReplicationErrors are replica errors that can be fixed by
+// re-pointing the channel to a source with GTID auto-positioning.
+var recoverableReplicationErrors = map[string]map[string]bool{
+	"Last_IO_Errno": {
+		"1236":  true, // fatal error reading binlog from the source, e.g. the source was restarted or failed over
+		"13114": true, // the same as 1236 in 8.0
+		"2003":  true, // can't connect to the source, connection retries are exhausted
+		"2013":  true, // lost connection to the source
+	},
+	"Last_SQL_Errno": {
+		"1594":  true, // relay log read failure
+		"13121": true, // relay log read failure in 8.0
+	},
+}
+
+func recoverableReplicationError(replicaStatus map[string]string) bool {
+	for key, errnos := range recoverableReplicationErrors {
+		if errnos[replicaStatus[key]] {
+			return true
+		}
+	}
+	return false
+}
+
+// repositionReplicationChannel re-points the broken channel to the next source by weight
+// if it's broken with a recoverable error. Attempts are made with exponential backoff
+// until reposition.maxAttempts is reached, the counter is reset once the channel is running.
+func (r *ReconcilePerconaXtraDBCluster) repositionReplicationChannel(ctx context.Context, cr *api.PerconaXtraDBCluster, primaryDB queries.Database, channel api.ReplicationChannel, replicaStatus map[string]string, replicaPW string, shouldGetMasterKey bool) {
+	log := logf.FromContext(ctx)
+
+	reposition := channel.Reposition
+	if reposition == nil || !reposition.Enabled || len(channel.SourcesList) == 0 {
+		return
+	}
+
+	chStatus := replicationChannelStatus(cr, channel)
+	if !chStatus.Broken() {
+		if chStatus.RepositionAttempts > 0 && chStatus.IOThread == "Yes" && chStatus.SQLThread == "Yes" {
+			log.Info("Replication channel is running after repositioning", "channel", channel.Name, "attempts", chStatus.RepositionAttempts)
+			chStatus.RepositionAttempts = 0
+			chStatus.LastRepositionTime = nil
+		}
+		return
+	}
+
+	if chStatus.RepositionAttempts >= reposition.MaxAttempts || !recoverableReplicationError(replicaStatus) {
+		return
+	}
+
+	if chStatus.LastRepositionTime != nil && time.Since(chStatus.LastRepositionTime.Time) < repositionBackoff(reposition, chStatus.RepositionAttempts) {
+		return
+	}
+
+	sources := make([]api.ReplicationSource, len(channel.SourcesList))
+	copy(sources, channel.SourcesList)
+	sort.SliceStable(sources, func(i, j int) bool { return sources[i].Weight > sources[j].Weight })
+	src := sources[int(chStatus.RepositionAttempts)%len(sources)]
+
+	now := metav1.Now()
+	chStatus.RepositionAttempts++
+	chStatus.LastRepositionTime = &now
+
+	log.Info("Repositioning broken replication channel", "channel", channel.Name, "source", src.Host,
+		"attempt", chStatus.RepositionAttempts, "maxAttempts", reposition.MaxAttempts, "error", chStatus.LastError)
+
+	err := repositionChannel(primaryDB, replicationConfig(channel, src), replicaPW, shouldGetMasterKey)
+	if err == nil {
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventReplicationRepositioned,
+			"Replication channel %s is repositioned to %s (attempt %d of %d)", channel.Name, src.Host, chStatus.RepositionAttempts, reposition.MaxAttempts)
+		return
+	}
+
+	log.Error(err, "failed to reposition replication channel", "channel", channel.Name)
+	if chStatus.RepositionAttempts == reposition.MaxAttempts {
+		r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventReplicationRepositionFailed,
+			"Failed to reposition replication channel %s after %d attempts, giving up: %v", channel.Name, chStatus.RepositionAttempts, err)
+		return
+	}
+	r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventReplicationRepositionFailed,
+		"Failed to reposition replication channel %s (attempt %d of %d): %v", channel.Name, chStatus.RepositionAttempts, reposition.MaxAttempts, err)
+}
+
+// repositionBackoff returns the delay after the given number of attempts.
+func repositionBackoff(reposition *api.ReplicationReposition, attempts int32) time.Duration {
+	backoff := time.Duration(reposition.BackoffSeconds) * time.Second
+	for i := int32(1); i < attempts && backoff < time.Hour; i++ {
+		backoff *= 2
+	}
+	return backoff
+}
+
+// repositionChannel restarts the channel from scratch, the replica requests
+// transactions missing in its gtid_executed set from the source.
+func repositionChannel(db queries.Database, config queries.ReplicationConfig, replicaPW string, shouldGetMasterKey bool) error {
+	if err := db.StopReplication(config.Source.Name); err != nil {
+		return err
+	}
+	if err := db.ResetReplication(config.Source.Name); err != nil {
+		return err
+	}
+	return errors.Wrap(db.StartReplication(replicaPW, config, shouldGetMasterKey), "start replication")
+}
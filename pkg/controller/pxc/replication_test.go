@@ -0,0 +1,100 @@
+package pxc
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestReplicationChannelHealth(t *testing.T) {
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.PXC.ReplicationChannels = []api.ReplicationChannel{
+		{Name: "dr", Config: &api.ReplicationChannelConfig{SourceRetryCount: 3, SourceConnectRetry: 60}},
+	}
+	cr.Status.PXCReplication = &api.ReplicationStatus{Channels: []api.ReplicationChannelStatus{{Name: "removed", IOThread: "No", SQLThread: "Yes", LastError: "error"}}}
+
+	setReplicationChannelStatus(cr, cr.Spec.PXC.ReplicationChannels[0])
+	setReplicationChannelHealth(cr, "dr", map[string]string{
+		"Replica_IO_Running":    "Yes",
+		"Replica_SQL_Running":   "Yes",
+		"Seconds_Behind_Source": "42",
+	})
+	resetReplicationHealth(cr)
+
+	removed, dr := cr.Status.PXCReplication.Channels[0], cr.Status.PXCReplication.Channels[1]
+	if removed.Broken() || removed.IOThread != "" || removed.LastError != "" {
+		t.Errorf("health of the removed channel isn't reset: %+v", removed)
+	}
+	if dr.Broken() || dr.SecondsBehindSource == nil || *dr.SecondsBehindSource != 42 {
+		t.Errorf("unexpected channel health: %+v", dr)
+	}
+
+	setReplicationChannelHealth(cr, "dr", map[string]string{
+		"Replica_IO_Running":    "Yes",
+		"Replica_SQL_Running":   "No",
+		"Last_SQL_Error":        "Duplicate entry",
+		"Seconds_Behind_Source": "",
+	})
+	dr = cr.Status.PXCReplication.Channels[1]
+	if !dr.Broken() || dr.LastError != "Duplicate entry" || dr.SecondsBehindSource != nil {
+		t.Errorf("unexpected channel health: %+v", dr)
+	}
+}
+
+func TestRecoverableReplicationError(t *testing.T) {
+	cases := []struct {
+		status      map[string]string
+		recoverable bool
+	}{
+		{map[string]string{"Last_IO_Errno": "13114", "Last_SQL_Errno": "0"}, true},
+		{map[string]string{"Last_IO_Errno": "0", "Last_SQL_Errno": "13121"}, true},
+		{map[string]string{"Last_IO_Errno": "0", "Last_SQL_Errno": "1062"}, false},
+		{map[string]string{"Last_IO_Errno": "0", "Last_SQL_Errno": "0"}, false},
+	}
+
+	for _, c := range cases {
+		if recoverableReplicationError(c.status) != c.recoverable {
+			t.Errorf("status %v: expected recoverable %t", c.status, c.recoverable)
+		}
+	}
+}
+
+func TestRepositionBackoff(t *testing.T) {
+	reposition := &api.ReplicationReposition{BackoffSeconds: 30}
+
+	for attempts, expected := range map[int32]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		20: 64 * time.Minute,
+	} {
+		if backoff := repositionBackoff(reposition, attempts); backoff != expected {
+			t.Errorf("attempts %d: expected backoff %s, got %s", attempts, expected, backoff)
+		}
+	}
+}
+
+func TestReplicationFilters(t *testing.T) {
+	filters := &api.ReplicationFilters{
+		DoDB:        []string{"app"},
+		IgnoreTable: []string{"app.sessions"},
+		WildDoTable: []string{"app.audit_%"},
+		RewriteDB:   []api.ReplicationRewriteDB{{From: "app", To: "app_dr"}},
+	}
+
+	expected := "CHANGE REPLICATION FILTER REPLICATE_DO_DB = (`app`), REPLICATE_IGNORE_DB = (), " +
+		"REPLICATE_DO_TABLE = (), REPLICATE_IGNORE_TABLE = (`app`.`sessions`), " +
+		"REPLICATE_WILD_DO_TABLE = ('app.audit_%'), REPLICATE_WILD_IGNORE_TABLE = (), " +
+		"REPLICATE_REWRITE_DB = ((`app`, `app_dr`))"
+	if q := replicationFilters(filters).ChangeFilterQuery(); q != expected {
+		t.Errorf("unexpected query:\n%s\nexpected:\n%s", q, expected)
+	}
+
+	if replicationFiltersChanged(nil, &api.ReplicationFilters{DoDB: []string{}}) {
+		t.Error("empty filters are changed")
+	}
+	if !replicationFiltersChanged(filters, nil) {
+		t.Error("added filters aren't changed")
+	}
+}
@@ -0,0 +1,46 @@
+package pxc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc"
+)
+
+// reconcileReporting manages the service of the reporting pod.
+// The pod itself is configured by the PXC statefulset, HAProxy and ProxySQL
+// exclude it from their backends by the REPORTING_NODE variable.
+func (r *ReconcilePerconaXtraDBCluster) reconcileReporting(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	reporting := cr.Spec.PXC.Reporting
+	if !reporting.IsEnabled() {
+		return r.deleteReportingService(ctx, cr)
+	}
+
+	svc := pxc.NewServiceReporting(cr)
+	return errors.Wrap(r.createOrUpdateService(ctx, cr, svc, len(reporting.Expose.Annotations) == 0), "create or update reporting service")
+}
+
+func (r *ReconcilePerconaXtraDBCluster) deleteReportingService(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	svc := new(corev1.Service)
+	err := r.client.Get(ctx, types.NamespacedName{Name: naming.ReportingServiceName(cr), Namespace: cr.Namespace}, svc)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "get reporting service")
+	}
+
+	if !metav1.IsControlledBy(svc, cr) {
+		return nil
+	}
+
+	return errors.Wrap(client.IgnoreNotFound(r.client.Delete(ctx, svc)), "delete reporting service")
+}
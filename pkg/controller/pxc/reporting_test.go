@@ -0,0 +1,46 @@
+package pxc
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/version"
+)
+
+func TestReconcileReporting(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.CRVersion = version.Version
+	cr.Spec.PXC.Reporting = &api.ReportingSpec{Enabled: true}
+
+	r := buildFakeClient([]runtime.Object{cr})
+
+	if err := r.reconcileReporting(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+
+	nn := types.NamespacedName{Name: naming.ReportingServiceName(cr), Namespace: cr.Namespace}
+	svc := new(corev1.Service)
+	if err := r.client.Get(ctx, nn, svc); err != nil {
+		t.Fatal(err)
+	}
+	if pod := svc.Spec.Selector["statefulset.kubernetes.io/pod-name"]; pod != "cr-mock-pxc-2" {
+		t.Errorf("expected the service to select the last PXC pod, got %q", pod)
+	}
+
+	cr.Spec.PXC.Reporting.Enabled = false
+	if err := r.reconcileReporting(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.client.Get(ctx, nn, svc); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the service to be deleted, got %v", err)
+	}
+}
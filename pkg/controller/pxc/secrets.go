@@ -2,28 +2,33 @@ package pxc
 
 import (
 	"context"
-	"crypto/rand"
 	"fmt"
-	"math/big"
-	mrand "math/rand"
 	"strings"
-	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	k8serror "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
 )
 
-const internalSecretsPrefix = "internal-"
+// secretSysUsers are the users which must have a password in the users secret
+var secretSysUsers = []string{users.Root, users.Xtrabackup, users.Monitor, users.ProxyAdmin, users.Operator, users.Replication}
 
 func (r *ReconcilePerconaXtraDBCluster) reconcileUsersSecret(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	if cr.Spec.ExternalUsersSecret {
+		return r.checkExternalUsersSecret(ctx, cr)
+	}
+
 	log := logf.FromContext(ctx)
 
 	secretObj := new(corev1.Secret)
@@ -38,7 +43,7 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileUsersSecret(ctx context.Context
 		if err := validatePasswords(secretObj); err != nil {
 			return errors.Wrap(err, "validate passwords")
 		}
-		isChanged, err := setUserSecretDefaults(secretObj)
+		isChanged, err := setUserSecretDefaults(secretObj, cr.Spec.PXC.PasswordPolicy)
 		if err != nil {
 			return errors.Wrap(err, "set user secret defaults")
 		}
@@ -54,6 +59,10 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileUsersSecret(ctx context.Context
 		return errors.Wrap(err, "get secret")
 	}
 
+	if err := r.checkUsersSecretRenamed(ctx, cr); err != nil {
+		return err
+	}
+
 	secretObj = &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cr.Spec.SecretsName,
@@ -63,7 +72,7 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileUsersSecret(ctx context.Context
 		Type: corev1.SecretTypeOpaque,
 	}
 
-	if _, err = setUserSecretDefaults(secretObj); err != nil {
+	if _, err = setUserSecretDefaults(secretObj, cr.Spec.PXC.PasswordPolicy); err != nil {
 		return errors.Wrap(err, "set user secret defaults")
 	}
 
@@ -76,14 +85,104 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileUsersSecret(ctx context.Context
 	return nil
 }
 
-func setUserSecretDefaults(secret *corev1.Secret) (isChanged bool, err error) {
+// checkUsersSecretRenamed refuses to generate a new users secret for a running cluster.
+// The new passwords would replace the passwords of all system users, so secretsName
+// can be changed only with the allow-unsafe-changes annotation.
+func (r *ReconcilePerconaXtraDBCluster) checkUsersSecretRenamed(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	if cr.Status.Status == "" || cr.UnsafeChangesAllowed() {
+		return nil
+	}
+
+	internalName := users.InternalSecretsPrefix + cr.Name
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: internalName}, new(corev1.Secret))
+	if k8serror.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "get internal secret %s", internalName)
+	}
+
+	return errors.Errorf("users secret %s doesn't exist, secretsName can't be changed on a running cluster; set %s annotation to generate new passwords",
+		cr.Spec.SecretsName, api.AnnotationAllowUnsafeChanges)
+}
+
+// checkExternalUsersSecret validates the users secret owned by an external tool.
+// The operator never creates or modifies such secret, so it waits
+// until the secret exists and contains passwords for all system users.
+func (r *ReconcilePerconaXtraDBCluster) checkExternalUsersSecret(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	secretObj := new(corev1.Secret)
+	err := r.client.Get(ctx,
+		types.NamespacedName{
+			Namespace: cr.Namespace,
+			Name:      cr.Spec.SecretsName,
+		},
+		secretObj,
+	)
+	if err != nil {
+		if k8serror.IsNotFound(err) {
+			return errors.Errorf("users secret %s is managed externally and doesn't exist yet", cr.Spec.SecretsName)
+		}
+		return errors.Wrap(err, "get secret")
+	}
+
+	if err := validatePasswords(secretObj); err != nil {
+		return errors.Wrap(err, "validate passwords")
+	}
+
+	var missing []string
+	for _, user := range secretSysUsers {
+		if len(secretObj.Data[user]) == 0 {
+			missing = append(missing, user)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Errorf("users secret %s is managed externally and has no passwords for users: %s",
+			cr.Spec.SecretsName, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// usersSecretToClusters maps an externally managed users secret
+// to the clusters which use it, so password rotations are applied right away.
+func usersSecretToClusters(cl client.Client) handler.MapFunc {
+	return func(ctx context.Context, obj client.Object) []reconcile.Request {
+		list := new(api.PerconaXtraDBClusterList)
+		if err := k8s.ListByIndex(ctx, cl, list, obj.GetNamespace(), k8s.IndexExternalUsersSecret, obj.GetName()); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to list clusters", "secret", obj.GetName())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		for _, cr := range list.Items {
+			if !cr.Spec.ExternalUsersSecret {
+				continue
+			}
+
+			secretsName := cr.Spec.SecretsName
+			if secretsName == "" {
+				secretsName = cr.Name + "-secrets"
+			}
+			if secretsName != obj.GetName() {
+				continue
+			}
+
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name},
+			})
+		}
+
+		return requests
+	}
+}
+
+func setUserSecretDefaults(secret *corev1.Secret, policy *api.PasswordPolicySpec) (isChanged bool, err error) {
 	if secret.Data == nil {
 		secret.Data = make(map[string][]byte)
 	}
-	users := []string{users.Root, users.Xtrabackup, users.Monitor, users.ProxyAdmin, users.Operator, users.Replication}
-	for _, user := range users {
+	for _, user := range secretSysUsers {
 		if pass, ok := secret.Data[user]; !ok || len(pass) == 0 {
-			secret.Data[user], err = generatePass()
+			secret.Data[user], err = users.GeneratePass(policy)
 			if err != nil {
 				return false, errors.Wrapf(err, "create %s users password", user)
 			}
@@ -94,31 +193,6 @@ func setUserSecretDefaults(secret *corev1.Secret) (isChanged bool, err error) {
 	return
 }
 
-const (
-	passwordMaxLen = 20
-	passwordMinLen = 16
-	passSymbols    = "ABCDEFGHIJKLMNOPQRSTUVWXYZ" +
-		"abcdefghijklmnopqrstuvwxyz" +
-		"0123456789" +
-		"!#$%&()*+,-.<=>?@[]^_{}~"
-)
-
-// generatePass generates a random password
-func generatePass() ([]byte, error) {
-	mrand.Seed(time.Now().UnixNano())
-	ln := mrand.Intn(passwordMaxLen-passwordMinLen) + passwordMinLen
-	b := make([]byte, ln)
-	for i := 0; i < ln; i++ {
-		randInt, err := rand.Int(rand.Reader, big.NewInt(int64(len(passSymbols))))
-		if err != nil {
-			return nil, errors.Wrap(err, "get rand int")
-		}
-		b[i] = passSymbols[randInt.Int64()]
-	}
-
-	return b, nil
-}
-
 func validatePasswords(secret *corev1.Secret) error {
 	for user, pass := range secret.Data {
 		switch user {
@@ -0,0 +1,102 @@
+package pxc
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/secretmanager"
+)
+
+const secretSourceVersionAnnotation = "percona.com/secret-source-version"
+
+// syncUsersSecretFromSource copies user passwords from the cloud secret manager
+// into the users secret. Passwords which are not present in the source
+// are left untouched and generated as usual by reconcileUsersSecret.
+func (r *ReconcilePerconaXtraDBCluster) syncUsersSecretFromSource(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	src := cr.Spec.UsersSecretSource
+	if src == nil {
+		return nil
+	}
+
+	log := logf.FromContext(ctx)
+
+	newProvider := r.newSecretProvider
+	if newProvider == nil {
+		newProvider = secretmanager.NewProvider
+	}
+
+	provider, err := newProvider(src)
+	if err != nil {
+		return errors.Wrap(err, "create secret provider")
+	}
+
+	ext, err := r.secretsCache.Get(ctx, cr.Namespace+"/"+cr.Name, src.RefreshInterval.Duration, provider)
+	if err != nil {
+		return errors.Wrapf(err, "get secret from %s", src.Provider)
+	}
+
+	secretObj := new(corev1.Secret)
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Spec.SecretsName}, secretObj)
+	if err != nil && !k8serror.IsNotFound(err) {
+		return errors.Wrap(err, "get secret")
+	}
+
+	if k8serror.IsNotFound(err) {
+		secretObj = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        cr.Spec.SecretsName,
+				Namespace:   cr.Namespace,
+				Labels:      naming.LabelsCluster(cr),
+				Annotations: map[string]string{secretSourceVersionAnnotation: ext.Version},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: ext.Data,
+		}
+		if err := r.client.Create(ctx, secretObj); err != nil {
+			return errors.Wrap(err, "create secret")
+		}
+
+		log.Info("Created users secret from external source", "secrets", cr.Spec.SecretsName, "provider", src.Provider)
+		return nil
+	}
+
+	if secretObj.Data == nil {
+		secretObj.Data = make(map[string][]byte)
+	}
+
+	changed := false
+	for user, pass := range ext.Data {
+		if !bytes.Equal(secretObj.Data[user], pass) {
+			secretObj.Data[user] = pass
+			changed = true
+		}
+	}
+
+	if !changed && secretObj.Annotations[secretSourceVersionAnnotation] == ext.Version {
+		return nil
+	}
+
+	if secretObj.Annotations == nil {
+		secretObj.Annotations = make(map[string]string)
+	}
+	secretObj.Annotations[secretSourceVersionAnnotation] = ext.Version
+
+	if err := r.client.Update(ctx, secretObj); err != nil {
+		return errors.Wrap(err, "update secret")
+	}
+
+	if changed {
+		log.Info("Users secret synced from external source", "secrets", cr.Spec.SecretsName, "provider", src.Provider, "version", ext.Version)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package pxc
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestReconcileUsersSecretRenamed(t *testing.T) {
+	ctx := context.Background()
+
+	internal := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "internal-cluster1", Namespace: "pxc"}}
+
+	tests := map[string]struct {
+		status  api.AppState
+		objs    []runtime.Object
+		unsafe  bool
+		created bool
+	}{
+		"new cluster":          {objs: nil, created: true},
+		"running cluster":      {status: api.AppStateReady, objs: []runtime.Object{internal}, created: false},
+		"unsafe changes":       {status: api.AppStateReady, objs: []runtime.Object{internal}, unsafe: true, created: true},
+		"no internal secret":   {status: api.AppStateReady, objs: nil, created: true},
+		"initializing cluster": {status: "", objs: []runtime.Object{internal}, created: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := newCR("cluster1", "pxc")
+			cr.Spec.SecretsName = "cluster1-secrets-renamed"
+			cr.Status.Status = tt.status
+			if tt.unsafe {
+				cr.Annotations = map[string]string{api.AnnotationAllowUnsafeChanges: "true"}
+			}
+
+			r := buildFakeClient(tt.objs)
+
+			err := r.reconcileUsersSecret(ctx, cr)
+			if tt.created && err != nil {
+				t.Fatal(err)
+			}
+			if !tt.created && err == nil {
+				t.Fatal("expected an error for the renamed users secret of a running cluster")
+			}
+
+			err = r.client.Get(ctx, types.NamespacedName{Name: cr.Spec.SecretsName, Namespace: cr.Namespace}, new(corev1.Secret))
+			if (err == nil) != tt.created {
+				t.Errorf("expected users secret created %t, got error %v", tt.created, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package pxc
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+)
+
+// setSmartUpdateProgress updates the smart update progress in the cluster status.
+// The status is written immediately since smart update of all pods
+// can take hours within a single reconcile.
+func (r *ReconcilePerconaXtraDBCluster) setSmartUpdateProgress(ctx context.Context, cr *api.PerconaXtraDBCluster, sts *appsv1.StatefulSet, phase api.SmartUpdatePhase, currentPod string) {
+	log := logf.FromContext(ctx)
+
+	old := cr.Status.SmartUpdate.DeepCopy()
+
+	s := cr.Status.SmartUpdate
+	if s == nil || s.Revision != sts.Status.UpdateRevision {
+		now := metav1.NewTime(time.Now().Truncate(time.Second))
+		s = &api.SmartUpdateStatus{
+			Revision:    sts.Status.UpdateRevision,
+			TargetImage: pxcImage(sts),
+			StartedAt:   &now,
+		}
+		cr.Status.SmartUpdate = s
+	}
+
+	s.Phase = phase
+	s.CurrentPod = currentPod
+	if phase == api.SmartUpdateCompleted && s.FinishedAt == nil {
+		now := metav1.NewTime(time.Now().Truncate(time.Second))
+		s.FinishedAt = &now
+	}
+
+	updated, err := r.updatedPods(ctx, sts)
+	if err != nil {
+		log.Error(err, "failed to count updated pods")
+	} else {
+		s.PodsUpdated = updated
+		s.PodsPending = 0
+		if sts.Spec.Replicas != nil && *sts.Spec.Replicas > updated {
+			s.PodsPending = *sts.Spec.Replicas - updated
+		}
+	}
+
+	if reflect.DeepEqual(old, s) {
+		return
+	}
+
+	err = k8s.PatchStatus(ctx, r.client, cr.DeepCopy(), func(c *api.PerconaXtraDBCluster) {
+		c.Status.SmartUpdate = s
+	})
+	if err != nil {
+		log.Error(err, "failed to write smart update progress")
+	}
+}
+
+func (r *ReconcilePerconaXtraDBCluster) updatedPods(ctx context.Context, sts *appsv1.StatefulSet) (int32, error) {
+	list := corev1.PodList{}
+	err := r.client.List(ctx, &list, &client.ListOptions{
+		Namespace:     sts.Namespace,
+		LabelSelector: labels.SelectorFromSet(sts.Spec.Selector.MatchLabels),
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "get pod list")
+	}
+
+	var updated int32
+	for _, pod := range list.Items {
+		if pod.Labels["controller-revision-hash"] == sts.Status.UpdateRevision {
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+func pxcImage(sts *appsv1.StatefulSet) string {
+	for _, c := range sts.Spec.Template.Spec.Containers {
+		if c.Name == "pxc" {
+			return c.Image
+		}
+	}
+	return ""
+}
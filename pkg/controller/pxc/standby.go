@@ -0,0 +1,274 @@
+package pxc
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup"
+)
+
+// standbyRecoverType makes the pitr recoverer apply binlogs newer than the executed GTID set of the cluster.
+const standbyRecoverType = "standby"
+
+// reconcileStandby restores a standby cluster from the latest backup of the source cluster
+// and applies the binlogs of the source every apply interval. If the standby falls behind
+// more than the allowed lag, it's rebuilt from a newer backup.
+func (r *ReconcilePerconaXtraDBCluster) reconcileStandby(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	log := logf.FromContext(ctx)
+
+	if !cr.Spec.Standby.IsEnabled() {
+		if cr.Status.Standby != nil {
+			log.Info("Standby mode is disabled, the cluster is promoted")
+			r.recorder.Event(cr, corev1.EventTypeNormal, naming.EventStandbyPromoted, "Standby mode is disabled, the cluster accepts writes")
+			cr.Status.Standby = nil
+		}
+		return r.deleteStandbyApplyJob(ctx, cr)
+	}
+
+	if cr.Status.Standby == nil {
+		cr.Status.Standby = new(api.StandbyStatus)
+	}
+	status := cr.Status.Standby
+
+	if status.Restore != "" {
+		return r.checkStandbyRestore(ctx, cr)
+	}
+
+	if status.Backup == "" {
+		return r.restoreStandby(ctx, cr)
+	}
+
+	if cr.Status.Status != api.AppStateReady {
+		return nil
+	}
+
+	if err := r.applyStandbyBinlogs(ctx, cr); err != nil {
+		return errors.Wrap(err, "apply binlogs")
+	}
+
+	if status.LastAppliedTime == nil {
+		return nil
+	}
+	lag := time.Since(status.LastAppliedTime.Time)
+	status.LagSeconds = int64(lag.Seconds())
+	if lag <= time.Duration(cr.Spec.Standby.MaxLagSeconds)*time.Second {
+		return nil
+	}
+
+	return r.restoreStandby(ctx, cr)
+}
+
+// restoreStandby restores the cluster from the latest backup of the source cluster
+// if it's newer than the backup the cluster is restored from.
+func (r *ReconcilePerconaXtraDBCluster) restoreStandby(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	log := logf.FromContext(ctx)
+
+	sb, status := cr.Spec.Standby, cr.Status.Standby
+
+	bcp, err := r.latestSourceBackup(ctx, cr, sb.StorageName, sb.SourceCluster)
+	if err != nil {
+		return errors.Wrap(err, "get latest backup of the source cluster")
+	}
+	if bcp == "" {
+		log.Info("Waiting for a backup of the source cluster", "sourceCluster", sb.SourceCluster, "storage", sb.StorageName)
+		return nil
+	}
+	if !newerBackup(bcp, status.Backup, sb.SourceCluster) {
+		if status.Backup != "" {
+			log.Info("Standby is lagging, but there is no newer backup to restore", "backup", status.Backup, "lagSeconds", status.LagSeconds)
+		}
+		return nil
+	}
+
+	if err := r.deleteStandbyApplyJob(ctx, cr); err != nil {
+		return err
+	}
+
+	restore, err := sourceBackupRestore(cr, sb.StorageName, bcp, "standby")
+	if err != nil {
+		return errors.Wrap(err, "build restore")
+	}
+	if err := r.client.Create(ctx, restore); err != nil {
+		return errors.Wrap(err, "create restore")
+	}
+
+	if status.Backup != "" {
+		r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventStandbyLagging,
+			"Standby is %ds behind the source, it's restored from backup %s", status.LagSeconds, bcp)
+	}
+	log.Info("Restoring standby from backup of the source cluster", "backup", bcp, "restore", restore.Name)
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventStandbyRestoreStarted, "Standby is restored from backup %s", bcp)
+
+	status.Backup = bcp
+	status.Restore = restore.Name
+
+	return nil
+}
+
+func (r *ReconcilePerconaXtraDBCluster) checkStandbyRestore(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	log := logf.FromContext(ctx)
+
+	status := cr.Status.Standby
+
+	restore := new(api.PerconaXtraDBClusterRestore)
+	err := r.client.Get(ctx, types.NamespacedName{Name: status.Restore, Namespace: cr.Namespace}, restore)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrapf(err, "get restore %s", status.Restore)
+	}
+
+	switch {
+	case k8serrors.IsNotFound(err):
+		// the cluster is restored from the latest backup again
+		log.Info("Standby restore is deleted", "restore", status.Restore)
+		status.Backup = ""
+	case restore.Status.State == api.RestoreSucceeded:
+		log.Info("Standby is restored from backup", "backup", status.Backup)
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventStandbyRestored,
+			"Standby is restored from backup %s, binlogs of the source are applied", status.Backup)
+		status.LastAppliedTime = nil
+		if t, ok := backupCreationTime(status.Backup, cr.Spec.Standby.SourceCluster); ok {
+			status.LastAppliedTime = &metav1.Time{Time: t}
+		}
+	default:
+		// the restore is running or failed and has to be deleted to restore the cluster again
+		return nil
+	}
+	status.Restore = ""
+
+	return nil
+}
+
+// applyStandbyBinlogs runs the job applying binlogs of the source cluster every apply interval.
+func (r *ReconcilePerconaXtraDBCluster) applyStandbyBinlogs(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	log := logf.FromContext(ctx)
+
+	status := cr.Status.Standby
+
+	job := new(batchv1.Job)
+	err := r.client.Get(ctx, types.NamespacedName{Name: naming.StandbyApplyJobName(cr), Namespace: cr.Namespace}, job)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "get apply job")
+	}
+
+	if k8serrors.IsNotFound(err) {
+		interval := time.Duration(cr.Spec.Standby.ApplyIntervalSeconds) * time.Second
+		if status.LastAppliedTime != nil && time.Since(status.LastAppliedTime.Time) < interval {
+			return nil
+		}
+
+		job, err := r.standbyApplyJob(ctx, cr)
+		if err != nil {
+			return errors.Wrap(err, "build apply job")
+		}
+		return errors.Wrap(r.client.Create(ctx, job), "create apply job")
+	}
+
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			status.LastAppliedTime = job.Status.StartTime
+		case batchv1.JobFailed:
+			log.Info("Failed to apply binlogs of the source cluster", "job", job.Name, "reason", cond.Message)
+			r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventStandbyApplyFailed,
+				"Failed to apply binlogs of the source cluster: %s", cond.Message)
+		default:
+			continue
+		}
+		return r.deleteStandbyApplyJob(ctx, cr)
+	}
+
+	return nil
+}
+
+func (r *ReconcilePerconaXtraDBCluster) standbyApplyJob(ctx context.Context, cr *api.PerconaXtraDBCluster) (*batchv1.Job, error) {
+	sb := cr.Spec.Standby
+
+	source, err := sourceBackupStatus(cr, sb.StorageName, cr.Status.Standby.Backup)
+	if err != nil {
+		return nil, err
+	}
+	source.State = api.BackupSucceeded
+
+	// the job is built as the point-in-time recovery of a restore, the objects aren't created
+	restore := &api.PerconaXtraDBClusterRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "standby",
+			Namespace: cr.Namespace,
+		},
+		Spec: api.PerconaXtraDBClusterRestoreSpec{
+			PXCCluster:   cr.Name,
+			BackupSource: source,
+			PITR: &api.PITR{
+				BackupSource: &api.PXCBackupStatus{StorageName: sb.BinlogStorageName},
+				Type:         standbyRecoverType,
+			},
+		},
+	}
+	bcp := &api.PerconaXtraDBClusterBackup{
+		ObjectMeta: restore.ObjectMeta,
+		Spec: api.PXCBackupSpec{
+			PXCCluster:  cr.Name,
+			StorageName: sb.StorageName,
+		},
+		Status: *source,
+	}
+
+	initImage, err := k8s.GetInitImage(ctx, cr, r.client)
+	if err != nil {
+		return nil, errors.Wrap(err, "get init image")
+	}
+
+	job, err := backup.RestoreJob(restore, bcp, cr, initImage, source.Destination, true)
+	if err != nil {
+		return nil, err
+	}
+	job.Name = naming.StandbyApplyJobName(cr)
+
+	if err := k8s.SetControllerReference(cr, job, r.scheme); err != nil {
+		return nil, errors.Wrap(err, "set controller reference")
+	}
+
+	return job, nil
+}
+
+func (r *ReconcilePerconaXtraDBCluster) deleteStandbyApplyJob(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.StandbyApplyJobName(cr),
+			Namespace: cr.Namespace,
+		},
+	}
+	err := r.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "delete apply job")
+	}
+	return nil
+}
+
+// newerBackup returns true if the backup was created after the current one.
+func newerBackup(name, current, cluster string) bool {
+	if current == "" {
+		return true
+	}
+	t, ok := backupCreationTime(name, cluster)
+	if !ok {
+		return false
+	}
+	currentTime, ok := backupCreationTime(current, cluster)
+	return !ok || t.After(currentTime)
+}
@@ -0,0 +1,160 @@
+package pxc
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+)
+
+func TestReconcileStandby(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.InitContainer.Image = "init-image"
+	cr.Spec.PXC.Affinity = new(api.PodAffinity)
+	cr.Spec.Backup = &api.PXCScheduledBackup{
+		Image: "backup-image",
+		Storages: map[string]*api.BackupStorageSpec{
+			"source-s3": {
+				Type: api.BackupStorageS3,
+				S3:   &api.BackupStorageS3Spec{Bucket: "backups/source", CredentialsSecret: "s3-secret"},
+			},
+			"source-binlogs": {
+				Type: api.BackupStorageS3,
+				S3:   &api.BackupStorageS3Spec{Bucket: "binlogs/source", CredentialsSecret: "s3-secret"},
+			},
+		},
+	}
+	cr.Spec.Standby = &api.StandbySpec{
+		Enabled:              true,
+		SourceCluster:        "src",
+		StorageName:          "source-s3",
+		BinlogStorageName:    "source-binlogs",
+		ApplyIntervalSeconds: 60,
+		MaxLagSeconds:        900,
+	}
+
+	objects := []string{"src-2026-10-01-03:00:00-full.md5"}
+	r := buildFakeClient([]runtime.Object{cr.DeepCopy()})
+	r.newStorageClient = func(context.Context, storage.Options) (storage.Storage, error) {
+		return &backupListStorage{objects: objects}, nil
+	}
+
+	if err := r.reconcileStandby(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	status := cr.Status.Standby
+	if status == nil || status.Backup != "src-2026-10-01-03:00:00-full" || status.Restore == "" {
+		t.Fatalf("unexpected standby status: %+v", status)
+	}
+
+	restore := new(api.PerconaXtraDBClusterRestore)
+	if err := r.client.Get(ctx, client.ObjectKey{Name: status.Restore, Namespace: cr.Namespace}, restore); err != nil {
+		t.Fatal(err)
+	}
+	restore.Status.State = api.RestoreSucceeded
+	if err := r.client.Update(ctx, restore); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.reconcileStandby(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	if status.Restore != "" || status.LastAppliedTime == nil {
+		t.Fatalf("the restored backup should be the applied point: %+v", status)
+	}
+	if err := r.client.Delete(ctx, restore); err != nil {
+		t.Fatal(err)
+	}
+
+	// the backup is older than the allowed lag, binlogs are applied once the cluster is ready
+	cr.Status.Status = api.AppStateReady
+	objects = append(objects, "src-2026-10-02-03:00:00-full.md5")
+	if err := r.reconcileStandby(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	if status.Backup != "src-2026-10-02-03:00:00-full" || status.Restore == "" {
+		t.Fatalf("lagging standby should be restored from the newer backup: %+v", status)
+	}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: naming.StandbyApplyJobName(cr), Namespace: cr.Namespace}, new(batchv1.Job)); err == nil {
+		t.Fatal("apply job should be deleted before the restore")
+	}
+}
+
+func TestStandbyApplyJob(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.InitContainer.Image = "init-image"
+	cr.Spec.PXC.Affinity = new(api.PodAffinity)
+	cr.Spec.Backup = &api.PXCScheduledBackup{
+		Image: "backup-image",
+		Storages: map[string]*api.BackupStorageSpec{
+			"source-s3": {
+				Type: api.BackupStorageS3,
+				S3:   &api.BackupStorageS3Spec{Bucket: "backups/source", CredentialsSecret: "s3-secret"},
+			},
+			"source-binlogs": {
+				Type: api.BackupStorageS3,
+				S3:   &api.BackupStorageS3Spec{Bucket: "binlogs/source", CredentialsSecret: "s3-secret"},
+			},
+		},
+	}
+	cr.Spec.Standby = &api.StandbySpec{Enabled: true, SourceCluster: "src", StorageName: "source-s3", BinlogStorageName: "source-binlogs"}
+	cr.Status.Standby = &api.StandbyStatus{Backup: "src-2026-10-01-03:00:00-full"}
+
+	r := buildFakeClient([]runtime.Object{cr.DeepCopy()})
+
+	job, err := r.standbyApplyJob(ctx, cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job.Name != naming.StandbyApplyJobName(cr) || !metav1.IsControlledBy(job, cr) {
+		t.Errorf("unexpected job metadata: %+v", job.ObjectMeta)
+	}
+
+	env := make(map[string]string)
+	for _, e := range job.Spec.Template.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	expected := map[string]string{
+		"PITR_RECOVERY_TYPE":   standbyRecoverType,
+		"BINLOG_S3_BUCKET_URL": "binlogs/source",
+		"S3_BUCKET_URL":        "backups/source/src-2026-10-01-03:00:00-full",
+		"PXC_SERVICE":          "cr-mock-pxc",
+	}
+	for k, v := range expected {
+		if env[k] != v {
+			t.Errorf("expected %s=%s, got %s", k, v, env[k])
+		}
+	}
+	if job.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("unexpected restart policy %s", job.Spec.Template.Spec.RestartPolicy)
+	}
+}
+
+func TestNewerBackup(t *testing.T) {
+	tests := []struct {
+		backup, current string
+		newer           bool
+	}{
+		{"src-2026-10-01-03:00:00-full", "", true},
+		{"src-2026-10-02-03:00:00-full", "src-2026-10-01-03:00:00-full", true},
+		{"src-2026-10-01-03:00:00-full", "src-2026-10-01-03:00:00-full", false},
+		{"src-2026-09-30-03:00:00-full", "src-2026-10-01-03:00:00-full", false},
+	}
+	for _, tt := range tests {
+		if newer := newerBackup(tt.backup, tt.current, "src"); newer != tt.newer {
+			t.Errorf("newerBackup(%s, %s) = %t, expected %t", tt.backup, tt.current, newer, tt.newer)
+		}
+	}
+}
@@ -2,7 +2,10 @@ package pxc
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -15,9 +18,13 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	k8sretry "k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup"
 )
 
 func (r *ReconcilePerconaXtraDBCluster) updateStatus(ctx context.Context, cr *api.PerconaXtraDBCluster, inProgress bool, reconcileErr error) (err error) {
@@ -27,6 +34,18 @@ func (r *ReconcilePerconaXtraDBCluster) updateStatus(ctx context.Context, cr *ap
 		LastTransitionTime: metav1.NewTime(time.Now().Truncate(time.Second)),
 	}
 
+	if cr.Spec.Suspend {
+		cr.Status.SetCondition(api.ClusterCondition{
+			Type:               api.ConditionSuspended,
+			Status:             api.ConditionTrue,
+			Reason:             "SuspendedBySpec",
+			Message:            "the operator doesn't change the cluster while spec.suspend is true",
+			LastTransitionTime: metav1.NewTime(time.Now().Truncate(time.Second)),
+		})
+	} else {
+		cr.Status.RemoveCondition(api.ConditionSuspended)
+	}
+
 	if reconcileErr != nil {
 		if cr.Status.Status != api.AppStateError {
 			clusterCondition := api.ClusterCondition{
@@ -41,12 +60,14 @@ func (r *ReconcilePerconaXtraDBCluster) updateStatus(ctx context.Context, cr *ap
 			cr.Status.Messages = append(cr.Status.Messages, "Error: "+reconcileErr.Error())
 			cr.Status.Status = api.AppStateError
 		}
+		cr.Status.SetStateConditions(cr.Generation, inProgress, reconcileErr)
 
 		return r.writeStatus(ctx, cr)
 	}
 
 	if cr.PVCResizeInProgress() {
 		cr.Status.Status = api.AppStateInit
+		cr.Status.SetStateConditions(cr.Generation, true, nil)
 		return r.writeStatus(ctx, cr)
 	}
 
@@ -106,12 +127,16 @@ func (r *ReconcilePerconaXtraDBCluster) updateStatus(ctx context.Context, cr *ap
 		if err != nil {
 			return errors.Wrapf(err, "get %s status", a.app.Name())
 		}
+		if isPXC(a.app) && !cr.Spec.Pause {
+			r.setWsrepUnhealthyReasons(ctx, cr, status.UnhealthyPods)
+		}
 		status.Version = a.status.Version
 		status.Image = a.status.Image
 		// Ready count can be greater than total size in case of downscale
 		if status.Ready > status.Size {
 			status.Ready = status.Size
 		}
+		status.ReadyMembers = fmt.Sprintf("%d/%d", status.Ready, status.Size)
 		*a.status = status
 
 		host, err := r.appHost(cr, a.app, a.spec, a.expose)
@@ -123,6 +148,9 @@ func (r *ReconcilePerconaXtraDBCluster) updateStatus(ctx context.Context, cr *ap
 		if a.status.Message != "" {
 			cr.Status.Messages = append(cr.Status.Messages, a.app.Name()+": "+a.status.Message)
 		}
+		for _, p := range a.status.UnhealthyPods {
+			cr.Status.Messages = append(cr.Status.Messages, fmt.Sprintf("%s: pod %s: %s: %s", a.app.Name(), p.Name, p.Reason, p.Message))
+		}
 
 		cr.Status.Size += status.Size
 		cr.Status.Ready += status.Ready
@@ -135,27 +163,57 @@ func (r *ReconcilePerconaXtraDBCluster) updateStatus(ctx context.Context, cr *ap
 		}
 	}
 
+	if err := r.updateBackupSummary(ctx, cr); err != nil {
+		return errors.Wrap(err, "update backup summary")
+	}
+
 	cr.Status.Status = cr.Status.ClusterStatus(inProgress, cr.ObjectMeta.DeletionTimestamp != nil)
 	clusterCondition.Type = cr.Status.Status
 	cr.Status.AddCondition(clusterCondition)
+	cr.Status.SetStateConditions(cr.Generation, inProgress, nil)
 	cr.Status.ObservedGeneration = cr.ObjectMeta.Generation
 
 	return r.writeStatus(ctx, cr)
 }
 
-func (r *ReconcilePerconaXtraDBCluster) writeStatus(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
-	err := k8sretry.RetryOnConflict(k8sretry.DefaultRetry, func() error {
-		c := &api.PerconaXtraDBCluster{}
+// updateBackupSummary sets the latest successful backup and the latest restorable time in the status.
+func (r *ReconcilePerconaXtraDBCluster) updateBackupSummary(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	cr.Status.LastBackup = nil
+	cr.Status.PITR = nil
+	if cr.Spec.Backup == nil {
+		return nil
+	}
 
-		err := r.client.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, c)
-		if err != nil {
-			return err
+	bcp, err := backup.LatestSuccessfulBackup(ctx, r.client, cr)
+	if err != nil {
+		return err
+	}
+	if bcp == nil {
+		return nil
+	}
+
+	cr.Status.LastBackup = &api.LastBackupStatus{
+		Name:        bcp.Name,
+		StorageName: bcp.Status.StorageName,
+		Completed:   bcp.Status.CompletedAt,
+	}
+	if cr.Spec.Backup.PITR.Enabled {
+		cr.Status.PITR = &api.PITRStatus{
+			LatestRestorableTime: bcp.Status.LatestRestorableTime,
 		}
+	}
 
-		c.Status = cr.Status
+	return nil
+}
 
-		return r.client.Status().Update(ctx, c)
+func (r *ReconcilePerconaXtraDBCluster) writeStatus(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	status := cr.Status.DeepCopy()
+	err := k8s.PatchStatus(ctx, r.client, cr, func(c *api.PerconaXtraDBCluster) {
+		c.Status = *status
 	})
+	if err == nil {
+		metrics.SetClusterState(cr)
+	}
 
 	// We need to make sure that the next reconcile gets a PerconaXtraDBCluster with an updated status.
 	// Without this, the next reconcile may occur too quickly, possibly before the status is updated.
@@ -232,6 +290,8 @@ func (r *ReconcilePerconaXtraDBCluster) appStatus(ctx context.Context, app api.S
 			}
 		}
 
+		ready := false
+		var unhealthy *api.UnhealthyPod
 		for _, cond := range pod.Status.Conditions {
 			switch cond.Type {
 			case corev1.ContainersReady:
@@ -241,6 +301,7 @@ func (r *ReconcilePerconaXtraDBCluster) appStatus(ctx context.Context, app api.S
 
 				if !isPXC(app) || crLt170 {
 					status.Ready++
+					ready = true
 					continue
 				}
 
@@ -249,8 +310,20 @@ func (r *ReconcilePerconaXtraDBCluster) appStatus(ctx context.Context, app api.S
 					return api.AppStatus{}, errors.Wrapf(err, "parse %s pod logs", pod.Name)
 				}
 
-				if !isPodWaitingForRecovery && pod.ObjectMeta.Labels["controller-revision-hash"] == sfs.Status.UpdateRevision {
+				switch {
+				case isPodWaitingForRecovery:
+					unhealthy = &api.UnhealthyPod{
+						Reason:  api.UnhealthyPodWaitingForRecovery,
+						Message: "the cluster is waiting for the full cluster crash recovery",
+					}
+				case pod.ObjectMeta.Labels["controller-revision-hash"] != sfs.Status.UpdateRevision:
+					unhealthy = &api.UnhealthyPod{
+						Reason:  api.UnhealthyPodOutdated,
+						Message: "the pod isn't updated to the revision " + sfs.Status.UpdateRevision,
+					}
+				default:
 					status.Ready++
+					ready = true
 				}
 			case corev1.PodScheduled:
 				if cond.Reason == corev1.PodReasonUnschedulable &&
@@ -259,7 +332,23 @@ func (r *ReconcilePerconaXtraDBCluster) appStatus(ctx context.Context, app api.S
 				}
 			}
 		}
+
+		if ready || paused {
+			continue
+		}
+		if unhealthy == nil {
+			unhealthy = podUnhealthyReason(&pod, func(name string) bool {
+				pvc := new(corev1.PersistentVolumeClaim)
+				err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, pvc)
+				return err == nil && pvc.Status.Phase == corev1.ClaimPending
+			})
+		}
+		unhealthy.Name = pod.Name
+		status.UnhealthyPods = append(status.UnhealthyPods, *unhealthy)
 	}
+	sort.Slice(status.UnhealthyPods, func(i, j int) bool {
+		return status.UnhealthyPods[i].Name < status.UnhealthyPods[j].Name
+	})
 
 	switch {
 	case paused && status.Ready > 0:
@@ -273,6 +362,100 @@ func (r *ReconcilePerconaXtraDBCluster) appStatus(ctx context.Context, app api.S
 	return status, nil
 }
 
+// setWsrepUnhealthyReasons replaces the failing probes of the PXC pods with the wsrep state of the nodes,
+// the readiness probe fails while the node isn't synced with the cluster.
+func (r *ReconcilePerconaXtraDBCluster) setWsrepUnhealthyReasons(ctx context.Context, cr *api.PerconaXtraDBCluster, pods []api.UnhealthyPod) {
+	for i := range pods {
+		if pods[i].Reason != api.UnhealthyPodProbeFailed {
+			continue
+		}
+
+		status, err := r.wsrepStatus(ctx, cr, pods[i].Name)
+		if err != nil {
+			logf.FromContext(ctx).V(1).Info("Failed to get wsrep status", "pod", pods[i].Name, "error", err.Error())
+			continue
+		}
+		if state := status["local_state_comment"]; state != "Synced" {
+			pods[i].Reason = api.UnhealthyPodWsrepNotSynced
+			pods[i].Message = fmt.Sprintf("wsrep_local_state_comment is %s, wsrep_cluster_status is %s", state, status["cluster_status"])
+		}
+	}
+}
+
+// podUnhealthyReason returns the most relevant reason the pod isn't ready: scheduling and volumes first,
+// then images and failed containers, then probes. pvcPending reports if the PVC is pending.
+func podUnhealthyReason(pod *corev1.Pod, pvcPending func(name string) bool) *api.UnhealthyPod {
+	if pod.DeletionTimestamp != nil {
+		return &api.UnhealthyPod{Reason: api.UnhealthyPodNotReady, Message: "the pod is terminating"}
+	}
+
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type != corev1.PodScheduled || cond.Status == corev1.ConditionTrue {
+			continue
+		}
+		for _, vol := range pod.Spec.Volumes {
+			if vol.PersistentVolumeClaim != nil && pvcPending(vol.PersistentVolumeClaim.ClaimName) {
+				return &api.UnhealthyPod{
+					Reason:  api.UnhealthyPodPVCPending,
+					Message: "PVC " + vol.PersistentVolumeClaim.ClaimName + " is pending",
+				}
+			}
+		}
+		return &api.UnhealthyPod{Reason: api.UnhealthyPodUnschedulable, Message: cond.Message}
+	}
+
+	if u := containersUnhealthyReason(pod.Status.InitContainerStatuses, api.UnhealthyPodInitContainerFailed); u != nil {
+		return u
+	}
+	if u := containersUnhealthyReason(pod.Status.ContainerStatuses, api.UnhealthyPodContainerFailed); u != nil {
+		return u
+	}
+
+	for _, cntr := range pod.Status.ContainerStatuses {
+		if cntr.State.Running != nil && !cntr.Ready {
+			return &api.UnhealthyPod{
+				Reason:  api.UnhealthyPodProbeFailed,
+				Message: "readiness probe of container " + cntr.Name + " is failing",
+			}
+		}
+	}
+
+	return &api.UnhealthyPod{Reason: api.UnhealthyPodNotReady, Message: "the pod is " + strings.ToLower(string(pod.Status.Phase))}
+}
+
+// containersUnhealthyReason returns the reason of the first container which can't pull its image
+// or failed, nil if there is none.
+func containersUnhealthyReason(statuses []corev1.ContainerStatus, failed api.UnhealthyPodReason) *api.UnhealthyPod {
+	for _, cntr := range statuses {
+		switch {
+		case cntr.State.Waiting != nil:
+			switch cntr.State.Waiting.Reason {
+			case "ContainerCreating", "PodInitializing", "":
+				continue
+			case "ErrImagePull", "ImagePullBackOff", "InvalidImageName":
+				return &api.UnhealthyPod{
+					Reason:  api.UnhealthyPodImagePullFailed,
+					Message: cntr.Name + ": " + cntr.State.Waiting.Message,
+				}
+			}
+			msg := cntr.Name + ": " + cntr.State.Waiting.Reason
+			if t := cntr.LastTerminationState.Terminated; t != nil {
+				msg += fmt.Sprintf(", last exit code %d", t.ExitCode)
+				if t.Reason != "" {
+					msg += " (" + t.Reason + ")"
+				}
+			}
+			return &api.UnhealthyPod{Reason: failed, Message: msg}
+		case cntr.State.Terminated != nil && cntr.State.Terminated.ExitCode != 0:
+			return &api.UnhealthyPod{
+				Reason:  failed,
+				Message: fmt.Sprintf("%s: exit code %d (%s)", cntr.Name, cntr.State.Terminated.ExitCode, cntr.State.Terminated.Reason),
+			}
+		}
+	}
+	return nil
+}
+
 func (r *ReconcilePerconaXtraDBCluster) appHost(cr *api.PerconaXtraDBCluster, app api.StatefulApp,
 	podSpec *api.PodSpec, expose *api.ServiceExpose,
 ) (string, error) {
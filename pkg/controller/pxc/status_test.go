@@ -4,15 +4,22 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake" // nolint
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
 	"github.com/percona/percona-xtradb-cluster-operator/version"
 )
@@ -94,15 +101,38 @@ func newMockPod(name, namespace string, labels map[string]string, status corev1.
 func buildFakeClient(objs []runtime.Object) *ReconcilePerconaXtraDBCluster {
 	s := scheme.Scheme
 
-	s.AddKnownTypes(api.SchemeGroupVersion, &api.PerconaXtraDBCluster{})
+	s.AddKnownTypes(api.SchemeGroupVersion, &api.PerconaXtraDBCluster{},
+		new(api.PerconaXtraDBClusterBackup), new(api.PerconaXtraDBClusterBackupList), new(api.PerconaXtraDBClusterRestore))
 
-	cl := fake.NewClientBuilder().
+	b := fake.NewClientBuilder().
 		WithScheme(s).
 		WithRuntimeObjects(objs...).
 		WithStatusSubresource(&api.PerconaXtraDBCluster{}).
-		Build()
+		WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate})
+	for _, idx := range k8s.Indexes() {
+		b = b.WithIndex(idx.Object, idx.Field, idx.Extract)
+	}
+	cl := b.Build()
+
+	return &ReconcilePerconaXtraDBCluster{client: cl, scheme: s, recorder: new(record.FakeRecorder)}
+}
 
-	return &ReconcilePerconaXtraDBCluster{client: cl, scheme: s}
+// applyAsCreateOrUpdate emulates server-side apply, which the fake client doesn't support, with create and update.
+func applyAsCreateOrUpdate(ctx context.Context, cl client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return cl.Patch(ctx, obj, patch, opts...)
+	}
+
+	current := obj.DeepCopyObject().(client.Object)
+	err := cl.Get(ctx, client.ObjectKeyFromObject(obj), current)
+	if k8serrors.IsNotFound(err) {
+		return cl.Create(ctx, obj)
+	}
+	if err != nil {
+		return err
+	}
+	obj.SetResourceVersion(current.GetResourceVersion())
+	return cl.Update(ctx, obj)
 }
 
 func TestAppStatusInit(t *testing.T) {
@@ -213,6 +243,62 @@ func TestUpdateStatusReady(t *testing.T) {
 	}
 }
 
+func TestUpdateStatusSummary(t *testing.T) {
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.Backup = &api.PXCScheduledBackup{PITR: api.PITRSpec{Enabled: true, StorageName: "s3"}}
+
+	ctx := context.Background()
+
+	pxc := statefulset.NewNode(cr)
+	haproxy := statefulset.NewHAProxy(cr)
+	objs := []runtime.Object{cr, pxc.StatefulSet(), haproxy.StatefulSet()}
+	for i := 0; i < int(cr.Spec.PXC.Size)-1; i++ {
+		objs = append(objs, newMockPod(fmt.Sprintf("pxc-mock-%d", i), cr.Namespace, pxc.Labels(), podStatusReady))
+	}
+
+	completed := metav1.NewTime(time.Date(2024, 1, 2, 0, 5, 0, 0, time.UTC))
+	restorable := metav1.NewTime(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))
+	newBackup := func(name, cluster string, created time.Time, state api.PXCBackupState) *api.PerconaXtraDBClusterBackup {
+		return &api.PerconaXtraDBClusterBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: cr.Namespace, CreationTimestamp: metav1.NewTime(created)},
+			Spec:       api.PXCBackupSpec{PXCCluster: cluster, StorageName: "s3"},
+			Status:     api.PXCBackupStatus{State: state, StorageName: "s3"},
+		}
+	}
+	latest := newBackup("latest", cr.Name, completed.Add(-5*time.Minute), api.BackupSucceeded)
+	latest.Status.CompletedAt = &completed
+	latest.Status.LatestRestorableTime = &restorable
+	objs = append(objs,
+		newBackup("older", cr.Name, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), api.BackupSucceeded),
+		latest,
+		newBackup("failed", cr.Name, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), api.BackupFailed),
+		newBackup("other-cluster", "other", time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), api.BackupSucceeded),
+	)
+
+	r := buildFakeClient(objs)
+	if err := r.updateStatus(ctx, cr, false, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if cr.Status.PXC.ReadyMembers != "2/3" {
+		t.Errorf("unexpected ready PXC members %q", cr.Status.PXC.ReadyMembers)
+	}
+	if lb := cr.Status.LastBackup; lb == nil || lb.Name != "latest" || lb.StorageName != "s3" || !lb.Completed.Equal(&completed) {
+		t.Errorf("unexpected last backup %+v", lb)
+	}
+	if p := cr.Status.PITR; p == nil || !p.LatestRestorableTime.Equal(&restorable) {
+		t.Errorf("unexpected PITR status %+v", p)
+	}
+
+	cr.Spec.Backup.PITR.Enabled = false
+	if err := r.updateStatus(ctx, cr, false, nil); err != nil {
+		t.Fatal(err)
+	}
+	if cr.Status.PITR != nil {
+		t.Errorf("expected no PITR status, got %+v", cr.Status.PITR)
+	}
+}
+
 func TestUpdateStatusError(t *testing.T) {
 	cr := newCR("cr-mock", "pxc")
 
@@ -448,3 +534,88 @@ func TestClusterStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestPodUnhealthyReason(t *testing.T) {
+	unscheduled := corev1.PodStatus{
+		Phase: corev1.PodPending,
+		Conditions: []corev1.PodCondition{
+			{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Message: "0/3 nodes are available"},
+		},
+	}
+	waiting := func(reason string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:  "pxc",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: reason}},
+		}
+	}
+	crashLoop := waiting("CrashLoopBackOff")
+	crashLoop.LastTerminationState.Terminated = &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}
+
+	tests := map[string]struct {
+		status     corev1.PodStatus
+		pvcPending bool
+		expected   api.UnhealthyPodReason
+		message    string
+	}{
+		"pvc pending": {
+			status:     unscheduled,
+			pvcPending: true,
+			expected:   api.UnhealthyPodPVCPending,
+			message:    "PVC datadir-cluster1-pxc-0 is pending",
+		},
+		"unschedulable": {
+			status:   unscheduled,
+			expected: api.UnhealthyPodUnschedulable,
+			message:  "0/3 nodes are available",
+		},
+		"image pull": {
+			status:   corev1.PodStatus{Phase: corev1.PodPending, ContainerStatuses: []corev1.ContainerStatus{waiting("ImagePullBackOff")}},
+			expected: api.UnhealthyPodImagePullFailed,
+			message:  "pxc: ImagePullBackOff",
+		},
+		"init container": {
+			status: corev1.PodStatus{Phase: corev1.PodPending, InitContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "pxc-init",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 2, Reason: "Error"}},
+			}}},
+			expected: api.UnhealthyPodInitContainerFailed,
+			message:  "pxc-init: exit code 2 (Error)",
+		},
+		"crash loop": {
+			status:   corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{crashLoop}},
+			expected: api.UnhealthyPodContainerFailed,
+			message:  "pxc: CrashLoopBackOff, last exit code 1 (Error)",
+		},
+		"probe": {
+			status: corev1.PodStatus{Phase: corev1.PodRunning, ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "pxc",
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}}},
+			expected: api.UnhealthyPodProbeFailed,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+					Name: "datadir",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "datadir-cluster1-pxc-0"},
+					},
+				}}},
+				Status: tt.status,
+			}
+
+			u := podUnhealthyReason(pod, func(string) bool { return tt.pvcPending })
+			if u == nil {
+				t.Fatal("expected an unhealthy reason")
+			}
+			if u.Reason != tt.expected {
+				t.Errorf("expected reason %s, got %s", tt.expected, u.Reason)
+			}
+			if tt.message != "" && u.Message != tt.message {
+				t.Errorf("expected message %q, got %q", tt.message, u.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package pxc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+// checkStorageLocality refuses the PXC statefulset if its pod placement excludes all nodes
+// a bound persistent volume of a pod can be used on, since the pod would be pending until
+// the placement is reverted. The volumes left by a scale down are checked too, they are
+// bound again when the cluster is scaled up.
+// The statefulset template is shared by all pods, so the placement of each pod on the nodes
+// of its volume is left to the volume binding of the scheduler.
+func (r *ReconcilePerconaXtraDBCluster) checkStorageLocality(ctx context.Context, cr *api.PerconaXtraDBCluster, sts *appsv1.StatefulSet) error {
+	if !cr.Spec.PXC.StorageLocality.IsEnabled() || sts.Spec.Replicas == nil {
+		return nil
+	}
+
+	log := logf.FromContext(ctx)
+
+	nodes := new(corev1.NodeList)
+	if err := r.client.List(ctx, nodes); err != nil {
+		if k8serrors.IsForbidden(err) {
+			log.V(1).Info("Storage locality isn't checked, nodes can't be listed", "error", err.Error())
+			return nil
+		}
+		return errors.Wrap(err, "list nodes")
+	}
+
+	var conflicts []string
+	for i := int32(0); i < *sts.Spec.Replicas; i++ {
+		podName := fmt.Sprintf("%s-%d", sts.Name, i)
+
+		pvc := new(corev1.PersistentVolumeClaim)
+		err := r.client.Get(ctx, types.NamespacedName{Name: "datadir-" + podName, Namespace: sts.Namespace}, pvc)
+		if k8serrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "get PVC of pod %s", podName)
+		}
+		if pvc.Spec.VolumeName == "" {
+			continue
+		}
+
+		pv := new(corev1.PersistentVolume)
+		if err := r.client.Get(ctx, types.NamespacedName{Name: pvc.Spec.VolumeName}, pv); err != nil {
+			if k8serrors.IsForbidden(err) {
+				log.V(1).Info("Storage locality isn't checked, persistent volumes can't be read", "error", err.Error())
+				return nil
+			}
+			return errors.Wrapf(err, "get persistent volume %s", pvc.Spec.VolumeName)
+		}
+		if pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+			continue
+		}
+
+		var dataNodes []*corev1.Node
+		for j := range nodes.Items {
+			if k8s.NodeMatchesSelectorTerms(&nodes.Items[j], pv.Spec.NodeAffinity.Required.NodeSelectorTerms) {
+				dataNodes = append(dataNodes, &nodes.Items[j])
+			}
+		}
+
+		if len(dataNodes) == 0 {
+			r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventStorageNodeUnavailable,
+				"Volume %s of pod %s is bound to nodes that don't exist anymore, "+
+					"the pod can't be scheduled until such a node is back or PVC %s is deleted", pv.Name, podName, pvc.Name)
+			continue
+		}
+
+		placed := false
+		for _, node := range dataNodes {
+			if podPlacementAllows(&sts.Spec.Template.Spec, node) {
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			conflicts = append(conflicts, podName)
+		}
+	}
+
+	if len(conflicts) > 0 {
+		r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventStorageLocalityConflict,
+			"Placement of %s is refused, it excludes all nodes the volumes of the pods are bound to", sts.Name)
+		return errors.Errorf("placement of pods %s excludes the nodes of their volumes", strings.Join(conflicts, ", "))
+	}
+
+	return nil
+}
+
+// podPlacementAllows returns true if the node selector and the required node affinity of the pod allow the node.
+func podPlacementAllows(spec *corev1.PodSpec, node *corev1.Node) bool {
+	if !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return false
+	}
+
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil || spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+
+	return k8s.NodeMatchesSelectorTerms(node, spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+}
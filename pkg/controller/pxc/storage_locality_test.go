@@ -0,0 +1,68 @@
+package pxc
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
+)
+
+func TestCheckStorageLocality(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.PXC.StorageLocality = &api.StorageLocalitySpec{Enabled: true}
+
+	sts := statefulset.NewNode(cr).StatefulSet()
+	replicas := int32(2)
+	sts.Spec.Replicas = &replicas
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-a",
+			Labels: map[string]string{"kubernetes.io/hostname": "node-a", "disk": "nvme"},
+		},
+	}
+	pv := &corev1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "local-pv-1"},
+		Spec: corev1.PersistentVolumeSpec{
+			NodeAffinity: &corev1.VolumeNodeAffinity{
+				Required: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      "kubernetes.io/hostname",
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"node-a"},
+						}},
+					}},
+				},
+			},
+		},
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "datadir-" + sts.Name + "-0", Namespace: cr.Namespace},
+		Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: pv.Name},
+	}
+
+	r := buildFakeClient([]runtime.Object{cr, node, pv, pvc})
+
+	sts.Spec.Template.Spec.NodeSelector = map[string]string{"disk": "nvme"}
+	if err := r.checkStorageLocality(ctx, cr, sts); err != nil {
+		t.Errorf("expected the placement to be accepted: %v", err)
+	}
+
+	sts.Spec.Template.Spec.NodeSelector = map[string]string{"disk": "ssd"}
+	if err := r.checkStorageLocality(ctx, cr, sts); err == nil {
+		t.Error("expected the placement excluding the node of the volume to be refused")
+	}
+
+	cr.Spec.PXC.StorageLocality.Enabled = false
+	if err := r.checkStorageLocality(ctx, cr, sts); err != nil {
+		t.Errorf("expected no check if it's disabled: %v", err)
+	}
+}
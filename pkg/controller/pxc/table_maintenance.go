@@ -0,0 +1,320 @@
+package pxc
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robfig/cron/v3"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+// tableMaintenanceScript executes the statements generated by MAINTENANCE_QUERY.
+// RSU makes the statements local to the pod, NO_WRITE_TO_BINLOG keeps them out of the binlog.
+const tableMaintenanceScript = `set -o errexit -o pipefail
+export MYSQL_PWD="$OPERATOR_PASS"
+{
+	echo "SET SESSION wsrep_OSU_method='RSU';"
+	mysql -h "$PXC_HOST" -u "$OPERATOR_USER" -N -B -r -e "$MAINTENANCE_QUERY"
+} | mysql -h "$PXC_HOST" -u "$OPERATOR_USER" -v
+`
+
+// reconcileTableMaintenance starts a table maintenance run on schedule within the maintenance
+// windows and runs the maintenance job against the PXC pods except the writer one by one.
+func (r *ReconcilePerconaXtraDBCluster) reconcileTableMaintenance(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	log := logf.FromContext(ctx)
+
+	if !cr.Spec.TableMaintenance.IsEnabled() {
+		cr.Status.TableMaintenance = nil
+		return r.deleteTableMaintenanceJob(ctx, cr)
+	}
+
+	if cr.Status.TableMaintenance == nil {
+		cr.Status.TableMaintenance = new(api.TableMaintenanceStatus)
+	}
+	status := cr.Status.TableMaintenance
+
+	job := new(batchv1.Job)
+	err := r.client.Get(ctx, types.NamespacedName{Name: naming.TableMaintenanceJobName(cr), Namespace: cr.Namespace}, job)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "get table maintenance job")
+	}
+	if err == nil {
+		return r.checkTableMaintenanceJob(ctx, cr, job)
+	}
+
+	if cr.Spec.Pause || cr.Status.Status != api.AppStateReady {
+		return nil
+	}
+
+	now := time.Now()
+	if ok, reason := cr.Spec.UpgradeOptions.MaintenanceAllowed(now); !ok {
+		if status.InProgress() {
+			log.V(1).Info("Table maintenance is postponed", "reason", reason)
+		}
+		return nil
+	}
+
+	if !status.InProgress() {
+		started, err := r.startTableMaintenance(ctx, cr, now)
+		if err != nil || !started {
+			return err
+		}
+	}
+
+	return r.runTableMaintenance(ctx, cr)
+}
+
+// startTableMaintenance starts a run if the schedule fired since the last one.
+func (r *ReconcilePerconaXtraDBCluster) startTableMaintenance(ctx context.Context, cr *api.PerconaXtraDBCluster, now time.Time) (bool, error) {
+	status := cr.Status.TableMaintenance
+
+	sched, err := cron.ParseStandard(cr.Spec.TableMaintenance.Schedule)
+	if err != nil {
+		return false, errors.Wrap(err, "parse schedule")
+	}
+	last := cr.CreationTimestamp.Time
+	if status.LastScheduleTime != nil {
+		last = status.LastScheduleTime.Time
+	}
+	if sched.Next(last).After(now) {
+		return false, nil
+	}
+
+	pods := corev1.PodList{}
+	err = r.client.List(ctx, &pods, &client.ListOptions{
+		Namespace:     cr.Namespace,
+		LabelSelector: labels.SelectorFromSet(naming.LabelsPXC(cr)),
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "list pods")
+	}
+	names := make([]string, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		names = append(names, pod.Name)
+	}
+	sort.Strings(names)
+
+	logf.FromContext(ctx).Info("Starting table maintenance", "pods", names)
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventTableMaintenanceStarted,
+		"Table maintenance of %s started", strings.Join(cr.Spec.TableMaintenance.Databases, ", "))
+
+	status.LastScheduleTime = &metav1.Time{Time: now}
+	status.PendingPods = names
+	status.FailedPods = nil
+
+	return true, nil
+}
+
+// runTableMaintenance creates the maintenance job for the next pod of the run.
+// The writer pod is skipped, a pod that isn't ready is waited for.
+func (r *ReconcilePerconaXtraDBCluster) runTableMaintenance(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	log := logf.FromContext(ctx)
+
+	status := cr.Status.TableMaintenance
+
+	if status.CurrentPod == "" {
+		if len(status.PendingPods) == 0 {
+			log.Info("Table maintenance is finished", "failedPods", status.FailedPods)
+			if len(status.FailedPods) > 0 {
+				r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventTableMaintenanceFinished,
+					"Table maintenance is finished, it failed on %s", strings.Join(status.FailedPods, ", "))
+			} else {
+				r.recorder.Event(cr, corev1.EventTypeNormal, naming.EventTableMaintenanceFinished, "Table maintenance is finished")
+			}
+			status.LastCompletedTime = &metav1.Time{Time: time.Now()}
+			return nil
+		}
+		status.CurrentPod, status.PendingPods = status.PendingPods[0], status.PendingPods[1:]
+	}
+
+	pod := new(corev1.Pod)
+	err := r.client.Get(ctx, types.NamespacedName{Name: status.CurrentPod, Namespace: cr.Namespace}, pod)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			status.CurrentPod = ""
+			return nil
+		}
+		return errors.Wrapf(err, "get pod %s", status.CurrentPod)
+	}
+	if !isPodReady(*pod) {
+		log.V(1).Info("Waiting for pod to be ready for table maintenance", "pod", pod.Name)
+		return nil
+	}
+
+	primary, err := r.getPrimaryPod(cr)
+	if err != nil {
+		return errors.Wrap(err, "get primary pod")
+	}
+	if isPrimaryPod(pod, primary) {
+		log.Info("Table maintenance skips the writer pod", "pod", pod.Name)
+		status.CurrentPod = ""
+		return nil
+	}
+
+	job, err := r.tableMaintenanceJob(cr, pod.Name)
+	if err != nil {
+		return errors.Wrap(err, "build table maintenance job")
+	}
+
+	log.Info("Running table maintenance", "pod", pod.Name, "job", job.Name)
+
+	return errors.Wrap(r.client.Create(ctx, job), "create table maintenance job")
+}
+
+func (r *ReconcilePerconaXtraDBCluster) checkTableMaintenanceJob(ctx context.Context, cr *api.PerconaXtraDBCluster, job *batchv1.Job) error {
+	log := logf.FromContext(ctx)
+
+	status := cr.Status.TableMaintenance
+
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			log.Info("Table maintenance is done", "pod", status.CurrentPod)
+		case batchv1.JobFailed:
+			log.Info("Table maintenance failed", "pod", status.CurrentPod, "reason", cond.Message)
+			r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventTableMaintenanceFailed,
+				"Table maintenance failed on %s: %s", status.CurrentPod, cond.Message)
+			status.FailedPods = append(status.FailedPods, status.CurrentPod)
+		default:
+			continue
+		}
+		status.CurrentPod = ""
+		return r.deleteTableMaintenanceJob(ctx, cr)
+	}
+
+	return nil
+}
+
+func (r *ReconcilePerconaXtraDBCluster) tableMaintenanceJob(cr *api.PerconaXtraDBCluster, podName string) (*batchv1.Job, error) {
+	spec := cr.Spec.TableMaintenance
+
+	ls := naming.LabelsTableMaintenance(cr)
+	backoffLimit := int32(0)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.TableMaintenanceJobName(cr),
+			Namespace: cr.Namespace,
+			Labels:    ls,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: ls},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					SecurityContext:  cr.Spec.PXC.PodSecurityContext,
+					ImagePullSecrets: cr.Spec.PXC.ImagePullSecrets,
+					Containers: []corev1.Container{
+						{
+							Name:            "table-maintenance",
+							Image:           cr.Spec.PXC.Image,
+							ImagePullPolicy: cr.Spec.PXC.ImagePullPolicy,
+							SecurityContext: cr.Spec.PXC.ContainerSecurityContext,
+							Command:         []string{"/bin/bash", "-c", tableMaintenanceScript},
+							Resources:       spec.Resources,
+							Env: []corev1.EnvVar{
+								{
+									Name:  "PXC_HOST",
+									Value: podName + "." + cr.Name + "-pxc." + cr.Namespace,
+								},
+								{
+									Name:  "OPERATOR_USER",
+									Value: users.Operator,
+								},
+								{
+									Name: "OPERATOR_PASS",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: app.SecretKeySelector(cr.Spec.SecretsName, users.Operator),
+									},
+								},
+								{
+									Name:  "MAINTENANCE_QUERY",
+									Value: tableMaintenanceQuery(spec),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	cr.ApplyImageOverrides(&job.Spec.Template.Spec)
+
+	if err := k8s.SetControllerReference(cr, job, r.scheme); err != nil {
+		return nil, errors.Wrap(err, "set controller reference")
+	}
+
+	return job, nil
+}
+
+func (r *ReconcilePerconaXtraDBCluster) deleteTableMaintenanceJob(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.TableMaintenanceJobName(cr),
+			Namespace: cr.Namespace,
+		},
+	}
+	err := r.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "delete table maintenance job")
+	}
+	return nil
+}
+
+// tableMaintenanceQuery returns the query generating the maintenance statements, one per row.
+func tableMaintenanceQuery(spec *api.TableMaintenanceSpec) string {
+	dbs := make([]string, 0, len(spec.Databases))
+	for _, db := range spec.Databases {
+		// database names are validated to be plain identifiers
+		dbs = append(dbs, "'"+db+"'")
+	}
+	in := strings.Join(dbs, ", ")
+
+	quote := func(col string) string {
+		return fmt.Sprintf("'`', REPLACE(%s, '`', '``'), '`'", col)
+	}
+	tables := fmt.Sprintf("FROM information_schema.TABLES WHERE TABLE_TYPE = 'BASE TABLE' AND TABLE_SCHEMA IN (%s)", in)
+
+	queries := make([]string, 0, len(spec.Operations))
+	for _, op := range spec.Operations {
+		switch op {
+		case api.TableMaintenanceAnalyze:
+			queries = append(queries, fmt.Sprintf("SELECT CONCAT('ANALYZE NO_WRITE_TO_BINLOG TABLE ', %s, '.', %s, ';') %s",
+				quote("TABLE_SCHEMA"), quote("TABLE_NAME"), tables))
+		case api.TableMaintenanceOptimize:
+			queries = append(queries, fmt.Sprintf("SELECT CONCAT('OPTIMIZE NO_WRITE_TO_BINLOG TABLE ', %s, '.', %s, ';') %s",
+				quote("TABLE_SCHEMA"), quote("TABLE_NAME"), tables))
+		case api.TableMaintenanceHistograms:
+			queries = append(queries, fmt.Sprintf("SELECT CONCAT('ANALYZE NO_WRITE_TO_BINLOG TABLE ', %s, '.', %s, ' UPDATE HISTOGRAM ON ', %s, "+
+				"' WITH ', HISTOGRAM->>'$.\"number-of-buckets-specified\"', ' BUCKETS;') "+
+				"FROM information_schema.COLUMN_STATISTICS WHERE SCHEMA_NAME IN (%s)",
+				quote("SCHEMA_NAME"), quote("TABLE_NAME"), quote("COLUMN_NAME"), in))
+		}
+	}
+
+	return strings.Join(queries, "; ")
+}
+
+func isPrimaryPod(pod *corev1.Pod, primary string) bool {
+	return pod.Status.PodIP == primary || pod.Name == primary || strings.HasPrefix(primary, pod.Name+".")
+}
@@ -0,0 +1,82 @@
+package pxc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+func TestTableMaintenanceQuery(t *testing.T) {
+	spec := &api.TableMaintenanceSpec{
+		Databases:  []string{"app", "billing"},
+		Operations: []api.TableMaintenanceOperation{api.TableMaintenanceOptimize, api.TableMaintenanceHistograms},
+	}
+
+	q := tableMaintenanceQuery(spec)
+
+	queries := strings.Split(q, "; ")
+	if len(queries) != 2 {
+		t.Fatalf("expected 2 queries, got %q", q)
+	}
+	if !strings.Contains(queries[0], "OPTIMIZE NO_WRITE_TO_BINLOG TABLE") || !strings.Contains(queries[0], "TABLE_SCHEMA IN ('app', 'billing')") {
+		t.Errorf("unexpected optimize query %q", queries[0])
+	}
+	if !strings.Contains(queries[1], "UPDATE HISTOGRAM ON") || !strings.Contains(queries[1], "information_schema.COLUMN_STATISTICS") {
+		t.Errorf("unexpected histograms query %q", queries[1])
+	}
+}
+
+func TestCheckTableMaintenanceJob(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.TableMaintenance = &api.TableMaintenanceSpec{
+		Enabled:   true,
+		Schedule:  "0 3 * * 0",
+		Databases: []string{"app"},
+	}
+	cr.Status.TableMaintenance = &api.TableMaintenanceStatus{
+		CurrentPod:  "cr-mock-pxc-1",
+		PendingPods: []string{"cr-mock-pxc-2"},
+	}
+
+	r := buildFakeClient([]runtime.Object{cr})
+
+	job, err := r.tableMaintenanceJob(cr, "cr-mock-pxc-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.client.Create(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.checkTableMaintenanceJob(ctx, cr, job); err != nil {
+		t.Fatal(err)
+	}
+	if cr.Status.TableMaintenance.CurrentPod != "cr-mock-pxc-1" {
+		t.Fatal("running job shouldn't change the current pod")
+	}
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+	if err := r.checkTableMaintenanceJob(ctx, cr, job); err != nil {
+		t.Fatal(err)
+	}
+
+	status := cr.Status.TableMaintenance
+	if status.CurrentPod != "" || len(status.FailedPods) != 1 || status.FailedPods[0] != "cr-mock-pxc-1" {
+		t.Errorf("unexpected status %+v", status)
+	}
+	err = r.client.Get(ctx, types.NamespacedName{Name: naming.TableMaintenanceJobName(cr), Namespace: cr.Namespace}, new(batchv1.Job))
+	if !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the job to be deleted, got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+package pxc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+// orderedTeardown runs the steps of the percona.com/ordered-teardown finalizer of the deleted cluster:
+// it stops the backup schedules and the binlog collector, waits for or cancels the in-flight backups
+// and takes the final backup. It returns the reason the workloads can't be removed yet, or an empty
+// string once the teardown is done. Every step is idempotent, so it's run again on every reconcile.
+func (r *ReconcilePerconaXtraDBCluster) orderedTeardown(ctx context.Context, cr *api.PerconaXtraDBCluster) (string, error) {
+	r.stopClusterJobs(cr)
+
+	if cr.Spec.Backup != nil {
+		if err := r.deletePITR(ctx, cr); err != nil {
+			return "", errors.Wrap(err, "stop binlog collector")
+		}
+	}
+
+	policy := api.TeardownBackupWait
+	var finalBackup *api.TeardownFinalBackupSpec
+	if cr.Spec.Teardown != nil {
+		if cr.Spec.Teardown.InFlightBackups != "" {
+			policy = cr.Spec.Teardown.InFlightBackups
+		}
+		finalBackup = cr.Spec.Teardown.FinalBackup
+	}
+
+	finalName := ""
+	if finalBackup != nil {
+		finalName = naming.FinalBackupName(cr.Name, finalBackup.StorageName, cr.DeletionTimestamp.Time)
+	}
+
+	blocker, err := r.teardownInFlightBackups(ctx, cr, policy, finalName)
+	if err != nil || blocker != "" {
+		return blocker, err
+	}
+
+	if finalBackup == nil {
+		return "", nil
+	}
+	return r.teardownFinalBackup(ctx, cr, finalBackup.StorageName, finalName)
+}
+
+// teardownInFlightBackups deletes the backups of the cluster that haven't started and, depending on the policy,
+// waits for the running ones or deletes them too. The final backup is skipped.
+func (r *ReconcilePerconaXtraDBCluster) teardownInFlightBackups(ctx context.Context, cr *api.PerconaXtraDBCluster, policy api.TeardownBackupPolicy, finalName string) (string, error) {
+	log := logf.FromContext(ctx)
+
+	bcpList := new(api.PerconaXtraDBClusterBackupList)
+	if err := k8s.ListByIndex(ctx, r.client, bcpList, cr.Namespace, k8s.IndexPXCCluster, cr.Name); err != nil {
+		return "", errors.Wrap(err, "list backups")
+	}
+
+	for i := range bcpList.Items {
+		bcp := &bcpList.Items[i]
+		if bcp.Spec.PXCCluster != cr.Name || bcp.Name == finalName || bcp.DeletionTimestamp != nil {
+			continue
+		}
+
+		switch bcp.Status.State {
+		case api.BackupSucceeded, api.BackupFailed:
+			continue
+		case api.BackupStarting, api.BackupRunning:
+			if policy == api.TeardownBackupWait {
+				return fmt.Sprintf("backup %s is %s", bcp.Name, bcp.Status.State), nil
+			}
+		}
+
+		log.Info("Deleting in-flight backup of the deleted cluster", "backup", bcp.Name, "state", bcp.Status.State)
+		if err := r.client.Delete(ctx, bcp); err != nil && !k8serrors.IsNotFound(err) {
+			return "", errors.Wrapf(err, "delete backup %s", bcp.Name)
+		}
+	}
+
+	return "", nil
+}
+
+// teardownFinalBackup creates the final backup of the cluster and waits for it to succeed.
+func (r *ReconcilePerconaXtraDBCluster) teardownFinalBackup(ctx context.Context, cr *api.PerconaXtraDBCluster, storageName, name string) (string, error) {
+	var storage *api.BackupStorageSpec
+	if cr.Spec.Backup != nil {
+		storage = cr.Spec.Backup.Storages[storageName]
+	}
+	if storage == nil {
+		r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventDeletionPostponed,
+			"Final backup storage %s doesn't exist, fix spec.teardown.finalBackup or remove the %s finalizer", storageName, naming.FinalizerOrderedTeardown)
+		return fmt.Sprintf("final backup storage %s doesn't exist", storageName), nil
+	}
+
+	bcp := new(api.PerconaXtraDBClusterBackup)
+	err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: cr.Namespace}, bcp)
+	if k8serrors.IsNotFound(err) {
+		bcp = &api.PerconaXtraDBClusterBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Finalizers: backupFinalizers(cr, storage.Type),
+				Namespace:  cr.Namespace,
+				Name:       name,
+				Labels:     naming.LabelsFinalBackup(cr),
+			},
+			Spec: api.PXCBackupSpec{
+				PXCCluster:  cr.Name,
+				StorageName: storageName,
+			},
+		}
+		if err := r.client.Create(ctx, bcp); err != nil {
+			return "", errors.Wrap(err, "create final backup")
+		}
+		logf.FromContext(ctx).Info("Created final backup of the deleted cluster", "backup", bcp.Name, "storage", storageName)
+		return fmt.Sprintf("final backup %s is created", bcp.Name), nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, "get final backup")
+	}
+
+	switch bcp.Status.State {
+	case api.BackupSucceeded:
+		return "", nil
+	case api.BackupFailed:
+		r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventDeletionPostponed,
+			"Final backup %s failed: %s. Delete the backup to retry or remove the %s finalizer", bcp.Name, bcp.Status.Error, naming.FinalizerOrderedTeardown)
+		return fmt.Sprintf("final backup %s failed", bcp.Name), nil
+	}
+	return fmt.Sprintf("final backup %s is %s", bcp.Name, stateOrNew(bcp.Status.State)), nil
+}
+
+func stateOrNew(state api.PXCBackupState) api.PXCBackupState {
+	if state == api.BackupNew {
+		return "New"
+	}
+	return state
+}
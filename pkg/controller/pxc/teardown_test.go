@@ -0,0 +1,118 @@
+package pxc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+func TestOrderedTeardownInFlightBackups(t *testing.T) {
+	ctx := context.Background()
+
+	newBackup := func(name string, state api.PXCBackupState) *api.PerconaXtraDBClusterBackup {
+		return &api.PerconaXtraDBClusterBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "pxc"},
+			Spec:       api.PXCBackupSpec{PXCCluster: "cluster1", StorageName: "s3"},
+			Status:     api.PXCBackupStatus{State: state},
+		}
+	}
+
+	tests := map[string]struct {
+		policy    api.TeardownBackupPolicy
+		blocker   string
+		remaining []string
+	}{
+		"wait":   {policy: api.TeardownBackupWait, blocker: "backup running is Running", remaining: []string{"running", "succeeded"}},
+		"cancel": {policy: api.TeardownBackupCancel, remaining: []string{"succeeded"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := newCR("cluster1", "pxc")
+			cr.Spec.Teardown = &api.TeardownSpec{InFlightBackups: tt.policy}
+			cr.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+			r := buildFakeClient([]runtime.Object{
+				newBackup("new", api.BackupNew),
+				newBackup("running", api.BackupRunning),
+				newBackup("succeeded", api.BackupSucceeded),
+			})
+			r.crons = NewCronRegistry()
+
+			blocker, err := r.orderedTeardown(ctx, cr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if blocker != tt.blocker {
+				t.Errorf("expected blocker %q, got %q", tt.blocker, blocker)
+			}
+
+			backups := new(api.PerconaXtraDBClusterBackupList)
+			if err := r.client.List(ctx, backups); err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, bcp := range backups.Items {
+				names = append(names, bcp.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.remaining, ",") {
+				t.Errorf("expected backups %v, got %v", tt.remaining, names)
+			}
+		})
+	}
+}
+
+func TestOrderedTeardownFinalBackup(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cluster1", "pxc")
+	cr.Spec.CRVersion = "1.16.0"
+	cr.Spec.Backup = &api.PXCScheduledBackup{
+		Storages: map[string]*api.BackupStorageSpec{
+			"s3": {Type: api.BackupStorageS3, S3: &api.BackupStorageS3Spec{Bucket: "backups"}},
+		},
+	}
+	cr.Spec.Teardown = &api.TeardownSpec{FinalBackup: &api.TeardownFinalBackupSpec{StorageName: "s3"}}
+	cr.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	r := buildFakeClient(nil)
+	r.crons = NewCronRegistry()
+
+	blocker, err := r.orderedTeardown(ctx, cr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(blocker, "final backup final-cluster1-s3-") {
+		t.Fatalf("expected the teardown to wait for the final backup, got %q", blocker)
+	}
+
+	bcp := new(api.PerconaXtraDBClusterBackup)
+	name := naming.FinalBackupName(cr.Name, "s3", cr.DeletionTimestamp.Time)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: cr.Namespace}, bcp); err != nil {
+		t.Fatal(err)
+	}
+	if bcp.Labels[naming.LabelPerconaBackupType] != "final" || bcp.Spec.StorageName != "s3" {
+		t.Errorf("unexpected final backup %+v", bcp)
+	}
+
+	for state, done := range map[api.PXCBackupState]bool{api.BackupRunning: false, api.BackupFailed: false, api.BackupSucceeded: true} {
+		bcp.Status.State = state
+		if err := r.client.Update(ctx, bcp); err != nil {
+			t.Fatal(err)
+		}
+		blocker, err := r.orderedTeardown(ctx, cr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (blocker == "") != done {
+			t.Errorf("unexpected blocker %q for the final backup in state %s", blocker, state)
+		}
+	}
+}
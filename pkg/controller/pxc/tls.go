@@ -2,7 +2,10 @@ package pxc
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"strconv"
 	"time"
 
 	cm "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -13,9 +16,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxctls"
 )
@@ -46,6 +51,7 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileSSL(ctx context.Context, cr *ap
 		&secretInternalObj,
 	)
 	if errSecret == nil && errInternalSecret == nil {
+		observeCertificateExpiry(ctx, cr, &secretObj, &secretInternalObj)
 		return nil
 	} else if errSecret != nil && !k8serr.IsNotFound(errSecret) {
 		return fmt.Errorf("get secret: %v", errSecret)
@@ -357,6 +363,13 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileTLSToggle(ctx context.Context,
 		return nil
 	}
 
+	gitOps := cr.Spec.GitOps.IsEnabled()
+	if gitOps && !cr.Spec.Pause {
+		cr.Status.SuggestSpecChange("spec.pause", "true", "the cluster has to be paused to toggle TLS")
+		return nil
+	}
+
+	// the cluster is already paused in the GitOps mode, the spec isn't patched
 	clusterPaused, err := k8s.PauseCluster(ctx, r.client, cr)
 	if err != nil {
 		return errors.Wrap(err, "failed to pause cluster")
@@ -375,18 +388,42 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileTLSToggle(ctx context.Context,
 		return errors.Errorf("unknown value for %s condition status: %s", naming.ConditionTLS, condition.Status)
 	}
 
-	patch := client.MergeFrom(cr.DeepCopy())
-	cr.Spec.Unsafe.TLS = !*cr.Spec.TLS.Enabled
-	if err := r.client.Patch(ctx, cr.DeepCopy(), patch); err != nil {
-		return errors.Wrap(err, "failed to patch cr")
-	}
+	if gitOps {
+		if cr.Spec.Unsafe.TLS == *cr.Spec.TLS.Enabled {
+			cr.Status.SuggestSpecChange("spec.unsafeFlags.tls", strconv.FormatBool(!*cr.Spec.TLS.Enabled), "TLS is toggled")
+		}
+		cr.Status.SuggestSpecChange("spec.pause", "false", "TLS is toggled, the cluster can be started")
+	} else {
+		patch := client.MergeFrom(cr.DeepCopy())
+		cr.Spec.Unsafe.TLS = !*cr.Spec.TLS.Enabled
+		if err := r.client.Patch(ctx, cr.DeepCopy(), patch); err != nil {
+			return errors.Wrap(err, "failed to patch cr")
+		}
 
-	_, err = k8s.UnpauseCluster(ctx, r.client, cr)
-	if err != nil {
-		return errors.Wrap(err, "failed to start cluster")
+		_, err = k8s.UnpauseCluster(ctx, r.client, cr)
+		if err != nil {
+			return errors.Wrap(err, "failed to start cluster")
+		}
 	}
 
 	condition.Status = api.ConditionStatus(naming.GetConditionTLSState(cr))
 	condition.LastTransitionTime = metav1.NewTime(time.Now().Truncate(time.Second))
 	return nil
 }
+
+// observeCertificateExpiry exports expiration time of TLS certificates,
+// so alerts can be fired before certificates expire.
+func observeCertificateExpiry(ctx context.Context, cr *api.PerconaXtraDBCluster, secrets ...*corev1.Secret) {
+	for _, secret := range secrets {
+		block, _ := pem.Decode(secret.Data["tls.crt"])
+		if block == nil {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			logf.FromContext(ctx).V(1).Info("failed to parse certificate", "secret", secret.Name, "error", err.Error())
+			continue
+		}
+		metrics.SetCertificateExpiry(cr, secret.Name, cert.NotAfter)
+	}
+}
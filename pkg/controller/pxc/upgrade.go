@@ -21,7 +21,9 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/audit"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
@@ -58,6 +60,31 @@ func (r *ReconcilePerconaXtraDBCluster) updatePod(ctx context.Context, sfs api.S
 		return errors.Wrap(err, "upgradePod/updateApp error: update secret error")
 	}
 
+	var authConfigHash, loggingConfigHash, reportingConfigHash, providerOptionsHash string
+	if !isHAproxy(sfs) && !isProxySQL(sfs) {
+		if err := r.reconcileAuthConfig(ctx, cr); err != nil {
+			return errors.Wrap(err, "upgradePod/updateApp error: update auth config error")
+		}
+		authConfigHash, err = r.getAuthConfigHash(cr)
+		if err != nil {
+			return errors.Wrap(err, "upgradePod/updateApp error: get auth config hash")
+		}
+		loggingConfigHash, err = r.getLoggingConfigHash(cr)
+		if err != nil {
+			return errors.Wrap(err, "upgradePod/updateApp error: get logging config hash")
+		}
+		if reporting := cr.Spec.PXC.Reporting; reporting.IsEnabled() {
+			reportingConfigHash, err = getCustomConfigHashHex(map[string]string{"configuration": reporting.Configuration}, nil)
+			if err != nil {
+				return errors.Wrap(err, "upgradePod/updateApp error: get reporting config hash")
+			}
+		}
+		providerOptionsHash, err = getProviderOptionsHash(cr)
+		if err != nil {
+			return errors.Wrap(err, "upgradePod/updateApp error: get provider options hash")
+		}
+	}
+
 	var vaultConfigHash, sslHash, sslInternalHash string
 	if !isHAproxy(sfs) {
 		vaultConfigHash, err = r.getSecretHash(cr, cr.Spec.VaultSecretName, true)
@@ -80,6 +107,10 @@ func (r *ReconcilePerconaXtraDBCluster) updatePod(ctx context.Context, sfs api.S
 		"percona.com/ssl-internal-hash":      sslInternalHash,
 		"percona.com/vault-config-hash":      vaultConfigHash,
 		"percona.com/env-secret-config-hash": envVarsHash,
+		"percona.com/auth-config-hash":       authConfigHash,
+		"percona.com/logging-config-hash":    loggingConfigHash,
+		"percona.com/reporting-config-hash":  reportingConfigHash,
+		"percona.com/provider-options-hash":  providerOptionsHash,
 	}
 
 	secrets := new(corev1.Secret)
@@ -130,9 +161,26 @@ func (r *ReconcilePerconaXtraDBCluster) updatePod(ctx context.Context, sfs api.S
 		sts.Spec.Template.Annotations = annotations
 		sts.Spec.Template.Labels = labels
 
+		if isPXC(sfs) {
+			if err := r.checkStorageLocality(ctx, cr, sts); err != nil {
+				return errors.Wrap(err, "check storage locality")
+			}
+		}
+
 		if err := k8s.SetControllerReference(cr, sts, r.scheme); err != nil {
 			return errors.Wrap(err, "set controller reference")
 		}
+
+		actions := stsPendingActions(currentSet, sts)
+		cr.SetPendingActions(sfs.Name(), actions)
+		if len(actions) > 0 && !cr.ChangesApproved() {
+			log.Info("changes are waiting for approval", "sfs", sfs.Name(), "id", cr.Status.PendingChanges.ID)
+			r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventChangesPendingApproval,
+				"Changes of %s are waiting for approval, annotate the cluster with %s=%s to apply them",
+				sfs.Name(), api.AnnotationApprovedChanges, cr.Status.PendingChanges.ID)
+			return nil
+		}
+
 		err = r.createOrUpdate(ctx, cr, sts)
 		if err != nil {
 			return errors.Wrap(err, "update error")
@@ -205,10 +253,24 @@ func (r *ReconcilePerconaXtraDBCluster) smartUpdate(ctx context.Context, sfs api
 		}
 	}
 	if !statefulSetChanged {
+		if c := cr.Status.Canary; c != nil && c.Revision != currentSet.Status.UpdateRevision {
+			cr.Status.Canary = nil
+		}
+		if s := cr.Status.SmartUpdate; s != nil && s.Revision == currentSet.Status.UpdateRevision && s.Phase != api.SmartUpdateCompleted {
+			r.setSmartUpdateProgress(ctx, cr, currentSet, api.SmartUpdateCompleted, "")
+		}
 		return nil
 	}
 
 	log.Info("statefulSet was changed, run smart update")
+	metrics.SetSmartUpdateInProgress(cr, sfs.StatefulSet().Name, true)
+
+	if ok, reason := cr.Spec.UpgradeOptions.MaintenanceAllowed(time.Now()); !ok {
+		log.Info("can't start/continue 'SmartUpdate'", "reason", reason)
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventSmartUpdatePostponed, "Smart update of %s is postponed: %s", currentSet.Name, reason)
+		r.setSmartUpdateProgress(ctx, cr, currentSet, api.SmartUpdatePostponed, "")
+		return nil
+	}
 
 	running, err := r.isBackupRunning(cr)
 	if err != nil {
@@ -217,6 +279,26 @@ func (r *ReconcilePerconaXtraDBCluster) smartUpdate(ctx context.Context, sfs api
 	}
 	if running {
 		log.Info("can't start/continue 'SmartUpdate': backup is running")
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventSmartUpdatePostponed, "Smart update of %s is postponed: backup is running", currentSet.Name)
+		r.setSmartUpdateProgress(ctx, cr, currentSet, api.SmartUpdatePostponed, "")
+		return nil
+	}
+
+	ready, err := r.preUpgradeCheck(ctx, cr, currentSet, list.Items)
+	if err != nil {
+		return errors.Wrap(err, "pre-upgrade check")
+	}
+	if !ready {
+		r.setSmartUpdateProgress(ctx, cr, currentSet, api.SmartUpdatePostponed, "")
+		return nil
+	}
+
+	ready, err = r.preUpgradeBackup(ctx, cr, currentSet, list.Items)
+	if err != nil {
+		return errors.Wrap(err, "pre-upgrade backup")
+	}
+	if !ready {
+		r.setSmartUpdateProgress(ctx, cr, currentSet, api.SmartUpdatePostponed, "")
 		return nil
 	}
 
@@ -242,6 +324,19 @@ func (r *ReconcilePerconaXtraDBCluster) smartUpdate(ctx context.Context, sfs api
 		return list.Items[i].Name > list.Items[j].Name
 	})
 
+	if cr.Spec.UpgradeOptions.Canary.IsEnabled() {
+		proceed, err := r.canaryUpdate(ctx, cr, currentSet, list.Items, primary, waitLimit)
+		if err != nil {
+			return errors.Wrap(err, "canary")
+		}
+		if !proceed {
+			return nil
+		}
+	} else {
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventSmartUpdateStarted,
+			"Smart update of %s started to revision %s, primary %s will be restarted last", currentSet.Name, currentSet.Status.UpdateRevision, primary)
+	}
+
 	var primaryPod corev1.Pod
 	for _, pod := range list.Items {
 		pod := pod
@@ -249,6 +344,7 @@ func (r *ReconcilePerconaXtraDBCluster) smartUpdate(ctx context.Context, sfs api
 			primaryPod = pod
 		} else {
 			log.Info("apply changes to secondary pod", "pod", pod.Name)
+			r.setSmartUpdateProgress(ctx, cr, currentSet, api.SmartUpdateSecondaries, pod.Name)
 			if err := r.applyNWait(ctx, cr, currentSet, &pod, waitLimit); err != nil {
 				return errors.Wrap(err, "failed to apply changes")
 			}
@@ -256,11 +352,21 @@ func (r *ReconcilePerconaXtraDBCluster) smartUpdate(ctx context.Context, sfs api
 	}
 
 	log.Info("apply changes to primary pod", "pod", primaryPod.Name)
+	r.setSmartUpdateProgress(ctx, cr, currentSet, api.SmartUpdatePrimary, primaryPod.Name)
 	if err := r.applyNWait(ctx, cr, currentSet, &primaryPod, waitLimit); err != nil {
 		return errors.Wrap(err, "failed to apply changes")
 	}
 
 	log.Info("smart update finished")
+	r.setSmartUpdateProgress(ctx, cr, currentSet, api.SmartUpdateCompleted, "")
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventSmartUpdateFinished, "Smart update of %s finished", currentSet.Name)
+	audit.RecordOperation(ctx, r.client, cr.Namespace, cr.Name, audit.OperationEntry{
+		Operation: audit.OperationUpgrade,
+		Result:    audit.OperationSucceeded,
+		Object:    currentSet.Name,
+		Message:   "smart update to revision " + currentSet.Status.UpdateRevision + " finished",
+	})
+	metrics.SetSmartUpdateInProgress(cr, sfs.StatefulSet().Name, false)
 
 	return nil
 }
@@ -274,6 +380,8 @@ func (r *ReconcilePerconaXtraDBCluster) applyNWait(ctx context.Context, cr *api.
 		if err := r.client.Delete(ctx, pod); err != nil {
 			return errors.Wrap(err, "failed to delete pod")
 		}
+		metrics.IncSmartUpdatePodRestarts(cr, sfs.Name)
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventSmartUpdatePodRestart, "Pod %s deleted to apply revision %s", pod.Name, sfs.Status.UpdateRevision)
 	}
 
 	orderInSts, err := getPodOrderInSts(sfs.Name, pod.Name)
@@ -301,6 +409,8 @@ func (r *ReconcilePerconaXtraDBCluster) applyNWait(ctx context.Context, cr *api.
 		return errors.Wrap(err, "failed to wait pxc status")
 	}
 
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventSmartUpdatePodUpdated, "Pod %s is running revision %s", pod.Name, sfs.Status.UpdateRevision)
+
 	return nil
 }
 
@@ -587,7 +697,7 @@ func isProxySQL(sfs api.StatefulApp) bool {
 
 func (r *ReconcilePerconaXtraDBCluster) isBackupRunning(cr *api.PerconaXtraDBCluster) (bool, error) {
 	bcpList := api.PerconaXtraDBClusterBackupList{}
-	if err := r.client.List(context.TODO(), &bcpList, &client.ListOptions{Namespace: cr.Namespace}); err != nil {
+	if err := k8s.ListByIndex(context.TODO(), r.client, &bcpList, cr.Namespace, k8s.IndexPXCCluster, cr.Name); err != nil {
 		if k8serrors.IsNotFound(err) {
 			return false, nil
 		}
@@ -610,27 +720,48 @@ func (r *ReconcilePerconaXtraDBCluster) isBackupRunning(cr *api.PerconaXtraDBClu
 func (r *ReconcilePerconaXtraDBCluster) isRestoreRunning(clusterName, namespace string) (bool, error) {
 	restoreList := api.PerconaXtraDBClusterRestoreList{}
 
-	err := r.client.List(context.TODO(), &restoreList, &client.ListOptions{
-		Namespace: namespace,
-	})
+	err := k8s.ListByIndex(context.TODO(), r.client, &restoreList, namespace, k8s.IndexPXCCluster, clusterName)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to get restore list")
 	}
 
 	for _, v := range restoreList.Items {
-		if v.Spec.PXCCluster != clusterName {
+		// rehearsals restore to a temporary cluster
+		if v.Spec.PXCCluster != clusterName || v.Spec.Rehearsal.IsEnabled() {
 			continue
 		}
 
 		switch v.Status.State {
-		case api.RestoreStarting, api.RestoreStopCluster, api.RestoreRestore,
-			api.RestoreStartCluster, api.RestorePITR:
+		case api.RestoreStarting, api.RestoreAwaitingRetrieval, api.RestoreStopCluster, api.RestoreRestore,
+			api.RestoreStartCluster, api.RestorePITR, api.RestoreMaskData:
 			return true, nil
 		}
 	}
 	return false, nil
 }
 
+// deletionBlocker returns the reason to postpone the cluster deletion:
+// a backup or a restore of the cluster is in progress.
+func (r *ReconcilePerconaXtraDBCluster) deletionBlocker(cr *api.PerconaXtraDBCluster) (string, error) {
+	running, err := r.isBackupRunning(cr)
+	if err != nil {
+		return "", err
+	}
+	if running {
+		return "backup is running", nil
+	}
+
+	running, err = r.isRestoreRunning(cr.Name, cr.Namespace)
+	if err != nil {
+		return "", err
+	}
+	if running {
+		return "restore is running", nil
+	}
+
+	return "", nil
+}
+
 func getCustomConfigHashHex(strData map[string]string, binData map[string][]byte) (string, error) {
 	content := struct {
 		StrData map[string]string `json:"str_data,omitempty"`
@@ -0,0 +1,130 @@
+package pxc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+// preUpgradeBackup returns true if SmartUpdate can change the PXC image:
+// the revision has a successful backup to roll back to.
+func (r *ReconcilePerconaXtraDBCluster) preUpgradeBackup(ctx context.Context, cr *api.PerconaXtraDBCluster, sts *appsv1.StatefulSet, pods []corev1.Pod) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	spec := cr.Spec.UpgradeOptions.PreUpgradeBackup
+	if !spec.IsEnabled() || !pxcImageChanged(sts, pods) {
+		return true, nil
+	}
+
+	revision := sts.Status.UpdateRevision
+
+	if status := cr.Status.PreUpgradeBackup; status != nil && status.Revision == revision {
+		bcp := new(api.PerconaXtraDBClusterBackup)
+		err := r.client.Get(ctx, types.NamespacedName{Name: status.BackupName, Namespace: cr.Namespace}, bcp)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return false, errors.Wrapf(err, "get backup %s", status.BackupName)
+		}
+
+		switch {
+		case k8serrors.IsNotFound(err):
+			log.Info("pre-upgrade backup is deleted, looking for another one", "backup", status.BackupName)
+		case bcp.Status.State == api.BackupSucceeded:
+			return true, nil
+		case bcp.Status.State == api.BackupFailed:
+			log.Info("smart update is halted: pre-upgrade backup failed", "backup", bcp.Name)
+			r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventSmartUpdatePostponed,
+				"Smart update of %s is halted: pre-upgrade backup %s failed, delete it to take a new one", sts.Name, bcp.Name)
+			return false, nil
+		default:
+			log.Info("waiting for pre-upgrade backup", "backup", bcp.Name, "state", bcp.Status.State)
+			return false, nil
+		}
+	}
+
+	if spec.MaxAge != nil {
+		bcp, err := r.latestSucceededBackup(ctx, cr)
+		if err != nil {
+			return false, err
+		}
+		if bcp != nil && time.Since(bcp.Status.CompletedAt.Time) <= spec.MaxAge.Duration {
+			log.Info("using existing backup as pre-upgrade restore point", "backup", bcp.Name)
+			cr.Status.PreUpgradeBackup = &api.PreUpgradeBackupStatus{
+				Revision:   revision,
+				BackupName: bcp.Name,
+			}
+			return true, nil
+		}
+	}
+
+	bcp := &api.PerconaXtraDBClusterBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-pre-upgrade-%s", cr.Name, revision[strings.LastIndex(revision, "-")+1:]),
+			Namespace: cr.Namespace,
+			Labels:    naming.LabelsCluster(cr),
+		},
+		Spec: api.PXCBackupSpec{
+			PXCCluster:  cr.Name,
+			StorageName: spec.StorageName,
+		},
+	}
+	if err := r.client.Create(ctx, bcp); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return false, errors.Wrap(err, "create pre-upgrade backup")
+	}
+
+	cr.Status.PreUpgradeBackup = &api.PreUpgradeBackupStatus{
+		Revision:   revision,
+		BackupName: bcp.Name,
+	}
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventSmartUpdatePostponed,
+		"Smart update of %s is postponed: taking pre-upgrade backup %s", sts.Name, bcp.Name)
+
+	return false, nil
+}
+
+func (r *ReconcilePerconaXtraDBCluster) latestSucceededBackup(ctx context.Context, cr *api.PerconaXtraDBCluster) (*api.PerconaXtraDBClusterBackup, error) {
+	list := new(api.PerconaXtraDBClusterBackupList)
+	if err := k8s.ListByIndex(ctx, r.client, list, cr.Namespace, k8s.IndexPXCCluster, cr.Name); err != nil {
+		return nil, errors.Wrap(err, "list backups")
+	}
+
+	var latest *api.PerconaXtraDBClusterBackup
+	for i := range list.Items {
+		bcp := &list.Items[i]
+		if bcp.Spec.PXCCluster != cr.Name || bcp.Status.State != api.BackupSucceeded || bcp.Status.CompletedAt == nil {
+			continue
+		}
+		if latest == nil || bcp.Status.CompletedAt.After(latest.Status.CompletedAt.Time) {
+			latest = bcp
+		}
+	}
+
+	return latest, nil
+}
+
+// pxcImageChanged returns true if any pod runs a PXC image
+// different from the statefulset template.
+func pxcImageChanged(sts *appsv1.StatefulSet, pods []corev1.Pod) bool {
+	image := pxcImage(sts)
+	for _, pod := range pods {
+		for _, c := range pod.Spec.Containers {
+			if c.Name == "pxc" && c.Image != image {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,258 @@
+package pxc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+// maxUpgradeCheckReport limits the number of problems stored in the cluster status.
+const maxUpgradeCheckReport = 20
+
+var imageVersionRe = regexp.MustCompile(`:(\d+\.\d+\.\d+)`)
+
+// upgradeCheckReport is the JSON output of util.checkForServerUpgrade.
+type upgradeCheckReport struct {
+	ErrorCount   int32 `json:"errorCount"`
+	WarningCount int32 `json:"warningCount"`
+	Checks       []struct {
+		Title            string `json:"title"`
+		DetectedProblems []struct {
+			Level       string `json:"level"`
+			DBObject    string `json:"dbObject"`
+			Description string `json:"description"`
+		} `json:"detectedProblems"`
+	} `json:"checks"`
+}
+
+// preUpgradeCheck returns true if SmartUpdate can change the PXC image:
+// the upgrade checker found no errors for the revision.
+// The check is started again if its job is deleted.
+func (r *ReconcilePerconaXtraDBCluster) preUpgradeCheck(ctx context.Context, cr *api.PerconaXtraDBCluster, sts *appsv1.StatefulSet, pods []corev1.Pod) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	if !cr.Spec.UpgradeOptions.PreUpgradeCheck.IsEnabled() || !pxcImageChanged(sts, pods) {
+		return true, nil
+	}
+
+	revision := sts.Status.UpdateRevision
+	status := cr.Status.PreUpgradeCheck
+	if status != nil && status.Revision == revision && status.State == api.PreUpgradeCheckPassed {
+		return true, nil
+	}
+
+	job := new(batchv1.Job)
+	err := r.client.Get(ctx, types.NamespacedName{Name: upgradeCheckJobName(cr, revision), Namespace: cr.Namespace}, job)
+	if k8serrors.IsNotFound(err) {
+		job, err = r.upgradeCheckJob(cr, sts)
+		if err != nil {
+			return false, errors.Wrap(err, "build upgrade check job")
+		}
+		if err := r.client.Create(ctx, job); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return false, errors.Wrap(err, "create upgrade check job")
+		}
+
+		cr.Status.PreUpgradeCheck = &api.PreUpgradeCheckStatus{
+			Revision:    revision,
+			TargetImage: pxcImage(sts),
+			State:       api.PreUpgradeCheckRunning,
+		}
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventSmartUpdatePostponed,
+			"Smart update of %s is postponed: checking the cluster for upgrade to %s", sts.Name, pxcImage(sts))
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "get upgrade check job")
+	}
+
+	if status == nil || status.Revision != revision {
+		status = &api.PreUpgradeCheckStatus{
+			Revision:    revision,
+			TargetImage: pxcImage(sts),
+		}
+		cr.Status.PreUpgradeCheck = status
+	}
+
+	if job.Status.Succeeded == 0 && job.Status.Failed == 0 {
+		status.State = api.PreUpgradeCheckRunning
+		log.Info("waiting for upgrade check", "job", job.Name)
+		return false, nil
+	}
+
+	if status.State == api.PreUpgradeCheckFailed {
+		log.Info("smart update is halted: upgrade check failed", "job", job.Name, "reason", status.Message)
+		return false, nil
+	}
+
+	output, err := r.upgradeCheckOutput(ctx, job)
+	if err != nil {
+		return false, errors.Wrap(err, "get upgrade check output")
+	}
+
+	setUpgradeCheckResult(status, output)
+	if status.State == api.PreUpgradeCheckPassed {
+		log.Info("upgrade check passed", "warnings", status.Warnings)
+		return true, nil
+	}
+
+	r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventSmartUpdatePostponed,
+		"Smart update of %s is halted: %s, delete job %s to check again", sts.Name, status.Message, job.Name)
+	return false, nil
+}
+
+func setUpgradeCheckResult(status *api.PreUpgradeCheckStatus, output string) {
+	status.State = api.PreUpgradeCheckFailed
+
+	start := strings.Index(output, "{")
+	report := upgradeCheckReport{}
+	if start < 0 {
+		status.Message = "upgrade check didn't produce a report: " + lastLine(output)
+		return
+	}
+	if err := json.NewDecoder(strings.NewReader(output[start:])).Decode(&report); err != nil {
+		status.Message = fmt.Sprintf("failed to parse upgrade check report: %v", err)
+		return
+	}
+
+	status.Errors = report.ErrorCount
+	status.Warnings = report.WarningCount
+	status.Report = nil
+	for _, check := range report.Checks {
+		for _, p := range check.DetectedProblems {
+			if len(status.Report) == maxUpgradeCheckReport {
+				break
+			}
+			status.Report = append(status.Report, fmt.Sprintf("[%s] %s: %s %s", p.Level, check.Title, p.DBObject, p.Description))
+		}
+	}
+
+	if report.ErrorCount > 0 {
+		status.Message = fmt.Sprintf("upgrade check found %d errors", report.ErrorCount)
+		return
+	}
+
+	status.State = api.PreUpgradeCheckPassed
+	status.Message = fmt.Sprintf("upgrade check found %d warnings", report.WarningCount)
+}
+
+func (r *ReconcilePerconaXtraDBCluster) upgradeCheckOutput(ctx context.Context, job *batchv1.Job) (string, error) {
+	pods := new(corev1.PodList)
+	err := r.client.List(ctx, pods, &client.ListOptions{
+		Namespace:     job.Namespace,
+		LabelSelector: labels.SelectorFromSet(job.Spec.Selector.MatchLabels),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "list job pods")
+	}
+	if len(pods.Items) == 0 {
+		return "", errors.Errorf("no pods for job %s", job.Name)
+	}
+
+	lines, err := r.clientcmd.PodLogs(job.Namespace, pods.Items[0].Name, &corev1.PodLogOptions{Container: "upgrade-check"})
+	if err != nil {
+		return "", errors.Wrapf(err, "get logs of %s", pods.Items[0].Name)
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+func (r *ReconcilePerconaXtraDBCluster) upgradeCheckJob(cr *api.PerconaXtraDBCluster, sts *appsv1.StatefulSet) (*batchv1.Job, error) {
+	revision := sts.Status.UpdateRevision
+	name := upgradeCheckJobName(cr, revision)
+
+	image := cr.Spec.UpgradeOptions.PreUpgradeCheck.Image
+	if image == "" {
+		image = pxcImage(sts)
+	}
+
+	args := "--output-format=JSON"
+	if m := imageVersionRe.FindStringSubmatch(pxcImage(sts)); m != nil {
+		args += " --target-version=" + m[1]
+	}
+
+	ls := naming.LabelsCluster(cr)
+	ls["job-name"] = name
+
+	manualSelector := true
+	backoffLimit := int32(0)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    ls,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:   &backoffLimit,
+			ManualSelector: &manualSelector,
+			Selector:       &metav1.LabelSelector{MatchLabels: ls},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: ls},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					SecurityContext:  cr.Spec.PXC.PodSecurityContext,
+					ImagePullSecrets: cr.Spec.PXC.ImagePullSecrets,
+					Containers: []corev1.Container{
+						{
+							Name:            "upgrade-check",
+							Image:           image,
+							ImagePullPolicy: cr.Spec.PXC.ImagePullPolicy,
+							SecurityContext: cr.Spec.PXC.ContainerSecurityContext,
+							Command:         []string{"bash", "-c"},
+							Args: []string{
+								`mysqlsh --no-wizard --host="$PXC_SERVICE" --port=3306 --user=` + users.Operator +
+									` --password="$OPERATOR_PASS" -- util check-for-server-upgrade ` + args,
+							},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "PXC_SERVICE",
+									Value: cr.Name + "-pxc." + cr.Namespace,
+								},
+								{
+									Name: "OPERATOR_PASS",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: app.SecretKeySelector(cr.Spec.SecretsName, users.Operator),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	cr.ApplyImageOverrides(&job.Spec.Template.Spec)
+
+	if err := k8s.SetControllerReference(cr, job, r.scheme); err != nil {
+		return nil, errors.Wrap(err, "set controller reference")
+	}
+
+	return job, nil
+}
+
+func upgradeCheckJobName(cr *api.PerconaXtraDBCluster, revision string) string {
+	return fmt.Sprintf("%s-upgrade-check-%s", cr.Name, revision[strings.LastIndex(revision, "-")+1:])
+}
+
+func lastLine(s string) string {
+	s = strings.TrimSpace(s)
+	return s[strings.LastIndex(s, "\n")+1:]
+}
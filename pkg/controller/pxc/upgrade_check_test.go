@@ -0,0 +1,60 @@
+package pxc
+
+import (
+	"testing"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestSetUpgradeCheckResult(t *testing.T) {
+	tests := map[string]struct {
+		output   string
+		state    api.PreUpgradeCheckState
+		errors   int32
+		warnings int32
+		report   []string
+	}{
+		"no problems": {
+			output: `WARNING: Using a password on the command line interface can be insecure.
+{"serverAddress": "cluster1-pxc:3306", "errorCount": 0, "warningCount": 0, "noticeCount": 0, "checks": []}`,
+			state: api.PreUpgradeCheckPassed,
+		},
+		"warnings only": {
+			output:   `{"errorCount": 0, "warningCount": 1, "checks": [{"id": "defaultAuthenticationPlugin", "title": "New default authentication plugin considerations", "status": "OK", "detectedProblems": [{"level": "Warning", "dbObject": "app@%", "description": "uses mysql_native_password"}]}]}`,
+			state:    api.PreUpgradeCheckPassed,
+			warnings: 1,
+			report:   []string{"[Warning] New default authentication plugin considerations: app@% uses mysql_native_password"},
+		},
+		"errors": {
+			output: `{"errorCount": 1, "warningCount": 0, "checks": [{"id": "removedSysVars", "title": "Removed system variables", "status": "OK", "detectedProblems": [{"level": "Error", "dbObject": "query_cache_size", "description": "is set and will be removed"}]}]}`,
+			state:  api.PreUpgradeCheckFailed,
+			errors: 1,
+			report: []string{"[Error] Removed system variables: query_cache_size is set and will be removed"},
+		},
+		"no report": {
+			output: "ERROR: 2003 (HY000): Can't connect to MySQL server on 'cluster1-pxc:3306'",
+			state:  api.PreUpgradeCheckFailed,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			status := new(api.PreUpgradeCheckStatus)
+			setUpgradeCheckResult(status, tt.output)
+
+			if status.State != tt.state {
+				t.Errorf("expected state %s, got %s: %s", tt.state, status.State, status.Message)
+			}
+			if status.Errors != tt.errors || status.Warnings != tt.warnings {
+				t.Errorf("expected %d errors and %d warnings, got %d and %d", tt.errors, tt.warnings, status.Errors, status.Warnings)
+			}
+			if len(status.Report) != len(tt.report) {
+				t.Fatalf("expected report %v, got %v", tt.report, status.Report)
+			}
+			for i := range tt.report {
+				if status.Report[i] != tt.report[i] {
+					t.Errorf("expected %q, got %q", tt.report[i], status.Report[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package pxc
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestDeletionBlocker(t *testing.T) {
+	scheme.Scheme.AddKnownTypes(api.SchemeGroupVersion,
+		new(api.PerconaXtraDBClusterBackup), new(api.PerconaXtraDBClusterBackupList),
+		new(api.PerconaXtraDBClusterRestore), new(api.PerconaXtraDBClusterRestoreList))
+
+	cr := newCR("cluster1", "pxc")
+	backup := func(cluster string, state api.PXCBackupState) *api.PerconaXtraDBClusterBackup {
+		return &api.PerconaXtraDBClusterBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: cluster + "-" + string(state), Namespace: cr.Namespace},
+			Spec:       api.PXCBackupSpec{PXCCluster: cluster},
+			Status:     api.PXCBackupStatus{State: state},
+		}
+	}
+	restore := func(cluster string, state api.BcpRestoreStates) *api.PerconaXtraDBClusterRestore {
+		return &api.PerconaXtraDBClusterRestore{
+			ObjectMeta: metav1.ObjectMeta{Name: cluster + "-restore", Namespace: cr.Namespace},
+			Spec:       api.PerconaXtraDBClusterRestoreSpec{PXCCluster: cluster},
+			Status:     api.PerconaXtraDBClusterRestoreStatus{State: state},
+		}
+	}
+
+	tests := map[string]struct {
+		objs     []runtime.Object
+		expected string
+	}{
+		"nothing running": {
+			objs: []runtime.Object{backup("cluster1", api.BackupSucceeded), restore("cluster1", api.RestoreFailed)},
+		},
+		"backup running": {
+			objs:     []runtime.Object{backup("cluster1", api.BackupRunning)},
+			expected: "backup is running",
+		},
+		"restore running": {
+			objs:     []runtime.Object{restore("cluster1", api.RestoreRestore)},
+			expected: "restore is running",
+		},
+		"other cluster": {
+			objs: []runtime.Object{backup("cluster2", api.BackupRunning), restore("cluster2", api.RestorePITR)},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := buildFakeClient(append(tt.objs, cr))
+
+			blocker, err := r.deletionBlocker(cr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if blocker != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, blocker)
+			}
+		})
+	}
+}
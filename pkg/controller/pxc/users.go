@@ -21,6 +21,8 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/audit"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
 )
@@ -63,7 +65,7 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileUsers(ctx context.Context, cr *
 		return nil, errors.Wrapf(err, "get sys users secret '%s'", cr.Spec.SecretsName)
 	}
 
-	internalSecretName := internalSecretsPrefix + cr.Name
+	internalSecretName := users.InternalSecretsPrefix + cr.Name
 
 	internalSecrets := corev1.Secret{}
 	err = r.client.Get(context.TODO(),
@@ -186,7 +188,7 @@ func (r *ReconcilePerconaXtraDBCluster) updateUsers(ctx context.Context, cr *api
 			if err := r.handleProxyadminUser(ctx, cr, secrets, internalSecrets, res); err != nil {
 				return res, err
 			}
-		case users.PMMServer, users.PMMServerKey:
+		case users.PMMServer, users.PMMServerKey, users.PMMServerToken:
 			if err := r.handlePMMUser(ctx, cr, secrets, internalSecrets, res); err != nil {
 				return res, err
 			}
@@ -234,7 +236,7 @@ func (r *ReconcilePerconaXtraDBCluster) handleRootUser(ctx context.Context, cr *
 
 	log.Info("Password changed, updating user", "user", user.Name)
 
-	err = r.updateUserPassWithRetention(cr, secrets, internalSecrets, user)
+	err = r.updateUserPassWithRetention(ctx, cr, secrets, internalSecrets, user)
 	if err != nil {
 		return errors.Wrap(err, "update root users pass")
 	}
@@ -311,7 +313,7 @@ func (r *ReconcilePerconaXtraDBCluster) handleOperatorUser(ctx context.Context,
 
 	log.Info("Password changed, updating user", "user", user.Name)
 
-	err = r.updateUserPassWithRetention(cr, secrets, internalSecrets, user)
+	err = r.updateUserPassWithRetention(ctx, cr, secrets, internalSecrets, user)
 	if err != nil {
 		return errors.Wrap(err, "update operator users pass")
 	}
@@ -357,7 +359,7 @@ func (r *ReconcilePerconaXtraDBCluster) manageOperatorAdminUser(ctx context.Cont
 		return nil
 	}
 
-	pass, err := generatePass()
+	pass, err := users.GeneratePass(cr.Spec.PXC.PasswordPolicy)
 	if err != nil {
 		return errors.Wrap(err, "generate password")
 	}
@@ -388,6 +390,13 @@ func (r *ReconcilePerconaXtraDBCluster) manageOperatorAdminUser(ctx context.Cont
 		return errors.Wrap(err, "update internal users secret")
 	}
 
+	r.audit.Record(ctx, cr, nil, audit.Entry{
+		Action:  audit.ActionUserCreated,
+		User:    users.Operator,
+		Hosts:   []string{"%"},
+		Trigger: "operator",
+	})
+
 	log.Info("User created and privileges granted", "user", users.Operator)
 	return nil
 }
@@ -406,7 +415,7 @@ func (r *ReconcilePerconaXtraDBCluster) handleMonitorUser(ctx context.Context, c
 			return err
 		}
 
-		um, err := getUserManager(cr, internalSecrets)
+		um, err := pxc.NewUsersManager(cr, internalSecrets)
 		if err != nil {
 			return err
 		}
@@ -490,7 +499,7 @@ func (r *ReconcilePerconaXtraDBCluster) handleMonitorUser(ctx context.Context, c
 
 	log.Info("Password changed, updating user", "user", user.Name)
 
-	err = r.updateUserPassWithRetention(cr, secrets, internalSecrets, user)
+	err = r.updateUserPassWithRetention(ctx, cr, secrets, internalSecrets, user)
 	if err != nil {
 		return errors.Wrap(err, "update monitor users pass")
 	}
@@ -561,6 +570,12 @@ func (r *ReconcilePerconaXtraDBCluster) updateMonitorUserGrant(ctx context.Conte
 		return errors.Wrap(err, "update internal sys users secret annotation")
 	}
 
+	r.audit.Record(ctx, cr, nil, audit.Entry{
+		Action:  audit.ActionGrantsChanged,
+		User:    users.Monitor,
+		Trigger: "operator upgrade",
+	})
+
 	log.Info("User monitor: granted privileges")
 	return nil
 }
@@ -612,7 +627,7 @@ func (r *ReconcilePerconaXtraDBCluster) handleXtrabackupUser(ctx context.Context
 
 	log.Info("Password changed, updating user", "user", user.Name)
 
-	err = r.updateUserPassWithRetention(cr, secrets, internalSecrets, user)
+	err = r.updateUserPassWithRetention(ctx, cr, secrets, internalSecrets, user)
 	if err != nil {
 		return errors.Wrap(err, "update xtrabackup users pass")
 	}
@@ -648,7 +663,7 @@ func (r *ReconcilePerconaXtraDBCluster) updateXtrabackupUserGrant(ctx context.Co
 		return nil
 	}
 
-	um, err := getUserManager(cr, secrets)
+	um, err := pxc.NewUsersManager(cr, secrets)
 	if err != nil {
 		return err
 	}
@@ -669,6 +684,12 @@ func (r *ReconcilePerconaXtraDBCluster) updateXtrabackupUserGrant(ctx context.Co
 		return errors.Wrap(err, "update internal sys users secret annotation")
 	}
 
+	r.audit.Record(ctx, cr, nil, audit.Entry{
+		Action:  audit.ActionGrantsChanged,
+		User:    users.Xtrabackup,
+		Trigger: "operator upgrade",
+	})
+
 	log.Info("User xtrabackup: granted privileges")
 	return nil
 }
@@ -722,7 +743,7 @@ func (r *ReconcilePerconaXtraDBCluster) handleReplicationUser(ctx context.Contex
 
 	log.Info("Password changed, updating user", "user", user.Name)
 
-	err = r.updateUserPassWithRetention(cr, secrets, internalSecrets, user)
+	err = r.updateUserPassWithRetention(ctx, cr, secrets, internalSecrets, user)
 	if err != nil {
 		return errors.Wrap(err, "update replication users pass")
 	}
@@ -767,13 +788,13 @@ func (r *ReconcilePerconaXtraDBCluster) manageReplicationUser(ctx context.Contex
 		return nil
 	}
 
-	um, err := getUserManager(cr, secrets)
+	um, err := pxc.NewUsersManager(cr, secrets)
 	if err != nil {
 		return err
 	}
 	defer um.Close()
 
-	pass, err = generatePass()
+	pass, err = users.GeneratePass(cr.Spec.PXC.PasswordPolicy)
 	if err != nil {
 		return errors.Wrap(err, "generate password")
 	}
@@ -795,6 +816,13 @@ func (r *ReconcilePerconaXtraDBCluster) manageReplicationUser(ctx context.Contex
 		return errors.Wrap(err, "update internal users secret")
 	}
 
+	r.audit.Record(ctx, cr, nil, audit.Entry{
+		Action:  audit.ActionUserCreated,
+		User:    users.Replication,
+		Hosts:   []string{"%"},
+		Trigger: "operator",
+	})
+
 	log.Info("User replication: user created and privileges granted")
 	return nil
 }
@@ -830,6 +858,7 @@ func (r *ReconcilePerconaXtraDBCluster) handleProxyadminUser(ctx context.Context
 		return errors.Wrap(err, "update Proxy users")
 	}
 	log.Info("Proxy user updated", "user", user.Name)
+	r.auditPasswordChange(ctx, cr, secrets, user)
 
 	orig := internalSecrets.DeepCopy()
 	internalSecrets.Data[user.Name] = secrets.Data[user.Name]
@@ -851,22 +880,29 @@ func (r *ReconcilePerconaXtraDBCluster) handlePMMUser(ctx context.Context, cr *a
 		return nil
 	}
 
-	if key, ok := secrets.Data[users.PMMServerKey]; ok {
-		if _, ok := internalSecrets.Data[users.PMMServerKey]; !ok {
-			internalSecrets.Data[users.PMMServerKey] = key
+	for _, keyName := range []string{users.PMMServerKey, users.PMMServerToken} {
+		key, ok := secrets.Data[keyName]
+		if !ok {
+			continue
+		}
+		if _, ok := internalSecrets.Data[keyName]; !ok {
+			internalSecrets.Data[keyName] = key
 
 			err := r.client.Update(context.TODO(), internalSecrets)
 			if err != nil {
 				return errors.Wrap(err, "update internal users secrets pmm user password")
 			}
-			log.Info("Internal secrets updated", "user", users.PMMServerKey)
+			log.Info("Internal secrets updated", "user", keyName)
 
 			return nil
 		}
 	}
 
 	name := users.PMMServerKey
-	if !cr.Spec.PMM.UseAPI(secrets) {
+	switch {
+	case cr.Spec.PMM.IsPMM3(secrets):
+		name = users.PMMServerToken
+	case !cr.Spec.PMM.UseAPI(secrets):
 		name = users.PMMServer
 	}
 
@@ -933,8 +969,8 @@ func (r *ReconcilePerconaXtraDBCluster) syncPXCUsersWithProxySQL(ctx context.Con
 	return nil
 }
 
-func (r *ReconcilePerconaXtraDBCluster) updateUserPassWithRetention(cr *api.PerconaXtraDBCluster, secrets, internalSecrets *corev1.Secret, user *users.SysUser) error {
-	um, err := getUserManager(cr, internalSecrets)
+func (r *ReconcilePerconaXtraDBCluster) updateUserPassWithRetention(ctx context.Context, cr *api.PerconaXtraDBCluster, secrets, internalSecrets *corev1.Secret, user *users.SysUser) error {
+	um, err := pxc.NewUsersManager(cr, internalSecrets)
 	if err != nil {
 		return err
 	}
@@ -945,11 +981,24 @@ func (r *ReconcilePerconaXtraDBCluster) updateUserPassWithRetention(cr *api.Perc
 		return errors.Wrap(err, "update user pass")
 	}
 
+	r.auditPasswordChange(ctx, cr, secrets, user)
+
 	return nil
 }
 
+// auditPasswordChange records a system user password change caused by the users secret update
+func (r *ReconcilePerconaXtraDBCluster) auditPasswordChange(ctx context.Context, cr *api.PerconaXtraDBCluster, secrets *corev1.Secret, user *users.SysUser) {
+	r.audit.Record(ctx, cr, nil, audit.Entry{
+		Action:  audit.ActionPasswordChanged,
+		User:    user.Name,
+		Hosts:   user.Hosts,
+		Trigger: "secret/" + secrets.Name,
+		Actor:   audit.Actor(secrets),
+	})
+}
+
 func (r *ReconcilePerconaXtraDBCluster) discardOldPassword(cr *api.PerconaXtraDBCluster, secrets, internalSecrets *corev1.Secret, user *users.SysUser) error {
-	um, err := getUserManager(cr, internalSecrets)
+	um, err := pxc.NewUsersManager(cr, internalSecrets)
 	if err != nil {
 		return err
 	}
@@ -964,7 +1013,7 @@ func (r *ReconcilePerconaXtraDBCluster) discardOldPassword(cr *api.PerconaXtraDB
 }
 
 func (r *ReconcilePerconaXtraDBCluster) isOldPasswordDiscarded(cr *api.PerconaXtraDBCluster, secrets *corev1.Secret, user *users.SysUser) (bool, error) {
-	um, err := getUserManager(cr, secrets)
+	um, err := pxc.NewUsersManager(cr, secrets)
 	if err != nil {
 		return false, err
 	}
@@ -1076,35 +1125,16 @@ func (r *ReconcilePerconaXtraDBCluster) grantMonitorUserPrivilege(ctx context.Co
 		return errors.Wrap(err, "update internal sys users secret annotation")
 	}
 
+	r.audit.Record(ctx, cr, nil, audit.Entry{
+		Action:  audit.ActionGrantsChanged,
+		User:    users.Monitor,
+		Trigger: "operator upgrade",
+	})
+
 	log.Info("monitor user privileges granted")
 	return nil
 }
 
-func getUserManager(cr *api.PerconaXtraDBCluster, secrets *corev1.Secret) (*users.Manager, error) {
-	pxcUser := users.Root
-	pxcPass := string(secrets.Data[users.Root])
-	if _, ok := secrets.Data[users.Operator]; ok {
-		pxcUser = users.Operator
-		pxcPass = string(secrets.Data[users.Operator])
-	}
-
-	addr := cr.Name + "-pxc-unready." + cr.Namespace + ":3306"
-	hasKey, err := cr.ConfigHasKey("mysqld", "proxy_protocol_networks")
-	if err != nil {
-		return nil, errors.Wrap(err, "check if congfig has proxy_protocol_networks key")
-	}
-	if hasKey {
-		addr = cr.Name + "-pxc-unready." + cr.Namespace + ":33062"
-	}
-
-	um, err := users.NewManager(addr, pxcUser, pxcPass, cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
-	if err != nil {
-		return nil, errors.Wrap(err, "new users manager")
-	}
-
-	return &um, nil
-}
-
 func (r *ReconcilePerconaXtraDBCluster) updateUserPassExpirationPolicy(ctx context.Context, cr *api.PerconaXtraDBCluster, internalSecrets *corev1.Secret, user *users.SysUser) error {
 	log := logf.FromContext(ctx)
 
@@ -1114,7 +1144,7 @@ func (r *ReconcilePerconaXtraDBCluster) updateUserPassExpirationPolicy(ctx conte
 	}
 
 	if cr.CompareVersionWith("1.13.0") >= 0 {
-		um, err := getUserManager(cr, internalSecrets)
+		um, err := pxc.NewUsersManager(cr, internalSecrets)
 		if err != nil {
 			return err
 		}
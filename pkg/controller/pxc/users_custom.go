@@ -3,6 +3,7 @@ package pxc
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/go-logr/logr"
@@ -15,12 +16,19 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/audit"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
 )
 
 func (r *ReconcilePerconaXtraDBCluster) reconcileCustomUsers(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
-	if cr.Spec.Users == nil && len(cr.Spec.Users) == 0 {
+	customUsers := cr.Spec.Users
+	if u := externalReplicationUser(cr); u != nil {
+		customUsers = append(slices.Clone(customUsers), *u)
+	}
+
+	if len(customUsers) == 0 {
 		return nil
 	}
 
@@ -34,7 +42,7 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileCustomUsers(ctx context.Context
 	err := r.client.Get(ctx,
 		types.NamespacedName{
 			Namespace: cr.Namespace,
-			Name:      internalSecretsPrefix + cr.Name,
+			Name:      users.InternalSecretsPrefix + cr.Name,
 		},
 		&internalSecrets,
 	)
@@ -42,7 +50,7 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileCustomUsers(ctx context.Context
 		return errors.Wrap(err, "get internal sys users secret")
 	}
 
-	um, err := getUserManager(cr, &internalSecrets)
+	um, err := pxc.NewUsersManager(cr, &internalSecrets)
 	if err != nil {
 		return err
 	}
@@ -50,7 +58,7 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileCustomUsers(ctx context.Context
 
 	sysUserNames := sysUserNames()
 
-	for _, user := range cr.Spec.Users {
+	for _, user := range customUsers {
 		if user.Name == "" {
 			log.Error(nil, "user name is not set", "user", user)
 			continue
@@ -117,6 +125,14 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileCustomUsers(ctx context.Context
 				return errors.Wrap(err, "update user secret")
 			}
 
+			r.audit.Record(ctx, cr, nil, audit.Entry{
+				Action:  audit.ActionPasswordChanged,
+				User:    user.Name,
+				Hosts:   user.Hosts,
+				Trigger: "secret/" + userSecret.Name,
+				Actor:   audit.Actor(userSecret),
+			})
+
 			log.Info("User password updated", "user", user.Name)
 		}
 
@@ -135,6 +151,18 @@ func (r *ReconcilePerconaXtraDBCluster) reconcileCustomUsers(ctx context.Context
 				return errors.Wrap(err, "update user secret")
 			}
 
+			action := audit.ActionGrantsChanged
+			if u == nil {
+				action = audit.ActionUserCreated
+			}
+			r.audit.Record(ctx, cr, nil, audit.Entry{
+				Action:  action,
+				User:    user.Name,
+				Hosts:   user.Hosts,
+				Trigger: "spec.users",
+				Actor:   audit.Actor(cr),
+			})
+
 			log.Info("User created/updated", "user", user.Name)
 		}
 	}
@@ -151,7 +179,7 @@ func generateUserPass(
 
 	log := logf.FromContext(ctx)
 
-	pass, err := generatePass()
+	pass, err := users.GeneratePass(cr.Spec.PXC.PasswordPolicy)
 	if err != nil {
 		return errors.Wrap(err, "generate custom user password")
 	}
@@ -283,7 +311,7 @@ func getUserSecret(ctx context.Context, cl client.Client, cr *api.PerconaXtraDBC
 
 	_, hasPass := secret.Data[passKey]
 	if !hasPass && name == defaultName {
-		pass, err := generatePass()
+		pass, err := users.GeneratePass(cr.Spec.PXC.PasswordPolicy)
 		if err != nil {
 			return nil, errors.Wrap(err, "generate custom user password")
 		}
@@ -315,6 +343,7 @@ func sysUserNames() map[string]struct{} {
 	for _, v := range users.UserNames {
 		sysUserNames[string(v)] = struct{}{}
 	}
+	sysUserNames[users.Vault] = struct{}{}
 	return sysUserNames
 }
 
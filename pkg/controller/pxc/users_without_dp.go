@@ -11,6 +11,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
 )
@@ -48,7 +49,7 @@ func (r *ReconcilePerconaXtraDBCluster) updateUsersWithoutDP(ctx context.Context
 			if err := r.handleProxyadminUserWithoutDP(ctx, cr, secrets, internalSecrets, res); err != nil {
 				return res, err
 			}
-		case users.PMMServer, users.PMMServerKey:
+		case users.PMMServer, users.PMMServerKey, users.PMMServerToken:
 			if err := r.handlePMMUser(ctx, cr, secrets, internalSecrets, res); err != nil {
 				return res, err
 			}
@@ -81,7 +82,7 @@ func (r *ReconcilePerconaXtraDBCluster) handleRootUserWithoutDP(ctx context.Cont
 
 	log.Info("Password changed, updating user", "user", user.Name)
 
-	err := r.updateUserPassWithoutDP(cr, secrets, internalSecrets, user)
+	err := r.updateUserPassWithoutDP(ctx, cr, secrets, internalSecrets, user)
 	if err != nil {
 		return errors.Wrap(err, "update root users pass")
 	}
@@ -137,7 +138,7 @@ func (r *ReconcilePerconaXtraDBCluster) handleOperatorUserWithoutDP(ctx context.
 
 	log.Info("Password changed, updating user", "user", user.Name)
 
-	err := r.updateUserPassWithoutDP(cr, secrets, internalSecrets, user)
+	err := r.updateUserPassWithoutDP(ctx, cr, secrets, internalSecrets, user)
 	if err != nil {
 		return errors.Wrap(err, "update operator users pass")
 	}
@@ -173,7 +174,7 @@ func (r *ReconcilePerconaXtraDBCluster) handleMonitorUserWithoutDP(ctx context.C
 			return err
 		}
 
-		um, err := getUserManager(cr, internalSecrets)
+		um, err := pxc.NewUsersManager(cr, internalSecrets)
 		if err != nil {
 			return err
 		}
@@ -224,7 +225,7 @@ func (r *ReconcilePerconaXtraDBCluster) handleMonitorUserWithoutDP(ctx context.C
 
 	log.Info("Password changed, updating user", "user", user.Name)
 
-	err := r.updateUserPassWithoutDP(cr, secrets, internalSecrets, user)
+	err := r.updateUserPassWithoutDP(ctx, cr, secrets, internalSecrets, user)
 	if err != nil {
 		return errors.Wrap(err, "update monitor users pass")
 	}
@@ -296,7 +297,7 @@ func (r *ReconcilePerconaXtraDBCluster) handleXtrabackupUserWithoutDP(ctx contex
 
 	log.Info("Password changed, updating user", "user", user.Name)
 
-	err := r.updateUserPassWithoutDP(cr, secrets, internalSecrets, user)
+	err := r.updateUserPassWithoutDP(ctx, cr, secrets, internalSecrets, user)
 	if err != nil {
 		return errors.Wrap(err, "update xtrabackup users pass")
 	}
@@ -356,7 +357,7 @@ func (r *ReconcilePerconaXtraDBCluster) handleReplicationUserWithoutDP(ctx conte
 
 	log.Info("Password changed, updating user", "user", user.Name)
 
-	err := r.updateUserPassWithoutDP(cr, secrets, internalSecrets, user)
+	err := r.updateUserPassWithoutDP(ctx, cr, secrets, internalSecrets, user)
 	if err != nil {
 		return errors.Wrap(err, "update replication users pass")
 	}
@@ -409,6 +410,7 @@ func (r *ReconcilePerconaXtraDBCluster) handleProxyadminUserWithoutDP(ctx contex
 		return errors.Wrap(err, "update Proxy users")
 	}
 	log.Info("Proxy user updated", "user", user.Name)
+	r.auditPasswordChange(ctx, cr, secrets, user)
 
 	orig := internalSecrets.DeepCopy()
 	internalSecrets.Data[user.Name] = secrets.Data[user.Name]
@@ -423,8 +425,8 @@ func (r *ReconcilePerconaXtraDBCluster) handleProxyadminUserWithoutDP(ctx contex
 	return nil
 }
 
-func (r *ReconcilePerconaXtraDBCluster) updateUserPassWithoutDP(cr *api.PerconaXtraDBCluster, secrets, internalSecrets *corev1.Secret, user *users.SysUser) error {
-	um, err := getUserManager(cr, internalSecrets)
+func (r *ReconcilePerconaXtraDBCluster) updateUserPassWithoutDP(ctx context.Context, cr *api.PerconaXtraDBCluster, secrets, internalSecrets *corev1.Secret, user *users.SysUser) error {
+	um, err := pxc.NewUsersManager(cr, internalSecrets)
 	if err != nil {
 		return err
 	}
@@ -435,5 +437,7 @@ func (r *ReconcilePerconaXtraDBCluster) updateUserPassWithoutDP(cr *api.PerconaX
 		return errors.Wrap(err, "update user pass")
 	}
 
+	r.auditPasswordChange(ctx, cr, secrets, user)
+
 	return nil
 }
@@ -0,0 +1,216 @@
+package pxc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/audit"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/vault"
+)
+
+const (
+	vaultDBConfigHashAnnotation = "percona.com/vault-db-config-hash"
+	vaultDBRolesAnnotation      = "percona.com/vault-db-roles"
+	vaultDBPasswordKey          = "password"
+)
+
+var defaultVaultDBCreationStatements = []string{
+	"CREATE USER '{{name}}'@'%' IDENTIFIED BY '{{password}}';",
+	"GRANT SELECT ON *.* TO '{{name}}'@'%';",
+}
+
+// reconcileVaultDBSecrets configures the Vault database secrets engine
+// so applications can get short-lived MySQL credentials from Vault.
+func (r *ReconcilePerconaXtraDBCluster) reconcileVaultDBSecrets(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	spec := cr.Spec.VaultDBSecrets
+	if !spec.IsEnabled() {
+		return nil
+	}
+
+	if cr.Status.Status != api.AppStateReady {
+		return nil
+	}
+
+	log := logf.FromContext(ctx)
+
+	tokenSecret := new(corev1.Secret)
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: spec.TokenSecretRef.Name}, tokenSecret)
+	if err != nil {
+		return errors.Wrap(err, "get vault token secret")
+	}
+	token, ok := tokenSecret.Data[spec.TokenSecretRef.Key]
+	if !ok || len(token) == 0 {
+		return errors.Errorf("key %s is not found in secret %s", spec.TokenSecretRef.Key, spec.TokenSecretRef.Name)
+	}
+
+	userSecret, err := r.getOrCreateVaultDBUserSecret(ctx, cr)
+	if err != nil {
+		return errors.Wrap(err, "get vault user secret")
+	}
+	pass := userSecret.Data[vaultDBPasswordKey]
+
+	hash, err := vaultDBConfigHash(spec, pass)
+	if err != nil {
+		return errors.Wrap(err, "calculate vault config hash")
+	}
+	if userSecret.Annotations[vaultDBConfigHashAnnotation] == hash {
+		return nil
+	}
+
+	internalSecrets := new(corev1.Secret)
+	err = r.client.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: users.InternalSecretsPrefix + cr.Name}, internalSecrets)
+	if err != nil {
+		return errors.Wrap(err, "get internal sys users secret")
+	}
+
+	um, err := pxc.NewUsersManager(cr, internalSecrets)
+	if err != nil {
+		return err
+	}
+	defer um.Close()
+
+	if err := um.CreateVaultUser(string(pass)); err != nil {
+		return errors.Wrap(err, "create vault user")
+	}
+	if userSecret.Annotations[vaultDBConfigHashAnnotation] == "" {
+		r.audit.Record(ctx, cr, nil, audit.Entry{
+			Action:  audit.ActionUserCreated,
+			User:    users.Vault,
+			Hosts:   []string{"%"},
+			Trigger: "spec.vaultDBSecrets",
+			Actor:   audit.Actor(cr),
+		})
+	}
+
+	roles := make([]string, 0, len(spec.Roles))
+	for _, role := range spec.Roles {
+		roles = append(roles, role.Name)
+	}
+
+	vc := vault.NewClient(spec.Address, strings.TrimSpace(string(token)), spec.TLSSkipVerify)
+
+	err = vc.WriteDatabaseConnection(ctx, spec.MountPath, spec.ConnectionName, vault.DatabaseConnection{
+		PluginName:    "mysql-database-plugin",
+		ConnectionURL: fmt.Sprintf("{{username}}:{{password}}@tcp(%s-pxc.%s:3306)/", cr.Name, cr.Namespace),
+		Username:      users.Vault,
+		Password:      string(pass),
+		AllowedRoles:  roles,
+	})
+	if err != nil {
+		return errors.Wrap(err, "write vault database connection")
+	}
+
+	for _, role := range spec.Roles {
+		statements := role.CreationStatements
+		if len(statements) == 0 {
+			statements = defaultVaultDBCreationStatements
+		}
+
+		err := vc.WriteDatabaseRole(ctx, spec.MountPath, role.Name, vault.DatabaseRole{
+			DBName:               spec.ConnectionName,
+			CreationStatements:   statements,
+			RevocationStatements: role.RevocationStatements,
+			DefaultTTL:           role.DefaultTTL,
+			MaxTTL:               role.MaxTTL,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "write vault database role %s", role.Name)
+		}
+	}
+
+	for _, old := range strings.Split(userSecret.Annotations[vaultDBRolesAnnotation], ",") {
+		if old == "" || slices.Contains(roles, old) {
+			continue
+		}
+		if err := vc.DeleteDatabaseRole(ctx, spec.MountPath, old); err != nil {
+			return errors.Wrapf(err, "delete vault database role %s", old)
+		}
+		log.Info("Vault database role deleted", "role", old)
+	}
+
+	err = k8s.AnnotateObject(ctx, r.client, userSecret, map[string]string{
+		vaultDBConfigHashAnnotation: hash,
+		vaultDBRolesAnnotation:      strings.Join(roles, ","),
+	})
+	if err != nil {
+		return errors.Wrap(err, "annotate vault user secret")
+	}
+
+	log.Info("Vault database secrets engine configured", "connection", spec.ConnectionName, "roles", roles)
+
+	return nil
+}
+
+func (r *ReconcilePerconaXtraDBCluster) getOrCreateVaultDBUserSecret(ctx context.Context, cr *api.PerconaXtraDBCluster) (*corev1.Secret, error) {
+	secret := new(corev1.Secret)
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: cr.Namespace, Name: cr.Name + "-vault-db"}, secret)
+	if err == nil {
+		if len(secret.Data[vaultDBPasswordKey]) > 0 {
+			return secret, nil
+		}
+
+		pass, err := users.GeneratePass(cr.Spec.PXC.PasswordPolicy)
+		if err != nil {
+			return nil, errors.Wrap(err, "generate password")
+		}
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		secret.Data[vaultDBPasswordKey] = pass
+
+		return secret, r.client.Update(ctx, secret)
+	} else if !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	pass, err := users.GeneratePass(cr.Spec.PXC.PasswordPolicy)
+	if err != nil {
+		return nil, errors.Wrap(err, "generate password")
+	}
+
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-vault-db",
+			Namespace: cr.Namespace,
+			Labels:    naming.LabelsCluster(cr),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			vaultDBPasswordKey: pass,
+		},
+	}
+
+	if err := k8s.SetControllerReference(cr, secret, r.scheme); err != nil {
+		return nil, errors.Wrap(err, "set controller reference")
+	}
+
+	if err := r.client.Create(ctx, secret); err != nil {
+		return nil, errors.Wrap(err, "create secret")
+	}
+
+	return secret, nil
+}
+
+func vaultDBConfigHash(spec *api.VaultDBSecretsSpec, pass []byte) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+
+	return sha256Hash(append(data, pass...)), nil
+}
@@ -0,0 +1,205 @@
+package pxc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+func vaultDBCR(enabled bool) *api.PerconaXtraDBCluster {
+	cr := newCR("cluster1", "pxc")
+	cr.Status.Status = api.AppStateReady
+	cr.Spec.VaultDBSecrets = &api.VaultDBSecretsSpec{
+		Enabled:        enabled,
+		Address:        "https://vault:8200",
+		TokenSecretRef: &api.SecretKeySelector{Name: "vault-token", Key: "token"},
+		MountPath:      "database",
+		ConnectionName: "pxc-cluster1",
+		Roles:          []api.VaultDBRole{{Name: "app"}},
+	}
+	return cr
+}
+
+func vaultDBUserSecret(pass, hash string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1-vault-db", Namespace: "pxc"},
+		Data:       map[string][]byte{vaultDBPasswordKey: []byte(pass)},
+	}
+	if hash != "" {
+		secret.Annotations = map[string]string{vaultDBConfigHashAnnotation: hash}
+	}
+	return secret
+}
+
+func TestReconcileVaultDBSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	token := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "pxc"},
+		Data:       map[string][]byte{"token": []byte("s.token")},
+	}
+	hash, err := vaultDBConfigHash(vaultDBCR(true).Spec.VaultDBSecrets, []byte("pass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		enabled bool
+		status  api.AppState
+		objs    []runtime.Object
+
+		// there are no internal secrets, so reconfiguring Vault fails on reading them
+		expectedErr string
+		userSecret  bool
+	}{
+		"disabled": {
+			enabled: false,
+			status:  api.AppStateReady,
+			objs:    []runtime.Object{token},
+		},
+		"cluster not ready": {
+			enabled: true,
+			status:  api.AppStateInit,
+			objs:    []runtime.Object{token},
+		},
+		"no token secret": {
+			enabled:     true,
+			status:      api.AppStateReady,
+			expectedErr: "get vault token secret",
+		},
+		"new user secret": {
+			enabled:     true,
+			status:      api.AppStateReady,
+			objs:        []runtime.Object{token},
+			expectedErr: "get internal sys users secret",
+			userSecret:  true,
+		},
+		"config unchanged": {
+			enabled:    true,
+			status:     api.AppStateReady,
+			objs:       []runtime.Object{token, vaultDBUserSecret("pass", hash)},
+			userSecret: true,
+		},
+		"config changed": {
+			enabled:     true,
+			status:      api.AppStateReady,
+			objs:        []runtime.Object{token, vaultDBUserSecret("pass", "stale")},
+			expectedErr: "get internal sys users secret",
+			userSecret:  true,
+		},
+		"password changed": {
+			enabled:     true,
+			status:      api.AppStateReady,
+			objs:        []runtime.Object{token, vaultDBUserSecret("new-pass", hash)},
+			expectedErr: "get internal sys users secret",
+			userSecret:  true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := vaultDBCR(tt.enabled)
+			cr.Status.Status = tt.status
+
+			r := buildFakeClient(tt.objs)
+
+			err := r.reconcileVaultDBSecrets(ctx, cr)
+			if tt.expectedErr == "" && err != nil {
+				t.Fatal(err)
+			}
+			if tt.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErr)) {
+				t.Fatalf("expected error %q, got %v", tt.expectedErr, err)
+			}
+
+			err = r.client.Get(ctx, types.NamespacedName{Name: "cluster1-vault-db", Namespace: "pxc"}, new(corev1.Secret))
+			if (err == nil) != tt.userSecret {
+				t.Errorf("expected vault user secret %t, got error %v", tt.userSecret, err)
+			}
+		})
+	}
+}
+
+func TestGetOrCreateVaultDBUserSecret(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		objs []runtime.Object
+		pass string
+	}{
+		"new secret":              {},
+		"existing secret":         {objs: []runtime.Object{vaultDBUserSecret("pass", "")}, pass: "pass"},
+		"secret without password": {objs: []runtime.Object{vaultDBUserSecret("", "")}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := vaultDBCR(true)
+			r := buildFakeClient(tt.objs)
+
+			if _, err := r.getOrCreateVaultDBUserSecret(ctx, cr); err != nil {
+				t.Fatal(err)
+			}
+
+			secret := new(corev1.Secret)
+			if err := r.client.Get(ctx, types.NamespacedName{Name: "cluster1-vault-db", Namespace: "pxc"}, secret); err != nil {
+				t.Fatal(err)
+			}
+			pass := string(secret.Data[vaultDBPasswordKey])
+			if tt.pass != "" && pass != tt.pass {
+				t.Errorf("expected password %q to be kept, got %q", tt.pass, pass)
+			}
+			if tt.pass == "" && len(pass) < 16 {
+				t.Errorf("expected generated password, got %q", pass)
+			}
+			if len(tt.objs) == 0 {
+				if secret.Labels[naming.LabelAppKubernetesInstance] != cr.Name {
+					t.Errorf("expected cluster labels, got %v", secret.Labels)
+				}
+				if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].Name != cr.Name {
+					t.Errorf("expected the cluster to own the secret, got %v", secret.OwnerReferences)
+				}
+			}
+		})
+	}
+}
+
+func TestVaultDBConfigHash(t *testing.T) {
+	spec := vaultDBCR(true).Spec.VaultDBSecrets
+
+	tests := map[string]struct {
+		mutate  func(spec *api.VaultDBSecretsSpec)
+		pass    string
+		changed bool
+	}{
+		"same config":        {mutate: func(*api.VaultDBSecretsSpec) {}, pass: "pass"},
+		"password changed":   {mutate: func(*api.VaultDBSecretsSpec) {}, pass: "new-pass", changed: true},
+		"role added":         {mutate: func(s *api.VaultDBSecretsSpec) { s.Roles = append(s.Roles, api.VaultDBRole{Name: "ro"}) }, pass: "pass", changed: true},
+		"role ttl changed":   {mutate: func(s *api.VaultDBSecretsSpec) { s.Roles[0].DefaultTTL = "1h" }, pass: "pass", changed: true},
+		"connection changed": {mutate: func(s *api.VaultDBSecretsSpec) { s.ConnectionName = "other" }, pass: "pass", changed: true},
+	}
+
+	expected, err := vaultDBConfigHash(spec, []byte("pass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := spec.DeepCopy()
+			tt.mutate(s)
+
+			hash, err := vaultDBConfigHash(s, []byte(tt.pass))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (hash != expected) != tt.changed {
+				t.Errorf("expected hash changed %t, got %s and %s", tt.changed, expected, hash)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package pxc
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+// reconcileVelero keeps the objects derived from the cluster out of Velero backups
+// and recovers the cluster restored by Velero.
+// The backup hooks of the PXC pods are set by the PXC statefulset.
+func (r *ReconcilePerconaXtraDBCluster) reconcileVelero(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	if !cr.Spec.Velero.IsEnabled() {
+		if cr.Status.Velero == nil {
+			return nil
+		}
+		if err := r.setVeleroExclusion(ctx, cr, false); err != nil {
+			return errors.Wrap(err, "include derived objects into backups")
+		}
+		cr.Status.Velero = nil
+		return nil
+	}
+
+	if cr.Status.Velero == nil {
+		cr.Status.Velero = new(api.VeleroStatus)
+	}
+
+	if err := r.setVeleroExclusion(ctx, cr, true); err != nil {
+		return errors.Wrap(err, "exclude derived objects from backups")
+	}
+
+	return errors.Wrap(r.recoverVeleroRestore(ctx, cr), "recover restored pods")
+}
+
+// recoverVeleroRestore deletes the PXC pods restored by Velero.
+// The restored pods start at once from volumes snapshotted at different moments and
+// may form separate components. The statefulset recreates them one by one, so the
+// nodes join the most advanced one instead, or wait for the full crash recovery.
+func (r *ReconcilePerconaXtraDBCluster) recoverVeleroRestore(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	log := logf.FromContext(ctx)
+
+	selector := labels.SelectorFromSet(naming.LabelsPXC(cr))
+	restored, err := labels.NewRequirement(naming.LabelVeleroRestoreName, selection.Exists, nil)
+	if err != nil {
+		return errors.Wrap(err, "restore name requirement")
+	}
+
+	pods := corev1.PodList{}
+	err = r.client.List(ctx, &pods, &client.ListOptions{
+		Namespace:     cr.Namespace,
+		LabelSelector: selector.Add(*restored),
+	})
+	if err != nil {
+		return errors.Wrap(err, "get pod list")
+	}
+	if len(pods.Items) == 0 {
+		return nil
+	}
+
+	restoreName := pods.Items[0].Labels[naming.LabelVeleroRestoreName]
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		log.Info("Deleting pod restored by Velero", "pod", pod.Name, "restore", pod.Labels[naming.LabelVeleroRestoreName])
+		if err := r.client.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+			return errors.Wrapf(err, "delete pod %s", pod.Name)
+		}
+	}
+
+	if cr.Status.Velero.RestoreName != restoreName {
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventVeleroRestoreRecovered,
+			"PXC pods restored by Velero restore %s are recreated by the statefulset", restoreName)
+	}
+	now := metav1.Now()
+	cr.Status.Velero.RestoreName = restoreName
+	cr.Status.Velero.RecoveredAt = &now
+
+	return nil
+}
+
+// setVeleroExclusion sets or removes the Velero exclusion label on the statefulsets,
+// services and disruption budgets of the cluster. They are recreated by the operator
+// from the cluster after the restore.
+func (r *ReconcilePerconaXtraDBCluster) setVeleroExclusion(ctx context.Context, cr *api.PerconaXtraDBCluster, exclude bool) error {
+	opts := &client.ListOptions{
+		Namespace:     cr.Namespace,
+		LabelSelector: labels.SelectorFromSet(map[string]string{naming.LabelAppKubernetesInstance: cr.Name}),
+	}
+
+	var objs []client.Object
+
+	stsList := appsv1.StatefulSetList{}
+	if err := r.client.List(ctx, &stsList, opts); err != nil {
+		return errors.Wrap(err, "get statefulset list")
+	}
+	for i := range stsList.Items {
+		objs = append(objs, &stsList.Items[i])
+	}
+
+	svcList := corev1.ServiceList{}
+	if err := r.client.List(ctx, &svcList, opts); err != nil {
+		return errors.Wrap(err, "get service list")
+	}
+	for i := range svcList.Items {
+		objs = append(objs, &svcList.Items[i])
+	}
+
+	pdbList := policyv1.PodDisruptionBudgetList{}
+	if err := r.client.List(ctx, &pdbList, opts); err != nil {
+		return errors.Wrap(err, "get pod disruption budget list")
+	}
+	for i := range pdbList.Items {
+		objs = append(objs, &pdbList.Items[i])
+	}
+
+	for _, obj := range objs {
+		if !metav1.IsControlledBy(obj, cr) || !veleroExclusionChanged(obj, exclude) {
+			continue
+		}
+
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		ls := obj.GetLabels()
+		if exclude {
+			if ls == nil {
+				ls = make(map[string]string)
+			}
+			ls[naming.LabelVeleroExcludeFromBackup] = "true"
+		} else {
+			delete(ls, naming.LabelVeleroExcludeFromBackup)
+		}
+		obj.SetLabels(ls)
+
+		if err := r.client.Patch(ctx, obj, patch); client.IgnoreNotFound(err) != nil {
+			return errors.Wrapf(err, "patch %s", obj.GetName())
+		}
+	}
+
+	return nil
+}
+
+func veleroExclusionChanged(obj client.Object, exclude bool) bool {
+	_, ok := obj.GetLabels()[naming.LabelVeleroExcludeFromBackup]
+	return ok != exclude
+}
@@ -0,0 +1,109 @@
+package pxc
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+func TestReconcileVelero(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.UID = "cr-uid"
+	cr.Spec.Velero = &api.VeleroSpec{Enabled: true}
+
+	trueVar := true
+	owner := metav1.OwnerReference{
+		APIVersion: api.SchemeGroupVersion.String(),
+		Kind:       "PerconaXtraDBCluster",
+		Name:       cr.Name,
+		UID:        cr.UID,
+		Controller: &trueVar,
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cr.Name + "-pxc",
+			Namespace:       cr.Namespace,
+			Labels:          naming.LabelsPXC(cr),
+			OwnerReferences: []metav1.OwnerReference{owner},
+		},
+	}
+	userSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-custom",
+			Namespace: cr.Namespace,
+			Labels:    naming.LabelsPXC(cr),
+		},
+	}
+
+	restoredLabels := naming.LabelsPXC(cr)
+	restoredLabels[naming.LabelVeleroRestoreName] = "restore-1"
+	restoredPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-pxc-0",
+			Namespace: cr.Namespace,
+			Labels:    restoredLabels,
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-pxc-1",
+			Namespace: cr.Namespace,
+			Labels:    naming.LabelsPXC(cr),
+		},
+	}
+
+	r := buildFakeClient([]runtime.Object{cr, svc, userSvc, restoredPod, pod})
+
+	if err := r.reconcileVelero(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+
+	got := new(corev1.Service)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Labels[naming.LabelVeleroExcludeFromBackup] != "true" {
+		t.Errorf("expected the service to be excluded from backups, got labels %v", got.Labels)
+	}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: userSvc.Name, Namespace: userSvc.Namespace}, got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.Labels[naming.LabelVeleroExcludeFromBackup]; ok {
+		t.Error("expected the service not owned by the cluster to be kept in backups")
+	}
+
+	if err := r.client.Get(ctx, types.NamespacedName{Name: restoredPod.Name, Namespace: restoredPod.Namespace}, new(corev1.Pod)); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the restored pod to be deleted, got %v", err)
+	}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}, new(corev1.Pod)); err != nil {
+		t.Errorf("expected the pod to be kept, got %v", err)
+	}
+	if cr.Status.Velero == nil || cr.Status.Velero.RestoreName != "restore-1" {
+		t.Errorf("expected the restore to be recorded in the status, got %+v", cr.Status.Velero)
+	}
+
+	cr.Spec.Velero.Enabled = false
+	if err := r.reconcileVelero(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: svc.Name, Namespace: svc.Namespace}, got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.Labels[naming.LabelVeleroExcludeFromBackup]; ok {
+		t.Error("expected the service to be included into backups after Velero is disabled")
+	}
+	if cr.Status.Velero != nil {
+		t.Errorf("expected the status to be reset, got %+v", cr.Status.Velero)
+	}
+}
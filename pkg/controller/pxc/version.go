@@ -15,7 +15,6 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
-	k8sretry "k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -251,6 +250,19 @@ func (r *ReconcilePerconaXtraDBCluster) getNewVersions(ctx context.Context, cr *
 		UserManagementEnabled: len(cr.Spec.Users) > 0,
 	}
 
+	if cr.Spec.UpgradeOptions.VersionMap != "" {
+		return r.versionsFromMap(ctx, cr)
+	}
+
+	if cr.Spec.UpgradeOptions.VersionServiceCASecret != "" {
+		secret := new(corev1.Secret)
+		err := r.client.Get(ctx, types.NamespacedName{Name: cr.Spec.UpgradeOptions.VersionServiceCASecret, Namespace: cr.Namespace}, secret)
+		if err != nil {
+			return DepVersion{}, errors.Wrap(err, "get version service CA secret")
+		}
+		vm.CA = secret.Data["ca.crt"]
+	}
+
 	endpoint := apiv1.GetDefaultVersionServiceEndpoint()
 	log.V(1).Info("Use version service endpoint", "endpoint", endpoint)
 
@@ -281,11 +293,22 @@ func (r *ReconcilePerconaXtraDBCluster) ensurePXCVersion(ctx context.Context, cr
 		return nil
 	}
 
+	if cr.Status.PXC.Version != "" {
+		if ok, reason := cr.Spec.UpgradeOptions.MaintenanceAllowed(time.Now()); !ok {
+			log.Info("version upgrade is postponed", "reason", reason)
+			return nil
+		}
+	}
+
 	newVersion, err := r.getNewVersions(ctx, cr, vs)
 	if err != nil {
 		return errors.Wrap(err, "failed to get new versions")
 	}
 
+	if cr.Spec.GitOps.IsEnabled() {
+		return r.suggestNewVersions(ctx, cr, newVersion)
+	}
+
 	patch := client.MergeFrom(cr.DeepCopy())
 
 	if cr.Spec.PXC != nil && cr.Spec.PXC.Image != newVersion.PXCImage {
@@ -355,17 +378,9 @@ func (r *ReconcilePerconaXtraDBCluster) ensurePXCVersion(ctx context.Context, cr
 	cr.Status.PXC.Image = newVersion.PXCImage
 	cr.Status.LogCollector.Version = newVersion.LogCollectorVersion
 
-	err = k8sretry.RetryOnConflict(k8sretry.DefaultRetry, func() error {
-		localCr := &apiv1.PerconaXtraDBCluster{}
-
-		err := r.client.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, localCr)
-		if err != nil {
-			return err
-		}
-
-		localCr.Status = cr.Status
-
-		return r.client.Status().Update(ctx, localCr)
+	status := cr.Status.DeepCopy()
+	err = k8s.PatchStatus(ctx, r.client, cr, func(c *apiv1.PerconaXtraDBCluster) {
+		c.Status = *status
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to update CR status")
@@ -376,6 +391,40 @@ func (r *ReconcilePerconaXtraDBCluster) ensurePXCVersion(ctx context.Context, cr
 	return nil
 }
 
+// suggestNewVersions publishes the images recommended by the version service as suggested spec changes
+// instead of applying them, the spec is owned by a GitOps tool.
+func (r *ReconcilePerconaXtraDBCluster) suggestNewVersions(ctx context.Context, cr *apiv1.PerconaXtraDBCluster, newVersion DepVersion) error {
+	const reason = "recommended by the version service"
+
+	if cr.Spec.PXC != nil && cr.Spec.PXC.Image != newVersion.PXCImage {
+		cr.Status.SuggestSpecChange("spec.pxc.image", newVersion.PXCImage, reason)
+	}
+	if cr.Spec.Backup != nil && cr.Spec.Backup.Image != newVersion.BackupImage {
+		cr.Status.SuggestSpecChange("spec.backup.image", newVersion.BackupImage, reason)
+	}
+	if cr.Spec.PMM != nil && cr.Spec.PMM.Enabled && cr.Spec.PMM.Image != newVersion.PMMImage {
+		cr.Status.SuggestSpecChange("spec.pmm.image", newVersion.PMMImage, reason)
+	}
+	if cr.Spec.ProxySQLEnabled() && cr.Spec.ProxySQL.Image != newVersion.ProxySqlImage {
+		cr.Status.SuggestSpecChange("spec.proxysql.image", newVersion.ProxySqlImage, reason)
+	}
+	if cr.Spec.HAProxyEnabled() && cr.Spec.HAProxy.Image != newVersion.HAProxyImage {
+		cr.Status.SuggestSpecChange("spec.haproxy.image", newVersion.HAProxyImage, reason)
+	}
+	if cr.Spec.LogCollector != nil && cr.Spec.LogCollector.Enabled && cr.Spec.LogCollector.Image != newVersion.LogCollectorImage {
+		cr.Status.SuggestSpecChange("spec.logcollector.image", newVersion.LogCollectorImage, reason)
+	}
+
+	if cr.Status.GitOps == nil {
+		return nil
+	}
+
+	gitOps := cr.Status.GitOps.DeepCopy()
+	return errors.Wrap(k8s.PatchStatus(ctx, r.client, cr.DeepCopy(), func(c *apiv1.PerconaXtraDBCluster) {
+		c.Status.GitOps = gitOps
+	}), "patch status")
+}
+
 func (r *ReconcilePerconaXtraDBCluster) mysqlVersion(ctx context.Context, cr *apiv1.PerconaXtraDBCluster, sfs apiv1.StatefulApp) (string, error) {
 	log := logf.FromContext(ctx)
 
@@ -462,17 +511,9 @@ func (r *ReconcilePerconaXtraDBCluster) fetchVersionFromPXC(ctx context.Context,
 	cr.Status.PXC.Image = cr.Spec.PXC.Image
 
 	log.Info("update PXC version (fetched from db)", "new version", version)
-	err = k8sretry.RetryOnConflict(k8sretry.DefaultRetry, func() error {
-		localCr := &apiv1.PerconaXtraDBCluster{}
-
-		err := r.client.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, localCr)
-		if err != nil {
-			return err
-		}
-
-		localCr.Status = cr.Status
-
-		return r.client.Status().Update(ctx, localCr)
+	status := cr.Status.DeepCopy()
+	err = k8s.PatchStatus(ctx, r.client, cr, func(c *apiv1.PerconaXtraDBCluster) {
+		c.Status = *status
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to update CR")
@@ -501,6 +542,12 @@ func (r *ReconcilePerconaXtraDBCluster) setCRVersion(ctx context.Context, cr *ap
 		return nil
 	}
 
+	if cr.Spec.GitOps.IsEnabled() {
+		cr.Spec.CRVersion = version.Version
+		cr.Status.SuggestSpecChange("spec.crVersion", version.Version, "crVersion isn't set, the operator version is used")
+		return nil
+	}
+
 	orig := cr.DeepCopy()
 	cr.Spec.CRVersion = version.Version
 
@@ -0,0 +1,65 @@
+package pxc
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	apiv1 "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+const versionMapKey = "versions.json"
+
+// versionsFromMap returns versions for the apply value from the static version map.
+// Components missing in the map keep their current images.
+func (r *ReconcilePerconaXtraDBCluster) versionsFromMap(ctx context.Context, cr *apiv1.PerconaXtraDBCluster) (DepVersion, error) {
+	cm := new(corev1.ConfigMap)
+	err := r.client.Get(ctx, types.NamespacedName{Name: cr.Spec.UpgradeOptions.VersionMap, Namespace: cr.Namespace}, cm)
+	if err != nil {
+		return DepVersion{}, errors.Wrap(err, "get version map")
+	}
+
+	data, ok := cm.Data[versionMapKey]
+	if !ok {
+		return DepVersion{}, errors.Errorf("config map %s has no %s key", cm.Name, versionMapKey)
+	}
+
+	versions := make(map[string]DepVersion)
+	if err := json.Unmarshal([]byte(data), &versions); err != nil {
+		return DepVersion{}, errors.Wrapf(err, "parse %s", versionMapKey)
+	}
+
+	apply := strings.ToLower(cr.Spec.UpgradeOptions.Apply)
+	dv, ok := versions[apply]
+	if !ok {
+		return DepVersion{}, errors.Errorf("version map %s has no versions for %s", cm.Name, apply)
+	}
+
+	return withCurrentVersions(dv, cr), nil
+}
+
+func withCurrentVersions(dv DepVersion, cr *apiv1.PerconaXtraDBCluster) DepVersion {
+	if dv.PXCImage == "" && cr.Spec.PXC != nil {
+		dv.PXCImage, dv.PXCVersion = cr.Spec.PXC.Image, cr.Status.PXC.Version
+	}
+	if dv.BackupImage == "" && cr.Spec.Backup != nil {
+		dv.BackupImage, dv.BackupVersion = cr.Spec.Backup.Image, cr.Status.Backup.Version
+	}
+	if dv.PMMImage == "" && cr.Spec.PMM != nil {
+		dv.PMMImage, dv.PMMVersion = cr.Spec.PMM.Image, cr.Status.PMM.Version
+	}
+	if dv.ProxySqlImage == "" && cr.Spec.ProxySQL != nil {
+		dv.ProxySqlImage, dv.ProxySqlVersion = cr.Spec.ProxySQL.Image, cr.Status.ProxySQL.Version
+	}
+	if dv.HAProxyImage == "" && cr.Spec.HAProxy != nil {
+		dv.HAProxyImage, dv.HAProxyVersion = cr.Spec.HAProxy.Image, cr.Status.HAProxy.Version
+	}
+	if dv.LogCollectorImage == "" && cr.Spec.LogCollector != nil {
+		dv.LogCollectorImage, dv.LogCollectorVersion = cr.Spec.LogCollector.Image, cr.Status.LogCollector.Version
+	}
+	return dv
+}
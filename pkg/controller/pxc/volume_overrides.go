@@ -0,0 +1,142 @@
+package pxc
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
+)
+
+// reconcileVolumeOverrides creates the PVCs of the PXC pods with volume overrides before
+// the statefulset creates the pods, and expands the existing PVCs if their overridden size grows.
+// Such PVCs are skipped by the resize of the volume claim template.
+func (r *ReconcilePerconaXtraDBCluster) reconcileVolumeOverrides(ctx context.Context, cr *api.PerconaXtraDBCluster) error {
+	if len(cr.Spec.PXC.VolumeOverrides) == 0 || cr.Spec.PXC.VolumeSpec.PersistentVolumeClaim == nil {
+		return nil
+	}
+
+	log := logf.FromContext(ctx)
+
+	sts := statefulset.NewNode(cr).StatefulSet()
+	pendingComponent := sts.Name + "-volume-overrides"
+	cr.SetPendingActions(pendingComponent, nil)
+
+	var resize []*corev1.PersistentVolumeClaim
+	for _, o := range cr.Spec.PXC.VolumeOverrides {
+		if o.Ordinal >= cr.Spec.PXC.Size {
+			continue
+		}
+
+		desired := volumeOverridePVC(cr, sts, o)
+
+		pvc := new(corev1.PersistentVolumeClaim)
+		err := r.client.Get(ctx, client.ObjectKeyFromObject(desired), pvc)
+		if k8serrors.IsNotFound(err) {
+			log.Info("Creating PVC with volume override", "pvc", desired.Name, "ordinal", o.Ordinal)
+			if err := r.client.Create(ctx, desired); err != nil && !k8serrors.IsAlreadyExists(err) {
+				return errors.Wrapf(err, "create PVC %s", desired.Name)
+			}
+			continue
+		}
+		if err != nil {
+			return errors.Wrapf(err, "get PVC %s", desired.Name)
+		}
+
+		if sc := desired.Spec.StorageClassName; sc != nil && !ptr.Equal(sc, pvc.Spec.StorageClassName) {
+			r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventVolumeOverrideConflict,
+				"PVC %s has storageClassName %s instead of %s, delete the PVC together with its pod to recreate it",
+				pvc.Name, ptr.Deref(pvc.Spec.StorageClassName, ""), *sc)
+		}
+
+		requested := desired.Spec.Resources.Requests[corev1.ResourceStorage]
+		current := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		switch requested.Cmp(current) {
+		case 0:
+			continue
+		case -1:
+			r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventVolumeOverrideConflict,
+				"PVC %s can't be shrunk from %s to %s", pvc.Name, current.String(), requested.String())
+			continue
+		}
+		if !cr.Spec.VolumeExpansionEnabled {
+			log.Info("Volume override isn't applied, volume expansion is disabled", "pvc", pvc.Name, "requested", requested, "actual", current)
+			continue
+		}
+
+		if pvc.Spec.Resources.Requests == nil {
+			pvc.Spec.Resources.Requests = make(corev1.ResourceList)
+		}
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = requested
+		resize = append(resize, pvc)
+	}
+
+	if len(resize) == 0 {
+		return nil
+	}
+
+	actions := make([]string, 0, len(resize))
+	for _, pvc := range resize {
+		actions = append(actions, fmt.Sprintf("will resize PVC %s to %s", pvc.Name, pvc.Spec.Resources.Requests.Storage().String()))
+	}
+	cr.SetPendingActions(pendingComponent, actions)
+	if !cr.ChangesApproved() {
+		log.Info("Volume override resize is waiting for approval", "id", cr.Status.PendingChanges.ID)
+		return nil
+	}
+
+	for _, pvc := range resize {
+		log.Info("Resizing PVC with volume override", "pvc", pvc.Name, "requested", pvc.Spec.Resources.Requests.Storage())
+		if err := r.client.Update(ctx, pvc); err != nil {
+			return errors.Wrapf(err, "update PVC %s", pvc.Name)
+		}
+	}
+
+	return nil
+}
+
+// volumeOverridePVC returns the PVC the statefulset would create for the pod with the ordinal,
+// with the size and the storage class of the override.
+func volumeOverridePVC(cr *api.PerconaXtraDBCluster, sts *appsv1.StatefulSet, o api.PodVolumeOverride) *corev1.PersistentVolumeClaim {
+	spec := app.VolumeSpec(cr.Spec.PXC.VolumeSpec.DeepCopy())
+	if o.StorageClassName != nil {
+		spec.StorageClassName = ptr.To(*o.StorageClassName)
+	}
+	if o.Size != nil {
+		if spec.Resources.Requests == nil {
+			spec.Resources.Requests = make(corev1.ResourceList)
+		}
+		spec.Resources.Requests[corev1.ResourceStorage] = o.Size.DeepCopy()
+	}
+
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s-%d", app.DataVolumeName, sts.Name, o.Ordinal),
+			Namespace: sts.Namespace,
+			Labels:    naming.LabelsPXC(cr),
+		},
+		Spec: spec,
+	}
+}
+
+// volumeOverridden returns true if the PVC of the PXC statefulset belongs to a pod with a volume override.
+func volumeOverridden(cr *api.PerconaXtraDBCluster, pvc corev1.PersistentVolumeClaim, sts *appsv1.StatefulSet) bool {
+	ordinal, err := strconv.ParseInt(strings.TrimPrefix(pvc.Name, app.DataVolumeName+"-"+sts.Name+"-"), 10, 32)
+	if err != nil {
+		return false
+	}
+	return cr.Spec.PXC.VolumeOverride(int32(ordinal)) != nil
+}
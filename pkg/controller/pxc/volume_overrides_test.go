@@ -0,0 +1,76 @@
+package pxc
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
+)
+
+func TestReconcileVolumeOverrides(t *testing.T) {
+	ctx := context.Background()
+
+	cr := newCR("cr-mock", "pxc")
+	cr.Spec.VolumeExpansionEnabled = true
+	cr.Spec.PXC.VolumeSpec = &api.VolumeSpec{
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("10Gi")},
+			},
+		},
+	}
+	cr.Spec.PXC.VolumeOverrides = []api.PodVolumeOverride{
+		{Ordinal: 2, StorageClassName: ptr.To("cheap"), Size: ptr.To(resource.MustParse("50Gi"))},
+		{Ordinal: 5, Size: ptr.To(resource.MustParse("50Gi"))},
+	}
+
+	r := buildFakeClient([]runtime.Object{cr})
+
+	if err := r.reconcileVolumeOverrides(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+
+	pvc := new(corev1.PersistentVolumeClaim)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: "datadir-cr-mock-pxc-2", Namespace: cr.Namespace}, pvc); err != nil {
+		t.Fatal(err)
+	}
+	if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "50Gi" {
+		t.Errorf("expected the overridden size, got %s", size.String())
+	}
+	if sc := ptr.Deref(pvc.Spec.StorageClassName, ""); sc != "cheap" {
+		t.Errorf("expected the overridden storage class, got %s", sc)
+	}
+	if cr.Spec.PXC.VolumeSpec.PersistentVolumeClaim.StorageClassName != nil {
+		t.Error("volumeSpec is changed")
+	}
+
+	// the pod with the ordinal doesn't exist
+	err := r.client.Get(ctx, types.NamespacedName{Name: "datadir-cr-mock-pxc-5", Namespace: cr.Namespace}, new(corev1.PersistentVolumeClaim))
+	if err == nil {
+		t.Error("PVC of ordinal 5 is created")
+	}
+
+	cr.Spec.PXC.VolumeOverrides[0].Size = ptr.To(resource.MustParse("80Gi"))
+	if err := r.reconcileVolumeOverrides(ctx, cr); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: "datadir-cr-mock-pxc-2", Namespace: cr.Namespace}, pvc); err != nil {
+		t.Fatal(err)
+	}
+	if size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "80Gi" {
+		t.Errorf("expected the PVC to be resized, got %s", size.String())
+	}
+
+	sts := statefulset.NewNode(cr).StatefulSet()
+	if !volumeOverridden(cr, *pvc, sts) {
+		t.Error("expected the PVC to be skipped by the template resize")
+	}
+}
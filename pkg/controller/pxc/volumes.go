@@ -3,6 +3,7 @@ package pxc
 import (
 	"context"
 	stderrors "errors"
+	"fmt"
 	"math"
 	"slices"
 	"strings"
@@ -41,6 +42,9 @@ func (r *ReconcilePerconaXtraDBCluster) reconcilePersistentVolumes(ctx context.C
 	ls := naming.LabelsPXC(cr)
 	log := logf.FromContext(ctx).WithName("PVCResize").WithValues("sts", sts.Name)
 
+	pendingComponent := sts.Name + "-volumes"
+	cr.SetPendingActions(pendingComponent, nil)
+
 	pvcList := &corev1.PersistentVolumeClaimList{}
 	err := r.client.List(ctx, pvcList, &client.ListOptions{
 		Namespace:     sts.Namespace,
@@ -70,7 +74,7 @@ func (r *ReconcilePerconaXtraDBCluster) reconcilePersistentVolumes(ctx context.C
 
 	pvcsToUpdate := make([]string, 0, len(pvcList.Items))
 	for _, pvc := range pvcList.Items {
-		if !validatePVCName(pvc, sts) {
+		if !validatePVCName(pvc, sts) || volumeOverridden(cr, pvc, sts) {
 			continue
 		}
 
@@ -88,7 +92,7 @@ func (r *ReconcilePerconaXtraDBCluster) reconcilePersistentVolumes(ctx context.C
 
 	var actual resource.Quantity
 	for _, pvc := range pvcList.Items {
-		if !validatePVCName(pvc, sts) {
+		if !validatePVCName(pvc, sts) || volumeOverridden(cr, pvc, sts) {
 			continue
 		}
 
@@ -122,6 +126,28 @@ func (r *ReconcilePerconaXtraDBCluster) reconcilePersistentVolumes(ctx context.C
 		}
 	}
 
+	sc, configuredSC := cr.Spec.PXC.VolumeSpec.PersistentVolumeClaim.StorageClassName, volumeTemplate.Spec.StorageClassName
+	if sc != nil && configuredSC != nil && *sc != *configuredSC {
+		if !cr.UnsafeChangesAllowed() {
+			return errors.Errorf("storageClassName can't be changed from %s to %s, existing PVCs keep the old class; set %s annotation to recreate the statefulset",
+				*configuredSC, *sc, pxcv1.AnnotationAllowUnsafeChanges)
+		}
+
+		cr.SetPendingActions(pendingComponent, []string{
+			fmt.Sprintf("will recreate statefulset %s with storageClassName %s", sts.Name, *sc),
+		})
+		if !cr.ChangesApproved() {
+			log.Info("storageClassName change is waiting for approval", "id", cr.Status.PendingChanges.ID)
+			return nil
+		}
+
+		log.Info("storageClassName is changed, deleting statefulset", "old", *configuredSC, "new", *sc)
+		if err := r.client.Delete(ctx, sts, client.PropagationPolicy("Orphan")); err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrapf(err, "delete statefulset/%s", sts.Name)
+		}
+		return nil
+	}
+
 	configured := volumeTemplate.Spec.Resources.Requests[corev1.ResourceStorage]
 	requested := cr.Spec.PXC.VolumeSpec.PersistentVolumeClaim.Resources.Requests[corev1.ResourceStorage]
 	gib, err := RoundUpGiB(requested.Value())
@@ -141,7 +167,7 @@ func (r *ReconcilePerconaXtraDBCluster) reconcilePersistentVolumes(ctx context.C
 		var resizeErrors []error
 		pendingResize := false
 		for _, pvc := range pvcList.Items {
-			if !validatePVCName(pvc, sts) {
+			if !validatePVCName(pvc, sts) || volumeOverridden(cr, pvc, sts) {
 				continue
 			}
 
@@ -166,7 +192,7 @@ func (r *ReconcilePerconaXtraDBCluster) reconcilePersistentVolumes(ctx context.C
 			events := &eventsv1.EventList{}
 			if err := r.client.List(ctx, events, &client.ListOptions{
 				Namespace:     sts.Namespace,
-				FieldSelector: fields.SelectorFromSet(map[string]string{"regarding.name": pvc.Name}),
+				FieldSelector: fields.SelectorFromSet(map[string]string{k8s.IndexEventRegarding: pvc.Name}),
 			}); err != nil {
 				return errors.Wrapf(err, "list events for pvc/%s", pvc.Name)
 			}
@@ -244,6 +270,14 @@ func (r *ReconcilePerconaXtraDBCluster) reconcilePersistentVolumes(ctx context.C
 		return nil
 	}
 
+	cr.SetPendingActions(pendingComponent, []string{
+		fmt.Sprintf("will resize %d PVCs from %s to %s", len(pvcsToUpdate), actual.String(), requested.String()),
+	})
+	if !cr.ChangesApproved() {
+		log.Info("PVC resize is waiting for approval", "id", cr.Status.PendingChanges.ID)
+		return nil
+	}
+
 	err = k8s.AnnotateObject(ctx, r.client, cr, map[string]string{pxcv1.AnnotationPVCResizeInProgress: metav1.Now().Format(time.RFC3339)})
 	if err != nil {
 		return errors.Wrap(err, "annotate pxc")
@@ -300,6 +334,13 @@ func (r *ReconcilePerconaXtraDBCluster) handlePVCResizeFailure(ctx context.Conte
 func (r *ReconcilePerconaXtraDBCluster) revertVolumeTemplate(ctx context.Context, cr *pxcv1.PerconaXtraDBCluster, originalSize resource.Quantity) error {
 	log := logf.FromContext(ctx)
 
+	if cr.Spec.GitOps.IsEnabled() {
+		log.Info("Suggesting to revert volume template for PXC", "originalSize", originalSize)
+		cr.Status.SuggestSpecChange("spec.pxc.volumeSpec.persistentVolumeClaim.resources.requests.storage",
+			originalSize.String(), "PVC resize failed")
+		return nil
+	}
+
 	orig := cr.DeepCopy()
 
 	log.Info("Reverting volume template for PXC", "originalSize", originalSize)
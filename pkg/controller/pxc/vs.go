@@ -1,6 +1,8 @@
 package pxc
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -24,6 +26,11 @@ func (vs VersionServiceClient) GetExactVersion(cr *api.PerconaXtraDBCluster, end
 		return DepVersion{}, err
 	}
 
+	httpClient, err := newVersionServiceHTTPClient(vm.CA)
+	if err != nil {
+		return DepVersion{}, err
+	}
+
 	srvCl := vsc.NewHTTPClientWithConfig(nil, &vsc.TransportConfig{
 		Host:     requestURL.Host,
 		BasePath: requestURL.Path,
@@ -46,7 +53,7 @@ func (vs VersionServiceClient) GetExactVersion(cr *api.PerconaXtraDBCluster, end
 		ProxysqlVersion:       &vm.ProxySQLVersion,
 		Context:               nil,
 		ClusterWideEnabled:    &vm.ClusterWideEnabled,
-		HTTPClient:            &http.Client{Timeout: 10 * time.Second},
+		HTTPClient:            httpClient,
 		UserManagementEnabled: &vm.UserManagementEnabled,
 	}
 	applyParams = applyParams.WithTimeout(10 * time.Second)
@@ -117,6 +124,30 @@ func (vs VersionServiceClient) GetExactVersion(cr *api.PerconaXtraDBCluster, end
 	return dv, nil
 }
 
+// newVersionServiceHTTPClient returns the client which trusts
+// the system CAs and the CA of the version service mirror.
+func newVersionServiceHTTPClient(ca []byte) (*http.Client, error) {
+	cl := &http.Client{Timeout: 10 * time.Second}
+	if len(ca) == 0 {
+		return cl, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("failed to parse version service CA")
+	}
+
+	cl.Transport = &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12},
+	}
+
+	return cl, nil
+}
+
 func getVersion(versions map[string]models.VersionVersion) (string, error) {
 	if len(versions) != 1 {
 		return "", fmt.Errorf("response has multiple or zero versions")
@@ -164,4 +195,5 @@ type versionMeta struct {
 	CRUID                 string
 	ClusterWideEnabled    bool
 	UserManagementEnabled bool
+	CA                    []byte
 }
@@ -16,10 +16,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -27,11 +29,14 @@ import (
 
 	"github.com/percona/percona-xtradb-cluster-operator/clientcmd"
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/audit"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/binlogcollector"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/tracing"
 	"github.com/percona/percona-xtradb-cluster-operator/version"
 )
 
@@ -71,21 +76,44 @@ func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
 	}
 
 	return &ReconcilePerconaXtraDBClusterBackup{
-		client:              mgr.GetClient(),
+		client:              tracing.WrapClient(mgr.GetClient()),
 		scheme:              mgr.GetScheme(),
 		serverVersion:       sv,
 		clientcmd:           cli,
 		chLimit:             make(chan struct{}, limit),
 		bcpDeleteInProgress: new(sync.Map),
+		recorder:            mgr.GetEventRecorderFor("pxcbackup-controller"),
+		newStorageClient:    storage.NewClient,
 	}, nil
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
-	return builder.ControllerManagedBy(mgr).
+	maxConcurrentReconciles, err := k8s.GetMaxConcurrentReconciles(k8s.MaxConcurrentReconcilesBackupEnvVar)
+	if err != nil {
+		return err
+	}
+	rateLimiter, err := k8s.NewRateLimiter()
+	if err != nil {
+		return err
+	}
+
+	b := builder.ControllerManagedBy(mgr).
 		Named("pxcbackup-controller").
-		Watches(&api.PerconaXtraDBClusterBackup{}, &handler.EnqueueRequestForObject{}).
-		Complete(r)
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             rateLimiter,
+		}).
+		Watches(&api.PerconaXtraDBClusterBackup{}, &handler.EnqueueRequestForObject{},
+			builder.WithPredicates(
+				k8s.NamespaceSelectorPredicate(mgr.GetClient()),
+				k8s.ClusterSelectorPredicate(mgr.GetClient(), func(obj client.Object) types.NamespacedName {
+					return types.NamespacedName{Name: obj.(*api.PerconaXtraDBClusterBackup).Spec.PXCCluster, Namespace: obj.GetNamespace()}
+				}),
+			))
+
+	return k8s.WatchNamespaces(b, mgr.GetClient(), func() client.ObjectList { return new(api.PerconaXtraDBClusterBackupList) }).
+		Complete(k8s.DetachReconciler(metrics.InstrumentReconciler("pxcbackup-controller", tracing.InstrumentReconciler("pxcbackup-controller", r))))
 }
 
 var _ reconcile.Reconciler = &ReconcilePerconaXtraDBClusterBackup{}
@@ -101,6 +129,8 @@ type ReconcilePerconaXtraDBClusterBackup struct {
 	clientcmd           *clientcmd.Client
 	chLimit             chan struct{}
 	bcpDeleteInProgress *sync.Map
+	recorder            record.EventRecorder
+	newStorageClient    storage.NewClientFunc
 }
 
 // Reconcile reads that state of the cluster for a PerconaXtraDBClusterBackup object and makes changes based on the state read
@@ -134,7 +164,9 @@ func (r *ReconcilePerconaXtraDBClusterBackup) Reconcile(ctx context.Context, req
 		return reconcile.Result{}, errors.Wrap(err, "ensure finalizers")
 	}
 
-	err = r.tryRunBackupFinalizers(ctx, cr)
+	err = tracing.Phase(ctx, "finalizers", func(ctx context.Context) error {
+		return r.tryRunBackupFinalizers(ctx, cr)
+	})
 	if err != nil {
 		return reconcile.Result{}, errors.Wrap(err, "run finalizers")
 	}
@@ -191,7 +223,9 @@ func (r *ReconcilePerconaXtraDBClusterBackup) Reconcile(ctx context.Context, req
 		return rr, errors.Wrap(err, "reconcile backup job")
 	}
 
-	if err := cluster.CanBackup(); err != nil {
+	// the final backup of the ordered teardown runs while the deleted cluster is stopping
+	finalBackup := cluster.DeletionTimestamp != nil && cr.Labels[naming.LabelPerconaBackupType] == "final" && cluster.Status.PXC.Ready > 0
+	if err := cluster.CanBackup(); err != nil && !finalBackup {
 		log.Info("Cluster is not ready for backup", "reason", err.Error())
 
 		return rr, nil
@@ -224,6 +258,15 @@ func (r *ReconcilePerconaXtraDBClusterBackup) Reconcile(ctx context.Context, req
 		}
 	}
 
+	if storage.Type == api.BackupStorageS3 && storage.S3 != nil && len(storage.S3.Alternates) > 0 {
+		s3, err := r.availableS3Endpoint(ctx, cr, cluster)
+		if err != nil {
+			return rr, errors.Wrap(err, "select S3 endpoint")
+		}
+		storage = storage.DeepCopy()
+		storage.S3 = s3
+	}
+
 	if cr.Status.S3 == nil || cr.Status.Azure == nil {
 		cr.Status.S3 = storage.S3
 		cr.Status.Azure = storage.Azure
@@ -235,7 +278,12 @@ func (r *ReconcilePerconaXtraDBClusterBackup) Reconcile(ctx context.Context, req
 		cr.Status.VerifyTLS = storage.VerifyTLS
 	}
 
-	job, err := r.createBackupJob(ctx, cr, cluster, storage)
+	var job *batchv1.Job
+	err = tracing.Phase(ctx, "create job", func(ctx context.Context) error {
+		var err error
+		job, err = r.createBackupJob(ctx, cr, cluster, storage)
+		return err
+	})
 	if err != nil {
 		err = errors.Wrap(err, "create backup job")
 
@@ -246,7 +294,9 @@ func (r *ReconcilePerconaXtraDBClusterBackup) Reconcile(ctx context.Context, req
 		return reconcile.Result{}, err
 	}
 
-	err = r.updateJobStatus(ctx, cr, job, cr.Spec.StorageName, storage, cluster)
+	err = tracing.Phase(ctx, "job status", func(ctx context.Context) error {
+		return r.updateJobStatus(ctx, cr, job, cr.Spec.StorageName, storage, cluster)
+	})
 
 	switch cr.Status.State {
 	case api.BackupSucceeded, api.BackupFailed:
@@ -521,32 +571,7 @@ func (r *ReconcilePerconaXtraDBClusterBackup) runReleaseLockFinalizer(ctx contex
 
 func removeBackupObjects(ctx context.Context, s storage.Storage, destination string) func() error {
 	return func() error {
-		blobs, err := s.ListObjects(ctx, destination)
-		if err != nil {
-			return errors.Wrap(err, "list backup blobs")
-		}
-		for _, blob := range blobs {
-			if err := s.DeleteObject(ctx, blob); err != nil {
-				return errors.Wrapf(err, "delete object %s", blob)
-			}
-		}
-		if err := s.DeleteObject(ctx, strings.TrimSuffix(destination, "/")+".md5"); err != nil && err != storage.ErrObjectNotFound {
-			return errors.Wrapf(err, "delete object %s", strings.TrimSuffix(destination, "/")+".md5")
-		}
-		destination = strings.TrimSuffix(destination, "/") + ".sst_info/"
-		blobs, err = s.ListObjects(ctx, destination)
-		if err != nil {
-			return errors.Wrap(err, "list backup objects")
-		}
-		for _, blob := range blobs {
-			if err := s.DeleteObject(ctx, blob); err != nil {
-				return errors.Wrapf(err, "delete object %s", blob)
-			}
-		}
-		if err := s.DeleteObject(ctx, strings.TrimSuffix(destination, "/")+".md5"); err != nil && err != storage.ErrObjectNotFound {
-			return errors.Wrapf(err, "delete object %s", strings.TrimSuffix(destination, "/")+".md5")
-		}
-		return nil
+		return storage.DeleteBackupObjects(ctx, s, destination)
 	}
 }
 
@@ -591,6 +616,7 @@ func (r *ReconcilePerconaXtraDBClusterBackup) updateJobStatus(
 		SSLInternalSecretName: bcp.Status.SSLInternalSecretName,
 		VaultSecretName:       bcp.Status.VaultSecretName,
 		VerifyTLS:             storage.VerifyTLS,
+		Conditions:            append([]metav1.Condition(nil), bcp.Status.Conditions...),
 	}
 
 	if job.Status.Active == 1 {
@@ -610,6 +636,8 @@ func (r *ReconcilePerconaXtraDBClusterBackup) updateJobStatus(
 		}
 	}
 
+	status.SetStateConditions(bcp.Generation)
+
 	// don't update the status if there aren't any changes.
 	if reflect.DeepEqual(bcp.Status, status) {
 		return nil
@@ -658,6 +686,29 @@ func (r *ReconcilePerconaXtraDBClusterBackup) updateJobStatus(
 		return errors.Wrap(err, "update status")
 	}
 
+	switch status.State {
+	case api.BackupSucceeded:
+		r.recorder.Eventf(bcp, corev1.EventTypeNormal, naming.EventBackupSucceeded, "Backup to storage %s succeeded", storageName)
+		audit.RecordOperation(ctx, r.client, bcp.Namespace, bcp.Spec.PXCCluster, audit.OperationEntry{
+			Operation: audit.OperationBackup,
+			Result:    audit.OperationSucceeded,
+			Object:    bcp.Name,
+			Message:   "backup to " + status.Destination.String(),
+		})
+	case api.BackupFailed:
+		r.recorder.Eventf(bcp, corev1.EventTypeWarning, naming.EventBackupFailed, "Backup job %s failed", job.Name)
+		audit.RecordOperation(ctx, r.client, bcp.Namespace, bcp.Spec.PXCCluster, audit.OperationEntry{
+			Operation: audit.OperationBackup,
+			Result:    audit.OperationFailed,
+			Object:    bcp.Name,
+			Message:   "backup job " + job.Name + " failed",
+		})
+	}
+
+	if status.State == api.BackupSucceeded || status.State == api.BackupFailed {
+		metrics.ObserveBackup(bcp)
+	}
+
 	return nil
 }
 
@@ -685,16 +736,11 @@ func (r *ReconcilePerconaXtraDBClusterBackup) checkStartingDeadline(ctx context.
 }
 
 func (r *ReconcilePerconaXtraDBClusterBackup) updateStatus(ctx context.Context, cr *api.PerconaXtraDBClusterBackup) error {
-	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		localCr := new(api.PerconaXtraDBClusterBackup)
-		err := r.client.Get(ctx, client.ObjectKeyFromObject(cr), localCr)
-		if err != nil {
-			return err
-		}
-
-		localCr.Status = cr.Status
+	cr.Status.SetStateConditions(cr.Generation)
 
-		return r.client.Status().Update(ctx, localCr)
+	status := cr.Status.DeepCopy()
+	return k8s.PatchStatus(ctx, r.client, cr, func(c *api.PerconaXtraDBClusterBackup) {
+		c.Status = *status
 	})
 }
 
@@ -704,7 +750,20 @@ func (r *ReconcilePerconaXtraDBClusterBackup) setFailedStatus(
 	err error,
 ) error {
 	cr.SetFailedStatusWithError(err)
-	return r.updateStatus(ctx, cr)
+	if err := r.updateStatus(ctx, cr); err != nil {
+		return err
+	}
+
+	r.recorder.Event(cr, corev1.EventTypeWarning, naming.EventBackupFailed, err.Error())
+	audit.RecordOperation(ctx, r.client, cr.Namespace, cr.Spec.PXCCluster, audit.OperationEntry{
+		Operation: audit.OperationBackup,
+		Result:    audit.OperationFailed,
+		Object:    cr.Name,
+		Message:   err.Error(),
+	})
+
+	metrics.ObserveBackup(cr)
+	return nil
 }
 
 func (r *ReconcilePerconaXtraDBClusterBackup) suspendJobIfNeeded(
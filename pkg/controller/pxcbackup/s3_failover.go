@@ -0,0 +1,47 @@
+package pxcbackup
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+)
+
+// availableS3Endpoint returns the S3 storage of the backup with the first available endpoint as the main one.
+// The endpoint is selected once, before the backup job is started, the backup keeps it afterwards.
+func (r *ReconcilePerconaXtraDBClusterBackup) availableS3Endpoint(ctx context.Context, cr *api.PerconaXtraDBClusterBackup, cluster *api.PerconaXtraDBCluster) (*api.BackupStorageS3Spec, error) {
+	log := logf.FromContext(ctx)
+
+	if cr.Status.State != api.BackupNew && cr.Status.S3 != nil {
+		return cr.Status.S3, nil
+	}
+
+	s3 := cluster.Spec.Backup.Storages[cr.Spec.StorageName].S3
+
+	opts, err := storage.GetOptions(ctx, r.client, cluster, cr.Spec.StorageName)
+	if err != nil {
+		return nil, errors.Wrap(err, "get storage options")
+	}
+	s3Opts, ok := opts.(*storage.S3Options)
+	if !ok {
+		return nil, errors.New("invalid options type")
+	}
+
+	i, err := storage.AvailableS3Endpoint(ctx, s3Opts, r.newStorageClient)
+	if err != nil {
+		return nil, err
+	}
+	if i > 0 {
+		endpoint := s3.Endpoints()[i]
+		log.Info("Main S3 endpoint is unavailable, using the alternate one", "endpoint", endpoint.EndpointURL, "bucket", endpoint.Bucket)
+		r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventS3EndpointFailover,
+			"Main endpoint of storage %s is unavailable, the backup goes to bucket %s of endpoint %s", cr.Spec.StorageName, endpoint.Bucket, endpoint.EndpointURL)
+	}
+
+	return s3.Failover(i), nil
+}
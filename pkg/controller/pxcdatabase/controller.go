@@ -0,0 +1,314 @@
+package pxcdatabase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/audit"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+// Add creates a new PerconaXtraDBDatabase Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcilePerconaXtraDBDatabase{
+		client: mgr.GetClient(),
+		scheme: mgr.GetScheme(),
+		audit:  audit.NewRecorder(mgr.GetClient(), mgr.GetEventRecorderFor("pxcdatabase-controller")),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	rateLimiter, err := k8s.NewRateLimiter()
+	if err != nil {
+		return err
+	}
+
+	b := builder.ControllerManagedBy(mgr).
+		Named("pxcdatabase-controller").
+		WithOptions(controller.Options{RateLimiter: rateLimiter}).
+		// status updates shouldn't bypass the backoff of failed reconciles
+		For(&api.PerconaXtraDBDatabase{}, builder.WithPredicates(
+			predicate.GenerationChangedPredicate{},
+			k8s.NamespaceSelectorPredicate(mgr.GetClient()),
+			k8s.ClusterSelectorPredicate(mgr.GetClient(), func(obj client.Object) types.NamespacedName {
+				ref := obj.(*api.PerconaXtraDBDatabase).Spec.ClusterRef
+				if ref.Namespace == "" {
+					return types.NamespacedName{Name: ref.Name, Namespace: obj.GetNamespace()}
+				}
+				return types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+			}),
+		)).
+		Owns(&corev1.Secret{})
+
+	return k8s.WatchNamespaces(b, mgr.GetClient(), func() client.ObjectList { return new(api.PerconaXtraDBDatabaseList) }).
+		Complete(k8s.DetachReconciler(metrics.InstrumentReconciler("pxcdatabase-controller", r)))
+}
+
+var _ reconcile.Reconciler = &ReconcilePerconaXtraDBDatabase{}
+
+// ReconcilePerconaXtraDBDatabase reconciles a PerconaXtraDBDatabase object
+type ReconcilePerconaXtraDBDatabase struct {
+	client client.Client
+	scheme *runtime.Scheme
+	audit  *audit.Recorder
+}
+
+// Reconcile creates the schema, the owner user and the connection secret
+// described by the PerconaXtraDBDatabase object.
+func (r *ReconcilePerconaXtraDBDatabase) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logf.FromContext(ctx)
+
+	rr := reconcile.Result{}
+
+	cr := new(api.PerconaXtraDBDatabase)
+	err := r.client.Get(ctx, request.NamespacedName, cr)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return rr, nil
+		}
+		return rr, err
+	}
+
+	if err := cr.CheckNSetDefaults(); err != nil {
+		return rr, r.setStatus(ctx, cr, api.DatabaseStateError, err.Error())
+	}
+
+	if cr.DeletionTimestamp != nil {
+		return rr, r.delete(ctx, cr)
+	}
+
+	if !controllerutil.ContainsFinalizer(cr, naming.FinalizerDeleteDatabase) {
+		controllerutil.AddFinalizer(cr, naming.FinalizerDeleteDatabase)
+		if err := r.client.Update(ctx, cr); err != nil {
+			return rr, errors.Wrap(err, "add finalizer")
+		}
+	}
+
+	cluster, err := r.getCluster(ctx, cr)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			msg := fmt.Sprintf("cluster %s/%s is not found", cr.Spec.ClusterRef.Namespace, cr.Spec.ClusterRef.Name)
+			return reconcile.Result{RequeueAfter: 10 * time.Second}, r.setStatus(ctx, cr, api.DatabaseStatePending, msg)
+		}
+		return rr, errors.Wrap(err, "get cluster")
+	}
+
+	if cluster.Status.Status != api.AppStateReady || cluster.Status.Host == "" {
+		msg := fmt.Sprintf("waiting for cluster %s to be ready", cluster.Name)
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, r.setStatus(ctx, cr, api.DatabaseStatePending, msg)
+	}
+
+	if err := r.reconcileDatabase(ctx, cr, cluster); err != nil {
+		// the request is requeued with backoff
+		if serr := r.setStatus(ctx, cr, api.DatabaseStateError, err.Error()); serr != nil {
+			log.Error(serr, "failed to set status")
+		}
+		return rr, errors.Wrap(err, "reconcile database")
+	}
+
+	return rr, r.setStatus(ctx, cr, api.DatabaseStateReady, "")
+}
+
+func (r *ReconcilePerconaXtraDBDatabase) reconcileDatabase(ctx context.Context, cr *api.PerconaXtraDBDatabase, cluster *api.PerconaXtraDBCluster) error {
+	secret, err := r.getConnectionSecret(ctx, cr)
+	if err != nil {
+		return errors.Wrap(err, "get connection secret")
+	}
+
+	pass := secret.Data["password"]
+	newUser := len(pass) == 0
+	if newUser {
+		pass, err = users.GenerateDSNSafePass(cluster.Spec.PXC.PasswordPolicy)
+		if err != nil {
+			return errors.Wrap(err, "generate password")
+		}
+	}
+
+	um, err := pxc.GetUsersManager(ctx, r.client, cluster)
+	if err != nil {
+		return err
+	}
+	defer um.Close()
+
+	if err := um.CreateDatabase(ctx, cr.Spec.Name, cr.Spec.CharacterSet, cr.Spec.Collation); err != nil {
+		return err
+	}
+
+	owner := cr.Spec.Owner
+	if err := um.UpsertDatabaseOwner(ctx, owner.Name, owner.Hosts, string(pass), cr.Spec.Name, owner.Grants); err != nil {
+		return err
+	}
+	if newUser {
+		r.audit.Record(ctx, cluster, cr, audit.Entry{
+			Action:  audit.ActionUserCreated,
+			User:    owner.Name,
+			Hosts:   owner.Hosts,
+			Trigger: "perconaxtradbdatabase/" + cr.Name,
+			Actor:   audit.Actor(cr),
+		})
+	}
+
+	_, err = controllerutil.CreateOrUpdate(ctx, r.client, secret, func() error {
+		if cr.Spec.ConnectionSecret.Namespace == cr.Namespace {
+			if err := controllerutil.SetControllerReference(cr, secret, r.scheme); err != nil {
+				return err
+			}
+		}
+		if secret.Labels == nil {
+			secret.Labels = make(map[string]string)
+		}
+		secret.Labels[naming.LabelAppKubernetesInstance] = cluster.Name
+		secret.Labels[naming.LabelAppKubernetesManagedBy] = "percona-xtradb-cluster-operator"
+
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = map[string][]byte{
+			"host":     []byte(cluster.Status.Host),
+			"port":     []byte(fmt.Sprint(users.MySQLPort)),
+			"database": []byte(cr.Spec.Name),
+			"username": []byte(owner.Name),
+			"password": pass,
+			"dsn":      []byte(fmt.Sprintf("%s:%s@tcp(%s:%d)/%s", owner.Name, pass, cluster.Status.Host, users.MySQLPort, cr.Spec.Name)),
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Wrap(err, "create or update connection secret")
+	}
+
+	return nil
+}
+
+func (r *ReconcilePerconaXtraDBDatabase) delete(ctx context.Context, cr *api.PerconaXtraDBDatabase) error {
+	log := logf.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(cr, naming.FinalizerDeleteDatabase) {
+		return nil
+	}
+
+	if cr.Spec.DeletionPolicy == api.DatabaseDeletionDelete {
+		cluster, err := r.getCluster(ctx, cr)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrap(err, "get cluster")
+		}
+
+		// if the cluster is gone there is nothing to drop
+		if err == nil {
+			if cluster.Status.Status != api.AppStateReady {
+				return errors.Errorf("cluster %s is not ready, can't drop database %s", cluster.Name, cr.Spec.Name)
+			}
+
+			um, err := pxc.GetUsersManager(ctx, r.client, cluster)
+			if err != nil {
+				return err
+			}
+			defer um.Close()
+
+			if err := um.DropUser(ctx, cr.Spec.Owner.Name, cr.Spec.Owner.Hosts); err != nil {
+				return err
+			}
+			r.audit.Record(ctx, cluster, cr, audit.Entry{
+				Action:  audit.ActionUserDropped,
+				User:    cr.Spec.Owner.Name,
+				Hosts:   cr.Spec.Owner.Hosts,
+				Trigger: "perconaxtradbdatabase/" + cr.Name,
+				Actor:   audit.Actor(cr),
+			})
+			if err := um.DropDatabase(ctx, cr.Spec.Name); err != nil {
+				return err
+			}
+
+			log.Info("Database dropped", "database", cr.Spec.Name, "cluster", cluster.Name)
+		}
+	}
+
+	// secrets in the same namespace are garbage collected by owner reference
+	if cr.Spec.ConnectionSecret.Namespace != cr.Namespace {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cr.Spec.ConnectionSecret.Name,
+				Namespace: cr.Spec.ConnectionSecret.Namespace,
+			},
+		}
+		if err := r.client.Delete(ctx, secret); err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrap(err, "delete connection secret")
+		}
+	}
+
+	controllerutil.RemoveFinalizer(cr, naming.FinalizerDeleteDatabase)
+	if err := r.client.Update(ctx, cr); err != nil {
+		return errors.Wrap(err, "remove finalizer")
+	}
+
+	return nil
+}
+
+func (r *ReconcilePerconaXtraDBDatabase) getCluster(ctx context.Context, cr *api.PerconaXtraDBDatabase) (*api.PerconaXtraDBCluster, error) {
+	cluster := new(api.PerconaXtraDBCluster)
+	err := r.client.Get(ctx, types.NamespacedName{Name: cr.Spec.ClusterRef.Name, Namespace: cr.Spec.ClusterRef.Namespace}, cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return cluster, nil
+}
+
+func (r *ReconcilePerconaXtraDBDatabase) getConnectionSecret(ctx context.Context, cr *api.PerconaXtraDBDatabase) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Spec.ConnectionSecret.Name,
+			Namespace: cr.Spec.ConnectionSecret.Namespace,
+		},
+	}
+
+	err := r.client.Get(ctx, client.ObjectKeyFromObject(secret), secret)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+func (r *ReconcilePerconaXtraDBDatabase) setStatus(ctx context.Context, cr *api.PerconaXtraDBDatabase, state api.DatabaseState, msg string) error {
+	if cr.Status.State == state && cr.Status.Message == msg && cr.Status.ObservedGeneration == cr.Generation {
+		return nil
+	}
+
+	err := k8s.PatchStatus(ctx, r.client, cr, func(c *api.PerconaXtraDBDatabase) {
+		c.Status.State = state
+		c.Status.Message = msg
+		c.Status.ObservedGeneration = c.Generation
+	})
+	if err != nil {
+		return errors.Wrap(err, "update status")
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package pxcdatabase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/audit"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		db      *api.PerconaXtraDBDatabase
+		cluster *api.PerconaXtraDBCluster
+
+		state     api.DatabaseState
+		message   string
+		requeue   time.Duration
+		finalizer bool
+	}{
+		"invalid spec": {
+			db: newDatabase(func(db *api.PerconaXtraDBDatabase) {
+				db.Spec.Name = "app;drop"
+			}),
+			state:   api.DatabaseStateError,
+			message: "invalid schema name app;drop",
+		},
+		"cluster not found": {
+			db:        newDatabase(nil),
+			state:     api.DatabaseStatePending,
+			message:   "cluster pxc/cluster1 is not found",
+			requeue:   10 * time.Second,
+			finalizer: true,
+		},
+		"cluster not ready": {
+			db:        newDatabase(nil),
+			cluster:   newCluster(api.AppStateInit),
+			state:     api.DatabaseStatePending,
+			message:   "waiting for cluster cluster1 to be ready",
+			requeue:   10 * time.Second,
+			finalizer: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			objs := []runtime.Object{tt.db}
+			if tt.cluster != nil {
+				objs = append(objs, tt.cluster)
+			}
+			cl := buildFakeClient(objs...)
+
+			res, err := reconciler(cl).Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(tt.db)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.RequeueAfter != tt.requeue {
+				t.Errorf("expected requeue after %s, got %s", tt.requeue, res.RequeueAfter)
+			}
+
+			db := new(api.PerconaXtraDBDatabase)
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(tt.db), db); err != nil {
+				t.Fatal(err)
+			}
+			if db.Status.State != tt.state || db.Status.Message != tt.message {
+				t.Errorf("expected status %s %q, got %s %q", tt.state, tt.message, db.Status.State, db.Status.Message)
+			}
+			if controllerutil.ContainsFinalizer(db, naming.FinalizerDeleteDatabase) != tt.finalizer {
+				t.Errorf("expected finalizer %t, got %v", tt.finalizer, db.Finalizers)
+			}
+		})
+	}
+}
+
+func TestReconcileDelete(t *testing.T) {
+	ctx := context.Background()
+
+	tests := map[string]struct {
+		policy  api.DatabaseDeletionPolicy
+		cluster *api.PerconaXtraDBCluster
+		deleted bool
+	}{
+		"retain": {
+			policy:  api.DatabaseDeletionRetain,
+			cluster: newCluster(api.AppStateReady),
+			deleted: true,
+		},
+		"delete without cluster": {
+			policy:  api.DatabaseDeletionDelete,
+			deleted: true,
+		},
+		"delete with cluster not ready": {
+			policy:  api.DatabaseDeletionDelete,
+			cluster: newCluster(api.AppStateInit),
+			deleted: false,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			db := newDatabase(func(db *api.PerconaXtraDBDatabase) {
+				db.Spec.DeletionPolicy = tt.policy
+				db.Spec.ConnectionSecret = api.DatabaseConnectionSecret{Name: "app-conn", Namespace: "apps"}
+				db.Finalizers = []string{naming.FinalizerDeleteDatabase}
+				db.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			})
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "app-conn", Namespace: "apps"}}
+
+			objs := []runtime.Object{db, secret}
+			if tt.cluster != nil {
+				objs = append(objs, tt.cluster)
+			}
+			cl := buildFakeClient(objs...)
+
+			_, err := reconciler(cl).Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(db)})
+			if tt.deleted && err != nil {
+				t.Fatal(err)
+			}
+			if !tt.deleted && err == nil {
+				t.Fatal("expected an error for the database of a not ready cluster")
+			}
+
+			err = cl.Get(ctx, client.ObjectKeyFromObject(db), new(api.PerconaXtraDBDatabase))
+			if k8serrors.IsNotFound(err) != tt.deleted {
+				t.Errorf("expected database deleted %t, got error %v", tt.deleted, err)
+			}
+			err = cl.Get(ctx, types.NamespacedName{Name: "app-conn", Namespace: "apps"}, new(corev1.Secret))
+			if k8serrors.IsNotFound(err) != tt.deleted {
+				t.Errorf("expected connection secret deleted %t, got error %v", tt.deleted, err)
+			}
+		})
+	}
+}
+
+func newDatabase(f func(db *api.PerconaXtraDBDatabase)) *api.PerconaXtraDBDatabase {
+	db := &api.PerconaXtraDBDatabase{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "pxc"},
+		Spec: api.PerconaXtraDBDatabaseSpec{
+			ClusterRef: api.ClusterReference{Name: "cluster1"},
+		},
+	}
+	if f != nil {
+		f(db)
+	}
+	return db
+}
+
+func newCluster(state api.AppState) *api.PerconaXtraDBCluster {
+	return &api.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "pxc"},
+		Status:     api.PerconaXtraDBClusterStatus{Status: state},
+	}
+}
+
+func reconciler(cl client.Client) *ReconcilePerconaXtraDBDatabase {
+	return &ReconcilePerconaXtraDBDatabase{
+		client: cl,
+		scheme: cl.Scheme(),
+		audit:  audit.NewRecorder(cl, new(record.FakeRecorder)),
+	}
+}
+
+func buildFakeClient(objs ...runtime.Object) client.Client {
+	s := scheme.Scheme
+
+	s.AddKnownTypes(api.SchemeGroupVersion, new(api.PerconaXtraDBDatabase), new(api.PerconaXtraDBDatabaseList))
+	s.AddKnownTypes(api.SchemeGroupVersion, new(api.PerconaXtraDBCluster), new(api.PerconaXtraDBClusterList))
+
+	return fake.NewClientBuilder().
+		WithScheme(s).
+		WithRuntimeObjects(objs...).
+		WithStatusSubresource(&api.PerconaXtraDBDatabase{}).
+		Build()
+}
@@ -0,0 +1,288 @@
+package pxcexport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+// Add creates a new PerconaXtraDBClusterExport Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcilePerconaXtraDBClusterExport{
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetEventRecorderFor("pxcexport-controller"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	rateLimiter, err := k8s.NewRateLimiter()
+	if err != nil {
+		return err
+	}
+
+	b := builder.ControllerManagedBy(mgr).
+		Named("pxcexport-controller").
+		WithOptions(controller.Options{RateLimiter: rateLimiter}).
+		For(&api.PerconaXtraDBClusterExport{}, builder.WithPredicates(
+			predicate.GenerationChangedPredicate{},
+			k8s.NamespaceSelectorPredicate(mgr.GetClient()),
+			k8s.ClusterSelectorPredicate(mgr.GetClient(), func(obj client.Object) types.NamespacedName {
+				return types.NamespacedName{Name: obj.(*api.PerconaXtraDBClusterExport).Spec.PXCCluster, Namespace: obj.GetNamespace()}
+			}),
+		)).
+		// the status follows the job and the runs of the cron job
+		Owns(&batchv1.Job{}).
+		Owns(&batchv1.CronJob{})
+
+	return k8s.WatchNamespaces(b, mgr.GetClient(), func() client.ObjectList { return new(api.PerconaXtraDBClusterExportList) }).
+		Complete(k8s.DetachReconciler(metrics.InstrumentReconciler("pxcexport-controller", r)))
+}
+
+var _ reconcile.Reconciler = &ReconcilePerconaXtraDBClusterExport{}
+
+// ReconcilePerconaXtraDBClusterExport reconciles a PerconaXtraDBClusterExport object
+type ReconcilePerconaXtraDBClusterExport struct {
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// Reconcile exports the data of the PerconaXtraDBClusterExport object once by a job,
+// or on schedule by a cron job if the schedule is set.
+func (r *ReconcilePerconaXtraDBClusterExport) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logf.FromContext(ctx)
+
+	rr := reconcile.Result{}
+
+	cr := new(api.PerconaXtraDBClusterExport)
+	err := r.client.Get(ctx, request.NamespacedName, cr)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return rr, nil
+		}
+		return rr, err
+	}
+
+	scheduled := cr.Spec.Schedule != ""
+	if !scheduled && (cr.Status.State == api.ExportStateSucceeded || cr.Status.State == api.ExportStateFailed) {
+		return rr, nil
+	}
+
+	if err := cr.CheckNSetDefaults(); err != nil {
+		return rr, r.setStatus(ctx, cr, api.ExportStateFailed, err.Error())
+	}
+
+	cluster := new(api.PerconaXtraDBCluster)
+	err = r.client.Get(ctx, types.NamespacedName{Name: cr.Spec.PXCCluster, Namespace: cr.Namespace}, cluster)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return rr, errors.Wrapf(err, "get cluster %s", cr.Spec.PXCCluster)
+	}
+	if k8serrors.IsNotFound(err) && cr.Status.State != api.ExportStateRunning {
+		msg := fmt.Sprintf("cluster %s is not found", cr.Spec.PXCCluster)
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, r.setStatus(ctx, cr, api.ExportStatePending, msg)
+	}
+
+	var state api.ExportState
+	var msg string
+	switch {
+	case scheduled:
+		state, msg, err = r.schedule(ctx, cr, cluster)
+	case cr.Status.State == api.ExportStateRunning:
+		state, msg, err = r.check(ctx, cr)
+	default:
+		if err := r.deleteCronJob(ctx, cr); err != nil {
+			return rr, err
+		}
+		if cluster.Status.Status != api.AppStateReady {
+			msg := fmt.Sprintf("waiting for cluster %s to be ready", cluster.Name)
+			return reconcile.Result{RequeueAfter: 10 * time.Second}, r.setStatus(ctx, cr, api.ExportStatePending, msg)
+		}
+		state, msg, err = r.start(ctx, cr, cluster)
+	}
+	if err != nil {
+		// the request is requeued with backoff
+		if serr := r.setStatus(ctx, cr, cr.Status.State, err.Error()); serr != nil {
+			log.Error(serr, "failed to set status", "state", cr.Status.State)
+		}
+		return rr, errors.Wrapf(err, "reconcile export in state %s", cr.Status.State)
+	}
+
+	if err := r.setStatus(ctx, cr, state, msg); err != nil {
+		return rr, err
+	}
+
+	switch {
+	case scheduled && state == api.ExportStateFailed:
+		// the storage can be fixed in the cluster, which isn't watched
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	case scheduled, state == api.ExportStateSucceeded, state == api.ExportStateFailed:
+		return rr, nil
+	}
+	return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+}
+
+// start creates the export job.
+func (r *ReconcilePerconaXtraDBClusterExport) start(ctx context.Context, cr *api.PerconaXtraDBClusterExport, cluster *api.PerconaXtraDBCluster) (api.ExportState, string, error) {
+	log := logf.FromContext(ctx)
+
+	storage, err := exportStorage(cr, cluster)
+	if err != nil {
+		return api.ExportStateFailed, err.Error(), nil
+	}
+
+	job := exportJob(cr, cluster, storage)
+	if err := controllerutil.SetControllerReference(cr, job, r.scheme); err != nil {
+		return "", "", errors.Wrap(err, "set controller reference")
+	}
+	if err := r.client.Create(ctx, job); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return "", "", errors.Wrap(err, "create job")
+	}
+
+	bucket, path := exportDestination(cr, cluster, storage)
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	cr.Status.Job = job.Name
+	cr.Status.Destination = destinationURL(storage, bucket, path)
+	cr.Status.StartedAt = &now
+
+	log.Info("export is started", "job", job.Name, "destination", cr.Status.Destination)
+
+	return api.ExportStateRunning, fmt.Sprintf("data is exported by job %s", job.Name), nil
+}
+
+// check waits for the export job to finish.
+func (r *ReconcilePerconaXtraDBClusterExport) check(ctx context.Context, cr *api.PerconaXtraDBClusterExport) (api.ExportState, string, error) {
+	job := new(batchv1.Job)
+	err := r.client.Get(ctx, types.NamespacedName{Name: cr.Status.Job, Namespace: cr.Namespace}, job)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return api.ExportStateFailed, fmt.Sprintf("job %s is deleted", cr.Status.Job), nil
+		}
+		return "", "", errors.Wrapf(err, "get job %s", cr.Status.Job)
+	}
+
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			cr.Status.LastSuccessfulTime = job.Status.CompletionTime
+			return api.ExportStateSucceeded, "data is exported to " + cr.Status.Destination, nil
+		case batchv1.JobFailed:
+			return api.ExportStateFailed, fmt.Sprintf("job %s failed: %s", job.Name, cond.Message), nil
+		}
+	}
+
+	return api.ExportStateRunning, fmt.Sprintf("data is exported by job %s", job.Name), nil
+}
+
+// schedule creates or updates the cron job and reports its last runs.
+func (r *ReconcilePerconaXtraDBClusterExport) schedule(ctx context.Context, cr *api.PerconaXtraDBClusterExport, cluster *api.PerconaXtraDBCluster) (api.ExportState, string, error) {
+	storage, err := exportStorage(cr, cluster)
+	if err != nil {
+		return api.ExportStateFailed, err.Error(), nil
+	}
+
+	desired := exportCronJob(cr, cluster, storage)
+	cronJob := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: desired.Name, Namespace: desired.Namespace}}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.client, cronJob, func() error {
+		cronJob.Labels = desired.Labels
+		cronJob.Spec = desired.Spec
+		return controllerutil.SetControllerReference(cr, cronJob, r.scheme)
+	})
+	if err != nil {
+		return "", "", errors.Wrap(err, "create or update cron job")
+	}
+
+	bucket, path := exportDestination(cr, cluster, storage)
+	cr.Status.Job = cronJob.Name
+	cr.Status.Destination = destinationURL(storage, bucket, path)
+	cr.Status.LastScheduleTime = cronJob.Status.LastScheduleTime
+	cr.Status.LastSuccessfulTime = cronJob.Status.LastSuccessfulTime
+
+	return api.ExportStateScheduled, fmt.Sprintf("data is exported on schedule %s", cr.Spec.Schedule), nil
+}
+
+// deleteCronJob deletes the cron job left after the schedule is removed.
+func (r *ReconcilePerconaXtraDBClusterExport) deleteCronJob(ctx context.Context, cr *api.PerconaXtraDBClusterExport) error {
+	cronJob := new(batchv1.CronJob)
+	err := r.client.Get(ctx, types.NamespacedName{Name: jobName(cr), Namespace: cr.Namespace}, cronJob)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrap(err, "get cron job")
+	}
+
+	if !metav1.IsControlledBy(cronJob, cr) {
+		return nil
+	}
+
+	err = r.client.Delete(ctx, cronJob, client.PropagationPolicy(metav1.DeletePropagationBackground))
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "delete cron job")
+	}
+	return nil
+}
+
+func (r *ReconcilePerconaXtraDBClusterExport) setStatus(ctx context.Context, cr *api.PerconaXtraDBClusterExport, state api.ExportState, msg string) error {
+	if state != cr.Status.State {
+		eventType := corev1.EventTypeNormal
+		if state == api.ExportStateFailed {
+			eventType = corev1.EventTypeWarning
+		}
+		r.recorder.Eventf(cr, eventType, naming.EventExportStateChanged, "Export state changed to %s: %s", state, msg)
+	}
+
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	status := cr.Status
+	err := k8s.PatchStatus(ctx, r.client, cr, func(c *api.PerconaXtraDBClusterExport) {
+		if c.Spec.Schedule == "" && (state == api.ExportStateSucceeded || state == api.ExportStateFailed) && c.Status.CompletedAt == nil {
+			c.Status.CompletedAt = &now
+		}
+
+		c.Status.Job = status.Job
+		c.Status.Destination = status.Destination
+		c.Status.LastScheduleTime = status.LastScheduleTime
+		c.Status.LastSuccessfulTime = status.LastSuccessfulTime
+		c.Status.StartedAt = status.StartedAt
+		c.Status.State = state
+		c.Status.Message = msg
+		c.Status.ObservedGeneration = c.Generation
+	})
+	if err != nil {
+		return errors.Wrap(err, "update status")
+	}
+
+	return nil
+}
@@ -0,0 +1,327 @@
+package pxcexport
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+const (
+	dumpContainer   = "dump"
+	uploadContainer = "upload"
+	exportVolume    = "export"
+	exportDir       = "/export"
+)
+
+// exportStorage returns the storage of the cluster backup section the export is uploaded to.
+func exportStorage(cr *api.PerconaXtraDBClusterExport, cluster *api.PerconaXtraDBCluster) (*api.BackupStorageSpec, error) {
+	if cluster.Spec.Backup == nil {
+		return nil, errors.Errorf("backup section is not set in cluster %s", cluster.Name)
+	}
+	storage, ok := cluster.Spec.Backup.Storages[cr.Spec.StorageName]
+	if !ok || storage == nil {
+		return nil, errors.Errorf("storage %s is not found in cluster %s", cr.Spec.StorageName, cluster.Name)
+	}
+
+	switch storage.Type {
+	case api.BackupStorageS3:
+		if storage.S3 == nil {
+			return nil, errors.Errorf("s3 section of storage %s is empty", cr.Spec.StorageName)
+		}
+	case api.BackupStorageAzure:
+		if storage.Azure == nil {
+			return nil, errors.Errorf("azure section of storage %s is empty", cr.Spec.StorageName)
+		}
+	default:
+		return nil, errors.Errorf("storage %s of type %s is not supported, exports are uploaded to s3 or azure", cr.Spec.StorageName, storage.Type)
+	}
+
+	return storage, nil
+}
+
+// exportDestination returns the bucket and the path the export is uploaded to.
+func exportDestination(cr *api.PerconaXtraDBClusterExport, cluster *api.PerconaXtraDBCluster, storage *api.BackupStorageSpec) (string, string) {
+	var bucket, prefix string
+	if storage.Type == api.BackupStorageAzure {
+		bucket, prefix = storage.Azure.ContainerAndPrefix()
+	} else {
+		bucket, prefix = storage.S3.BucketAndPrefix()
+	}
+
+	return bucket, prefix + cluster.Name + "-export-" + cr.Name
+}
+
+// destinationURL is the destination shown in the status.
+func destinationURL(storage *api.BackupStorageSpec, bucket, path string) string {
+	if storage.Type == api.BackupStorageAzure {
+		return api.AzureBlobStoragePrefix + bucket + "/" + path
+	}
+	return api.AwsBlobStoragePrefix + bucket + "/" + path
+}
+
+func exportJob(cr *api.PerconaXtraDBClusterExport, cluster *api.PerconaXtraDBCluster, storage *api.BackupStorageSpec) *batchv1.Job {
+	ls := naming.LabelsExport(cluster, cr.Name)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName(cr),
+			Namespace: cr.Namespace,
+			Labels:    ls,
+		},
+		Spec: exportJobSpec(cr, cluster, storage, ls),
+	}
+}
+
+func exportCronJob(cr *api.PerconaXtraDBClusterExport, cluster *api.PerconaXtraDBCluster, storage *api.BackupStorageSpec) *batchv1.CronJob {
+	ls := naming.LabelsExport(cluster, cr.Name)
+	return &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      jobName(cr),
+			Namespace: cr.Namespace,
+			Labels:    ls,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:          cr.Spec.Schedule,
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: ls},
+				Spec:       exportJobSpec(cr, cluster, storage, ls),
+			},
+		},
+	}
+}
+
+// exportJobSpec dumps the data to an empty dir by the tool in the init container.
+// The dump is streamed to the storage by xbcloud of the backup image.
+func exportJobSpec(cr *api.PerconaXtraDBClusterExport, cluster *api.PerconaXtraDBCluster, storage *api.BackupStorageSpec, ls map[string]string) batchv1.JobSpec {
+	image := cr.Spec.Image
+	if image == "" {
+		image = cluster.Spec.PXC.Image
+	}
+
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      exportVolume,
+			MountPath: exportDir,
+		},
+	}
+
+	bucket, path := exportDestination(cr, cluster, storage)
+
+	backoffLimit := int32(0)
+	spec := batchv1.JobSpec{
+		BackoffLimit:          &backoffLimit,
+		ActiveDeadlineSeconds: cr.Spec.ActiveDeadlineSeconds,
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels:      ls,
+				Annotations: storage.Annotations,
+			},
+			Spec: corev1.PodSpec{
+				RestartPolicy:      corev1.RestartPolicyNever,
+				ImagePullSecrets:   cluster.Spec.Backup.ImagePullSecrets,
+				ServiceAccountName: cluster.Spec.Backup.ServiceAccountName,
+				SecurityContext:    storage.PodSecurityContext,
+				NodeSelector:       storage.NodeSelector,
+				Affinity:           storage.Affinity,
+				Tolerations:        storage.Tolerations,
+				SchedulerName:      storage.SchedulerName,
+				PriorityClassName:  storage.PriorityClassName,
+				RuntimeClassName:   storage.RuntimeClassName,
+				InitContainers: []corev1.Container{
+					{
+						Name:            dumpContainer,
+						Image:           image,
+						ImagePullPolicy: cluster.Spec.PXC.ImagePullPolicy,
+						SecurityContext: storage.ContainerSecurityContext,
+						Command:         []string{"bash", "-c"},
+						Args:            []string{dumpCommand(cr)},
+						Resources:       storage.Resources,
+						VolumeMounts:    mounts,
+						Env: []corev1.EnvVar{
+							{
+								Name:  "PXC_HOST",
+								Value: cluster.Name + "-pxc-0." + cluster.Name + "-pxc." + cluster.Namespace,
+							},
+							{
+								Name: "EXPORT_PASS",
+								ValueFrom: &corev1.EnvVarSource{
+									SecretKeyRef: app.SecretKeySelector(cluster.Spec.SecretsName, users.Xtrabackup),
+								},
+							},
+						},
+					},
+				},
+				Containers: []corev1.Container{
+					{
+						Name:            uploadContainer,
+						Image:           cluster.Spec.Backup.Image,
+						ImagePullPolicy: cluster.Spec.Backup.ImagePullPolicy,
+						SecurityContext: storage.ContainerSecurityContext,
+						Command:         []string{"bash", "-c"},
+						Args:            []string{uploadCommand(cr, storage, bucket, path)},
+						Resources:       storage.Resources,
+						VolumeMounts:    mounts,
+						Env:             storageEnv(storage),
+					},
+				},
+				Volumes: []corev1.Volume{
+					{
+						Name: exportVolume,
+						VolumeSource: corev1.VolumeSource{
+							EmptyDir: &corev1.EmptyDirVolumeSource{},
+						},
+					},
+				},
+			},
+		},
+	}
+	cluster.ApplyImageOverrides(&spec.Template.Spec)
+
+	return spec
+}
+
+// dumpCommand runs the tool against the first PXC node. The names are validated by CheckNSetDefaults.
+func dumpCommand(cr *api.PerconaXtraDBClusterExport) string {
+	spec := cr.Spec
+	conn := `--host="$PXC_HOST" --port=3306 --user=` + users.Xtrabackup + ` --password="$EXPORT_PASS"`
+
+	var extra string
+	for _, arg := range spec.Args {
+		extra += " " + shellQuote(arg)
+	}
+
+	if spec.Tool == api.ExportToolMysqldump {
+		base := "mysqldump " + conn + " --single-transaction --routines --triggers --events" + extra
+
+		var cmds []string
+		switch {
+		case len(spec.Tables) > 0:
+			var dbs []string
+			tables := make(map[string][]string)
+			for _, t := range spec.Tables {
+				db, table, _ := strings.Cut(t, ".")
+				if _, ok := tables[db]; !ok {
+					dbs = append(dbs, db)
+				}
+				tables[db] = append(tables[db], table)
+			}
+			for _, db := range dbs {
+				cmds = append(cmds, base+" "+db+" "+strings.Join(tables[db], " ")+" > "+exportDir+"/"+db+".sql")
+			}
+		case len(spec.Databases) > 0:
+			for _, db := range spec.Databases {
+				cmds = append(cmds, base+" --databases "+db+" > "+exportDir+"/"+db+".sql")
+			}
+		default:
+			cmds = append(cmds, base+" --all-databases > "+exportDir+"/all-databases.sql")
+		}
+
+		return "set -e; " + strings.Join(cmds, "; ")
+	}
+
+	cmd := "mydumper " + conn + " --outputdir=" + exportDir + " --trx-consistency-only --compress"
+	switch {
+	case len(spec.Tables) > 0:
+		cmd += " --tables-list=" + strings.Join(spec.Tables, ",")
+	case len(spec.Databases) > 0:
+		cmd += " --database=" + strings.Join(spec.Databases, ",")
+	}
+
+	return cmd + extra
+}
+
+// uploadCommand streams the dump files to the storage. Scheduled exports
+// get the time of the export appended to the path.
+func uploadCommand(cr *api.PerconaXtraDBClusterExport, storage *api.BackupStorageSpec, bucket, path string) string {
+	dest := shellQuote(path)
+	if cr.Spec.Schedule != "" {
+		dest += `"-$(date -u +%Y-%m-%d-%H%M%S)"`
+	}
+
+	var args []string
+	switch storage.Type {
+	case api.BackupStorageAzure:
+		args = []string{
+			"--storage=azure",
+			"--azure-container-name=" + shellQuote(bucket),
+			`--azure-storage-account="$AZURE_STORAGE_ACCOUNT"`,
+			`--azure-access-key="$AZURE_ACCESS_KEY"`,
+		}
+		if storage.Azure.Endpoint != "" {
+			args = append(args, "--azure-endpoint="+shellQuote(storage.Azure.Endpoint))
+		}
+	default:
+		args = []string{
+			"--storage=s3",
+			"--s3-bucket=" + shellQuote(bucket),
+		}
+		if storage.S3.CredentialsSecret != "" {
+			args = append(args, `--s3-access-key="$ACCESS_KEY_ID"`, `--s3-secret-key="$SECRET_ACCESS_KEY"`)
+		}
+		if storage.S3.Region != "" {
+			args = append(args, "--s3-region="+shellQuote(storage.S3.Region))
+		}
+		if storage.S3.EndpointURL != "" {
+			args = append(args, "--s3-endpoint="+shellQuote(storage.S3.EndpointURL))
+		}
+	}
+	if storage.VerifyTLS != nil && !*storage.VerifyTLS {
+		args = append(args, "--insecure")
+	}
+
+	return "set -o pipefail; cd " + exportDir + " && xbstream -c * | xbcloud put --parallel=10 " + strings.Join(args, " ") + " " + dest
+}
+
+func storageEnv(storage *api.BackupStorageSpec) []corev1.EnvVar {
+	switch storage.Type {
+	case api.BackupStorageAzure:
+		return []corev1.EnvVar{
+			{
+				Name: "AZURE_STORAGE_ACCOUNT",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: app.SecretKeySelector(storage.Azure.CredentialsSecret, "AZURE_STORAGE_ACCOUNT_NAME"),
+				},
+			},
+			{
+				Name: "AZURE_ACCESS_KEY",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: app.SecretKeySelector(storage.Azure.CredentialsSecret, "AZURE_STORAGE_ACCOUNT_KEY"),
+				},
+			},
+		}
+	default:
+		if storage.S3.CredentialsSecret == "" {
+			return nil
+		}
+		return []corev1.EnvVar{
+			{
+				Name: "ACCESS_KEY_ID",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: app.SecretKeySelector(storage.S3.CredentialsSecret, "AWS_ACCESS_KEY_ID"),
+				},
+			},
+			{
+				Name: "SECRET_ACCESS_KEY",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: app.SecretKeySelector(storage.S3.CredentialsSecret, "AWS_SECRET_ACCESS_KEY"),
+				},
+			},
+		}
+	}
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func jobName(cr *api.PerconaXtraDBClusterExport) string {
+	return "export-" + cr.Name
+}
@@ -0,0 +1,139 @@
+package pxcexport
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestDumpCommand(t *testing.T) {
+	tests := map[string]struct {
+		spec     api.PerconaXtraDBClusterExportSpec
+		contains []string
+	}{
+		"mydumper databases": {
+			spec: api.PerconaXtraDBClusterExportSpec{Databases: []string{"app", "crm"}, Image: "mydumper"},
+			contains: []string{
+				"mydumper ",
+				"--outputdir=/export",
+				"--database=app,crm",
+			},
+		},
+		"mydumper tables": {
+			spec: api.PerconaXtraDBClusterExportSpec{Tables: []string{"app.orders"}, Image: "mydumper", Args: []string{"--threads=4"}},
+			contains: []string{
+				"--tables-list=app.orders",
+				"'--threads=4'",
+			},
+		},
+		"mysqldump tables": {
+			spec: api.PerconaXtraDBClusterExportSpec{Tool: api.ExportToolMysqldump, Tables: []string{"app.orders", "crm.leads", "app.customers"}},
+			contains: []string{
+				"--single-transaction",
+				" app orders customers > /export/app.sql",
+				" crm leads > /export/crm.sql",
+			},
+		},
+		"mysqldump all": {
+			spec: api.PerconaXtraDBClusterExportSpec{Tool: api.ExportToolMysqldump},
+			contains: []string{
+				"--all-databases > /export/all-databases.sql",
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := &api.PerconaXtraDBClusterExport{Spec: tt.spec}
+			cr.Spec.PXCCluster = "cluster1"
+			cr.Spec.StorageName = "s3"
+			if err := cr.CheckNSetDefaults(); err != nil {
+				t.Fatal(err)
+			}
+
+			cmd := dumpCommand(cr)
+			for _, s := range tt.contains {
+				if !strings.Contains(cmd, s) {
+					t.Errorf("command %q doesn't contain %q", cmd, s)
+				}
+			}
+		})
+	}
+}
+
+func TestExportJob(t *testing.T) {
+	verifyTLS := false
+	cluster := &api.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "ns"},
+		Spec: api.PerconaXtraDBClusterSpec{
+			SecretsName: "cluster1-secrets",
+			PXC:         &api.PXCSpec{PodSpec: &api.PodSpec{Image: "pxc-image"}},
+			Backup: &api.PXCScheduledBackup{
+				Image: "backup-image",
+				Storages: map[string]*api.BackupStorageSpec{
+					"s3": {
+						Type:      api.BackupStorageS3,
+						VerifyTLS: &verifyTLS,
+						S3: &api.BackupStorageS3Spec{
+							Bucket:            "bucket/exports",
+							CredentialsSecret: "s3-secret",
+							Region:            "us-west-2",
+						},
+					},
+					"fs": {Type: api.BackupStorageFilesystem},
+				},
+			},
+		},
+	}
+	cr := &api.PerconaXtraDBClusterExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "ns"},
+		Spec: api.PerconaXtraDBClusterExportSpec{
+			PXCCluster:  "cluster1",
+			StorageName: "fs",
+			Tool:        api.ExportToolMysqldump,
+			Schedule:    "0 3 * * *",
+		},
+	}
+	if err := cr.CheckNSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := exportStorage(cr, cluster); err == nil {
+		t.Error("expected error for filesystem storage")
+	}
+
+	cr.Spec.StorageName = "s3"
+	storage, err := exportStorage(cr, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket, path := exportDestination(cr, cluster, storage)
+	if dest := destinationURL(storage, bucket, path); dest != "s3://bucket/exports/cluster1-export-orders" {
+		t.Errorf("unexpected destination %s", dest)
+	}
+
+	spec := exportCronJob(cr, cluster, storage).Spec.JobTemplate.Spec.Template.Spec
+	if img := spec.InitContainers[0].Image; img != "pxc-image" {
+		t.Errorf("unexpected dump image %s", img)
+	}
+	upload := spec.Containers[0]
+	if upload.Image != "backup-image" {
+		t.Errorf("unexpected upload image %s", upload.Image)
+	}
+	for _, s := range []string{
+		"xbstream -c * | xbcloud put",
+		"--s3-bucket='bucket'",
+		"--s3-region='us-west-2'",
+		`--s3-access-key="$ACCESS_KEY_ID"`,
+		"--insecure",
+		`'exports/cluster1-export-orders'"-$(date -u +%Y-%m-%d-%H%M%S)"`,
+	} {
+		if !strings.Contains(upload.Args[0], s) {
+			t.Errorf("upload command %q doesn't contain %q", upload.Args[0], s)
+		}
+	}
+}
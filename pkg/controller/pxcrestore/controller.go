@@ -3,18 +3,20 @@ package pxcrestore
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -22,10 +24,12 @@ import (
 
 	"github.com/percona/percona-xtradb-cluster-operator/clientcmd"
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/audit"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
-	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/binlogcollector"
-	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/tracing"
 	"github.com/percona/percona-xtradb-cluster-operator/version"
 )
 
@@ -52,20 +56,42 @@ func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
 	}
 
 	return &ReconcilePerconaXtraDBClusterRestore{
-		client:               mgr.GetClient(),
+		client:               tracing.WrapClient(mgr.GetClient()),
 		clientcmd:            cli,
 		scheme:               mgr.GetScheme(),
 		serverVersion:        sv,
+		recorder:             mgr.GetEventRecorderFor("pxcrestore-controller"),
 		newStorageClientFunc: storage.NewClient,
 	}, nil
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
 func add(mgr manager.Manager, r reconcile.Reconciler) error {
-	return builder.ControllerManagedBy(mgr).
+	maxConcurrentReconciles, err := k8s.GetMaxConcurrentReconciles(k8s.MaxConcurrentReconcilesRestoreEnvVar)
+	if err != nil {
+		return err
+	}
+	rateLimiter, err := k8s.NewRateLimiter()
+	if err != nil {
+		return err
+	}
+
+	b := builder.ControllerManagedBy(mgr).
 		Named("pxcrestore-controller").
-		Watches(&api.PerconaXtraDBClusterRestore{}, &handler.EnqueueRequestForObject{}).
-		Complete(r)
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: maxConcurrentReconciles,
+			RateLimiter:             rateLimiter,
+		}).
+		Watches(&api.PerconaXtraDBClusterRestore{}, &handler.EnqueueRequestForObject{},
+			builder.WithPredicates(
+				k8s.NamespaceSelectorPredicate(mgr.GetClient()),
+				k8s.ClusterSelectorPredicate(mgr.GetClient(), func(obj client.Object) types.NamespacedName {
+					return types.NamespacedName{Name: obj.(*api.PerconaXtraDBClusterRestore).Spec.PXCCluster, Namespace: obj.GetNamespace()}
+				}),
+			))
+
+	return k8s.WatchNamespaces(b, mgr.GetClient(), func() client.ObjectList { return new(api.PerconaXtraDBClusterRestoreList) }).
+		Complete(k8s.DetachReconciler(metrics.InstrumentReconciler("pxcrestore-controller", tracing.InstrumentReconciler("pxcrestore-controller", r))))
 }
 
 var _ reconcile.Reconciler = &ReconcilePerconaXtraDBClusterRestore{}
@@ -79,6 +105,7 @@ type ReconcilePerconaXtraDBClusterRestore struct {
 	scheme    *runtime.Scheme
 
 	serverVersion *version.ServerVersion
+	recorder      record.EventRecorder
 
 	newStorageClientFunc storage.NewClientFunc
 }
@@ -88,13 +115,11 @@ type ReconcilePerconaXtraDBClusterRestore struct {
 // Note:
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
-func (r *ReconcilePerconaXtraDBClusterRestore) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+func (r *ReconcilePerconaXtraDBClusterRestore) Reconcile(ctx context.Context, request reconcile.Request) (rr reconcile.Result, err error) {
 	log := logf.FromContext(ctx)
 
-	rr := reconcile.Result{}
-
 	cr := &api.PerconaXtraDBClusterRestore{}
-	err := r.client.Get(context.TODO(), request.NamespacedName, cr)
+	err = r.client.Get(context.TODO(), request.NamespacedName, cr)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
@@ -103,202 +128,200 @@ func (r *ReconcilePerconaXtraDBClusterRestore) Reconcile(ctx context.Context, re
 		// Error reading the object - requeue the request.
 		return rr, err
 	}
-	if cr.Status.State != api.RestoreNew {
+	if cr.Status.State == api.RestoreSucceeded || cr.Status.State == api.RestoreFailed {
 		return rr, nil
 	}
 
-	log.Info("backup restore request")
-
-	err = r.setStatus(cr, api.RestoreStarting, "")
-	if err != nil {
-		return rr, errors.Wrap(err, "set status")
-	}
-	rJobsList := &api.PerconaXtraDBClusterRestoreList{}
-	err = r.client.List(
-		context.TODO(),
-		rJobsList,
-		&client.ListOptions{
-			Namespace: cr.Namespace,
-		},
-	)
-	if err != nil {
-		return rr, errors.Wrap(err, "get restore jobs list")
-	}
-
-	returnMsg := fmt.Sprintf(backupRestoredMsg, cr.Name, cr.Spec.PXCCluster, cr.Name)
-
+	status := k8s.NewStatusWriter(r.client, cr)
 	defer func() {
-		status := api.BcpRestoreStates(api.RestoreSucceeded)
-		if err != nil {
-			status = api.RestoreFailed
-			returnMsg = err.Error()
-		}
-		err := r.setStatus(cr, status, returnMsg)
-		if err != nil {
-			return
+		if ferr := status.Flush(ctx); ferr != nil && err == nil {
+			err = errors.Wrap(ferr, "set status")
 		}
 	}()
 
-	for _, j := range rJobsList.Items {
-		if j.Spec.PXCCluster == cr.Spec.PXCCluster &&
-			j.Name != cr.Name && j.Status.State != api.RestoreFailed &&
-			j.Status.State != api.RestoreSucceeded {
-			err = errors.Errorf("unable to continue, concurent restore job %s running now.", j.Name)
-			return rr, err
-		}
+	if cr.Status.State == api.RestoreNew {
+		log.Info("backup restore request")
+		r.setStatus(status, api.RestoreStarting, "")
 	}
 
-	err = cr.CheckNsetDefaults()
-	if err != nil {
-		return rr, err
-	}
+	// the rehearsal swaps the cluster of the spec in memory
+	clusterName := cr.Spec.PXCCluster
 
-	cluster := new(api.PerconaXtraDBCluster)
-	err = r.client.Get(context.TODO(), types.NamespacedName{Name: cr.Spec.PXCCluster, Namespace: cr.Namespace}, cluster)
-	if err != nil {
-		err = errors.Wrapf(err, "get cluster %s", cr.Spec.PXCCluster)
-		return rr, err
-	}
-	clusterOrig := cluster.DeepCopy()
+	// the log lines are set by the job phases directly on the object
+	prevLogLines := slices.Clone(cr.Status.LatestLogLines)
+	// the retrieval of archived objects and the progress are reported by the phases directly on the object
+	prevRetrieval := cr.Status.Retrieval.DeepCopy()
+	prevProgress := cr.Status.Progress.DeepCopy()
 
-	err = cluster.CheckNSetDefaults(r.serverVersion, log)
-	if err != nil {
-		return reconcile.Result{}, fmt.Errorf("wrong PXC options: %v", err)
-	}
+	state, err := r.reconcileState(ctx, cr)
 
-	bcp, err := r.getBackup(ctx, cr)
-	if err != nil {
-		return rr, errors.Wrap(err, "get backup")
+	if logLines := cr.Status.LatestLogLines; !slices.Equal(prevLogLines, logLines) {
+		status.Update(func(cr *api.PerconaXtraDBClusterRestore) {
+			cr.Status.LatestLogLines = logLines
+		})
 	}
-
-	if cr.Spec.PITR != nil {
-		err = backup.CheckPITRErrors(ctx, r.client, r.clientcmd, cluster)
-		if err != nil {
-			return reconcile.Result{}, err
-		}
-
-		annotations := cr.GetAnnotations()
-		_, unsafePITR := annotations[api.AnnotationUnsafePITR]
-		cond := meta.FindStatusCondition(bcp.Status.Conditions, api.BackupConditionPITRReady)
-		if cond != nil && cond.Status == metav1.ConditionFalse && !unsafePITR {
-			msg := fmt.Sprintf("Backup doesn't guarantee consistent recovery with PITR. Annotate PerconaXtraDBClusterRestore with %s to force it.", api.AnnotationUnsafePITR)
-			err = errors.New(msg)
-			return reconcile.Result{}, nil
-		}
+	if retrieval := cr.Status.Retrieval; !equality.Semantic.DeepEqual(prevRetrieval, retrieval) {
+		status.Update(func(cr *api.PerconaXtraDBClusterRestore) {
+			cr.Status.Retrieval = retrieval
+		})
 	}
-
-	err = r.validate(ctx, cr, bcp, cluster)
-	if err != nil {
-		err = errors.Wrap(err, "failed to validate restore job")
-		return rr, err
+	if progress := cr.Status.Progress; !equality.Semantic.DeepEqual(prevProgress, progress) {
+		status.Update(func(cr *api.PerconaXtraDBClusterRestore) {
+			cr.Status.Progress = progress
+		})
 	}
 
-	log.Info("stopping cluster", "cluster", cr.Spec.PXCCluster)
-	err = r.setStatus(cr, api.RestoreStopCluster, "")
 	if err != nil {
-		err = errors.Wrap(err, "set status")
-		return rr, err
-	}
-	err = k8s.PauseClusterWithWait(ctx, r.client, cluster, true)
-	if err != nil {
-		err = errors.Wrapf(err, "stop cluster %s", cluster.Name)
-		return rr, err
+		var ferr *failedError
+		if !errors.As(err, &ferr) {
+			return rr, err
+		}
+		msg := err.Error()
+		r.setStatus(status, api.RestoreFailed, msg)
+		status.OnFlush(func() { r.recordOperation(ctx, cr, clusterName, audit.OperationFailed, msg) })
+		return rr, nil
 	}
 
-	log.Info("starting restore", "cluster", cr.Spec.PXCCluster, "backup", cr.Spec.BackupName)
-	err = r.setStatus(cr, api.RestoreRestore, "")
-	if err != nil {
-		err = errors.Wrap(err, "set status")
-		return rr, err
+	if state == api.RestoreSucceeded {
+		returnMsg := fmt.Sprintf(backupRestoredMsg, cr.Name, cr.Spec.PXCCluster, cr.Name)
+		r.setStatus(status, api.RestoreSucceeded, returnMsg)
+		status.OnFlush(func() { log.Info(returnMsg) })
+		status.OnFlush(func() { r.recordOperation(ctx, cr, clusterName, audit.OperationSucceeded, returnMsg) })
+		return rr, nil
 	}
 
-	err = r.restore(ctx, cr, bcp, cluster)
-	if err != nil {
-		err = errors.Wrap(err, "run restore")
-		return rr, err
+	if state != cr.Status.State {
+		r.setStatus(status, state, "")
+	} else if cr.Status.StateStartedAt == nil {
+		// the restore was started by an operator version which didn't track the state time
+		startedAt := metav1.Now()
+		status.Update(func(cr *api.PerconaXtraDBClusterRestore) {
+			cr.Status.StateStartedAt = &startedAt
+		})
 	}
 
-	if cluster.Spec.Backup.PITR.Enabled {
-		if err := binlogcollector.InvalidateCache(ctx, r.client, cluster); err != nil {
-			log.Error(err, "failed to invalidate binlog collector cache")
-		}
+	if state == api.RestoreAwaitingRetrieval {
+		return reconcile.Result{RequeueAfter: retrievalPollInterval}, nil
 	}
+	return reconcile.Result{RequeueAfter: statePollInterval}, nil
+}
 
-	log.Info("starting cluster", "cluster", cr.Spec.PXCCluster)
-	err = r.setStatus(cr, api.RestoreStartCluster, "")
-	if err != nil {
-		err = errors.Wrap(err, "set status")
-		return rr, err
+// reconcileState runs a single step of the current restore state and returns the state to move to.
+// Errors wrapped by failed() fail the restore, other errors are retried.
+func (r *ReconcilePerconaXtraDBClusterRestore) reconcileState(ctx context.Context, cr *api.PerconaXtraDBClusterRestore) (api.BcpRestoreStates, error) {
+	log := logf.FromContext(ctx)
+
+	if err := cr.CheckNsetDefaults(); err != nil {
+		return "", failed(err)
 	}
 
-	if cr.Spec.PITR != nil {
-		oldSize := cluster.Spec.PXC.Size
-		oldUnsafePXCSize := cluster.Spec.Unsafe.PXCSize
-		oldUnsafeProxySize := cluster.Spec.Unsafe.ProxySize
+	// the rehearsal swaps the cluster of the spec in memory
+	clusterName := cr.Spec.PXCCluster
 
-		var oldProxySQLSize int32
-		if cluster.Spec.ProxySQL != nil {
-			oldProxySQLSize = cluster.Spec.ProxySQL.Size
+	if cr.Status.State == api.RestoreStarting {
+		rJobsList := &api.PerconaXtraDBClusterRestoreList{}
+		err := k8s.ListByIndex(ctx, r.client, rJobsList, cr.Namespace, k8s.IndexPXCCluster, cr.Spec.PXCCluster)
+		if err != nil {
+			return "", errors.Wrap(err, "get restore jobs list")
 		}
-		var oldHAProxySize int32
-		if cluster.Spec.HAProxy != nil {
-			oldHAProxySize = cluster.Spec.HAProxy.Size
+		for _, j := range rJobsList.Items {
+			// the defaults set the name of the rehearsal cluster
+			_ = j.CheckNsetDefaults()
+			if j.TargetCluster() == cr.TargetCluster() &&
+				j.Name != cr.Name && j.Status.State != api.RestoreFailed &&
+				j.Status.State != api.RestoreSucceeded {
+				return "", failed(errors.Errorf("unable to continue, concurent restore job %s running now.", j.Name))
+			}
 		}
+	}
 
-		cluster.Spec.Unsafe.PXCSize = true
-		cluster.Spec.Unsafe.ProxySize = true
-		cluster.Spec.PXC.Size = 1
-
-		if cluster.Spec.ProxySQL != nil {
-			cluster.Spec.ProxySQL.Size = 0
-		}
-		if cluster.Spec.HAProxy != nil {
-			cluster.Spec.HAProxy.Size = 0
+	cluster := new(api.PerconaXtraDBCluster)
+	err := r.client.Get(ctx, types.NamespacedName{Name: cr.Spec.PXCCluster, Namespace: cr.Namespace}, cluster)
+	if err != nil {
+		err = errors.Wrapf(err, "get cluster %s", cr.Spec.PXCCluster)
+		if k8serrors.IsNotFound(err) {
+			return "", failed(err)
 		}
+		return "", err
+	}
 
-		if err := k8s.UnpauseClusterWithWait(ctx, r.client, cluster); err != nil {
-			return rr, errors.Wrap(err, "restart cluster for pitr")
-		}
+	if err := cluster.CheckNSetDefaults(r.serverVersion, log); err != nil {
+		return "", failed(errors.Errorf("wrong PXC options: %v", err))
+	}
 
-		log.Info("point-in-time recovering", "cluster", cr.Spec.PXCCluster)
-		err = r.setStatus(cr, api.RestorePITR, "")
+	if cr.Spec.Rehearsal.IsEnabled() {
+		cluster, err = r.rehearsalCluster(ctx, cr, cluster)
 		if err != nil {
-			return rr, errors.Wrap(err, "set status")
+			return "", err
 		}
+		// the restore phases run on the rehearsal cluster
+		cr.Spec.PXCCluster = cluster.Name
+	}
 
-		err = r.pitr(ctx, cr, bcp, cluster)
-		if err != nil {
-			return rr, errors.Wrap(err, "run pitr")
-		}
+	bcp, err := r.getBackup(ctx, cr)
+	if err != nil {
+		return "", failed(errors.Wrap(err, "get backup"))
+	}
 
-		cluster.Spec.PXC.Size = oldSize
-		cluster.Spec.Unsafe.PXCSize = oldUnsafePXCSize
-		cluster.Spec.Unsafe.ProxySize = oldUnsafeProxySize
+	var state api.BcpRestoreStates
+	phase := func(name string, f func(ctx context.Context) (api.BcpRestoreStates, error)) error {
+		return tracing.Phase(ctx, name, func(ctx context.Context) error {
+			var err error
+			state, err = f(ctx)
+			return err
+		})
+	}
 
-		if cluster.Spec.ProxySQL != nil {
-			cluster.Spec.ProxySQL.Size = oldProxySQLSize
-		}
-		if cluster.Spec.HAProxy != nil {
-			cluster.Spec.HAProxy.Size = oldHAProxySize
-		}
+	switch cr.Status.State {
+	case api.RestoreStarting:
+		err = phase("validate", func(ctx context.Context) (api.BcpRestoreStates, error) {
+			return r.validateRestore(ctx, cr, bcp, cluster)
+		})
+	case api.RestoreAwaitingRetrieval:
+		err = phase("retrieval", func(ctx context.Context) (api.BcpRestoreStates, error) {
+			return r.awaitRetrieval(ctx, cr, bcp, cluster)
+		})
+	case api.RestoreStopCluster:
+		err = phase("stop cluster", func(ctx context.Context) (api.BcpRestoreStates, error) {
+			return r.stopCluster(ctx, cr, cluster)
+		})
+	case api.RestoreRestore:
+		err = phase("restore", func(ctx context.Context) (api.BcpRestoreStates, error) {
+			return r.restore(ctx, cr, bcp, cluster)
+		})
+	case api.RestorePITR:
+		err = phase("pitr", func(ctx context.Context) (api.BcpRestoreStates, error) {
+			return r.pitr(ctx, cr, bcp, cluster)
+		})
+	case api.RestoreMaskData:
+		err = phase("masking", func(ctx context.Context) (api.BcpRestoreStates, error) {
+			return r.mask(ctx, cr, cluster)
+		})
+	case api.RestoreStartCluster:
+		err = phase("start cluster", func(ctx context.Context) (api.BcpRestoreStates, error) {
+			return r.startCluster(ctx, cr, bcp, cluster)
+		})
+	default:
+		err = failed(errors.Errorf("unknown restore state %q", cr.Status.State))
+	}
 
-		log.Info("starting cluster", "cluster", cr.Spec.PXCCluster)
-		err = r.setStatus(cr, api.RestoreStartCluster, "")
-		if err != nil {
-			err = errors.Wrap(err, "set status")
-			return rr, err
+	if err == nil && state == cr.Status.State || err != nil && !errors.As(err, new(*failedError)) {
+		if terr := stateTimeoutExceeded(cr, cluster, err); terr != nil {
+			return "", failed(terr)
 		}
 	}
 
-	err = k8s.UnpauseClusterWithWait(ctx, r.client, clusterOrig)
-	if err != nil {
-		err = errors.Wrap(err, "restart cluster")
-		return rr, err
+	if err == nil {
+		r.updateProgress(ctx, cr, bcp, cluster, clusterName, state)
 	}
 
-	log.Info(returnMsg)
+	if err == nil && state == api.RestoreSucceeded && cr.Spec.Rehearsal.IsEnabled() {
+		if err := r.finishRehearsal(ctx, cr, cluster); err != nil {
+			return "", errors.Wrap(err, "finish rehearsal")
+		}
+	}
 
-	return rr, err
+	return state, err
 }
 
 func (r *ReconcilePerconaXtraDBClusterRestore) getBackup(ctx context.Context, cr *api.PerconaXtraDBClusterRestore) (*api.PerconaXtraDBClusterBackup, error) {
@@ -340,74 +363,77 @@ If everything is fine, you can cleanup the job:
 $ kubectl delete pxc-restore/%s
 `
 
-const waitLimitSec int64 = 300
+// statePollInterval is how often an in-progress restore is checked.
+const statePollInterval = 5 * time.Second
 
-func (r *ReconcilePerconaXtraDBClusterRestore) waitForPodsShutdown(ls map[string]string, namespace string, gracePeriodSec int64) error {
-	for i := int64(0); i < waitLimitSec+gracePeriodSec; i++ {
-		pods := corev1.PodList{}
+// failedError marks errors after which the restore can't be continued.
+type failedError struct {
+	err error
+}
 
-		err := r.client.List(
-			context.TODO(),
-			&pods,
-			&client.ListOptions{
-				Namespace:     namespace,
-				LabelSelector: labels.SelectorFromSet(ls),
-			},
-		)
-		if err != nil {
-			return errors.Wrap(err, "get pods list")
-		}
+func (e *failedError) Error() string { return e.err.Error() }
 
-		if len(pods.Items) == 0 {
-			return nil
-		}
+func (e *failedError) Unwrap() error { return e.err }
 
-		time.Sleep(time.Second * 1)
+func failed(err error) error {
+	return &failedError{err: err}
+}
+
+// setStatus queues the state change to the status writer. Events are sent once the status is written.
+func (r *ReconcilePerconaXtraDBClusterRestore) setStatus(w *k8s.StatusWriter[*api.PerconaXtraDBClusterRestore], state api.BcpRestoreStates, comments string) {
+	var completedAt *metav1.Time
+	if state == api.RestoreSucceeded {
+		tm := metav1.NewTime(time.Now())
+		completedAt = &tm
 	}
 
-	return errors.Errorf("exceeded wait limit")
-}
+	// the original cluster sizes and the rehearsal result are set by the reconcile phases directly on the object
+	originalCluster := w.Object().Status.OriginalCluster.DeepCopy()
+	rehearsal := w.Object().Status.Rehearsal.DeepCopy()
 
-func (r *ReconcilePerconaXtraDBClusterRestore) waitForPVCShutdown(ls map[string]string, namespace string) error {
-	for i := int64(0); i < waitLimitSec; i++ {
-		pvcs := corev1.PersistentVolumeClaimList{}
+	startedAt := metav1.Now()
 
-		err := r.client.List(
-			context.TODO(),
-			&pvcs,
-			&client.ListOptions{
-				Namespace:     namespace,
-				LabelSelector: labels.SelectorFromSet(ls),
-			},
-		)
-		if err != nil {
-			return errors.Wrap(err, "get pvc list")
+	w.Update(func(cr *api.PerconaXtraDBClusterRestore) {
+		if cr.Status.State != state {
+			cr.Status.StateStartedAt = &startedAt
 		}
-
-		if len(pvcs.Items) == 1 {
-			return nil
+		cr.Status.State = state
+		if completedAt != nil {
+			cr.Status.CompletedAt = completedAt
 		}
+		cr.Status.Comments = comments
+		cr.Status.OriginalCluster = originalCluster
+		cr.Status.Rehearsal = rehearsal
+		cr.Status.SetStateConditions(cr.Generation)
+	})
+	w.OnFlush(func() { r.sendStatusEvents(w.Object(), state, comments) })
+}
 
-		time.Sleep(time.Second * 1)
+// recordOperation adds the finished restore to the operations history of the cluster.
+// Rehearsals don't change the cluster and aren't recorded.
+func (r *ReconcilePerconaXtraDBClusterRestore) recordOperation(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, clusterName string, result audit.OperationResult, msg string) {
+	if cr.Spec.Rehearsal.IsEnabled() {
+		return
 	}
-
-	return errors.Errorf("exceeded wait limit")
+	audit.RecordOperation(ctx, r.client, cr.Namespace, clusterName, audit.OperationEntry{
+		Operation: audit.OperationRestore,
+		Result:    result,
+		Object:    cr.Name,
+		Message:   msg,
+	})
 }
 
-func (r *ReconcilePerconaXtraDBClusterRestore) setStatus(cr *api.PerconaXtraDBClusterRestore, state api.BcpRestoreStates, comments string) error {
-	cr.Status.State = state
+func (r *ReconcilePerconaXtraDBClusterRestore) sendStatusEvents(cr *api.PerconaXtraDBClusterRestore, state api.BcpRestoreStates, comments string) {
 	switch state {
 	case api.RestoreSucceeded:
-		tm := metav1.NewTime(time.Now())
-		cr.Status.CompletedAt = &tm
+		r.recorder.Event(cr, corev1.EventTypeNormal, naming.EventRestoreSucceeded, comments)
+	case api.RestoreFailed:
+		r.recorder.Event(cr, corev1.EventTypeWarning, naming.EventRestoreFailed, comments)
+	default:
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventRestoreStateChanged, "Restore state changed to %s", state)
 	}
 
-	cr.Status.Comments = comments
-
-	err := r.client.Status().Update(context.TODO(), cr)
-	if err != nil {
-		return errors.Wrap(err, "send update")
+	if state == api.RestoreSucceeded || state == api.RestoreFailed {
+		metrics.ObserveRestore(cr)
 	}
-
-	return nil
 }
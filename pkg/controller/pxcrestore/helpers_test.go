@@ -9,10 +9,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake" //nolint
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
 	fakestorage "github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage/fake"
 )
 
@@ -137,6 +139,7 @@ func reconciler(cl client.Client) *ReconcilePerconaXtraDBClusterRestore {
 	return &ReconcilePerconaXtraDBClusterRestore{
 		client:               cl,
 		scheme:               cl.Scheme(),
+		recorder:             new(record.FakeRecorder),
 		newStorageClientFunc: fakestorage.NewFakeClient,
 	}
 }
@@ -146,16 +149,19 @@ func buildFakeClient(objs ...runtime.Object) client.Client {
 	s := scheme.Scheme
 
 	s.AddKnownTypes(api.SchemeGroupVersion, new(api.PerconaXtraDBClusterRestore))
+	s.AddKnownTypes(api.SchemeGroupVersion, new(api.PerconaXtraDBClusterRestoreList))
 	s.AddKnownTypes(api.SchemeGroupVersion, new(api.PerconaXtraDBClusterBackup))
 	s.AddKnownTypes(api.SchemeGroupVersion, new(api.PerconaXtraDBCluster))
 
-	cl := fake.NewClientBuilder().
+	b := fake.NewClientBuilder().
 		WithScheme(s).
 		WithRuntimeObjects(objs...).
-		WithStatusSubresource(&api.PerconaXtraDBClusterRestore{}).
-		Build()
+		WithStatusSubresource(&api.PerconaXtraDBClusterRestore{})
+	for _, idx := range k8s.Indexes() {
+		b = b.WithIndex(idx.Object, idx.Field, idx.Extract)
+	}
 
-	return cl
+	return b.Build()
 }
 
 func updateResource[T runtime.Object](res runtime.Object, f func(T)) T {
@@ -0,0 +1,112 @@
+package pxcrestore
+
+import (
+	"context"
+	"regexp"
+	"slices"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+const (
+	// jobLogTailLines is how many lines of the job output are searched for the key lines.
+	jobLogTailLines = 500
+	// maxLatestLogLines is how many key lines are kept in the restore status.
+	maxLatestLogLines = 10
+)
+
+var (
+	errorLogLineRe = regexp.MustCompile(`(?i)\b(error|fatal|failed|panic)\b`)
+	keyLogLineRe   = regexp.MustCompile(`(?i)(completed OK!|^\+?\s*(starting|restoring|downloading|preparing|moving back|applying|stopping at|finished))`)
+)
+
+// updateLatestLogLines keeps the key lines of the output of the latest job pod in the restore status
+// and sends an event for each new line, so the progress and the reason of a failure are visible
+// without access to the job logs.
+func (r *ReconcilePerconaXtraDBClusterRestore) updateLatestLogLines(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, job *batchv1.Job) {
+	log := logf.FromContext(ctx)
+
+	selector := job.Spec.Template.Labels
+	if job.Spec.Selector != nil {
+		selector = job.Spec.Selector.MatchLabels
+	}
+
+	pods := new(corev1.PodList)
+	err := r.client.List(ctx, pods, &client.ListOptions{
+		Namespace:     job.Namespace,
+		LabelSelector: labels.SelectorFromSet(selector),
+	})
+	if err != nil {
+		log.V(1).Info("Failed to list restore job pods", "job", job.Name, "error", err.Error())
+		return
+	}
+
+	var pod *corev1.Pod
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if p.Status.Phase == corev1.PodPending {
+			continue
+		}
+		if pod == nil || pod.CreationTimestamp.Before(&p.CreationTimestamp) {
+			pod = p
+		}
+	}
+	if pod == nil || len(job.Spec.Template.Spec.Containers) == 0 {
+		return
+	}
+
+	tailLines := int64(jobLogTailLines)
+	lines, err := r.clientcmd.PodLogs(pod.Namespace, pod.Name, &corev1.PodLogOptions{
+		Container: job.Spec.Template.Spec.Containers[0].Name,
+		TailLines: &tailLines,
+	})
+	if err != nil {
+		log.V(1).Info("Failed to get restore job logs", "pod", pod.Name, "error", err.Error())
+		return
+	}
+
+	keyLines := keyLogLines(lines)
+	for _, line := range keyLines {
+		if slices.Contains(cr.Status.LatestLogLines, line) {
+			continue
+		}
+		eventType := corev1.EventTypeNormal
+		if errorLogLineRe.MatchString(line) {
+			eventType = corev1.EventTypeWarning
+		}
+		r.recorder.Event(cr, eventType, naming.EventRestoreJobLog, line)
+	}
+
+	cr.Status.LatestLogLines = keyLines
+}
+
+// keyLogLines returns the last maxLatestLogLines errors, phase markers and completion messages of the lines.
+func keyLogLines(lines []string) []string {
+	var keyLines []string
+	for _, line := range lines {
+		if errorLogLineRe.MatchString(line) || keyLogLineRe.MatchString(line) {
+			keyLines = append(keyLines, line)
+		}
+	}
+	if len(keyLines) > maxLatestLogLines {
+		keyLines = keyLines[len(keyLines)-maxLatestLogLines:]
+	}
+	return keyLines
+}
+
+// lastErrorLine returns the last error line of the lines, if any.
+func lastErrorLine(lines []string) string {
+	for i := len(lines) - 1; i >= 0; i-- {
+		if errorLogLineRe.MatchString(lines[i]) {
+			return lines[i]
+		}
+	}
+	return ""
+}
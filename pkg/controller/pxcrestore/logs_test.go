@@ -0,0 +1,46 @@
+package pxcrestore
+
+import (
+	"testing"
+)
+
+func TestKeyLogLines(t *testing.T) {
+	lines := []string{
+		"+ xbcloud get --storage=s3 backup",
+		"Downloading backup cluster1-2024-01-01",
+		"230101 10:00:00 [01] decompressing ./ibdata1",
+		"Preparing the backup",
+		"xtrabackup: recognized server arguments: --datadir=/datadir",
+		"230101 10:01:00 completed OK!",
+		"2024/01/01 10:02:00 ERROR: restore replica_parallel_workers: timeout",
+	}
+
+	expected := []string{
+		"Downloading backup cluster1-2024-01-01",
+		"Preparing the backup",
+		"230101 10:01:00 completed OK!",
+		"2024/01/01 10:02:00 ERROR: restore replica_parallel_workers: timeout",
+	}
+
+	got := keyLogLines(lines)
+	if len(got) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("line %d: expected %q, got %q", i, expected[i], got[i])
+		}
+	}
+
+	if line := lastErrorLine(got); line != expected[3] {
+		t.Errorf("unexpected last error line %q", line)
+	}
+
+	many := make([]string, 0, 2*maxLatestLogLines)
+	for i := 0; i < 2*maxLatestLogLines; i++ {
+		many = append(many, "Finished")
+	}
+	if got := keyLogLines(many); len(got) != maxLatestLogLines {
+		t.Errorf("expected %d lines, got %d", maxLatestLogLines, len(got))
+	}
+}
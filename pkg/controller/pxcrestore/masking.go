@@ -0,0 +1,183 @@
+package pxcrestore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+const (
+	maskingContainer = "mysql"
+	maskingVolume    = "script"
+	maskingDir       = "/etc/mysql-masking"
+	maskingFile      = "script.sql"
+)
+
+// mask runs the masking job on the single started PXC node. If the job fails,
+// the restore fails with the proxies still scaled down.
+func (r *ReconcilePerconaXtraDBClusterRestore) mask(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, cluster *api.PerconaXtraDBCluster) (api.BcpRestoreStates, error) {
+	log := logf.FromContext(ctx)
+
+	job, err := maskingJob(cr, cluster)
+	if err != nil {
+		return "", failed(errors.Wrap(err, "failed to get masking job"))
+	}
+
+	done, err := r.runJob(ctx, cr, nil, job)
+	if err != nil {
+		return "", errors.Wrap(err, "run masking")
+	}
+	if !done {
+		return api.RestoreMaskData, nil
+	}
+
+	log.Info("starting cluster", "cluster", cr.Spec.PXCCluster)
+	return api.RestoreStartCluster, nil
+}
+
+func maskingJob(cr *api.PerconaXtraDBClusterRestore, cluster *api.PerconaXtraDBCluster) (*batchv1.Job, error) {
+	masking := cr.Spec.Masking
+	if masking == nil {
+		return nil, errors.New("masking is not set")
+	}
+
+	name := "masking-job-" + cr.Name + "-" + cr.Spec.PXCCluster
+
+	image := masking.Image
+	if image == "" {
+		image = cluster.Spec.PXC.Image
+	}
+
+	ls := naming.LabelsCluster(cluster)
+	ls["job-name"] = name
+
+	container := corev1.Container{
+		Name:            maskingContainer,
+		Image:           image,
+		ImagePullPolicy: cluster.Spec.PXC.ImagePullPolicy,
+		SecurityContext: cluster.Spec.PXC.ContainerSecurityContext,
+		Command:         []string{"bash", "-c"},
+		Args:            []string{maskingCommand(masking)},
+		Env: []corev1.EnvVar{
+			{
+				Name:  "PXC_HOST",
+				Value: cluster.Name + "-pxc-0." + cluster.Name + "-pxc." + cluster.Namespace,
+			},
+			{
+				Name: "MYSQL_ROOT_PASSWORD",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: app.SecretKeySelector(cluster.Spec.SecretsName, users.Root),
+				},
+			},
+			{
+				Name:  "MASKING_SQL",
+				Value: maskingSQL(masking.Rules),
+			},
+		},
+	}
+
+	var volumes []corev1.Volume
+	if script := masking.Script; script != nil {
+		items := []corev1.KeyToPath{{Path: maskingFile}}
+		var source corev1.VolumeSource
+		switch {
+		case script.ConfigMapKeyRef != nil:
+			items[0].Key = script.ConfigMapKeyRef.Key
+			source.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: script.ConfigMapKeyRef.Name},
+				Items:                items,
+			}
+		case script.SecretKeyRef != nil:
+			items[0].Key = script.SecretKeyRef.Key
+			source.Secret = &corev1.SecretVolumeSource{
+				SecretName: script.SecretKeyRef.Name,
+				Items:      items,
+			}
+		default:
+			return nil, errors.New("script source is not set")
+		}
+		volumes = append(volumes, corev1.Volume{Name: maskingVolume, VolumeSource: source})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      maskingVolume,
+			MountPath: maskingDir,
+			ReadOnly:  true,
+		})
+	}
+
+	manualSelector := true
+	backoffLimit := int32(0)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    ls,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: masking.ActiveDeadlineSeconds,
+			ManualSelector:        &manualSelector,
+			Selector:              &metav1.LabelSelector{MatchLabels: ls},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: ls},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					SecurityContext:  cluster.Spec.PXC.PodSecurityContext,
+					ImagePullSecrets: cluster.Spec.PXC.ImagePullSecrets,
+					Containers:       []corev1.Container{container},
+					Volumes:          volumes,
+				},
+			},
+		},
+	}, nil
+}
+
+// maskingCommand pipes the rules and the script to the mysql client. The changes aren't
+// written to the binary log, and the binary logs with the original data are purged.
+func maskingCommand(masking *api.RestoreMasking) string {
+	script := `echo 'SET SESSION sql_log_bin=0;'; printf '%s\n' "$MASKING_SQL";`
+	if masking.Script != nil {
+		script += " cat " + maskingDir + "/" + maskingFile + "; echo;"
+	}
+	script += " echo 'FLUSH BINARY LOGS; PURGE BINARY LOGS BEFORE NOW();';"
+
+	return "set -o pipefail; { " + script + ` } | mysql --host="$PXC_HOST" --port=3306 --user=root --password="$MYSQL_ROOT_PASSWORD" -vv`
+}
+
+// maskingSQL returns an UPDATE statement for each rule. The identifiers are validated by CheckNsetDefaults.
+func maskingSQL(rules []api.MaskingRule) string {
+	var b strings.Builder
+	for _, rule := range rules {
+		col := "`" + rule.Column + "`"
+
+		var expr string
+		switch rule.Method {
+		case api.MaskingNull:
+			expr = "NULL"
+		case api.MaskingStatic:
+			expr = quoteString(rule.Value)
+		case api.MaskingRegex:
+			expr = fmt.Sprintf("REGEXP_REPLACE(%s, %s, %s)", col, quoteString(rule.Pattern), quoteString(rule.Value))
+		default:
+			// keeps the length to fit the column
+			expr = fmt.Sprintf("LEFT(SHA2(%s, 256), CHAR_LENGTH(%s))", col, col)
+		}
+
+		fmt.Fprintf(&b, "UPDATE `%s`.`%s` SET %s = %s WHERE %s IS NOT NULL;\n", rule.Database, rule.Table, col, expr, col)
+	}
+	return b.String()
+}
+
+func quoteString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,113 @@
+package pxcrestore
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestMaskingSQL(t *testing.T) {
+	cr := &api.PerconaXtraDBClusterRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore1", Namespace: "ns"},
+		Spec: api.PerconaXtraDBClusterRestoreSpec{
+			PXCCluster: "cluster1",
+			BackupName: "backup1",
+			Masking: &api.RestoreMasking{
+				Rules: []api.MaskingRule{
+					{Database: "app", Table: "users", Column: "email"},
+					{Database: "app", Table: "users", Column: "phone", Method: api.MaskingNull},
+					{Database: "app", Table: "users", Column: "name", Method: api.MaskingStatic, Value: "O'Brien"},
+					{Database: "app", Table: "cards", Column: "pan", Method: api.MaskingRegex, Pattern: `\d{12}`, Value: "XXXXXXXXXXXX"},
+				},
+			},
+		},
+	}
+	if err := cr.CheckNsetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "UPDATE `app`.`users` SET `email` = LEFT(SHA2(`email`, 256), CHAR_LENGTH(`email`)) WHERE `email` IS NOT NULL;\n" +
+		"UPDATE `app`.`users` SET `phone` = NULL WHERE `phone` IS NOT NULL;\n" +
+		"UPDATE `app`.`users` SET `name` = 'O\\'Brien' WHERE `name` IS NOT NULL;\n" +
+		"UPDATE `app`.`cards` SET `pan` = REGEXP_REPLACE(`pan`, '\\\\d{12}', 'XXXXXXXXXXXX') WHERE `pan` IS NOT NULL;\n"
+	if sql := maskingSQL(cr.Spec.Masking.Rules); sql != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, sql)
+	}
+}
+
+func TestMaskingValidation(t *testing.T) {
+	cases := map[string]*api.RestoreMasking{
+		"empty":              {},
+		"invalid identifier": {Rules: []api.MaskingRule{{Database: "app", Table: "users`; DROP", Column: "email"}}},
+		"regex without pattern": {Rules: []api.MaskingRule{
+			{Database: "app", Table: "users", Column: "email", Method: api.MaskingRegex},
+		}},
+		"unknown method": {Rules: []api.MaskingRule{
+			{Database: "app", Table: "users", Column: "email", Method: "shuffle"},
+		}},
+		"script without key": {Script: &api.SQLJobScript{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "masking"},
+		}}},
+	}
+
+	for name, masking := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &api.PerconaXtraDBClusterRestore{
+				Spec: api.PerconaXtraDBClusterRestoreSpec{
+					PXCCluster: "cluster1",
+					BackupName: "backup1",
+					Masking:    masking,
+				},
+			}
+			if err := cr.CheckNsetDefaults(); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestMaskingJob(t *testing.T) {
+	cr := &api.PerconaXtraDBClusterRestore{
+		ObjectMeta: metav1.ObjectMeta{Name: "restore1", Namespace: "ns"},
+		Spec: api.PerconaXtraDBClusterRestoreSpec{
+			PXCCluster: "cluster1",
+			BackupName: "backup1",
+			Masking: &api.RestoreMasking{
+				Script: &api.SQLJobScript{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "masking"},
+					Key:                  "mask.sql",
+				}},
+			},
+		},
+	}
+	cluster := &api.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "ns"},
+		Spec: api.PerconaXtraDBClusterSpec{
+			SecretsName: "cluster1-secrets",
+			PXC:         &api.PXCSpec{PodSpec: &api.PodSpec{Image: "percona/percona-xtradb-cluster:8.0"}},
+		},
+	}
+
+	job, err := maskingJob(cr, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := job.Spec.Template.Spec.Containers[0]
+	if c.Image != "percona/percona-xtradb-cluster:8.0" {
+		t.Errorf("unexpected image %s", c.Image)
+	}
+	cmd := c.Args[0]
+	for _, s := range []string{"SET SESSION sql_log_bin=0;", "cat /etc/mysql-masking/script.sql", "PURGE BINARY LOGS BEFORE NOW();"} {
+		if !strings.Contains(cmd, s) {
+			t.Errorf("command %q doesn't contain %q", cmd, s)
+		}
+	}
+	if v := job.Spec.Template.Spec.Volumes; len(v) != 1 || v[0].Secret == nil || v[0].Secret.SecretName != "masking" {
+		t.Errorf("unexpected volumes %+v", v)
+	}
+}
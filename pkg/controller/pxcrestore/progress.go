@@ -0,0 +1,214 @@
+package pxcrestore
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+)
+
+const (
+	// defaultDownloadRate and defaultPrepareRate in bytes per second are used for the estimation
+	// until the rates are observed by the restore or by the previous restores of the cluster.
+	defaultDownloadRate = 100 << 20
+	defaultPrepareRate  = 200 << 20
+
+	// etaUpdateThreshold is the minimal change of the estimated completion time written to the status.
+	etaUpdateThreshold = time.Minute
+)
+
+var prepareLogLineRe = regexp.MustCompile(`(?i)^\+?\s*preparing`)
+
+// restoreRates are the rates the phases of the restore are estimated with.
+type restoreRates struct {
+	// download and prepare are in bytes per second
+	download float64
+	prepare  float64
+	// start is the time from the completion of the restore job to the completion of the restore
+	start time.Duration
+}
+
+// updateProgress tracks the phases of the restore and updates its estimated completion time.
+// Failures don't stop the restore, the estimation is skipped instead.
+func (r *ReconcilePerconaXtraDBClusterRestore) updateProgress(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClusterBackup, cluster *api.PerconaXtraDBCluster, clusterName string, state api.BcpRestoreStates) {
+	log := logf.FromContext(ctx)
+
+	now := time.Now().Truncate(time.Second)
+
+	p := cr.Status.Progress
+	if p == nil {
+		p = new(api.RestoreProgress)
+		size, err := r.backupSize(ctx, bcp, cluster)
+		if err != nil {
+			log.V(1).Info("Failed to get backup size", "error", err.Error())
+		}
+		p.BackupSize = size
+		cr.Status.Progress = p
+	}
+
+	if cr.Status.State == api.RestoreRestore {
+		if p.DownloadStartedAt == nil {
+			p.DownloadStartedAt = &metav1.Time{Time: now}
+		}
+		if p.PrepareStartedAt == nil && slices.ContainsFunc(cr.Status.LatestLogLines, prepareLogLineRe.MatchString) {
+			p.PrepareStartedAt = &metav1.Time{Time: now}
+			p.DownloadRate = bytesPerSecond(p.BackupSize, p.PrepareStartedAt.Sub(p.DownloadStartedAt.Time))
+		}
+		if state != api.RestoreRestore && p.RestoredAt == nil {
+			p.RestoredAt = &metav1.Time{Time: now}
+			if p.PrepareStartedAt != nil {
+				p.PrepareRate = bytesPerSecond(p.BackupSize, p.RestoredAt.Sub(p.PrepareStartedAt.Time))
+			}
+		}
+	}
+
+	rates, err := r.historicalRates(ctx, cr, clusterName)
+	if err != nil {
+		log.V(1).Info("Failed to get rates of the previous restores", "error", err.Error())
+	}
+
+	eta, ok := estimateCompletion(p, rates, now)
+	if !ok {
+		p.EstimatedCompletionTime = nil
+		return
+	}
+	if prev := p.EstimatedCompletionTime; prev == nil || eta.Sub(prev.Time).Abs() >= etaUpdateThreshold {
+		p.EstimatedCompletionTime = &metav1.Time{Time: eta}
+	}
+}
+
+// estimateCompletion returns the time the restore is expected to complete at.
+// The rates observed by the restore take precedence over the given ones.
+func estimateCompletion(p *api.RestoreProgress, rates restoreRates, now time.Time) (time.Time, bool) {
+	if p.BackupSize == 0 {
+		return time.Time{}, false
+	}
+
+	download, prepare := rates.download, rates.prepare
+	if p.DownloadRate > 0 {
+		download = float64(p.DownloadRate)
+	}
+	if p.PrepareRate > 0 {
+		prepare = float64(p.PrepareRate)
+	}
+	duration := func(rate float64) time.Duration {
+		return time.Duration(float64(p.BackupSize) / rate * float64(time.Second))
+	}
+
+	var end time.Time
+	switch {
+	case p.RestoredAt != nil:
+		end = p.RestoredAt.Time
+	case p.PrepareStartedAt != nil:
+		end = latest(p.PrepareStartedAt.Add(duration(prepare)), now)
+	default:
+		start := now
+		if p.DownloadStartedAt != nil {
+			start = p.DownloadStartedAt.Time
+		}
+		end = latest(start.Add(duration(download)), now).Add(duration(prepare))
+	}
+
+	return latest(end.Add(rates.start), now).Truncate(time.Second), true
+}
+
+// historicalRates returns the average rates of the previous succeeded restores of the cluster.
+// The time to start the cluster is taken only from the restores with the same phases.
+func (r *ReconcilePerconaXtraDBClusterRestore) historicalRates(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, clusterName string) (restoreRates, error) {
+	rates := restoreRates{download: defaultDownloadRate, prepare: defaultPrepareRate}
+
+	restores := new(api.PerconaXtraDBClusterRestoreList)
+	if err := k8s.ListByIndex(ctx, r.client, restores, cr.Namespace, k8s.IndexPXCCluster, clusterName); err != nil {
+		return rates, errors.Wrap(err, "list restores")
+	}
+
+	var download, prepare []int64
+	var start []time.Duration
+	for _, rs := range restores.Items {
+		p := rs.Status.Progress
+		if rs.Name == cr.Name || rs.Status.State != api.RestoreSucceeded || p == nil {
+			continue
+		}
+		if p.DownloadRate > 0 {
+			download = append(download, p.DownloadRate)
+		}
+		if p.PrepareRate > 0 {
+			prepare = append(prepare, p.PrepareRate)
+		}
+		samePhases := (rs.Spec.PITR != nil) == (cr.Spec.PITR != nil) && (rs.Spec.Masking != nil) == (cr.Spec.Masking != nil)
+		if samePhases && p.RestoredAt != nil && rs.Status.CompletedAt != nil {
+			start = append(start, rs.Status.CompletedAt.Sub(p.RestoredAt.Time))
+		}
+	}
+
+	if len(download) > 0 {
+		rates.download = float64(average(download))
+	}
+	if len(prepare) > 0 {
+		rates.prepare = float64(average(prepare))
+	}
+	if len(start) > 0 {
+		rates.start = average(start)
+	}
+	return rates, nil
+}
+
+// backupSize returns the size of the backup objects, 0 if the storage doesn't report it.
+func (r *ReconcilePerconaXtraDBClusterRestore) backupSize(ctx context.Context, bcp *api.PerconaXtraDBClusterBackup, cluster *api.PerconaXtraDBCluster) (int64, error) {
+	if bcp.Status.Destination.StorageTypePrefix() == api.PVCStoragePrefix {
+		return 0, nil
+	}
+
+	opts, err := storage.GetOptionsFromBackup(ctx, r.client, cluster, bcp)
+	if err != nil {
+		return 0, errors.Wrap(err, "get storage options")
+	}
+	cli, err := r.newStorageClientFunc(ctx, opts)
+	if err != nil {
+		return 0, errors.Wrap(err, "new storage client")
+	}
+	stg, ok := cli.(storage.SizeStorage)
+	if !ok {
+		return 0, nil
+	}
+
+	var size int64
+	for _, prefix := range bcp.Status.ObjectPrefixes() {
+		s, err := stg.PrefixSize(ctx, prefix)
+		if err != nil {
+			return 0, errors.Wrapf(err, "get size of %s", prefix)
+		}
+		size += s
+	}
+	return size, nil
+}
+
+func bytesPerSecond(size int64, d time.Duration) int64 {
+	if d < time.Second {
+		return 0
+	}
+	return int64(float64(size) / d.Seconds())
+}
+
+func average[T int64 | time.Duration](values []T) T {
+	var sum T
+	for _, v := range values {
+		sum += v
+	}
+	return sum / T(len(values))
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
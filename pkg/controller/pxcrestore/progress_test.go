@@ -0,0 +1,124 @@
+package pxcrestore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestEstimateCompletion(t *testing.T) {
+	const gb = 1 << 30
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *metav1.Time {
+		return &metav1.Time{Time: now.Add(d)}
+	}
+	rates := restoreRates{download: gb / 10, prepare: gb / 20, start: 5 * time.Minute}
+
+	tests := []struct {
+		name     string
+		progress api.RestoreProgress
+		expected time.Time
+		ok       bool
+	}{
+		{
+			name:     "unknown size",
+			progress: api.RestoreProgress{},
+		},
+		{
+			name:     "not started",
+			progress: api.RestoreProgress{BackupSize: 60 * gb},
+			expected: now.Add(10*time.Minute + 20*time.Minute + 5*time.Minute),
+			ok:       true,
+		},
+		{
+			name:     "downloading",
+			progress: api.RestoreProgress{BackupSize: 60 * gb, DownloadStartedAt: at(-4 * time.Minute)},
+			expected: now.Add(6*time.Minute + 20*time.Minute + 5*time.Minute),
+			ok:       true,
+		},
+		{
+			name:     "download is slower than expected",
+			progress: api.RestoreProgress{BackupSize: 60 * gb, DownloadStartedAt: at(-15 * time.Minute)},
+			expected: now.Add(20*time.Minute + 5*time.Minute),
+			ok:       true,
+		},
+		{
+			name: "preparing with the observed download rate",
+			progress: api.RestoreProgress{
+				BackupSize:        60 * gb,
+				DownloadStartedAt: at(-30 * time.Minute),
+				PrepareStartedAt:  at(-10 * time.Minute),
+				DownloadRate:      gb / 20,
+			},
+			expected: now.Add(10*time.Minute + 5*time.Minute),
+			ok:       true,
+		},
+		{
+			name: "starting the cluster",
+			progress: api.RestoreProgress{
+				BackupSize: 60 * gb,
+				RestoredAt: at(-2 * time.Minute),
+			},
+			expected: now.Add(3 * time.Minute),
+			ok:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eta, ok := estimateCompletion(&tt.progress, rates, now)
+			if ok != tt.ok {
+				t.Fatalf("expected ok %v, got %v", tt.ok, ok)
+			}
+			if !eta.Equal(tt.expected) {
+				t.Errorf("expected %s, got %s", tt.expected, eta)
+			}
+		})
+	}
+}
+
+func TestHistoricalRates(t *testing.T) {
+	ctx := context.Background()
+
+	const namespace = "namespace"
+	now := time.Now()
+
+	restore := func(name string, state api.BcpRestoreStates, p *api.RestoreProgress, completed time.Time) *api.PerconaXtraDBClusterRestore {
+		cr := readDefaultRestore(t, name, namespace)
+		cr.Status.State = state
+		cr.Status.Progress = p
+		cr.Status.CompletedAt = &metav1.Time{Time: completed}
+		return cr
+	}
+	cr := readDefaultRestore(t, "restore", namespace)
+
+	r := reconciler(buildFakeClient(
+		restore("restore1", api.RestoreSucceeded, &api.RestoreProgress{
+			DownloadRate: 100,
+			PrepareRate:  200,
+			RestoredAt:   &metav1.Time{Time: now.Add(-10 * time.Minute)},
+		}, now),
+		restore("restore2", api.RestoreSucceeded, &api.RestoreProgress{
+			DownloadRate: 300,
+			RestoredAt:   &metav1.Time{Time: now.Add(-20 * time.Minute)},
+		}, now),
+		restore("restore3", api.RestoreFailed, &api.RestoreProgress{
+			DownloadRate: 1000,
+			PrepareRate:  1000,
+		}, now),
+	))
+
+	rates, err := r.historicalRates(ctx, cr, cr.Spec.PXCCluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := restoreRates{download: 200, prepare: 200, start: 15 * time.Minute}
+	if rates != expected {
+		t.Errorf("expected %+v, got %+v", expected, rates)
+	}
+}
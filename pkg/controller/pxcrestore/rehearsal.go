@@ -0,0 +1,210 @@
+package pxcrestore
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+// rehearsalCluster returns the temporary cluster of the rehearsal. The cluster, its users secret
+// and the data volume of its first pod are created paused when the restore starts, so the restore
+// phases run on the temporary cluster the same way as on the source cluster.
+func (r *ReconcilePerconaXtraDBClusterRestore) rehearsalCluster(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, source *api.PerconaXtraDBCluster) (*api.PerconaXtraDBCluster, error) {
+	log := logf.FromContext(ctx)
+
+	name := cr.Spec.Rehearsal.ClusterName
+
+	cluster := new(api.PerconaXtraDBCluster)
+	err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: cr.Namespace}, cluster)
+	if err == nil {
+		if !metav1.IsControlledBy(cluster, cr) {
+			return nil, failed(errors.Errorf("cluster %s exists and isn't a rehearsal cluster of the restore", name))
+		}
+		if err := cluster.CheckNSetDefaults(r.serverVersion, log); err != nil {
+			return nil, failed(errors.Wrapf(err, "wrong options of rehearsal cluster %s", name))
+		}
+		return cluster, nil
+	}
+	if !k8serrors.IsNotFound(err) {
+		return nil, errors.Wrapf(err, "get rehearsal cluster %s", name)
+	}
+	if cr.Status.State != api.RestoreStarting {
+		return nil, failed(errors.Errorf("rehearsal cluster %s was deleted", name))
+	}
+
+	if source.Spec.PXC.VolumeSpec == nil || source.Spec.PXC.VolumeSpec.PersistentVolumeClaim == nil {
+		return nil, failed(errors.New("rehearsal requires a persistent volume claim for the PXC data"))
+	}
+
+	cluster = rehearsalClusterSpec(source, name)
+	if err := k8s.SetControllerReference(cr, cluster, r.scheme); err != nil {
+		return nil, errors.Wrap(err, "set controller reference")
+	}
+
+	sourceSecret := new(corev1.Secret)
+	err = r.client.Get(ctx, types.NamespacedName{Name: source.Spec.SecretsName, Namespace: source.Namespace}, sourceSecret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get users secret %s", source.Spec.SecretsName)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cluster.Spec.SecretsName,
+			Namespace: cluster.Namespace,
+		},
+		Data: sourceSecret.Data,
+	}
+	if err := k8s.SetControllerReference(cr, secret, r.scheme); err != nil {
+		return nil, errors.Wrap(err, "set controller reference")
+	}
+	if err := r.client.Create(ctx, secret); client.IgnoreAlreadyExists(err) != nil {
+		return nil, errors.Wrapf(err, "create users secret %s", secret.Name)
+	}
+
+	node := statefulset.NewNode(cluster)
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      app.DataVolumeName + "-" + node.StatefulSet().Name + "-0",
+			Namespace: cluster.Namespace,
+			Labels:    node.Labels(),
+		},
+		Spec: app.VolumeSpec(cluster.Spec.PXC.VolumeSpec),
+	}
+	if err := r.client.Create(ctx, pvc); client.IgnoreAlreadyExists(err) != nil {
+		return nil, errors.Wrapf(err, "create PVC %s", pvc.Name)
+	}
+
+	if err := r.client.Create(ctx, cluster); err != nil {
+		return nil, errors.Wrapf(err, "create rehearsal cluster %s", name)
+	}
+	log.Info("rehearsal cluster created", "cluster", name, "source", source.Name)
+
+	if err := cluster.CheckNSetDefaults(r.serverVersion, log); err != nil {
+		return nil, failed(errors.Wrapf(err, "wrong options of rehearsal cluster %s", name))
+	}
+
+	return cluster, nil
+}
+
+// rehearsalClusterSpec returns a paused single node copy of the source cluster.
+// The features writing to the backup storages, connecting to other clusters or exposing
+// the cluster are disabled, and the cluster gets its own secrets.
+func rehearsalClusterSpec(source *api.PerconaXtraDBCluster, name string) *api.PerconaXtraDBCluster {
+	spec := source.Spec.DeepCopy()
+
+	spec.Pause = true
+	spec.SecretsName = name + "-secrets"
+	spec.ExternalUsersSecret = false
+	spec.UsersSecretSource = nil
+	spec.VaultDBSecrets = nil
+	spec.SSLSecretName = ""
+	spec.SSLInternalSecretName = ""
+	spec.Users = nil
+	spec.Standby = nil
+	spec.GitOps = nil
+	spec.ConsistencyCheck = nil
+	spec.Velero = nil
+	spec.OperationsHistory = nil
+	if spec.PMM != nil {
+		spec.PMM.Enabled = false
+	}
+	if spec.Backup != nil {
+		spec.Backup.Schedule = nil
+		spec.Backup.PITR.Enabled = false
+	}
+
+	spec.PXC.Size = 1
+	spec.Unsafe.PXCSize = true
+	spec.Unsafe.ProxySize = true
+	spec.PXC.Expose = api.ServiceExpose{}
+	spec.PXC.ReplicationChannels = nil
+	spec.PXC.ExternalReplication = nil
+	spec.PXC.Reporting = nil
+	if spec.HAProxy != nil {
+		spec.HAProxy.Enabled = false
+	}
+	if spec.ProxySQL != nil {
+		spec.ProxySQL.Enabled = false
+	}
+	// smart update requires a proxy
+	if spec.UpdateStrategy == api.SmartUpdateStatefulSetStrategyType {
+		spec.UpdateStrategy = appsv1.RollingUpdateStatefulSetStrategyType
+	}
+
+	return &api.PerconaXtraDBCluster{
+		TypeMeta: source.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: source.Namespace,
+			Finalizers: []string{
+				naming.FinalizerDeletePxcPvc,
+				naming.FinalizerDeleteSSL,
+			},
+		},
+		Spec: *spec,
+	}
+}
+
+// finishRehearsal reports the recovery point reached by the temporary cluster and deletes the cluster
+// with its volumes unless it should be kept.
+func (r *ReconcilePerconaXtraDBClusterRestore) finishRehearsal(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, cluster *api.PerconaXtraDBCluster) error {
+	host := cluster.Name + "-pxc-0." + cluster.Name + "-pxc." + cluster.Namespace
+	db, err := queries.New(r.client, cluster.Namespace, "internal-"+cluster.Name, users.Root, host, 33062, cluster.Spec.PXC.ReadinessProbes.TimeoutSeconds)
+	if err != nil {
+		return errors.Wrap(err, "connect to rehearsal cluster")
+	}
+	defer db.Close()
+
+	gtidExecuted, err := db.ReadVariable("gtid_executed")
+	if err != nil {
+		return errors.Wrap(err, "get gtid_executed")
+	}
+
+	cr.Status.Rehearsal = &api.RestoreRehearsalStatus{
+		ClusterName:    cluster.Name,
+		RecoveryTarget: recoveryTarget(cr.Spec.PITR),
+		RecoveryPoint:  gtidExecuted,
+	}
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventRestoreRehearsed,
+		"Rehearsal cluster %s recovered to %s", cluster.Name, gtidExecuted)
+
+	if cr.Spec.Rehearsal.KeepCluster {
+		return nil
+	}
+
+	if err := r.client.Delete(ctx, cluster); client.IgnoreNotFound(err) != nil {
+		return errors.Wrapf(err, "delete rehearsal cluster %s", cluster.Name)
+	}
+	logf.FromContext(ctx).Info("rehearsal cluster deleted", "cluster", cluster.Name)
+
+	return nil
+}
+
+func recoveryTarget(pitr *api.PITR) string {
+	if pitr == nil {
+		return ""
+	}
+
+	switch pitr.Type {
+	case "date":
+		return pitr.Date
+	case "transaction", "skip":
+		return pitr.GTID
+	default:
+		return pitr.Type
+	}
+}
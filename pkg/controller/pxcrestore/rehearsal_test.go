@@ -0,0 +1,90 @@
+package pxcrestore
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/version"
+)
+
+func TestRehearsalCluster(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := readDefaultCR(t, "cluster1", "default")
+	if err := cluster.CheckNSetDefaults(new(version.ServerVersion), logf.FromContext(ctx)); err != nil {
+		t.Fatal(err)
+	}
+	cluster.Spec.Backup.PITR.Enabled = true
+
+	cr := readDefaultRestore(t, "restore1", "default")
+	cr.Spec.Rehearsal = &api.RestoreRehearsal{Enabled: true}
+	if err := cr.CheckNsetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	if cr.TargetCluster() != "cluster1-rh" {
+		t.Fatalf("unexpected rehearsal cluster name %q", cr.TargetCluster())
+	}
+	cr.Status.State = api.RestoreStarting
+
+	secret := readDefaultCRSecret(t, cluster.Spec.SecretsName, "default")
+	secret.Data = make(map[string][]byte)
+	for k, v := range secret.StringData {
+		secret.Data[k] = []byte(v)
+	}
+	secret.StringData = nil
+
+	r := reconciler(buildFakeClient(cluster, cr, secret))
+	r.serverVersion = new(version.ServerVersion)
+
+	rehearsal, err := r.rehearsalCluster(ctx, cr, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the defaults set the size of paused clusters to 0
+	created := new(api.PerconaXtraDBCluster)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: "cluster1-rh", Namespace: "default"}, created); err != nil {
+		t.Fatal(err)
+	}
+	if !created.Spec.Pause || created.Spec.PXC.Size != 1 || created.HAProxyEnabled() {
+		t.Error("expected a paused single node cluster without proxies")
+	}
+	if rehearsal.Spec.Backup.PITR.Enabled || len(rehearsal.Spec.Backup.Schedule) != 0 {
+		t.Error("expected backups of the rehearsal cluster to be disabled")
+	}
+	if !metav1.IsControlledBy(rehearsal, cr) {
+		t.Error("expected the rehearsal cluster to be controlled by the restore")
+	}
+
+	copied := new(corev1.Secret)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: "cluster1-rh-secrets", Namespace: "default"}, copied); err != nil {
+		t.Fatal(err)
+	}
+	if string(copied.Data["root"]) != string(secret.Data["root"]) {
+		t.Error("expected the users secret to be copied")
+	}
+
+	pvc := new(corev1.PersistentVolumeClaim)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: "datadir-cluster1-rh-pxc-0", Namespace: "default"}, pvc); err != nil {
+		t.Fatal(err)
+	}
+
+	source := new(api.PerconaXtraDBCluster)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: "cluster1", Namespace: "default"}, source); err != nil {
+		t.Fatal(err)
+	}
+	if source.Spec.Pause || !source.Spec.Backup.PITR.Enabled {
+		t.Error("the source cluster is changed")
+	}
+
+	cr.Status.State = api.RestoreRestore
+	if _, err := r.rehearsalCluster(ctx, cr, cluster); err != nil {
+		t.Errorf("expected the existing rehearsal cluster to be returned: %v", err)
+	}
+}
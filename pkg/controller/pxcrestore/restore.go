@@ -2,74 +2,294 @@ package pxcrestore
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/binlogcollector"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup"
 )
 
-func (r *ReconcilePerconaXtraDBClusterRestore) restore(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClusterBackup, cluster *api.PerconaXtraDBCluster) error {
+func (r *ReconcilePerconaXtraDBClusterRestore) validateRestore(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClusterBackup, cluster *api.PerconaXtraDBCluster) (api.BcpRestoreStates, error) {
 	log := logf.FromContext(ctx)
 
 	if cluster.Spec.Backup == nil {
-		return errors.New("undefined backup section in a cluster spec")
+		return "", failed(errors.New("undefined backup section in a cluster spec"))
 	}
 
+	// the restore changes the size and the pause of the cluster in several steps
+	if cluster.Spec.GitOps.IsEnabled() {
+		return "", failed(errors.Errorf("the spec of cluster %s is owned by a GitOps tool and can't be changed by the restore", cluster.Name))
+	}
+
+	if cr.Spec.PITR != nil {
+		err := backup.CheckPITRErrors(ctx, r.client, r.clientcmd, r.recorder, cluster)
+		if err != nil {
+			return "", failed(err)
+		}
+
+		annotations := cr.GetAnnotations()
+		_, unsafePITR := annotations[api.AnnotationUnsafePITR]
+		cond := meta.FindStatusCondition(bcp.Status.Conditions, api.BackupConditionPITRReady)
+		if cond != nil && cond.Status == metav1.ConditionFalse && !unsafePITR {
+			msg := fmt.Sprintf("Backup doesn't guarantee consistent recovery with PITR. Annotate PerconaXtraDBClusterRestore with %s to force it.", api.AnnotationUnsafePITR)
+			return "", failed(errors.New(msg))
+		}
+	}
+
+	if err := r.validate(ctx, cr, bcp, cluster); err != nil {
+		return "", failed(errors.Wrap(err, "failed to validate restore job"))
+	}
+
+	retrieved, err := r.retrieveArchived(ctx, cr, bcp, cluster)
+	if err != nil {
+		return "", errors.Wrap(err, "retrieve archived backup objects")
+	}
+	if !retrieved {
+		log.Info("waiting for the retrieval of archived backup objects", "backup", bcp.Status.Destination)
+		return api.RestoreAwaitingRetrieval, nil
+	}
+
+	if cr.Spec.PITR != nil || cr.Spec.Masking != nil {
+		orig := &api.RestoreOriginalCluster{
+			PXCSize:         cluster.Spec.PXC.Size,
+			UnsafePXCSize:   cluster.Spec.Unsafe.PXCSize,
+			UnsafeProxySize: cluster.Spec.Unsafe.ProxySize,
+		}
+		if cluster.Spec.ProxySQL != nil {
+			orig.ProxySQLSize = cluster.Spec.ProxySQL.Size
+		}
+		if cluster.Spec.HAProxy != nil {
+			orig.HAProxySize = cluster.Spec.HAProxy.Size
+		}
+		cr.Status.OriginalCluster = orig
+	}
+
+	log.Info("stopping cluster", "cluster", cr.Spec.PXCCluster)
+	return api.RestoreStopCluster, nil
+}
+
+func (r *ReconcilePerconaXtraDBClusterRestore) stopCluster(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, cluster *api.PerconaXtraDBCluster) (api.BcpRestoreStates, error) {
+	log := logf.FromContext(ctx)
+
+	paused, err := k8s.PauseCluster(ctx, r.client, cluster)
+	if err != nil {
+		return "", errors.Wrapf(err, "stop cluster %s", cluster.Name)
+	}
+	if !paused {
+		return api.RestoreStopCluster, nil
+	}
+
+	deleted, err := k8s.DeletePVCs(ctx, r.client, cluster)
+	if err != nil {
+		return "", errors.Wrapf(err, "stop cluster %s", cluster.Name)
+	}
+	if !deleted {
+		return api.RestoreStopCluster, nil
+	}
+
+	log.Info("starting restore", "cluster", cr.Spec.PXCCluster, "backup", cr.Spec.BackupName)
+	return api.RestoreRestore, nil
+}
+
+func (r *ReconcilePerconaXtraDBClusterRestore) restore(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClusterBackup, cluster *api.PerconaXtraDBCluster) (api.BcpRestoreStates, error) {
+	log := logf.FromContext(ctx)
+
 	restorer, err := r.getRestorer(ctx, cr, bcp, cluster)
 	if err != nil {
-		return errors.Wrap(err, "failed to get restorer")
+		return "", errors.Wrap(err, "failed to get restorer")
 	}
 	job, err := restorer.Job()
 	if err != nil {
-		return errors.Wrap(err, "failed to get restore job")
-	}
-	if err = k8s.SetControllerReference(cr, job, r.scheme); err != nil {
-		return err
+		return "", failed(errors.Wrap(err, "failed to get restore job"))
 	}
 
-	if err = restorer.Init(ctx); err != nil {
-		return errors.Wrap(err, "failed to init restore")
+	done, err := r.runJob(ctx, cr, restorer, job)
+	if err != nil {
+		return "", errors.Wrap(err, "run restore")
+	}
+	if !done {
+		return api.RestoreRestore, nil
 	}
-	defer func() {
-		if derr := restorer.Finalize(ctx); derr != nil {
-			log.Error(derr, "failed to finalize restore")
+
+	if cluster.Spec.Backup.PITR.Enabled {
+		if err := binlogcollector.InvalidateCache(ctx, r.client, cluster); err != nil {
+			log.Error(err, "failed to invalidate binlog collector cache")
 		}
-	}()
+	}
 
-	return r.createJob(ctx, job)
+	log.Info("starting cluster", "cluster", cr.Spec.PXCCluster)
+	return api.RestoreStartCluster, nil
 }
 
-func (r *ReconcilePerconaXtraDBClusterRestore) pitr(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClusterBackup, cluster *api.PerconaXtraDBCluster) error {
+func (r *ReconcilePerconaXtraDBClusterRestore) pitr(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClusterBackup, cluster *api.PerconaXtraDBCluster) (api.BcpRestoreStates, error) {
 	log := logf.FromContext(ctx)
 
 	restorer, err := r.getRestorer(ctx, cr, bcp, cluster)
 	if err != nil {
-		return errors.Wrap(err, "failed to get restorer")
+		return "", errors.Wrap(err, "failed to get restorer")
 	}
 	job, err := restorer.PITRJob()
 	if err != nil {
-		return errors.Wrap(err, "failed to create pitr restore job")
+		return "", failed(errors.Wrap(err, "failed to create pitr restore job"))
+	}
+
+	done, err := r.runJob(ctx, cr, restorer, job)
+	if err != nil {
+		return "", errors.Wrap(err, "run pitr")
+	}
+	if !done {
+		return api.RestorePITR, nil
+	}
+
+	log.Info("starting cluster", "cluster", cr.Spec.PXCCluster)
+	return api.RestoreStartCluster, nil
+}
+
+// startCluster unpauses the cluster. For point-in-time recovery and masking the cluster is
+// started with a single PXC pod and without proxies first, and brought back to its original
+// sizes once the pitr and masking jobs have completed.
+func (r *ReconcilePerconaXtraDBClusterRestore) startCluster(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClusterBackup, cluster *api.PerconaXtraDBCluster) (api.BcpRestoreStates, error) {
+	log := logf.FromContext(ctx)
+
+	if cr.Spec.PITR != nil {
+		restorer, err := r.getRestorer(ctx, cr, bcp, cluster)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to get restorer")
+		}
+		job, err := restorer.PITRJob()
+		if err != nil {
+			return "", failed(errors.Wrap(err, "failed to create pitr restore job"))
+		}
+		pitrDone, err := r.jobCompleted(ctx, job)
+		if err != nil {
+			return "", err
+		}
+
+		if !pitrDone {
+			ready, err := r.updateCluster(ctx, cluster, singleNode)
+			if err != nil {
+				return "", errors.Wrap(err, "restart cluster for pitr")
+			}
+			if !ready {
+				return api.RestoreStartCluster, nil
+			}
+
+			log.Info("point-in-time recovering", "cluster", cr.Spec.PXCCluster)
+			return api.RestorePITR, nil
+		}
 	}
-	if err := k8s.SetControllerReference(cr, job, r.scheme); err != nil {
-		return err
+
+	if cr.Spec.Masking != nil {
+		job, err := maskingJob(cr, cluster)
+		if err != nil {
+			return "", failed(errors.Wrap(err, "failed to get masking job"))
+		}
+		masked, err := r.jobCompleted(ctx, job)
+		if err != nil {
+			return "", err
+		}
+
+		if !masked {
+			ready, err := r.updateCluster(ctx, cluster, singleNode)
+			if err != nil {
+				return "", errors.Wrap(err, "restart cluster for masking")
+			}
+			if !ready {
+				return api.RestoreStartCluster, nil
+			}
+
+			log.Info("masking data", "cluster", cr.Spec.PXCCluster)
+			return api.RestoreMaskData, nil
+		}
+	}
+
+	ready, err := r.updateCluster(ctx, cluster, func(c *api.PerconaXtraDBCluster) {
+		c.Spec.Pause = false
+
+		orig := cr.Status.OriginalCluster
+		if orig == nil {
+			return
+		}
+		c.Spec.Unsafe.PXCSize = orig.UnsafePXCSize
+		c.Spec.Unsafe.ProxySize = orig.UnsafeProxySize
+		c.Spec.PXC.Size = orig.PXCSize
+		if c.Spec.ProxySQL != nil {
+			c.Spec.ProxySQL.Size = orig.ProxySQLSize
+		}
+		if c.Spec.HAProxy != nil {
+			c.Spec.HAProxy.Size = orig.HAProxySize
+		}
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "restart cluster")
 	}
-	if err = restorer.Init(ctx); err != nil {
-		return errors.Wrap(err, "failed to init restore")
+	if !ready {
+		return api.RestoreStartCluster, nil
 	}
-	defer func() {
-		if derr := restorer.Finalize(ctx); derr != nil {
-			log.Error(derr, "failed to finalize restore")
+
+	return api.RestoreSucceeded, nil
+}
+
+// waitLimit is how long the restore waits for the pods or the PVCs of the cluster to be deleted.
+const waitLimit = 300 * time.Second
+
+// stateTimeout returns how long the restore can stay in the state, 0 if the time isn't limited.
+func stateTimeout(state api.BcpRestoreStates, cluster *api.PerconaXtraDBCluster) time.Duration {
+	switch state {
+	case api.RestoreStopCluster:
+		timeout := 2 * waitLimit
+		if cluster.Spec.PXC.TerminationGracePeriodSeconds != nil {
+			timeout += time.Duration(int64(cluster.Spec.PXC.Size)**cluster.Spec.PXC.TerminationGracePeriodSeconds) * time.Second
 		}
-	}()
+		return timeout
+	case api.RestoreStartCluster:
+		if cluster.Spec.PXC.LivenessInitialDelaySeconds != nil {
+			return time.Duration(*cluster.Spec.PXC.LivenessInitialDelaySeconds*cluster.Spec.PXC.Size) * time.Second
+		}
+		return 2 * time.Hour
+	}
+	return 0
+}
+
+// stateTimeoutExceeded returns an error if the restore didn't leave its current state in time.
+// lastErr is the error of the last attempt, if any.
+func stateTimeoutExceeded(cr *api.PerconaXtraDBClusterRestore, cluster *api.PerconaXtraDBCluster, lastErr error) error {
+	timeout := stateTimeout(cr.Status.State, cluster)
+	if timeout == 0 || cr.Status.StateStartedAt == nil || time.Since(cr.Status.StateStartedAt.Time) < timeout {
+		return nil
+	}
+
+	if lastErr != nil {
+		return errors.Wrapf(lastErr, "restore state %s exceeded wait limit %s", cr.Status.State, timeout)
+	}
+	return errors.Errorf("restore state %s exceeded wait limit %s", cr.Status.State, timeout)
+}
 
-	return r.createJob(ctx, job)
+// singleNode starts the cluster with a single PXC pod closed for the clients.
+func singleNode(c *api.PerconaXtraDBCluster) {
+	c.Spec.Pause = false
+	c.Spec.Unsafe.PXCSize = true
+	c.Spec.Unsafe.ProxySize = true
+	c.Spec.PXC.Size = 1
+	if c.Spec.ProxySQL != nil {
+		c.Spec.ProxySQL.Size = 0
+	}
+	if c.Spec.HAProxy != nil {
+		c.Spec.HAProxy.Size = 0
+	}
 }
 
 func (r *ReconcilePerconaXtraDBClusterRestore) validate(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClusterBackup, cluster *api.PerconaXtraDBCluster) error {
@@ -100,33 +320,98 @@ func (r *ReconcilePerconaXtraDBClusterRestore) validate(ctx context.Context, cr
 	return nil
 }
 
-func (r *ReconcilePerconaXtraDBClusterRestore) createJob(ctx context.Context, job *batchv1.Job) error {
-	err := r.client.Create(ctx, job)
+// runJob creates the job if it doesn't exist yet and reports whether it has completed.
+// The restorer, if any, is finalized once the job is finished.
+func (r *ReconcilePerconaXtraDBClusterRestore) runJob(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, restorer Restorer, job *batchv1.Job) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	current := new(batchv1.Job)
+	err := r.client.Get(ctx, types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, current)
+	if k8serrors.IsNotFound(err) {
+		if err := k8s.SetControllerReference(cr, job, r.scheme); err != nil {
+			return false, err
+		}
+		if restorer != nil {
+			if err := restorer.Init(ctx); err != nil {
+				return false, errors.Wrap(err, "failed to init restore")
+			}
+		}
+		if err := r.client.Create(ctx, job); err != nil {
+			return false, errors.Wrap(err, "create job")
+		}
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrap(err, "get job status")
+	}
+
+	r.updateLatestLogLines(ctx, cr, current)
+
+	cond := jobFinishedCondition(current)
+	if cond == nil {
+		return false, nil
+	}
+
+	if restorer != nil {
+		if err := restorer.Finalize(ctx); err != nil {
+			log.Error(err, "failed to finalize restore")
+		}
+	}
+	if cond.Type == batchv1.JobFailed {
+		if line := lastErrorLine(cr.Status.LatestLogLines); line != "" {
+			return false, failed(errors.Errorf("%s: %s", cond.Message, line))
+		}
+		return false, failed(errors.New(cond.Message))
+	}
+
+	return true, nil
+}
+
+// jobCompleted reports whether the job exists and has completed successfully.
+func (r *ReconcilePerconaXtraDBClusterRestore) jobCompleted(ctx context.Context, job *batchv1.Job) (bool, error) {
+	current := new(batchv1.Job)
+	err := r.client.Get(ctx, types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, current)
 	if err != nil {
-		return errors.Wrap(err, "create job")
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "get job status")
 	}
 
-	for {
-		time.Sleep(time.Second * 1)
+	cond := jobFinishedCondition(current)
+	return cond != nil && cond.Type == batchv1.JobComplete, nil
+}
 
-		checkJob := batchv1.Job{}
-		err := r.client.Get(ctx, types.NamespacedName{Name: job.Name, Namespace: job.Namespace}, &checkJob)
-		if err != nil {
-			if k8serrors.IsNotFound(err) {
-				return nil
-			}
-			return errors.Wrap(err, "get job status")
+func jobFinishedCondition(job *batchv1.Job) *batchv1.JobCondition {
+	for i, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
 		}
-		for _, cond := range checkJob.Status.Conditions {
-			if cond.Status != corev1.ConditionTrue {
-				continue
-			}
-			switch cond.Type {
-			case batchv1.JobComplete:
-				return nil
-			case batchv1.JobFailed:
-				return errors.New(cond.Message)
-			}
+		switch cond.Type {
+		case batchv1.JobComplete, batchv1.JobFailed:
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// updateCluster applies mutate to the cluster spec and reports whether
+// the cluster is ready with the updated spec.
+func (r *ReconcilePerconaXtraDBClusterRestore) updateCluster(ctx context.Context, cluster *api.PerconaXtraDBCluster, mutate func(*api.PerconaXtraDBCluster)) (bool, error) {
+	current := new(api.PerconaXtraDBCluster)
+	err := r.client.Get(ctx, types.NamespacedName{Name: cluster.Name, Namespace: cluster.Namespace}, current)
+	if err != nil {
+		return false, errors.Wrap(err, "get cluster")
+	}
+
+	orig := current.DeepCopy()
+	mutate(current)
+	if !equality.Semantic.DeepEqual(orig.Spec, current.Spec) {
+		if err := r.client.Patch(ctx, current, client.MergeFrom(orig)); err != nil {
+			return false, errors.Wrap(err, "update cluster")
 		}
+		return false, nil
 	}
+
+	return current.Status.ObservedGeneration == current.Generation && current.Status.PXC.Status == api.AppStateReady, nil
 }
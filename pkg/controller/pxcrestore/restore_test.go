@@ -2,10 +2,15 @@ package pxcrestore
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
@@ -118,6 +123,26 @@ func TestValidate(t *testing.T) {
 				s3Secret,
 			},
 		},
+		{
+			name: "s3 xbstream backup",
+			cr: updateResource(cr, func(cr *api.PerconaXtraDBClusterRestore) {
+				cr.Spec.BackupName = ""
+				cr.Spec.BackupSource = &api.PXCBackupStatus{
+					Destination: s3Bcp.Status.Destination,
+					StorageType: api.BackupStorageS3,
+					S3:          s3Bcp.Status.S3,
+					Layout: &api.BackupLayout{
+						Format:      api.BackupFormatXbstream,
+						Compression: "zstd",
+					},
+				}
+			}),
+			cluster: cluster.DeepCopy(),
+			objects: []runtime.Object{
+				crSecret,
+				s3Secret,
+			},
+		},
 		{
 			name:        "s3 with empty bucket",
 			cr:          cr.DeepCopy(),
@@ -297,3 +322,108 @@ func (c *fakeStorageClient) ListObjects(_ context.Context, _ string) ([]string,
 	}
 	return []string{"some-dest/backup1", "some-dest/backup2"}, nil
 }
+
+func TestUpdateCluster(t *testing.T) {
+	ctx := context.Background()
+
+	const clusterName = "test-cluster"
+	const namespace = "namespace"
+
+	cluster := readDefaultCR(t, clusterName, namespace)
+	cluster.Spec.Pause = true
+	cluster.Spec.PXC.Size = 1
+	cluster.Spec.HAProxy.Size = 0
+
+	cl := buildFakeClient(cluster)
+	r := reconciler(cl)
+
+	restoreSizes := func(c *api.PerconaXtraDBCluster) {
+		c.Spec.Pause = false
+		c.Spec.PXC.Size = 3
+		c.Spec.HAProxy.Size = 3
+	}
+
+	ready, err := r.updateCluster(ctx, cluster, restoreSizes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ready {
+		t.Fatal("expected cluster not to be ready right after update")
+	}
+
+	updated := new(api.PerconaXtraDBCluster)
+	if err := cl.Get(ctx, types.NamespacedName{Name: clusterName, Namespace: namespace}, updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Spec.Pause || updated.Spec.PXC.Size != 3 || updated.Spec.HAProxy.Size != 3 {
+		t.Fatalf("unexpected cluster spec: pause=%t pxc=%d haproxy=%d", updated.Spec.Pause, updated.Spec.PXC.Size, updated.Spec.HAProxy.Size)
+	}
+
+	updated.Status.ObservedGeneration = updated.Generation
+	updated.Status.PXC.Status = api.AppStateReady
+	if err := cl.Update(ctx, updated); err != nil {
+		t.Fatal(err)
+	}
+
+	ready, err = r.updateCluster(ctx, cluster, restoreSizes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ready {
+		t.Fatal("expected cluster to be ready")
+	}
+}
+
+func TestStateTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	const namespace = "namespace"
+
+	tests := map[string]struct {
+		startedAt time.Duration
+		state     api.BcpRestoreStates
+	}{
+		"starting cluster":       {startedAt: time.Minute, state: api.RestoreStartCluster},
+		"cluster doesn't start":  {startedAt: 3 * time.Hour, state: api.RestoreFailed},
+		"unknown state duration": {state: api.RestoreStartCluster},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cluster := readDefaultCR(t, "cluster1", namespace)
+			cluster.Spec.Pause = true
+			cluster.Spec.PXC.LivenessInitialDelaySeconds = nil
+
+			bcp := readDefaultBackup(t, "backup1", namespace)
+			bcp.Status.State = api.BackupSucceeded
+
+			cr := readDefaultRestore(t, "restore1", namespace)
+			cr.Spec.BackupName = bcp.Name
+			cr.Status.State = api.RestoreStartCluster
+			if tt.startedAt > 0 {
+				cr.Status.StateStartedAt = &metav1.Time{Time: time.Now().Add(-tt.startedAt)}
+			}
+
+			cl := buildFakeClient(cluster, bcp, cr)
+			r := reconciler(cl)
+			r.serverVersion = new(version.ServerVersion)
+
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: namespace}}); err != nil {
+				t.Fatal(err)
+			}
+
+			updated := new(api.PerconaXtraDBClusterRestore)
+			if err := cl.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: namespace}, updated); err != nil {
+				t.Fatal(err)
+			}
+			if updated.Status.State != tt.state {
+				t.Fatalf("expected state %s, got %s: %s", tt.state, updated.Status.State, updated.Status.Comments)
+			}
+			if tt.state == api.RestoreFailed && !strings.Contains(updated.Status.Comments, "exceeded wait limit") {
+				t.Errorf("unexpected comments %q", updated.Status.Comments)
+			}
+			if updated.Status.StateStartedAt == nil {
+				t.Error("expected the state start time to be set")
+			}
+		})
+	}
+}
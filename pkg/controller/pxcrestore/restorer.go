@@ -64,7 +64,7 @@ func (s *s3) Validate(ctx context.Context) error {
 		return errors.Wrap(err, "failed to create s3 client")
 	}
 
-	backupName := s.bcp.Status.Destination.BackupName() + "/"
+	backupName := s.bcp.Status.ObjectPrefixes()[0]
 	objs, err := s3cli.ListObjects(ctx, backupName)
 	if err != nil {
 		return errors.Wrap(err, "failed to list objects")
@@ -207,7 +207,7 @@ func (s *azure) Validate(ctx context.Context) error {
 		return errors.Wrap(err, "failed to create s3 client")
 	}
 
-	backupName := s.bcp.Status.Destination.BackupName() + "/"
+	backupName := s.bcp.Status.ObjectPrefixes()[0]
 	blobs, err := azurecli.ListObjects(ctx, backupName)
 	if err != nil {
 		return errors.Wrap(err, "list blobs")
@@ -244,6 +244,12 @@ func (r *ReconcilePerconaXtraDBClusterRestore) getRestorer(
 		sr := pvc{&s}
 		return &sr, nil
 	case api.AwsBlobStoragePrefix:
+		if s3 := s.bcp.Status.S3; s3 != nil && len(s3.Alternates) > 0 {
+			s.bcp, err = r.availableS3Backup(ctx, cr, bcp)
+			if err != nil {
+				return nil, errors.Wrap(err, "select S3 endpoint")
+			}
+		}
 		sr := s3{&s}
 		return &sr, nil
 	case api.AzureBlobStoragePrefix:
@@ -0,0 +1,173 @@
+package pxcrestore
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+)
+
+// retrievalPollInterval is how often the archived objects are checked while the restore awaits their retrieval.
+const retrievalPollInterval = time.Minute
+
+// retrievalTimes are the typical times the retrieval of archived objects takes per storage class and tier.
+var retrievalTimes = map[string]map[api.RetrievalTier]time.Duration{
+	"GLACIER": {
+		api.RetrievalTierExpedited: 5 * time.Minute,
+		api.RetrievalTierStandard:  5 * time.Hour,
+		api.RetrievalTierBulk:      12 * time.Hour,
+	},
+	"DEEP_ARCHIVE": {
+		api.RetrievalTierStandard: 12 * time.Hour,
+		api.RetrievalTierBulk:     48 * time.Hour,
+	},
+}
+
+// retrievalTier returns the tier the objects of the storage class are retrieved with.
+// DEEP_ARCHIVE objects can't be retrieved with the Expedited tier, Standard is used instead.
+func retrievalTier(storageClass string, tier api.RetrievalTier) api.RetrievalTier {
+	if _, ok := retrievalTimes[storageClass][tier]; !ok {
+		return api.RetrievalTierStandard
+	}
+	return tier
+}
+
+// retrieveArchived requests the retrieval of the archived objects of the backup and reports
+// the retrieval in the restore status. It returns true once all the objects can be read.
+func (r *ReconcilePerconaXtraDBClusterRestore) retrieveArchived(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClusterBackup, cluster *api.PerconaXtraDBCluster) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	restorer, err := r.getRestorer(ctx, cr, bcp, cluster)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get restorer")
+	}
+	s3r, ok := restorer.(*s3)
+	if !ok {
+		return true, nil
+	}
+
+	opts, err := storage.GetOptionsFromBackup(ctx, r.client, cluster, s3r.bcp)
+	if err != nil {
+		return false, errors.Wrap(err, "get storage options")
+	}
+	cli, err := r.newStorageClientFunc(ctx, opts)
+	if err != nil {
+		return false, errors.Wrap(err, "new storage client")
+	}
+	stg, ok := cli.(storage.ArchiveStorage)
+	if !ok {
+		return true, nil
+	}
+
+	objects, err := backupObjects(ctx, cli, &s3r.bcp.Status)
+	if err != nil {
+		return false, err
+	}
+
+	tier, days := api.RetrievalTierStandard, int32(1)
+	if spec := cr.Spec.Retrieval; spec != nil {
+		tier, days = spec.Tier, spec.Days
+	}
+
+	status := cr.Status.Retrieval
+	if status == nil {
+		status = new(api.RestoreRetrievalStatus)
+	}
+	status.Objects, status.Pending = 0, 0
+
+	now := time.Now()
+	var requested int
+	var longest time.Duration
+	for _, name := range objects {
+		state, err := stg.ArchiveState(ctx, name)
+		if err != nil {
+			if errors.Is(err, storage.ErrObjectNotFound) {
+				continue
+			}
+			return false, errors.Wrapf(err, "get archive state of %s", name)
+		}
+		if !state.Archived {
+			continue
+		}
+		status.Objects++
+		if state.Retrieved {
+			continue
+		}
+		status.Pending++
+
+		t := retrievalTier(state.StorageClass, tier)
+		if d := retrievalTimes[state.StorageClass][t]; d > longest {
+			longest = d
+		}
+		if state.Retrieving {
+			continue
+		}
+		if err := stg.RetrieveObject(ctx, name, string(t), int(days)); err != nil {
+			return false, errors.Wrapf(err, "retrieve %s", name)
+		}
+		requested++
+	}
+
+	if status.Objects == 0 {
+		cr.Status.Retrieval = nil
+		return true, nil
+	}
+
+	if status.StartedAt == nil {
+		status.StartedAt = &metav1.Time{Time: now}
+	}
+	if status.Pending > 0 {
+		status.ETA = &metav1.Time{Time: status.StartedAt.Add(longest)}
+	} else {
+		status.ETA = nil
+	}
+	cr.Status.Retrieval = status
+
+	if requested > 0 {
+		log.Info("Requested the retrieval of archived backup objects", "objects", requested, "tier", tier, "eta", status.ETA)
+		r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventBackupRetrievalRequested,
+			"Requested the retrieval of %d archived objects of backup %s, expected by %s", requested, s3r.bcp.Status.Destination, status.ETA.Format(time.RFC3339))
+	}
+
+	return status.Pending == 0, nil
+}
+
+// awaitRetrieval moves the restore on once the archived objects of the backup are retrieved.
+func (r *ReconcilePerconaXtraDBClusterRestore) awaitRetrieval(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClusterBackup, cluster *api.PerconaXtraDBCluster) (api.BcpRestoreStates, error) {
+	log := logf.FromContext(ctx)
+
+	retrieved, err := r.retrieveArchived(ctx, cr, bcp, cluster)
+	if err != nil {
+		return "", err
+	}
+	if !retrieved {
+		return api.RestoreAwaitingRetrieval, nil
+	}
+
+	log.Info("archived backup objects are retrieved", "backup", bcp.Status.Destination)
+	return api.RestoreStarting, nil
+}
+
+// backupObjects returns the names of all the objects of the backup, including its SST info and checksums.
+func backupObjects(ctx context.Context, stg storage.Storage, status *api.PXCBackupStatus) ([]string, error) {
+	var objects []string
+	for _, prefix := range status.ObjectPrefixes() {
+		list, err := stg.ListObjects(ctx, prefix)
+		if err != nil {
+			return nil, errors.Wrapf(err, "list objects %s", prefix)
+		}
+		objects = append(objects, list...)
+	}
+	if status.Layout.GetFormat() != api.BackupFormatOperator {
+		return objects, nil
+	}
+	backupName := status.Destination.BackupName()
+	return append(objects, backupName+".md5", backupName+".sst_info.md5"), nil
+}
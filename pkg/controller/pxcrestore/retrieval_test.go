@@ -0,0 +1,117 @@
+package pxcrestore
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+	fakestorage "github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage/fake"
+)
+
+type archiveStorage struct {
+	fakestorage.FakeStorageClient
+	states    map[string]storage.ArchiveState
+	retrieved map[string]string
+}
+
+func (s *archiveStorage) ListObjects(_ context.Context, prefix string) ([]string, error) {
+	var list []string
+	for name := range s.states {
+		if strings.HasPrefix(name, prefix) {
+			list = append(list, name)
+		}
+	}
+	return list, nil
+}
+
+func (s *archiveStorage) ArchiveState(_ context.Context, name string) (storage.ArchiveState, error) {
+	state, ok := s.states[name]
+	if !ok {
+		return storage.ArchiveState{}, storage.ErrObjectNotFound
+	}
+	return state, nil
+}
+
+func (s *archiveStorage) RetrieveObject(_ context.Context, name string, tier string, days int) error {
+	s.retrieved[name] = tier
+	state := s.states[name]
+	state.Retrieving = true
+	s.states[name] = state
+	return nil
+}
+
+func TestRetrieveArchived(t *testing.T) {
+	ctx := context.Background()
+
+	const namespace = "namespace"
+	cluster := readDefaultCR(t, "test-cluster", namespace)
+	cluster.Spec.InitContainer.Image = "init-image"
+	bcp := readDefaultBackup(t, "backup1", namespace)
+	bcp.Status.Destination.SetS3Destination("some-bucket", "test-cluster-2026-10-01-03:00:00-full")
+	bcp.Status.S3 = &api.BackupStorageS3Spec{Bucket: "some-bucket", CredentialsSecret: "s3-secret"}
+	bcp.Status.State = api.BackupSucceeded
+	cr := readDefaultRestore(t, "restore1", namespace)
+	cr.Spec.BackupName = bcp.Name
+	cr.Spec.Retrieval = &api.RestoreRetrieval{Tier: api.RetrievalTierExpedited, Days: 2}
+
+	const name = "test-cluster-2026-10-01-03:00:00-full"
+	stg := &archiveStorage{
+		states: map[string]storage.ArchiveState{
+			name + "/xtrabackup.stream.00000000000000000000": {StorageClass: "GLACIER", Archived: true},
+			name + "/xtrabackup.stream.00000000000000000001": {StorageClass: "DEEP_ARCHIVE", Archived: true},
+			name + ".sst_info/sst_info.00000000000000000000": {StorageClass: "GLACIER", Archived: true, Retrieved: true},
+			name + ".md5": {StorageClass: "STANDARD"},
+		},
+		retrieved: make(map[string]string),
+	}
+
+	r := reconciler(buildFakeClient(cluster, bcp))
+	r.newStorageClientFunc = func(context.Context, storage.Options) (storage.Storage, error) {
+		return stg, nil
+	}
+
+	retrieved, err := r.retrieveArchived(ctx, cr, bcp, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if retrieved {
+		t.Fatal("expected the archived objects to be retrieved first")
+	}
+	status := cr.Status.Retrieval
+	if status == nil || status.Objects != 3 || status.Pending != 2 || status.StartedAt == nil {
+		t.Fatalf("unexpected retrieval status: %+v", status)
+	}
+	if eta := status.ETA.Sub(status.StartedAt.Time); eta != 12*time.Hour {
+		t.Errorf("expected the ETA of the DEEP_ARCHIVE object, got %s", eta)
+	}
+	if len(stg.retrieved) != 2 ||
+		stg.retrieved[name+"/xtrabackup.stream.00000000000000000000"] != "Expedited" ||
+		stg.retrieved[name+"/xtrabackup.stream.00000000000000000001"] != "Standard" {
+		t.Errorf("unexpected retrieval requests: %v", stg.retrieved)
+	}
+
+	// requests aren't repeated while the objects are retrieved
+	stg.retrieved = make(map[string]string)
+	if retrieved, err := r.retrieveArchived(ctx, cr, bcp, cluster); err != nil || retrieved {
+		t.Fatalf("unexpected result: %v %v", retrieved, err)
+	}
+	if len(stg.retrieved) != 0 {
+		t.Errorf("unexpected retrieval requests: %v", stg.retrieved)
+	}
+
+	for n, state := range stg.states {
+		if state.Archived {
+			state.Retrieving, state.Retrieved = false, true
+			stg.states[n] = state
+		}
+	}
+	if retrieved, err := r.retrieveArchived(ctx, cr, bcp, cluster); err != nil || !retrieved {
+		t.Fatalf("expected the objects to be retrieved: %v %v", retrieved, err)
+	}
+	if status := cr.Status.Retrieval; status.Pending != 0 || status.ETA != nil {
+		t.Errorf("unexpected retrieval status: %+v", status)
+	}
+}
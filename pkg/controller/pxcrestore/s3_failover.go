@@ -0,0 +1,48 @@
+package pxcrestore
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/backup/storage"
+)
+
+// availableS3Backup returns a copy of the backup stored on the first available endpoint of its S3 storage.
+// The alternate endpoints are expected to keep the replicas of the backup under the same names.
+func (r *ReconcilePerconaXtraDBClusterRestore) availableS3Backup(ctx context.Context, cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClusterBackup) (*api.PerconaXtraDBClusterBackup, error) {
+	log := logf.FromContext(ctx)
+
+	opts, err := storage.GetOptionsFromBackup(ctx, r.client, nil, bcp)
+	if err != nil {
+		return nil, errors.Wrap(err, "get storage options")
+	}
+	s3Opts, ok := opts.(*storage.S3Options)
+	if !ok {
+		return nil, errors.New("invalid options type")
+	}
+
+	i, err := storage.AvailableS3Endpoint(ctx, s3Opts, r.newStorageClientFunc)
+	if err != nil {
+		return nil, err
+	}
+	if i == 0 {
+		return bcp, nil
+	}
+
+	bcp = bcp.DeepCopy()
+	s3 := bcp.Status.S3.Failover(i)
+	backupName := bcp.Status.Destination.BackupName()
+	bcp.Status.S3 = s3
+	bcp.Status.Destination.SetS3Destination(s3.Bucket, backupName)
+
+	log.Info("Main S3 endpoint of the backup is unavailable, using the alternate one", "endpoint", s3.EndpointURL, "destination", bcp.Status.Destination)
+	r.recorder.Eventf(cr, corev1.EventTypeWarning, naming.EventS3EndpointFailover,
+		"Main endpoint of backup %s is unavailable, restoring from %s of endpoint %s", bcp.Name, bcp.Status.Destination, s3.EndpointURL)
+
+	return bcp, nil
+}
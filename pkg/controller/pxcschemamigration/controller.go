@@ -0,0 +1,464 @@
+package pxcschemamigration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/percona/percona-xtradb-cluster-operator/clientcmd"
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+// Add creates a new PerconaXtraDBClusterSchemaMigration Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	r, err := newReconciler(mgr)
+	if err != nil {
+		return err
+	}
+
+	return add(mgr, r)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
+	cli, err := clientcmd.NewClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "create clientcmd")
+	}
+
+	return &ReconcilePerconaXtraDBClusterSchemaMigration{
+		client:    mgr.GetClient(),
+		scheme:    mgr.GetScheme(),
+		clientcmd: cli,
+		recorder:  mgr.GetEventRecorderFor("pxcschemamigration-controller"),
+	}, nil
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	rateLimiter, err := k8s.NewRateLimiter()
+	if err != nil {
+		return err
+	}
+
+	b := builder.ControllerManagedBy(mgr).
+		Named("pxcschemamigration-controller").
+		WithOptions(controller.Options{RateLimiter: rateLimiter}).
+		// flow control is sampled by requeues
+		For(&api.PerconaXtraDBClusterSchemaMigration{}, builder.WithPredicates(
+			predicate.GenerationChangedPredicate{},
+			k8s.NamespaceSelectorPredicate(mgr.GetClient()),
+			k8s.ClusterSelectorPredicate(mgr.GetClient(), func(obj client.Object) types.NamespacedName {
+				return types.NamespacedName{Name: obj.(*api.PerconaXtraDBClusterSchemaMigration).Spec.PXCCluster, Namespace: obj.GetNamespace()}
+			}),
+		)).
+		Owns(&batchv1.Job{})
+
+	return k8s.WatchNamespaces(b, mgr.GetClient(), func() client.ObjectList { return new(api.PerconaXtraDBClusterSchemaMigrationList) }).
+		Complete(k8s.DetachReconciler(metrics.InstrumentReconciler("pxcschemamigration-controller", r)))
+}
+
+var _ reconcile.Reconciler = &ReconcilePerconaXtraDBClusterSchemaMigration{}
+
+// ReconcilePerconaXtraDBClusterSchemaMigration reconciles a PerconaXtraDBClusterSchemaMigration object
+type ReconcilePerconaXtraDBClusterSchemaMigration struct {
+	client    client.Client
+	scheme    *runtime.Scheme
+	clientcmd *clientcmd.Client
+	recorder  record.EventRecorder
+}
+
+// Reconcile runs the online schema change tool against pod 0 of the cluster, so the row copy
+// and the triggers don't conflict with each other on certification. The tool is throttled
+// and the migration is aborted by the flow control pressure sampled on every reconcile.
+func (r *ReconcilePerconaXtraDBClusterSchemaMigration) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logf.FromContext(ctx)
+
+	rr := reconcile.Result{}
+
+	cr := new(api.PerconaXtraDBClusterSchemaMigration)
+	err := r.client.Get(ctx, request.NamespacedName, cr)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return rr, nil
+		}
+		return rr, err
+	}
+
+	if cr.DeletionTimestamp != nil {
+		return rr, r.cleanup(ctx, cr)
+	}
+
+	if finished(cr.Status.State) {
+		return rr, nil
+	}
+
+	if err := cr.CheckNSetDefaults(); err != nil {
+		return rr, r.setStatus(ctx, cr, api.SchemaMigrationStateFailed, err.Error())
+	}
+
+	cluster := new(api.PerconaXtraDBCluster)
+	err = r.client.Get(ctx, types.NamespacedName{Name: cr.Spec.PXCCluster, Namespace: cr.Namespace}, cluster)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return rr, errors.Wrapf(err, "get cluster %s", cr.Spec.PXCCluster)
+	}
+	if k8serrors.IsNotFound(err) && cr.Status.State != api.SchemaMigrationStateRunning {
+		msg := fmt.Sprintf("cluster %s is not found", cr.Spec.PXCCluster)
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, r.setStatus(ctx, cr, api.SchemaMigrationStatePending, msg)
+	}
+
+	var state api.SchemaMigrationState
+	var msg string
+	switch cr.Status.State {
+	case api.SchemaMigrationStateNew, api.SchemaMigrationStatePending:
+		state, msg, err = r.start(ctx, cr, cluster)
+	case api.SchemaMigrationStateRunning:
+		state, msg, err = r.check(ctx, cr, cluster)
+	}
+	if err != nil {
+		// the request is requeued with backoff
+		if serr := r.setStatus(ctx, cr, cr.Status.State, err.Error()); serr != nil {
+			log.Error(serr, "failed to set status", "state", cr.Status.State)
+		}
+		return rr, errors.Wrapf(err, "reconcile schema migration in state %s", cr.Status.State)
+	}
+
+	if err := r.setStatus(ctx, cr, state, msg); err != nil {
+		return rr, err
+	}
+
+	if finished(state) {
+		return rr, nil
+	}
+	return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+}
+
+// start creates the user running the tool and its job once the cluster is ready for the migration.
+func (r *ReconcilePerconaXtraDBClusterSchemaMigration) start(ctx context.Context, cr *api.PerconaXtraDBClusterSchemaMigration, cluster *api.PerconaXtraDBCluster) (api.SchemaMigrationState, string, error) {
+	log := logf.FromContext(ctx)
+
+	if cluster.Status.Status != api.AppStateReady {
+		return api.SchemaMigrationStatePending, fmt.Sprintf("waiting for cluster %s to be ready", cluster.Name), nil
+	}
+
+	// the tools can't alter tables consistently while a rolling schema upgrade is in progress
+	osu, err := r.osuMethod(ctx, cluster)
+	if err != nil {
+		return "", "", errors.Wrap(err, "get wsrep_OSU_method")
+	}
+	if !strings.EqualFold(osu, "TOI") {
+		return api.SchemaMigrationStatePending, fmt.Sprintf("waiting for wsrep_OSU_method to be TOI, it's %s", osu), nil
+	}
+
+	if !controllerutil.ContainsFinalizer(cr, naming.FinalizerDeleteMigrationUser) {
+		controllerutil.AddFinalizer(cr, naming.FinalizerDeleteMigrationUser)
+		if err := r.updateFinalizers(ctx, cr); err != nil {
+			return "", "", errors.Wrap(err, "add finalizer")
+		}
+	}
+
+	secret, err := r.credentialsSecret(ctx, cr, cluster)
+	if err != nil {
+		return "", "", errors.Wrap(err, "reconcile credentials secret")
+	}
+
+	um, err := pxc.GetUsersManager(ctx, r.client, cluster)
+	if err != nil {
+		return "", "", err
+	}
+	defer um.Close()
+
+	user, pass := string(secret.Data["user"]), string(secret.Data["password"])
+	if err := um.UpsertScriptUser(ctx, user, "%", pass, cr.Spec.Database, []string{"ALL PRIVILEGES"}); err != nil {
+		return "", "", err
+	}
+	// the tools check the processlist and gh-ost reads the binary log
+	if err := um.UpsertScriptUser(ctx, user, "%", pass, "", []string{"PROCESS", "REPLICATION CLIENT", "REPLICATION SLAVE"}); err != nil {
+		return "", "", err
+	}
+
+	job := migrationJob(cr, cluster)
+	if err := controllerutil.SetControllerReference(cr, job, r.scheme); err != nil {
+		return "", "", errors.Wrap(err, "set controller reference")
+	}
+	if err := r.client.Create(ctx, job); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return "", "", errors.Wrap(err, "create job")
+	}
+
+	log.Info("Schema migration is started", "job", job.Name, "tool", cr.Spec.Tool, "table", cr.Spec.Database+"."+cr.Spec.Table)
+
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	cr.Status.Job = job.Name
+	cr.Status.StartedAt = &now
+
+	return api.SchemaMigrationStateRunning, fmt.Sprintf("table is migrated by job %s", job.Name), nil
+}
+
+// check follows the job, throttles or aborts it by the flow control pressure
+// and drops the user once the job is finished.
+func (r *ReconcilePerconaXtraDBClusterSchemaMigration) check(ctx context.Context, cr *api.PerconaXtraDBClusterSchemaMigration, cluster *api.PerconaXtraDBCluster) (api.SchemaMigrationState, string, error) {
+	log := logf.FromContext(ctx)
+
+	job := new(batchv1.Job)
+	err := r.client.Get(ctx, types.NamespacedName{Name: cr.Status.Job, Namespace: cr.Namespace}, job)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return "", "", errors.Wrapf(err, "get job %s", cr.Status.Job)
+	}
+	if k8serrors.IsNotFound(err) {
+		return api.SchemaMigrationStateFailed, fmt.Sprintf("job %s is deleted", cr.Status.Job), r.cleanup(ctx, cr)
+	}
+
+	pod, err := r.jobPod(ctx, job)
+	if err != nil {
+		log.Error(err, "failed to get job pod", "job", job.Name)
+	}
+	if pod != nil {
+		progress, err := r.progress(pod)
+		if err != nil {
+			log.Error(err, "failed to get progress", "job", job.Name)
+		} else if progress != "" {
+			cr.Status.Progress = progress
+		}
+	}
+
+	state, msg := jobResult(job)
+	if finished(state) {
+		return state, msg, r.cleanup(ctx, cr)
+	}
+
+	// the cluster is deleted, the job fails once it loses the connection
+	if cluster.Name == "" {
+		return state, msg, nil
+	}
+
+	paused, err := r.sampleFlowControl(ctx, cr, cluster)
+	if err != nil {
+		log.Error(err, "failed to sample flow control", "cluster", cluster.Name)
+		return state, msg, nil
+	}
+	if paused < 0 {
+		return state, msg, nil
+	}
+
+	fc := cr.Spec.FlowControl
+	if err := r.throttle(cr, pod, paused >= fc.ThrottlePercent); err != nil {
+		log.Error(err, "failed to throttle", "job", job.Name)
+	}
+
+	if fc.AbortPercent == 0 || paused < fc.AbortPercent {
+		cr.Status.OverloadedSince = nil
+		return state, msg, nil
+	}
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	if cr.Status.OverloadedSince == nil {
+		cr.Status.OverloadedSince = &now
+	}
+	overloaded := now.Sub(cr.Status.OverloadedSince.Time)
+	if overloaded < time.Duration(fc.AbortAfterSeconds)*time.Second {
+		return state, msg, nil
+	}
+
+	log.Info("Aborting schema migration, the cluster is paused by flow control", "job", job.Name, "pausedPercent", paused)
+	err = r.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationForeground))
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return "", "", errors.Wrapf(err, "delete job %s", job.Name)
+	}
+
+	msg = fmt.Sprintf("the cluster was paused by flow control %d%% of time for %s", paused, overloaded)
+	return api.SchemaMigrationStateAborted, msg, r.cleanup(ctx, cr)
+}
+
+// jobResult returns the state of the migration by the conditions of the job.
+func jobResult(job *batchv1.Job) (api.SchemaMigrationState, string) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return api.SchemaMigrationStateSucceeded, "table is migrated"
+		case batchv1.JobFailed:
+			return api.SchemaMigrationStateFailed, fmt.Sprintf("job %s failed: %s", job.Name, cond.Message)
+		}
+	}
+
+	return api.SchemaMigrationStateRunning, fmt.Sprintf("table is migrated by job %s", job.Name)
+}
+
+func finished(state api.SchemaMigrationState) bool {
+	switch state {
+	case api.SchemaMigrationStateSucceeded, api.SchemaMigrationStateFailed, api.SchemaMigrationStateAborted:
+		return true
+	}
+	return false
+}
+
+// cleanup drops the user running the tool, deletes its credentials and removes the finalizer.
+func (r *ReconcilePerconaXtraDBClusterSchemaMigration) cleanup(ctx context.Context, cr *api.PerconaXtraDBClusterSchemaMigration) error {
+	log := logf.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(cr, naming.FinalizerDeleteMigrationUser) {
+		return nil
+	}
+
+	cluster := new(api.PerconaXtraDBCluster)
+	err := r.client.Get(ctx, types.NamespacedName{Name: cr.Spec.PXCCluster, Namespace: cr.Namespace}, cluster)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrapf(err, "get cluster %s", cr.Spec.PXCCluster)
+	}
+
+	// if the cluster is gone there is nothing to drop
+	if err == nil {
+		if cluster.Status.Status != api.AppStateReady {
+			return errors.Errorf("cluster %s is not ready, can't drop user %s", cluster.Name, migrationUser(cr))
+		}
+
+		um, err := pxc.GetUsersManager(ctx, r.client, cluster)
+		if err != nil {
+			return err
+		}
+		defer um.Close()
+
+		if err := um.DropUser(ctx, migrationUser(cr), []string{"%"}); err != nil {
+			return err
+		}
+		log.Info("Schema migration user is dropped", "user", migrationUser(cr))
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      credentialsSecretName(cr),
+			Namespace: cr.Namespace,
+		},
+	}
+	if err := r.client.Delete(ctx, secret); err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "delete credentials secret")
+	}
+
+	controllerutil.RemoveFinalizer(cr, naming.FinalizerDeleteMigrationUser)
+	if err := r.updateFinalizers(ctx, cr); err != nil {
+		return errors.Wrap(err, "remove finalizer")
+	}
+
+	return nil
+}
+
+// updateFinalizers updates the object keeping the status collected by the reconcile,
+// the update returns the stored one.
+func (r *ReconcilePerconaXtraDBClusterSchemaMigration) updateFinalizers(ctx context.Context, cr *api.PerconaXtraDBClusterSchemaMigration) error {
+	status := cr.Status
+	if err := r.client.Update(ctx, cr); err != nil {
+		return err
+	}
+	cr.Status = status
+	return nil
+}
+
+// credentialsSecret returns the secret with credentials of the user running the tool.
+func (r *ReconcilePerconaXtraDBClusterSchemaMigration) credentialsSecret(ctx context.Context, cr *api.PerconaXtraDBClusterSchemaMigration, cluster *api.PerconaXtraDBCluster) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      credentialsSecretName(cr),
+			Namespace: cr.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.client, secret, func() error {
+		if err := controllerutil.SetControllerReference(cr, secret, r.scheme); err != nil {
+			return err
+		}
+		secret.Labels = naming.LabelsCluster(cluster)
+		secret.Type = corev1.SecretTypeOpaque
+
+		if len(secret.Data["password"]) > 0 {
+			return nil
+		}
+		pass, err := users.GenerateDSNSafePass(cluster.Spec.PXC.PasswordPolicy)
+		if err != nil {
+			return errors.Wrap(err, "generate password")
+		}
+		secret.Data = map[string][]byte{
+			"user":     []byte(migrationUser(cr)),
+			"password": pass,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+func (r *ReconcilePerconaXtraDBClusterSchemaMigration) setStatus(ctx context.Context, cr *api.PerconaXtraDBClusterSchemaMigration, state api.SchemaMigrationState, msg string) error {
+	if state != cr.Status.State {
+		eventType := corev1.EventTypeNormal
+		if state == api.SchemaMigrationStateFailed || state == api.SchemaMigrationStateAborted {
+			eventType = corev1.EventTypeWarning
+		}
+		r.recorder.Eventf(cr, eventType, naming.EventSchemaMigrationStateChanged, "Schema migration state changed to %s: %s", state, msg)
+	}
+
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	status := cr.Status
+	err := k8s.PatchStatus(ctx, r.client, cr, func(c *api.PerconaXtraDBClusterSchemaMigration) {
+		if finished(state) && c.Status.CompletedAt == nil {
+			c.Status.CompletedAt = &now
+		}
+
+		c.Status.Job = status.Job
+		c.Status.Progress = status.Progress
+		c.Status.Throttled = status.Throttled
+		c.Status.FlowControlPausedPercent = status.FlowControlPausedPercent
+		c.Status.FlowControlPausedNS = status.FlowControlPausedNS
+		c.Status.FlowControlSampledAt = status.FlowControlSampledAt
+		c.Status.OverloadedSince = status.OverloadedSince
+		c.Status.StartedAt = status.StartedAt
+		c.Status.State = state
+		c.Status.Message = msg
+		c.Status.ObservedGeneration = c.Generation
+	})
+	if err != nil {
+		return errors.Wrap(err, "update status")
+	}
+
+	return nil
+}
+
+// migrationUser returns the name of the user running the tool.
+// It's derived from the object UID to be unique among all migrations of the cluster.
+func migrationUser(cr *api.PerconaXtraDBClusterSchemaMigration) string {
+	uid := strings.ReplaceAll(string(cr.UID), "-", "")
+	if len(uid) > 16 {
+		uid = uid[:16]
+	}
+	return "migration_" + uid
+}
+
+func credentialsSecretName(cr *api.PerconaXtraDBClusterSchemaMigration) string {
+	return "migration-" + cr.Name + "-credentials"
+}
@@ -0,0 +1,257 @@
+package pxcschemamigration
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+const (
+	containerName = "migration"
+	// ghostThrottleFile throttles gh-ost while it exists.
+	ghostThrottleFile = "/tmp/gh-ost.throttle"
+)
+
+// progressRe matches the progress lines of pt-online-schema-change
+// ("Copying `db`.`t`:  45% 01:23 remain") and gh-ost ("Copy: 1000/2000 50.0%;").
+var progressRe = regexp.MustCompile(`(?:Copying \S+:|Copy: \S+)\s+(\d+(?:\.\d+)?%)`)
+
+func migrationJob(cr *api.PerconaXtraDBClusterSchemaMigration, cluster *api.PerconaXtraDBCluster) *batchv1.Job {
+	name := "migration-" + cr.Name
+
+	ls := naming.LabelsCluster(cluster)
+	ls["job-name"] = name
+
+	manualSelector := true
+	// a failed migration is cleaned up by the tool and has to be checked before it's started again
+	backoffLimit := int32(0)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    ls,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: cr.Spec.ActiveDeadlineSeconds,
+			ManualSelector:        &manualSelector,
+			Selector:              &metav1.LabelSelector{MatchLabels: ls},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: ls},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					SecurityContext:  cluster.Spec.PXC.PodSecurityContext,
+					ImagePullSecrets: cluster.Spec.PXC.ImagePullSecrets,
+					Containers: []corev1.Container{
+						{
+							Name:            containerName,
+							Image:           cr.Spec.Image,
+							ImagePullPolicy: cluster.Spec.PXC.ImagePullPolicy,
+							SecurityContext: cluster.Spec.PXC.ContainerSecurityContext,
+							Command:         []string{string(cr.Spec.Tool)},
+							Args:            toolArgs(cr),
+							Env: []corev1.EnvVar{
+								{
+									Name:  "PXC_HOST",
+									Value: migrationHost(cluster),
+								},
+								{
+									Name: "MIGRATION_USER",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: app.SecretKeySelector(credentialsSecretName(cr), "user"),
+									},
+								},
+								{
+									Name: "MIGRATION_PASS",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: app.SecretKeySelector(credentialsSecretName(cr), "password"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	cluster.ApplyImageOverrides(&job.Spec.Template.Spec)
+
+	return job
+}
+
+// toolArgs returns the tool arguments with settings required by Galera.
+func toolArgs(cr *api.PerconaXtraDBClusterSchemaMigration) []string {
+	spec := cr.Spec
+
+	var args []string
+	switch spec.Tool {
+	case api.SchemaMigrationToolGhost:
+		args = []string{
+			"--host=$(PXC_HOST)",
+			"--port=3306",
+			"--user=$(MIGRATION_USER)",
+			"--password=$(MIGRATION_PASS)",
+			"--database=" + spec.Database,
+			"--table=" + spec.Table,
+			"--alter=" + spec.Alter,
+			// PXC nodes are sources, the binary log of the node is read
+			"--allow-on-master",
+			"--assume-rbr",
+			// the atomic cut-over relies on LOCK TABLES which isn't replicated by Galera
+			"--cut-over=two-step",
+			"--throttle-additional-flag-file=" + ghostThrottleFile,
+			"--verbose",
+			"--execute",
+		}
+	default:
+		args = []string{
+			"h=$(PXC_HOST),P=3306,u=$(MIGRATION_USER),p=$(MIGRATION_PASS),D=" + spec.Database + ",t=" + spec.Table,
+			"--alter=" + spec.Alter,
+			// the triggers and the table swap have to be replicated to all nodes
+			"--set-vars=wsrep_OSU_method=TOI",
+			"--max-flow-ctl=" + strconv.Itoa(int(spec.FlowControl.ThrottlePercent)),
+			// PXC nodes aren't async replicas, flow control is used instead of the replica lag
+			"--recursion-method=none",
+			"--progress=time,10",
+			"--no-version-check",
+			"--execute",
+		}
+	}
+
+	return append(args, spec.Args...)
+}
+
+// migrationHost is the PXC node the tool connects to, all writes of the migration go to it.
+func migrationHost(cluster *api.PerconaXtraDBCluster) string {
+	return cluster.Name + "-pxc-0." + cluster.Name + "-pxc." + cluster.Namespace
+}
+
+func (r *ReconcilePerconaXtraDBClusterSchemaMigration) osuMethod(ctx context.Context, cluster *api.PerconaXtraDBCluster) (string, error) {
+	db, err := queries.New(r.client, cluster.Namespace, users.InternalSecretsPrefix+cluster.Name, users.Monitor, migrationHost(cluster), users.MySQLAdminPort, cluster.Spec.PXC.ReadinessProbes.TimeoutSeconds)
+	if err != nil {
+		return "", errors.Wrap(err, "connect to pxc")
+	}
+	defer db.Close()
+
+	return db.ReadVariable("wsrep_OSU_method")
+}
+
+// sampleFlowControl returns the percent of time the node the tool writes to was paused by flow control
+// since the previous sample, or -1 if there is no previous sample.
+func (r *ReconcilePerconaXtraDBClusterSchemaMigration) sampleFlowControl(ctx context.Context, cr *api.PerconaXtraDBClusterSchemaMigration, cluster *api.PerconaXtraDBCluster) (int32, error) {
+	db, err := queries.New(r.client, cluster.Namespace, users.InternalSecretsPrefix+cluster.Name, users.Monitor, migrationHost(cluster), users.MySQLAdminPort, cluster.Spec.PXC.ReadinessProbes.TimeoutSeconds)
+	if err != nil {
+		return -1, errors.Wrap(err, "connect to pxc")
+	}
+	defer db.Close()
+
+	status, err := db.WsrepStatus(ctx)
+	if err != nil {
+		return -1, err
+	}
+	pausedNS, err := strconv.ParseInt(status["flow_control_paused_ns"], 10, 64)
+	if err != nil {
+		return -1, errors.Wrap(err, "parse wsrep_flow_control_paused_ns")
+	}
+
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	paused := int32(-1)
+	if at := cr.Status.FlowControlSampledAt; at != nil {
+		paused = flowControlPausedPercent(cr.Status.FlowControlPausedNS, pausedNS, now.Sub(at.Time))
+	}
+	if paused >= 0 {
+		cr.Status.FlowControlPausedPercent = paused
+	}
+	cr.Status.FlowControlPausedNS = pausedNS
+	cr.Status.FlowControlSampledAt = &now
+
+	return paused, nil
+}
+
+// flowControlPausedPercent returns the percent of the interval spent in flow control pauses
+// or -1 if the counter was reset by a restart of the node.
+func flowControlPausedPercent(prevNS, ns int64, interval time.Duration) int32 {
+	if ns < prevNS || interval <= 0 {
+		return -1
+	}
+	percent := (ns - prevNS) * 100 / interval.Nanoseconds()
+	return int32(min(percent, 100))
+}
+
+// throttle pauses gh-ost by its flag file, pt-online-schema-change throttles itself by --max-flow-ctl.
+func (r *ReconcilePerconaXtraDBClusterSchemaMigration) throttle(cr *api.PerconaXtraDBClusterSchemaMigration, pod *corev1.Pod, throttled bool) error {
+	if cr.Spec.Tool != api.SchemaMigrationToolGhost {
+		cr.Status.Throttled = throttled
+		return nil
+	}
+	if pod == nil || pod.Status.Phase != corev1.PodRunning || cr.Status.Throttled == throttled {
+		return nil
+	}
+
+	cmd := []string{"rm", "-f", ghostThrottleFile}
+	if throttled {
+		cmd = []string{"touch", ghostThrottleFile}
+	}
+	var outb, errb bytes.Buffer
+	if err := r.clientcmd.Exec(pod, containerName, cmd, nil, &outb, &errb, false); err != nil {
+		return errors.Wrapf(err, "exec %v: %s", cmd, errb.String())
+	}
+	cr.Status.Throttled = throttled
+
+	return nil
+}
+
+func (r *ReconcilePerconaXtraDBClusterSchemaMigration) jobPod(ctx context.Context, job *batchv1.Job) (*corev1.Pod, error) {
+	pods := new(corev1.PodList)
+	err := r.client.List(ctx, pods, &client.ListOptions{
+		Namespace:     job.Namespace,
+		LabelSelector: labels.SelectorFromSet(job.Spec.Selector.MatchLabels),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "list job pods")
+	}
+	if len(pods.Items) == 0 {
+		return nil, nil
+	}
+
+	return &pods.Items[0], nil
+}
+
+// progress returns the last progress reported by the tool.
+func (r *ReconcilePerconaXtraDBClusterSchemaMigration) progress(pod *corev1.Pod) (string, error) {
+	if pod.Status.Phase == corev1.PodPending {
+		return "", nil
+	}
+
+	tailLines := int64(50)
+	lines, err := r.clientcmd.PodLogs(pod.Namespace, pod.Name, &corev1.PodLogOptions{Container: containerName, TailLines: &tailLines})
+	if err != nil {
+		return "", errors.Wrapf(err, "get logs of %s", pod.Name)
+	}
+
+	return lastProgress(lines), nil
+}
+
+func lastProgress(lines []string) string {
+	for i := len(lines) - 1; i >= 0; i-- {
+		if m := progressRe.FindStringSubmatch(lines[i]); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package pxcschemamigration
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestToolArgs(t *testing.T) {
+	cr := &api.PerconaXtraDBClusterSchemaMigration{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "ns"},
+		Spec: api.PerconaXtraDBClusterSchemaMigrationSpec{
+			PXCCluster: "cluster1",
+			Database:   "app",
+			Table:      "orders",
+			Alter:      "ADD COLUMN status INT",
+			Image:      "percona/percona-toolkit",
+			Args:       []string{"--chunk-size=500"},
+		},
+	}
+	if err := cr.CheckNSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+
+	args := toolArgs(cr)
+	for _, arg := range []string{
+		"h=$(PXC_HOST),P=3306,u=$(MIGRATION_USER),p=$(MIGRATION_PASS),D=app,t=orders",
+		"--alter=ADD COLUMN status INT",
+		"--set-vars=wsrep_OSU_method=TOI",
+		"--max-flow-ctl=10",
+		"--execute",
+		"--chunk-size=500",
+	} {
+		if !slices.Contains(args, arg) {
+			t.Errorf("pt-online-schema-change args %v don't contain %s", args, arg)
+		}
+	}
+
+	cr.Spec.Tool = api.SchemaMigrationToolGhost
+	args = toolArgs(cr)
+	for _, arg := range []string{"--database=app", "--table=orders", "--cut-over=two-step", "--throttle-additional-flag-file=" + ghostThrottleFile} {
+		if !slices.Contains(args, arg) {
+			t.Errorf("gh-ost args %v don't contain %s", args, arg)
+		}
+	}
+}
+
+func TestFlowControlPausedPercent(t *testing.T) {
+	tests := []struct {
+		prev, cur int64
+		interval  time.Duration
+		expected  int32
+	}{
+		{0, int64(time.Second), 10 * time.Second, 10},
+		{int64(time.Second), int64(time.Second), 10 * time.Second, 0},
+		{0, int64(20 * time.Second), 10 * time.Second, 100},
+		// the node is restarted
+		{int64(time.Minute), int64(time.Second), 10 * time.Second, -1},
+	}
+	for _, tt := range tests {
+		if p := flowControlPausedPercent(tt.prev, tt.cur, tt.interval); p != tt.expected {
+			t.Errorf("flowControlPausedPercent(%d, %d, %s) = %d, expected %d", tt.prev, tt.cur, tt.interval, p, tt.expected)
+		}
+	}
+}
+
+func TestLastProgress(t *testing.T) {
+	ptosc := []string{
+		"Creating triggers...",
+		"Copying `app`.`orders`:  12% 03:10 remain",
+		"Copying `app`.`orders`:  45% 01:23 remain",
+	}
+	if p := lastProgress(ptosc); p != "45%" {
+		t.Errorf("unexpected pt-online-schema-change progress %s", p)
+	}
+
+	ghost := []string{
+		"Copy: 1000/2000 50.0%; Applied: 0; Backlog: 0/1000; Time: 10s(total), 9s(copy); streamer: binlog.000003:4521; Lag: 0.01s, State: migrating; ETA: 9s",
+		"# Migrating `app`.`orders`; Ghost table is `app`.`_orders_gho`",
+	}
+	if p := lastProgress(ghost); p != "50.0%" {
+		t.Errorf("unexpected gh-ost progress %s", p)
+	}
+}
@@ -0,0 +1,392 @@
+package pxcsqljob
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/percona/percona-xtradb-cluster-operator/clientcmd"
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+// Add creates a new PerconaXtraDBClusterSQLJob Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	r, err := newReconciler(mgr)
+	if err != nil {
+		return err
+	}
+
+	return add(mgr, r)
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) (reconcile.Reconciler, error) {
+	cli, err := clientcmd.NewClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "create clientcmd")
+	}
+
+	return &ReconcilePerconaXtraDBClusterSQLJob{
+		client:    mgr.GetClient(),
+		scheme:    mgr.GetScheme(),
+		clientcmd: cli,
+		recorder:  mgr.GetEventRecorderFor("pxcsqljob-controller"),
+	}, nil
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	rateLimiter, err := k8s.NewRateLimiter()
+	if err != nil {
+		return err
+	}
+
+	b := builder.ControllerManagedBy(mgr).
+		Named("pxcsqljob-controller").
+		WithOptions(controller.Options{RateLimiter: rateLimiter}).
+		For(&api.PerconaXtraDBClusterSQLJob{}, builder.WithPredicates(
+			predicate.GenerationChangedPredicate{},
+			k8s.NamespaceSelectorPredicate(mgr.GetClient()),
+			k8s.ClusterSelectorPredicate(mgr.GetClient(), func(obj client.Object) types.NamespacedName {
+				return types.NamespacedName{Name: obj.(*api.PerconaXtraDBClusterSQLJob).Spec.PXCCluster, Namespace: obj.GetNamespace()}
+			}),
+		)).
+		// the job is checked as soon as it's finished
+		Owns(&batchv1.Job{})
+
+	return k8s.WatchNamespaces(b, mgr.GetClient(), func() client.ObjectList { return new(api.PerconaXtraDBClusterSQLJobList) }).
+		Complete(k8s.DetachReconciler(metrics.InstrumentReconciler("pxcsqljob-controller", r)))
+}
+
+var _ reconcile.Reconciler = &ReconcilePerconaXtraDBClusterSQLJob{}
+
+// ReconcilePerconaXtraDBClusterSQLJob reconciles a PerconaXtraDBClusterSQLJob object
+type ReconcilePerconaXtraDBClusterSQLJob struct {
+	client    client.Client
+	scheme    *runtime.Scheme
+	clientcmd *clientcmd.Client
+	recorder  record.EventRecorder
+}
+
+// Reconcile runs the SQL script of the PerconaXtraDBClusterSQLJob object once.
+// The script is executed by a job as a user created for the job only,
+// the user is dropped as soon as the job is finished.
+func (r *ReconcilePerconaXtraDBClusterSQLJob) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logf.FromContext(ctx)
+
+	rr := reconcile.Result{}
+
+	cr := new(api.PerconaXtraDBClusterSQLJob)
+	err := r.client.Get(ctx, request.NamespacedName, cr)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return rr, nil
+		}
+		return rr, err
+	}
+
+	if cr.DeletionTimestamp != nil {
+		return rr, r.cleanup(ctx, cr)
+	}
+
+	if cr.Status.State == api.SQLJobStateSucceeded || cr.Status.State == api.SQLJobStateFailed {
+		return rr, nil
+	}
+
+	if err := cr.CheckNSetDefaults(); err != nil {
+		return rr, r.setStatus(ctx, cr, api.SQLJobStateFailed, err.Error())
+	}
+
+	cluster := new(api.PerconaXtraDBCluster)
+	err = r.client.Get(ctx, types.NamespacedName{Name: cr.Spec.PXCCluster, Namespace: cr.Namespace}, cluster)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return rr, errors.Wrapf(err, "get cluster %s", cr.Spec.PXCCluster)
+	}
+	if k8serrors.IsNotFound(err) && cr.Status.State != api.SQLJobStateRunning {
+		msg := fmt.Sprintf("cluster %s is not found", cr.Spec.PXCCluster)
+		return reconcile.Result{RequeueAfter: 10 * time.Second}, r.setStatus(ctx, cr, api.SQLJobStatePending, msg)
+	}
+
+	var state api.SQLJobState
+	var msg string
+	switch cr.Status.State {
+	case api.SQLJobStateNew, api.SQLJobStatePending:
+		if cluster.Status.Status != api.AppStateReady {
+			msg := fmt.Sprintf("waiting for cluster %s to be ready", cluster.Name)
+			return reconcile.Result{RequeueAfter: 10 * time.Second}, r.setStatus(ctx, cr, api.SQLJobStatePending, msg)
+		}
+		state, msg, err = r.start(ctx, cr, cluster)
+	case api.SQLJobStateRunning:
+		state, msg, err = r.check(ctx, cr)
+	}
+	if err != nil {
+		// the request is requeued with backoff
+		if serr := r.setStatus(ctx, cr, cr.Status.State, err.Error()); serr != nil {
+			log.Error(serr, "failed to set status", "state", cr.Status.State)
+		}
+		return rr, errors.Wrapf(err, "reconcile sql job in state %s", cr.Status.State)
+	}
+
+	if err := r.setStatus(ctx, cr, state, msg); err != nil {
+		return rr, err
+	}
+
+	if state == api.SQLJobStateSucceeded || state == api.SQLJobStateFailed {
+		return rr, nil
+	}
+	return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+}
+
+// start creates the user running the script and its job.
+func (r *ReconcilePerconaXtraDBClusterSQLJob) start(ctx context.Context, cr *api.PerconaXtraDBClusterSQLJob, cluster *api.PerconaXtraDBCluster) (api.SQLJobState, string, error) {
+	log := logf.FromContext(ctx)
+
+	// the user has to be dropped even if the object is deleted before the job is finished
+	if !controllerutil.ContainsFinalizer(cr, naming.FinalizerDeleteSQLJobUser) {
+		controllerutil.AddFinalizer(cr, naming.FinalizerDeleteSQLJobUser)
+		if err := r.client.Update(ctx, cr); err != nil {
+			return "", "", errors.Wrap(err, "add finalizer")
+		}
+	}
+
+	secret, err := r.credentialsSecret(ctx, cr, cluster)
+	if err != nil {
+		return "", "", errors.Wrap(err, "reconcile credentials secret")
+	}
+
+	um, err := pxc.GetUsersManager(ctx, r.client, cluster)
+	if err != nil {
+		return "", "", err
+	}
+	defer um.Close()
+
+	user, pass := string(secret.Data["user"]), string(secret.Data["password"])
+	if err := um.UpsertScriptUser(ctx, user, "%", pass, cr.Spec.Database, cr.Spec.Grants); err != nil {
+		return "", "", err
+	}
+
+	job, err := sqlJob(cr, cluster)
+	if err != nil {
+		return "", "", errors.Wrap(err, "build job")
+	}
+	if err := controllerutil.SetControllerReference(cr, job, r.scheme); err != nil {
+		return "", "", errors.Wrap(err, "set controller reference")
+	}
+	if err := r.client.Create(ctx, job); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return "", "", errors.Wrap(err, "create job")
+	}
+
+	log.Info("SQL job is started", "job", job.Name, "user", user)
+
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	cr.Status.Job = job.Name
+	cr.Status.StartedAt = &now
+
+	return api.SQLJobStateRunning, fmt.Sprintf("script is executed by job %s", job.Name), nil
+}
+
+// check waits for the job to finish, captures its output and drops the user.
+func (r *ReconcilePerconaXtraDBClusterSQLJob) check(ctx context.Context, cr *api.PerconaXtraDBClusterSQLJob) (api.SQLJobState, string, error) {
+	log := logf.FromContext(ctx)
+
+	job := new(batchv1.Job)
+	err := r.client.Get(ctx, types.NamespacedName{Name: cr.Status.Job, Namespace: cr.Namespace}, job)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return "", "", errors.Wrapf(err, "get job %s", cr.Status.Job)
+	}
+
+	state, msg, output := api.SQLJobStateFailed, fmt.Sprintf("job %s is deleted", cr.Status.Job), ""
+	if err == nil {
+		state, msg = jobResult(job)
+		if state == api.SQLJobStateRunning {
+			return state, msg, nil
+		}
+
+		output, err = r.jobOutput(ctx, job)
+		if err != nil {
+			log.Error(err, "failed to get job output", "job", job.Name)
+		}
+	}
+
+	if err := r.cleanup(ctx, cr); err != nil {
+		return "", "", err
+	}
+	// the status is overwritten by the update of the finalizers
+	cr.Status.Output = output
+
+	return state, msg, nil
+}
+
+// jobResult returns the state of the SQL job by the conditions of the job.
+func jobResult(job *batchv1.Job) (api.SQLJobState, string) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return api.SQLJobStateSucceeded, "script is executed"
+		case batchv1.JobFailed:
+			return api.SQLJobStateFailed, fmt.Sprintf("job %s failed: %s", job.Name, cond.Message)
+		}
+	}
+
+	return api.SQLJobStateRunning, fmt.Sprintf("script is executed by job %s", job.Name)
+}
+
+// cleanup drops the user running the script, deletes its credentials and removes the finalizer.
+func (r *ReconcilePerconaXtraDBClusterSQLJob) cleanup(ctx context.Context, cr *api.PerconaXtraDBClusterSQLJob) error {
+	log := logf.FromContext(ctx)
+
+	if !controllerutil.ContainsFinalizer(cr, naming.FinalizerDeleteSQLJobUser) {
+		return nil
+	}
+
+	cluster := new(api.PerconaXtraDBCluster)
+	err := r.client.Get(ctx, types.NamespacedName{Name: cr.Spec.PXCCluster, Namespace: cr.Namespace}, cluster)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrapf(err, "get cluster %s", cr.Spec.PXCCluster)
+	}
+
+	// if the cluster is gone there is nothing to drop
+	if err == nil {
+		if cluster.Status.Status != api.AppStateReady {
+			return errors.Errorf("cluster %s is not ready, can't drop user %s", cluster.Name, sqlJobUser(cr))
+		}
+
+		um, err := pxc.GetUsersManager(ctx, r.client, cluster)
+		if err != nil {
+			return err
+		}
+		defer um.Close()
+
+		if err := um.DropUser(ctx, sqlJobUser(cr), []string{"%"}); err != nil {
+			return err
+		}
+		log.Info("SQL job user is dropped", "user", sqlJobUser(cr))
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      credentialsSecretName(cr),
+			Namespace: cr.Namespace,
+		},
+	}
+	if err := r.client.Delete(ctx, secret); err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "delete credentials secret")
+	}
+
+	controllerutil.RemoveFinalizer(cr, naming.FinalizerDeleteSQLJobUser)
+	if err := r.client.Update(ctx, cr); err != nil {
+		return errors.Wrap(err, "remove finalizer")
+	}
+
+	return nil
+}
+
+// credentialsSecret returns the secret with credentials of the user running the script.
+// The password is generated once, so the user gets the same password if the job is started again.
+func (r *ReconcilePerconaXtraDBClusterSQLJob) credentialsSecret(ctx context.Context, cr *api.PerconaXtraDBClusterSQLJob, cluster *api.PerconaXtraDBCluster) (*corev1.Secret, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      credentialsSecretName(cr),
+			Namespace: cr.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.client, secret, func() error {
+		if err := controllerutil.SetControllerReference(cr, secret, r.scheme); err != nil {
+			return err
+		}
+		secret.Labels = naming.LabelsCluster(cluster)
+		secret.Type = corev1.SecretTypeOpaque
+
+		if len(secret.Data["password"]) > 0 {
+			return nil
+		}
+		pass, err := users.GenerateDSNSafePass(cluster.Spec.PXC.PasswordPolicy)
+		if err != nil {
+			return errors.Wrap(err, "generate password")
+		}
+		secret.Data = map[string][]byte{
+			"user":     []byte(sqlJobUser(cr)),
+			"password": pass,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return secret, nil
+}
+
+func (r *ReconcilePerconaXtraDBClusterSQLJob) setStatus(ctx context.Context, cr *api.PerconaXtraDBClusterSQLJob, state api.SQLJobState, msg string) error {
+	if state != cr.Status.State {
+		eventType := corev1.EventTypeNormal
+		if state == api.SQLJobStateFailed {
+			eventType = corev1.EventTypeWarning
+		}
+		r.recorder.Eventf(cr, eventType, naming.EventSQLJobStateChanged, "SQL job state changed to %s: %s", state, msg)
+	} else if cr.Status.Message == msg && cr.Status.ObservedGeneration == cr.Generation {
+		return nil
+	}
+
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	status := cr.Status
+	err := k8s.PatchStatus(ctx, r.client, cr, func(c *api.PerconaXtraDBClusterSQLJob) {
+		if (state == api.SQLJobStateSucceeded || state == api.SQLJobStateFailed) && c.Status.CompletedAt == nil {
+			c.Status.CompletedAt = &now
+		}
+
+		c.Status.Job = status.Job
+		c.Status.Output = status.Output
+		c.Status.StartedAt = status.StartedAt
+		c.Status.State = state
+		c.Status.Message = msg
+		c.Status.ObservedGeneration = c.Generation
+	})
+	if err != nil {
+		return errors.Wrap(err, "update status")
+	}
+
+	return nil
+}
+
+// sqlJobUser returns the name of the user running the script.
+// It's derived from the object UID to be unique among all jobs of the cluster.
+func sqlJobUser(cr *api.PerconaXtraDBClusterSQLJob) string {
+	uid := strings.ReplaceAll(string(cr.UID), "-", "")
+	if len(uid) > 16 {
+		uid = uid[:16]
+	}
+	return "sqljob_" + uid
+}
+
+func credentialsSecretName(cr *api.PerconaXtraDBClusterSQLJob) string {
+	return "sqljob-" + cr.Name + "-credentials"
+}
@@ -0,0 +1,177 @@
+package pxcsqljob
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
+)
+
+const (
+	containerName = "mysql"
+	scriptVolume  = "script"
+	scriptDir     = "/etc/mysql-script"
+	scriptFile    = "script.sql"
+
+	// maxOutputSize limits the output stored in the status.
+	maxOutputSize = 4096
+)
+
+func sqlJob(cr *api.PerconaXtraDBClusterSQLJob, cluster *api.PerconaXtraDBCluster) (*batchv1.Job, error) {
+	name := jobName(cr)
+
+	image := cr.Spec.Image
+	if image == "" {
+		image = cluster.Spec.PXC.Image
+	}
+
+	items := []corev1.KeyToPath{{Path: scriptFile}}
+	var source corev1.VolumeSource
+	switch script := cr.Spec.Script; {
+	case script.ConfigMapKeyRef != nil:
+		items[0].Key = script.ConfigMapKeyRef.Key
+		source.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: script.ConfigMapKeyRef.Name},
+			Items:                items,
+		}
+	case script.SecretKeyRef != nil:
+		items[0].Key = script.SecretKeyRef.Key
+		source.Secret = &corev1.SecretVolumeSource{
+			SecretName: script.SecretKeyRef.Name,
+			Items:      items,
+		}
+	default:
+		return nil, errors.New("script source is not set")
+	}
+
+	ls := naming.LabelsCluster(cluster)
+	ls["job-name"] = name
+
+	manualSelector := true
+	// the script isn't idempotent and must not be executed twice
+	backoffLimit := int32(0)
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    ls,
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:          &backoffLimit,
+			ActiveDeadlineSeconds: cr.Spec.ActiveDeadlineSeconds,
+			ManualSelector:        &manualSelector,
+			Selector:              &metav1.LabelSelector{MatchLabels: ls},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: ls},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					SecurityContext:  cluster.Spec.PXC.PodSecurityContext,
+					ImagePullSecrets: cluster.Spec.PXC.ImagePullSecrets,
+					Containers: []corev1.Container{
+						{
+							Name:            containerName,
+							Image:           image,
+							ImagePullPolicy: cluster.Spec.PXC.ImagePullPolicy,
+							SecurityContext: cluster.Spec.PXC.ContainerSecurityContext,
+							Command:         []string{"bash", "-c"},
+							Args:            []string{sqlJobCommand(cr)},
+							Env: []corev1.EnvVar{
+								{
+									Name:  "PXC_SERVICE",
+									Value: cluster.Name + "-pxc." + cluster.Namespace,
+								},
+								{
+									Name: "SQLJOB_USER",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: app.SecretKeySelector(credentialsSecretName(cr), "user"),
+									},
+								},
+								{
+									Name: "SQLJOB_PASS",
+									ValueFrom: &corev1.EnvVarSource{
+										SecretKeyRef: app.SecretKeySelector(credentialsSecretName(cr), "password"),
+									},
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      scriptVolume,
+									MountPath: scriptDir,
+									ReadOnly:  true,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name:         scriptVolume,
+							VolumeSource: source,
+						},
+					},
+				},
+			},
+		},
+	}
+	cluster.ApplyImageOverrides(&job.Spec.Template.Spec)
+
+	return job, nil
+}
+
+// sqlJobCommand pipes the script to the mysql client. The client stops at the first
+// failed statement, so a transaction without COMMIT is rolled back on disconnect.
+func sqlJobCommand(cr *api.PerconaXtraDBClusterSQLJob) string {
+	script := "cat " + scriptDir + "/" + scriptFile
+	if cr.Spec.Transactional {
+		script = "{ echo 'START TRANSACTION;'; " + script + "; echo; echo 'COMMIT;'; }"
+	}
+
+	cmd := script + ` | mysql --host="$PXC_SERVICE" --port=3306 --user="$SQLJOB_USER" --password="$SQLJOB_PASS" --table -vv`
+	if cr.Spec.Database != "" {
+		// the name is validated by CheckNSetDefaults
+		cmd += " --database=" + cr.Spec.Database
+	}
+
+	return cmd
+}
+
+// jobOutput returns the tail of the mysql client output.
+func (r *ReconcilePerconaXtraDBClusterSQLJob) jobOutput(ctx context.Context, job *batchv1.Job) (string, error) {
+	pods := new(corev1.PodList)
+	err := r.client.List(ctx, pods, &client.ListOptions{
+		Namespace:     job.Namespace,
+		LabelSelector: labels.SelectorFromSet(job.Spec.Selector.MatchLabels),
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "list job pods")
+	}
+	if len(pods.Items) == 0 {
+		return "", errors.Errorf("no pods for job %s", job.Name)
+	}
+
+	lines, err := r.clientcmd.PodLogs(job.Namespace, pods.Items[0].Name, &corev1.PodLogOptions{Container: containerName})
+	if err != nil {
+		return "", errors.Wrapf(err, "get logs of %s", pods.Items[0].Name)
+	}
+
+	return tail(strings.Join(lines, "\n"), maxOutputSize), nil
+}
+
+func tail(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+	return s[len(s)-size:]
+}
+
+func jobName(cr *api.PerconaXtraDBClusterSQLJob) string {
+	return "sqljob-" + cr.Name
+}
@@ -0,0 +1,78 @@
+package pxcsqljob
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestSQLJob(t *testing.T) {
+	cluster := &api.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "ns"},
+		Spec: api.PerconaXtraDBClusterSpec{
+			PXC: &api.PXCSpec{PodSpec: &api.PodSpec{Image: "pxc-image"}},
+		},
+	}
+	cr := &api.PerconaXtraDBClusterSQLJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "seed", Namespace: "ns"},
+		Spec: api.PerconaXtraDBClusterSQLJobSpec{
+			PXCCluster: "cluster1",
+			Script: api.SQLJobScript{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "seed"},
+					Key:                  "seed.sql",
+				},
+			},
+			Database:      "app",
+			Transactional: true,
+		},
+	}
+	if err := cr.CheckNSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := sqlJob(cr, cluster)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *job.Spec.BackoffLimit != 0 {
+		t.Errorf("the script must not be retried, backoffLimit is %d", *job.Spec.BackoffLimit)
+	}
+
+	pod := job.Spec.Template.Spec
+	if pod.Containers[0].Image != "pxc-image" {
+		t.Errorf("unexpected image %s", pod.Containers[0].Image)
+	}
+
+	secret := pod.Volumes[0].Secret
+	if secret == nil || secret.SecretName != "seed" || secret.Items[0].Key != "seed.sql" || secret.Items[0].Path != scriptFile {
+		t.Errorf("unexpected script volume %+v", pod.Volumes[0].VolumeSource)
+	}
+
+	cmd := pod.Containers[0].Args[0]
+	for _, s := range []string{"START TRANSACTION;", "COMMIT;", "--database=app"} {
+		if !strings.Contains(cmd, s) {
+			t.Errorf("command %q doesn't contain %q", cmd, s)
+		}
+	}
+
+	cr.Spec.Transactional = false
+	cr.Spec.Database = ""
+	if cmd := sqlJobCommand(cr); strings.Contains(cmd, "TRANSACTION") || strings.Contains(cmd, "--database") {
+		t.Errorf("unexpected command %q", cmd)
+	}
+}
+
+func TestSQLJobUser(t *testing.T) {
+	cr := &api.PerconaXtraDBClusterSQLJob{
+		ObjectMeta: metav1.ObjectMeta{UID: "0f3c5e2a-7d41-4c7b-9a55-3b2e8f1d6c90"},
+	}
+	if user := sqlJobUser(cr); user != "sqljob_0f3c5e2a7d414c7b" {
+		t.Errorf("unexpected user %s", user)
+	}
+}
@@ -0,0 +1,241 @@
+package pxcswitchover
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	k8sretry "k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/audit"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+// Add creates a new PerconaXtraDBClusterSwitchover Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcilePerconaXtraDBClusterSwitchover{
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetEventRecorderFor("pxcswitchover-controller"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	rateLimiter, err := k8s.NewRateLimiter()
+	if err != nil {
+		return err
+	}
+
+	b := builder.ControllerManagedBy(mgr).
+		Named("pxcswitchover-controller").
+		WithOptions(controller.Options{RateLimiter: rateLimiter}).
+		// the steps are driven by requeues
+		For(&api.PerconaXtraDBClusterSwitchover{}, builder.WithPredicates(
+			predicate.GenerationChangedPredicate{},
+			k8s.NamespaceSelectorPredicate(mgr.GetClient()),
+			k8s.ClusterSelectorPredicate(mgr.GetClient(), func(obj client.Object) types.NamespacedName {
+				return types.NamespacedName{Name: obj.(*api.PerconaXtraDBClusterSwitchover).Spec.SourceCluster, Namespace: obj.GetNamespace()}
+			}),
+		))
+
+	return k8s.WatchNamespaces(b, mgr.GetClient(), func() client.ObjectList { return new(api.PerconaXtraDBClusterSwitchoverList) }).
+		Complete(k8s.DetachReconciler(metrics.InstrumentReconciler("pxcswitchover-controller", r)))
+}
+
+var _ reconcile.Reconciler = &ReconcilePerconaXtraDBClusterSwitchover{}
+
+// ReconcilePerconaXtraDBClusterSwitchover reconciles a PerconaXtraDBClusterSwitchover object
+type ReconcilePerconaXtraDBClusterSwitchover struct {
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// Reconcile swaps the roles of a source cluster and its replica: the source
+// is made read-only, the replica applies the remaining transactions and
+// the replication direction is reversed.
+func (r *ReconcilePerconaXtraDBClusterSwitchover) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logf.FromContext(ctx)
+
+	rr := reconcile.Result{}
+
+	cr := new(api.PerconaXtraDBClusterSwitchover)
+	err := r.client.Get(ctx, request.NamespacedName, cr)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return rr, nil
+		}
+		return rr, err
+	}
+
+	if cr.Status.State == api.SwitchoverStateSucceeded || cr.Status.State == api.SwitchoverStateFailed {
+		return rr, nil
+	}
+
+	if err := cr.CheckNSetDefaults(); err != nil {
+		return rr, r.setStatus(ctx, cr, api.SwitchoverStateFailed, err.Error())
+	}
+
+	source, replica := new(api.PerconaXtraDBCluster), new(api.PerconaXtraDBCluster)
+	for _, c := range []struct {
+		name    string
+		cluster *api.PerconaXtraDBCluster
+	}{{cr.Spec.SourceCluster, source}, {cr.Spec.ReplicaCluster, replica}} {
+		if err := r.client.Get(ctx, types.NamespacedName{Name: c.name, Namespace: cr.Namespace}, c.cluster); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return rr, r.setStatus(ctx, cr, api.SwitchoverStateFailed, fmt.Sprintf("cluster %s is not found", c.name))
+			}
+			return rr, errors.Wrapf(err, "get cluster %s", c.name)
+		}
+	}
+
+	var state api.SwitchoverState
+	var msg string
+	switch cr.Status.State {
+	case api.SwitchoverStateNew:
+		channel, verr := switchoverChannel(source, replica)
+		if verr != nil {
+			state, msg = api.SwitchoverStateFailed, verr.Error()
+			break
+		}
+		now := metav1.NewTime(time.Now().Truncate(time.Second))
+		cr.Status.Channel = channel
+		cr.Status.StartedAt = &now
+		state, msg = api.SwitchoverStateFencing, fmt.Sprintf("making cluster %s read-only", source.Name)
+	case api.SwitchoverStateFencing:
+		state, msg, err = r.fence(ctx, cr, source)
+	case api.SwitchoverStateCatchingUp:
+		state, msg, err = r.catchUp(ctx, cr, source, replica)
+	case api.SwitchoverStateReversing:
+		state, msg, err = r.reverse(ctx, cr, source, replica)
+	}
+	if err != nil {
+		// the request is requeued with backoff
+		if serr := r.setStatus(ctx, cr, cr.Status.State, err.Error()); serr != nil {
+			log.Error(serr, "failed to set status", "state", cr.Status.State)
+		}
+		return rr, errors.Wrapf(err, "reconcile switchover in state %s", cr.Status.State)
+	}
+
+	if err := r.setStatus(ctx, cr, state, msg); err != nil {
+		return rr, err
+	}
+
+	if state == api.SwitchoverStateSucceeded || state == api.SwitchoverStateFailed {
+		return rr, nil
+	}
+	return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+}
+
+// switchoverChannel returns the replication channel between the clusters.
+// The replica has to replicate through a single channel and the source must not replicate at all.
+// The specs of both clusters are changed, so they can't be owned by a GitOps tool.
+func switchoverChannel(source, replica *api.PerconaXtraDBCluster) (string, error) {
+	for _, c := range []*api.PerconaXtraDBCluster{source, replica} {
+		if c.Spec.GitOps.IsEnabled() {
+			return "", errors.Errorf("the spec of cluster %s is owned by a GitOps tool and can't be changed by the switchover", c.Name)
+		}
+	}
+
+	for _, ch := range source.Spec.PXC.ReplicationChannels {
+		if !ch.IsSource {
+			return "", errors.Errorf("cluster %s replicates through channel %s, it can't be switched over", source.Name, ch.Name)
+		}
+	}
+
+	channels := replica.Spec.PXC.ReplicationChannels
+	if len(channels) != 1 || channels[0].IsSource {
+		return "", errors.Errorf("cluster %s should have exactly one replication channel", replica.Name)
+	}
+
+	return channels[0].Name, nil
+}
+
+func (r *ReconcilePerconaXtraDBClusterSwitchover) updateCluster(ctx context.Context, name, namespace string, mutate func(*api.PerconaXtraDBCluster)) error {
+	return k8sretry.RetryOnConflict(k8sretry.DefaultRetry, func() error {
+		cluster := new(api.PerconaXtraDBCluster)
+		if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, cluster); err != nil {
+			return err
+		}
+		mutate(cluster)
+		return r.client.Update(ctx, cluster)
+	})
+}
+
+func (r *ReconcilePerconaXtraDBClusterSwitchover) setStatus(ctx context.Context, cr *api.PerconaXtraDBClusterSwitchover, state api.SwitchoverState, msg string) error {
+	prevState := cr.Status.State
+	if state != cr.Status.State {
+		eventType := corev1.EventTypeNormal
+		if state == api.SwitchoverStateFailed {
+			eventType = corev1.EventTypeWarning
+		}
+		r.recorder.Eventf(cr, eventType, naming.EventSwitchoverStateChanged, "Switchover state changed to %s: %s", state, msg)
+	}
+
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	status := cr.Status
+	err := k8s.PatchStatus(ctx, r.client, cr, func(c *api.PerconaXtraDBClusterSwitchover) {
+		if (state == api.SwitchoverStateSucceeded || state == api.SwitchoverStateFailed) && c.Status.CompletedAt == nil {
+			c.Status.CompletedAt = &now
+		}
+
+		c.Status.Channel = status.Channel
+		c.Status.SwitchoverGTID = status.SwitchoverGTID
+		c.Status.StartedAt = status.StartedAt
+		c.Status.ReversedAt = status.ReversedAt
+		c.Status.State = state
+		c.Status.Message = msg
+		c.Status.ObservedGeneration = c.Generation
+	})
+	if err != nil {
+		return errors.Wrap(err, "update status")
+	}
+
+	if state != prevState {
+		var result audit.OperationResult
+		switch state {
+		case api.SwitchoverStateSucceeded:
+			result = audit.OperationSucceeded
+		case api.SwitchoverStateFailed:
+			result = audit.OperationFailed
+		default:
+			if prevState == api.SwitchoverStateNew {
+				result = audit.OperationStarted
+			}
+		}
+		if result != "" {
+			audit.RecordOperation(ctx, r.client, cr.Namespace, cr.Spec.SourceCluster, audit.OperationEntry{
+				Operation: audit.OperationSwitchover,
+				Result:    result,
+				Object:    cr.Name,
+				Message:   msg,
+			})
+		}
+	}
+
+	return nil
+}
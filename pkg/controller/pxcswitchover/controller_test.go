@@ -0,0 +1,77 @@
+package pxcswitchover
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestReversedChannels(t *testing.T) {
+	source := &api.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "site-a", Namespace: "ns"},
+		Spec: api.PerconaXtraDBClusterSpec{
+			PXC: &api.PXCSpec{
+				PodSpec:             &api.PodSpec{Size: 3},
+				ReplicationChannels: []api.ReplicationChannel{{Name: "dr", IsSource: true}},
+			},
+		},
+	}
+	replica := &api.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "site-b", Namespace: "ns"},
+		Spec: api.PerconaXtraDBClusterSpec{
+			PXC: &api.PXCSpec{
+				PodSpec: &api.PodSpec{Size: 2},
+				ReplicationChannels: []api.ReplicationChannel{{
+					Name:        "dr",
+					SourcesList: []api.ReplicationSource{{Host: "site-a-pxc-0.site-a-pxc.ns", Port: 3306, Weight: 100}},
+					Config:      &api.ReplicationChannelConfig{SourceRetryCount: 5},
+					Rebuild:     &api.ReplicationRebuild{Enabled: true, SourceCluster: "site-a", StorageName: "s3"},
+				}},
+			},
+		},
+	}
+	cr := &api.PerconaXtraDBClusterSwitchover{
+		ObjectMeta: metav1.ObjectMeta{Name: "drill", Namespace: "ns"},
+		Spec:       api.PerconaXtraDBClusterSwitchoverSpec{SourceCluster: "site-a", ReplicaCluster: "site-b"},
+	}
+
+	channel, err := switchoverChannel(source, replica)
+	if err != nil || channel != "dr" {
+		t.Fatalf("unexpected channel %q: %v", channel, err)
+	}
+	if _, err := switchoverChannel(replica, source); err == nil {
+		t.Error("clusters with swapped roles should be rejected")
+	}
+	gitOps := replica.DeepCopy()
+	gitOps.Spec.GitOps = &api.GitOpsSpec{Enabled: true}
+	if _, err := switchoverChannel(source, gitOps); err == nil {
+		t.Error("cluster with the spec owned by a GitOps tool should be rejected")
+	}
+
+	sourceChannels, replicaChannels := reversedChannels(cr, source, replica)
+
+	if len(replicaChannels) != 1 || replicaChannels[0].Name != "dr" || !replicaChannels[0].IsSource {
+		t.Errorf("replica should become the source of the channel: %+v", replicaChannels)
+	}
+	if len(sourceChannels) != 1 || sourceChannels[0].IsSource || sourceChannels[0].Rebuild != nil {
+		t.Fatalf("unexpected source channels: %+v", sourceChannels)
+	}
+	if sourceChannels[0].Config == nil || sourceChannels[0].Config.SourceRetryCount != 5 {
+		t.Errorf("channel config should be kept: %+v", sourceChannels[0].Config)
+	}
+	sources := sourceChannels[0].SourcesList
+	if len(sources) != 2 || sources[0].Host != "site-b-pxc-0.site-b-pxc.ns" || sources[1].Weight != 99 {
+		t.Errorf("source should replicate from the replica pods: %+v", sources)
+	}
+	if replica.Spec.PXC.ReplicationChannels[0].Rebuild == nil {
+		t.Error("replica spec must not be modified")
+	}
+
+	cr.Spec.SourcesList = []api.ReplicationSource{{Host: "site-b.example.com", Port: 3306, Weight: 100}}
+	sourceChannels, _ = reversedChannels(cr, source, replica)
+	if sources := sourceChannels[0].SourcesList; len(sources) != 1 || sources[0].Host != "site-b.example.com" {
+		t.Errorf("sourcesList of the switchover should be used: %+v", sources)
+	}
+}
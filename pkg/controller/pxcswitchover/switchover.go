@@ -0,0 +1,232 @@
+package pxcswitchover
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+const dbTimeout = 10
+
+// fence makes the source cluster read-only and records its executed GTID set.
+func (r *ReconcilePerconaXtraDBClusterSwitchover) fence(ctx context.Context, cr *api.PerconaXtraDBClusterSwitchover, source *api.PerconaXtraDBCluster) (api.SwitchoverState, string, error) {
+	if source.Annotations[api.AnnotationReadOnly] != "true" {
+		err := r.updateCluster(ctx, source.Name, source.Namespace, func(c *api.PerconaXtraDBCluster) {
+			if c.Annotations == nil {
+				c.Annotations = make(map[string]string)
+			}
+			c.Annotations[api.AnnotationReadOnly] = "true"
+		})
+		if err != nil {
+			return "", "", errors.Wrap(err, "make source read-only")
+		}
+		return api.SwitchoverStateFencing, fmt.Sprintf("making cluster %s read-only", source.Name), nil
+	}
+
+	gtid, readOnly, err := r.sourceGTID(ctx, source)
+	if err != nil {
+		return "", "", errors.Wrap(err, "get source gtid")
+	}
+	if !readOnly {
+		return api.SwitchoverStateFencing, fmt.Sprintf("waiting for cluster %s to become read-only", source.Name), nil
+	}
+
+	logf.FromContext(ctx).Info("source cluster is read-only", "cluster", source.Name, "gtid", gtid)
+	cr.Status.SwitchoverGTID = gtid
+
+	return api.SwitchoverStateCatchingUp, fmt.Sprintf("waiting for cluster %s to apply %s", cr.Spec.ReplicaCluster, gtid), nil
+}
+
+// catchUp waits until the replica applies all transactions of the source.
+// The source is made writable again if the replica doesn't catch up in time.
+func (r *ReconcilePerconaXtraDBClusterSwitchover) catchUp(ctx context.Context, cr *api.PerconaXtraDBClusterSwitchover, source, replica *api.PerconaXtraDBCluster) (api.SwitchoverState, string, error) {
+	applied, err := r.replicaApplied(ctx, replica, cr.Status.SwitchoverGTID)
+	if err != nil {
+		return "", "", errors.Wrap(err, "wait for replica")
+	}
+	if applied {
+		return api.SwitchoverStateReversing, "reversing the replication direction", nil
+	}
+
+	timeout := time.Duration(cr.Spec.CatchUpTimeoutSeconds) * time.Second
+	if time.Since(cr.Status.StartedAt.Time) < timeout {
+		return api.SwitchoverStateCatchingUp, fmt.Sprintf("waiting for cluster %s to apply %s", replica.Name, cr.Status.SwitchoverGTID), nil
+	}
+
+	err = r.updateCluster(ctx, source.Name, source.Namespace, func(c *api.PerconaXtraDBCluster) {
+		delete(c.Annotations, api.AnnotationReadOnly)
+	})
+	if err != nil {
+		return "", "", errors.Wrap(err, "make source writable")
+	}
+	return api.SwitchoverStateFailed, fmt.Sprintf("cluster %s didn't apply transactions of %s in %s, cluster %s is writable again", replica.Name, source.Name, timeout, source.Name), nil
+}
+
+// reverse makes the source cluster replicate from the replica and the replica
+// the source of the channel, then waits until the replication is running.
+func (r *ReconcilePerconaXtraDBClusterSwitchover) reverse(ctx context.Context, cr *api.PerconaXtraDBClusterSwitchover, source, replica *api.PerconaXtraDBCluster) (api.SwitchoverState, string, error) {
+	if cr.Status.ReversedAt == nil {
+		sourceChannels, replicaChannels := reversedChannels(cr, source, replica)
+
+		// the source stays read-only as a replica of the channel,
+		// so the annotation is removed together with the channel change
+		err := r.updateCluster(ctx, source.Name, source.Namespace, func(c *api.PerconaXtraDBCluster) {
+			c.Spec.PXC.ReplicationChannels = sourceChannels
+			delete(c.Annotations, api.AnnotationReadOnly)
+		})
+		if err != nil {
+			return "", "", errors.Wrapf(err, "configure replication on %s", source.Name)
+		}
+
+		err = r.updateCluster(ctx, replica.Name, replica.Namespace, func(c *api.PerconaXtraDBCluster) {
+			c.Spec.PXC.ReplicationChannels = replicaChannels
+		})
+		if err != nil {
+			return "", "", errors.Wrapf(err, "make %s the source", replica.Name)
+		}
+
+		now := metav1.NewTime(time.Now().Truncate(time.Second))
+		cr.Status.ReversedAt = &now
+		return api.SwitchoverStateReversing, fmt.Sprintf("waiting for cluster %s to replicate from %s", source.Name, replica.Name), nil
+	}
+
+	if source.Status.PXCReplication != nil {
+		for _, ch := range source.Status.PXCReplication.Channels {
+			if ch.Name == cr.Status.Channel && ch.IOThread == "Yes" && ch.SQLThread == "Yes" {
+				return api.SwitchoverStateSucceeded, fmt.Sprintf("cluster %s is the source, cluster %s replicates from it", replica.Name, source.Name), nil
+			}
+		}
+	}
+
+	timeout := time.Duration(cr.Spec.ReplicationTimeoutSeconds) * time.Second
+	if time.Since(cr.Status.ReversedAt.Time) < timeout {
+		return api.SwitchoverStateReversing, fmt.Sprintf("waiting for cluster %s to replicate from %s", source.Name, replica.Name), nil
+	}
+
+	// the roles are already swapped, the replication has to be fixed manually
+	return api.SwitchoverStateFailed, fmt.Sprintf("cluster %s is the source, but replication of cluster %s isn't running after %s", replica.Name, source.Name, timeout), nil
+}
+
+// reversedChannels returns the replication channels of the source and the replica after the switchover.
+func reversedChannels(cr *api.PerconaXtraDBClusterSwitchover, source, replica *api.PerconaXtraDBCluster) ([]api.ReplicationChannel, []api.ReplicationChannel) {
+	channel := *replica.Spec.PXC.ReplicationChannels[0].DeepCopy()
+	// the old source isn't rebuilt from backups of a cluster that was just its replica
+	channel.Rebuild = nil
+	channel.SourcesList = cr.Spec.SourcesList
+	if len(channel.SourcesList) == 0 {
+		channel.SourcesList = replicationSources(replica)
+	}
+
+	return []api.ReplicationChannel{channel}, []api.ReplicationChannel{{Name: channel.Name, IsSource: true}}
+}
+
+func replicationSources(cluster *api.PerconaXtraDBCluster) []api.ReplicationSource {
+	sources := make([]api.ReplicationSource, 0, cluster.Spec.PXC.Size)
+	for i := 0; i < int(cluster.Spec.PXC.Size); i++ {
+		sources = append(sources, api.ReplicationSource{
+			Host:   fmt.Sprintf("%s-pxc-%d.%s-pxc.%s", cluster.Name, i, cluster.Name, cluster.Namespace),
+			Port:   3306,
+			Weight: 100 - i,
+		})
+	}
+	return sources
+}
+
+// sourceGTID returns the executed GTID set of the source cluster
+// and whether all ready PXC pods are read-only.
+func (r *ReconcilePerconaXtraDBClusterSwitchover) sourceGTID(ctx context.Context, source *api.PerconaXtraDBCluster) (string, bool, error) {
+	pods, err := r.readyPods(ctx, source)
+	if err != nil {
+		return "", false, err
+	}
+	if len(pods) == 0 {
+		return "", false, errors.New("no ready pods")
+	}
+
+	var gtid string
+	for _, pod := range pods {
+		db, err := queries.New(r.client, source.Namespace, users.InternalSecretsPrefix+source.Name, users.Operator, podHost(source, pod), users.MySQLAdminPort, dbTimeout)
+		if err != nil {
+			return "", false, errors.Wrapf(err, "connect to pod %s", pod.Name)
+		}
+		readOnly, err := db.IsReadonly()
+		if err == nil && readOnly && gtid == "" {
+			gtid, err = db.ReadVariable("gtid_executed")
+		}
+		db.Close()
+		if err != nil {
+			return "", false, errors.Wrapf(err, "pod %s", pod.Name)
+		}
+		if !readOnly {
+			return "", false, nil
+		}
+	}
+
+	return gtid, true, nil
+}
+
+// replicaApplied checks if all ready PXC pods of the replica have applied the GTID set.
+func (r *ReconcilePerconaXtraDBClusterSwitchover) replicaApplied(ctx context.Context, replica *api.PerconaXtraDBCluster, gtid string) (bool, error) {
+	pods, err := r.readyPods(ctx, replica)
+	if err != nil {
+		return false, err
+	}
+	if len(pods) == 0 {
+		return false, nil
+	}
+
+	for _, pod := range pods {
+		db, err := queries.New(r.client, replica.Namespace, users.InternalSecretsPrefix+replica.Name, users.Operator, podHost(replica, pod), users.MySQLAdminPort, dbTimeout)
+		if err != nil {
+			return false, errors.Wrapf(err, "connect to pod %s", pod.Name)
+		}
+		applied, err := db.WaitForExecutedGTIDSet(ctx, gtid, time.Second)
+		db.Close()
+		if err != nil {
+			return false, errors.Wrapf(err, "pod %s", pod.Name)
+		}
+		if !applied {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (r *ReconcilePerconaXtraDBClusterSwitchover) readyPods(ctx context.Context, cluster *api.PerconaXtraDBCluster) ([]corev1.Pod, error) {
+	list := new(corev1.PodList)
+	err := r.client.List(ctx, list, &client.ListOptions{
+		Namespace:     cluster.Namespace,
+		LabelSelector: labels.SelectorFromSet(statefulset.NewNode(cluster).Labels()),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list pods of %s", cluster.Name)
+	}
+
+	pods := make([]corev1.Pod, 0, len(list.Items))
+	for _, pod := range list.Items {
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+				pods = append(pods, pod)
+				break
+			}
+		}
+	}
+	return pods, nil
+}
+
+func podHost(cluster *api.PerconaXtraDBCluster, pod corev1.Pod) string {
+	return pod.Name + "." + cluster.Name + "-pxc." + cluster.Namespace
+}
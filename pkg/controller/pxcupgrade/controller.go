@@ -0,0 +1,388 @@
+package pxcupgrade
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	k8sretry "k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/audit"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+// upgradeLabel marks objects created for the upgrade
+const upgradeLabel = "percona.com/cluster-upgrade"
+
+// Add creates a new PerconaXtraDBClusterUpgrade Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	return add(mgr, newReconciler(mgr))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+	return &ReconcilePerconaXtraDBClusterUpgrade{
+		client:   mgr.GetClient(),
+		scheme:   mgr.GetScheme(),
+		recorder: mgr.GetEventRecorderFor("pxcupgrade-controller"),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	rateLimiter, err := k8s.NewRateLimiter()
+	if err != nil {
+		return err
+	}
+
+	b := builder.ControllerManagedBy(mgr).
+		Named("pxcupgrade-controller").
+		WithOptions(controller.Options{RateLimiter: rateLimiter}).
+		// status is updated on every step, the steps are driven by requeues
+		For(&api.PerconaXtraDBClusterUpgrade{}, builder.WithPredicates(
+			predicate.GenerationChangedPredicate{},
+			k8s.NamespaceSelectorPredicate(mgr.GetClient()),
+			k8s.ClusterSelectorPredicate(mgr.GetClient(), func(obj client.Object) types.NamespacedName {
+				return types.NamespacedName{Name: obj.(*api.PerconaXtraDBClusterUpgrade).Spec.SourceCluster, Namespace: obj.GetNamespace()}
+			}),
+		))
+
+	return k8s.WatchNamespaces(b, mgr.GetClient(), func() client.ObjectList { return new(api.PerconaXtraDBClusterUpgradeList) }).
+		Complete(k8s.DetachReconciler(metrics.InstrumentReconciler("pxcupgrade-controller", r)))
+}
+
+var _ reconcile.Reconciler = &ReconcilePerconaXtraDBClusterUpgrade{}
+
+// ReconcilePerconaXtraDBClusterUpgrade reconciles a PerconaXtraDBClusterUpgrade object
+type ReconcilePerconaXtraDBClusterUpgrade struct {
+	client   client.Client
+	scheme   *runtime.Scheme
+	recorder record.EventRecorder
+}
+
+// Reconcile drives the blue/green upgrade: it provisions the target cluster,
+// seeds it from a backup of the source, replicates from the source
+// and switches clients to the target on cutover.
+func (r *ReconcilePerconaXtraDBClusterUpgrade) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	log := logf.FromContext(ctx)
+
+	rr := reconcile.Result{}
+
+	cr := new(api.PerconaXtraDBClusterUpgrade)
+	err := r.client.Get(ctx, request.NamespacedName, cr)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return rr, nil
+		}
+		return rr, err
+	}
+
+	if cr.Status.State == api.UpgradeStateSucceeded || cr.Status.State == api.UpgradeStateFailed {
+		return rr, nil
+	}
+
+	if err := cr.CheckNSetDefaults(); err != nil {
+		return rr, r.setStatus(ctx, cr, api.UpgradeStateFailed, err.Error())
+	}
+
+	source := new(api.PerconaXtraDBCluster)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: cr.Spec.SourceCluster, Namespace: cr.Namespace}, source); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return rr, r.setStatus(ctx, cr, api.UpgradeStateFailed, fmt.Sprintf("source cluster %s is not found", cr.Spec.SourceCluster))
+		}
+		return rr, errors.Wrap(err, "get source cluster")
+	}
+
+	if cr.Status.State == api.UpgradeStateNew && source.Spec.GitOps.IsEnabled() {
+		return rr, r.setStatus(ctx, cr, api.UpgradeStateFailed, fmt.Sprintf("the spec of cluster %s is owned by a GitOps tool and can't be changed by the upgrade", source.Name))
+	}
+
+	var state api.UpgradeState
+	var msg string
+	switch cr.Status.State {
+	case api.UpgradeStateNew, api.UpgradeStateProvisioning:
+		state, msg, err = r.provision(ctx, cr, source)
+	case api.UpgradeStateSeeding:
+		state, msg, err = r.seed(ctx, cr, source)
+	case api.UpgradeStateReplicating:
+		state, msg = api.UpgradeStateReplicating, fmt.Sprintf("cluster %s replicates from %s, set spec.cutover to switch clients", cr.Spec.Target.Name, source.Name)
+		if cr.Spec.Cutover {
+			now := metav1.NewTime(time.Now().Truncate(time.Second))
+			cr.Status.CutoverStartedAt = &now
+			state, msg = api.UpgradeStateCuttingOver, "making the source cluster read-only"
+		}
+	case api.UpgradeStateCuttingOver:
+		state, msg, err = r.cutover(ctx, cr, source)
+	}
+	if err != nil {
+		// the request is requeued with backoff
+		if serr := r.setStatus(ctx, cr, cr.Status.State, err.Error()); serr != nil {
+			log.Error(serr, "failed to set status", "state", cr.Status.State)
+		}
+		return rr, errors.Wrapf(err, "reconcile upgrade in state %s", cr.Status.State)
+	}
+
+	if err := r.setStatus(ctx, cr, state, msg); err != nil {
+		return rr, err
+	}
+
+	switch state {
+	case api.UpgradeStateSucceeded, api.UpgradeStateFailed:
+		return rr, nil
+	case api.UpgradeStateCuttingOver:
+		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+	return reconcile.Result{RequeueAfter: 10 * time.Second}, nil
+}
+
+// provision creates the target cluster with the spec of the source cluster
+// and waits until it's ready.
+func (r *ReconcilePerconaXtraDBClusterUpgrade) provision(ctx context.Context, cr *api.PerconaXtraDBClusterUpgrade, source *api.PerconaXtraDBCluster) (api.UpgradeState, string, error) {
+	target := new(api.PerconaXtraDBCluster)
+	err := r.client.Get(ctx, types.NamespacedName{Name: cr.Spec.Target.Name, Namespace: cr.Namespace}, target)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return "", "", errors.Wrap(err, "get target cluster")
+	}
+
+	if k8serrors.IsNotFound(err) {
+		target = newTargetCluster(cr, source)
+		if err := r.client.Create(ctx, target); err != nil {
+			return "", "", errors.Wrap(err, "create target cluster")
+		}
+		return api.UpgradeStateProvisioning, fmt.Sprintf("cluster %s is created", target.Name), nil
+	}
+
+	if target.Labels[upgradeLabel] != cr.Name {
+		return api.UpgradeStateFailed, fmt.Sprintf("cluster %s already exists and isn't managed by the upgrade", target.Name), nil
+	}
+
+	if target.Status.Status != api.AppStateReady {
+		return api.UpgradeStateProvisioning, fmt.Sprintf("waiting for cluster %s to be ready", target.Name), nil
+	}
+
+	return api.UpgradeStateSeeding, "seeding the target cluster", nil
+}
+
+func newTargetCluster(cr *api.PerconaXtraDBClusterUpgrade, source *api.PerconaXtraDBCluster) *api.PerconaXtraDBCluster {
+	target := &api.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Spec.Target.Name,
+			Namespace: cr.Namespace,
+			Labels: map[string]string{
+				upgradeLabel: cr.Name,
+			},
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+
+	spec := &target.Spec
+	spec.Pause = false
+
+	// system users and encryption keys are restored from the backup,
+	// so the target has to use the secrets of the source
+	if spec.SecretsName == "" {
+		spec.SecretsName = source.Name + "-secrets"
+	}
+	if spec.VaultSecretName == "" {
+		spec.VaultSecretName = source.Name + "-vault"
+	}
+	spec.SSLSecretName = ""
+	spec.SSLInternalSecretName = ""
+
+	spec.UpgradeOptions.Apply = api.UpgradeStrategyDisabled
+	spec.PXC.Image = cr.Spec.Target.Image
+	spec.PXC.ReplicationChannels = nil
+	if spec.HAProxy != nil && cr.Spec.Target.HAProxyImage != "" {
+		spec.HAProxy.Image = cr.Spec.Target.HAProxyImage
+	}
+	if spec.ProxySQL != nil && cr.Spec.Target.ProxySQLImage != "" {
+		spec.ProxySQL.Image = cr.Spec.Target.ProxySQLImage
+	}
+
+	// scheduled backups and binlogs are taken over on cutover
+	if spec.Backup != nil {
+		spec.Backup.Schedule = nil
+		spec.Backup.PITR.Enabled = false
+	}
+
+	return target
+}
+
+// seed restores a backup of the source cluster to the target cluster
+// and sets up replication from the source.
+func (r *ReconcilePerconaXtraDBClusterUpgrade) seed(ctx context.Context, cr *api.PerconaXtraDBClusterUpgrade, source *api.PerconaXtraDBCluster) (api.UpgradeState, string, error) {
+	backupName := cr.Spec.Seed.BackupName
+	if backupName == "" {
+		backupName = cr.Name + "-seed"
+		bcp := &api.PerconaXtraDBClusterBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      backupName,
+				Namespace: cr.Namespace,
+				Labels: map[string]string{
+					upgradeLabel: cr.Name,
+				},
+			},
+			Spec: api.PXCBackupSpec{
+				PXCCluster:  source.Name,
+				StorageName: cr.Spec.Seed.StorageName,
+			},
+		}
+		if err := r.client.Create(ctx, bcp); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return "", "", errors.Wrap(err, "create backup")
+		}
+	}
+	cr.Status.BackupName = backupName
+
+	bcp := new(api.PerconaXtraDBClusterBackup)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: backupName, Namespace: cr.Namespace}, bcp); err != nil {
+		return "", "", errors.Wrapf(err, "get backup %s", backupName)
+	}
+	switch bcp.Status.State {
+	case api.BackupSucceeded:
+	case api.BackupFailed:
+		return api.UpgradeStateFailed, fmt.Sprintf("backup %s failed: %s", backupName, bcp.Status.Error), nil
+	default:
+		return api.UpgradeStateSeeding, fmt.Sprintf("waiting for backup %s", backupName), nil
+	}
+
+	restore := &api.PerconaXtraDBClusterRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cr.Name + "-seed",
+			Namespace: cr.Namespace,
+			Labels: map[string]string{
+				upgradeLabel: cr.Name,
+			},
+		},
+		Spec: api.PerconaXtraDBClusterRestoreSpec{
+			PXCCluster: cr.Spec.Target.Name,
+			BackupName: backupName,
+		},
+	}
+	if err := controllerutil.SetControllerReference(cr, restore, r.scheme); err != nil {
+		return "", "", errors.Wrap(err, "set controller reference")
+	}
+	if err := r.client.Create(ctx, restore); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return "", "", errors.Wrap(err, "create restore")
+	}
+	cr.Status.RestoreName = restore.Name
+
+	if err := r.client.Get(ctx, client.ObjectKeyFromObject(restore), restore); err != nil {
+		return "", "", errors.Wrapf(err, "get restore %s", restore.Name)
+	}
+	switch restore.Status.State {
+	case api.RestoreSucceeded:
+	case api.RestoreFailed:
+		return api.UpgradeStateFailed, fmt.Sprintf("restore %s failed: %s", restore.Name, restore.Status.Comments), nil
+	default:
+		return api.UpgradeStateSeeding, fmt.Sprintf("waiting for restore %s", restore.Name), nil
+	}
+
+	err := r.updateCluster(ctx, cr.Spec.Target.Name, cr.Namespace, func(target *api.PerconaXtraDBCluster) {
+		target.Spec.PXC.ReplicationChannels = []api.ReplicationChannel{{
+			Name:        api.UpgradeReplicationChannel,
+			SourcesList: replicationSources(source),
+		}}
+		if target.Spec.Backup != nil && cr.Spec.Target.BackupImage != "" {
+			target.Spec.Backup.Image = cr.Spec.Target.BackupImage
+		}
+	})
+	if err != nil {
+		return "", "", errors.Wrap(err, "configure replication")
+	}
+
+	r.recorder.Eventf(cr, corev1.EventTypeNormal, naming.EventUpgradeStateChanged,
+		"Cluster %s is seeded from backup %s and replicates from %s", cr.Spec.Target.Name, backupName, source.Name)
+
+	return api.UpgradeStateReplicating, fmt.Sprintf("cluster %s replicates from %s", cr.Spec.Target.Name, source.Name), nil
+}
+
+func replicationSources(source *api.PerconaXtraDBCluster) []api.ReplicationSource {
+	sources := make([]api.ReplicationSource, 0, source.Spec.PXC.Size)
+	for i := 0; i < int(source.Spec.PXC.Size); i++ {
+		sources = append(sources, api.ReplicationSource{
+			Host:   fmt.Sprintf("%s-pxc-%d.%s-pxc.%s", source.Name, i, source.Name, source.Namespace),
+			Port:   3306,
+			Weight: 100 - i,
+		})
+	}
+	return sources
+}
+
+func (r *ReconcilePerconaXtraDBClusterUpgrade) updateCluster(ctx context.Context, name, namespace string, mutate func(*api.PerconaXtraDBCluster)) error {
+	return k8sretry.RetryOnConflict(k8sretry.DefaultRetry, func() error {
+		cluster := new(api.PerconaXtraDBCluster)
+		if err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, cluster); err != nil {
+			return err
+		}
+		mutate(cluster)
+		return r.client.Update(ctx, cluster)
+	})
+}
+
+func (r *ReconcilePerconaXtraDBClusterUpgrade) setStatus(ctx context.Context, cr *api.PerconaXtraDBClusterUpgrade, state api.UpgradeState, msg string) error {
+	prevState := cr.Status.State
+	if state != cr.Status.State {
+		eventType := corev1.EventTypeNormal
+		if state == api.UpgradeStateFailed {
+			eventType = corev1.EventTypeWarning
+		}
+		r.recorder.Eventf(cr, eventType, naming.EventUpgradeStateChanged, "Upgrade state changed to %s: %s", state, msg)
+	}
+
+	now := metav1.NewTime(time.Now().Truncate(time.Second))
+	err := k8s.PatchStatus(ctx, r.client, cr, func(c *api.PerconaXtraDBClusterUpgrade) {
+		if state == api.UpgradeStateSucceeded && c.Status.CompletedAt == nil {
+			c.Status.CompletedAt = &now
+		}
+
+		c.Status.State = state
+		c.Status.Message = msg
+		c.Status.ObservedGeneration = c.Generation
+	})
+	if err != nil {
+		return errors.Wrap(err, "update status")
+	}
+
+	if state != prevState {
+		var result audit.OperationResult
+		switch state {
+		case api.UpgradeStateSucceeded:
+			result = audit.OperationSucceeded
+		case api.UpgradeStateFailed:
+			result = audit.OperationFailed
+		default:
+			if prevState == api.UpgradeStateNew {
+				result = audit.OperationStarted
+			}
+		}
+		if result != "" {
+			audit.RecordOperation(ctx, r.client, cr.Namespace, cr.Spec.SourceCluster, audit.OperationEntry{
+				Operation: audit.OperationUpgrade,
+				Result:    result,
+				Object:    cr.Name,
+				Message:   msg,
+			})
+		}
+	}
+
+	return nil
+}
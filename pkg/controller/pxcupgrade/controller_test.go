@@ -0,0 +1,59 @@
+package pxcupgrade
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestNewTargetCluster(t *testing.T) {
+	source := &api.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "ns"},
+		Spec: api.PerconaXtraDBClusterSpec{
+			SSLSecretName: "cluster1-ssl",
+			PXC: &api.PXCSpec{
+				PodSpec:             &api.PodSpec{Image: "pxc:5.7", Size: 3},
+				ReplicationChannels: []api.ReplicationChannel{{Name: "ch1", IsSource: true}},
+			},
+			Backup: &api.PXCScheduledBackup{
+				Image:    "backup:5.7",
+				Schedule: []api.PXCScheduledBackupSchedule{{Name: "daily"}},
+				PITR:     api.PITRSpec{Enabled: true},
+			},
+		},
+	}
+	cr := &api.PerconaXtraDBClusterUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "upgrade", Namespace: "ns"},
+		Spec: api.PerconaXtraDBClusterUpgradeSpec{
+			SourceCluster: "cluster1",
+			Target:        api.UpgradeTarget{Name: "cluster2", Image: "pxc:8.0", BackupImage: "backup:8.0"},
+		},
+	}
+
+	target := newTargetCluster(cr, source)
+
+	if target.Name != "cluster2" || target.Labels[upgradeLabel] != "upgrade" {
+		t.Errorf("unexpected target metadata: %v", target.ObjectMeta)
+	}
+	if target.Spec.PXC.Image != "pxc:8.0" {
+		t.Errorf("expected target image pxc:8.0, got %s", target.Spec.PXC.Image)
+	}
+	if target.Spec.SecretsName != "cluster1-secrets" || target.Spec.VaultSecretName != "cluster1-vault" {
+		t.Errorf("target should use secrets of the source, got %s and %s", target.Spec.SecretsName, target.Spec.VaultSecretName)
+	}
+	if target.Spec.SSLSecretName != "" {
+		t.Errorf("target should use its own TLS secret, got %s", target.Spec.SSLSecretName)
+	}
+	if target.Spec.Backup.Image != "backup:5.7" {
+		t.Errorf("backup image should be replaced after seeding, got %s", target.Spec.Backup.Image)
+	}
+	if len(target.Spec.Backup.Schedule) != 0 || target.Spec.Backup.PITR.Enabled || len(target.Spec.PXC.ReplicationChannels) != 0 {
+		t.Errorf("backups and replication should be disabled on the target: %v", target.Spec)
+	}
+
+	if source.Spec.PXC.Image != "pxc:5.7" || len(source.Spec.Backup.Schedule) != 1 {
+		t.Error("source spec must not be modified")
+	}
+}
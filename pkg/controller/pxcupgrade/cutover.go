@@ -0,0 +1,189 @@
+package pxcupgrade
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/queries"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+const dbTimeout = 10
+
+// cutover makes the source cluster read-only, waits until the target applies
+// all transactions of the source and switches the source services to the target.
+// The source is paused, its data is kept until the source cluster is deleted.
+func (r *ReconcilePerconaXtraDBClusterUpgrade) cutover(ctx context.Context, cr *api.PerconaXtraDBClusterUpgrade, source *api.PerconaXtraDBCluster) (api.UpgradeState, string, error) {
+	log := logf.FromContext(ctx)
+
+	if cr.Status.CutoverGTID == "" {
+		if source.Annotations[api.AnnotationReadOnly] != "true" {
+			err := r.updateCluster(ctx, source.Name, source.Namespace, func(c *api.PerconaXtraDBCluster) {
+				if c.Annotations == nil {
+					c.Annotations = make(map[string]string)
+				}
+				c.Annotations[api.AnnotationReadOnly] = "true"
+			})
+			if err != nil {
+				return "", "", errors.Wrap(err, "make source read-only")
+			}
+			return api.UpgradeStateCuttingOver, "making the source cluster read-only", nil
+		}
+
+		gtid, readOnly, err := r.sourceGTID(ctx, source)
+		if err != nil {
+			return "", "", errors.Wrap(err, "get source gtid")
+		}
+		if !readOnly {
+			return api.UpgradeStateCuttingOver, "waiting for the source cluster to become read-only", nil
+		}
+
+		log.Info("source cluster is read-only", "cluster", source.Name, "gtid", gtid)
+		cr.Status.CutoverGTID = gtid
+	}
+
+	applied, err := r.targetApplied(ctx, cr)
+	if err != nil {
+		return "", "", errors.Wrap(err, "wait for target")
+	}
+	if !applied {
+		timeout := time.Duration(cr.Spec.CutoverTimeoutSeconds) * time.Second
+		if time.Since(cr.Status.CutoverStartedAt.Time) < timeout {
+			return api.UpgradeStateCuttingOver, fmt.Sprintf("waiting for cluster %s to apply %s", cr.Spec.Target.Name, cr.Status.CutoverGTID), nil
+		}
+
+		err := r.updateCluster(ctx, source.Name, source.Namespace, func(c *api.PerconaXtraDBCluster) {
+			delete(c.Annotations, api.AnnotationReadOnly)
+		})
+		if err != nil {
+			return "", "", errors.Wrap(err, "make source writable")
+		}
+		return api.UpgradeStateFailed, fmt.Sprintf("cluster %s didn't apply transactions of the source in %s, source cluster is writable again", cr.Spec.Target.Name, timeout), nil
+	}
+
+	err = r.updateCluster(ctx, cr.Spec.Target.Name, cr.Namespace, func(c *api.PerconaXtraDBCluster) {
+		c.Spec.PXC.ReplicationChannels = nil
+		if c.Spec.Backup != nil && source.Spec.Backup != nil {
+			c.Spec.Backup.Schedule = source.Spec.Backup.Schedule
+			c.Spec.Backup.PITR.Enabled = source.Spec.Backup.PITR.Enabled
+		}
+	})
+	if err != nil {
+		return "", "", errors.Wrap(err, "stop replication on target")
+	}
+
+	err = r.updateCluster(ctx, source.Name, source.Namespace, func(c *api.PerconaXtraDBCluster) {
+		c.Annotations[api.AnnotationCutoverTo] = cr.Spec.Target.Name
+		c.Spec.Pause = true
+		if c.Spec.Backup != nil {
+			c.Spec.Backup.Schedule = nil
+			c.Spec.Backup.PITR.Enabled = false
+		}
+	})
+	if err != nil {
+		return "", "", errors.Wrap(err, "switch source services")
+	}
+
+	return api.UpgradeStateSucceeded, fmt.Sprintf("services of %s point to %s, source cluster is paused", source.Name, cr.Spec.Target.Name), nil
+}
+
+// sourceGTID returns the executed GTID set of the source cluster
+// and whether all ready PXC pods are read-only.
+func (r *ReconcilePerconaXtraDBClusterUpgrade) sourceGTID(ctx context.Context, source *api.PerconaXtraDBCluster) (string, bool, error) {
+	pods, err := r.readyPods(ctx, source)
+	if err != nil {
+		return "", false, err
+	}
+	if len(pods) == 0 {
+		return "", false, errors.New("no ready pods")
+	}
+
+	var gtid string
+	for _, pod := range pods {
+		db, err := queries.New(r.client, source.Namespace, users.InternalSecretsPrefix+source.Name, users.Operator, podHost(source, pod), users.MySQLAdminPort, dbTimeout)
+		if err != nil {
+			return "", false, errors.Wrapf(err, "connect to pod %s", pod.Name)
+		}
+		readOnly, err := db.IsReadonly()
+		if err == nil && readOnly && gtid == "" {
+			gtid, err = db.ReadVariable("gtid_executed")
+		}
+		db.Close()
+		if err != nil {
+			return "", false, errors.Wrapf(err, "pod %s", pod.Name)
+		}
+		if !readOnly {
+			return "", false, nil
+		}
+	}
+
+	return gtid, true, nil
+}
+
+// targetApplied checks if all ready PXC pods of the target have applied the cutover GTID set.
+func (r *ReconcilePerconaXtraDBClusterUpgrade) targetApplied(ctx context.Context, cr *api.PerconaXtraDBClusterUpgrade) (bool, error) {
+	target := new(api.PerconaXtraDBCluster)
+	if err := r.client.Get(ctx, client.ObjectKey{Name: cr.Spec.Target.Name, Namespace: cr.Namespace}, target); err != nil {
+		return false, errors.Wrap(err, "get target cluster")
+	}
+
+	pods, err := r.readyPods(ctx, target)
+	if err != nil {
+		return false, err
+	}
+	if len(pods) == 0 {
+		return false, nil
+	}
+
+	for _, pod := range pods {
+		db, err := queries.New(r.client, target.Namespace, users.InternalSecretsPrefix+target.Name, users.Operator, podHost(target, pod), users.MySQLAdminPort, dbTimeout)
+		if err != nil {
+			return false, errors.Wrapf(err, "connect to pod %s", pod.Name)
+		}
+		applied, err := db.WaitForExecutedGTIDSet(ctx, cr.Status.CutoverGTID, time.Second)
+		db.Close()
+		if err != nil {
+			return false, errors.Wrapf(err, "pod %s", pod.Name)
+		}
+		if !applied {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (r *ReconcilePerconaXtraDBClusterUpgrade) readyPods(ctx context.Context, cluster *api.PerconaXtraDBCluster) ([]corev1.Pod, error) {
+	list := new(corev1.PodList)
+	err := r.client.List(ctx, list, &client.ListOptions{
+		Namespace:     cluster.Namespace,
+		LabelSelector: labels.SelectorFromSet(statefulset.NewNode(cluster).Labels()),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "list pods of %s", cluster.Name)
+	}
+
+	pods := make([]corev1.Pod, 0, len(list.Items))
+	for _, pod := range list.Items {
+		for _, c := range pod.Status.Conditions {
+			if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+				pods = append(pods, pod)
+				break
+			}
+		}
+	}
+	return pods, nil
+}
+
+func podHost(cluster *api.PerconaXtraDBCluster, pod corev1.Pod) string {
+	return pod.Name + "." + cluster.Name + "-pxc." + cluster.Namespace
+}
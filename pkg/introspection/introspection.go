@@ -0,0 +1,249 @@
+// Package introspection serves a read-only JSON summary of the clusters managed by the operator,
+// so fleet dashboards can watch them without access to the Kubernetes API.
+package introspection
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+// Path is the path the summary is served on.
+const Path = "/introspection/clusters"
+
+// Cluster is the summary of a managed cluster.
+type Cluster struct {
+	Namespace string       `json:"namespace"`
+	Name      string       `json:"name"`
+	Phase     api.AppState `json:"phase"`
+	Size      int32        `json:"size"`
+	Ready     int32        `json:"ready"`
+	Paused    bool         `json:"paused,omitempty"`
+	Suspended bool         `json:"suspended,omitempty"`
+	// Generation and ObservedGeneration differ while a spec change isn't reconciled yet.
+	Generation         int64 `json:"generation"`
+	ObservedGeneration int64 `json:"observedGeneration"`
+	// PendingActions are the changes the operator waits to apply, see status.pendingChanges.
+	PendingActions []api.PendingAction    `json:"pendingActions,omitempty"`
+	SmartUpdate    *api.SmartUpdateStatus `json:"smartUpdate,omitempty"`
+	Backups        []Operation            `json:"backups,omitempty"`
+	Restores       []Operation            `json:"restores,omitempty"`
+	LastBackup     *api.LastBackupStatus  `json:"lastBackup,omitempty"`
+	Errors         []string               `json:"errors,omitempty"`
+	Conditions     []api.ClusterCondition `json:"conditions,omitempty"`
+}
+
+// Operation is an in-flight backup or restore of a cluster.
+type Operation struct {
+	Name      string      `json:"name"`
+	State     string      `json:"state"`
+	Storage   string      `json:"storage,omitempty"`
+	CreatedAt metav1.Time `json:"createdAt"`
+}
+
+// Server serves the summary of the clusters to the requests with the bearer token read from tokenFile.
+// The token file is read on every request, so the token can be rotated by updating the mounted secret.
+type Server struct {
+	client    client.Reader
+	addr      string
+	tokenFile string
+}
+
+func NewServer(c client.Reader, addr, tokenFile string) *Server {
+	return &Server{client: c, addr: addr, tokenFile: tokenFile}
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface,
+// every operator replica serves the summary from its cache.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves the summary until ctx is done.
+func (s *Server) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("introspection")
+
+	mux := http.NewServeMux()
+	mux.Handle(Path, s)
+	srv := &http.Server{
+		Addr:              s.addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	go func() {
+		<-ctx.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.Error(err, "shut down introspection server")
+		}
+	}()
+
+	log.Info("Serving introspection endpoint", "bindAddress", s.addr, "path", Path)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return errors.Wrap(err, "serve introspection endpoint")
+	}
+	return nil
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ok, err := s.authenticated(r)
+	if err != nil {
+		logf.FromContext(r.Context()).Error(err, "authenticate introspection request")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	if !ok {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	clusters, err := Clusters(r.Context(), s.client, r.URL.Query().Get("namespace"))
+	if err != nil {
+		logf.FromContext(r.Context()).Error(err, "list clusters for introspection")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(clusters); err != nil {
+		logf.FromContext(r.Context()).Error(err, "write introspection response")
+	}
+}
+
+func (s *Server) authenticated(r *http.Request) (bool, error) {
+	data, err := os.ReadFile(s.tokenFile)
+	if err != nil {
+		return false, errors.Wrap(err, "read token file")
+	}
+	expected := strings.TrimSpace(string(data))
+	if expected == "" {
+		return false, errors.Errorf("token file %s is empty", s.tokenFile)
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return false, nil
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1, nil
+}
+
+// Clusters returns the summaries of the clusters in the namespace, or in all watched namespaces if it's empty,
+// sorted by namespace and name.
+func Clusters(ctx context.Context, c client.Reader, namespace string) ([]Cluster, error) {
+	clusters := new(api.PerconaXtraDBClusterList)
+	if err := c.List(ctx, clusters, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrap(err, "list clusters")
+	}
+	backups := new(api.PerconaXtraDBClusterBackupList)
+	if err := c.List(ctx, backups, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrap(err, "list backups")
+	}
+	restores := new(api.PerconaXtraDBClusterRestoreList)
+	if err := c.List(ctx, restores, client.InNamespace(namespace)); err != nil {
+		return nil, errors.Wrap(err, "list restores")
+	}
+
+	byName := make(map[string]*Cluster, len(clusters.Items))
+	summaries := make([]Cluster, 0, len(clusters.Items))
+	for i := range clusters.Items {
+		summaries = append(summaries, clusterSummary(&clusters.Items[i]))
+	}
+	for i := range summaries {
+		byName[summaries[i].Namespace+"/"+summaries[i].Name] = &summaries[i]
+	}
+
+	for _, bcp := range backups.Items {
+		cl, ok := byName[bcp.Namespace+"/"+bcp.Spec.PXCCluster]
+		if !ok || bcp.Status.State == api.BackupSucceeded || bcp.Status.State == api.BackupFailed {
+			continue
+		}
+		cl.Backups = append(cl.Backups, Operation{
+			Name:      bcp.Name,
+			State:     stateOrNew(string(bcp.Status.State)),
+			Storage:   bcp.Spec.StorageName,
+			CreatedAt: bcp.CreationTimestamp,
+		})
+	}
+	for _, restore := range restores.Items {
+		cl, ok := byName[restore.Namespace+"/"+restore.Spec.PXCCluster]
+		if !ok || restore.Status.State == api.RestoreSucceeded || restore.Status.State == api.RestoreFailed {
+			continue
+		}
+		cl.Restores = append(cl.Restores, Operation{
+			Name:      restore.Name,
+			State:     stateOrNew(string(restore.Status.State)),
+			CreatedAt: restore.CreationTimestamp,
+		})
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+	for i := range summaries {
+		sortOperations(summaries[i].Backups)
+		sortOperations(summaries[i].Restores)
+	}
+	return summaries, nil
+}
+
+func clusterSummary(cr *api.PerconaXtraDBCluster) Cluster {
+	cl := Cluster{
+		Namespace:          cr.Namespace,
+		Name:               cr.Name,
+		Phase:              cr.Status.Status,
+		Size:               cr.Status.Size,
+		Ready:              cr.Status.Ready,
+		Paused:             cr.Spec.Pause,
+		Suspended:          cr.Spec.Suspend,
+		Generation:         cr.Generation,
+		ObservedGeneration: cr.Status.ObservedGeneration,
+		SmartUpdate:        cr.Status.SmartUpdate,
+		LastBackup:         cr.Status.LastBackup,
+		Errors:             cr.Status.Messages,
+		Conditions:         cr.Status.Conditions,
+	}
+	if cr.Status.PendingChanges != nil {
+		cl.PendingActions = cr.Status.PendingChanges.Actions
+	}
+	if cl.Phase == "" {
+		cl.Phase = api.AppStateUnknown
+	}
+	return cl
+}
+
+func sortOperations(ops []Operation) {
+	sort.Slice(ops, func(i, j int) bool { return ops[i].CreatedAt.Before(&ops[j].CreatedAt) })
+}
+
+func stateOrNew(state string) string {
+	if state == "" {
+		return "New"
+	}
+	return state
+}
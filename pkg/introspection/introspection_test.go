@@ -0,0 +1,130 @@
+package introspection
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/apis"
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	s := k8sruntime.NewScheme()
+	if err := apis.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+
+	cluster := &api.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "db", Generation: 3},
+		Spec:       api.PerconaXtraDBClusterSpec{Pause: true},
+		Status: api.PerconaXtraDBClusterStatus{
+			Status:             api.AppStateError,
+			Size:               3,
+			Ready:              2,
+			ObservedGeneration: 2,
+			Messages:           []string{"pxc: pod cluster1-pxc-2: ImagePullFailed: not found"},
+			PendingChanges: &api.PendingChangesStatus{
+				Actions: []api.PendingAction{{Component: "pxc", Description: "rolling restart"}},
+			},
+		},
+	}
+	objs := []k8sruntime.Object{
+		cluster,
+		&api.PerconaXtraDBCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster0", Namespace: "db"}},
+		&api.PerconaXtraDBClusterBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "db"},
+			Spec:       api.PXCBackupSpec{PXCCluster: "cluster1", StorageName: "s3-us-west"},
+			Status:     api.PXCBackupStatus{State: api.BackupRunning},
+		},
+		&api.PerconaXtraDBClusterBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: "done", Namespace: "db"},
+			Spec:       api.PXCBackupSpec{PXCCluster: "cluster1", StorageName: "s3-us-west"},
+			Status:     api.PXCBackupStatus{State: api.BackupSucceeded},
+		},
+		&api.PerconaXtraDBClusterRestore{
+			ObjectMeta: metav1.ObjectMeta{Name: "restore1", Namespace: "db"},
+			Spec:       api.PerconaXtraDBClusterRestoreSpec{PXCCluster: "cluster1"},
+		},
+	}
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()
+	return NewServer(cl, ":0", tokenFile)
+}
+
+func TestServeHTTP(t *testing.T) {
+	srv := newTestServer(t)
+
+	tests := map[string]struct {
+		method string
+		token  string
+		code   int
+	}{
+		"no token":    {method: http.MethodGet, code: http.StatusUnauthorized},
+		"wrong token": {method: http.MethodGet, token: "wrong", code: http.StatusUnauthorized},
+		"post":        {method: http.MethodPost, token: "secret", code: http.StatusMethodNotAllowed},
+		"ok":          {method: http.MethodGet, token: "secret", code: http.StatusOK},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, Path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			rec := httptest.NewRecorder()
+			srv.ServeHTTP(rec, req)
+			if rec.Code != tt.code {
+				t.Errorf("expected code %d, got %d: %s", tt.code, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestClusters(t *testing.T) {
+	srv := newTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, Path+"?namespace=db", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	var clusters []Cluster
+	if err := json.NewDecoder(rec.Body).Decode(&clusters); err != nil {
+		t.Fatal(err)
+	}
+	if len(clusters) != 2 || clusters[0].Name != "cluster0" || clusters[1].Name != "cluster1" {
+		t.Fatalf("unexpected clusters %+v", clusters)
+	}
+
+	if clusters[0].Phase != api.AppStateUnknown {
+		t.Errorf("expected phase %s for a new cluster, got %s", api.AppStateUnknown, clusters[0].Phase)
+	}
+
+	cl := clusters[1]
+	if cl.Phase != api.AppStateError || !cl.Paused || cl.Generation != 3 || cl.ObservedGeneration != 2 {
+		t.Errorf("unexpected cluster summary %+v", cl)
+	}
+	if len(cl.PendingActions) != 1 || len(cl.Errors) != 1 {
+		t.Errorf("expected the pending actions and errors of the cluster, got %+v", cl)
+	}
+	if len(cl.Backups) != 1 || cl.Backups[0].Name != "running" || cl.Backups[0].Storage != "s3-us-west" {
+		t.Errorf("expected only the running backup, got %+v", cl.Backups)
+	}
+	if len(cl.Restores) != 1 || cl.Restores[0].State != "New" {
+		t.Errorf("expected the new restore, got %+v", cl.Restores)
+	}
+}
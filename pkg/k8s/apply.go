@@ -0,0 +1,137 @@
+package k8s
+
+import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// FieldManager is the name of the operator in managed fields of the objects it applies.
+const FieldManager = "percona-xtradb-cluster-operator"
+
+// UpdateFieldManager is the name of the operator in managed fields of the objects it creates, updates and patches.
+// It differs from FieldManager, so the fields of these requests aren't mistaken for the fields
+// of the updates made by the operator versions which didn't use server-side apply.
+const UpdateFieldManager = "percona-xtradb-cluster-operator-update"
+
+// csaManagers are the field managers of the updates made by the operator versions which didn't use server-side apply:
+// the client names them after the operator binary, or the manager binary of development builds.
+var csaManagers = sets.New(FieldManager, "manager")
+
+// migratedObjects keeps the objects checked by migrateManagedFields, so every object is read only once per process.
+var migratedObjects sync.Map
+
+// Apply creates or updates the object with server-side apply.
+// Only the fields set in the object are applied, so the operator owns only them and fields set by other
+// controllers (e.g. VPA, GitOps tools, admission mutators) are kept. Ownership isn't forced: the apply fails
+// with a conflict if another manager owns a field the operator sets to a different value, unless
+// client.ForceOwnership is passed in opts.
+func Apply(ctx context.Context, cl client.Client, obj client.Object, opts ...client.PatchOption) error {
+	u, err := ApplyConfiguration(obj, cl.Scheme())
+	if err != nil {
+		return err
+	}
+
+	if err := migrateManagedFields(ctx, cl, u); err != nil {
+		return errors.Wrapf(err, "migrate managed fields of %s", obj.GetName())
+	}
+
+	opts = append([]client.PatchOption{client.FieldOwner(FieldManager)}, opts...)
+	if err := cl.Patch(ctx, u, client.Apply, opts...); err != nil {
+		return err
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj)
+}
+
+// ApplyConfiguration returns the fields of the object the operator applies. The object is serialized
+// without the status, the metadata set by the API server and the null values of unset typed fields.
+func ApplyConfiguration(obj client.Object, scheme *runtime.Scheme) (*unstructured.Unstructured, error) {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "get object kind")
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert object")
+	}
+	delete(content, "status")
+	if metadata, ok := content["metadata"].(map[string]any); ok {
+		for _, f := range []string{"creationTimestamp", "deletionTimestamp", "generation", "managedFields", "resourceVersion", "selfLink", "uid"} {
+			delete(metadata, f)
+		}
+	}
+	pruneNulls(content)
+
+	u := &unstructured.Unstructured{Object: content}
+	u.SetGroupVersionKind(gvk)
+	return u, nil
+}
+
+// pruneNulls removes the null values, e.g. creationTimestamp of pod templates.
+// Empty objects are kept, some of them are meaningful (e.g. emptyDir volumes).
+func pruneNulls(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if val == nil {
+				delete(v, k)
+				continue
+			}
+			pruneNulls(val)
+		}
+	case []any:
+		for _, val := range v {
+			pruneNulls(val)
+		}
+	}
+}
+
+// UpgradeManagedFields moves the fields owned by the updates of the operator versions which didn't use
+// server-side apply to FieldManager, so the operator can change them without conflicts and the fields
+// it doesn't set anymore are removed by the next apply.
+func UpgradeManagedFields(obj runtime.Object) error {
+	return csaupgrade.UpgradeManagedFields(obj, csaManagers, FieldManager)
+}
+
+// migrateManagedFields runs UpgradeManagedFields on the object in the API server.
+// The objects created with server-side apply have nothing to migrate, so every object is checked once per process.
+func migrateManagedFields(ctx context.Context, cl client.Client, obj *unstructured.Unstructured) error {
+	key := obj.GroupVersionKind().String() + "/" + obj.GetNamespace() + "/" + obj.GetName()
+	if _, ok := migratedObjects.Load(key); ok {
+		return nil
+	}
+
+	// unstructured objects are read from the API server, cached objects don't have managed fields
+	live := new(unstructured.Unstructured)
+	live.SetGroupVersionKind(obj.GroupVersionKind())
+	err := cl.Get(ctx, client.ObjectKeyFromObject(obj), live)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "get object")
+	}
+
+	if err == nil {
+		patch, err := csaupgrade.UpgradeManagedFieldsPatch(live, csaManagers, FieldManager)
+		if err != nil {
+			return errors.Wrap(err, "upgrade managed fields")
+		}
+		if patch != nil {
+			if err := cl.Patch(ctx, live, client.RawPatch(types.JSONPatchType, patch)); err != nil {
+				return errors.Wrap(err, "patch managed fields")
+			}
+		}
+	}
+
+	migratedObjects.Store(key, struct{}{})
+	return nil
+}
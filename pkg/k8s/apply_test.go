@@ -0,0 +1,145 @@
+package k8s_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/managedfields/managedfieldstest"
+	"k8s.io/client-go/applyconfigurations"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("Server-side apply", func() {
+	var f managedfieldstest.TestFieldManager
+
+	BeforeEach(func() {
+		f = managedfieldstest.NewTestFieldManager(applyconfigurations.NewTypeConverter(scheme.Scheme),
+			appsv1.SchemeGroupVersion.WithKind("StatefulSet"))
+	})
+
+	desired := func(replicas int32, env ...corev1.EnvVar) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cluster1-pxc",
+				Namespace: "pxc",
+				Labels:    map[string]string{"app.kubernetes.io/instance": "cluster1"},
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To(replicas),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/instance": "cluster1"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app.kubernetes.io/instance": "cluster1"}},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "pxc", Image: "percona/percona-xtradb-cluster:8.0", Env: env}},
+						Volumes: []corev1.Volume{{
+							Name:         "tmp",
+							VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+						}},
+					},
+				},
+			},
+		}
+	}
+
+	applyConfiguration := func(sts *appsv1.StatefulSet) *unstructured.Unstructured {
+		u, err := k8s.ApplyConfiguration(sts, scheme.Scheme)
+		Expect(err).ToNot(HaveOccurred())
+		return u
+	}
+
+	live := func() *appsv1.StatefulSet {
+		sts := new(appsv1.StatefulSet)
+		u := f.Live().(*unstructured.Unstructured)
+		Expect(runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, sts)).To(Succeed())
+		return sts
+	}
+
+	// vpa sets resources of the pxc container and an annotation of the statefulset
+	applyVPA := func() {
+		vpa := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "apps/v1",
+			"kind":       "StatefulSet",
+			"metadata": map[string]any{
+				"name":        "cluster1-pxc",
+				"namespace":   "pxc",
+				"annotations": map[string]any{"vpa/updated": "true"},
+			},
+			"spec": map[string]any{
+				"template": map[string]any{
+					"spec": map[string]any{
+						"containers": []any{map[string]any{
+							"name":      "pxc",
+							"resources": map[string]any{"requests": map[string]any{"memory": "2Gi"}},
+						}},
+					},
+				},
+			},
+		}}
+		Expect(f.Apply(vpa, "vpa", false)).To(Succeed())
+	}
+
+	It("should apply only set fields", func() {
+		u := applyConfiguration(desired(3))
+
+		Expect(u.GetKind()).To(Equal("StatefulSet"))
+		Expect(u.Object).ToNot(HaveKey("status"))
+		Expect(u.Object["metadata"]).ToNot(HaveKey("creationTimestamp"))
+		Expect(u.Object["spec"].(map[string]any)["template"].(map[string]any)["metadata"]).ToNot(HaveKey("creationTimestamp"))
+
+		volumes, _, err := unstructured.NestedSlice(u.Object, "spec", "template", "spec", "volumes")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(volumes[0]).To(HaveKeyWithValue("emptyDir", map[string]any{}))
+	})
+
+	It("should keep fields of another field manager", func() {
+		Expect(f.Apply(applyConfiguration(desired(3)), k8s.FieldManager, false)).To(Succeed())
+		applyVPA()
+
+		Expect(f.Apply(applyConfiguration(desired(3)), k8s.FieldManager, false)).To(Succeed())
+
+		sts := live()
+		Expect(sts.Annotations).To(HaveKeyWithValue("vpa/updated", "true"))
+		Expect(sts.Spec.Template.Spec.Containers[0].Resources.Requests.Memory().String()).To(Equal("2Gi"))
+		Expect(sts.Spec.Template.Spec.Containers[0].Image).To(Equal("percona/percona-xtradb-cluster:8.0"))
+	})
+
+	It("should not take ownership of fields of another field manager", func() {
+		Expect(f.Apply(applyConfiguration(desired(3)), k8s.FieldManager, false)).To(Succeed())
+
+		scaled := f.Live().(*unstructured.Unstructured)
+		Expect(unstructured.SetNestedField(scaled.Object, int64(5), "spec", "replicas")).To(Succeed())
+		Expect(f.Update(scaled, "kubectl-scale")).To(Succeed())
+
+		err := f.Apply(applyConfiguration(desired(3)), k8s.FieldManager, false)
+		Expect(k8serrors.IsConflict(err)).To(BeTrue(), "expected a conflict, got %v", err)
+		Expect(*live().Spec.Replicas).To(Equal(int32(5)))
+
+		Expect(f.Apply(applyConfiguration(desired(3)), k8s.FieldManager, true)).To(Succeed())
+		Expect(*live().Spec.Replicas).To(Equal(int32(3)))
+	})
+
+	It("should remove fields set by updates of the previous operator versions after migration", func() {
+		old := applyConfiguration(desired(3, corev1.EnvVar{Name: "OLD", Value: "1"}))
+		Expect(f.Update(old, k8s.FieldManager)).To(Succeed())
+
+		Expect(f.Apply(applyConfiguration(desired(3)), k8s.FieldManager, false)).To(Succeed())
+		Expect(live().Spec.Template.Spec.Containers[0].Env).ToNot(BeEmpty(), "fields of the update are kept without migration")
+
+		migrated := f.Live()
+		Expect(k8s.UpgradeManagedFields(migrated)).To(Succeed())
+		Expect(f.Update(migrated, k8s.UpdateFieldManager)).To(Succeed())
+		for _, entry := range f.ManagedFields() {
+			Expect(entry.Operation).To(Equal(metav1.ManagedFieldsOperationApply))
+		}
+
+		Expect(f.Apply(applyConfiguration(desired(3)), k8s.FieldManager, false)).To(Succeed())
+		Expect(live().Spec.Template.Spec.Containers[0].Env).To(BeEmpty())
+	})
+})
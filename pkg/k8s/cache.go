@@ -0,0 +1,54 @@
+package k8s
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CacheTransform strips the data the operator doesn't use from cached objects to reduce memory usage.
+// Managed fields are stripped from all objects. The last applied configuration (which duplicates the object)
+// is stripped only from objects that are never updated from the cache, so updates can't drop it.
+func CacheTransform() toolscache.TransformFunc {
+	stripManagedFields := cache.TransformStripManagedFields()
+
+	return func(in any) (any, error) {
+		in, err := stripManagedFields(in)
+		if err != nil {
+			return in, err
+		}
+
+		switch in.(type) {
+		case *corev1.ConfigMap, *eventsv1.Event:
+			obj := in.(client.Object)
+			annotations := obj.GetAnnotations()
+			if _, ok := annotations[corev1.LastAppliedConfigAnnotation]; ok {
+				delete(annotations, corev1.LastAppliedConfigAnnotation)
+				obj.SetAnnotations(annotations)
+			}
+		}
+
+		return in, nil
+	}
+}
+
+// CacheByObject returns cache options of the types the operator needs only partially.
+// Only events about PVCs are used (to check volume resizing), so other events aren't cached.
+func CacheByObject() map[client.Object]cache.ByObject {
+	return map[client.Object]cache.ByObject{
+		&eventsv1.Event{}: {Field: fields.OneTermEqualSelector("regarding.kind", "PersistentVolumeClaim")},
+	}
+}
+
+// UncachedObjects returns the types the operator reads directly from the API server.
+// Nodes and persistent volumes are cluster scoped and the operator may not be allowed to watch them,
+// so they are read only when a cluster needs them.
+func UncachedObjects() []client.Object {
+	return []client.Object{
+		&corev1.Node{},
+		&corev1.PersistentVolume{},
+	}
+}
@@ -0,0 +1,38 @@
+package k8s_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Cache transform", func() {
+	meta := func() metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:          "obj",
+			Annotations:   map[string]string{corev1.LastAppliedConfigAnnotation: "{}", "keep": "true"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl"}},
+		}
+	}
+	transform := k8s.CacheTransform()
+
+	It("should strip last applied configuration from configmaps", func() {
+		out, err := transform(&corev1.ConfigMap{ObjectMeta: meta()})
+		Expect(err).ToNot(HaveOccurred())
+
+		cm := out.(*corev1.ConfigMap)
+		Expect(cm.ManagedFields).To(BeNil())
+		Expect(cm.Annotations).To(Equal(map[string]string{"keep": "true"}))
+	})
+
+	It("should keep last applied configuration of secrets", func() {
+		out, err := transform(&corev1.Secret{ObjectMeta: meta()})
+		Expect(err).ToNot(HaveOccurred())
+
+		secret := out.(*corev1.Secret)
+		Expect(secret.ManagedFields).To(BeNil())
+		Expect(secret.Annotations).To(HaveKey(corev1.LastAppliedConfigAnnotation))
+	})
+})
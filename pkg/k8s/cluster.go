@@ -17,8 +17,15 @@ import (
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/statefulset"
 )
 
+// PauseCluster sets spec.pause of the cluster and reports whether the PXC pods are deleted.
+// If the spec is owned by a GitOps tool, the pause is suggested in the status instead
+// and the cluster is reported as running until the suggestion is applied.
 func PauseCluster(ctx context.Context, cl client.Client, cr *api.PerconaXtraDBCluster) (bool, error) {
 	if !cr.Spec.Pause {
+		if cr.Spec.GitOps.IsEnabled() {
+			return false, suggestSpecChange(ctx, cl, cr, "spec.pause", "true", "the operator has to stop the cluster")
+		}
+
 		cr := cr.DeepCopy() // calling patch will overwrite cr, removing values set by CheckNsetDefaults. We need to copy it into a new variable
 
 		patch := client.MergeFrom(cr.DeepCopy())
@@ -52,8 +59,14 @@ func PauseCluster(ctx context.Context, cl client.Client, cr *api.PerconaXtraDBCl
 	return true, nil
 }
 
+// UnpauseCluster resets spec.pause of the cluster and reports whether all PXC pods are created.
+// If the spec is owned by a GitOps tool, the change is suggested in the status instead.
 func UnpauseCluster(ctx context.Context, cl client.Client, cr *api.PerconaXtraDBCluster) (bool, error) {
 	if cr.Spec.Pause {
+		if cr.Spec.GitOps.IsEnabled() {
+			return false, suggestSpecChange(ctx, cl, cr, "spec.pause", "false", "the operator has to start the cluster")
+		}
+
 		cr := cr.DeepCopy() // calling patch will overwrite cr, removing values set by CheckNsetDefaults. We need to copy it into a new variable
 
 		patch := client.MergeFrom(cr.DeepCopy())
@@ -85,6 +98,51 @@ func UnpauseCluster(ctx context.Context, cl client.Client, cr *api.PerconaXtraDB
 	return true, nil
 }
 
+// suggestSpecChange publishes the spec change in the status of the cluster owned by a GitOps tool.
+func suggestSpecChange(ctx context.Context, cl client.Client, cr *api.PerconaXtraDBCluster, path, value, reason string) error {
+	err := PatchStatus(ctx, cl, cr.DeepCopy(), func(c *api.PerconaXtraDBCluster) {
+		c.Status.SuggestSpecChange(path, value, reason)
+	})
+	return errors.Wrapf(err, "suggest %s", path)
+}
+
+// DeletePVCs deletes data PVCs of all PXC pods except the first one.
+// It returns true once only the first PVC is left.
+func DeletePVCs(ctx context.Context, cl client.Client, cr *api.PerconaXtraDBCluster) (bool, error) {
+	pxcNode := statefulset.NewNode(cr)
+
+	pvcs := corev1.PersistentVolumeClaimList{}
+	err := cl.List(
+		ctx,
+		&pvcs,
+		&client.ListOptions{
+			Namespace:     cr.Namespace,
+			LabelSelector: labels.SelectorFromSet(pxcNode.Labels()),
+		},
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "get pvc list")
+	}
+
+	pvcNameTemplate := app.DataVolumeName + "-" + pxcNode.StatefulSet().Name
+	for _, pvc := range pvcs.Items {
+		// check prefix just in case, to be sure we're not going to delete a wrong pvc
+		if pvc.Name == pvcNameTemplate+"-0" || !strings.HasPrefix(pvc.Name, pvcNameTemplate) {
+			continue
+		}
+		if pvc.DeletionTimestamp != nil {
+			continue
+		}
+
+		err = cl.Delete(ctx, &pvc)
+		if client.IgnoreNotFound(err) != nil {
+			return false, errors.Wrap(err, "delete pvc")
+		}
+	}
+
+	return len(pvcs.Items) == 1, nil
+}
+
 // Deprecated: PauseClusterWithWait is a function which blocks reconcile process. Use PauseCluster instead
 func PauseClusterWithWait(ctx context.Context, cl client.Client, cr *api.PerconaXtraDBCluster, deletePVC bool) error {
 	cr = cr.DeepCopy()
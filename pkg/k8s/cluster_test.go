@@ -0,0 +1,67 @@
+package k8s_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" // nolint
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+)
+
+var _ = Describe("Pause cluster", func() {
+	ctx := context.Background()
+
+	newClient := func(gitOps bool) (client.Client, *api.PerconaXtraDBCluster) {
+		s := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(s)).To(Succeed())
+		Expect(api.SchemeBuilder.AddToScheme(s)).To(Succeed())
+
+		cr := &api.PerconaXtraDBCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "ns"},
+			Spec: api.PerconaXtraDBClusterSpec{
+				PXC:    &api.PXCSpec{PodSpec: &api.PodSpec{Size: 3}},
+				GitOps: &api.GitOpsSpec{Enabled: gitOps},
+			},
+		}
+		cl := fake.NewClientBuilder().WithScheme(s).WithObjects(cr).WithStatusSubresource(cr).Build()
+
+		current := new(api.PerconaXtraDBCluster)
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(cr), current)).To(Succeed())
+		return cl, current
+	}
+
+	It("should patch the spec", func() {
+		cl, cr := newClient(false)
+
+		paused, err := k8s.PauseCluster(ctx, cl, cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paused).To(BeTrue())
+
+		current := new(api.PerconaXtraDBCluster)
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(cr), current)).To(Succeed())
+		Expect(current.Spec.Pause).To(BeTrue())
+		Expect(current.Status.GitOps).To(BeNil())
+	})
+
+	It("should suggest the pause if the spec is owned by a GitOps tool", func() {
+		cl, cr := newClient(true)
+
+		paused, err := k8s.PauseCluster(ctx, cl, cr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paused).To(BeFalse())
+
+		current := new(api.PerconaXtraDBCluster)
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(cr), current)).To(Succeed())
+		Expect(current.Spec.Pause).To(BeFalse())
+		Expect(current.Status.GitOps).NotTo(BeNil())
+		Expect(current.Status.GitOps.SuggestedChanges).To(ConsistOf(HaveField("Path", "spec.pause")))
+		Expect(current.Status.GitOps.SuggestedChanges[0].Value).To(Equal("true"))
+	})
+})
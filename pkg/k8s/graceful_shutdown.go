@@ -10,6 +10,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var log = logf.Log
@@ -75,3 +76,17 @@ func checkClusters(cl client.Client, namespaces []string) (bool, error) {
 
 	return true, nil
 }
+
+// DetachReconciler runs reconciles with a context which isn't cancelled when
+// the manager is stopping. It lets an in-flight reconcile finish its current
+// phase within the graceful shutdown timeout instead of being interrupted at
+// an arbitrary point, e.g. in the middle of a restore or a smart update.
+// Reconciles which start after the shutdown began are refused.
+func DetachReconciler(r reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		if err := ctx.Err(); err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "operator is stopping")
+		}
+		return r.Reconcile(context.WithoutCancel(ctx), req)
+	})
+}
@@ -0,0 +1,53 @@
+package k8s_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Detached reconciler", func() {
+	type result struct {
+		res reconcile.Result
+		err error
+	}
+
+	It("should finish in-flight reconciles and refuse new ones after shutdown begins", func() {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		calls := 0
+		var innerErr error
+
+		r := k8s.DetachReconciler(reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+			calls++
+			close(started)
+			<-release
+			innerErr = ctx.Err()
+			return reconcile.Result{Requeue: true}, nil
+		}))
+
+		ctx, shutdown := context.WithCancel(context.Background())
+		done := make(chan result)
+		go func() {
+			res, err := r.Reconcile(ctx, reconcile.Request{})
+			done <- result{res, err}
+		}()
+
+		Eventually(started).Should(BeClosed())
+		shutdown()
+		close(release)
+
+		var inFlight result
+		Eventually(done).Should(Receive(&inFlight))
+		Expect(inFlight.err).NotTo(HaveOccurred())
+		Expect(inFlight.res.Requeue).To(BeTrue())
+		Expect(innerErr).NotTo(HaveOccurred())
+
+		_, err := r.Reconcile(ctx, reconcile.Request{})
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(calls).To(Equal(1))
+	})
+})
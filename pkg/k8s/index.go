@@ -0,0 +1,90 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	eventsv1 "k8s.io/api/events/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+const (
+	// IndexPXCCluster indexes backups and restores by the cluster name.
+	IndexPXCCluster = "spec.pxcCluster"
+	// IndexExternalUsersSecret indexes clusters by the externally managed users secret.
+	IndexExternalUsersSecret = "spec.secretsName"
+	// IndexEventRegarding indexes events by the name of the object they are about.
+	IndexEventRegarding = "regarding.name"
+)
+
+// Index is a cache field index used by the controllers.
+type Index struct {
+	Object  client.Object
+	Field   string
+	Extract client.IndexerFunc
+}
+
+// Indexes returns the field indexes that should be added to the manager cache.
+func Indexes() []Index {
+	return []Index{
+		{
+			Object: new(api.PerconaXtraDBClusterBackup),
+			Field:  IndexPXCCluster,
+			Extract: func(obj client.Object) []string {
+				return []string{obj.(*api.PerconaXtraDBClusterBackup).Spec.PXCCluster}
+			},
+		},
+		{
+			Object: new(api.PerconaXtraDBClusterRestore),
+			Field:  IndexPXCCluster,
+			Extract: func(obj client.Object) []string {
+				return []string{obj.(*api.PerconaXtraDBClusterRestore).Spec.PXCCluster}
+			},
+		},
+		{
+			Object: new(api.PerconaXtraDBCluster),
+			Field:  IndexExternalUsersSecret,
+			Extract: func(obj client.Object) []string {
+				cr := obj.(*api.PerconaXtraDBCluster)
+				if !cr.Spec.ExternalUsersSecret {
+					return nil
+				}
+				if cr.Spec.SecretsName == "" {
+					return []string{cr.Name + "-secrets"}
+				}
+				return []string{cr.Spec.SecretsName}
+			},
+		},
+		{
+			Object: new(eventsv1.Event),
+			Field:  IndexEventRegarding,
+			Extract: func(obj client.Object) []string {
+				return []string{obj.(*eventsv1.Event).Regarding.Name}
+			},
+		},
+	}
+}
+
+// AddIndexes adds the field indexes to the indexer.
+func AddIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	for _, idx := range Indexes() {
+		if err := indexer.IndexField(ctx, idx.Object, idx.Field, idx.Extract); err != nil {
+			return errors.Wrapf(err, "index %T by %s", idx.Object, idx.Field)
+		}
+	}
+	return nil
+}
+
+// ListByIndex lists objects of the namespace with the indexed field equal to the value.
+// If the client can't list by the index (e.g. it reads from the API server directly),
+// all objects of the namespace are listed, so callers should filter the items as well.
+func ListByIndex(ctx context.Context, cl client.Reader, list client.ObjectList, namespace, field, value string) error {
+	err := cl.List(ctx, list, client.InNamespace(namespace), client.MatchingFields{field: value})
+	if err == nil {
+		return nil
+	}
+
+	return cl.List(ctx, list, client.InNamespace(namespace))
+}
@@ -0,0 +1,67 @@
+package k8s
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+)
+
+var nodeSelectorOperators = map[corev1.NodeSelectorOperator]selection.Operator{
+	corev1.NodeSelectorOpIn:           selection.In,
+	corev1.NodeSelectorOpNotIn:        selection.NotIn,
+	corev1.NodeSelectorOpExists:       selection.Exists,
+	corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	corev1.NodeSelectorOpGt:           selection.GreaterThan,
+	corev1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+// NodeMatchesSelectorTerms returns true if the node matches any of the terms,
+// the same way the scheduler evaluates the required node affinity.
+// A term without requirements doesn't match any node.
+func NodeMatchesSelectorTerms(node *corev1.Node, terms []corev1.NodeSelectorTerm) bool {
+	for _, term := range terms {
+		if nodeMatchesSelectorTerm(node, term) {
+			return true
+		}
+	}
+	return false
+}
+
+func nodeMatchesSelectorTerm(node *corev1.Node, term corev1.NodeSelectorTerm) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+
+	for _, expr := range term.MatchExpressions {
+		op, ok := nodeSelectorOperators[expr.Operator]
+		if !ok {
+			return false
+		}
+		req, err := labels.NewRequirement(expr.Key, op, expr.Values)
+		if err != nil || !req.Matches(labels.Set(node.Labels)) {
+			return false
+		}
+	}
+
+	for _, field := range term.MatchFields {
+		if field.Key != "metadata.name" {
+			return false
+		}
+		switch field.Operator {
+		case corev1.NodeSelectorOpIn:
+			if !slices.Contains(field.Values, node.Name) {
+				return false
+			}
+		case corev1.NodeSelectorOpNotIn:
+			if slices.Contains(field.Values, node.Name) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+
+	return true
+}
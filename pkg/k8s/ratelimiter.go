@@ -0,0 +1,88 @@
+package k8s
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	BackoffBaseDelayEnvVar        = "RECONCILE_BACKOFF_BASE_DELAY"
+	BackoffMaxDelayEnvVar         = "RECONCILE_BACKOFF_MAX_DELAY"
+	BackoffFailureThresholdEnvVar = "RECONCILE_BACKOFF_FAILURE_THRESHOLD"
+	ReconcileQPSEnvVar            = "RECONCILE_QPS"
+	ReconcileBurstEnvVar          = "RECONCILE_BURST"
+)
+
+// NewRateLimiter returns the workqueue rate limiter for failed reconciles.
+// Failed objects are requeued with exponential backoff from the base to the max delay.
+// After the failure threshold is reached the object is requeued with the max delay right away,
+// so a permanently broken object doesn't keep the controller busy.
+// Requeues of all objects are limited by the overall QPS and burst.
+// Defaults are the same as controller-runtime ones.
+func NewRateLimiter() (workqueue.TypedRateLimiter[reconcile.Request], error) {
+	baseDelay, err := durationFromEnv(BackoffBaseDelayEnvVar, 5*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	maxDelay, err := durationFromEnv(BackoffMaxDelayEnvVar, 1000*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	if baseDelay > maxDelay {
+		return nil, errors.Errorf("%s should be less than %s", BackoffBaseDelayEnvVar, BackoffMaxDelayEnvVar)
+	}
+	threshold, err := intFromEnv(BackoffFailureThresholdEnvVar, 0)
+	if err != nil {
+		return nil, err
+	}
+	qps, err := intFromEnv(ReconcileQPSEnvVar, 10)
+	if err != nil {
+		return nil, err
+	}
+	burst, err := intFromEnv(ReconcileBurstEnvVar, 100)
+	if err != nil {
+		return nil, err
+	}
+
+	limiters := []workqueue.TypedRateLimiter[reconcile.Request]{
+		workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](baseDelay, maxDelay),
+		&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(qps), burst)},
+	}
+	if threshold > 0 {
+		limiters = append(limiters, workqueue.NewTypedItemFastSlowRateLimiter[reconcile.Request](0, maxDelay, threshold))
+	}
+
+	return workqueue.NewTypedMaxOfRateLimiter(limiters...), nil
+}
+
+func durationFromEnv(envVar string, def time.Duration) (time.Duration, error) {
+	s := os.Getenv(envVar)
+	if s == "" {
+		return def, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, errors.Errorf("invalid %s value (%s), should be positive duration", envVar, s)
+	}
+	return d, nil
+}
+
+func intFromEnv(envVar string, def int) (int, error) {
+	s := os.Getenv(envVar)
+	if s == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, errors.Errorf("invalid %s value (%s), should be non-negative int", envVar, s)
+	}
+	return n, nil
+}
@@ -0,0 +1,56 @@
+package k8s_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var _ = Describe("Rate limiter", func() {
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "cluster1", Namespace: "test"}}
+
+	It("should back off exponentially", func() {
+		GinkgoT().Setenv(k8s.BackoffBaseDelayEnvVar, "1s")
+		GinkgoT().Setenv(k8s.BackoffMaxDelayEnvVar, "5s")
+
+		rl, err := k8s.NewRateLimiter()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(rl.When(req)).To(Equal(time.Second))
+		Expect(rl.When(req)).To(Equal(2 * time.Second))
+		Expect(rl.When(req)).To(Equal(4 * time.Second))
+		Expect(rl.When(req)).To(Equal(5 * time.Second))
+
+		rl.Forget(req)
+		Expect(rl.When(req)).To(Equal(time.Second))
+	})
+
+	It("should use max delay after failure threshold", func() {
+		GinkgoT().Setenv(k8s.BackoffBaseDelayEnvVar, "1s")
+		GinkgoT().Setenv(k8s.BackoffMaxDelayEnvVar, "1m")
+		GinkgoT().Setenv(k8s.BackoffFailureThresholdEnvVar, "2")
+
+		rl, err := k8s.NewRateLimiter()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(rl.When(req)).To(Equal(time.Second))
+		Expect(rl.When(req)).To(Equal(2 * time.Second))
+		Expect(rl.When(req)).To(Equal(time.Minute))
+	})
+
+	It("should fail on invalid values", func() {
+		GinkgoT().Setenv(k8s.BackoffBaseDelayEnvVar, "1m")
+		GinkgoT().Setenv(k8s.BackoffMaxDelayEnvVar, "1s")
+
+		_, err := k8s.NewRateLimiter()
+		Expect(err).To(HaveOccurred())
+
+		GinkgoT().Setenv(k8s.BackoffMaxDelayEnvVar, "forever")
+		_, err = k8s.NewRateLimiter()
+		Expect(err).To(HaveOccurred())
+	})
+})
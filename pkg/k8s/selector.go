@@ -0,0 +1,87 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+// ClusterSelectorPredicate filters out objects that refer to clusters not matching WATCH_LABEL_SELECTOR,
+// so the objects are reconciled by the same operator instance as their cluster.
+// If the selector isn't set, all objects are accepted.
+func ClusterSelectorPredicate(cl client.Reader, cluster func(client.Object) types.NamespacedName) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		selector, err := GetWatchLabelSelector()
+		if err != nil || selector == nil {
+			return true
+		}
+
+		cr := new(api.PerconaXtraDBCluster)
+		if err := cl.Get(context.Background(), cluster(obj), cr); err != nil {
+			return false
+		}
+		return selector.Matches(labels.Set(cr.Labels))
+	})
+}
+
+// NamespaceWatched returns true if labels of the namespace match WATCH_NAMESPACE_SELECTOR
+// or the selector isn't set.
+func NamespaceWatched(ctx context.Context, cl client.Reader, namespace string) (bool, error) {
+	selector, err := GetWatchNamespaceSelector()
+	if err != nil || selector == nil {
+		return true, err
+	}
+
+	ns := new(corev1.Namespace)
+	if err := cl.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return false, client.IgnoreNotFound(errors.Wrapf(err, "get namespace %s", namespace))
+	}
+	return selector.Matches(labels.Set(ns.Labels)), nil
+}
+
+// NamespaceSelectorPredicate filters out objects from namespaces not matching WATCH_NAMESPACE_SELECTOR.
+func NamespaceSelectorPredicate(cl client.Reader) predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		ok, err := NamespaceWatched(context.Background(), cl, obj.GetNamespace())
+		return ok || err != nil
+	})
+}
+
+// WatchNamespaces enqueues all objects of the namespace if its labels are changed,
+// so the objects are reconciled as soon as the namespace starts matching WATCH_NAMESPACE_SELECTOR.
+// Namespaces are watched only if the selector is set, since it requires cluster-wide permissions.
+func WatchNamespaces(b *builder.Builder, cl client.Reader, newList func() client.ObjectList) *builder.Builder {
+	if selector, err := GetWatchNamespaceSelector(); err != nil || selector == nil {
+		return b
+	}
+
+	return b.Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		list := newList()
+		if err := cl.List(ctx, list, client.InNamespace(obj.GetName())); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to list objects", "namespace", obj.GetName())
+			return nil
+		}
+
+		var requests []reconcile.Request
+		_ = meta.EachListItem(list, func(o runtime.Object) error {
+			if item, ok := o.(client.Object); ok {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(item)})
+			}
+			return nil
+		})
+		return requests
+	}), builder.WithPredicates(predicate.LabelChangedPredicate{}))
+}
@@ -0,0 +1,72 @@
+package k8s_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" // nolint
+)
+
+var _ = Describe("Watch label selector", func() {
+	It("should be nil if not set", func() {
+		GinkgoT().Setenv(k8s.WatchLabelSelectorEnvVar, "")
+
+		selector, err := k8s.GetWatchLabelSelector()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(selector).To(BeNil())
+	})
+
+	It("should match cluster labels", func() {
+		GinkgoT().Setenv(k8s.WatchLabelSelectorEnvVar, "tenant=team-a,canary!=true")
+
+		selector, err := k8s.GetWatchLabelSelector()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(selector.Matches(labels.Set{"tenant": "team-a"})).To(BeTrue())
+		Expect(selector.Matches(labels.Set{"tenant": "team-a", "canary": "true"})).To(BeFalse())
+		Expect(selector.Matches(labels.Set{"tenant": "team-b"})).To(BeFalse())
+	})
+
+	It("should fail on invalid selector", func() {
+		GinkgoT().Setenv(k8s.WatchLabelSelectorEnvVar, "tenant in (")
+
+		_, err := k8s.GetWatchLabelSelector()
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Watch namespace selector", func() {
+	ctx := context.Background()
+	cl := fake.NewFakeClient(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"pxc-operator": "enabled"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+	)
+
+	It("should watch all namespaces if not set", func() {
+		GinkgoT().Setenv(k8s.WatchNamespaceSelectorEnvVar, "")
+
+		watched, err := k8s.NamespaceWatched(ctx, cl, "team-b")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(watched).To(BeTrue())
+	})
+
+	It("should watch only labeled namespaces", func() {
+		GinkgoT().Setenv(k8s.WatchNamespaceSelectorEnvVar, "pxc-operator=enabled")
+
+		watched, err := k8s.NamespaceWatched(ctx, cl, "team-a")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(watched).To(BeTrue())
+
+		watched, err = k8s.NamespaceWatched(ctx, cl, "team-b")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(watched).To(BeFalse())
+
+		watched, err = k8s.NamespaceWatched(ctx, cl, "missing")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(watched).To(BeFalse())
+	})
+})
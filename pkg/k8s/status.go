@@ -0,0 +1,87 @@
+package k8s
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8sretry "k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// StatusWriter coalesces status changes of an object made during a reconcile
+// and writes them with a single status patch.
+//
+// Changes are recorded as functions so they can be replayed on the latest
+// version of the object if the patch fails with a conflict.
+type StatusWriter[T client.Object] struct {
+	cl        client.Client
+	obj       T
+	mutations []func(T)
+	onFlush   []func()
+}
+
+func NewStatusWriter[T client.Object](cl client.Client, obj T) *StatusWriter[T] {
+	return &StatusWriter[T]{cl: cl, obj: obj}
+}
+
+// Object returns the object the changes are applied to.
+func (w *StatusWriter[T]) Object() T {
+	return w.obj
+}
+
+// Update applies f to the object and queues it for the next Flush.
+// f should only change the object status.
+func (w *StatusWriter[T]) Update(f func(T)) {
+	f(w.obj)
+	w.mutations = append(w.mutations, f)
+}
+
+// OnFlush registers f to be called once the queued changes are written.
+func (w *StatusWriter[T]) OnFlush(f func()) {
+	w.onFlush = append(w.onFlush, f)
+}
+
+// Flush writes the queued changes. The patch is skipped if they don't change
+// the latest version of the object.
+func (w *StatusWriter[T]) Flush(ctx context.Context) error {
+	if len(w.mutations) == 0 {
+		return nil
+	}
+
+	err := k8sretry.RetryOnConflict(k8sretry.DefaultRetry, func() error {
+		latest := w.obj.DeepCopyObject().(T)
+		if err := w.cl.Get(ctx, client.ObjectKeyFromObject(w.obj), latest); err != nil {
+			return err
+		}
+
+		base := latest.DeepCopyObject().(T)
+		for _, f := range w.mutations {
+			f(latest)
+		}
+		if equality.Semantic.DeepEqual(base, latest) {
+			return nil
+		}
+
+		return w.cl.Status().Patch(ctx, latest, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{}))
+	})
+	if err != nil {
+		return errors.Wrap(err, "patch status")
+	}
+
+	onFlush := w.onFlush
+	w.mutations = nil
+	w.onFlush = nil
+	for _, f := range onFlush {
+		f()
+	}
+
+	return nil
+}
+
+// PatchStatus applies f to the latest version of obj and writes the status change.
+func PatchStatus[T client.Object](ctx context.Context, cl client.Client, obj T, f func(T)) error {
+	w := NewStatusWriter(cl, obj)
+	w.Update(f)
+	return w.Flush(ctx)
+}
@@ -0,0 +1,84 @@
+package k8s_test
+
+import (
+	"context"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake" // nolint
+)
+
+var _ = Describe("Status writer", func() {
+	ctx := context.Background()
+
+	newClient := func() (client.Client, *corev1.Pod) {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns"}}
+		cl := fake.NewClientBuilder().WithObjects(pod).WithStatusSubresource(pod).Build()
+
+		current := new(corev1.Pod)
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(pod), current)).To(Succeed())
+		return cl, current
+	}
+
+	It("should write coalesced changes with a single patch", func() {
+		cl, pod := newClient()
+		rv := pod.ResourceVersion
+
+		w := k8s.NewStatusWriter(cl, pod)
+		w.Update(func(p *corev1.Pod) { p.Status.Phase = corev1.PodPending })
+		w.Update(func(p *corev1.Pod) { p.Status.Phase = corev1.PodRunning })
+		w.Update(func(p *corev1.Pod) { p.Status.Message = "ok" })
+
+		flushed := false
+		w.OnFlush(func() { flushed = true })
+		Expect(w.Flush(ctx)).To(Succeed())
+		Expect(flushed).To(BeTrue())
+
+		current := new(corev1.Pod)
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(pod), current)).To(Succeed())
+		Expect(current.Status.Phase).To(Equal(corev1.PodRunning))
+		Expect(current.Status.Message).To(Equal("ok"))
+		Expect(current.ResourceVersion).To(Equal(incResourceVersion(rv)))
+	})
+
+	It("should replay changes on a stale object", func() {
+		cl, pod := newClient()
+		stale := pod.DeepCopy()
+
+		pod.Labels = map[string]string{"changed": "true"}
+		Expect(cl.Update(ctx, pod)).To(Succeed())
+
+		Expect(k8s.PatchStatus(ctx, cl, stale, func(p *corev1.Pod) {
+			p.Status.Phase = corev1.PodRunning
+		})).To(Succeed())
+
+		current := new(corev1.Pod)
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(pod), current)).To(Succeed())
+		Expect(current.Status.Phase).To(Equal(corev1.PodRunning))
+		Expect(current.Labels).To(HaveKeyWithValue("changed", "true"))
+	})
+
+	It("should skip unchanged status", func() {
+		cl, pod := newClient()
+		rv := pod.ResourceVersion
+
+		Expect(k8s.PatchStatus(ctx, cl, pod, func(p *corev1.Pod) {
+			p.Status.Phase = ""
+		})).To(Succeed())
+
+		current := new(corev1.Pod)
+		Expect(cl.Get(ctx, client.ObjectKeyFromObject(pod), current)).To(Succeed())
+		Expect(current.ResourceVersion).To(Equal(rv))
+	})
+})
+
+func incResourceVersion(rv string) string {
+	n, err := strconv.Atoi(rv)
+	Expect(err).ToNot(HaveOccurred())
+	return strconv.Itoa(n + 1)
+}
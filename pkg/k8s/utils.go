@@ -4,17 +4,30 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
 	"github.com/percona/percona-xtradb-cluster-operator/version"
 )
 
-const WatchNamespaceEnvVar = "WATCH_NAMESPACE"
+const (
+	WatchNamespaceEnvVar     = "WATCH_NAMESPACE"
+	WatchLabelSelectorEnvVar = "WATCH_LABEL_SELECTOR"
+
+	// WatchNamespaceSelectorEnvVar limits watched namespaces to the ones with matching labels.
+	// Namespaces are selected dynamically, so they can be (un)labeled without the operator restart.
+	WatchNamespaceSelectorEnvVar = "WATCH_NAMESPACE_SELECTOR"
+
+	MaxConcurrentReconcilesPXCEnvVar     = "MAX_CONCURRENT_RECONCILES"
+	MaxConcurrentReconcilesBackupEnvVar  = "MAX_CONCURRENT_BACKUP_RECONCILES"
+	MaxConcurrentReconcilesRestoreEnvVar = "MAX_CONCURRENT_RESTORE_RECONCILES"
+)
 
 // GetWatchNamespace returns the namespace the operator should be watching for changes
 func GetWatchNamespace() (string, error) {
@@ -25,6 +38,46 @@ func GetWatchNamespace() (string, error) {
 	return ns, nil
 }
 
+// GetWatchLabelSelector returns the selector of the clusters the operator should reconcile.
+// nil is returned if the operator reconciles all clusters.
+func GetWatchLabelSelector() (labels.Selector, error) {
+	return selectorFromEnv(WatchLabelSelectorEnvVar)
+}
+
+// GetWatchNamespaceSelector returns the selector of the namespaces the operator should reconcile.
+// nil is returned if the operator reconciles all watched namespaces.
+func GetWatchNamespaceSelector() (labels.Selector, error) {
+	return selectorFromEnv(WatchNamespaceSelectorEnvVar)
+}
+
+func selectorFromEnv(envVar string) (labels.Selector, error) {
+	s := os.Getenv(envVar)
+	if s == "" {
+		return nil, nil
+	}
+
+	selector, err := labels.Parse(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %s", envVar)
+	}
+	return selector, nil
+}
+
+// GetMaxConcurrentReconciles returns the number of objects the controller can reconcile in parallel.
+// Objects are reconciled one by one if the variable isn't set.
+func GetMaxConcurrentReconciles(envVar string) (int, error) {
+	s := os.Getenv(envVar)
+	if s == "" {
+		return 1, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, errors.Errorf("invalid %s value (%s), should be positive int", envVar, s)
+	}
+	return n, nil
+}
+
 // GetOperatorNamespace returns the namespace of the operator pod
 func GetOperatorNamespace() (string, error) {
 	nsBytes, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace")
@@ -0,0 +1,371 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+const namespace = "pxc_operator"
+
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+	ResultRequeue = "requeue"
+)
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reconcile_total",
+		Help:      "Number of reconciles per custom resource and result.",
+	}, []string{"controller", "namespace", "name", "result"})
+
+	clusterState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cluster_state",
+		Help:      "Current state of the cluster, the gauge is 1 for the current state and 0 for others.",
+	}, []string{"namespace", "name", "state"})
+
+	backupTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "backup_total",
+		Help:      "Number of finished backups per cluster, storage and state.",
+	}, []string{"namespace", "cluster", "storage", "state"})
+
+	backupLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "backup_last_success_timestamp_seconds",
+		Help:      "Completion time of the last successful backup.",
+	}, []string{"namespace", "cluster", "storage"})
+
+	restoreTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "restore_total",
+		Help:      "Number of finished restores per cluster and state.",
+	}, []string{"namespace", "cluster", "state"})
+
+	restoreLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "restore_last_success_timestamp_seconds",
+		Help:      "Completion time of the last successful restore.",
+	}, []string{"namespace", "cluster"})
+
+	smartUpdateInProgress = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "smart_update_in_progress",
+		Help:      "1 if the smart update of the statefulset is in progress.",
+	}, []string{"namespace", "name", "statefulset"})
+
+	smartUpdatePodRestarts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "smart_update_pod_restarts_total",
+		Help:      "Number of pods restarted by the smart update.",
+	}, []string{"namespace", "name", "statefulset"})
+
+	pitrLatestRestorable = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "pitr_latest_restorable_timestamp_seconds",
+		Help:      "Latest time the cluster can be restored to with point-in-time recovery.",
+	}, []string{"namespace", "cluster"})
+
+	tlsCertificateExpiry = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "tls_certificate_expiration_timestamp_seconds",
+		Help:      "Expiration time of the TLS certificate stored in the secret.",
+	}, []string{"namespace", "cluster", "secret"})
+
+	queriesKilled = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "queries_killed_total",
+		Help:      "Number of queries and connections killed by the query killer.",
+	}, []string{"namespace", "cluster", "rule"})
+)
+
+var (
+	wsrepClusterSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "wsrep_cluster_size",
+		Help:      "Number of nodes in the Galera cluster as seen by the pod.",
+	}, []string{"namespace", "cluster", "pod"})
+
+	wsrepLocalState = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "wsrep_local_state",
+		Help:      "Galera node state: 1 joining, 2 donor, 3 joined, 4 synced.",
+	}, []string{"namespace", "cluster", "pod"})
+
+	wsrepReady = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "wsrep_ready",
+		Help:      "1 if the node accepts queries.",
+	}, []string{"namespace", "cluster", "pod"})
+
+	wsrepClusterPrimary = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "wsrep_cluster_status_primary",
+		Help:      "1 if the node is a part of the primary component.",
+	}, []string{"namespace", "cluster", "pod"})
+
+	wsrepFlowControlPaused = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "wsrep_flow_control_paused",
+		Help:      "Fraction of time the replication was paused by flow control since the last FLUSH STATUS.",
+	}, []string{"namespace", "cluster", "pod"})
+
+	wsrepLocalCertFailures = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "wsrep_local_cert_failures",
+		Help:      "Number of writesets that failed the certification test.",
+	}, []string{"namespace", "cluster", "pod"})
+)
+
+var (
+	replicationIOThreadRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "replication_io_thread_running",
+		Help:      "1 if the replica IO thread of the channel is running.",
+	}, []string{"namespace", "cluster", "channel"})
+
+	replicationSQLThreadRunning = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "replication_sql_thread_running",
+		Help:      "1 if the replica SQL thread of the channel is running.",
+	}, []string{"namespace", "cluster", "channel"})
+
+	replicationSecondsBehindSource = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "replication_seconds_behind_source",
+		Help:      "Replication lag of the channel as reported by SHOW REPLICA STATUS.",
+	}, []string{"namespace", "cluster", "channel"})
+
+	replicationBroken = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "replication_broken",
+		Help:      "1 if the replication channel has an error or one of its threads is stopped.",
+	}, []string{"namespace", "cluster", "channel"})
+)
+
+var clusterStates = []api.AppState{
+	api.AppStateUnknown,
+	api.AppStateInit,
+	api.AppStatePaused,
+	api.AppStateStopping,
+	api.AppStateReady,
+	api.AppStateError,
+}
+
+func init() {
+	metrics.Registry.MustRegister(
+		reconcileTotal,
+		clusterState,
+		backupTotal,
+		backupLastSuccess,
+		restoreTotal,
+		restoreLastSuccess,
+		smartUpdateInProgress,
+		smartUpdatePodRestarts,
+		pitrLatestRestorable,
+		tlsCertificateExpiry,
+		queriesKilled,
+		wsrepClusterSize,
+		wsrepLocalState,
+		wsrepReady,
+		wsrepClusterPrimary,
+		wsrepFlowControlPaused,
+		wsrepLocalCertFailures,
+		replicationIOThreadRunning,
+		replicationSQLThreadRunning,
+		replicationSecondsBehindSource,
+		replicationBroken,
+	)
+}
+
+type instrumentedReconciler struct {
+	controller string
+	reconcile.Reconciler
+}
+
+// InstrumentReconciler counts reconcile results of r per custom resource
+func InstrumentReconciler(controller string, r reconcile.Reconciler) reconcile.Reconciler {
+	return &instrumentedReconciler{controller: controller, Reconciler: r}
+}
+
+func (r *instrumentedReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	res, err := r.Reconciler.Reconcile(ctx, req)
+
+	result := ResultSuccess
+	switch {
+	case err != nil:
+		result = ResultError
+	case res.Requeue:
+		result = ResultRequeue
+	}
+	reconcileTotal.WithLabelValues(r.controller, req.Namespace, req.Name, result).Inc()
+
+	return res, err
+}
+
+func SetClusterState(cr *api.PerconaXtraDBCluster) {
+	for _, s := range clusterStates {
+		v := 0.0
+		if s == cr.Status.Status {
+			v = 1
+		}
+		clusterState.WithLabelValues(cr.Namespace, cr.Name, string(s)).Set(v)
+	}
+}
+
+// DeleteCluster removes series of the deleted cluster
+func DeleteCluster(ns, name string) {
+	labels := prometheus.Labels{"namespace": ns, "name": name}
+	clusterState.DeletePartialMatch(labels)
+	smartUpdateInProgress.DeletePartialMatch(labels)
+	smartUpdatePodRestarts.DeletePartialMatch(labels)
+	reconcileTotal.DeletePartialMatch(labels)
+	pitrLatestRestorable.DeletePartialMatch(prometheus.Labels{"namespace": ns, "cluster": name})
+	tlsCertificateExpiry.DeletePartialMatch(prometheus.Labels{"namespace": ns, "cluster": name})
+	queriesKilled.DeletePartialMatch(prometheus.Labels{"namespace": ns, "cluster": name})
+	DeleteWsrepStatus(ns, name, "")
+	DeleteReplicationStatus(ns, name, "")
+}
+
+func ObserveBackup(bcp *api.PerconaXtraDBClusterBackup) {
+	backupTotal.WithLabelValues(bcp.Namespace, bcp.Spec.PXCCluster, bcp.Spec.StorageName, string(bcp.Status.State)).Inc()
+
+	if bcp.Status.State != api.BackupSucceeded {
+		return
+	}
+	completed := time.Now()
+	if bcp.Status.CompletedAt != nil {
+		completed = bcp.Status.CompletedAt.Time
+	}
+	backupLastSuccess.WithLabelValues(bcp.Namespace, bcp.Spec.PXCCluster, bcp.Spec.StorageName).Set(float64(completed.Unix()))
+}
+
+func ObserveRestore(cr *api.PerconaXtraDBClusterRestore) {
+	restoreTotal.WithLabelValues(cr.Namespace, cr.Spec.PXCCluster, string(cr.Status.State)).Inc()
+
+	if cr.Status.State != api.RestoreSucceeded {
+		return
+	}
+	completed := time.Now()
+	if cr.Status.CompletedAt != nil {
+		completed = cr.Status.CompletedAt.Time
+	}
+	restoreLastSuccess.WithLabelValues(cr.Namespace, cr.Spec.PXCCluster).Set(float64(completed.Unix()))
+}
+
+func SetPITRLatestRestorable(cr *api.PerconaXtraDBCluster, t time.Time) {
+	pitrLatestRestorable.WithLabelValues(cr.Namespace, cr.Name).Set(float64(t.Unix()))
+}
+
+func SetCertificateExpiry(cr *api.PerconaXtraDBCluster, secret string, t time.Time) {
+	tlsCertificateExpiry.WithLabelValues(cr.Namespace, cr.Name, secret).Set(float64(t.Unix()))
+}
+
+func SetSmartUpdateInProgress(cr *api.PerconaXtraDBCluster, sts string, inProgress bool) {
+	v := 0.0
+	if inProgress {
+		v = 1
+	}
+	smartUpdateInProgress.WithLabelValues(cr.Namespace, cr.Name, sts).Set(v)
+}
+
+func IncSmartUpdatePodRestarts(cr *api.PerconaXtraDBCluster, sts string) {
+	smartUpdatePodRestarts.WithLabelValues(cr.Namespace, cr.Name, sts).Inc()
+}
+
+// IncQueriesKilled counts a query or connection killed because of the rule
+func IncQueriesKilled(cr *api.PerconaXtraDBCluster, rule string) {
+	queriesKilled.WithLabelValues(cr.Namespace, cr.Name, rule).Inc()
+}
+
+// SetWsrepStatus exports wsrep status variables of the pod,
+// status keys are variable names without the wsrep_ prefix.
+func SetWsrepStatus(ns, cluster, pod string, status map[string]string) {
+	set := func(g *prometheus.GaugeVec, v float64) {
+		g.WithLabelValues(ns, cluster, pod).Set(v)
+	}
+	setFloat := func(g *prometheus.GaugeVec, key string) {
+		if v, err := strconv.ParseFloat(status[key], 64); err == nil {
+			set(g, v)
+		}
+	}
+	setBool := func(g *prometheus.GaugeVec, key, want string) {
+		v := 0.0
+		if strings.EqualFold(status[key], want) {
+			v = 1
+		}
+		set(g, v)
+	}
+
+	setFloat(wsrepClusterSize, "cluster_size")
+	setFloat(wsrepLocalState, "local_state")
+	setFloat(wsrepFlowControlPaused, "flow_control_paused")
+	setFloat(wsrepLocalCertFailures, "local_cert_failures")
+	setBool(wsrepReady, "ready", "ON")
+	setBool(wsrepClusterPrimary, "cluster_status", "Primary")
+}
+
+// DeleteWsrepStatus removes wsrep series of the pod or of all cluster pods if pod is empty
+func DeleteWsrepStatus(ns, cluster, pod string) {
+	labels := prometheus.Labels{"namespace": ns, "cluster": cluster}
+	if pod != "" {
+		labels["pod"] = pod
+	}
+	for _, g := range []*prometheus.GaugeVec{
+		wsrepClusterSize,
+		wsrepLocalState,
+		wsrepReady,
+		wsrepClusterPrimary,
+		wsrepFlowControlPaused,
+		wsrepLocalCertFailures,
+	} {
+		g.DeletePartialMatch(labels)
+	}
+}
+
+// SetReplicationStatus exports the health of the replication channel
+func SetReplicationStatus(ns, cluster string, ch *api.ReplicationChannelStatus) {
+	boolValue := func(b bool) float64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	replicationIOThreadRunning.WithLabelValues(ns, cluster, ch.Name).Set(boolValue(ch.IOThread == "Yes"))
+	replicationSQLThreadRunning.WithLabelValues(ns, cluster, ch.Name).Set(boolValue(ch.SQLThread == "Yes"))
+	replicationBroken.WithLabelValues(ns, cluster, ch.Name).Set(boolValue(ch.Broken()))
+
+	// the lag is unknown while the SQL thread is stopped
+	if ch.SecondsBehindSource == nil {
+		replicationSecondsBehindSource.DeleteLabelValues(ns, cluster, ch.Name)
+		return
+	}
+	replicationSecondsBehindSource.WithLabelValues(ns, cluster, ch.Name).Set(float64(*ch.SecondsBehindSource))
+}
+
+// DeleteReplicationStatus removes replication series of the channel or of all cluster channels if channel is empty
+func DeleteReplicationStatus(ns, cluster, channel string) {
+	labels := prometheus.Labels{"namespace": ns, "cluster": cluster}
+	if channel != "" {
+		labels["channel"] = channel
+	}
+	for _, g := range []*prometheus.GaugeVec{
+		replicationIOThreadRunning,
+		replicationSQLThreadRunning,
+		replicationSecondsBehindSource,
+		replicationBroken,
+	} {
+		g.DeletePartialMatch(labels)
+	}
+}
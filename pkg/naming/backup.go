@@ -56,7 +56,22 @@ func trimJobName(name string) string {
 }
 
 func ScheduledBackupName(crName, storageName, schedule string) string {
-	result := "cron"
+	return backupName("cron", crName, storageName, time.Now()) + "-" + strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(schedule))), 32)[:5]
+}
+
+// BackupNowName returns the name of the backup requested with the backup-now annotation of the cluster.
+func BackupNowName(crName, storageName string) string {
+	return backupName("now", crName, storageName, time.Now())
+}
+
+// FinalBackupName returns the name of the backup the ordered teardown takes before the cluster deleted at deletedAt is removed.
+// The name doesn't change between reconciles, so the backup is created once.
+func FinalBackupName(crName, storageName string, deletedAt time.Time) string {
+	return backupName("final", crName, storageName, deletedAt)
+}
+
+func backupName(prefix, crName, storageName string, t time.Time) string {
+	result := prefix
 
 	if len(crName) > 16 {
 		result += "-" + crName[:16]
@@ -70,8 +85,6 @@ func ScheduledBackupName(crName, storageName, schedule string) string {
 		result += "-" + storageName
 	}
 
-	tnow := time.Now()
-	result += "-" + fmt.Sprintf("%d%d%d%d%d%d", tnow.Year(), tnow.Month(), tnow.Day(), tnow.Hour(), tnow.Minute(), tnow.Second())
-	result += "-" + strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(schedule))), 32)[:5]
+	result += "-" + fmt.Sprintf("%d%d%d%d%d%d", t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second())
 	return result
 }
@@ -0,0 +1,7 @@
+package naming
+
+import api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+
+func DiagnosticsPVCName(cr *api.PerconaXtraDBCluster) string {
+	return cr.Name + "-diagnostics"
+}
@@ -0,0 +1,10 @@
+package naming
+
+const (
+	// AnnotationSafeToEvict is checked by cluster-autoscaler before it drains a node.
+	AnnotationSafeToEvict = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+	// AnnotationDeschedulerPreventEviction excludes the pod from descheduler evictions.
+	AnnotationDeschedulerPreventEviction = "descheduler.alpha.kubernetes.io/prevent-eviction"
+	// AnnotationEvictionProtection lists the roles the operator protects the pod from eviction for.
+	AnnotationEvictionProtection = annotationPrefix + "eviction-protection"
+)
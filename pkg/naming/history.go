@@ -0,0 +1,5 @@
+package naming
+
+func OperationsHistoryConfigMapName(clusterName string) string {
+	return clusterName + "-operations-history"
+}
@@ -28,6 +28,8 @@ const (
 
 	LabelPerconaRestoreServiceName = perconaPrefix + "restore-svc-name"
 	LabelPerconaRestoreJobName     = perconaPrefix + "restore-job-name"
+
+	LabelPerconaExportName = perconaPrefix + "export-name"
 )
 
 func GetLabelBackupType(cr *api.PerconaXtraDBCluster) string {
@@ -51,6 +53,14 @@ const (
 	componentPITR            = "pitr"
 	componentPXC             = "pxc"
 	componentExternalService = "external-service"
+	componentConsistency     = "consistency-check"
+	componentReplication     = "replication-source"
+	componentExport          = "export"
+	componentMaintenance     = "table-maintenance"
+	componentReporting       = "reporting"
+	componentGatewayRoute    = "gateway-route"
+	componentNetworkPolicy   = "network-policy"
+	componentDiagnostics     = "diagnostics"
 
 	ComponentProxySQL = "proxysql"
 	ComponentHAProxy  = "haproxy"
@@ -78,6 +88,40 @@ func LabelsPXC(cr *api.PerconaXtraDBCluster) map[string]string {
 	return componentLabels(cr, componentPXC)
 }
 
+func LabelsConsistencyCheck(cr *api.PerconaXtraDBCluster) map[string]string {
+	return componentLabels(cr, componentConsistency)
+}
+
+func LabelsTableMaintenance(cr *api.PerconaXtraDBCluster) map[string]string {
+	return componentLabels(cr, componentMaintenance)
+}
+
+func LabelsReporting(cr *api.PerconaXtraDBCluster) map[string]string {
+	return componentLabels(cr, componentReporting)
+}
+
+func LabelsDiagnostics(cr *api.PerconaXtraDBCluster) map[string]string {
+	return componentLabels(cr, componentDiagnostics)
+}
+
+func LabelsGatewayRoute(cr *api.PerconaXtraDBCluster) map[string]string {
+	return componentLabels(cr, componentGatewayRoute)
+}
+
+func LabelsNetworkPolicy(cr *api.PerconaXtraDBCluster) map[string]string {
+	return componentLabels(cr, componentNetworkPolicy)
+}
+
+func LabelsReplicationSource(cr *api.PerconaXtraDBCluster) map[string]string {
+	return componentLabels(cr, componentReplication)
+}
+
+func LabelsExport(cr *api.PerconaXtraDBCluster, exportName string) map[string]string {
+	m := componentLabels(cr, componentExport)
+	m[LabelPerconaExportName] = exportName
+	return m
+}
+
 func LabelsRestorePVCPod(cr *api.PerconaXtraDBCluster, storageName string, restoreSvcName string) map[string]string {
 	labels := make(map[string]string)
 	if cr.Spec.Backup.Storages != nil && cr.Spec.Backup.Storages[storageName] != nil && len(cr.Spec.Backup.Storages[storageName].Labels) > 0 {
@@ -134,6 +178,33 @@ func LabelsScheduledBackup(cluster *api.PerconaXtraDBCluster, ancestor string) m
 	return labels
 }
 
+// LabelsBackupNow returns the labels of the backup requested with the backup-now annotation of the cluster.
+func LabelsBackupNow(cluster *api.PerconaXtraDBCluster) map[string]string {
+	labels := make(map[string]string)
+
+	if cluster.CompareVersionWith("1.16.0") < 0 {
+		util.MergeMaps(labels, map[string]string{
+			"cluster": cluster.Name,
+			"type":    "now",
+		})
+	} else {
+		util.MergeMaps(labels, LabelsCluster(cluster), map[string]string{
+			LabelPerconaBackupType:  "now",
+			LabelPerconaClusterName: cluster.Name,
+		})
+	}
+
+	return labels
+}
+
+// LabelsFinalBackup returns the labels of the backup the ordered teardown takes before the cluster is removed.
+func LabelsFinalBackup(cluster *api.PerconaXtraDBCluster) map[string]string {
+	return util.MergeMaps(make(map[string]string), LabelsCluster(cluster), map[string]string{
+		LabelPerconaBackupType:  "final",
+		LabelPerconaClusterName: cluster.Name,
+	})
+}
+
 func LabelsBackup(cluster *api.PerconaXtraDBCluster) map[string]string {
 	if cluster.CompareVersionWith("1.16.0") < 0 {
 		return map[string]string{
@@ -0,0 +1,35 @@
+package naming
+
+import api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+
+func PXCPodMonitorName(cr *api.PerconaXtraDBCluster) string {
+	return cr.Name + "-pxc"
+}
+
+func HAProxyPodMonitorName(cr *api.PerconaXtraDBCluster) string {
+	return cr.Name + "-haproxy"
+}
+
+func ProxySQLPodMonitorName(cr *api.PerconaXtraDBCluster) string {
+	return cr.Name + "-proxysql"
+}
+
+func PITRPodMonitorName(cr *api.PerconaXtraDBCluster) string {
+	return cr.Name + "-pitr"
+}
+
+func PrometheusRuleName(cr *api.PerconaXtraDBCluster) string {
+	return cr.Name + "-pxc-alerts"
+}
+
+func DashboardConfigMapName(cr *api.PerconaXtraDBCluster) string {
+	return cr.Name + "-pxc-dashboard"
+}
+
+func ConsistencyCheckCronJobName(cr *api.PerconaXtraDBCluster) string {
+	return cr.Name + "-consistency-check"
+}
+
+func TableMaintenanceJobName(cr *api.PerconaXtraDBCluster) string {
+	return cr.Name + "-table-maintenance"
+}
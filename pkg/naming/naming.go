@@ -13,6 +13,25 @@ const (
 	FinalizerDeleteBackup         = annotationPrefix + "delete-backup"
 	FinalizerS3DeleteBackup       = "delete-s3-backup"
 	FinalizerReleaseLock          = internalAnnotationPrefix + "release-lock"
+	FinalizerDeleteDatabase       = annotationPrefix + "delete-database"
+	FinalizerWaitForBackupRestore = annotationPrefix + "wait-for-backup-restore"
+	FinalizerDeleteSQLJobUser     = annotationPrefix + "delete-sqljob-user"
+	FinalizerDeleteMigrationUser  = annotationPrefix + "delete-migration-user"
+	FinalizerOrderedTeardown      = annotationPrefix + "ordered-teardown"
+)
+
+const (
+	AnnotationOperatorOwner     = annotationPrefix + "operator-owner"
+	AnnotationOperatorVersion   = annotationPrefix + "operator-version"
+	AnnotationOperatorRenewedAt = annotationPrefix + "operator-renewed-at"
+
+	// AnnotationManagedMetadata lists the keys of the labels and annotations patched by the operator
+	// to the pod metadata and propagated from the cluster labels.
+	AnnotationManagedMetadata = annotationPrefix + "managed-metadata"
+
+	// AnnotationBackupNow requests a backup of the cluster to the storage in the value.
+	// The operator removes the annotation once the backup is created.
+	AnnotationBackupNow = annotationPrefix + "backup-now"
 )
 
 const (
@@ -22,4 +41,60 @@ const (
 const (
 	EventStorageClassNotSupportResize = "StorageClassNotSupportResize"
 	EventExceededQuota                = "ExceededQuota"
+	EventStatefulSetCreated           = "StatefulSetCreated"
+	EventStatefulSetUpdated           = "StatefulSetUpdated"
+	EventSmartUpdateStarted           = "SmartUpdateStarted"
+	EventSmartUpdatePostponed         = "SmartUpdatePostponed"
+	EventSmartUpdatePodRestart        = "SmartUpdatePodRestart"
+	EventSmartUpdatePodUpdated        = "SmartUpdatePodUpdated"
+	EventSmartUpdateFinished          = "SmartUpdateFinished"
+	EventSmartUpdateCanaryPassed      = "SmartUpdateCanaryPassed"
+	EventSmartUpdateCanaryFailed      = "SmartUpdateCanaryFailed"
+	EventPITRInvalidated              = "PITRInvalidated"
+	EventReplicationSourceChanged     = "ReplicationSourceChanged"
+	EventReplicationSourceDown        = "ReplicationSourceDown"
+	EventReplicationFailover          = "ReplicationFailover"
+	EventReplicationRepositioned      = "ReplicationRepositioned"
+	EventReplicationRepositionFailed  = "ReplicationRepositionFailed"
+	EventReplicationRebuildStarted    = "ReplicationRebuildStarted"
+	EventReplicationRebuilt           = "ReplicationRebuilt"
+	EventReplicaConsistent            = "ReplicaConsistent"
+	EventStandbyRestoreStarted        = "StandbyRestoreStarted"
+	EventStandbyRestored              = "StandbyRestored"
+	EventStandbyApplyFailed           = "StandbyApplyFailed"
+	EventStandbyLagging               = "StandbyLagging"
+	EventStandbyPromoted              = "StandbyPromoted"
+	EventReplicaInconsistent          = "ReplicaInconsistent"
+	EventQueryKilled                  = "QueryKilled"
+	EventTableMaintenanceStarted      = "TableMaintenanceStarted"
+	EventTableMaintenanceFailed       = "TableMaintenanceFailed"
+	EventTableMaintenanceFinished     = "TableMaintenanceFinished"
+	EventBinlogPurgeHeld              = "BinlogPurgeHeld"
+	EventFullClusterCrashRecovery     = "FullClusterCrashRecovery"
+	EventBackupSucceeded              = "BackupSucceeded"
+	EventBackupFailed                 = "BackupFailed"
+	EventS3EndpointFailover           = "S3EndpointFailover"
+	EventRestoreStateChanged          = "RestoreStateChanged"
+	EventRestoreSucceeded             = "RestoreSucceeded"
+	EventRestoreFailed                = "RestoreFailed"
+	EventRestoreJobLog                = "RestoreJobLog"
+	EventRestoreRehearsed             = "RestoreRehearsed"
+	EventBackupRetrievalRequested     = "BackupRetrievalRequested"
+	EventUpgradeStateChanged          = "UpgradeStateChanged"
+	EventSwitchoverStateChanged       = "SwitchoverStateChanged"
+	EventSQLJobStateChanged           = "SQLJobStateChanged"
+	EventSchemaMigrationStateChanged  = "SchemaMigrationStateChanged"
+	EventExportStateChanged           = "ExportStateChanged"
+	EventChangesPendingApproval       = "ChangesPendingApproval"
+	EventInvalidBackupSchedule        = "InvalidBackupSchedule"
+	EventInvalidBackupRequest         = "InvalidBackupRequest"
+	EventDeletionPostponed            = "DeletionPostponed"
+	EventVeleroRestoreRecovered       = "VeleroRestoreRecovered"
+	EventStorageLocalityConflict      = "StorageLocalityConflict"
+	EventStorageNodeUnavailable       = "StorageNodeUnavailable"
+	EventOrphanedBackupFound          = "OrphanedBackupFound"
+	EventOrphanedBackupDeleted        = "OrphanedBackupDeleted"
+	EventVolumeOverrideConflict       = "VolumeOverrideConflict"
+	EventDiagnosticBundleCollected    = "DiagnosticBundleCollected"
+	EventDiagnosticBundleFailed       = "DiagnosticBundleFailed"
 )
@@ -0,0 +1,11 @@
+package naming
+
+import api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+
+func ReplicationSourceServiceName(cr *api.PerconaXtraDBCluster) string {
+	return cr.Name + "-pxc-replication-source"
+}
+
+func StandbyApplyJobName(cr *api.PerconaXtraDBCluster) string {
+	return cr.Name + "-standby-apply"
+}
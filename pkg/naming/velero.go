@@ -0,0 +1,28 @@
+package naming
+
+const (
+	// LabelVeleroExcludeFromBackup excludes the object from Velero backups.
+	LabelVeleroExcludeFromBackup = "velero.io/exclude-from-backup"
+	// LabelVeleroRestoreName is set by Velero on the restored objects.
+	LabelVeleroRestoreName = "velero.io/restore-name"
+)
+
+const (
+	annotationVeleroPreBackupHook  = "pre.hook.backup.velero.io/"
+	annotationVeleroPostBackupHook = "post.hook.backup.velero.io/"
+)
+
+// VeleroHookAnnotations returns the annotations running the backup hooks of the PXC pods.
+// The pre hook desyncs the node and flushes tables before its volumes are backed up,
+// the post hook syncs the node back.
+func VeleroHookAnnotations(timeout string) map[string]string {
+	return map[string]string{
+		annotationVeleroPreBackupHook + "container":  componentPXC,
+		annotationVeleroPreBackupHook + "command":    `["/var/lib/mysql/velero-hook.sh", "pre"]`,
+		annotationVeleroPreBackupHook + "timeout":    timeout,
+		annotationVeleroPreBackupHook + "on-error":   "Fail",
+		annotationVeleroPostBackupHook + "container": componentPXC,
+		annotationVeleroPostBackupHook + "command":   `["/var/lib/mysql/velero-hook.sh", "post"]`,
+		annotationVeleroPostBackupHook + "timeout":   timeout,
+	}
+}
@@ -204,6 +204,7 @@ func GetDeployment(cr *api.PerconaXtraDBCluster, initImage string) (appsv1.Deplo
 	if cr.CompareVersionWith("1.16.0") >= 0 {
 		depl.Labels = labels
 	}
+	cr.ApplyImageOverrides(&depl.Spec.Template.Spec)
 
 	return depl, nil
 }
@@ -257,6 +258,7 @@ func getStorageEnvs(cr *api.PerconaXtraDBCluster) ([]corev1.EnvVar, error) {
 				Value: storage.S3.EndpointURL,
 			})
 		}
+		envs = append(envs, storage.S3.AlternateEnvs("")...)
 	case api.BackupStorageAzure:
 		if storage.Azure == nil {
 			return nil, errors.New("azure storage is not specified")
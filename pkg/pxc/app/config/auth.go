@@ -0,0 +1,187 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+const (
+	AuthConfigFileName           = "auth.cnf"
+	PasswordPolicyConfigFileName = "password-policy.cnf"
+	LDAPCAFileName               = "ldap-ca.crt"
+	// AuthConfigDir is the directory where auth config and LDAP CA are mounted
+	AuthConfigDir = "/etc/my.cnf.d"
+)
+
+func AuthConfigSecretName(clusterName string) string {
+	return fmt.Sprintf("%s-pxc-auth", clusterName)
+}
+
+// AuthConfigEnabled returns true if authentication plugin
+// or password policy options should be mounted to PXC pods.
+func AuthConfigEnabled(cr *api.PerconaXtraDBCluster) bool {
+	return cr.Spec.PXC.Authentication.IsEnabled() || cr.Spec.PXC.PasswordPolicy != nil
+}
+
+// NewAuthConfigSecret returns a secret with mysqld options for the authentication plugin
+// and password policy. It's a secret and not a config map since the options contain the LDAP bind password.
+func NewAuthConfigSecret(cr *api.PerconaXtraDBCluster, bindSecret *corev1.Secret) (*corev1.Secret, error) {
+	data := make(map[string][]byte)
+
+	if cr.Spec.PXC.Authentication.IsEnabled() {
+		cnf, err := authConfig(cr.Spec.PXC.Authentication, bindSecret)
+		if err != nil {
+			return nil, err
+		}
+		data[AuthConfigFileName] = []byte(cnf)
+	}
+
+	if cr.Spec.PXC.PasswordPolicy != nil {
+		data[PasswordPolicyConfigFileName] = []byte(passwordPolicyConfig(cr.Spec.PXC.PasswordPolicy))
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      AuthConfigSecretName(cr.Name),
+			Namespace: cr.Namespace,
+			Labels:    naming.LabelsCluster(cr),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}, nil
+}
+
+// passwordPolicyConfig returns options of the validate_password component.
+// The options are prefixed with loose- since the component is installed
+// by the operator after the cluster is ready.
+func passwordPolicyConfig(p *api.PasswordPolicySpec) string {
+	opts := []string{
+		"[mysqld]",
+		"loose-validate_password.policy=" + string(p.Policy),
+		fmt.Sprintf("loose-validate_password.length=%d", p.Length),
+		fmt.Sprintf("loose-validate_password.mixed_case_count=%d", p.GetMixedCaseCount()),
+		fmt.Sprintf("loose-validate_password.number_count=%d", p.GetNumberCount()),
+		fmt.Sprintf("loose-validate_password.special_char_count=%d", p.GetSpecialCharCount()),
+	}
+	if p.History > 0 {
+		opts = append(opts, fmt.Sprintf("password_history=%d", p.History))
+	}
+	if p.ReuseIntervalDays > 0 {
+		opts = append(opts, fmt.Sprintf("password_reuse_interval=%d", p.ReuseIntervalDays))
+	}
+	if p.ExpirationDays > 0 {
+		opts = append(opts, fmt.Sprintf("default_password_lifetime=%d", p.ExpirationDays))
+	}
+
+	return strings.Join(opts, "\n") + "\n"
+}
+
+func authConfig(spec *api.AuthenticationSpec, bindSecret *corev1.Secret) (string, error) {
+	if spec.OIDC != nil {
+		return oidcConfig(spec.OIDC)
+	}
+
+	l := spec.LDAP
+	plugin := "authentication_ldap_" + string(l.Mechanism)
+
+	opts := make([]string, 0)
+	set := func(name, value string) {
+		if value != "" {
+			opts = append(opts, fmt.Sprintf("%s_%s=%s", plugin, name, value))
+		}
+	}
+
+	opts = append(opts, fmt.Sprintf("plugin-load-add=%s.so", plugin))
+
+	for i, uri := range l.ServerURIs {
+		host, port, tls, err := parseLDAPURI(uri)
+		if err != nil {
+			return "", err
+		}
+
+		prefix := ""
+		if i > 0 {
+			prefix = "fallback_"
+		}
+		set(prefix+"server_host", host)
+		set(prefix+"server_port", port)
+		if i == 0 && tls {
+			set("tls", "ON")
+		}
+	}
+
+	set("bind_base_dn", l.BindBaseDN)
+	if bindSecret != nil {
+		set("bind_root_dn", string(bindSecret.Data["dn"]))
+		set("bind_root_pwd", string(bindSecret.Data["password"]))
+	}
+	set("user_search_attr", l.UserSearchAttr)
+	set("group_search_attr", l.GroupSearchAttr)
+	set("group_search_filter", l.GroupSearchFilter)
+	if l.Mechanism == api.LDAPAuthSASL {
+		set("auth_method_name", l.SASLMethod)
+	}
+	if l.CASecretName != "" {
+		set("ca_path", AuthConfigDir+"/"+LDAPCAFileName)
+	}
+
+	if len(l.GroupRoleMapping) > 0 {
+		groups := make([]string, 0, len(l.GroupRoleMapping))
+		for g := range l.GroupRoleMapping {
+			groups = append(groups, g)
+		}
+		sort.Strings(groups)
+
+		mapping := make([]string, 0, len(groups))
+		for _, g := range groups {
+			mapping = append(mapping, g+"="+l.GroupRoleMapping[g])
+		}
+		set("group_role_mapping", strings.Join(mapping, ","))
+	}
+
+	return "[mysqld]\n" + strings.Join(opts, "\n") + "\n", nil
+}
+
+func oidcConfig(spec *api.OIDCAuthSpec) (string, error) {
+	providers := make(map[string]string, len(spec.Providers))
+	for _, p := range spec.Providers {
+		providers[p.Name] = p.Issuer
+	}
+
+	conf, err := json.Marshal(providers)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal oidc providers")
+	}
+
+	return "[mysqld]\n" +
+		"plugin-load-add=authentication_openid_connect.so\n" +
+		fmt.Sprintf("authentication_openid_connect_configuration=JSON://%s\n", conf), nil
+}
+
+func parseLDAPURI(uri string) (host, port string, tls bool, err error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", "", false, errors.Wrapf(err, "parse ldap uri %s", uri)
+	}
+
+	tls = u.Scheme == "ldaps"
+	port = u.Port()
+	if port == "" {
+		port = "389"
+		if tls {
+			port = "636"
+		}
+	}
+
+	return u.Hostname(), port, tls, nil
+}
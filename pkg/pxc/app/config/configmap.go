@@ -65,6 +65,21 @@ func HookScriptConfigMapName(clusterName, component string) string {
 	return fmt.Sprintf("%s-%s-hookscript", clusterName, component)
 }
 
+// ReportingConfigFileName is the my.cnf profile of the reporting pod in the reporting config map.
+const ReportingConfigFileName = "my.cnf"
+
+func ReportingConfigMapName(clusterName string) string {
+	return fmt.Sprintf("%s-pxc-reporting", clusterName)
+}
+
+// GaleraSegmentsDir is the directory the galera segments config map is mounted to in the PXC pods,
+// with a file named after each pod holding its gmcast.segment.
+const GaleraSegmentsDir = "/etc/mysql/segments"
+
+func GaleraSegmentsConfigMapName(clusterName string) string {
+	return fmt.Sprintf("%s-pxc-segments", clusterName)
+}
+
 func CustomConfigMapName(clusterName, component string) string {
 	return fmt.Sprintf("%s-%s", clusterName, component)
 }
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+const (
+	DataVolumesConfigFileName = "data-volumes.cnf"
+
+	BinlogVolumeName     = "binlog"
+	BinlogDir            = "/var/lib/mysql-binlog"
+	TmpdirVolumeName     = "tmpdir"
+	TmpdirDir            = "/var/lib/mysql-tmpdir"
+	InnoDBLogsVolumeName = "innodb-logs"
+	InnoDBLogsDir        = "/var/lib/mysql-innodb-logs"
+)
+
+func DataVolumesConfigMapName(clusterName string) string {
+	return fmt.Sprintf("%s-pxc-data-volumes", clusterName)
+}
+
+// DataVolumesConfigEnabled returns true if the files of mysqld are moved
+// out of the datadir to separate volumes.
+func DataVolumesConfigEnabled(cr *api.PerconaXtraDBCluster) bool {
+	d := cr.Spec.PXC.DataVolumes
+	return d != nil && (d.Binlog != nil || d.Tmpdir != nil || d.InnoDBLogs != nil)
+}
+
+// DataVolume is a volume of the PXC pods with the files of mysqld moved out of the datadir.
+type DataVolume struct {
+	Name      string
+	MountPath string
+	Spec      *corev1.PersistentVolumeClaimSpec
+}
+
+// DataVolumes returns the configured data volumes of the PXC pods.
+func DataVolumes(cr *api.PerconaXtraDBCluster) []DataVolume {
+	d := cr.Spec.PXC.DataVolumes
+	if d == nil {
+		return nil
+	}
+
+	var volumes []DataVolume
+	if d.Binlog != nil {
+		volumes = append(volumes, DataVolume{Name: BinlogVolumeName, MountPath: BinlogDir, Spec: d.Binlog})
+	}
+	if d.Tmpdir != nil {
+		volumes = append(volumes, DataVolume{Name: TmpdirVolumeName, MountPath: TmpdirDir, Spec: d.Tmpdir})
+	}
+	if d.InnoDBLogs != nil {
+		volumes = append(volumes, DataVolume{Name: InnoDBLogsVolumeName, MountPath: InnoDBLogsDir, Spec: d.InnoDBLogs})
+	}
+	return volumes
+}
+
+// NewDataVolumesConfigMap returns a config map with mysqld options pointing to the data volumes.
+func NewDataVolumesConfigMap(cr *api.PerconaXtraDBCluster) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DataVolumesConfigMapName(cr.Name),
+			Namespace: cr.Namespace,
+			Labels:    naming.LabelsCluster(cr),
+		},
+		Data: map[string]string{
+			DataVolumesConfigFileName: dataVolumesConfig(cr.Spec.PXC.DataVolumes),
+		},
+	}
+}
+
+func dataVolumesConfig(d *api.DataVolumesSpec) string {
+	opts := []string{"[mysqld]"}
+	if d.Binlog != nil {
+		opts = append(opts,
+			"log_bin="+BinlogDir+"/binlog",
+			"log_bin_index="+BinlogDir+"/binlog.index",
+		)
+	}
+	if d.Tmpdir != nil {
+		opts = append(opts, "tmpdir="+TmpdirDir)
+	}
+	if d.InnoDBLogs != nil {
+		opts = append(opts,
+			"innodb_log_group_home_dir="+InnoDBLogsDir,
+			"innodb_undo_directory="+InnoDBLogsDir,
+		)
+	}
+
+	return strings.Join(opts, "\n") + "\n"
+}
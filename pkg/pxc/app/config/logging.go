@@ -0,0 +1,309 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+const (
+	SlowQueryLogConfigFileName = "slow-query-log.cnf"
+	SlowQueryLogFile           = "/var/lib/mysql/slow.log"
+	AuditLogConfigFileName     = "audit-log.cnf"
+	AuditLogFile               = "/var/lib/mysql/audit.log"
+
+	LogCollectorCustomConfigFileName   = "fluentbit_custom.conf"
+	LogCollectorShippingConfigFileName = "fluentbit_shipping.conf"
+	LogCollectorParsersFileName        = "parsers_shipping.conf"
+	LogCollectorConfigDir              = "/etc/fluentbit/custom"
+
+	logShippingTagPrefix = "shipping."
+)
+
+func LoggingConfigMapName(clusterName string) string {
+	return fmt.Sprintf("%s-pxc-logging", clusterName)
+}
+
+// LoggingConfigEnabled returns true if mysqld logging options
+// should be mounted to PXC pods.
+func LoggingConfigEnabled(cr *api.PerconaXtraDBCluster) bool {
+	return cr.Spec.LogCollector != nil &&
+		(cr.Spec.LogCollector.SlowQueryLog.IsEnabled() || cr.Spec.LogCollector.AuditLog.IsEnabled())
+}
+
+// LogShippingEnabled returns true if the log collector should ship logs to the sink.
+func LogShippingEnabled(cr *api.PerconaXtraDBCluster) bool {
+	return LoggingConfigEnabled(cr) && cr.Spec.LogCollector.Enabled && cr.Spec.LogCollector.Sink != nil
+}
+
+// LogCollectorConfigEnabled returns true if the custom log collector config map
+// should be mounted to the log collector container.
+func LogCollectorConfigEnabled(cr *api.PerconaXtraDBCluster) bool {
+	return cr.Spec.LogCollector != nil && (cr.Spec.LogCollector.Configuration != "" || LogShippingEnabled(cr))
+}
+
+// NewLoggingConfigMap returns a config map with mysqld options of managed logs.
+func NewLoggingConfigMap(cr *api.PerconaXtraDBCluster) *corev1.ConfigMap {
+	data := make(map[string]string)
+
+	if slow := cr.Spec.LogCollector.SlowQueryLog; slow.IsEnabled() {
+		data[SlowQueryLogConfigFileName] = slowQueryLogConfig(slow)
+	}
+
+	if audit := cr.Spec.LogCollector.AuditLog; audit.IsEnabled() {
+		data[AuditLogConfigFileName] = auditLogConfig(audit)
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      LoggingConfigMapName(cr.Name),
+			Namespace: cr.Namespace,
+			Labels:    naming.LabelsCluster(cr),
+		},
+		Data: data,
+	}
+}
+
+// NewLogCollectorConfigMap returns the config map with the user provided
+// fluent-bit configuration and the generated log shipping pipeline.
+func NewLogCollectorConfigMap(cr *api.PerconaXtraDBCluster) *corev1.ConfigMap {
+	cm := NewConfigMap(cr, CustomConfigMapName(cr.Name, "logcollector"), LogCollectorCustomConfigFileName, cr.Spec.LogCollector.Configuration)
+	if cr.Spec.LogCollector.Configuration == "" {
+		cm.Data = make(map[string]string)
+	}
+
+	if LogShippingEnabled(cr) {
+		cm.Data[LogCollectorShippingConfigFileName] = logShippingConfig(cr)
+		cm.Data[LogCollectorParsersFileName] = logShippingParsers
+	}
+
+	return cm
+}
+
+// slowQueryLogConfig returns slow query log options. The log is rotated by
+// mysqld using max_slowlog_size and max_slowlog_files options of Percona Server.
+func slowQueryLogConfig(s *api.SlowQueryLogSpec) string {
+	opts := []string{
+		"[mysqld]",
+		"slow_query_log=ON",
+		"slow_query_log_file=" + SlowQueryLogFile,
+	}
+	if s.LongQueryTime != "" {
+		opts = append(opts, "long_query_time="+s.LongQueryTime)
+	}
+	if s.MinExaminedRowLimit > 0 {
+		opts = append(opts, fmt.Sprintf("min_examined_row_limit=%d", s.MinExaminedRowLimit))
+	}
+	if s.LogQueriesNotUsingIndexes {
+		opts = append(opts, "log_queries_not_using_indexes=ON")
+	}
+	if s.RateLimit > 0 {
+		opts = append(opts, fmt.Sprintf("log_slow_rate_limit=%d", s.RateLimit))
+	}
+	if s.Verbosity != "" {
+		opts = append(opts, "log_slow_verbosity="+s.Verbosity)
+	}
+	if s.MaxSize != "" {
+		opts = append(opts, "max_slowlog_size="+s.MaxSize)
+	}
+	if s.MaxFiles > 0 {
+		opts = append(opts, fmt.Sprintf("max_slowlog_files=%d", s.MaxFiles))
+	}
+
+	return strings.Join(opts, "\n") + "\n"
+}
+
+// auditLogConfig returns audit_log plugin options. The plugin is loaded
+// on startup and can't be unloaded at runtime.
+func auditLogConfig(a *api.AuditLogSpec) string {
+	opts := []string{
+		"[mysqld]",
+		"plugin-load-add=audit_log.so",
+		"audit_log=FORCE_PLUS_PERMANENT",
+		"audit_log_file=" + AuditLogFile,
+		"audit_log_format=" + a.Format,
+		"audit_log_policy=" + a.Policy,
+	}
+	if len(a.IncludeAccounts) > 0 {
+		opts = append(opts, "audit_log_include_accounts="+strings.Join(a.IncludeAccounts, ","))
+	}
+	if len(a.ExcludeAccounts) > 0 {
+		opts = append(opts, "audit_log_exclude_accounts="+strings.Join(a.ExcludeAccounts, ","))
+	}
+	if a.RotateOnSize != "" {
+		opts = append(opts, "audit_log_rotate_on_size="+a.RotateOnSize)
+	}
+	if a.Rotations > 0 {
+		opts = append(opts, fmt.Sprintf("audit_log_rotations=%d", a.Rotations))
+	}
+
+	return strings.Join(opts, "\n") + "\n"
+}
+
+const logShippingParsers = `[MULTILINE_PARSER]
+    name          mysql_slow
+    type          regex
+    flush_timeout 1000
+    rule          "start_state"  "/^# Time: .*/"         "cont"
+    rule          "cont"         "/^(?!# Time: ).*/"     "cont"
+
+[PARSER]
+    Name   audit_json
+    Format json
+`
+
+// logShippingConfig returns fluent-bit pipeline which tails managed logs
+// and sends them to the sink as JSON records.
+func logShippingConfig(cr *api.PerconaXtraDBCluster) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "[SERVICE]\n    Parsers_File %s/%s\n\n", LogCollectorConfigDir, LogCollectorParsersFileName)
+
+	if cr.Spec.LogCollector.SlowQueryLog.IsEnabled() {
+		writeSection(&b, "INPUT", [][2]string{
+			{"Name", "tail"},
+			{"Path", SlowQueryLogFile + "*"},
+			{"Tag", logShippingTagPrefix + "slow"},
+			{"DB", "/tmp/flb_slow.db"},
+			{"Refresh_Interval", "5"},
+			{"Read_from_Head", "true"},
+			{"multiline.parser", "mysql_slow"},
+		})
+		writeSection(&b, "FILTER", [][2]string{
+			{"Name", "record_modifier"},
+			{"Match", logShippingTagPrefix + "slow"},
+			{"Record", "log_type slow"},
+		})
+	}
+
+	if audit := cr.Spec.LogCollector.AuditLog; audit.IsEnabled() {
+		input := [][2]string{
+			{"Name", "tail"},
+			{"Path", AuditLogFile + "*"},
+			{"Tag", logShippingTagPrefix + "audit"},
+			{"DB", "/tmp/flb_audit.db"},
+			{"Refresh_Interval", "5"},
+			{"Read_from_Head", "true"},
+		}
+		if audit.Format == "JSON" {
+			input = append(input, [2]string{"Parser", "audit_json"})
+		}
+		writeSection(&b, "INPUT", input)
+		writeSection(&b, "FILTER", [][2]string{
+			{"Name", "record_modifier"},
+			{"Match", logShippingTagPrefix + "audit"},
+			{"Record", "log_type audit"},
+		})
+	}
+
+	writeSection(&b, "FILTER", [][2]string{
+		{"Name", "record_modifier"},
+		{"Match", logShippingTagPrefix + "*"},
+		{"Record", "cluster " + cr.Name},
+		{"Record", "namespace ${POD_NAMESPASE}"},
+		{"Record", "pod ${POD_NAME}"},
+	})
+	writeSection(&b, "OUTPUT", sinkOutput(cr))
+
+	return b.String()
+}
+
+func sinkOutput(cr *api.PerconaXtraDBCluster) [][2]string {
+	sink := cr.Spec.LogCollector.Sink
+	match := logShippingTagPrefix + "*"
+
+	tls := "Off"
+	if sink.TLS {
+		tls = "On"
+	}
+
+	switch sink.Type {
+	case api.LogSinkLoki:
+		labels := map[string]string{
+			"job":      "percona-xtradb-cluster",
+			"cluster":  cr.Name,
+			"log_type": "$log_type",
+		}
+		for k, v := range sink.Labels {
+			labels[k] = v
+		}
+		keys := make([]string, 0, len(labels))
+		for k := range labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			pairs = append(pairs, k+"="+labels[k])
+		}
+
+		out := [][2]string{
+			{"Name", "loki"},
+			{"Match", match},
+			{"Host", sink.Host},
+			{"Port", fmt.Sprint(sink.Port)},
+			{"tls", tls},
+			{"Labels", strings.Join(pairs, ", ")},
+			{"line_format", "json"},
+		}
+		if sink.BasicAuth {
+			out = append(out, [2]string{"http_user", "${LOG_SINK_USER}"}, [2]string{"http_passwd", "${LOG_SINK_PASSWORD}"})
+		}
+		return out
+	case api.LogSinkElasticsearch:
+		index := sink.Index
+		if index == "" {
+			index = cr.Name + "-logs"
+		}
+		out := [][2]string{
+			{"Name", "es"},
+			{"Match", match},
+			{"Host", sink.Host},
+			{"Port", fmt.Sprint(sink.Port)},
+			{"tls", tls},
+			{"Index", index},
+			{"Suppress_Type_Name", "On"},
+			{"Replace_Dots", "On"},
+		}
+		if sink.BasicAuth {
+			out = append(out, [2]string{"HTTP_User", "${LOG_SINK_USER}"}, [2]string{"HTTP_Passwd", "${LOG_SINK_PASSWORD}"})
+		}
+		return out
+	default:
+		prefix := strings.Trim(sink.Prefix, "/")
+		if prefix != "" {
+			prefix = "/" + prefix
+		}
+		out := [][2]string{
+			{"Name", "s3"},
+			{"Match", match},
+			{"bucket", sink.Bucket},
+			{"s3_key_format", prefix + "/" + cr.Namespace + "/" + cr.Name + "/$TAG[1]/%Y/%m/%d/%H%M%S-$UUID.json"},
+			{"total_file_size", "50M"},
+			{"upload_timeout", "10m"},
+			{"use_put_object", "On"},
+			{"store_dir", "/tmp/fluent-bit/s3"},
+		}
+		if sink.Region != "" {
+			out = append(out, [2]string{"region", sink.Region})
+		}
+		if sink.EndpointURL != "" {
+			out = append(out, [2]string{"endpoint", sink.EndpointURL})
+		}
+		return out
+	}
+}
+
+func writeSection(b *strings.Builder, name string, opts [][2]string) {
+	fmt.Fprintf(b, "[%s]\n", name)
+	for _, o := range opts {
+		fmt.Fprintf(b, "    %-18s %s\n", o[0], o[1])
+	}
+	b.WriteString("\n")
+}
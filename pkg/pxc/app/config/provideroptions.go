@@ -0,0 +1,111 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+const (
+	// ProviderOptionsDir is the directory the provider options config map is mounted to in the PXC pods.
+	ProviderOptionsDir = "/etc/mysql/provider-options"
+	// ProviderOptionsFileName is the file with the options separated with ';' in the provider options config map.
+	ProviderOptionsFileName = "options"
+)
+
+func ProviderOptionsConfigMapName(clusterName string) string {
+	return fmt.Sprintf("%s-pxc-provider-options", clusterName)
+}
+
+// ProviderOption is a wsrep_provider_options option.
+type ProviderOption struct {
+	Name  string
+	Value string
+	// Dynamic options can be set on a running node.
+	Dynamic bool
+}
+
+func (o ProviderOption) String() string {
+	return o.Name + "=" + o.Value
+}
+
+// ProviderOptions returns the provider options of the spec sorted by name.
+func ProviderOptions(spec *api.ProviderOptionsSpec) []ProviderOption {
+	if spec == nil {
+		return nil
+	}
+
+	var opts []ProviderOption
+	if spec.GCacheSize != nil {
+		opts = append(opts, ProviderOption{Name: "gcache.size", Value: galeraSize(spec.GCacheSize.Value())})
+	}
+	if spec.GCSFCLimit != nil {
+		opts = append(opts, ProviderOption{Name: "gcs.fc_limit", Value: strconv.Itoa(int(*spec.GCSFCLimit)), Dynamic: true})
+	}
+	if evs := spec.EVS; evs != nil {
+		for name, d := range map[string]*metav1.Duration{
+			"evs.keepalive_period":      evs.KeepalivePeriod,
+			"evs.inactive_check_period": evs.InactiveCheckPeriod,
+			"evs.suspect_timeout":       evs.SuspectTimeout,
+			"evs.inactive_timeout":      evs.InactiveTimeout,
+			"evs.install_timeout":       evs.InstallTimeout,
+		} {
+			if d != nil {
+				opts = append(opts, ProviderOption{Name: name, Value: galeraDuration(d.Duration)})
+			}
+		}
+	}
+
+	sort.Slice(opts, func(i, j int) bool { return opts[i].Name < opts[j].Name })
+	return opts
+}
+
+// JoinProviderOptions returns the options in the wsrep_provider_options format.
+func JoinProviderOptions(opts []ProviderOption) string {
+	s := make([]string, 0, len(opts))
+	for _, o := range opts {
+		s = append(s, o.String())
+	}
+	return strings.Join(s, ";")
+}
+
+// NewProviderOptionsConfigMap returns a config map with the provider options merged into
+// wsrep_provider_options of the PXC nodes when mysqld starts.
+func NewProviderOptionsConfigMap(cr *api.PerconaXtraDBCluster) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ProviderOptionsConfigMapName(cr.Name),
+			Namespace: cr.Namespace,
+			Labels:    naming.LabelsCluster(cr),
+		},
+		Data: map[string]string{
+			ProviderOptionsFileName: JoinProviderOptions(ProviderOptions(cr.Spec.PXC.ProviderOptions)),
+		},
+	}
+}
+
+// galeraSize returns the size in bytes with the largest suffix galera accepts that keeps it exact.
+func galeraSize(bytes int64) string {
+	for _, u := range []struct {
+		suffix string
+		size   int64
+	}{{"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}} {
+		if bytes >= u.size && bytes%u.size == 0 {
+			return strconv.FormatInt(bytes/u.size, 10) + u.suffix
+		}
+	}
+	return strconv.FormatInt(bytes, 10)
+}
+
+// galeraDuration returns the duration in the ISO 8601 format used by galera, e.g. PT1.5S.
+func galeraDuration(d time.Duration) string {
+	return "PT" + strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S"
+}
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+const SSTConfigFileName = "sst.cnf"
+
+func SSTConfigMapName(clusterName string) string {
+	return fmt.Sprintf("%s-pxc-sst", clusterName)
+}
+
+// SSTConfigEnabled returns true if the SST streaming options are set.
+func SSTConfigEnabled(cr *api.PerconaXtraDBCluster) bool {
+	s := cr.Spec.PXC.SST
+	return s != nil && (s.Parallel > 0 || s.Compressor != "" || s.Encrypt != nil)
+}
+
+// NewSSTConfigMap returns a config map with the SST options of the donor and the joiner.
+func NewSSTConfigMap(cr *api.PerconaXtraDBCluster) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      SSTConfigMapName(cr.Name),
+			Namespace: cr.Namespace,
+			Labels:    naming.LabelsCluster(cr),
+		},
+		Data: map[string]string{
+			SSTConfigFileName: sstConfig(cr.Spec.PXC.SST),
+		},
+	}
+}
+
+// sstConfig returns the options of the SST script in [sst] and the options of xtrabackup on the donor in [xtrabackup].
+// The joiner extracts and decompresses the stream with xbstream-opts.
+func sstConfig(s *api.SSTSpec) string {
+	var sst, xtrabackup, xbstream []string
+
+	if s.Encrypt != nil {
+		if *s.Encrypt {
+			sst = append(sst, "encrypt=4")
+		} else {
+			sst = append(sst, "encrypt=0")
+		}
+	}
+	if s.Parallel > 0 {
+		xtrabackup = append(xtrabackup, "parallel="+strconv.Itoa(int(s.Parallel)))
+		xbstream = append(xbstream, "--parallel="+strconv.Itoa(int(s.Parallel)))
+	}
+	if s.Compressor != "" {
+		xtrabackup = append(xtrabackup, "compress="+string(s.Compressor))
+		xbstream = append(xbstream, "--decompress")
+		if threads := s.GetCompressThreads(); threads > 0 {
+			xtrabackup = append(xtrabackup, "compress-threads="+strconv.Itoa(int(threads)))
+			xbstream = append(xbstream, "--decompress-threads="+strconv.Itoa(int(threads)))
+		}
+	}
+	if len(xbstream) > 0 {
+		sst = append(sst, "xbstream-opts="+strings.Join(xbstream, " "))
+	}
+
+	opts := append([]string{"[sst]"}, sst...)
+	if len(xtrabackup) > 0 {
+		opts = append(opts, "[xtrabackup]")
+		opts = append(opts, xtrabackup...)
+	}
+	return strings.Join(opts, "\n") + "\n"
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestSSTConfig(t *testing.T) {
+	f := false
+
+	tests := map[string]struct {
+		spec     api.SSTSpec
+		expected string
+	}{
+		"parallel": {
+			spec:     api.SSTSpec{Parallel: 4},
+			expected: "[sst]\nxbstream-opts=--parallel=4\n[xtrabackup]\nparallel=4\n",
+		},
+		"compressed": {
+			spec: api.SSTSpec{Parallel: 4, Compressor: api.SSTCompressorZstd, CompressThreads: 2},
+			expected: "[sst]\nxbstream-opts=--parallel=4 --decompress --decompress-threads=2\n" +
+				"[xtrabackup]\nparallel=4\ncompress=zstd\ncompress-threads=2\n",
+		},
+		"unencrypted": {
+			spec:     api.SSTSpec{Encrypt: &f},
+			expected: "[sst]\nencrypt=0\n",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := sstConfig(&tt.spec); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package app
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+const (
+	MetricsContainerName = "mysqld-exporter"
+	MetricsPortName      = "metrics"
+)
+
+// MysqldExporter returns the mysqld_exporter sidecar container.
+// It connects to the local mysqld with the monitor user.
+func MysqldExporter(spec *api.MetricsSpec, secrets string) corev1.Container {
+	args := []string{
+		"--mysqld.address=127.0.0.1:3306",
+		"--mysqld.username=" + users.Monitor,
+		fmt.Sprintf("--web.listen-address=:%d", spec.Port),
+	}
+	args = append(args, spec.Args...)
+
+	return corev1.Container{
+		Name:            MetricsContainerName,
+		Image:           spec.Image,
+		ImagePullPolicy: spec.ImagePullPolicy,
+		Args:            args,
+		Env: []corev1.EnvVar{
+			{
+				Name: "MYSQLD_EXPORTER_PASSWORD",
+				ValueFrom: &corev1.EnvVarSource{
+					SecretKeyRef: SecretKeySelector(secrets, users.Monitor),
+				},
+			},
+		},
+		Ports: []corev1.ContainerPort{
+			{
+				ContainerPort: spec.Port,
+				Name:          MetricsPortName,
+			},
+		},
+		LivenessProbe: &corev1.Probe{
+			InitialDelaySeconds: 30,
+			TimeoutSeconds:      5,
+			PeriodSeconds:       10,
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{
+					Port: intstr.FromString(MetricsPortName),
+					Path: "/",
+				},
+			},
+		},
+		Resources:       spec.Resources,
+		SecurityContext: spec.ContainerSecurityContext,
+	}
+}
+
+var PodMonitorGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PodMonitor",
+}
+
+// PXCPodMonitor returns the prometheus-operator PodMonitor scraping mysqld_exporter sidecars.
+// It's unstructured to not depend on prometheus-operator API.
+func PXCPodMonitor(cr *api.PerconaXtraDBCluster) *unstructured.Unstructured {
+	spec := cr.Spec.PXC.Metrics
+
+	endpoint := metricsEndpoint(cr, MetricsPortName)
+	if spec.ScrapeInterval != "" {
+		endpoint["interval"] = spec.ScrapeInterval
+	}
+	if sm := cr.Spec.Monitoring; sm != nil && sm.ServiceMonitor.IsEnabled() && sm.ServiceMonitor.TLS != nil {
+		tls := sm.ServiceMonitor.TLS
+		tlsConfig := map[string]interface{}{
+			"insecureSkipVerify": tls.InsecureSkipVerify,
+		}
+		if tls.ServerName != "" {
+			tlsConfig["serverName"] = tls.ServerName
+		}
+		if tls.CA != nil {
+			tlsConfig["ca"] = map[string]interface{}{
+				"secret": map[string]interface{}{
+					"name": tls.CA.Name,
+					"key":  tls.CA.Key,
+				},
+			}
+		}
+		endpoint["scheme"] = "https"
+		endpoint["tlsConfig"] = tlsConfig
+	}
+
+	return podMonitor(cr, naming.PXCPodMonitorName(cr), naming.LabelsPXC(cr), spec.PodMonitorLabels, endpoint)
+}
+
+// ComponentPodMonitors returns the PodMonitors of the HAProxy, ProxySQL and binlog
+// collector metrics by their names. They're generated if monitoring.serviceMonitor is enabled.
+func ComponentPodMonitors(cr *api.PerconaXtraDBCluster) map[string]*unstructured.Unstructured {
+	monitors := make(map[string]*unstructured.Unstructured)
+	if cr.Spec.Monitoring == nil || !cr.Spec.Monitoring.ServiceMonitor.IsEnabled() || cr.CompareVersionWith("1.17.0") < 0 {
+		return monitors
+	}
+
+	if cr.HAProxyEnabled() {
+		name := naming.HAProxyPodMonitorName(cr)
+		monitors[name] = podMonitor(cr, name, naming.LabelsHAProxy(cr), nil, metricsEndpoint(cr, "stats"))
+	}
+	if cr.ProxySQLEnabled() {
+		name := naming.ProxySQLPodMonitorName(cr)
+		monitors[name] = podMonitor(cr, name, naming.LabelsProxySQL(cr), nil, metricsEndpoint(cr, "stats"))
+	}
+	if cr.Spec.Backup != nil && cr.Spec.Backup.PITR.Enabled {
+		name := naming.PITRPodMonitorName(cr)
+		monitors[name] = podMonitor(cr, name, naming.LabelsPITR(cr), nil, metricsEndpoint(cr, "metrics"))
+	}
+
+	return monitors
+}
+
+// metricsEndpoint returns the endpoint scraping /metrics on the named port of the pods.
+func metricsEndpoint(cr *api.PerconaXtraDBCluster, port string) map[string]interface{} {
+	endpoint := map[string]interface{}{
+		"port": port,
+		"path": "/metrics",
+	}
+	if sm := cr.Spec.Monitoring; sm != nil && sm.ServiceMonitor.IsEnabled() && sm.ServiceMonitor.Interval != "" {
+		endpoint["interval"] = sm.ServiceMonitor.Interval
+	}
+	return endpoint
+}
+
+func podMonitor(cr *api.PerconaXtraDBCluster, name string, podLabels, extraLabels map[string]string, endpoint map[string]interface{}) *unstructured.Unstructured {
+	labels := make(map[string]string, len(podLabels))
+	for k, v := range podLabels {
+		labels[k] = v
+	}
+	if sm := cr.Spec.Monitoring; sm != nil && sm.ServiceMonitor.IsEnabled() {
+		for k, v := range sm.ServiceMonitor.Labels {
+			labels[k] = v
+		}
+	}
+	for k, v := range extraLabels {
+		labels[k] = v
+	}
+
+	selector := make(map[string]interface{})
+	for k, v := range podLabels {
+		selector[k] = v
+	}
+
+	pm := new(unstructured.Unstructured)
+	pm.SetGroupVersionKind(PodMonitorGVK)
+	pm.SetName(name)
+	pm.SetNamespace(cr.Namespace)
+	pm.SetLabels(labels)
+	pm.Object["spec"] = map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": selector,
+		},
+		"podMetricsEndpoints": []interface{}{endpoint},
+	}
+
+	return pm
+}
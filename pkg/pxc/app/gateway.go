@@ -0,0 +1,90 @@
+package app
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+var (
+	TCPRouteGVK = schema.GroupVersionKind{
+		Group:   "gateway.networking.k8s.io",
+		Version: "v1alpha2",
+		Kind:    string(api.GatewayRouteTCP),
+	}
+	TLSRouteGVK = schema.GroupVersionKind{
+		Group:   "gateway.networking.k8s.io",
+		Version: "v1alpha2",
+		Kind:    string(api.GatewayRouteTLS),
+	}
+)
+
+func GatewayRouteGVK(kind api.GatewayRouteKind) schema.GroupVersionKind {
+	if kind == api.GatewayRouteTLS {
+		return TLSRouteGVK
+	}
+	return TCPRouteGVK
+}
+
+// GatewayRoute returns the Gateway API route forwarding connections to the port of the service.
+// The route has the name of the service.
+func GatewayRoute(cr *api.PerconaXtraDBCluster, svcName string, port int32, spec *api.GatewayRouteSpec) *unstructured.Unstructured {
+	labels := naming.LabelsGatewayRoute(cr)
+	for k, v := range spec.Labels {
+		if _, ok := labels[k]; !ok {
+			labels[k] = v
+		}
+	}
+
+	parentRefs := make([]interface{}, 0, len(spec.ParentRefs))
+	for _, ref := range spec.ParentRefs {
+		r := map[string]interface{}{
+			"name": ref.Name,
+		}
+		if ref.Namespace != "" {
+			r["namespace"] = ref.Namespace
+		}
+		if ref.SectionName != "" {
+			r["sectionName"] = ref.SectionName
+		}
+		if ref.Port != 0 {
+			r["port"] = int64(ref.Port)
+		}
+		parentRefs = append(parentRefs, r)
+	}
+
+	routeSpec := map[string]interface{}{
+		"parentRefs": parentRefs,
+		"rules": []interface{}{
+			map[string]interface{}{
+				"backendRefs": []interface{}{
+					map[string]interface{}{
+						"name": svcName,
+						"port": int64(port),
+					},
+				},
+			},
+		},
+	}
+	if spec.Kind == api.GatewayRouteTLS {
+		hostnames := make([]interface{}, 0, len(spec.Hostnames))
+		for _, h := range spec.Hostnames {
+			hostnames = append(hostnames, h)
+		}
+		routeSpec["hostnames"] = hostnames
+	}
+
+	route := new(unstructured.Unstructured)
+	route.SetGroupVersionKind(GatewayRouteGVK(spec.Kind))
+	route.SetName(svcName)
+	route.SetNamespace(cr.Namespace)
+	route.SetLabels(labels)
+	if len(spec.Annotations) > 0 {
+		route.SetAnnotations(spec.Annotations)
+	}
+	route.Object["spec"] = routeSpec
+
+	return route
+}
@@ -0,0 +1,201 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+var PrometheusRuleGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PrometheusRule",
+}
+
+// PrometheusRule returns the prometheus-operator PrometheusRule with cluster alerts
+// based on operator metrics.
+func PrometheusRule(cr *api.PerconaXtraDBCluster) (*unstructured.Unstructured, error) {
+	spec := cr.Spec.Monitoring.PrometheusRule
+
+	seconds := func(d string) (int64, error) {
+		v, err := model.ParseDuration(d)
+		if err != nil {
+			return 0, errors.Wrapf(err, "parse duration %s", d)
+		}
+		return int64(time.Duration(v).Seconds()), nil
+	}
+
+	sel := fmt.Sprintf(`namespace=%q,cluster=%q`, cr.Namespace, cr.Name)
+	alert := func(name, expr, forDuration, severity, summary string) interface{} {
+		return map[string]interface{}{
+			"alert": name,
+			"expr":  expr,
+			"for":   forDuration,
+			"labels": map[string]interface{}{
+				"severity": severity,
+				"cluster":  cr.Name,
+			},
+			"annotations": map[string]interface{}{
+				"summary": summary,
+			},
+		}
+	}
+
+	rules := []interface{}{
+		alert("PXCNoPrimary",
+			fmt.Sprintf(`max(pxc_operator_wsrep_cluster_status_primary{%s}) < 1`, sel),
+			"1m", "critical", fmt.Sprintf("No PXC node of %s/%s is a part of the primary component", cr.Namespace, cr.Name)),
+		alert("PXCFlowControl",
+			fmt.Sprintf(`max by (pod) (pxc_operator_wsrep_flow_control_paused{%s}) > 0.1`, sel),
+			"5m", "warning", fmt.Sprintf("Replication in %s/%s is paused by flow control more than 10%% of time", cr.Namespace, cr.Name)),
+	}
+
+	certExpiry, err := seconds(spec.CertExpiryWarning)
+	if err != nil {
+		return nil, err
+	}
+	rules = append(rules, alert("PXCCertificateExpiry",
+		fmt.Sprintf(`min by (secret) (pxc_operator_tls_certificate_expiration_timestamp_seconds{%s}) - time() < %d`, sel, certExpiry),
+		"10m", "warning", fmt.Sprintf("TLS certificate of %s/%s expires in less than %s", cr.Namespace, cr.Name, spec.CertExpiryWarning)))
+
+	if cr.Spec.Backup != nil && len(cr.Spec.Backup.Schedule) > 0 {
+		maxAge, err := seconds(spec.BackupMaxAge)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, alert("PXCBackupMissed",
+			fmt.Sprintf(`time() - max(pxc_operator_backup_last_success_timestamp_seconds{%s}) > %d`, sel, maxAge),
+			"10m", "warning", fmt.Sprintf("No successful backup of %s/%s for more than %s", cr.Namespace, cr.Name, spec.BackupMaxAge)))
+	}
+
+	if cr.Spec.Backup != nil && cr.Spec.Backup.PITR.Enabled {
+		maxLag, err := seconds(spec.PITRMaxLag)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, alert("PXCPITRLag",
+			fmt.Sprintf(`time() - pxc_operator_pitr_latest_restorable_timestamp_seconds{%s} > %d`, sel, maxLag),
+			"5m", "warning", fmt.Sprintf("Point-in-time recovery of %s/%s lags behind for more than %s", cr.Namespace, cr.Name, spec.PITRMaxLag)))
+	}
+
+	labels := naming.LabelsCluster(cr)
+	for k, v := range spec.Labels {
+		labels[k] = v
+	}
+
+	pr := new(unstructured.Unstructured)
+	pr.SetGroupVersionKind(PrometheusRuleGVK)
+	pr.SetName(naming.PrometheusRuleName(cr))
+	pr.SetNamespace(cr.Namespace)
+	pr.SetLabels(labels)
+	pr.Object["spec"] = map[string]interface{}{
+		"groups": []interface{}{
+			map[string]interface{}{
+				"name":  "percona-xtradb-cluster." + cr.Name,
+				"rules": rules,
+			},
+		},
+	}
+
+	return pr, nil
+}
+
+// DashboardConfigMap returns the config map with Grafana dashboard of the cluster.
+func DashboardConfigMap(cr *api.PerconaXtraDBCluster) (*corev1.ConfigMap, error) {
+	sel := fmt.Sprintf(`namespace=%q,cluster=%q`, cr.Namespace, cr.Name)
+
+	type target struct {
+		Expr         string `json:"expr"`
+		LegendFormat string `json:"legendFormat,omitempty"`
+	}
+	type gridPos struct {
+		H int `json:"h"`
+		W int `json:"w"`
+		X int `json:"x"`
+		Y int `json:"y"`
+	}
+	type panel struct {
+		ID      int      `json:"id"`
+		Title   string   `json:"title"`
+		Type    string   `json:"type"`
+		GridPos gridPos  `json:"gridPos"`
+		Targets []target `json:"targets"`
+	}
+
+	panels := []panel{
+		{Title: "Cluster state", Type: "stat", Targets: []target{{
+			Expr:         fmt.Sprintf(`pxc_operator_cluster_state{namespace=%q,name=%q} == 1`, cr.Namespace, cr.Name),
+			LegendFormat: "{{state}}",
+		}}},
+		{Title: "Cluster size", Type: "timeseries", Targets: []target{{
+			Expr:         fmt.Sprintf(`pxc_operator_wsrep_cluster_size{%s}`, sel),
+			LegendFormat: "{{pod}}",
+		}}},
+		{Title: "Node state", Type: "timeseries", Targets: []target{{
+			Expr:         fmt.Sprintf(`pxc_operator_wsrep_local_state{%s}`, sel),
+			LegendFormat: "{{pod}}",
+		}}},
+		{Title: "Flow control paused", Type: "timeseries", Targets: []target{{
+			Expr:         fmt.Sprintf(`pxc_operator_wsrep_flow_control_paused{%s}`, sel),
+			LegendFormat: "{{pod}}",
+		}}},
+		{Title: "Certification failures", Type: "timeseries", Targets: []target{{
+			Expr:         fmt.Sprintf(`pxc_operator_wsrep_local_cert_failures{%s}`, sel),
+			LegendFormat: "{{pod}}",
+		}}},
+		{Title: "Latest backup age", Type: "stat", Targets: []target{{
+			Expr:         fmt.Sprintf(`time() - pxc_operator_backup_last_success_timestamp_seconds{%s}`, sel),
+			LegendFormat: "{{storage}}",
+		}}},
+		{Title: "PITR lag", Type: "stat", Targets: []target{{
+			Expr: fmt.Sprintf(`time() - pxc_operator_pitr_latest_restorable_timestamp_seconds{%s}`, sel),
+		}}},
+		{Title: "Certificate expiry", Type: "stat", Targets: []target{{
+			Expr:         fmt.Sprintf(`pxc_operator_tls_certificate_expiration_timestamp_seconds{%s} - time()`, sel),
+			LegendFormat: "{{secret}}",
+		}}},
+	}
+	for i := range panels {
+		panels[i].ID = i + 1
+		panels[i].GridPos = gridPos{H: 8, W: 12, X: (i % 2) * 12, Y: (i / 2) * 8}
+	}
+
+	dashboard, err := json.Marshal(map[string]interface{}{
+		"title":         fmt.Sprintf("PXC %s/%s", cr.Namespace, cr.Name),
+		"uid":           string(cr.UID),
+		"tags":          []string{"percona-xtradb-cluster"},
+		"schemaVersion": 39,
+		"refresh":       "30s",
+		"time":          map[string]string{"from": "now-6h", "to": "now"},
+		"panels":        panels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal dashboard")
+	}
+
+	labels := naming.LabelsCluster(cr)
+	for k, v := range cr.Spec.Monitoring.Dashboard.Labels {
+		labels[k] = v
+	}
+
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.DashboardConfigMapName(cr),
+			Namespace: cr.Namespace,
+			Labels:    labels,
+		},
+		Data: map[string]string{
+			cr.Name + "-pxc.json": string(dashboard),
+		},
+	}, nil
+}
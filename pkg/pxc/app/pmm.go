@@ -17,6 +17,10 @@ func PMMClient(cr *api.PerconaXtraDBCluster, spec *api.PMMSpec, secret *corev1.S
 		ports = append(ports, corev1.ContainerPort{ContainerPort: int32(i)})
 	}
 
+	if spec.IsPMM3(secret) {
+		return pmm3Client(cr, spec, secret, envVarsSecret, ports)
+	}
+
 	pmmEnvs := []corev1.EnvVar{
 		{
 			Name:  "PMM_SERVER",
@@ -45,24 +49,34 @@ func PMMClient(cr *api.PerconaXtraDBCluster, spec *api.PMMSpec, secret *corev1.S
 	pmmEnvs = append(pmmEnvs, clientEnvs...)
 
 	pmmAgentEnvs := pmmAgentEnvs(spec.ServerHost, spec.ServerUser, secret.Name, spec.UseAPI(secret))
-	if cr.CompareVersionWith("1.14.0") >= 0 {
-		val := "$(POD_NAMESPASE)-$(POD_NAME)"
-		if len(envVarsSecret.Data["PMM_PREFIX"]) > 0 {
-			val = "$(PMM_PREFIX)$(POD_NAMESPASE)-$(POD_NAME)"
-		}
-		pmmAgentEnvs = append(pmmAgentEnvs, corev1.EnvVar{
-			Name:  "PMM_AGENT_SETUP_NODE_NAME",
-			Value: val,
-		})
-	} else {
-		pmmAgentEnvs = append(pmmAgentEnvs, corev1.EnvVar{
-			Name:  "PMM_AGENT_SETUP_NODE_NAME",
-			Value: "$(POD_NAMESPASE)-$(POD_NAME)",
-		})
-	}
+	pmmAgentEnvs = append(pmmAgentEnvs, pmmNodeNameEnv(cr, envVarsSecret))
 
 	pmmEnvs = append(pmmEnvs, pmmAgentEnvs...)
 
+	return pmmContainer(cr, spec, pmmEnvs, ports)
+}
+
+// pmm3Client returns the PMM 3 client container. PMM 3 authenticates
+// with the service account token and doesn't use pmm-admin environment.
+func pmm3Client(cr *api.PerconaXtraDBCluster, spec *api.PMMSpec, secret *corev1.Secret, envVarsSecret *corev1.Secret, ports []corev1.ContainerPort) corev1.Container {
+	envs := pmm3AgentEnvs(spec.ServerHost, secret.Name)
+	envs = append(envs, pmmNodeNameEnv(cr, envVarsSecret))
+
+	return pmmContainer(cr, spec, envs, ports)
+}
+
+func pmmNodeNameEnv(cr *api.PerconaXtraDBCluster, envVarsSecret *corev1.Secret) corev1.EnvVar {
+	val := "$(POD_NAMESPASE)-$(POD_NAME)"
+	if cr.CompareVersionWith("1.14.0") >= 0 && len(envVarsSecret.Data["PMM_PREFIX"]) > 0 {
+		val = "$(PMM_PREFIX)$(POD_NAMESPASE)-$(POD_NAME)"
+	}
+	return corev1.EnvVar{
+		Name:  "PMM_AGENT_SETUP_NODE_NAME",
+		Value: val,
+	}
+}
+
+func pmmContainer(cr *api.PerconaXtraDBCluster, spec *api.PMMSpec, pmmEnvs []corev1.EnvVar, ports []corev1.ContainerPort) corev1.Container {
 	container := corev1.Container{
 		Name:            "pmm-client",
 		Image:           spec.Image,
@@ -208,6 +222,33 @@ func pmmAgentEnvs(pmmServerHost, pmmServerUser, secrets string, useAPI bool) []c
 	}
 }
 
+func pmm3AgentEnvs(pmmServerHost, secrets string) []corev1.EnvVar {
+	envs := pmmAgentEnvs(pmmServerHost, "service_token", secrets, false)
+	for i := range envs {
+		switch envs[i].Name {
+		case "PMM_AGENT_SERVER_PASSWORD":
+			envs[i].ValueFrom.SecretKeyRef = SecretKeySelector(secrets, users.PMMServerToken)
+		case "PMM_AGENT_CONFIG_FILE":
+			envs[i].Value = "/usr/local/percona/pmm/config/pmm-agent.yaml"
+		}
+	}
+
+	return append(envs,
+		corev1.EnvVar{
+			Name:  "PMM_AGENT_PATHS_TEMPDIR",
+			Value: "/tmp",
+		},
+		corev1.EnvVar{
+			Name:  "PMM_AGENT_SIDECAR",
+			Value: "true",
+		},
+		corev1.EnvVar{
+			Name:  "PMM_AGENT_SIDECAR_SLEEP",
+			Value: "5",
+		},
+	)
+}
+
 func PMMAgentScript(cr *api.PerconaXtraDBCluster, dbType string) []corev1.EnvVar {
 	if cr.CompareVersionWith("1.13.0") < 0 {
 		pmmServerArgs := " $(PMM_ADMIN_CUSTOM_PARAMS) --skip-connection-check --metrics-mode=push"
@@ -0,0 +1,67 @@
+package app
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+// galeraPorts are the SST, group communication and IST ports.
+// Galera authenticates and encrypts this traffic itself and the SST donor
+// connects to the IP of the joiner, so the proxy must not intercept it.
+const galeraPorts = "4444,4567,4568"
+
+// ServiceMeshAnnotations returns a copy of the pod annotations extended with
+// the annotations configuring the proxy sidecar of the service mesh.
+func ServiceMeshAnnotations(mesh api.ServiceMeshType, annotations map[string]string) map[string]string {
+	var meshAnnotations map[string]string
+	switch mesh {
+	case api.ServiceMeshIstio:
+		meshAnnotations = map[string]string{
+			"traffic.sidecar.istio.io/excludeInboundPorts":  galeraPorts,
+			"traffic.sidecar.istio.io/excludeOutboundPorts": galeraPorts,
+			"proxy.istio.io/config":                         `{"holdApplicationUntilProxyStarts": true}`,
+			"sidecar.istio.io/rewriteAppHTTPProbers":        "true",
+		}
+	case api.ServiceMeshLinkerd:
+		meshAnnotations = map[string]string{
+			"config.linkerd.io/skip-inbound-ports":   galeraPorts,
+			"config.linkerd.io/skip-outbound-ports":  galeraPorts,
+			"config.linkerd.io/proxy-await":          "enabled",
+			"config.linkerd.io/proxy-admin-shutdown": "enabled",
+		}
+	default:
+		return annotations
+	}
+
+	merged := make(map[string]string, len(annotations)+len(meshAnnotations))
+	for k, v := range annotations {
+		merged[k] = v
+	}
+	for k, v := range meshAnnotations {
+		merged[k] = v
+	}
+	return merged
+}
+
+// ServiceMeshJobContainer wraps the command of the job container to stop the
+// proxy sidecar when the command exits. The job never completes otherwise.
+// The exit code of the command is kept.
+func ServiceMeshJobContainer(mesh api.ServiceMeshType, c *corev1.Container) {
+	var shutdownURL string
+	switch mesh {
+	case api.ServiceMeshIstio:
+		shutdownURL = "http://127.0.0.1:15020/quitquitquit"
+	case api.ServiceMeshLinkerd:
+		shutdownURL = "http://127.0.0.1:4191/shutdown"
+	default:
+		return
+	}
+
+	script := `"$@"; rc=$?; curl -fsS -X POST ` + shutdownURL + ` >/dev/null || true; exit $rc`
+
+	cmd := []string{"bash", "-c", script, "--"}
+	cmd = append(cmd, c.Command...)
+	c.Command = append(cmd, c.Args...)
+	c.Args = nil
+}
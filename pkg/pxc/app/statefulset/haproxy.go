@@ -238,6 +238,13 @@ func (c *HAProxy) SidecarContainers(spec *api.PodSpec, secrets string, cr *api.P
 		container.Command = []string{"/opt/percona/haproxy-entrypoint.sh"}
 	}
 
+	if pod := cr.ReportingPodName(); pod != "" {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  "REPORTING_NODE",
+			Value: pod,
+		})
+	}
+
 	if cr.CompareVersionWith("1.15.0") < 0 {
 		container.Args = []string{
 			"/usr/bin/peer-list",
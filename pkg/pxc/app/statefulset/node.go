@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"hash/fnv"
+	"strconv"
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
@@ -252,6 +253,77 @@ func (c *Node) AppContainer(spec *api.PodSpec, secrets string, cr *api.PerconaXt
 		appc.Lifecycle = &cr.Spec.PXC.Lifecycle
 	}
 
+	if gs := cr.Spec.PXC.GracefulShutdown; gs.IsEnabled() {
+		lifecycle := cr.Spec.PXC.Lifecycle.DeepCopy()
+		lifecycle.PreStop = &corev1.LifecycleHandler{
+			Exec: &corev1.ExecAction{Command: []string{"/var/lib/mysql/pxc-graceful-shutdown.sh"}},
+		}
+		appc.Lifecycle = lifecycle
+
+		flushTables := "no"
+		if gs.FlushTables {
+			flushTables = "yes"
+		}
+		appc.Env = append(appc.Env, []corev1.EnvVar{
+			{
+				Name:  "GRACEFUL_SHUTDOWN_DRAIN_SECONDS",
+				Value: strconv.Itoa(int(gs.DrainSeconds)),
+			},
+			{
+				Name:  "GRACEFUL_SHUTDOWN_RECV_QUEUE_TIMEOUT",
+				Value: strconv.Itoa(int(gs.RecvQueueTimeoutSeconds)),
+			},
+			{
+				Name:  "GRACEFUL_SHUTDOWN_FLUSH_TABLES",
+				Value: flushTables,
+			},
+			{
+				Name:  "GRACEFUL_SHUTDOWN_FLUSH_TABLES_TIMEOUT",
+				Value: strconv.Itoa(int(gs.FlushTablesTimeoutSeconds)),
+			},
+		}...)
+	}
+
+	for _, dv := range config.DataVolumes(cr) {
+		appc.VolumeMounts = append(appc.VolumeMounts, corev1.VolumeMount{
+			Name:      dv.Name,
+			MountPath: dv.MountPath,
+		})
+	}
+
+	if pod := cr.ReportingPodName(); pod != "" {
+		appc.Env = append(appc.Env, corev1.EnvVar{
+			Name:  "REPORTING_NODE",
+			Value: pod,
+		})
+		appc.VolumeMounts = append(appc.VolumeMounts, corev1.VolumeMount{
+			Name:      "reporting-config",
+			MountPath: "/etc/mysql/reporting",
+		})
+	}
+
+	if cr.Spec.PXC.ProviderOptions != nil {
+		appc.Env = append(appc.Env, corev1.EnvVar{
+			Name:  "PROVIDER_OPTIONS_DIR",
+			Value: config.ProviderOptionsDir,
+		})
+		appc.VolumeMounts = append(appc.VolumeMounts, corev1.VolumeMount{
+			Name:      "provider-options",
+			MountPath: config.ProviderOptionsDir,
+		})
+	}
+
+	if cr.Spec.PXC.GaleraSegments.IsEnabled() {
+		appc.Env = append(appc.Env, corev1.EnvVar{
+			Name:  "GALERA_SEGMENTS_DIR",
+			Value: config.GaleraSegmentsDir,
+		})
+		appc.VolumeMounts = append(appc.VolumeMounts, corev1.VolumeMount{
+			Name:      "galera-segments",
+			MountPath: config.GaleraSegmentsDir,
+		})
+	}
+
 	if cr.CompareVersionWith("1.16.0") >= 0 {
 		appc.Env = append(appc.Env, []corev1.EnvVar{
 			{
@@ -269,6 +341,9 @@ func (c *Node) AppContainer(spec *api.PodSpec, secrets string, cr *api.PerconaXt
 }
 
 func (c *Node) SidecarContainers(spec *api.PodSpec, secrets string, cr *api.PerconaXtraDBCluster) ([]corev1.Container, error) {
+	if cr.Spec.PXC.Metrics.IsEnabled() {
+		return []corev1.Container{app.MysqldExporter(cr.Spec.PXC.Metrics, secrets)}, nil
+	}
 	return nil, nil
 }
 
@@ -354,10 +429,10 @@ func (c *Node) LogCollectorContainer(spec *api.LogCollectorSpec, logPsecrets str
 	}
 
 	if cr.Spec.LogCollector != nil {
-		if cr.Spec.LogCollector.Configuration != "" {
+		if config.LogCollectorConfigEnabled(cr) {
 			logProcContainer.VolumeMounts = append(logProcContainer.VolumeMounts, corev1.VolumeMount{
 				Name:      "logcollector-config",
-				MountPath: "/etc/fluentbit/custom",
+				MountPath: config.LogCollectorConfigDir,
 			})
 		}
 
@@ -500,6 +575,74 @@ func (c *Node) PMMContainer(ctx context.Context, cl client.Client, spec *api.PMM
 	return &ct, nil
 }
 
+// autoConfigVolume returns the volume with autotune config. If an authentication
+// plugin, password policy, managed logs, data volumes or SST options are configured, the volume also contains their options and LDAP CA.
+func autoConfigVolume(cr *api.PerconaXtraDBCluster, component string) corev1.Volume {
+	if !config.AuthConfigEnabled(cr) && !config.LoggingConfigEnabled(cr) && !config.DataVolumesConfigEnabled(cr) && !config.SSTConfigEnabled(cr) {
+		return app.GetConfigVolumes("auto-config", config.AutoTuneConfigMapName(cr.Name, component))
+	}
+
+	t := true
+	sources := []corev1.VolumeProjection{
+		{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: config.AutoTuneConfigMapName(cr.Name, component)},
+				Optional:             &t,
+			},
+		},
+	}
+
+	if config.AuthConfigEnabled(cr) {
+		sources = append(sources, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: config.AuthConfigSecretName(cr.Name)},
+			},
+		})
+	}
+
+	if config.LoggingConfigEnabled(cr) {
+		sources = append(sources, corev1.VolumeProjection{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: config.LoggingConfigMapName(cr.Name)},
+			},
+		})
+	}
+
+	if config.DataVolumesConfigEnabled(cr) {
+		sources = append(sources, corev1.VolumeProjection{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: config.DataVolumesConfigMapName(cr.Name)},
+			},
+		})
+	}
+
+	if config.SSTConfigEnabled(cr) {
+		sources = append(sources, corev1.VolumeProjection{
+			ConfigMap: &corev1.ConfigMapProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: config.SSTConfigMapName(cr.Name)},
+			},
+		})
+	}
+
+	if auth := cr.Spec.PXC.Authentication; config.AuthConfigEnabled(cr) && auth != nil && auth.LDAP != nil && auth.LDAP.CASecretName != "" {
+		sources = append(sources, corev1.VolumeProjection{
+			Secret: &corev1.SecretProjection{
+				LocalObjectReference: corev1.LocalObjectReference{Name: auth.LDAP.CASecretName},
+				Items: []corev1.KeyToPath{
+					{Key: "ca.crt", Path: config.LDAPCAFileName},
+				},
+			},
+		})
+	}
+
+	return corev1.Volume{
+		Name: "auto-config",
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{Sources: sources},
+		},
+	}
+}
+
 func (c *Node) Volumes(podSpec *api.PodSpec, cr *api.PerconaXtraDBCluster, vg api.CustomVolumeGetter) (*api.Volume, error) {
 	vol := app.Volumes(podSpec, app.DataVolumeName)
 
@@ -519,16 +662,31 @@ func (c *Node) Volumes(podSpec *api.PodSpec, cr *api.PerconaXtraDBCluster, vg ap
 		configVolume,
 		app.GetSecretVolumes("ssl-internal", podSpec.SSLInternalSecretName, true),
 		sslVolume,
-		app.GetConfigVolumes("auto-config", config.AutoTuneConfigMapName(cr.Name, app.Name)),
+		autoConfigVolume(cr, app.Name),
 		app.GetSecretVolumes(VaultSecretVolumeName, podSpec.VaultSecretName, true),
 		app.GetSecretVolumes("mysql-users-secret-file", "internal-"+cr.Name, false),
 	)
 
-	if cr.Spec.LogCollector != nil && cr.Spec.LogCollector.Configuration != "" {
+	if config.LogCollectorConfigEnabled(cr) {
 		vol.Volumes = append(vol.Volumes,
 			app.GetConfigVolumes("logcollector-config", config.CustomConfigMapName(cr.Name, "logcollector")))
 	}
 
+	if cr.Spec.PXC.Reporting.IsEnabled() {
+		vol.Volumes = append(vol.Volumes,
+			app.GetConfigVolumes("reporting-config", config.ReportingConfigMapName(cr.Name)))
+	}
+
+	if cr.Spec.PXC.ProviderOptions != nil {
+		vol.Volumes = append(vol.Volumes,
+			app.GetConfigVolumes("provider-options", config.ProviderOptionsConfigMapName(cr.Name)))
+	}
+
+	if cr.Spec.PXC.GaleraSegments.IsEnabled() {
+		vol.Volumes = append(vol.Volumes,
+			app.GetConfigVolumes("galera-segments", config.GaleraSegmentsConfigMapName(cr.Name)))
+	}
+
 	if cr.CompareVersionWith("1.11.0") >= 0 {
 		if cr.Spec.PXC != nil && cr.Spec.PXC.HookScript != "" {
 			vol.Volumes = append(vol.Volumes,
@@ -545,6 +703,15 @@ func (c *Node) Volumes(podSpec *api.PodSpec, cr *api.PerconaXtraDBCluster, vg ap
 		vol.Volumes = append(vol.Volumes, app.GetSecretVolumes("mysql-init-file", cr.Name+"-mysql-init", true))
 	}
 
+	if vol.PVCs != nil {
+		for _, dv := range config.DataVolumes(cr) {
+			vol.PVCs = append(vol.PVCs, corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: dv.Name},
+				Spec:       *dv.Spec.DeepCopy(),
+			})
+		}
+	}
+
 	if cr.CompareVersionWith("1.16.0") >= 0 {
 		for i := range vol.PVCs {
 			vol.PVCs[i].Labels = c.Labels()
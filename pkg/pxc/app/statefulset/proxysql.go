@@ -226,6 +226,13 @@ func (c *Proxy) SidecarContainers(spec *api.PodSpec, secrets string, cr *api.Per
 		})
 	}
 
+	if pod := cr.ReportingPodName(); pod != "" {
+		pxcMonit.Env = append(pxcMonit.Env, corev1.EnvVar{
+			Name:  "REPORTING_NODE",
+			Value: pod,
+		})
+	}
+
 	if cr.CompareVersionWith("1.15.0") < 0 {
 		pxcMonit.Args = []string{
 			"/usr/bin/peer-list",
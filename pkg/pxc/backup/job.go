@@ -100,7 +100,19 @@ func (bcp *Backup) JobSpec(spec api.PXCBackupSpec, cluster *api.PerconaXtraDBClu
 		initContainers = append(initContainers, statefulset.BackupInitContainer(cluster, initImage, storage.ContainerSecurityContext))
 	}
 
-	return batchv1.JobSpec{
+	container := corev1.Container{
+		Name:            "xtrabackup",
+		Image:           bcp.image,
+		SecurityContext: storage.ContainerSecurityContext,
+		ImagePullPolicy: bcp.imagePullPolicy,
+		Command:         []string{"bash", "/usr/bin/backup.sh"},
+		Env:             envs,
+		Resources:       storage.Resources,
+		VolumeMounts:    volumeMounts,
+	}
+	app.ServiceMeshJobContainer(cluster.Spec.ServiceMesh, &container)
+
+	jobSpec := batchv1.JobSpec{
 		ActiveDeadlineSeconds: activeDeadlineSeconds,
 		BackoffLimit:          &backoffLimit,
 		ManualSelector:        &manualSelector,
@@ -110,26 +122,15 @@ func (bcp *Backup) JobSpec(spec api.PXCBackupSpec, cluster *api.PerconaXtraDBClu
 		Template: corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
 				Labels:      job.Labels,
-				Annotations: storage.Annotations,
+				Annotations: app.ServiceMeshAnnotations(cluster.Spec.ServiceMesh, storage.Annotations),
 			},
 			Spec: corev1.PodSpec{
-				SecurityContext:    storage.PodSecurityContext,
-				ImagePullSecrets:   bcp.imagePullSecrets,
-				RestartPolicy:      corev1.RestartPolicyNever,
-				ServiceAccountName: cluster.Spec.Backup.ServiceAccountName,
-				InitContainers:     initContainers,
-				Containers: []corev1.Container{
-					{
-						Name:            "xtrabackup",
-						Image:           bcp.image,
-						SecurityContext: storage.ContainerSecurityContext,
-						ImagePullPolicy: bcp.imagePullPolicy,
-						Command:         []string{"bash", "/usr/bin/backup.sh"},
-						Env:             envs,
-						Resources:       storage.Resources,
-						VolumeMounts:    volumeMounts,
-					},
-				},
+				SecurityContext:           storage.PodSecurityContext,
+				ImagePullSecrets:          bcp.imagePullSecrets,
+				RestartPolicy:             corev1.RestartPolicyNever,
+				ServiceAccountName:        cluster.Spec.Backup.ServiceAccountName,
+				InitContainers:            initContainers,
+				Containers:                []corev1.Container{container},
 				Affinity:                  storage.Affinity,
 				TopologySpreadConstraints: pxc.PodTopologySpreadConstraints(storage.TopologySpreadConstraints, job.Labels),
 				Tolerations:               storage.Tolerations,
@@ -140,7 +141,10 @@ func (bcp *Backup) JobSpec(spec api.PXCBackupSpec, cluster *api.PerconaXtraDBClu
 				Volumes:                   volumes,
 			},
 		},
-	}, nil
+	}
+	cluster.ApplyImageOverrides(&jobSpec.Template.Spec)
+
+	return jobSpec, nil
 }
 
 func appendStorageSecret(job *batchv1.JobSpec, cr *api.PerconaXtraDBClusterBackup) error {
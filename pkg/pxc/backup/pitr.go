@@ -10,20 +10,24 @@ import (
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/percona/percona-xtradb-cluster-operator/clientcmd"
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/metrics"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app/binlogcollector"
 )
 
-func CheckPITRErrors(ctx context.Context, cl client.Client, clcmd *clientcmd.Client, cr *api.PerconaXtraDBCluster) error {
+func CheckPITRErrors(ctx context.Context, cl client.Client, clcmd *clientcmd.Client, recorder record.EventRecorder, cr *api.PerconaXtraDBCluster) error {
 	log := logf.FromContext(ctx)
 
 	if cr.Spec.Backup == nil || !cr.Spec.Backup.PITR.Enabled {
@@ -83,12 +87,19 @@ func CheckPITRErrors(ctx context.Context, cl client.Client, clcmd *clientcmd.Cli
 		Message:            fmt.Sprintf("Binlog with GTID set %s not found", missingGTIDSet),
 		LastTransitionTime: metav1.Now(),
 	}
-	meta.SetStatusCondition(&backup.Status.Conditions, condition)
-
-	if err := cl.Status().Update(ctx, backup); err != nil {
+	err = k8s.PatchStatus(ctx, cl, backup, func(b *api.PerconaXtraDBClusterBackup) {
+		meta.SetStatusCondition(&b.Status.Conditions, condition)
+	})
+	if err != nil {
 		return errors.Wrap(err, "update backup status")
 	}
 
+	if recorder != nil {
+		msg := fmt.Sprintf("Gap detected in binary logs, backup %s can't be used for point-in-time recovery: GTID set %s not found", backup.Name, missingGTIDSet)
+		recorder.Event(cr, corev1.EventTypeWarning, naming.EventPITRInvalidated, msg)
+		recorder.Event(backup, corev1.EventTypeWarning, naming.EventPITRInvalidated, msg)
+	}
+
 	if err := binlogcollector.RemoveGapFile(clcmd, collectorPod); err != nil {
 		if !errors.Is(err, binlogcollector.GapFileNotFound) {
 			return errors.Wrap(err, "remove gap file")
@@ -149,13 +160,16 @@ func UpdatePITRTimeline(ctx context.Context, cl client.Client, clcmd *clientcmd.
 	}
 	latestTm := time.Unix(latest, 0)
 
+	metrics.SetPITRLatestRestorable(cr, latestTm)
+
 	if backup.Status.LatestRestorableTime != nil && backup.Status.LatestRestorableTime.Time.Equal(latestTm) {
 		return nil
 	}
 
-	backup.Status.LatestRestorableTime = &metav1.Time{Time: latestTm}
-
-	if err := cl.Status().Update(ctx, backup); err != nil {
+	err = k8s.PatchStatus(ctx, cl, backup, func(b *api.PerconaXtraDBClusterBackup) {
+		b.Status.LatestRestorableTime = &metav1.Time{Time: latestTm}
+	})
+	if err != nil {
 		return errors.Wrap(err, "update backup status")
 	}
 
@@ -164,33 +178,51 @@ func UpdatePITRTimeline(ctx context.Context, cl client.Client, clcmd *clientcmd.
 	return nil
 }
 
+// LatestRestorableTime returns the time the binary logs are uploaded up to by the binlog collector.
+// It returns nil if there is no successful backup or the collector hasn't uploaded anything after it.
+func LatestRestorableTime(ctx context.Context, cl client.Client, cr *api.PerconaXtraDBCluster) (*metav1.Time, error) {
+	backup, err := getLatestSuccessfulBackup(ctx, cl, cr)
+	if err != nil {
+		if errors.Is(err, ErrNoBackups) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "get latest successful backup")
+	}
+
+	return backup.Status.LatestRestorableTime, nil
+}
+
+// LatestSuccessfulBackup returns the latest successful backup of the cluster or nil if there is none.
+func LatestSuccessfulBackup(ctx context.Context, cl client.Client, cr *api.PerconaXtraDBCluster) (*api.PerconaXtraDBClusterBackup, error) {
+	backup, err := getLatestSuccessfulBackup(ctx, cl, cr)
+	if errors.Is(err, ErrNoBackups) {
+		return nil, nil
+	}
+	return backup, err
+}
+
 var ErrNoBackups = errors.New("No backups found")
 
 func getLatestSuccessfulBackup(ctx context.Context, cl client.Client, cr *api.PerconaXtraDBCluster) (*api.PerconaXtraDBClusterBackup, error) {
 	bcpList := api.PerconaXtraDBClusterBackupList{}
-	if err := cl.List(ctx, &bcpList, &client.ListOptions{Namespace: cr.Namespace}); err != nil {
+	if err := k8s.ListByIndex(ctx, cl, &bcpList, cr.Namespace, k8s.IndexPXCCluster, cr.Name); err != nil {
 		return nil, errors.Wrap(err, "get backup objects")
 	}
 
-	if len(bcpList.Items) == 0 {
-		return nil, ErrNoBackups
-	}
-
-	latest := bcpList.Items[0]
-	for _, bcp := range bcpList.Items {
+	var latest *api.PerconaXtraDBClusterBackup
+	for i, bcp := range bcpList.Items {
 		if bcp.Spec.PXCCluster != cr.Name || bcp.Status.State != api.BackupSucceeded {
 			continue
 		}
 
-		if latest.ObjectMeta.CreationTimestamp.Before(&bcp.ObjectMeta.CreationTimestamp) {
-			latest = bcp
+		if latest == nil || latest.ObjectMeta.CreationTimestamp.Before(&bcp.ObjectMeta.CreationTimestamp) {
+			latest = &bcpList.Items[i]
 		}
 	}
 
-	// if there are no successful backups, don't blindly return the first item
-	if latest.Status.State != api.BackupSucceeded {
+	if latest == nil {
 		return nil, ErrNoBackups
 	}
 
-	return &latest, nil
+	return latest, nil
 }
@@ -83,7 +83,7 @@ func PVCRestorePod(cr *api.PerconaXtraDBClusterRestore, bcpStorageName, pvcName
 	restoreSvcName := pvcRestoreSvcName(cr)
 
 	labels := naming.LabelsRestorePVCPod(cluster, bcpStorageName, restoreSvcName)
-	return &corev1.Pod{
+	pod := &corev1.Pod{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
 			Kind:       "Pod",
@@ -148,7 +148,10 @@ func PVCRestorePod(cr *api.PerconaXtraDBClusterRestore, bcpStorageName, pvcName
 			ServiceAccountName:        cluster.Spec.PXC.ServiceAccountName,
 			RuntimeClassName:          cluster.Spec.Backup.Storages[bcpStorageName].RuntimeClassName,
 		},
-	}, nil
+	}
+	cluster.ApplyImageOverrides(&pod.Spec)
+
+	return pod, nil
 }
 
 func RestoreJob(cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClusterBackup, cluster *api.PerconaXtraDBCluster, initImage string, destination api.PXCBackupDestination, pitr bool) (*batchv1.Job, error) {
@@ -224,6 +227,21 @@ func RestoreJob(cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClust
 			jobName = "pitr-job-" + cr.Name + "-" + cr.Spec.PXCCluster
 			volumeMounts = []corev1.VolumeMount{}
 			volumes = []corev1.Volume{}
+			if cr.Spec.PITR.ParallelWorkers > 0 {
+				// binlogs are copied to the datadir of the node they are applied on as relay logs
+				volumeMounts = append(volumeMounts, corev1.VolumeMount{
+					Name:      app.DataVolumeName,
+					MountPath: "/var/lib/mysql",
+				})
+				volumes = append(volumes, corev1.Volume{
+					Name: app.DataVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "datadir-" + cr.Spec.PXCCluster + "-pxc-0",
+						},
+					},
+				})
+			}
 			command = []string{"/opt/percona/pitr", "recover"}
 			if cluster.CompareVersionWith("1.15.0") < 0 {
 				command = []string{"pitr", "recover"}
@@ -255,6 +273,12 @@ func RestoreJob(cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClust
 		}
 	}
 
+	// the PITR job doesn't prepare the backup
+	var tuning *api.XtrabackupTuning
+	if !pitr {
+		tuning = cr.Spec.ContainerOptions.GetTuning(cluster, bcp.Spec.StorageName)
+	}
+
 	envs, err := restoreJobEnvs(bcp, cr, cluster, destination, pitr)
 	if err != nil {
 		return nil, errors.Wrap(err, "restore job envs")
@@ -281,7 +305,7 @@ func RestoreJob(cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClust
 					SecurityContext:  cluster.Spec.PXC.PodSecurityContext,
 					InitContainers:   initContainers,
 					Containers: []corev1.Container{
-						xtrabackupContainer(cr, cluster, command, volumeMounts, envs),
+						xtrabackupContainer(cr, cluster, tuning, command, volumeMounts, envs),
 					},
 					RestartPolicy:             corev1.RestartPolicyNever,
 					Volumes:                   volumes,
@@ -301,9 +325,35 @@ func RestoreJob(cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClust
 	if cluster.CompareVersionWith("1.16.0") < 0 {
 		job.Labels = cluster.Spec.PXC.Labels
 	}
+	if cluster.Spec.ServiceMesh != "" {
+		job.Spec.Template.Annotations = app.ServiceMeshAnnotations(cluster.Spec.ServiceMesh, job.Spec.Template.Annotations)
+		app.ServiceMeshJobContainer(cluster.Spec.ServiceMesh, &job.Spec.Template.Spec.Containers[0])
+	}
+	if pitr && cr.Spec.PITR.ParallelWorkers > 0 {
+		job.Spec.Template.Spec.Affinity = pitrParallelAffinity(cluster)
+	}
+	cluster.ApplyImageOverrides(&job.Spec.Template.Spec)
 	return job, nil
 }
 
+// pitrParallelAffinity schedules the PITR job on the node of the first PXC pod,
+// so the job can share its datadir volume.
+func pitrParallelAffinity(cluster *api.PerconaXtraDBCluster) *corev1.Affinity {
+	ls := naming.LabelsPXC(cluster)
+	ls["statefulset.kubernetes.io/pod-name"] = cluster.Name + "-pxc-0"
+
+	return &corev1.Affinity{
+		PodAffinity: &corev1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{MatchLabels: ls},
+					TopologyKey:   "kubernetes.io/hostname",
+				},
+			},
+		},
+	}
+}
+
 func restoreJobEnvs(bcp *api.PerconaXtraDBClusterBackup, cr *api.PerconaXtraDBClusterRestore, cluster *api.PerconaXtraDBCluster, destination api.PXCBackupDestination, pitr bool) ([]corev1.EnvVar, error) {
 	if bcp.Status.GetStorageType(cluster) == api.BackupStorageFilesystem {
 		return util.MergeEnvLists(
@@ -366,6 +416,12 @@ func restoreJobEnvs(bcp *api.PerconaXtraDBClusterBackup, cr *api.PerconaXtraDBCl
 				Value: cr.Spec.PITR.Type,
 			},
 		}...)
+		if cr.Spec.PITR.ParallelWorkers > 0 {
+			envs = append(envs, corev1.EnvVar{
+				Name:  "PITR_PARALLEL_WORKERS",
+				Value: strconv.Itoa(int(cr.Spec.PITR.ParallelWorkers)),
+			})
+		}
 		if bs := cr.Spec.PITR.BackupSource; bs != nil {
 			if bs.StorageName != "" {
 				storage, ok := cluster.Spec.Backup.Storages[bs.StorageName]
@@ -383,6 +439,9 @@ func restoreJobEnvs(bcp *api.PerconaXtraDBClusterBackup, cr *api.PerconaXtraDBCl
 		Name:  "VERIFY_TLS",
 		Value: strconv.FormatBool(verifyTLS),
 	})
+	if l := bcp.Status.Layout; l != nil && !pitr {
+		envs = append(envs, layoutEnvs(l)...)
+	}
 
 	switch bcp.Status.GetStorageType(cluster) {
 	case api.BackupStorageAzure:
@@ -406,6 +465,27 @@ func restoreJobEnvs(bcp *api.PerconaXtraDBClusterBackup, cr *api.PerconaXtraDBCl
 	), nil
 }
 
+// layoutEnvs describe the backups which weren't taken by the operator to the restore script.
+func layoutEnvs(l *api.BackupLayout) []corev1.EnvVar {
+	envs := []corev1.EnvVar{
+		{
+			Name:  "BACKUP_FORMAT",
+			Value: string(l.GetFormat()),
+		},
+		{
+			Name:  "BACKUP_PREPARED",
+			Value: strconv.FormatBool(l.Prepared),
+		},
+	}
+	if l.Compression != "" {
+		envs = append(envs, corev1.EnvVar{
+			Name:  "BACKUP_COMPRESSION",
+			Value: l.Compression,
+		})
+	}
+	return envs
+}
+
 func azureEnvs(cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClusterBackup, cluster *api.PerconaXtraDBCluster, destination api.PXCBackupDestination, pitr bool) ([]corev1.EnvVar, error) {
 	azure := bcp.Status.Azure
 	container, prefix := azure.ContainerAndPrefix()
@@ -589,11 +669,12 @@ func s3Envs(cr *api.PerconaXtraDBClusterRestore, bcp *api.PerconaXtraDBClusterBa
 				Value: "s3",
 			},
 		}...)
+		envs = append(envs, storageS3.AlternateEnvs("BINLOG_")...)
 	}
 	return envs, nil
 }
 
-func xtrabackupContainer(cr *api.PerconaXtraDBClusterRestore, cluster *api.PerconaXtraDBCluster, cmd []string, volumeMounts []corev1.VolumeMount, envs []corev1.EnvVar) corev1.Container {
+func xtrabackupContainer(cr *api.PerconaXtraDBClusterRestore, cluster *api.PerconaXtraDBCluster, tuning *api.XtrabackupTuning, cmd []string, volumeMounts []corev1.VolumeMount, envs []corev1.EnvVar) corev1.Container {
 	container := corev1.Container{
 		Name:            "xtrabackup",
 		Image:           cluster.Spec.Backup.Image,
@@ -608,7 +689,14 @@ func xtrabackupContainer(cr *api.PerconaXtraDBClusterRestore, cluster *api.Perco
 		container.Resources = cluster.Spec.PXC.Resources
 	}
 
+	if tuning != nil && tuning.PrepareResources != nil {
+		container.Resources = *tuning.PrepareResources.DeepCopy()
+	}
+
 	useMem := xbMemoryUse(container.Resources)
+	if tuning != nil && tuning.UseMemory != nil {
+		useMem = strconv.FormatInt(tuning.UseMemory.Value(), 10)
+	}
 	container.Env = append(
 		container.Env,
 		corev1.EnvVar{
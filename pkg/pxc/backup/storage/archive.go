@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"path"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+)
+
+// ArchiveStorage is implemented by the storages whose objects can be in archival storage classes
+// and have to be retrieved before they can be read.
+type ArchiveStorage interface {
+	// ArchiveState returns the archival state of the object.
+	ArchiveState(ctx context.Context, name string) (ArchiveState, error)
+	// RetrieveObject requests a temporary copy of the archived object kept for the given days.
+	RetrieveObject(ctx context.Context, name string, tier string, days int) error
+}
+
+// ArchiveState is the archival state of an object.
+type ArchiveState struct {
+	StorageClass string
+	// Archived objects can't be read until they are retrieved.
+	Archived bool
+	// Retrieving is true if the retrieval of the object is requested and not finished yet.
+	Retrieving bool
+	// Retrieved is true if a temporary copy of the archived object can be read.
+	Retrieved bool
+}
+
+// Readable returns true if the object can be read.
+func (s ArchiveState) Readable() bool {
+	return !s.Archived || s.Retrieved
+}
+
+// archivalStorageClasses are the S3 storage classes objects have to be retrieved from.
+// GLACIER_IR objects can be read right away.
+var archivalStorageClasses = map[string]bool{
+	"GLACIER":      true,
+	"DEEP_ARCHIVE": true,
+}
+
+func (s *S3) ArchiveState(ctx context.Context, name string) (ArchiveState, error) {
+	objPath := path.Join(s.prefix, name)
+	info, err := s.client.StatObject(ctx, s.bucketName, objPath, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(errors.Cause(err)).Code == "NoSuchKey" {
+			return ArchiveState{}, ErrObjectNotFound
+		}
+		return ArchiveState{}, errors.Wrapf(err, "stat object %s", objPath)
+	}
+
+	state := ArchiveState{
+		StorageClass: info.StorageClass,
+		Archived:     archivalStorageClasses[info.StorageClass],
+	}
+	if info.Restore != nil {
+		state.Retrieving = info.Restore.OngoingRestore
+		state.Retrieved = !info.Restore.OngoingRestore
+	}
+	return state, nil
+}
+
+func (s *S3) RetrieveObject(ctx context.Context, name string, tier string, days int) error {
+	objPath := path.Join(s.prefix, name)
+
+	req := minio.RestoreRequest{}
+	req.SetDays(days)
+	req.SetGlacierJobParameters(minio.GlacierJobParameters{Tier: minio.TierType(tier)})
+
+	err := s.client.RestoreObject(ctx, s.bucketName, objPath, "", req)
+	if err != nil {
+		// the retrieval is requested already
+		if minio.ToErrorResponse(errors.Cause(err)).Code == "RestoreAlreadyInProgress" {
+			return nil
+		}
+		return errors.Wrapf(err, "restore object %s", objPath)
+	}
+	return nil
+}
+
+func (f *S3Failover) ArchiveState(ctx context.Context, name string) (ArchiveState, error) {
+	var state ArchiveState
+	err := f.do(ctx, nil, func(s Storage) error {
+		a, ok := s.(ArchiveStorage)
+		if !ok {
+			return nil
+		}
+		var err error
+		state, err = a.ArchiveState(ctx, name)
+		return err
+	})
+	return state, err
+}
+
+func (f *S3Failover) RetrieveObject(ctx context.Context, name string, tier string, days int) error {
+	return f.do(ctx, nil, func(s Storage) error {
+		a, ok := s.(ArchiveStorage)
+		if !ok {
+			return nil
+		}
+		return a.RetrieveObject(ctx, name, tier, days)
+	})
+}
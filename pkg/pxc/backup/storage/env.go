@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"os"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+// S3AlternatesFromEnv reads the alternate endpoints passed by BackupStorageS3Spec.AlternateEnvs.
+// The options not passed are taken from main.
+func S3AlternatesFromEnv(prefix string, main *S3Options) []*S3Options {
+	var alternates []*S3Options
+	for i := 0; ; i++ {
+		bucketURL, ok := os.LookupEnv(api.S3AlternateEnvName(prefix, i, "BUCKET_URL"))
+		if !ok {
+			return alternates
+		}
+
+		opts := *main
+		opts.Alternates = nil
+		opts.BucketName, opts.Prefix = (&api.BackupStorageS3Spec{Bucket: bucketURL}).BucketAndPrefix()
+		opts.Endpoint = os.Getenv(api.S3AlternateEnvName(prefix, i, "ENDPOINT"))
+		if region := os.Getenv(api.S3AlternateEnvName(prefix, i, "REGION")); region != "" {
+			opts.Region = region
+		}
+		if key, ok := os.LookupEnv(api.S3AlternateEnvName(prefix, i, "ACCESS_KEY_ID")); ok {
+			opts.AccessKeyID = key
+			opts.SecretAccessKey = os.Getenv(api.S3AlternateEnvName(prefix, i, "SECRET_ACCESS_KEY"))
+		}
+		alternates = append(alternates, &opts)
+	}
+}
@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// S3Failover is an S3 storage with alternate endpoints.
+// The requests go to the first available endpoint, a failed request is repeated
+// on the next endpoints if it can be, and the next requests start from the endpoint which served it.
+type S3Failover struct {
+	mu        sync.Mutex
+	endpoints []*S3Options
+	clients   []Storage
+	current   int
+	newClient func(context.Context, *S3Options) (Storage, error)
+}
+
+// NewS3Failover returns the storage of the main endpoint of opts and its alternates.
+// It fails only if none of the endpoints is available.
+func NewS3Failover(ctx context.Context, opts *S3Options) (Storage, error) {
+	return newS3Failover(ctx, opts, func(ctx context.Context, o *S3Options) (Storage, error) {
+		return NewS3(ctx, o.Endpoint, o.AccessKeyID, o.SecretAccessKey, o.BucketName, o.Prefix, o.Region, o.VerifyTLS)
+	})
+}
+
+func newS3Failover(ctx context.Context, opts *S3Options, newClient func(context.Context, *S3Options) (Storage, error)) (*S3Failover, error) {
+	main := *opts
+	main.Alternates = nil
+	endpoints := append([]*S3Options{&main}, opts.Alternates...)
+
+	f := &S3Failover{
+		endpoints: endpoints,
+		clients:   make([]Storage, len(endpoints)),
+		newClient: newClient,
+	}
+
+	i, err := f.available(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	f.current = i
+
+	return f, nil
+}
+
+// AvailableS3Endpoint returns the index of the first endpoint of opts a client can be created for,
+// 0 is the main endpoint and i is the alternate i-1.
+func AvailableS3Endpoint(ctx context.Context, opts *S3Options, newClient NewClientFunc) (int, error) {
+	f, err := newS3Failover(ctx, opts, func(ctx context.Context, o *S3Options) (Storage, error) {
+		return newClient(ctx, o)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return f.current, nil
+}
+
+// Endpoint returns the options of the endpoint the requests go to.
+func (f *S3Failover) Endpoint() *S3Options {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.endpoints[f.current]
+}
+
+// available returns the index of the first available endpoint starting from the start one.
+func (f *S3Failover) available(ctx context.Context, start int) (int, error) {
+	var errs []error
+	for n := 0; n < len(f.endpoints); n++ {
+		i := (start + n) % len(f.endpoints)
+		if _, err := f.client(ctx, i); err != nil {
+			errs = append(errs, errors.Wrapf(err, "endpoint %s bucket %s", f.endpoints[i].Endpoint, f.endpoints[i].BucketName))
+			continue
+		}
+		return i, nil
+	}
+	return 0, errors.Wrap(stderrors.Join(errs...), "no S3 endpoint is available")
+}
+
+func (f *S3Failover) client(ctx context.Context, i int) (Storage, error) {
+	if f.clients[i] != nil {
+		return f.clients[i], nil
+	}
+	cli, err := f.newClient(ctx, f.endpoints[i])
+	if err != nil {
+		return nil, err
+	}
+	f.clients[i] = cli
+	return cli, nil
+}
+
+// do runs req on the current endpoint and, if it fails and retry allows it, on the next available ones.
+// Missing objects aren't a reason to fail over.
+func (f *S3Failover) do(ctx context.Context, retry func() bool, req func(Storage) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	start := f.current
+	var errs []error
+	for n := 0; n < len(f.endpoints); n++ {
+		i := (start + n) % len(f.endpoints)
+
+		cli, err := f.client(ctx, i)
+		if err == nil {
+			err = req(cli)
+			if err == nil || errors.Is(err, ErrObjectNotFound) {
+				f.current = i
+				return err
+			}
+		}
+		errs = append(errs, errors.Wrapf(err, "endpoint %s bucket %s", f.endpoints[i].Endpoint, f.endpoints[i].BucketName))
+
+		// the next requests start from the next endpoint
+		f.current = (i + 1) % len(f.endpoints)
+		if retry != nil && !retry() {
+			break
+		}
+	}
+	return stderrors.Join(errs...)
+}
+
+func (f *S3Failover) GetObject(ctx context.Context, objectName string) (io.ReadCloser, error) {
+	var obj io.ReadCloser
+	err := f.do(ctx, nil, func(s Storage) error {
+		var err error
+		obj, err = s.GetObject(ctx, objectName)
+		return err
+	})
+	return obj, err
+}
+
+// PutObject repeats the upload on the next endpoint only if data can be rewound.
+func (f *S3Failover) PutObject(ctx context.Context, name string, data io.Reader, size int64) error {
+	seeker, ok := data.(io.Seeker)
+	retry := func() bool {
+		if !ok {
+			return false
+		}
+		_, err := seeker.Seek(0, io.SeekStart)
+		return err == nil
+	}
+	return f.do(ctx, retry, func(s Storage) error {
+		return s.PutObject(ctx, name, data, size)
+	})
+}
+
+func (f *S3Failover) ListObjects(ctx context.Context, prefix string) ([]string, error) {
+	var list []string
+	err := f.do(ctx, nil, func(s Storage) error {
+		var err error
+		list, err = s.ListObjects(ctx, prefix)
+		return err
+	})
+	return list, err
+}
+
+func (f *S3Failover) DeleteObject(ctx context.Context, objectName string) error {
+	return f.do(ctx, nil, func(s Storage) error {
+		return s.DeleteObject(ctx, objectName)
+	})
+}
+
+func (f *S3Failover) SetPrefix(prefix string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i, o := range f.endpoints {
+		o := *o
+		o.Prefix = prefix
+		f.endpoints[i] = &o
+		if f.clients[i] != nil {
+			f.clients[i].SetPrefix(prefix)
+		}
+	}
+}
+
+func (f *S3Failover) GetPrefix() string {
+	return f.Endpoint().Prefix
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type endpointStorage struct {
+	bucket string
+	down   bool
+	puts   []string
+}
+
+func (s *endpointStorage) GetObject(ctx context.Context, name string) (io.ReadCloser, error) {
+	if s.down {
+		return nil, errors.New("connection refused")
+	}
+	if name == "missing" {
+		return nil, ErrObjectNotFound
+	}
+	return io.NopCloser(strings.NewReader(s.bucket)), nil
+}
+
+func (s *endpointStorage) PutObject(ctx context.Context, name string, data io.Reader, size int64) error {
+	if s.down {
+		return errors.New("connection refused")
+	}
+	b, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+	s.puts = append(s.puts, name+"="+string(b))
+	return nil
+}
+
+func (s *endpointStorage) ListObjects(ctx context.Context, prefix string) ([]string, error) {
+	return nil, nil
+}
+func (s *endpointStorage) DeleteObject(ctx context.Context, name string) error { return nil }
+func (s *endpointStorage) SetPrefix(prefix string)                             {}
+func (s *endpointStorage) GetPrefix() string                                   { return "" }
+
+func TestS3Failover(t *testing.T) {
+	ctx := context.Background()
+
+	opts := &S3Options{
+		BucketName: "main",
+		Region:     "us-east-1",
+		Alternates: []*S3Options{
+			{BucketName: "replica-1", Region: "eu-west-1"},
+			{BucketName: "replica-2", Region: "eu-central-1"},
+		},
+	}
+	storages := map[string]*endpointStorage{
+		"main":      {bucket: "main"},
+		"replica-1": {bucket: "replica-1", down: true},
+		"replica-2": {bucket: "replica-2"},
+	}
+	unreachable := map[string]bool{"main": true}
+	newClient := func(ctx context.Context, o *S3Options) (Storage, error) {
+		if o.Alternates != nil {
+			t.Errorf("endpoint %s has alternates", o.BucketName)
+		}
+		if unreachable[o.BucketName] {
+			return nil, errors.Errorf("bucket %s is unreachable", o.BucketName)
+		}
+		return storages[o.BucketName], nil
+	}
+
+	f, err := newS3Failover(ctx, opts, newClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := f.Endpoint(); e.BucketName != "replica-1" {
+		t.Fatalf("expected the first reachable endpoint, got %s", e.BucketName)
+	}
+
+	// replica-1 is reachable but its requests fail
+	obj, err := f.GetObject(ctx, "binlog_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := io.ReadAll(obj); string(b) != "replica-2" {
+		t.Errorf("expected the object from replica-2, got %s", b)
+	}
+	if e := f.Endpoint(); e.BucketName != "replica-2" {
+		t.Errorf("expected the requests to stay on replica-2, got %s", e.BucketName)
+	}
+
+	if _, err := f.GetObject(ctx, "missing"); err != ErrObjectNotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if e := f.Endpoint(); e.BucketName != "replica-2" {
+		t.Errorf("missing objects shouldn't fail over, got %s", e.BucketName)
+	}
+
+	storages["replica-2"].down = true
+	storages["replica-1"].down = false
+	if err := f.PutObject(ctx, "seekable", bytes.NewReader([]byte("data")), 4); err != nil {
+		t.Fatal(err)
+	}
+	if puts := storages["replica-1"].puts; len(puts) != 1 || puts[0] != "seekable=data" {
+		t.Errorf("expected the upload to be repeated on replica-1, got %v", puts)
+	}
+
+	storages["replica-1"].down = true
+	storages["replica-2"].down = false
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write([]byte("stream"))
+		_ = pw.Close()
+	}()
+	if err := f.PutObject(ctx, "stream", pr, -1); err == nil {
+		t.Error("expected the stream upload to fail without retries")
+	}
+	_ = pr.Close()
+	if e := f.Endpoint(); e.BucketName != "replica-2" {
+		t.Errorf("expected the next requests to go to replica-2, got %s", e.BucketName)
+	}
+
+	unreachable = map[string]bool{"main": true, "replica-1": true, "replica-2": true}
+	if _, err := AvailableS3Endpoint(ctx, opts, func(ctx context.Context, o Options) (Storage, error) {
+		return newClient(ctx, o.(*S3Options))
+	}); err == nil {
+		t.Error("expected an error if no endpoint is available")
+	}
+}
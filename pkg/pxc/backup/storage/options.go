@@ -136,7 +136,7 @@ func getS3Options(
 		verify = false
 	}
 
-	return &S3Options{
+	opts := &S3Options{
 		Endpoint:        s3.EndpointURL,
 		AccessKeyID:     accessKeyID,
 		SecretAccessKey: secretAccessKey,
@@ -144,7 +144,13 @@ func getS3Options(
 		Prefix:          prefix,
 		Region:          region,
 		VerifyTLS:       verify,
-	}, nil
+	}
+	opts.Alternates, err = getS3AlternateOptions(ctx, cl, cluster.Namespace, s3, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "get alternate endpoints")
+	}
+
+	return opts, nil
 }
 
 func getS3OptionsFromBackup(ctx context.Context, cl client.Client, cluster *api.PerconaXtraDBCluster, backup *api.PerconaXtraDBClusterBackup) (*S3Options, error) {
@@ -184,7 +190,7 @@ func getS3OptionsFromBackup(ctx context.Context, cl client.Client, cluster *api.
 		}
 	}
 
-	return &S3Options{
+	opts := &S3Options{
 		Endpoint:        backup.Status.S3.EndpointURL,
 		AccessKeyID:     accessKeyID,
 		SecretAccessKey: secretAccessKey,
@@ -192,7 +198,50 @@ func getS3OptionsFromBackup(ctx context.Context, cl client.Client, cluster *api.
 		Prefix:          prefix,
 		Region:          region,
 		VerifyTLS:       verifyTLS,
-	}, nil
+	}
+	opts.Alternates, err = getS3AlternateOptions(ctx, cl, backup.Namespace, backup.Status.S3, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "get alternate endpoints")
+	}
+
+	return opts, nil
+}
+
+// getS3AlternateOptions returns the options of the alternate endpoints of the storage.
+// The options not set for an endpoint are taken from the main one.
+func getS3AlternateOptions(ctx context.Context, cl client.Client, namespace string, s3 *api.BackupStorageS3Spec, main *S3Options) ([]*S3Options, error) {
+	var alternates []*S3Options
+	for _, e := range s3.Endpoints()[1:] {
+		opts := *main
+		opts.Alternates = nil
+		opts.Endpoint = e.EndpointURL
+
+		if e.CredentialsSecret != s3.CredentialsSecret {
+			secret := new(corev1.Secret)
+			err := cl.Get(ctx, types.NamespacedName{
+				Name:      e.CredentialsSecret,
+				Namespace: namespace,
+			}, secret)
+			if client.IgnoreNotFound(err) != nil {
+				return nil, errors.Wrap(err, "failed to get secret")
+			}
+			opts.AccessKeyID = string(secret.Data["AWS_ACCESS_KEY_ID"])
+			opts.SecretAccessKey = string(secret.Data["AWS_SECRET_ACCESS_KEY"])
+		}
+
+		if bucket, prefix := e.BucketAndPrefix(); bucket != "" {
+			opts.BucketName = bucket
+			opts.Prefix = prefix
+		}
+
+		opts.Region = e.Region
+		if opts.Region == "" {
+			opts.Region = "us-east-1"
+		}
+
+		alternates = append(alternates, &opts)
+	}
+	return alternates, nil
 }
 
 var _ = Options(new(S3Options))
@@ -205,6 +254,8 @@ type S3Options struct {
 	Prefix          string
 	Region          string
 	VerifyTLS       bool
+	// Alternates are the options of the endpoints used if this one is unavailable.
+	Alternates []*S3Options
 }
 
 func (o *S3Options) Type() api.BackupStorageType {
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	"path"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/minio/minio-go/v7"
+	"github.com/pkg/errors"
+)
+
+// SizeStorage is implemented by the storages which can report the size of their objects.
+type SizeStorage interface {
+	// PrefixSize returns the total size of the objects with the prefix in bytes.
+	PrefixSize(ctx context.Context, prefix string) (int64, error)
+}
+
+func (s *S3) PrefixSize(ctx context.Context, prefix string) (int64, error) {
+	opts := minio.ListObjectsOptions{
+		UseV1:     true,
+		Recursive: true,
+		Prefix:    s.prefix + prefix,
+	}
+
+	var size int64
+	var err error
+	// the channel has to be drained, see ListObjects
+	for object := range s.client.ListObjects(ctx, s.bucketName, opts) {
+		if err != nil {
+			continue
+		}
+		if object.Err != nil {
+			err = errors.Wrapf(object.Err, "list object %s", object.Key)
+			continue
+		}
+		size += object.Size
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+func (a *Azure) PrefixSize(ctx context.Context, prefix string) (int64, error) {
+	listPrefix := path.Join(a.prefix, prefix)
+	pg := a.client.NewListBlobsFlatPager(a.container, &container.ListBlobsFlatOptions{
+		Prefix: &listPrefix,
+	})
+	var size int64
+	for pg.More() {
+		resp, err := pg.NextPage(ctx)
+		if err != nil {
+			return 0, errors.Wrapf(err, "next page: %s", prefix)
+		}
+		if resp.Segment == nil {
+			continue
+		}
+		for _, item := range resp.Segment.BlobItems {
+			if item != nil && item.Properties != nil && item.Properties.ContentLength != nil {
+				size += *item.Properties.ContentLength
+			}
+		}
+	}
+	return size, nil
+}
+
+func (f *S3Failover) PrefixSize(ctx context.Context, prefix string) (int64, error) {
+	var size int64
+	err := f.do(ctx, nil, func(s Storage) error {
+		sz, ok := s.(SizeStorage)
+		if !ok {
+			return nil
+		}
+		var err error
+		size, err = sz.PrefixSize(ctx, prefix)
+		return err
+	})
+	return size, err
+}
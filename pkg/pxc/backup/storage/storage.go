@@ -39,6 +39,9 @@ func NewClient(ctx context.Context, opts Options) (Storage, error) {
 		if !ok {
 			return nil, errors.New("invalid options type")
 		}
+		if len(opts.Alternates) > 0 {
+			return NewS3Failover(ctx, opts)
+		}
 		return NewS3(ctx, opts.Endpoint, opts.AccessKeyID, opts.SecretAccessKey, opts.BucketName, opts.Prefix, opts.Region, opts.VerifyTLS)
 	case api.BackupStorageAzure:
 		opts, ok := opts.(*AzureOptions)
@@ -276,3 +279,34 @@ func (a *Azure) DeleteObject(ctx context.Context, objectName string) error {
 	}
 	return nil
 }
+
+// DeleteBackupObjects deletes the objects of the backup stored under destination,
+// including its SST info and checksums.
+func DeleteBackupObjects(ctx context.Context, s Storage, destination string) error {
+	blobs, err := s.ListObjects(ctx, destination)
+	if err != nil {
+		return errors.Wrap(err, "list backup blobs")
+	}
+	for _, blob := range blobs {
+		if err := s.DeleteObject(ctx, blob); err != nil {
+			return errors.Wrapf(err, "delete object %s", blob)
+		}
+	}
+	if err := s.DeleteObject(ctx, strings.TrimSuffix(destination, "/")+".md5"); err != nil && err != ErrObjectNotFound {
+		return errors.Wrapf(err, "delete object %s", strings.TrimSuffix(destination, "/")+".md5")
+	}
+	destination = strings.TrimSuffix(destination, "/") + ".sst_info/"
+	blobs, err = s.ListObjects(ctx, destination)
+	if err != nil {
+		return errors.Wrap(err, "list backup objects")
+	}
+	for _, blob := range blobs {
+		if err := s.DeleteObject(ctx, blob); err != nil {
+			return errors.Wrapf(err, "delete object %s", blob)
+		}
+	}
+	if err := s.DeleteObject(ctx, strings.TrimSuffix(destination, "/")+".md5"); err != nil && err != ErrObjectNotFound {
+		return errors.Wrapf(err, "delete object %s", strings.TrimSuffix(destination, "/")+".md5")
+	}
+	return nil
+}
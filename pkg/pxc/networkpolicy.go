@@ -0,0 +1,134 @@
+package pxc
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+)
+
+// NetworkPolicies returns the NetworkPolicies of the enabled components of the cluster.
+func NetworkPolicies(cr *api.PerconaXtraDBCluster, operatorNamespace string) []*networkingv1.NetworkPolicy {
+	spec := cr.Spec.NetworkPolicy
+
+	// the proxies, backup, restore and PITR pods of the cluster connect to the PXC pods
+	clusterPods := networkingv1.NetworkPolicyPeer{
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{naming.LabelAppKubernetesInstance: cr.Name},
+		},
+	}
+	operator := networkingv1.NetworkPolicyPeer{
+		NamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{corev1.LabelMetadataName: operatorNamespace},
+		},
+		PodSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{naming.LabelAppKubernetesName: "percona-xtradb-cluster-operator"},
+		},
+	}
+
+	pxcIngress := []networkingv1.NetworkPolicyIngressRule{
+		{
+			From:  []networkingv1.NetworkPolicyPeer{clusterPods},
+			Ports: tcpPorts(3306, 4444, 4567, 4568, 33060, 33062),
+		},
+		{
+			From:  []networkingv1.NetworkPolicyPeer{operator},
+			Ports: tcpPorts(3306, 33062),
+		},
+	}
+	if cr.Spec.PXC.Expose.Enabled || cr.Spec.PXC.ExternalReplication.IsEnabled() || cr.Spec.PXC.Reporting.IsEnabled() {
+		pxcIngress = append(pxcIngress, networkingv1.NetworkPolicyIngressRule{Ports: tcpPorts(3306, 33060)})
+	}
+	if m := cr.Spec.PXC.Metrics; m.IsEnabled() {
+		pxcIngress = append(pxcIngress, networkingv1.NetworkPolicyIngressRule{Ports: tcpPorts(m.Port)})
+	}
+	pxcIngress = append(pxcIngress, pmmIngress(cr)...)
+
+	policies := []*networkingv1.NetworkPolicy{
+		networkPolicy(cr, cr.Name+"-"+appName, naming.SelectorPXC(cr), pxcIngress, spec.AdditionalIngress.PXC),
+	}
+
+	if cr.HAProxyEnabled() {
+		ingress := []networkingv1.NetworkPolicyIngressRule{
+			// the clients of the cluster and the operator
+			{Ports: tcpPorts(3306, 3307, 3309, 33060, 33062)},
+			{Ports: tcpPorts(8404)},
+		}
+		ingress = append(ingress, pmmIngress(cr)...)
+		policies = append(policies, networkPolicy(cr, cr.Name+"-haproxy", naming.SelectorHAProxy(cr), ingress, spec.AdditionalIngress.HAProxy))
+	}
+
+	if cr.ProxySQLEnabled() {
+		ingress := []networkingv1.NetworkPolicyIngressRule{
+			// the clients of the cluster and the operator
+			{Ports: tcpPorts(3306, 33062)},
+			{
+				From:  []networkingv1.NetworkPolicyPeer{clusterPods, operator},
+				Ports: tcpPorts(6032),
+			},
+			{Ports: tcpPorts(6070)},
+		}
+		ingress = append(ingress, pmmIngress(cr)...)
+		policies = append(policies, networkPolicy(cr, cr.Name+"-proxysql", naming.SelectorProxySQL(cr), ingress, spec.AdditionalIngress.ProxySQL))
+	}
+
+	if cr.Spec.Backup != nil && cr.Spec.Backup.PITR.Enabled {
+		ingress := []networkingv1.NetworkPolicyIngressRule{
+			{Ports: tcpPorts(8080)},
+		}
+		policies = append(policies, networkPolicy(cr, cr.Name+"-pitr", naming.LabelsPITR(cr), ingress, nil))
+	}
+
+	return policies
+}
+
+// pmmIngress allows PMM Server to reach the pmm-client sidecar.
+func pmmIngress(cr *api.PerconaXtraDBCluster) []networkingv1.NetworkPolicyIngressRule {
+	if cr.Spec.PMM == nil || !cr.Spec.PMM.Enabled {
+		return nil
+	}
+
+	ports := tcpPorts(7777, 30100)
+	endPort := int32(30105)
+	ports[1].EndPort = &endPort
+
+	return []networkingv1.NetworkPolicyIngressRule{{Ports: ports}}
+}
+
+func networkPolicy(cr *api.PerconaXtraDBCluster, name string, selector map[string]string, ingress, additional []networkingv1.NetworkPolicyIngressRule) *networkingv1.NetworkPolicy {
+	rules := make([]networkingv1.NetworkPolicyIngressRule, 0, len(ingress)+len(additional))
+	rules = append(rules, ingress...)
+	for _, r := range additional {
+		rules = append(rules, *r.DeepCopy())
+	}
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.k8s.io/v1",
+			Kind:       "NetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cr.Namespace,
+			Labels:    naming.LabelsNetworkPolicy(cr),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: selector},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     rules,
+		},
+	}
+}
+
+func tcpPorts(ports ...int32) []networkingv1.NetworkPolicyPort {
+	proto := corev1.ProtocolTCP
+	p := make([]networkingv1.NetworkPolicyPort, 0, len(ports))
+	for _, port := range ports {
+		port := intstr.FromInt32(port)
+		p = append(p, networkingv1.NetworkPolicyPort{Protocol: &proto, Port: &port})
+	}
+	return p
+}
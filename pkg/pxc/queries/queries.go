@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -42,6 +43,68 @@ type ReplicationConfig struct {
 	SSL                bool
 	SSLSkipVerify      bool
 	CA                 string
+	SSLCert            string
+	SSLKey             string
+	SSLCipher          string
+	TLSVersion         string
+	// HeartbeatPeriod, CompressionAlgorithms and ZstdCompressionLevel aren't changed if they're empty.
+	HeartbeatPeriod       uint
+	CompressionAlgorithms string
+	ZstdCompressionLevel  uint
+	// Filters are applied to the channel before it's started, nil leaves the current filters unchanged.
+	Filters *ReplicationFilters
+}
+
+// ReplicationFilters are the per channel replication filters.
+// Tables are specified as db.table, RewriteDB pairs are the from and to databases.
+type ReplicationFilters struct {
+	DoDB            []string
+	IgnoreDB        []string
+	DoTable         []string
+	IgnoreTable     []string
+	WildDoTable     []string
+	WildIgnoreTable []string
+	RewriteDB       [][2]string
+}
+
+// ChangeFilterQuery renders the CHANGE REPLICATION FILTER statement without the channel clause.
+// All filters are set, so the empty ones are cleared.
+func (f *ReplicationFilters) ChangeFilterQuery() string {
+	list := func(values []string, quote func(string) string) string {
+		quoted := make([]string, 0, len(values))
+		for _, v := range values {
+			quoted = append(quoted, quote(v))
+		}
+		return "(" + strings.Join(quoted, ", ") + ")"
+	}
+
+	rewrite := make([]string, 0, len(f.RewriteDB))
+	for _, r := range f.RewriteDB {
+		rewrite = append(rewrite, "("+quoteIdentifier(r[0])+", "+quoteIdentifier(r[1])+")")
+	}
+
+	return "CHANGE REPLICATION FILTER " + strings.Join([]string{
+		"REPLICATE_DO_DB = " + list(f.DoDB, quoteIdentifier),
+		"REPLICATE_IGNORE_DB = " + list(f.IgnoreDB, quoteIdentifier),
+		"REPLICATE_DO_TABLE = " + list(f.DoTable, quoteTable),
+		"REPLICATE_IGNORE_TABLE = " + list(f.IgnoreTable, quoteTable),
+		"REPLICATE_WILD_DO_TABLE = " + list(f.WildDoTable, quoteString),
+		"REPLICATE_WILD_IGNORE_TABLE = " + list(f.WildIgnoreTable, quoteString),
+		"REPLICATE_REWRITE_DB = (" + strings.Join(rewrite, ", ") + ")",
+	}, ", ")
+}
+
+func quoteIdentifier(s string) string {
+	return "`" + strings.ReplaceAll(s, "`", "``") + "`"
+}
+
+func quoteTable(s string) string {
+	db, table, _ := strings.Cut(s, ".")
+	return quoteIdentifier(db) + "." + quoteIdentifier(table)
+}
+
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), "'", "''") + "'"
 }
 
 type ReplicationChannelSource struct {
@@ -252,6 +315,55 @@ func (p *Database) StopReplication(name string) error {
 	return errors.Wrap(err, "stop replication for channel "+name)
 }
 
+// ResetReplication clears the relay logs and the replication position of the stopped channel,
+// the connection parameters are kept.
+func (p *Database) ResetReplication(name string) error {
+	_, err := p.db.Exec("RESET REPLICA FOR CHANNEL ?", name)
+	return errors.Wrap(err, "reset replication for channel "+name)
+}
+
+// ChecksumDiffs returns the time of the latest pt-table-checksum result replicated
+// to percona.checksums and the tables with checksums different from the source.
+// ErrNotFound is returned if the checksums table doesn't exist.
+func (p *Database) ChecksumDiffs() (time.Time, []string, error) {
+	var last sql.NullInt64
+	err := p.db.QueryRow("SELECT UNIX_TIMESTAMP(MAX(ts)) FROM percona.checksums").Scan(&last)
+	if err != nil {
+		var mErr *mysql.MySQLError
+		if errors.As(err, &mErr) && mErr.Number == 1146 {
+			return time.Time{}, nil, ErrNotFound
+		}
+		return time.Time{}, nil, errors.Wrap(err, "get latest checksum")
+	}
+	if !last.Valid {
+		return time.Time{}, nil, nil
+	}
+
+	rows, err := p.db.Query(`
+		SELECT DISTINCT CONCAT(db, '.', tbl)
+		FROM   percona.checksums
+		WHERE  master_cnt <> this_cnt
+		       OR master_crc <> this_crc
+		       OR ISNULL(master_crc) <> ISNULL(this_crc)
+		ORDER  BY 1
+	`)
+	if err != nil {
+		return time.Time{}, nil, errors.Wrap(err, "get checksum diffs")
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return time.Time{}, nil, errors.Wrap(err, "read checksum diff")
+		}
+		tables = append(tables, table)
+	}
+
+	return time.Unix(last.Int64, 0), tables, rows.Err()
+}
+
 func (p *Database) EnableReadonly() error {
 	_, err := p.db.Exec("SET GLOBAL READ_ONLY=1")
 	return errors.Wrap(err, "set global read_only param to 1")
@@ -262,6 +374,69 @@ func (p *Database) DisableReadonly() error {
 	return errors.Wrap(err, "set global read_only param to 0")
 }
 
+// WaitForExecutedGTIDSet waits until the server applies all transactions from the GTID set.
+// It returns false if the timeout is reached.
+func (p *Database) WaitForExecutedGTIDSet(ctx context.Context, gtidSet string, timeout time.Duration) (bool, error) {
+	var result int
+	err := p.db.QueryRowContext(ctx, "SELECT WAIT_FOR_EXECUTED_GTID_SET(?, ?)", gtidSet, int(timeout.Seconds())).Scan(&result)
+	if err != nil {
+		return false, errors.Wrap(err, "wait for executed gtid set")
+	}
+	return result == 0, nil
+}
+
+// BinlogExpireSeconds returns how long binary logs are kept, 0 means they aren't purged automatically.
+func (p *Database) BinlogExpireSeconds() (int64, error) {
+	var seconds int64
+	err := p.db.QueryRow("SELECT @@binlog_expire_logs_seconds").Scan(&seconds)
+	return seconds, errors.Wrap(err, "select binlog_expire_logs_seconds")
+}
+
+func (p *Database) SetBinlogExpireSeconds(seconds int64) error {
+	_, err := p.db.Exec("SET GLOBAL binlog_expire_logs_seconds = ?", seconds)
+	return errors.Wrap(err, "set binlog_expire_logs_seconds")
+}
+
+// PurgeBinaryLogsBefore deletes the binary logs older than t.
+// The server doesn't delete the log a connected replica is reading and the later ones.
+func (p *Database) PurgeBinaryLogsBefore(ctx context.Context, t time.Time) error {
+	_, err := p.db.ExecContext(ctx, fmt.Sprintf("PURGE BINARY LOGS BEFORE FROM_UNIXTIME(%d)", t.Unix()))
+	return errors.Wrap(err, "purge binary logs")
+}
+
+// BinlogDumpThreads returns the number of replicas reading the binary logs of the server.
+func (p *Database) BinlogDumpThreads(ctx context.Context) (int, error) {
+	var count int
+	err := p.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM information_schema.PROCESSLIST WHERE COMMAND IN ('Binlog Dump', 'Binlog Dump GTID')").Scan(&count)
+	return count, errors.Wrap(err, "count binlog dump threads")
+}
+
+// WsrepProviderOptions returns the current wsrep_provider_options of the node by name.
+func (p *Database) WsrepProviderOptions() (map[string]string, error) {
+	var value string
+	err := p.db.QueryRow("SELECT @@wsrep_provider_options").Scan(&value)
+	if err != nil {
+		return nil, errors.Wrap(err, "select wsrep_provider_options")
+	}
+
+	opts := make(map[string]string)
+	for _, opt := range strings.Split(value, ";") {
+		name, v, ok := strings.Cut(opt, "=")
+		if !ok {
+			continue
+		}
+		opts[strings.TrimSpace(name)] = strings.TrimSpace(v)
+	}
+	return opts, nil
+}
+
+// SetWsrepProviderOptions sets the dynamic provider options of the node, the other options are kept.
+func (p *Database) SetWsrepProviderOptions(options string) error {
+	_, err := p.db.Exec("SET GLOBAL wsrep_provider_options = ?", options)
+	return errors.Wrap(err, "set wsrep_provider_options")
+}
+
 func (p *Database) IsReadonly() (bool, error) {
 	readonly := 0
 	err := p.db.QueryRow("select @@read_only").Scan(&readonly)
@@ -281,7 +456,12 @@ func (p *Database) StartReplication(replicaPass string, config ReplicationConfig
 		sslVerify = 1
 	}
 
-	_, err := p.db.Exec(`
+	var cert, key, cipher, tlsVersion string
+	if config.SSL {
+		cert, key, cipher, tlsVersion = config.SSLCert, config.SSLKey, config.SSLCipher, config.TLSVersion
+	}
+
+	query := `
 	CHANGE REPLICATION SOURCE TO
 		SOURCE_USER='replication',
 		SOURCE_PASSWORD=?,
@@ -293,9 +473,27 @@ func (p *Database) StartReplication(replicaPass string, config ReplicationConfig
 		SOURCE_CONNECT_RETRY=?,
 		SOURCE_SSL=?,
 		SOURCE_SSL_CA=?,
-		SOURCE_SSL_VERIFY_SERVER_CERT=?
-		FOR CHANNEL ?
-`, replicaPass, config.Source.Host, config.Source.Port, config.SourceRetryCount, config.SourceConnectRetry, ssl, ca, sslVerify, config.Source.Name)
+		SOURCE_SSL_CERT=?,
+		SOURCE_SSL_KEY=?,
+		SOURCE_SSL_CIPHER=?,
+		SOURCE_TLS_VERSION=?,
+		SOURCE_SSL_VERIFY_SERVER_CERT=?`
+	args := []any{replicaPass, config.Source.Host, config.Source.Port, config.SourceRetryCount, config.SourceConnectRetry, ssl, ca, cert, key, cipher, tlsVersion, sslVerify}
+
+	if config.HeartbeatPeriod > 0 {
+		query += ",\n\t\tSOURCE_HEARTBEAT_PERIOD=?"
+		args = append(args, config.HeartbeatPeriod)
+	}
+	if config.CompressionAlgorithms != "" {
+		query += ",\n\t\tSOURCE_COMPRESSION_ALGORITHMS=?"
+		args = append(args, config.CompressionAlgorithms)
+	}
+	if config.ZstdCompressionLevel > 0 {
+		query += ",\n\t\tSOURCE_ZSTD_COMPRESSION_LEVEL=?"
+		args = append(args, config.ZstdCompressionLevel)
+	}
+
+	_, err := p.db.Exec(query+"\n\t\tFOR CHANNEL ?", append(args, config.Source.Name)...)
 	if err != nil {
 		return errors.Wrapf(err, "change source for channel %s", config.Source.Name)
 	}
@@ -307,6 +505,13 @@ func (p *Database) StartReplication(replicaPass string, config ReplicationConfig
 		}
 	}
 
+	if config.Filters != nil {
+		_, err = p.db.Exec(config.Filters.ChangeFilterQuery()+" FOR CHANNEL ?", config.Source.Name)
+		if err != nil {
+			return errors.Wrapf(err, "change replication filter for channel %s", config.Source.Name)
+		}
+	}
+
 	_, err = p.db.Exec(`START REPLICA FOR CHANNEL ?`, config.Source.Name)
 	return errors.Wrapf(err, "start replica for source %s", config.Source.Name)
 }
@@ -400,6 +605,78 @@ func (p *Database) WsrepLocalStateComment() (string, error) {
 	return value, nil
 }
 
+// WsrepStatus returns wsrep status variables without the wsrep_ prefix
+func (p *Database) WsrepStatus(ctx context.Context) (map[string]string, error) {
+	rows, err := p.db.QueryContext(ctx, "SHOW GLOBAL STATUS LIKE 'wsrep_%'")
+	if err != nil {
+		return nil, errors.Wrap(err, "select wsrep status")
+	}
+	defer rows.Close()
+
+	status := make(map[string]string)
+	for rows.Next() {
+		var name, value string
+		if err := rows.Scan(&name, &value); err != nil {
+			return nil, errors.Wrap(err, "scan wsrep status")
+		}
+		status[strings.TrimPrefix(strings.ToLower(name), "wsrep_")] = value
+	}
+
+	return status, rows.Err()
+}
+
+// Process is a connection of the processlist.
+type Process struct {
+	ID      int64
+	User    string
+	Host    string
+	DB      string
+	Command string
+	// Time is the number of seconds the connection is in the current state.
+	Time int64
+	Info string
+	// InTransaction is true if the connection has an open InnoDB transaction.
+	InTransaction bool
+}
+
+// Processlist returns the connections of the server except the current one.
+func (p *Database) Processlist(ctx context.Context) ([]Process, error) {
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT p.ID, p.USER, IFNULL(p.HOST, ''), IFNULL(p.DB, ''), p.COMMAND, p.TIME,
+		       IFNULL(p.INFO, ''), t.trx_id IS NOT NULL
+		FROM   information_schema.PROCESSLIST p
+		       LEFT JOIN information_schema.INNODB_TRX t ON t.trx_mysql_thread_id = p.ID
+		WHERE  p.ID <> CONNECTION_ID()
+	`)
+	if err != nil {
+		return nil, errors.Wrap(err, "select processlist")
+	}
+	defer rows.Close()
+
+	var list []Process
+	for rows.Next() {
+		var proc Process
+		err := rows.Scan(&proc.ID, &proc.User, &proc.Host, &proc.DB, &proc.Command, &proc.Time, &proc.Info, &proc.InTransaction)
+		if err != nil {
+			return nil, errors.Wrap(err, "scan processlist")
+		}
+		list = append(list, proc)
+	}
+
+	return list, rows.Err()
+}
+
+// Kill terminates the statement the connection is executing
+// or the connection itself if query is false.
+func (p *Database) Kill(ctx context.Context, id int64, query bool) error {
+	stmt := "KILL CONNECTION %d"
+	if query {
+		stmt = "KILL QUERY %d"
+	}
+	_, err := p.db.ExecContext(ctx, fmt.Sprintf(stmt, id))
+	return errors.Wrapf(err, "kill %d", id)
+}
+
 func (p *Database) Version() (string, error) {
 	var version string
 
@@ -503,6 +503,107 @@ func NewServiceHAProxyReplicas(cr *api.PerconaXtraDBCluster) *corev1.Service {
 	return obj
 }
 
+// NewServiceReplicationSource returns the service replication consumers
+// that aren't managed by the operator connect to.
+func NewServiceReplicationSource(cr *api.PerconaXtraDBCluster) *corev1.Service {
+	expose := cr.Spec.PXC.ExternalReplication.Expose
+
+	svcType := corev1.ServiceTypeClusterIP
+	if len(expose.Type) > 0 {
+		svcType = expose.Type
+	}
+
+	obj := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.ReplicationSourceServiceName(cr),
+			Namespace:   cr.Namespace,
+			Labels:      fillServiceLabels(naming.LabelsReplicationSource(cr), expose.Labels),
+			Annotations: expose.Annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: svcType,
+			Ports: []corev1.ServicePort{
+				{
+					Port:       3306,
+					TargetPort: intstr.FromInt(3306),
+					Name:       "mysql",
+				},
+			},
+			Selector: naming.SelectorPXC(cr),
+			// consumers reconnect to the same node, the binlog file positions differ between the nodes
+			SessionAffinity:          corev1.ServiceAffinityClientIP,
+			LoadBalancerSourceRanges: expose.LoadBalancerSourceRanges,
+			LoadBalancerIP:           expose.LoadBalancerIP,
+		},
+	}
+
+	if svcType == corev1.ServiceTypeLoadBalancer || svcType == corev1.ServiceTypeNodePort {
+		obj.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
+		if len(expose.ExternalTrafficPolicy) > 0 {
+			obj.Spec.ExternalTrafficPolicy = expose.ExternalTrafficPolicy
+		}
+	}
+
+	return obj
+}
+
+// NewServiceReporting returns the service of the PXC pod dedicated to reporting queries.
+func NewServiceReporting(cr *api.PerconaXtraDBCluster) *corev1.Service {
+	expose := cr.Spec.PXC.Reporting.Expose
+
+	svcType := corev1.ServiceTypeClusterIP
+	if len(expose.Type) > 0 {
+		svcType = expose.Type
+	}
+
+	selector := naming.SelectorPXC(cr)
+	selector["statefulset.kubernetes.io/pod-name"] = cr.ReportingPodName()
+
+	obj := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        naming.ReportingServiceName(cr),
+			Namespace:   cr.Namespace,
+			Labels:      fillServiceLabels(naming.LabelsReporting(cr), expose.Labels),
+			Annotations: expose.Annotations,
+		},
+		Spec: corev1.ServiceSpec{
+			Type: svcType,
+			Ports: []corev1.ServicePort{
+				{
+					Port:       3306,
+					TargetPort: intstr.FromInt(3306),
+					Name:       "mysql",
+				},
+				{
+					Port:       33060,
+					TargetPort: intstr.FromInt(33060),
+					Name:       "mysqlx",
+				},
+			},
+			Selector:                 selector,
+			LoadBalancerSourceRanges: expose.LoadBalancerSourceRanges,
+			LoadBalancerIP:           expose.LoadBalancerIP,
+		},
+	}
+
+	if svcType == corev1.ServiceTypeLoadBalancer || svcType == corev1.ServiceTypeNodePort {
+		obj.Spec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicyTypeCluster
+		if len(expose.ExternalTrafficPolicy) > 0 {
+			obj.Spec.ExternalTrafficPolicy = expose.ExternalTrafficPolicy
+		}
+	}
+
+	return obj
+}
+
 func fillServiceLabels(labels map[string]string, serviceLabels map[string]string) map[string]string {
 	for k, v := range serviceLabels {
 		if _, ok := labels[k]; ok {
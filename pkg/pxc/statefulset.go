@@ -14,6 +14,7 @@ import (
 
 	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/naming"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/app"
 )
 
 // StatefulSet returns StatefulSet according for app to podSpec
@@ -59,7 +60,7 @@ func StatefulSet(ctx context.Context, cl client.Client, sfs api.StatefulApp, pod
 
 	if cr.Spec.PMM != nil && cr.Spec.PMM.Enabled {
 		if !cr.Spec.PMM.HasSecret(secret) {
-			log.Info(`Can't enable PMM: either "pmmserverkey" key doesn't exist in the secrets, or secrets and internal secrets are out of sync`,
+			log.Info(`Can't enable PMM: either "pmmserverkey" (or "pmmservertoken" for PMM 3) key doesn't exist in the secrets, or secrets and internal secrets are out of sync`,
 				"secrets", cr.Spec.SecretsName, "internalSecrets", "internal-"+cr.Name)
 		} else {
 			pmmC, err := sfs.PMMContainer(ctx, cl, cr.Spec.PMM, secret, cr)
@@ -127,6 +128,20 @@ func StatefulSet(ctx context.Context, cl client.Client, sfs api.StatefulApp, pod
 		customAnnotations["kubectl.kubernetes.io/default-container"] = sfs.Labels()[naming.LabelAppKubernetesComponent]
 	}
 
+	if cr.Spec.Velero.IsEnabled() && sfs.Labels()[naming.LabelAppKubernetesComponent] == "pxc" {
+		annotations := make(map[string]string, len(customAnnotations))
+		for k, v := range customAnnotations {
+			annotations[k] = v
+		}
+		for k, v := range naming.VeleroHookAnnotations(fmt.Sprintf("%ds", cr.Spec.Velero.HookTimeoutSeconds)) {
+			annotations[k] = v
+		}
+		customAnnotations = annotations
+	}
+	customAnnotations = app.ServiceMeshAnnotations(cr.Spec.ServiceMesh, customAnnotations)
+
+	cr.ApplyImageOverrides(&pod)
+
 	obj := sfs.StatefulSet()
 	obj.Spec = appsv1.StatefulSetSpec{
 		Replicas: &podSpec.Size,
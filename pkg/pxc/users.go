@@ -0,0 +1,54 @@
+package pxc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxc/users"
+)
+
+// NewUsersManager connects to the PXC nodes of the cluster with the passwords of the internal secret.
+// It uses the operator user, or root if the cluster was created before the operator user existed.
+func NewUsersManager(cr *api.PerconaXtraDBCluster, internalSecret *corev1.Secret) (*users.Manager, error) {
+	user := users.Root
+	pass := string(internalSecret.Data[users.Root])
+	if _, ok := internalSecret.Data[users.Operator]; ok {
+		user = users.Operator
+		pass = string(internalSecret.Data[users.Operator])
+	}
+
+	port := users.MySQLPort
+	// mysqld expects the proxy protocol header on the main port from these networks
+	hasKey, err := cr.ConfigHasKey("mysqld", "proxy_protocol_networks")
+	if err != nil {
+		return nil, errors.Wrap(err, "check if config has proxy_protocol_networks key")
+	}
+	if hasKey {
+		port = users.MySQLAdminPort
+	}
+
+	addr := fmt.Sprintf("%s-pxc-unready.%s:%d", cr.Name, cr.Namespace, port)
+	um, err := users.NewManager(addr, user, pass, cr.Spec.PXC.ReadinessProbes.TimeoutSeconds)
+	if err != nil {
+		return nil, errors.Wrap(err, "new users manager")
+	}
+
+	return &um, nil
+}
+
+// GetUsersManager reads the internal secret of the cluster and calls NewUsersManager.
+func GetUsersManager(ctx context.Context, cl client.Reader, cr *api.PerconaXtraDBCluster) (*users.Manager, error) {
+	secret := new(corev1.Secret)
+	err := cl.Get(ctx, types.NamespacedName{Name: users.InternalSecretsPrefix + cr.Name, Namespace: cr.Namespace}, secret)
+	if err != nil {
+		return nil, errors.Wrap(err, "get internal secret")
+	}
+
+	return NewUsersManager(cr, secret)
+}
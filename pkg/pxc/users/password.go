@@ -0,0 +1,100 @@
+package users
+
+import (
+	"crypto/rand"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	passwordMaxLen = 20
+	passwordMinLen = 16
+
+	passUpper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passLower   = "abcdefghijklmnopqrstuvwxyz"
+	passDigits  = "0123456789"
+	passSpecial = "!#$%&()*+,-.<=>?@[]^_{}~"
+	// passDSNSpecial are the special symbols which don't need escaping in DSNs and URLs
+	passDSNSpecial = "-_.~"
+)
+
+// PasswordPolicy is the validate_password policy the generated passwords comply with.
+// It's implemented by v1.PasswordPolicySpec, including the nil one.
+type PasswordPolicy interface {
+	GetLength() int32
+	GetMixedCaseCount() int32
+	GetNumberCount() int32
+	GetSpecialCharCount() int32
+}
+
+// GeneratePass generates a random password for a system user which complies with the password policy.
+func GeneratePass(policy PasswordPolicy) ([]byte, error) {
+	return generatePass(policy, passSpecial)
+}
+
+// GenerateDSNSafePass generates a random password which complies with the password policy
+// and can be used in connection strings without escaping.
+func GenerateDSNSafePass(policy PasswordPolicy) ([]byte, error) {
+	return generatePass(policy, passDSNSpecial)
+}
+
+func generatePass(policy PasswordPolicy, special string) ([]byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(passwordMaxLen-passwordMinLen))
+	if err != nil {
+		return nil, errors.Wrap(err, "get rand int")
+	}
+	ln := int(n.Int64()) + passwordMinLen
+	if int(policy.GetLength()) > ln {
+		ln = int(policy.GetLength())
+	}
+
+	required := []struct {
+		count   int32
+		symbols string
+	}{
+		{policy.GetMixedCaseCount(), passUpper},
+		{policy.GetMixedCaseCount(), passLower},
+		{policy.GetNumberCount(), passDigits},
+		{policy.GetSpecialCharCount(), special},
+	}
+
+	b := make([]byte, 0, ln)
+	for _, r := range required {
+		for i := int32(0); i < r.count; i++ {
+			c, err := randSymbol(r.symbols)
+			if err != nil {
+				return nil, err
+			}
+			b = append(b, c)
+		}
+	}
+
+	symbols := passUpper + passLower + passDigits + special
+	for len(b) < ln {
+		c, err := randSymbol(symbols)
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, c)
+	}
+
+	// the required symbols shouldn't always be at the beginning
+	for i := len(b) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return nil, errors.Wrap(err, "get rand int")
+		}
+		b[i], b[j.Int64()] = b[j.Int64()], b[i]
+	}
+
+	return b, nil
+}
+
+func randSymbol(symbols string) (byte, error) {
+	randInt, err := rand.Int(rand.Reader, big.NewInt(int64(len(symbols))))
+	if err != nil {
+		return 0, errors.Wrap(err, "get rand int")
+	}
+	return symbols[randInt.Int64()], nil
+}
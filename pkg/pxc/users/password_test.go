@@ -0,0 +1,59 @@
+package users
+
+import (
+	"strings"
+	"testing"
+)
+
+type testPolicy struct {
+	length, mixedCase, numbers, specialChars int32
+}
+
+func (p testPolicy) GetLength() int32           { return p.length }
+func (p testPolicy) GetMixedCaseCount() int32   { return p.mixedCase }
+func (p testPolicy) GetNumberCount() int32      { return p.numbers }
+func (p testPolicy) GetSpecialCharCount() int32 { return p.specialChars }
+
+func TestGeneratePass(t *testing.T) {
+	count := func(pass []byte, symbols string) int32 {
+		n := int32(0)
+		for _, c := range pass {
+			if strings.IndexByte(symbols, c) >= 0 {
+				n++
+			}
+		}
+		return n
+	}
+
+	tests := map[string]struct {
+		generate func(PasswordPolicy) ([]byte, error)
+		policy   testPolicy
+		special  string
+	}{
+		"no policy":               {generate: GeneratePass, special: passSpecial},
+		"policy":                  {generate: GeneratePass, policy: testPolicy{24, 2, 3, 4}, special: passSpecial},
+		"dsn safe without policy": {generate: GenerateDSNSafePass, special: passDSNSpecial},
+		"dsn safe policy":         {generate: GenerateDSNSafePass, policy: testPolicy{30, 1, 1, 5}, special: passDSNSpecial},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				pass, err := tt.generate(tt.policy)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if len(pass) < passwordMinLen || len(pass) < int(tt.policy.length) {
+					t.Fatalf("password %q is too short", pass)
+				}
+				if n := count(pass, passUpper+passLower+passDigits+tt.special); n != int32(len(pass)) {
+					t.Fatalf("password %q has unexpected symbols", pass)
+				}
+				if count(pass, passUpper) < tt.policy.mixedCase || count(pass, passLower) < tt.policy.mixedCase ||
+					count(pass, passDigits) < tt.policy.numbers || count(pass, tt.special) < tt.policy.specialChars {
+					t.Fatalf("password %q doesn't comply with policy %+v", pass, tt.policy)
+				}
+			}
+		})
+	}
+}
@@ -20,10 +20,25 @@ const (
 	ProxyAdmin   = "proxyadmin"
 	PMMServer    = "pmmserver"
 	PMMServerKey = "pmmserverkey"
+	// PMMServerToken is the service account token used by PMM 3 clients
+	PMMServerToken = "pmmservertoken"
+)
+
+// Vault is the user which HashiCorp Vault uses to manage dynamic credentials.
+// It's not a system user and exists only if vaultDBSecrets is enabled.
+const Vault = "vault"
+
+const (
+	// InternalSecretsPrefix is the name prefix of the secret with the passwords
+	// the operator has set for the system users.
+	InternalSecretsPrefix = "internal-"
+
+	MySQLPort      = 3306
+	MySQLAdminPort = 33062
 )
 
 var UserNames = []string{Root, Operator, Monitor, Xtrabackup,
-	Replication, ProxyAdmin, PMMServer, PMMServerKey}
+	Replication, ProxyAdmin, PMMServer, PMMServerKey, PMMServerToken}
 
 type Manager struct {
 	db *sql.DB
@@ -291,6 +306,47 @@ func (u *Manager) CreateReplicationUser(password string) error {
 	return nil
 }
 
+// CreateVaultUser creates or updates the user for the Vault database secrets engine.
+// Vault needs to be able to create users and grant them arbitrary privileges.
+func (u *Manager) CreateVaultUser(pass string) error {
+	_, err := u.db.Exec("CREATE USER IF NOT EXISTS 'vault'@'%' IDENTIFIED BY ?", pass)
+	if err != nil {
+		return errors.Wrap(err, "create vault user")
+	}
+
+	_, err = u.db.Exec("ALTER USER 'vault'@'%' IDENTIFIED BY ?", pass)
+	if err != nil {
+		return errors.Wrap(err, "update vault user password")
+	}
+
+	_, err = u.db.Exec("GRANT ALL ON *.* TO 'vault'@'%' WITH GRANT OPTION")
+	if err != nil {
+		return errors.Wrap(err, "grant vault user")
+	}
+
+	return nil
+}
+
+// InstallComponent installs the component if it's not installed yet
+func (u *Manager) InstallComponent(ctx context.Context, urn string) error {
+	var count int
+	err := u.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM mysql.component WHERE component_urn = ?", urn).Scan(&count)
+	if err != nil {
+		return errors.Wrap(err, "select component")
+	}
+	if count > 0 {
+		return nil
+	}
+
+	// INSTALL COMPONENT doesn't support placeholders
+	_, err = u.db.ExecContext(ctx, fmt.Sprintf("INSTALL COMPONENT '%s'", strings.ReplaceAll(urn, "'", "''")))
+	if err != nil {
+		return errors.Wrapf(err, "install component %s", urn)
+	}
+
+	return nil
+}
+
 // UpdatePassExpirationPolicy sets user password expiration policy to never
 func (u *Manager) UpdatePassExpirationPolicy(user *SysUser) error {
 	if user == nil {
@@ -376,3 +432,93 @@ func (p *Manager) GetUser(ctx context.Context, user string) (*User, error) {
 
 	return u, nil
 }
+
+// quoteIdentifier quotes a schema name so it can be used in DDL statements
+// which don't support placeholders.
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// CreateDatabase creates the schema if it doesn't exist.
+// Character set and collation must be validated by the caller.
+func (u *Manager) CreateDatabase(ctx context.Context, name, charset, collation string) error {
+	q := "CREATE DATABASE IF NOT EXISTS " + quoteIdentifier(name)
+	if charset != "" {
+		q += " CHARACTER SET " + charset
+	}
+	if collation != "" {
+		q += " COLLATE " + collation
+	}
+
+	if _, err := u.db.ExecContext(ctx, q); err != nil {
+		return errors.Wrapf(err, "create database %s", name)
+	}
+
+	return nil
+}
+
+func (u *Manager) DropDatabase(ctx context.Context, name string) error {
+	if _, err := u.db.ExecContext(ctx, "DROP DATABASE IF EXISTS "+quoteIdentifier(name)); err != nil {
+		return errors.Wrapf(err, "drop database %s", name)
+	}
+
+	return nil
+}
+
+// UpsertDatabaseOwner creates or updates the user and grants it privileges on the schema only.
+// Grants must be validated by the caller since they can't be passed as placeholders.
+func (u *Manager) UpsertDatabaseOwner(ctx context.Context, user string, hosts []string, pass, database string, grants []string) error {
+	for _, host := range hosts {
+		_, err := u.db.ExecContext(ctx, "CREATE USER IF NOT EXISTS ?@? IDENTIFIED BY ?", user, host, pass)
+		if err != nil {
+			return errors.Wrapf(err, "create user %s@%s", user, host)
+		}
+
+		_, err = u.db.ExecContext(ctx, "ALTER USER ?@? IDENTIFIED BY ?", user, host, pass)
+		if err != nil {
+			return errors.Wrapf(err, "update user %s@%s password", user, host)
+		}
+
+		_, err = u.db.ExecContext(ctx, fmt.Sprintf("GRANT %s ON %s.* TO ?@?", strings.Join(grants, ", "), quoteIdentifier(database)), user, host)
+		if err != nil {
+			return errors.Wrapf(err, "grant user %s@%s", user, host)
+		}
+	}
+
+	return nil
+}
+
+// UpsertScriptUser creates or updates a short-lived user running a SQL script or a tool. Privileges
+// are granted on the schema or on all schemas if it's empty. Grants must be validated by the caller.
+func (u *Manager) UpsertScriptUser(ctx context.Context, user, host, pass, database string, grants []string) error {
+	_, err := u.db.ExecContext(ctx, "CREATE USER IF NOT EXISTS ?@? IDENTIFIED BY ?", user, host, pass)
+	if err != nil {
+		return errors.Wrapf(err, "create user %s@%s", user, host)
+	}
+
+	_, err = u.db.ExecContext(ctx, "ALTER USER ?@? IDENTIFIED BY ?", user, host, pass)
+	if err != nil {
+		return errors.Wrapf(err, "update user %s@%s password", user, host)
+	}
+
+	scope := "*.*"
+	if database != "" {
+		scope = quoteIdentifier(database) + ".*"
+	}
+	_, err = u.db.ExecContext(ctx, fmt.Sprintf("GRANT %s ON %s TO ?@?", strings.Join(grants, ", "), scope), user, host)
+	if err != nil {
+		return errors.Wrapf(err, "grant user %s@%s", user, host)
+	}
+
+	return nil
+}
+
+func (u *Manager) DropUser(ctx context.Context, user string, hosts []string) error {
+	for _, host := range hosts {
+		if _, err := u.db.ExecContext(ctx, "DROP USER IF EXISTS ?@?", user, host); err != nil {
+			return errors.Wrapf(err, "drop user %s@%s", user, host)
+		}
+	}
+
+	return nil
+}
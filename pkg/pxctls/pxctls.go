@@ -14,11 +14,10 @@ import (
 
 const DefaultValidity = time.Hour * 24 * 365 * 3
 
-var validityNotAfter = time.Now().Add(DefaultValidity)
-
 // Issue returns CA certificate, TLS certificate and TLS private key
 func Issue(hosts []string) (caCert []byte, tlsCert []byte, tlsKey []byte, err error) {
 	rsaBits := 2048
+	validityNotAfter := time.Now().Add(DefaultValidity)
 	priv, err := rsa.GenerateKey(rand.Reader, rsaBits)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("generate rsa key: %v", err)
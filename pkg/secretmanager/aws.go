@@ -0,0 +1,128 @@
+package secretmanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/pkg/errors"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+type awsSecretsManager struct {
+	spec *api.AWSSecretsManagerSource
+	http *http.Client
+}
+
+// GetSecret calls GetSecretValue of AWS Secrets Manager. Credentials are taken
+// from the environment or from the IAM role of the operator (including IRSA).
+func (a *awsSecretsManager) GetSecret(ctx context.Context) (*Secret, error) {
+	region := a.spec.Region
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		return nil, errors.New("aws region is not specified")
+	}
+
+	creds := credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.EnvAWS{},
+		&credentials.IAM{Client: a.http},
+	})
+	cv, err := creds.Get()
+	if err != nil {
+		return nil, errors.Wrap(err, "get aws credentials")
+	}
+
+	reqBody := map[string]string{"SecretId": a.spec.SecretID}
+	if a.spec.VersionStage != "" {
+		reqBody["VersionStage"] = a.spec.VersionStage
+	}
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal request")
+	}
+
+	host := fmt.Sprintf("secretsmanager.%s.amazonaws.com", region)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+host+"/", bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "new request")
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	signV4(req, body, host, region, "secretsmanager", cv.AccessKeyID, cv.SecretAccessKey, cv.SessionToken, time.Now().UTC())
+
+	resp, err := a.http.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "get secret value")
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("get secret value: unexpected status %d: %s", resp.StatusCode, data)
+	}
+
+	out := struct {
+		SecretString string `json:"SecretString"`
+		VersionID    string `json:"VersionId"`
+	}{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, errors.Wrap(err, "unmarshal response")
+	}
+
+	return parseSecretValue([]byte(out.SecretString), out.VersionID)
+}
+
+// signV4 signs the request with AWS Signature Version 4.
+func signV4(req *http.Request, body []byte, host, region, service, accessKey, secretKey, sessionToken string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("Host", host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	signedHeaders := "content-type;host;x-amz-date;x-amz-target"
+	canonicalHeaders := fmt.Sprintf("content-type:%s\nhost:%s\nx-amz-date:%s\nx-amz-target:%s\n",
+		req.Header.Get("Content-Type"), host, amzDate, req.Header.Get("X-Amz-Target"))
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+		signedHeaders = "content-type;host;x-amz-date;x-amz-security-token;x-amz-target"
+		canonicalHeaders = fmt.Sprintf("content-type:%s\nhost:%s\nx-amz-date:%s\nx-amz-security-token:%s\nx-amz-target:%s\n",
+			req.Header.Get("Content-Type"), host, amzDate, sessionToken, req.Header.Get("X-Amz-Target"))
+	}
+
+	bodyHash := sha256.Sum256(body)
+	canonicalRequest := fmt.Sprintf("%s\n/\n\n%s\n%s\n%s", req.Method, canonicalHeaders, signedHeaders, hex.EncodeToString(bodyHash[:]))
+	crHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", date, region, service)
+	stringToSign := fmt.Sprintf("AWS4-HMAC-SHA256\n%s\n%s\n%s", amzDate, scope, hex.EncodeToString(crHash[:]))
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,72 @@
+package secretmanager
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+const azureIMDSTokenURL = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+type azureKeyVault struct {
+	spec *api.AzureKeyVaultSource
+	http *http.Client
+}
+
+// GetSecret gets a secret from Azure Key Vault using
+// the managed identity of the operator pod.
+func (a *azureKeyVault) GetSecret(ctx context.Context) (*Secret, error) {
+	token, err := a.token(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get access token")
+	}
+
+	u := strings.TrimSuffix(a.spec.VaultURL, "/") + "/secrets/" + url.PathEscape(a.spec.SecretName)
+	if a.spec.SecretVersion != "" {
+		u += "/" + url.PathEscape(a.spec.SecretVersion)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u+"?api-version=7.4", nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "new request")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	out := struct {
+		Value string `json:"value"`
+		ID    string `json:"id"`
+	}{}
+	if err := doJSON(a.http, req, &out); err != nil {
+		return nil, errors.Wrap(err, "get secret")
+	}
+
+	return parseSecretValue([]byte(out.Value), out.ID)
+}
+
+func (a *azureKeyVault) token(ctx context.Context) (string, error) {
+	q := url.Values{}
+	q.Set("api-version", "2018-02-01")
+	q.Set("resource", "https://vault.azure.net")
+	if a.spec.ClientID != "" {
+		q.Set("client_id", a.spec.ClientID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, azureIMDSTokenURL+"?"+q.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata", "true")
+
+	out := struct {
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := doJSON(a.http, req, &out); err != nil {
+		return "", err
+	}
+
+	return out.AccessToken, nil
+}
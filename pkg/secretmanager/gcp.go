@@ -0,0 +1,95 @@
+package secretmanager
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+const gcpMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+type gcpSecretManager struct {
+	spec *api.GCPSecretManagerSource
+	http *http.Client
+}
+
+// GetSecret accesses a secret version in GCP Secret Manager using
+// the service account of the operator pod (Workload Identity).
+func (g *gcpSecretManager) GetSecret(ctx context.Context) (*Secret, error) {
+	token, err := g.token(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "get access token")
+	}
+
+	version := g.spec.Version
+	if version == "" {
+		version = "latest"
+	}
+
+	url := fmt.Sprintf("https://secretmanager.googleapis.com/v1/projects/%s/secrets/%s/versions/%s:access",
+		g.spec.Project, g.spec.Secret, version)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "new request")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	out := struct {
+		Name    string `json:"name"`
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}{}
+	if err := doJSON(g.http, req, &out); err != nil {
+		return nil, errors.Wrap(err, "access secret version")
+	}
+
+	value, err := base64.StdEncoding.DecodeString(out.Payload.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "decode payload")
+	}
+
+	return parseSecretValue(value, out.Name)
+}
+
+func (g *gcpSecretManager) token(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpMetadataTokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	out := struct {
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := doJSON(g.http, req, &out); err != nil {
+		return "", err
+	}
+
+	return out.AccessToken, nil
+}
+
+func doJSON(cl *http.Client, req *http.Request, out any) error {
+	resp, err := cl.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "read response")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status %d: %s", resp.StatusCode, data)
+	}
+
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,120 @@
+package secretmanager
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	api "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+// Secret is a set of key-value pairs fetched from a cloud secret manager.
+type Secret struct {
+	Data map[string][]byte
+	// Version identifies the fetched secret version, it changes on every rotation.
+	Version string
+}
+
+type Provider interface {
+	GetSecret(ctx context.Context) (*Secret, error)
+}
+
+type NewProviderFunc func(spec *api.UsersSecretSourceSpec) (Provider, error)
+
+func NewProvider(spec *api.UsersSecretSourceSpec) (Provider, error) {
+	switch spec.Provider {
+	case api.SecretSourceAWS:
+		if spec.AWS == nil {
+			return nil, errors.New("aws section is not specified")
+		}
+		return &awsSecretsManager{spec: spec.AWS, http: newHTTPClient()}, nil
+	case api.SecretSourceGCP:
+		if spec.GCP == nil {
+			return nil, errors.New("gcp section is not specified")
+		}
+		return &gcpSecretManager{spec: spec.GCP, http: newHTTPClient()}, nil
+	case api.SecretSourceAzure:
+		if spec.Azure == nil {
+			return nil, errors.New("azure section is not specified")
+		}
+		return &azureKeyVault{spec: spec.Azure, http: newHTTPClient()}, nil
+	}
+	return nil, errors.Errorf("unknown secret source provider %q", spec.Provider)
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: 10 * time.Second}
+}
+
+// parseSecretValue parses a secret value stored as a JSON object
+// of user names and passwords.
+func parseSecretValue(value []byte, version string) (*Secret, error) {
+	kv := make(map[string]string)
+	if err := json.Unmarshal(value, &kv); err != nil {
+		return nil, errors.Wrap(err, "secret value must be a JSON object of strings")
+	}
+
+	s := &Secret{
+		Data:    make(map[string][]byte, len(kv)),
+		Version: version,
+	}
+	for k, v := range kv {
+		s.Data[k] = []byte(v)
+	}
+	if s.Version == "" {
+		s.Version = fmt.Sprintf("%x", sha256.Sum256(value))
+	}
+
+	return s, nil
+}
+
+// Cache keeps fetched secrets in memory to avoid calling
+// the secret manager on every reconcile.
+type Cache struct {
+	entries sync.Map
+}
+
+type cacheEntry struct {
+	secret    *Secret
+	fetchedAt time.Time
+}
+
+func NewCache() *Cache {
+	return new(Cache)
+}
+
+// Get returns the cached secret for the key if it's younger than ttl,
+// otherwise it fetches the secret using the provider.
+func (c *Cache) Get(ctx context.Context, key string, ttl time.Duration, p Provider) (*Secret, error) {
+	if c == nil {
+		return p.GetSecret(ctx)
+	}
+
+	if v, ok := c.entries.Load(key); ok {
+		e := v.(cacheEntry)
+		if time.Since(e.fetchedAt) < ttl {
+			return e.secret, nil
+		}
+	}
+
+	s, err := p.GetSecret(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.entries.Store(key, cacheEntry{secret: s, fetchedAt: time.Now()})
+
+	return s, nil
+}
+
+func (c *Cache) Delete(key string) {
+	if c == nil {
+		return
+	}
+	c.entries.Delete(key)
+}
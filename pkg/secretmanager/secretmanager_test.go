@@ -0,0 +1,113 @@
+package secretmanager
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseSecretValue(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		version     string
+		expected    map[string][]byte
+		expectedErr bool
+	}{
+		{
+			name:    "json object",
+			value:   `{"root":"root-pass","operator":"operator-pass"}`,
+			version: "v1",
+			expected: map[string][]byte{
+				"root":     []byte("root-pass"),
+				"operator": []byte("operator-pass"),
+			},
+		},
+		{
+			name:        "plain string",
+			value:       `root-pass`,
+			expectedErr: true,
+		},
+		{
+			name:        "nested object",
+			value:       `{"root":{"password":"root-pass"}}`,
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := parseSecretValue([]byte(tt.value), tt.version)
+			if tt.expectedErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(s.Data, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, s.Data)
+			}
+			if s.Version != tt.version {
+				t.Fatalf("expected version %s, got %s", tt.version, s.Version)
+			}
+		})
+	}
+
+	t.Run("version from content", func(t *testing.T) {
+		a, err := parseSecretValue([]byte(`{"root":"a"}`), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := parseSecretValue([]byte(`{"root":"b"}`), "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.Version == "" || a.Version == b.Version {
+			t.Fatalf("expected different non-empty versions, got %q and %q", a.Version, b.Version)
+		}
+	})
+}
+
+type countingProvider struct {
+	calls int
+}
+
+func (p *countingProvider) GetSecret(_ context.Context) (*Secret, error) {
+	p.calls++
+	return &Secret{Data: map[string][]byte{"root": []byte("pass")}, Version: "v1"}, nil
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+
+	p := new(countingProvider)
+	c := NewCache()
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Get(ctx, "ns/cluster1", time.Hour, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if p.calls != 1 {
+		t.Fatalf("expected 1 call, got %d", p.calls)
+	}
+
+	if _, err := c.Get(ctx, "ns/cluster1", 0, p); err != nil {
+		t.Fatal(err)
+	}
+	if p.calls != 2 {
+		t.Fatalf("expected 2 calls after ttl expired, got %d", p.calls)
+	}
+
+	var nilCache *Cache
+	if _, err := nilCache.Get(ctx, "ns/cluster1", time.Hour, p); err != nil {
+		t.Fatal(err)
+	}
+	if p.calls != 3 {
+		t.Fatalf("expected nil cache to call provider, got %d calls", p.calls)
+	}
+}
@@ -0,0 +1,98 @@
+package tracing
+
+import (
+	"context"
+	"reflect"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type tracedClient struct {
+	client.Client
+}
+
+// WrapClient returns the client which records every API request as a span,
+// so time spent waiting for the API server is visible in reconcile traces.
+func WrapClient(cl client.Client) client.Client {
+	return &tracedClient{Client: cl}
+}
+
+func (c *tracedClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	ctx, span := startRequest(ctx, "get", obj, key.Namespace, key.Name)
+	err := c.Client.Get(ctx, key, obj, opts...)
+	End(span, err)
+	return err
+}
+
+func (c *tracedClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	ctx, span := startRequest(ctx, "list", list, "", "")
+	err := c.Client.List(ctx, list, opts...)
+	End(span, err)
+	return err
+}
+
+func (c *tracedClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	ctx, span := startRequest(ctx, "create", obj, obj.GetNamespace(), obj.GetName())
+	err := c.Client.Create(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
+
+func (c *tracedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ctx, span := startRequest(ctx, "update", obj, obj.GetNamespace(), obj.GetName())
+	err := c.Client.Update(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
+
+func (c *tracedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, span := startRequest(ctx, "patch", obj, obj.GetNamespace(), obj.GetName())
+	err := c.Client.Patch(ctx, obj, patch, opts...)
+	End(span, err)
+	return err
+}
+
+func (c *tracedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	ctx, span := startRequest(ctx, "delete", obj, obj.GetNamespace(), obj.GetName())
+	err := c.Client.Delete(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
+
+func (c *tracedClient) Status() client.SubResourceWriter {
+	return &tracedStatusWriter{SubResourceWriter: c.Client.Status()}
+}
+
+type tracedStatusWriter struct {
+	client.SubResourceWriter
+}
+
+func (w *tracedStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	ctx, span := startRequest(ctx, "update status", obj, obj.GetNamespace(), obj.GetName())
+	err := w.SubResourceWriter.Update(ctx, obj, opts...)
+	End(span, err)
+	return err
+}
+
+func (w *tracedStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	ctx, span := startRequest(ctx, "patch status", obj, obj.GetNamespace(), obj.GetName())
+	err := w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+	End(span, err)
+	return err
+}
+
+func startRequest(ctx context.Context, verb string, obj any, ns, name string) (context.Context, trace.Span) {
+	kind := reflect.Indirect(reflect.ValueOf(obj)).Type().Name()
+
+	attrs := []attribute.KeyValue{attribute.String("k8s.kind", kind)}
+	if ns != "" {
+		attrs = append(attrs, attribute.String("k8s.namespace.name", ns))
+	}
+	if name != "" {
+		attrs = append(attrs, attribute.String("k8s.object.name", name))
+	}
+
+	return Start(ctx, "k8s "+verb+" "+kind, attrs...)
+}
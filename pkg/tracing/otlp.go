@@ -0,0 +1,173 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// otlpExporter sends spans using OTLP/HTTP with the JSON encoding,
+// which is supported by the OpenTelemetry Collector and most tracing backends.
+type otlpExporter struct {
+	endpoint string
+	headers  map[string]string
+	http     *http.Client
+}
+
+func newOTLPExporter(endpoint string, headers map[string]string) (*otlpExporter, error) {
+	if _, err := url.ParseRequestURI(endpoint); err != nil {
+		return nil, errors.Wrapf(err, "invalid endpoint %s", endpoint)
+	}
+
+	return &otlpExporter{
+		endpoint: endpoint,
+		headers:  headers,
+		http:     &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (e *otlpExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(otlpRequest(spans))
+	if err != nil {
+		return errors.Wrap(err, "marshal spans")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "new request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := e.http.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "send spans")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	return nil
+}
+
+func (e *otlpExporter) Shutdown(ctx context.Context) error {
+	e.http.CloseIdleConnections()
+	return nil
+}
+
+type jsonObject = map[string]any
+
+func otlpRequest(spans []sdktrace.ReadOnlySpan) jsonObject {
+	scopes := make(map[string][]jsonObject)
+	order := make([]string, 0)
+	for _, s := range spans {
+		name := s.InstrumentationScope().Name
+		if _, ok := scopes[name]; !ok {
+			order = append(order, name)
+		}
+		scopes[name] = append(scopes[name], otlpSpan(s))
+	}
+
+	scopeSpans := make([]jsonObject, 0, len(order))
+	for _, name := range order {
+		scopeSpans = append(scopeSpans, jsonObject{
+			"scope": jsonObject{"name": name},
+			"spans": scopes[name],
+		})
+	}
+
+	var resourceAttrs []attribute.KeyValue
+	if res := spans[0].Resource(); res != nil {
+		resourceAttrs = res.Attributes()
+	}
+
+	return jsonObject{
+		"resourceSpans": []jsonObject{{
+			"resource":   jsonObject{"attributes": otlpAttributes(resourceAttrs)},
+			"scopeSpans": scopeSpans,
+		}},
+	}
+}
+
+func otlpSpan(s sdktrace.ReadOnlySpan) jsonObject {
+	span := jsonObject{
+		"traceId":           s.SpanContext().TraceID().String(),
+		"spanId":            s.SpanContext().SpanID().String(),
+		"name":              s.Name(),
+		"kind":              int(s.SpanKind()),
+		"startTimeUnixNano": strconv.FormatInt(s.StartTime().UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(s.EndTime().UnixNano(), 10),
+		"attributes":        otlpAttributes(s.Attributes()),
+		"status":            otlpStatus(s.Status()),
+	}
+	if s.Parent().HasSpanID() {
+		span["parentSpanId"] = s.Parent().SpanID().String()
+	}
+
+	events := make([]jsonObject, 0, len(s.Events()))
+	for _, ev := range s.Events() {
+		events = append(events, jsonObject{
+			"name":         ev.Name,
+			"timeUnixNano": strconv.FormatInt(ev.Time.UnixNano(), 10),
+			"attributes":   otlpAttributes(ev.Attributes),
+		})
+	}
+	if len(events) > 0 {
+		span["events"] = events
+	}
+
+	return span
+}
+
+// otlpStatus maps the status code, OTLP uses 1 for OK and 2 for ERROR
+func otlpStatus(st sdktrace.Status) jsonObject {
+	code := 0
+	switch st.Code {
+	case codes.Ok:
+		code = 1
+	case codes.Error:
+		code = 2
+	}
+	return jsonObject{"code": code, "message": st.Description}
+}
+
+func otlpAttributes(attrs []attribute.KeyValue) []jsonObject {
+	out := make([]jsonObject, 0, len(attrs))
+	for _, kv := range attrs {
+		var v jsonObject
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			v = jsonObject{"boolValue": kv.Value.AsBool()}
+		case attribute.INT64:
+			v = jsonObject{"intValue": strconv.FormatInt(kv.Value.AsInt64(), 10)}
+		case attribute.FLOAT64:
+			v = jsonObject{"doubleValue": kv.Value.AsFloat64()}
+		case attribute.STRING:
+			v = jsonObject{"stringValue": kv.Value.AsString()}
+		default:
+			v = jsonObject{"stringValue": kv.Value.Emit()}
+		}
+		out = append(out, jsonObject{"key": string(kv.Key), "value": v})
+	}
+	return out
+}
@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	tracerName  = "github.com/percona/percona-xtradb-cluster-operator"
+	serviceName = "percona-xtradb-cluster-operator"
+)
+
+// Init sets up the global tracer provider exporting spans via OTLP/HTTP.
+// Tracing is disabled unless OTEL_EXPORTER_OTLP_TRACES_ENDPOINT or OTEL_EXPORTER_OTLP_ENDPOINT is set.
+// The returned function flushes and stops the exporter.
+func Init(version string) (func(context.Context) error, error) {
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if endpoint == "" {
+		base := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		if base == "" {
+			return func(context.Context) error { return nil }, nil
+		}
+		endpoint = strings.TrimSuffix(base, "/") + "/v1/traces"
+	}
+
+	exporter, err := newOTLPExporter(endpoint, parseHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")))
+	if err != nil {
+		return nil, errors.Wrap(err, "create otlp exporter")
+	}
+
+	name := os.Getenv("OTEL_SERVICE_NAME")
+	if name == "" {
+		name = serviceName
+	}
+
+	// the sampler is configured by OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(sdkresource.NewSchemaless(
+			attribute.String("service.name", name),
+			attribute.String("service.version", version),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+// Start starts a span which is a child of the span in ctx if there is one
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err in the span and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Phase runs f in a child span named after the reconcile phase
+func Phase(ctx context.Context, name string, f func(ctx context.Context) error) error {
+	ctx, span := Start(ctx, name)
+	err := f(ctx)
+	End(span, err)
+	return err
+}
+
+type instrumentedReconciler struct {
+	controller string
+	reconcile.Reconciler
+}
+
+// InstrumentReconciler wraps every reconcile of r into a root span
+func InstrumentReconciler(controller string, r reconcile.Reconciler) reconcile.Reconciler {
+	return &instrumentedReconciler{controller: controller, Reconciler: r}
+}
+
+func (r *instrumentedReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, span := Start(ctx, r.controller+" reconcile",
+		attribute.String("k8s.namespace.name", req.Namespace),
+		attribute.String("k8s.object.name", req.Name),
+	)
+	res, err := r.Reconciler.Reconcile(ctx, req)
+	End(span, err)
+
+	return res, err
+}
+
+func parseHeaders(s string) map[string]string {
+	headers := make(map[string]string)
+	for _, kv := range strings.Split(s, ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return headers
+}
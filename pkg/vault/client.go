@@ -0,0 +1,106 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Client is a minimal client for the HashiCorp Vault HTTP API
+// which covers the database secrets engine endpoints used by the operator.
+type Client struct {
+	addr  string
+	token string
+	http  *http.Client
+}
+
+func NewClient(addr, token string, tlsSkipVerify bool) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // nolint:gosec
+	}
+
+	return &Client{
+		addr:  strings.TrimSuffix(addr, "/"),
+		token: token,
+		http: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: transport,
+		},
+	}
+}
+
+// DatabaseConnection is a configuration of the mysql-database-plugin connection.
+type DatabaseConnection struct {
+	PluginName    string   `json:"plugin_name"`
+	ConnectionURL string   `json:"connection_url"`
+	Username      string   `json:"username"`
+	Password      string   `json:"password"`
+	AllowedRoles  []string `json:"allowed_roles"`
+}
+
+// DatabaseRole is a role which Vault uses to issue dynamic credentials.
+type DatabaseRole struct {
+	DBName               string   `json:"db_name"`
+	CreationStatements   []string `json:"creation_statements"`
+	RevocationStatements []string `json:"revocation_statements,omitempty"`
+	DefaultTTL           string   `json:"default_ttl,omitempty"`
+	MaxTTL               string   `json:"max_ttl,omitempty"`
+}
+
+func (c *Client) WriteDatabaseConnection(ctx context.Context, mount, name string, conn DatabaseConnection) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("%s/config/%s", mount, name), conn)
+}
+
+func (c *Client) DeleteDatabaseConnection(ctx context.Context, mount, name string) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("%s/config/%s", mount, name), nil)
+}
+
+func (c *Client) WriteDatabaseRole(ctx context.Context, mount, name string, role DatabaseRole) error {
+	return c.do(ctx, http.MethodPost, fmt.Sprintf("%s/roles/%s", mount, name), role)
+}
+
+func (c *Client) DeleteDatabaseRole(ctx context.Context, mount, name string) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("%s/roles/%s", mount, name), nil)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body any) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return errors.Wrap(err, "marshal request")
+		}
+		reqBody = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.addr+"/v1/"+strings.Trim(path, "/"), reqBody)
+	if err != nil {
+		return errors.Wrap(err, "new request")
+	}
+	req.Header.Set("X-Vault-Token", c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "%s %s", method, path)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return errors.Errorf("%s %s: unexpected status %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	admission "k8s.io/api/admission/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/webhook/json"
+)
+
+var backupHookPath = "/validate-percona-xtradbclusterbackup"
+
+// backupValidator rejects backups that can't be started for the cluster.
+type backupValidator struct {
+	cl  client.Reader
+	log logr.Logger
+}
+
+func (v *backupValidator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	req := &admission.AdmissionReview{}
+
+	bytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		v.log.Error(err, "can't read request body")
+		return
+	}
+
+	if err := json.Decode(bytes, req, true); err != nil || req.Request == nil {
+		v.log.Error(err, "Can't decode admission review request")
+		return
+	}
+
+	cr := &v1.PerconaXtraDBClusterBackup{}
+	if err := json.Decode(req.Request.Object.Raw, cr, true); err != nil {
+		if err := sendResponse(req.Request.UID, req.TypeMeta, w, err); err != nil {
+			v.log.Error(err, "Can't send validation response")
+		}
+		return
+	}
+
+	err = v.validate(r.Context(), cr)
+	if k8serrors.IsForbidden(err) {
+		// the operator doesn't watch the namespace
+		err = nil
+	}
+	if err := sendResponse(req.Request.UID, req.TypeMeta, w, err); err != nil {
+		v.log.Error(err, "Can't send validation response")
+	}
+}
+
+func (v *backupValidator) validate(ctx context.Context, cr *v1.PerconaXtraDBClusterBackup) error {
+	if cr.Spec.PXCCluster == "" {
+		return errors.New("pxcCluster can't be empty")
+	}
+	if cr.Spec.StorageName == "" {
+		return errors.New("storageName can't be empty")
+	}
+
+	cluster := new(v1.PerconaXtraDBCluster)
+	err := v.cl.Get(ctx, types.NamespacedName{Name: cr.Spec.PXCCluster, Namespace: cr.Namespace}, cluster)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return errors.Errorf("cluster %s doesn't exist", cr.Spec.PXCCluster)
+		}
+		return err
+	}
+
+	if cluster.Spec.Backup == nil {
+		return errors.Errorf("backups are not configured in cluster %s", cluster.Name)
+	}
+	if _, ok := cluster.Spec.Backup.Storages[cr.Spec.StorageName]; !ok {
+		return errors.Errorf("storage %s doesn't exist in cluster %s", cr.Spec.StorageName, cluster.Name)
+	}
+
+	return nil
+}
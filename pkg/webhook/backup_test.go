@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestBackupValidate(t *testing.T) {
+	ns := "test"
+
+	withBackup := &v1.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: ns},
+		Spec: v1.PerconaXtraDBClusterSpec{
+			Backup: &v1.PXCScheduledBackup{
+				Storages: map[string]*v1.BackupStorageSpec{"fs-pvc": {Type: v1.BackupStorageFilesystem}},
+			},
+		},
+	}
+	withoutBackup := &v1.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster2", Namespace: ns},
+	}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, new(v1.PerconaXtraDBCluster))
+	v := &backupValidator{cl: fake.NewClientBuilder().WithScheme(s).WithObjects(withBackup, withoutBackup).Build()}
+
+	tests := []struct {
+		name    string
+		spec    v1.PXCBackupSpec
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			spec: v1.PXCBackupSpec{PXCCluster: "cluster1", StorageName: "fs-pvc"},
+		},
+		{
+			name:    "unknown storage",
+			spec:    v1.PXCBackupSpec{PXCCluster: "cluster1", StorageName: "s3-us-west"},
+			wantErr: true,
+		},
+		{
+			name:    "empty storage",
+			spec:    v1.PXCBackupSpec{PXCCluster: "cluster1"},
+			wantErr: true,
+		},
+		{
+			name:    "backups disabled",
+			spec:    v1.PXCBackupSpec{PXCCluster: "cluster2", StorageName: "fs-pvc"},
+			wantErr: true,
+		},
+		{
+			name:    "cluster doesn't exist",
+			spec:    v1.PXCBackupSpec{PXCCluster: "cluster3", StorageName: "fs-pvc"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &v1.PerconaXtraDBClusterBackup{
+				ObjectMeta: metav1.ObjectMeta{Name: "backup1", Namespace: ns},
+				Spec:       tt.spec,
+			}
+			err := v.validate(context.Background(), cr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxctls"
+)
+
+const (
+	certSecretName = "pxc-webhook-ssl"
+
+	// annotationCertsManaged marks the certificates secret created by the operator.
+	// Only such secrets are rotated, secrets provided by users (e.g. by cert-manager) are just reloaded.
+	annotationCertsManaged = "percona.com/webhook-certs-managed"
+
+	certCheckPeriod = time.Hour
+	certRenewBefore = 30 * 24 * time.Hour
+)
+
+func webhookHosts(namespace string) []string {
+	return []string{"percona-xtradb-cluster-operator." + namespace + ".svc"}
+}
+
+// createCertSecret issues webhook certificates and stores them in the secret.
+// If the operator can't create secrets, the certificates are used without storing them.
+func createCertSecret(ctx context.Context, reader client.Reader, cl client.Client, namespace string) (*corev1.Secret, error) {
+	ca, crt, key, err := pxctls.Issue(webhookHosts(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        certSecretName,
+			Namespace:   namespace,
+			Annotations: map[string]string{annotationCertsManaged: "true"},
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			"ca.crt":  ca,
+			"tls.crt": crt,
+			"tls.key": key,
+		},
+	}
+
+	err = cl.Create(ctx, secret)
+	switch {
+	case err == nil, k8serrors.IsForbidden(err):
+		return secret, nil
+	case k8serrors.IsAlreadyExists(err):
+		// created by another operator replica
+		err = reader.Get(ctx, client.ObjectKeyFromObject(secret), secret)
+		return secret, errors.Wrap(err, "get secret")
+	default:
+		return nil, errors.Wrap(err, "create secret")
+	}
+}
+
+// rotateCertificates reissues the operator managed certificates before they expire
+// and reloads the certificates if the secret is changed.
+// New CA is added to the webhook configurations before the server starts using new certificates.
+func (h *hook) rotateCertificates(ctx context.Context) error {
+	secret := &corev1.Secret{}
+	err := h.cl.Get(ctx, types.NamespacedName{Name: certSecretName, Namespace: h.namespace}, secret)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	caBundle := secret.Data["ca.crt"]
+	if secret.Annotations[annotationCertsManaged] == "true" && certExpiresSoon(secret.Data["tls.crt"], certRenewBefore) {
+		ca, crt, key, err := pxctls.Issue(webhookHosts(h.namespace))
+		if err != nil {
+			return errors.Wrap(err, "issue tls certificates")
+		}
+
+		secret.Data = map[string][]byte{
+			"ca.crt":  ca,
+			"tls.crt": crt,
+			"tls.key": key,
+		}
+		if err := h.cl.Update(ctx, secret); err != nil {
+			return errors.Wrap(err, "update secret")
+		}
+		h.log.Info("webhook certificates are rotated")
+
+		// keep the old CA until the next check, so requests are accepted by all operator replicas
+		caBundle = append(append([]byte{}, ca...), caBundle...)
+	}
+
+	if !bytes.Equal(caBundle, h.caBundle) {
+		h.caBundle = caBundle
+		if err := h.setup(ctx); err != nil {
+			return errors.Wrap(err, "update CA bundle")
+		}
+	}
+
+	crt, err := os.ReadFile(certPath + "tls.crt")
+	if err == nil && bytes.Equal(crt, secret.Data["tls.crt"]) {
+		return nil
+	}
+
+	return writeCerts(secret.Data["tls.crt"], secret.Data["tls.key"])
+}
+
+// certExpiresSoon returns true if the first certificate in PEM data expires in less than d
+// or can't be parsed.
+func certExpiresSoon(data []byte, d time.Duration) bool {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return true
+	}
+	crt, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true
+	}
+	return time.Until(crt.NotAfter) < d
+}
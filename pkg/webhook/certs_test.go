@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxctls"
+)
+
+func TestCertExpiresSoon(t *testing.T) {
+	_, crt, _, err := pxctls.Issue(webhookHosts("test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if certExpiresSoon(crt, certRenewBefore) {
+		t.Error("new certificate expires soon")
+	}
+	if !certExpiresSoon(crt, pxctls.DefaultValidity+time.Hour) {
+		t.Error("certificate doesn't expire before its validity period")
+	}
+	if !certExpiresSoon([]byte("garbage"), certRenewBefore) {
+		t.Error("invalid certificate is accepted")
+	}
+}
+
+func TestCreateCertSecret(t *testing.T) {
+	ctx := context.Background()
+	cl := fake.NewClientBuilder().Build()
+
+	secret, err := createCertSecret(ctx, cl, cl, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret.Annotations[annotationCertsManaged] != "true" {
+		t.Error("secret isn't marked as managed by the operator")
+	}
+
+	// another replica gets the stored certificates
+	other, err := createCertSecret(ctx, cl, cl, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(other.Data["tls.crt"]) != string(secret.Data["tls.crt"]) {
+		t.Error("certificates are reissued for existing secret")
+	}
+}
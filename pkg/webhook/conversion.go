@@ -0,0 +1,147 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	v2 "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v2"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/webhook/json"
+)
+
+const clusterCRDName = "perconaxtradbclusters.pxc.percona.com"
+
+var conversionPath = "/convert"
+
+// converter handles ConversionReview requests for PerconaXtraDBCluster.
+// v2 is converted through the v1 hub, deprecated v1-x-0 versions
+// share the v1 schema and only get a new apiVersion.
+type converter struct {
+	log logr.Logger
+}
+
+func (c *converter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	review := &apiextensionsv1.ConversionReview{}
+
+	bytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		c.log.Error(err, "can't read request body")
+		return
+	}
+
+	if err := json.Decode(bytes, review, true); err != nil || review.Request == nil {
+		c.log.Error(err, "Can't decode conversion review request")
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	resp := &apiextensionsv1.ConversionResponse{
+		UID:    review.Request.UID,
+		Result: metav1.Status{Status: metav1.StatusSuccess},
+	}
+	for _, obj := range review.Request.Objects {
+		raw, err := convertCluster(obj.Raw, review.Request.DesiredAPIVersion)
+		if err != nil {
+			resp.ConvertedObjects = nil
+			resp.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			break
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: raw})
+	}
+
+	review.Request = nil
+	review.Response = resp
+	data, err := json.Marshal(review)
+	if err != nil {
+		c.log.Error(err, "Can't marshal conversion response")
+		return
+	}
+	w.Header().Add("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		c.log.Error(err, "Can't send conversion response")
+	}
+}
+
+func convertCluster(raw []byte, desiredVersion string) ([]byte, error) {
+	meta := metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, errors.Wrap(err, "decode type meta")
+	}
+	if meta.APIVersion == desiredVersion {
+		return raw, nil
+	}
+
+	v2Version := v2.SchemeGroupVersion.String()
+	if meta.APIVersion != v2Version && desiredVersion != v2Version {
+		obj := make(map[string]interface{})
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return nil, errors.Wrap(err, "decode object")
+		}
+		obj["apiVersion"] = desiredVersion
+		return json.Marshal(obj)
+	}
+
+	hub := &v1.PerconaXtraDBCluster{}
+	if meta.APIVersion == v2Version {
+		src := &v2.PerconaXtraDBCluster{}
+		if err := json.Unmarshal(raw, src); err != nil {
+			return nil, errors.Wrap(err, "decode v2 object")
+		}
+		if err := src.ConvertTo(hub); err != nil {
+			return nil, errors.Wrap(err, "convert to v1")
+		}
+	} else if err := json.Unmarshal(raw, hub); err != nil {
+		return nil, errors.Wrap(err, "decode v1 object")
+	}
+
+	if desiredVersion != v2Version {
+		hub.APIVersion = desiredVersion
+		return json.Marshal(hub)
+	}
+
+	dst := &v2.PerconaXtraDBCluster{}
+	if err := dst.ConvertFrom(hub); err != nil {
+		return nil, errors.Wrap(err, "convert from v1")
+	}
+	return json.Marshal(dst)
+}
+
+// setupConversion points the PerconaXtraDBCluster CRD conversion to the operator.
+// Operators without access to CRDs keep the None strategy.
+func (h *hook) setupConversion(ctx context.Context) error {
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterCRDName},
+	}
+	patch := client.MergeFrom(crd.DeepCopy())
+	crd.Spec.Conversion = &apiextensionsv1.CustomResourceConversion{
+		Strategy: apiextensionsv1.WebhookConverter,
+		Webhook: &apiextensionsv1.WebhookConversion{
+			ConversionReviewVersions: []string{"v1"},
+			ClientConfig: &apiextensionsv1.WebhookClientConfig{
+				Service: &apiextensionsv1.ServiceReference{
+					Namespace: h.namespace,
+					Name:      "percona-xtradb-cluster-operator",
+					Path:      &conversionPath,
+				},
+				CABundle: h.caBundle,
+			},
+		},
+	}
+
+	err := h.cl.Patch(ctx, crd, patch)
+	if k8serrors.IsForbidden(err) {
+		h.log.Info("no access to CRDs, conversion webhook is not configured")
+		return nil
+	}
+	return errors.Wrap(err, "patch crd")
+}
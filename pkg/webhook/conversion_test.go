@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/webhook/json"
+)
+
+func TestConvertCluster(t *testing.T) {
+	obj := []byte(`{"apiVersion":"pxc.percona.com/v1","kind":"PerconaXtraDBCluster","metadata":{"name":"cluster1"},"spec":{"crVersion":"1.17.0","pxc":{"size":3,"image":"pxc:8.0"}},"status":{"state":"ready"}}`)
+
+	tests := map[string]struct {
+		raw     []byte
+		desired string
+	}{
+		"v1 to v2":      {obj, "pxc.percona.com/v2"},
+		"v1 to v1-11-0": {obj, "pxc.percona.com/v1-11-0"},
+		"same version":  {obj, "pxc.percona.com/v1"},
+		"v2 to v1":      {[]byte(`{"apiVersion":"pxc.percona.com/v2","kind":"PerconaXtraDBCluster","metadata":{"name":"cluster1"},"spec":{"crVersion":"1.17.0","pxc":{"size":3,"image":"pxc:8.0"}},"status":{"state":"ready"}}`), "pxc.percona.com/v1"},
+		"v1-11-0 to v2": {[]byte(`{"apiVersion":"pxc.percona.com/v1-11-0","kind":"PerconaXtraDBCluster","metadata":{"name":"cluster1"},"spec":{"crVersion":"1.17.0","pxc":{"size":3,"image":"pxc:8.0"}},"status":{"state":"ready"}}`), "pxc.percona.com/v2"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			raw, err := convertCluster(tt.raw, tt.desired)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := struct {
+				APIVersion string `json:"apiVersion"`
+				Metadata   struct {
+					Name string `json:"name"`
+				} `json:"metadata"`
+				Spec struct {
+					PXC struct {
+						Size  int32  `json:"size"`
+						Image string `json:"image"`
+					} `json:"pxc"`
+				} `json:"spec"`
+				Status struct {
+					State string `json:"state"`
+				} `json:"status"`
+			}{}
+			if err := json.Unmarshal(raw, &got); err != nil {
+				t.Fatal(err)
+			}
+
+			if got.APIVersion != tt.desired {
+				t.Errorf("expected apiVersion %s, got %s", tt.desired, got.APIVersion)
+			}
+			if got.Metadata.Name != "cluster1" || got.Spec.PXC.Size != 3 || got.Spec.PXC.Image != "pxc:8.0" || got.Status.State != "ready" {
+				t.Errorf("object is not preserved: %s", raw)
+			}
+		})
+	}
+}
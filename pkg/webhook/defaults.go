@@ -0,0 +1,198 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	admission "k8s.io/api/admission/v1"
+	admissionregistration "k8s.io/api/admissionregistration/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	v1 "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/webhook/json"
+	"github.com/percona/percona-xtradb-cluster-operator/version"
+)
+
+var mutatePath = "/mutate-percona-xtradbcluster"
+
+// defaulter applies CheckNSetDefaults to the cluster spec at admission,
+// so the stored object matches what the operator reconciles.
+type defaulter struct {
+	serverVersion *version.ServerVersion
+	log           logr.Logger
+}
+
+func (d *defaulter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	req := &admission.AdmissionReview{}
+
+	bytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		d.log.Error(err, "can't read request body")
+		return
+	}
+
+	if err := json.Decode(bytes, req, true); err != nil || req.Request == nil {
+		d.log.Error(err, "Can't decode admission review request")
+		return
+	}
+
+	resp := &admission.AdmissionReview{
+		TypeMeta: req.TypeMeta,
+		Response: &admission.AdmissionResponse{
+			UID:     req.Request.UID,
+			Allowed: true,
+		},
+	}
+
+	patch, err := d.defaultsPatch(req.Request.Object.Raw)
+	if err != nil {
+		// the validation webhook or the operator reports invalid clusters
+		d.log.Info("can't apply defaults", "cluster", req.Request.Name, "namespace", req.Request.Namespace, "err", err.Error())
+	} else if len(patch) > 0 {
+		patchType := admission.PatchTypeJSONPatch
+		resp.Response.Patch = patch
+		resp.Response.PatchType = &patchType
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		d.log.Error(err, "Can't marshal admission response")
+		return
+	}
+	w.Header().Add("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		d.log.Error(err, "Can't send admission response")
+	}
+}
+
+// defaultsPatch returns JSON patch that adds defaults to the spec.
+// Fields unknown to the operator are kept as is.
+func (d *defaulter) defaultsPatch(raw []byte) ([]byte, error) {
+	cr := &v1.PerconaXtraDBCluster{}
+	if err := json.Decode(raw, cr, true); err != nil {
+		return nil, errors.Wrap(err, "decode cluster")
+	}
+
+	if cr.Spec.EnableCRDefaultingWebhook == nil || !*cr.Spec.EnableCRDefaultingWebhook {
+		return nil, nil
+	}
+
+	if cr.Spec.CRVersion == "" {
+		cr.Spec.CRVersion = version.Version
+	}
+	if err := cr.CheckNSetDefaults(d.serverVersion, d.log); err != nil {
+		return nil, errors.Wrap(err, "set defaults")
+	}
+
+	spec, err := json.Marshal(cr.Spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal spec")
+	}
+	defaults := make(map[string]interface{})
+	if err := json.Unmarshal(spec, &defaults); err != nil {
+		return nil, errors.Wrap(err, "unmarshal spec")
+	}
+
+	obj := make(map[string]interface{})
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, errors.Wrap(err, "unmarshal object")
+	}
+	orig, _ := obj["spec"].(map[string]interface{})
+	if orig == nil {
+		orig = make(map[string]interface{})
+	}
+	obj["spec"] = mergeDefaults(orig, defaults)
+
+	modified, err := json.Marshal(obj)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal object")
+	}
+
+	ops, err := jsonpatch.CreatePatch(raw, modified)
+	if err != nil {
+		return nil, errors.Wrap(err, "create patch")
+	}
+	if len(ops) == 0 {
+		return nil, nil
+	}
+
+	return json.Marshal(ops)
+}
+
+// mergeDefaults sets values of src into dst recursively, keys missing in src are kept.
+func mergeDefaults(dst, src map[string]interface{}) map[string]interface{} {
+	for k, v := range src {
+		sm, ok := v.(map[string]interface{})
+		dm, dok := dst[k].(map[string]interface{})
+		if ok && dok {
+			dst[k] = mergeDefaults(dm, sm)
+			continue
+		}
+		dst[k] = v
+	}
+	return dst
+}
+
+func (h *hook) createMutatingWebhook(ownerRef metav1.OwnerReference) error {
+	failPolicy := admissionregistration.Ignore
+	sideEffects := admissionregistration.SideEffectClassNone
+	hook := &admissionregistration.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "percona-xtradbcluster-mutating-webhook",
+			OwnerReferences: []metav1.OwnerReference{ownerRef},
+		},
+		Webhooks: []admissionregistration.MutatingWebhook{
+			{
+				AdmissionReviewVersions: []string{"v1"},
+				Name:                    "mutatingwebhook.pxc.percona.com",
+				ClientConfig: admissionregistration.WebhookClientConfig{
+					Service: &admissionregistration.ServiceReference{
+						Namespace: h.namespace,
+						Name:      "percona-xtradb-cluster-operator",
+						Path:      &mutatePath,
+					},
+					CABundle: h.caBundle,
+				},
+				SideEffects:   &sideEffects,
+				FailurePolicy: &failPolicy,
+				Rules: []admissionregistration.RuleWithOperations{
+					{
+						Rule: admissionregistration.Rule{
+							APIGroups:   []string{"pxc.percona.com"},
+							APIVersions: []string{"v1"},
+							Resources:   []string{"perconaxtradbclusters"},
+						},
+						Operations: []admissionregistration.OperationType{"CREATE", "UPDATE"},
+					},
+				},
+			},
+		},
+	}
+
+	err := h.cl.Create(context.TODO(), hook)
+	if k8serrors.IsForbidden(err) {
+		return nil
+	}
+
+	if err != nil && k8serrors.IsAlreadyExists(err) {
+		hook := &admissionregistration.MutatingWebhookConfiguration{}
+		err := h.cl.Get(context.TODO(), types.NamespacedName{
+			Name: "percona-xtradbcluster-mutating-webhook",
+		}, hook)
+		if err != nil {
+			return err
+		}
+
+		hook.Webhooks[0].ClientConfig.CABundle = h.caBundle
+		hook.ObjectMeta.OwnerReferences = []metav1.OwnerReference{ownerRef}
+		return h.cl.Update(context.TODO(), hook)
+	}
+	return err
+}
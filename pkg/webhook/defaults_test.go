@@ -0,0 +1,50 @@
+package webhook
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeDefaults(t *testing.T) {
+	dst := map[string]interface{}{
+		"unknownField": "kept",
+		"pxc": map[string]interface{}{
+			"size":  float64(3),
+			"image": "pxc:8.0",
+		},
+	}
+	src := map[string]interface{}{
+		"secretsName": "cluster1-secrets",
+		"pxc": map[string]interface{}{
+			"size":            float64(3),
+			"image":           "pxc:8.0",
+			"imagePullPolicy": "Always",
+		},
+	}
+
+	expected := map[string]interface{}{
+		"unknownField": "kept",
+		"secretsName":  "cluster1-secrets",
+		"pxc": map[string]interface{}{
+			"size":            float64(3),
+			"image":           "pxc:8.0",
+			"imagePullPolicy": "Always",
+		},
+	}
+
+	if got := mergeDefaults(dst, src); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestDefaultsPatchDisabled(t *testing.T) {
+	d := new(defaulter)
+
+	patch, err := d.defaultsPatch([]byte(`{"apiVersion":"pxc.percona.com/v1","kind":"PerconaXtraDBCluster","metadata":{"name":"cluster1"},"spec":{"pxc":{"size":3}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patch != nil {
+		t.Errorf("expected no patch if the webhook is disabled, got %s", patch)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
@@ -14,6 +15,8 @@ import (
 	admissionregistration "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -25,8 +28,8 @@ import (
 
 	v1 "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/k8s"
-	"github.com/percona/percona-xtradb-cluster-operator/pkg/pxctls"
 	"github.com/percona/percona-xtradb-cluster-operator/pkg/webhook/json"
+	"github.com/percona/percona-xtradb-cluster-operator/version"
 )
 
 const certPath = "/tmp/k8s-webhook-server/serving-certs/"
@@ -46,8 +49,19 @@ func (h *hook) Start(ctx context.Context) error {
 	if err != nil {
 		h.log.Info("failed to setup webhook", "err", err.Error())
 	}
-	<-ctx.Done()
-	return nil
+
+	ticker := time.NewTicker(certCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := h.rotateCertificates(ctx); err != nil {
+				h.log.Info("failed to rotate webhook certificates", "err", err.Error())
+			}
+		}
+	}
 }
 
 func (h *hook) setup(ctx context.Context) error {
@@ -70,6 +84,16 @@ func (h *hook) setup(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "can't create webhook")
 	}
+
+	err = h.createMutatingWebhook(ref)
+	if err != nil {
+		return errors.Wrap(err, "can't create mutating webhook")
+	}
+
+	err = h.setupConversion(ctx)
+	if err != nil {
+		return errors.Wrap(err, "can't setup conversion webhook")
+	}
 	return nil
 }
 
@@ -138,12 +162,60 @@ func (h *hook) createWebhook(ownerRef metav1.OwnerReference) error {
 						Rule: admissionregistration.Rule{
 							APIGroups:   []string{"pxc.percona.com"},
 							APIVersions: []string{"*"},
-							Resources:   []string{"perconaxtradbclusters/*"},
+							Resources:   []string{"perconaxtradbclusters", "perconaxtradbclusters/*"},
 						},
 						Operations: []admissionregistration.OperationType{"CREATE", "UPDATE"},
 					},
 				},
 			},
+			{
+				AdmissionReviewVersions: []string{"v1"},
+				Name:                    "restorevalidationwebhook.pxc.percona.com",
+				ClientConfig: admissionregistration.WebhookClientConfig{
+					Service: &admissionregistration.ServiceReference{
+						Namespace: h.namespace,
+						Name:      "percona-xtradb-cluster-operator",
+						Path:      &restoreHookPath,
+					},
+					CABundle: h.caBundle,
+				},
+				SideEffects:   &sideEffects,
+				FailurePolicy: &failPolicy,
+				Rules: []admissionregistration.RuleWithOperations{
+					{
+						Rule: admissionregistration.Rule{
+							APIGroups:   []string{"pxc.percona.com"},
+							APIVersions: []string{"*"},
+							Resources:   []string{"perconaxtradbclusterrestores"},
+						},
+						Operations: []admissionregistration.OperationType{"CREATE"},
+					},
+				},
+			},
+			{
+				AdmissionReviewVersions: []string{"v1"},
+				Name:                    "backupvalidationwebhook.pxc.percona.com",
+				ClientConfig: admissionregistration.WebhookClientConfig{
+					Service: &admissionregistration.ServiceReference{
+						Namespace: h.namespace,
+						Name:      "percona-xtradb-cluster-operator",
+						Path:      &backupHookPath,
+					},
+					CABundle: h.caBundle,
+				},
+				SideEffects:   &sideEffects,
+				FailurePolicy: &failPolicy,
+				Rules: []admissionregistration.RuleWithOperations{
+					{
+						Rule: admissionregistration.Rule{
+							APIGroups:   []string{"pxc.percona.com"},
+							APIVersions: []string{"*"},
+							Resources:   []string{"perconaxtradbclusterbackups"},
+						},
+						Operations: []admissionregistration.OperationType{"CREATE"},
+					},
+				},
+			},
 		},
 	}
 
@@ -153,17 +225,17 @@ func (h *hook) createWebhook(ownerRef metav1.OwnerReference) error {
 	}
 
 	if err != nil && k8serrors.IsAlreadyExists(err) {
-		hook := &admissionregistration.ValidatingWebhookConfiguration{}
+		existing := &admissionregistration.ValidatingWebhookConfiguration{}
 		err := h.cl.Get(context.TODO(), types.NamespacedName{
 			Name: "percona-xtradbcluster-webhook",
-		}, hook)
+		}, existing)
 		if err != nil {
 			return err
 		}
 
-		hook.Webhooks[0].ClientConfig.CABundle = h.caBundle
-		hook.ObjectMeta.OwnerReferences = []metav1.OwnerReference{ownerRef}
-		return h.cl.Update(context.TODO(), hook)
+		existing.Webhooks = hook.Webhooks
+		existing.ObjectMeta.OwnerReferences = []metav1.OwnerReference{ownerRef}
+		return h.cl.Update(context.TODO(), existing)
 	}
 	return err
 }
@@ -176,16 +248,26 @@ func SetupWebhook(mgr manager.Manager) error {
 		return errors.Wrap(err, "add admissionregistration to scheme")
 	}
 
+	err = apiextensionsv1.AddToScheme(mgr.GetScheme())
+	if err != nil {
+		return errors.Wrap(err, "add apiextensions to scheme")
+	}
+
 	namespace, err := k8s.GetOperatorNamespace()
 	if err != nil {
 		return errors.Wrap(err, "get operator namespace")
 	}
 
-	ca, err := setupCertificates(mgr.GetAPIReader(), namespace)
+	ca, err := setupCertificates(context.TODO(), mgr.GetAPIReader(), mgr.GetClient(), namespace)
 	if err != nil {
 		return errors.Wrap(err, "prepare hook tls certs")
 	}
 
+	sv, err := version.Server()
+	if err != nil {
+		return errors.Wrap(err, "get server version")
+	}
+
 	zapLog, err := zap.NewProduction()
 	if err != nil {
 		return errors.Wrap(err, "create logger")
@@ -200,6 +282,10 @@ func SetupWebhook(mgr manager.Manager) error {
 	}
 
 	mgr.GetWebhookServer().Register(hookPath, h)
+	mgr.GetWebhookServer().Register(conversionPath, &converter{log: h.log})
+	mgr.GetWebhookServer().Register(mutatePath, &defaulter{serverVersion: sv, log: h.log})
+	mgr.GetWebhookServer().Register(restoreHookPath, &restoreValidator{cl: mgr.GetAPIReader(), log: h.log})
+	mgr.GetWebhookServer().Register(backupHookPath, &backupValidator{cl: mgr.GetAPIReader(), log: h.log})
 
 	err = mgr.Add(h)
 	if err != nil {
@@ -209,28 +295,24 @@ func SetupWebhook(mgr manager.Manager) error {
 	return nil
 }
 
-func setupCertificates(cl client.Reader, namespace string) ([]byte, error) {
+func setupCertificates(ctx context.Context, reader client.Reader, cl client.Client, namespace string) ([]byte, error) {
 	certSecret := &corev1.Secret{}
-	err := cl.Get(context.TODO(), types.NamespacedName{
+	err := reader.Get(ctx, types.NamespacedName{
 		Namespace: namespace,
-		Name:      "pxc-webhook-ssl",
+		Name:      certSecretName,
 	}, certSecret)
 	if err != nil && !k8serrors.IsNotFound(err) {
 		return nil, err
 	}
 
-	var ca, crt, key []byte
-
 	if k8serrors.IsNotFound(err) {
-		ca, crt, key, err = pxctls.Issue([]string{"percona-xtradb-cluster-operator." + namespace + ".svc"})
+		certSecret, err = createCertSecret(ctx, reader, cl, namespace)
 		if err != nil {
 			return nil, errors.Wrap(err, "issue tls certificates")
 		}
-	} else {
-		ca, crt, key = certSecret.Data["ca.crt"], certSecret.Data["tls.crt"], certSecret.Data["tls.key"]
 	}
 
-	return ca, writeCerts(crt, key)
+	return certSecret.Data["ca.crt"], writeCerts(certSecret.Data["tls.crt"], certSecret.Data["tls.key"])
 }
 
 func writeCerts(crt, key []byte) error {
@@ -276,26 +358,57 @@ func (h *hook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if cr.Spec.EnableCRValidationWebhook == nil || !*cr.Spec.EnableCRValidationWebhook {
-		err = sendResponse(req.Request.UID, req.TypeMeta, w, nil)
+	warnings := cr.AdmissionWarnings()
+
+	// Status and other subresources are written by the operator, the spec isn't changed there.
+	if req.Request.SubResource != "" || cr.Spec.EnableCRValidationWebhook == nil || !*cr.Spec.EnableCRValidationWebhook {
+		err = sendResponse(req.Request.UID, req.TypeMeta, w, nil, warnings...)
 		if err != nil {
 			h.log.Error(err, "Can't send validation response")
 		}
 		return
 	}
 
-	err = sendResponse(req.Request.UID, req.TypeMeta, w, cr.Validate())
+	var old *v1.PerconaXtraDBCluster
+	if req.Request.Operation == admission.Update && len(req.Request.OldObject.Raw) > 0 {
+		old = &v1.PerconaXtraDBCluster{}
+		if err := json.Decode(req.Request.OldObject.Raw, old, true); err != nil {
+			h.log.Error(err, "Can't decode old object")
+			old = nil
+		} else if err := cr.ValidateUpdate(old); err != nil {
+			err = sendResponse(req.Request.UID, req.TypeMeta, w, err)
+			if err != nil {
+				h.log.Error(err, "Can't send validation response")
+			}
+			return
+		}
+	}
+
+	// Invalid schedules which are already stored are skipped by the operator,
+	// so they are checked only if spec.backup is changed.
+	if old == nil || !equality.Semantic.DeepEqual(cr.Spec.Backup, old.Spec.Backup) {
+		if err := cr.ValidateBackupSchedules(); err != nil {
+			err = sendResponse(req.Request.UID, req.TypeMeta, w, err)
+			if err != nil {
+				h.log.Error(err, "Can't send validation response")
+			}
+			return
+		}
+	}
+
+	err = sendResponse(req.Request.UID, req.TypeMeta, w, cr.Validate(), warnings...)
 	if err != nil {
 		h.log.Error(err, "Can't send validation response")
 	}
 }
 
-func sendResponse(uid types.UID, meta metav1.TypeMeta, w http.ResponseWriter, err error) error {
+func sendResponse(uid types.UID, meta metav1.TypeMeta, w http.ResponseWriter, err error, warnings ...string) error {
 	resp := &admission.AdmissionReview{
 		TypeMeta: meta,
 		Response: &admission.AdmissionResponse{
-			UID:     uid,
-			Allowed: true,
+			UID:      uid,
+			Allowed:  true,
+			Warnings: warnings,
 		},
 	}
 	if err != nil {
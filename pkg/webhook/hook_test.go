@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-logr/logr"
+	admission "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	v1 "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestHookValidateUpdate(t *testing.T) {
+	tests := map[string]struct {
+		old, cr     *v1.PerconaXtraDBCluster
+		subResource string
+		allowed     bool
+	}{
+		"validation disabled": {
+			old:     newHookCluster("6Gi", false, ""),
+			cr:      newHookCluster("2Gi", false, ""),
+			allowed: true,
+		},
+		"status subresource": {
+			old:         newHookCluster("6Gi", true, ""),
+			cr:          newHookCluster("2Gi", true, ""),
+			subResource: "status",
+			allowed:     true,
+		},
+		"shrunk volume": {
+			old:     newHookCluster("6Gi", true, ""),
+			cr:      newHookCluster("2Gi", true, ""),
+			allowed: false,
+		},
+		"invalid schedule is added": {
+			old:     newHookCluster("6Gi", true, ""),
+			cr:      newHookCluster("6Gi", true, "every day"),
+			allowed: false,
+		},
+		"invalid schedule isn't changed": {
+			old:     newHookCluster("6Gi", true, "every day"),
+			cr:      newHookCluster("8Gi", true, "every day"),
+			allowed: true,
+		},
+		"invalid schedule with validation disabled": {
+			old:     newHookCluster("6Gi", false, ""),
+			cr:      newHookCluster("6Gi", false, "every day"),
+			allowed: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			review := &admission.AdmissionReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: "admission.k8s.io/v1", Kind: "AdmissionReview"},
+				Request: &admission.AdmissionRequest{
+					UID:         "1",
+					Kind:        metav1.GroupVersionKind{Group: "pxc.percona.com", Version: "v1", Kind: "PerconaXtraDBCluster"},
+					Operation:   admission.Update,
+					SubResource: tt.subResource,
+					Object:      runtime.RawExtension{Raw: mustMarshal(t, tt.cr)},
+					OldObject:   runtime.RawExtension{Raw: mustMarshal(t, tt.old)},
+				},
+			}
+
+			rec := httptest.NewRecorder()
+			h := &hook{log: logr.Discard()}
+			h.ServeHTTP(rec, httptest.NewRequest("POST", hookPath, bytes.NewReader(mustMarshal(t, review))))
+
+			resp := new(admission.AdmissionReview)
+			if err := json.Unmarshal(rec.Body.Bytes(), resp); err != nil {
+				t.Fatal(err)
+			}
+			if resp.Response.Allowed != tt.allowed {
+				t.Errorf("expected allowed %t, got %t: %v", tt.allowed, resp.Response.Allowed, resp.Response.Result)
+			}
+		})
+	}
+}
+
+func newHookCluster(size string, validation bool, schedule string) *v1.PerconaXtraDBCluster {
+	cr := &v1.PerconaXtraDBCluster{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "pxc.percona.com/v1", Kind: "PerconaXtraDBCluster"},
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: "test"},
+		Spec: v1.PerconaXtraDBClusterSpec{
+			EnableCRValidationWebhook: &validation,
+			PXC: &v1.PXCSpec{
+				PodSpec: &v1.PodSpec{
+					Image: "percona/percona-xtradb-cluster:8.0",
+					VolumeSpec: &v1.VolumeSpec{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimSpec{
+							Resources: corev1.VolumeResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+							},
+						},
+					},
+				},
+			},
+		},
+		Status: v1.PerconaXtraDBClusterStatus{Status: v1.AppStateReady},
+	}
+	if schedule != "" {
+		cr.Spec.Backup = &v1.PXCScheduledBackup{
+			Image:    "percona/percona-xtradb-cluster-operator:backup",
+			Schedule: []v1.PXCScheduledBackupSchedule{{Name: "daily", Schedule: schedule, StorageName: "s3"}},
+			Storages: map[string]*v1.BackupStorageSpec{"s3": {Type: v1.BackupStorageS3}},
+		}
+	}
+	return cr
+}
+
+func mustMarshal(t *testing.T, obj any) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
@@ -0,0 +1,161 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	admission "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	v1 "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+	"github.com/percona/percona-xtradb-cluster-operator/pkg/webhook/json"
+)
+
+var restoreHookPath = "/validate-percona-xtradbclusterrestore"
+
+// pitrDateFormat is the format of spec.pitr.date expected by the PITR recoverer.
+const pitrDateFormat = "2006-01-02 15:04:05"
+
+// restoreValidator rejects restores that would fail right after they are started.
+type restoreValidator struct {
+	cl  client.Reader
+	log logr.Logger
+}
+
+func (v *restoreValidator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	req := &admission.AdmissionReview{}
+
+	bytes, err := io.ReadAll(r.Body)
+	if err != nil {
+		v.log.Error(err, "can't read request body")
+		return
+	}
+
+	if err := json.Decode(bytes, req, true); err != nil || req.Request == nil {
+		v.log.Error(err, "Can't decode admission review request")
+		return
+	}
+
+	cr := &v1.PerconaXtraDBClusterRestore{}
+	if err := json.Decode(req.Request.Object.Raw, cr, true); err != nil {
+		if err := sendResponse(req.Request.UID, req.TypeMeta, w, err); err != nil {
+			v.log.Error(err, "Can't send validation response")
+		}
+		return
+	}
+
+	err = v.validate(r.Context(), cr)
+	if k8serrors.IsForbidden(err) {
+		// the operator doesn't watch the namespace
+		err = nil
+	}
+	if err := sendResponse(req.Request.UID, req.TypeMeta, w, err); err != nil {
+		v.log.Error(err, "Can't send validation response")
+	}
+}
+
+func (v *restoreValidator) validate(ctx context.Context, cr *v1.PerconaXtraDBClusterRestore) error {
+	if err := cr.CheckNsetDefaults(); err != nil {
+		return err
+	}
+
+	cluster := new(v1.PerconaXtraDBCluster)
+	err := v.cl.Get(ctx, types.NamespacedName{Name: cr.Spec.PXCCluster, Namespace: cr.Namespace}, cluster)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return errors.Errorf("cluster %s doesn't exist", cr.Spec.PXCCluster)
+		}
+		return err
+	}
+
+	var backupTime *time.Time
+	if cr.Spec.BackupName != "" {
+		bcp := new(v1.PerconaXtraDBClusterBackup)
+		err := v.cl.Get(ctx, types.NamespacedName{Name: cr.Spec.BackupName, Namespace: cr.Namespace}, bcp)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return errors.Errorf("backup %s doesn't exist", cr.Spec.BackupName)
+			}
+			return err
+		}
+		if bcp.Status.State != v1.BackupSucceeded {
+			return errors.Errorf("backup %s isn't succeeded, current state: %s", bcp.Name, bcp.Status.State)
+		}
+		if bcp.Status.CompletedAt != nil {
+			backupTime = &bcp.Status.CompletedAt.Time
+		}
+	} else {
+		if err := v.validateBackupSource(ctx, cr.Namespace, cluster, cr.Spec.BackupSource); err != nil {
+			return errors.Wrap(err, "backupSource")
+		}
+		if cr.Spec.BackupSource.CompletedAt != nil {
+			backupTime = &cr.Spec.BackupSource.CompletedAt.Time
+		}
+	}
+
+	if cr.Spec.PITR == nil {
+		return nil
+	}
+
+	if cr.Spec.PITR.BackupSource != nil {
+		if err := v.validateBackupSource(ctx, cr.Namespace, cluster, cr.Spec.PITR.BackupSource); err != nil {
+			return errors.Wrap(err, "pitr.backupSource")
+		}
+	}
+
+	switch cr.Spec.PITR.Type {
+	case "date":
+		date, err := time.Parse(pitrDateFormat, cr.Spec.PITR.Date)
+		if err != nil {
+			return errors.Errorf("pitr.date %q should be in format %q", cr.Spec.PITR.Date, pitrDateFormat)
+		}
+		if backupTime != nil && !date.After(*backupTime) {
+			return errors.Errorf("pitr.date %s is before the backup completion time %s", cr.Spec.PITR.Date, backupTime.UTC().Format(pitrDateFormat))
+		}
+	case "transaction", "skip":
+		if cr.Spec.PITR.GTID == "" {
+			return errors.Errorf("pitr.gtid is required for pitr.type %s", cr.Spec.PITR.Type)
+		}
+	case "latest":
+	default:
+		return errors.Errorf("unknown pitr.type %q, expected one of: date, transaction, skip, latest", cr.Spec.PITR.Type)
+	}
+
+	return nil
+}
+
+func (v *restoreValidator) validateBackupSource(ctx context.Context, namespace string, cluster *v1.PerconaXtraDBCluster, source *v1.PXCBackupStatus) error {
+	var secret string
+	switch {
+	case source.S3 != nil:
+		// empty credentials secret allows authentication with IAM roles
+		secret = source.S3.CredentialsSecret
+	case source.Azure != nil:
+		if source.Azure.CredentialsSecret == "" {
+			return errors.New("azure.credentialsSecret is required")
+		}
+		secret = source.Azure.CredentialsSecret
+	case source.StorageName != "":
+		if cluster.Spec.Backup == nil || cluster.Spec.Backup.Storages[source.StorageName] == nil {
+			return errors.Errorf("storage %s isn't defined in cluster %s", source.StorageName, cluster.Name)
+		}
+	}
+
+	if secret == "" {
+		return nil
+	}
+
+	err := v.cl.Get(ctx, types.NamespacedName{Name: secret, Namespace: namespace}, new(corev1.Secret))
+	if k8serrors.IsNotFound(err) {
+		return errors.Errorf("credentials secret %s doesn't exist", secret)
+	}
+	return err
+}
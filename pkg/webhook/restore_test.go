@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	v1 "github.com/percona/percona-xtradb-cluster-operator/pkg/apis/pxc/v1"
+)
+
+func TestRestoreValidate(t *testing.T) {
+	ns := "test"
+	completed := metav1.NewTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	cluster := &v1.PerconaXtraDBCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Namespace: ns},
+		Spec: v1.PerconaXtraDBClusterSpec{
+			Backup: &v1.PXCScheduledBackup{
+				Storages: map[string]*v1.BackupStorageSpec{"s3-us-west": {Type: v1.BackupStorageS3}},
+			},
+		},
+	}
+	backup := func(name string, state v1.PXCBackupState) *v1.PerconaXtraDBClusterBackup {
+		return &v1.PerconaXtraDBClusterBackup{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+			Status: v1.PXCBackupStatus{
+				State:       state,
+				CompletedAt: &completed,
+			},
+		}
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "s3-creds", Namespace: ns}}
+
+	s := scheme.Scheme
+	s.AddKnownTypes(v1.SchemeGroupVersion, new(v1.PerconaXtraDBCluster), new(v1.PerconaXtraDBClusterBackup))
+	objs := []runtime.Object{cluster, backup("backup1", v1.BackupSucceeded), backup("running", v1.BackupRunning), secret}
+	v := &restoreValidator{cl: fake.NewClientBuilder().WithScheme(s).WithRuntimeObjects(objs...).Build()}
+
+	tests := []struct {
+		name    string
+		spec    v1.PerconaXtraDBClusterRestoreSpec
+		wantErr bool
+	}{
+		{
+			name: "valid backup",
+			spec: v1.PerconaXtraDBClusterRestoreSpec{PXCCluster: "cluster1", BackupName: "backup1"},
+		},
+		{
+			name:    "cluster doesn't exist",
+			spec:    v1.PerconaXtraDBClusterRestoreSpec{PXCCluster: "cluster2", BackupName: "backup1"},
+			wantErr: true,
+		},
+		{
+			name:    "backup doesn't exist",
+			spec:    v1.PerconaXtraDBClusterRestoreSpec{PXCCluster: "cluster1", BackupName: "backup2"},
+			wantErr: true,
+		},
+		{
+			name:    "backup isn't succeeded",
+			spec:    v1.PerconaXtraDBClusterRestoreSpec{PXCCluster: "cluster1", BackupName: "running"},
+			wantErr: true,
+		},
+		{
+			name: "valid pitr date",
+			spec: v1.PerconaXtraDBClusterRestoreSpec{
+				PXCCluster: "cluster1",
+				BackupName: "backup1",
+				PITR:       &v1.PITR{Type: "date", Date: "2024-05-01 13:00:00"},
+			},
+		},
+		{
+			name: "pitr date before backup",
+			spec: v1.PerconaXtraDBClusterRestoreSpec{
+				PXCCluster: "cluster1",
+				BackupName: "backup1",
+				PITR:       &v1.PITR{Type: "date", Date: "2024-05-01 11:00:00"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "malformed pitr date",
+			spec: v1.PerconaXtraDBClusterRestoreSpec{
+				PXCCluster: "cluster1",
+				BackupName: "backup1",
+				PITR:       &v1.PITR{Type: "date", Date: "2024-05-01T13:00:00Z"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "backup source with credentials",
+			spec: v1.PerconaXtraDBClusterRestoreSpec{
+				PXCCluster:   "cluster1",
+				BackupSource: &v1.PXCBackupStatus{S3: &v1.BackupStorageS3Spec{Bucket: "b", CredentialsSecret: "s3-creds"}},
+			},
+		},
+		{
+			name: "backup source without credentials secret",
+			spec: v1.PerconaXtraDBClusterRestoreSpec{
+				PXCCluster:   "cluster1",
+				BackupSource: &v1.PXCBackupStatus{S3: &v1.BackupStorageS3Spec{Bucket: "b", CredentialsSecret: "missing"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "backup source with unknown storage",
+			spec: v1.PerconaXtraDBClusterRestoreSpec{
+				PXCCluster:   "cluster1",
+				BackupSource: &v1.PXCBackupStatus{StorageName: "gcs"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &v1.PerconaXtraDBClusterRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: "restore1", Namespace: ns},
+				Spec:       tt.spec,
+			}
+			err := v.validate(context.Background(), cr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}